@@ -0,0 +1,408 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func (s *Service) listGroups(c echo.Context) error {
+	ctx := c.Request().Context()
+	startIndex, count, err := pagination(c)
+	if err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidValue", err.Error())
+	}
+	attribute, value, err := parseFilter(c.QueryParam("filter"))
+	if err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidFilter", err.Error())
+	}
+
+	find := &store.FindSCIMGroupMessage{}
+	switch attribute {
+	case "":
+	case "displayname":
+		find.DisplayName = &value
+	case "members", "members.value":
+		memberID, err := strconv.Atoi(value)
+		if err != nil {
+			return writeList(c, 0, startIndex, nil)
+		}
+		find.MemberID = &memberID
+	default:
+		return writeError(c, http.StatusBadRequest, "invalidFilter", fmt.Sprintf("unsupported filter attribute %q", attribute))
+	}
+	groups, err := s.store.ListSCIMGroups(ctx, find)
+	if err != nil {
+		return writeError(c, http.StatusInternalServerError, "", fmt.Sprintf("Failed to list groups: %v", err))
+	}
+
+	// Okta and Azure AD exclude the members with excludedAttributes=members when listing groups.
+	excludeMembers := c.QueryParam("excludedAttributes") == "members"
+	var resources []any
+	for i := startIndex - 1; i < len(groups) && len(resources) < count; i++ {
+		group := convertToGroup(groups[i])
+		if excludeMembers {
+			group.Members = nil
+		}
+		resources = append(resources, group)
+	}
+	return writeList(c, len(groups), startIndex, resources)
+}
+
+func (s *Service) getGroup(c echo.Context) error {
+	group, err := s.getSCIMGroup(c)
+	if err != nil || group == nil {
+		return err
+	}
+	return writeJSON(c, http.StatusOK, convertToGroup(group))
+}
+
+func (s *Service) createGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+	var request Group
+	if err := bindJSON(c, &request); err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidSyntax", err.Error())
+	}
+	if request.DisplayName == "" {
+		return writeError(c, http.StatusBadRequest, "invalidValue", "displayName is required")
+	}
+	existing, err := s.store.GetSCIMGroup(ctx, &store.FindSCIMGroupMessage{DisplayName: &request.DisplayName})
+	if err != nil {
+		return writeError(c, http.StatusInternalServerError, "", fmt.Sprintf("Failed to get group: %v", err))
+	}
+	if existing != nil {
+		return writeError(c, http.StatusConflict, "uniqueness", fmt.Sprintf("Group %q already exists", request.DisplayName))
+	}
+	members := map[string]bool{}
+	for _, member := range request.Members {
+		members[member.Value] = true
+	}
+	memberIDs, err := s.convertToMemberIDs(ctx, members)
+	if err != nil {
+		return writeRequestError(c, err)
+	}
+	if err := s.validateGroupMapping(ctx, request.DisplayName); err != nil {
+		return writeRequestError(c, err)
+	}
+
+	create := &store.SCIMGroupMessage{
+		DisplayName: request.DisplayName,
+		ExternalID:  request.ExternalID,
+		MemberIDs:   memberIDs,
+	}
+	policies, err := s.getGroupProjectPolicies(ctx, nil, create)
+	if err != nil {
+		return writeRequestError(c, err)
+	}
+	group, err := s.store.CreateSCIMGroup(ctx, create, policies)
+	if err != nil {
+		return writeError(c, http.StatusInternalServerError, "", fmt.Sprintf("Failed to create group: %v", err))
+	}
+	return writeJSON(c, http.StatusCreated, convertToGroup(group))
+}
+
+func (s *Service) replaceGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+	group, err := s.getSCIMGroup(c)
+	if err != nil || group == nil {
+		return err
+	}
+	var replace Group
+	if err := bindJSON(c, &replace); err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidSyntax", err.Error())
+	}
+	members := map[string]bool{}
+	for _, member := range replace.Members {
+		members[member.Value] = true
+	}
+	updated, err := s.updateGroup(ctx, group, &replace, members)
+	if err != nil {
+		return writeRequestError(c, err)
+	}
+	return writeJSON(c, http.StatusOK, convertToGroup(updated))
+}
+
+func (s *Service) patchGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+	group, err := s.getSCIMGroup(c)
+	if err != nil || group == nil {
+		return err
+	}
+	var patch PatchRequest
+	if err := bindJSON(c, &patch); err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidSyntax", err.Error())
+	}
+	update := convertToGroup(group)
+	members := map[string]bool{}
+	for _, member := range update.Members {
+		members[member.Value] = true
+	}
+	if err := applyGroupPatch(update, members, patch.Operations); err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidValue", err.Error())
+	}
+	updated, err := s.updateGroup(ctx, group, update, members)
+	if err != nil {
+		return writeRequestError(c, err)
+	}
+	return writeJSON(c, http.StatusOK, convertToGroup(updated))
+}
+
+func (s *Service) deleteGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+	group, err := s.getSCIMGroup(c)
+	if err != nil || group == nil {
+		return err
+	}
+	policies, err := s.getGroupProjectPolicies(ctx, group, nil)
+	if err != nil {
+		return writeRequestError(c, err)
+	}
+	if err := s.store.DeleteSCIMGroup(ctx, group.UID, policies); err != nil {
+		return writeError(c, http.StatusInternalServerError, "", fmt.Sprintf("Failed to delete group: %v", err))
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// getSCIMGroup gets the group by the ID in the path. It writes the error response and returns nil if the group is not found.
+func (s *Service) getSCIMGroup(c echo.Context) (*store.SCIMGroupMessage, error) {
+	id, ok := parseID(c)
+	if !ok {
+		return nil, writeError(c, http.StatusNotFound, "", fmt.Sprintf("Group %q not found", c.Param("id")))
+	}
+	group, err := s.store.GetSCIMGroup(c.Request().Context(), &store.FindSCIMGroupMessage{UID: &id})
+	if err != nil {
+		return nil, writeError(c, http.StatusInternalServerError, "", fmt.Sprintf("Failed to get group: %v", err))
+	}
+	if group == nil {
+		return nil, writeError(c, http.StatusNotFound, "", fmt.Sprintf("Group %d not found", id))
+	}
+	return group, nil
+}
+
+func (s *Service) updateGroup(ctx context.Context, group *store.SCIMGroupMessage, update *Group, members map[string]bool) (*store.SCIMGroupMessage, error) {
+	if update.DisplayName == "" {
+		return nil, &requestError{code: http.StatusBadRequest, scimType: "invalidValue", err: errors.New("displayName is required")}
+	}
+	memberIDs, err := s.convertToMemberIDs(ctx, members)
+	if err != nil {
+		return nil, err
+	}
+	patch := &store.UpdateSCIMGroupMessage{UID: group.UID, MemberIDs: &memberIDs}
+	if update.DisplayName != group.DisplayName {
+		existing, err := s.store.GetSCIMGroup(ctx, &store.FindSCIMGroupMessage{DisplayName: &update.DisplayName})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get group")
+		}
+		if existing != nil {
+			return nil, &requestError{code: http.StatusConflict, scimType: "uniqueness", err: errors.Errorf("group %q already exists", update.DisplayName)}
+		}
+		if err := s.validateGroupMapping(ctx, update.DisplayName); err != nil {
+			return nil, err
+		}
+		patch.DisplayName = &update.DisplayName
+	}
+	if update.ExternalID != group.ExternalID {
+		patch.ExternalID = &update.ExternalID
+	}
+	// The group and the project IAM policies are updated in one transaction.
+	policies, err := s.getGroupProjectPolicies(ctx, group, &store.SCIMGroupMessage{DisplayName: update.DisplayName, MemberIDs: memberIDs})
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.store.UpdateSCIMGroup(ctx, patch, policies)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update group %d", group.UID)
+	}
+	return updated, nil
+}
+
+// convertToMemberIDs converts the SCIM member values to the principal IDs. The members must be end users.
+func (s *Service) convertToMemberIDs(ctx context.Context, members map[string]bool) ([]int, error) {
+	var memberIDs []int
+	for value := range members {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, &requestError{code: http.StatusBadRequest, scimType: "invalidValue", err: errors.Errorf("invalid member %q", value)}
+		}
+		user, err := s.store.GetUserByID(ctx, id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get user %d", id)
+		}
+		if user == nil || user.Type != api.EndUser {
+			return nil, &requestError{code: http.StatusBadRequest, scimType: "invalidValue", err: errors.Errorf("member %q not found", value)}
+		}
+		memberIDs = append(memberIDs, id)
+	}
+	return memberIDs, nil
+}
+
+// validateGroupMapping checks the project and the role of the group if the group is mapped to the project IAM policy.
+func (s *Service) validateGroupMapping(ctx context.Context, displayName string) error {
+	projectID, role, ok := parseGroupProjectRole(displayName)
+	if !ok {
+		return nil
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{ResourceID: &projectID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get project %q", projectID)
+	}
+	if project == nil || project.Deleted {
+		return &requestError{code: http.StatusBadRequest, scimType: "invalidValue", err: errors.Errorf("project %q not found", projectID)}
+	}
+	roleMessage, err := s.store.GetRole(ctx, string(role))
+	if err != nil {
+		return errors.Wrapf(err, "failed to get role %q", role)
+	}
+	if roleMessage == nil {
+		return &requestError{code: http.StatusBadRequest, scimType: "invalidValue", err: errors.Errorf("role %q not found", role)}
+	}
+	return nil
+}
+
+// getGroupProjectPolicies returns the project IAM policies for the change of the group from oldGroup to newGroup,
+// either of which may be nil. The members removed from the group lose the role granted by the group,
+// even if the role was granted before the group is provisioned, because the identity provider is the source of truth.
+// The policies are set by the store in the same transaction as the change of the group.
+func (s *Service) getGroupProjectPolicies(ctx context.Context, oldGroup, newGroup *store.SCIMGroupMessage) ([]*store.SCIMProjectPolicyMessage, error) {
+	var oldProject, newProject string
+	var oldRole, newRole api.Role
+	var oldMapped, newMapped bool
+	var oldMembers, newMembers []int
+	if oldGroup != nil {
+		oldProject, oldRole, oldMapped = parseGroupProjectRole(oldGroup.DisplayName)
+		oldMembers = oldGroup.MemberIDs
+	}
+	if newGroup != nil {
+		newProject, newRole, newMapped = parseGroupProjectRole(newGroup.DisplayName)
+		newMembers = newGroup.MemberIDs
+	}
+
+	var policies []*store.SCIMProjectPolicyMessage
+	if oldMapped && newMapped && oldProject == newProject && oldRole == newRole {
+		removed, added := diffMembers(oldMembers, newMembers)
+		if err := s.updateProjectRoleMembers(ctx, &policies, newProject, newRole, removed, added); err != nil {
+			return nil, err
+		}
+		return policies, nil
+	}
+	if oldMapped {
+		if err := s.updateProjectRoleMembers(ctx, &policies, oldProject, oldRole, oldMembers, nil); err != nil {
+			return nil, err
+		}
+	}
+	if newMapped {
+		if err := s.updateProjectRoleMembers(ctx, &policies, newProject, newRole, nil, newMembers); err != nil {
+			return nil, err
+		}
+	}
+	return policies, nil
+}
+
+// updateProjectRoleMembers removes and adds the members of the unconditioned binding of the role in the project.
+// The new policy of the project is added to policies, or replaces the one updated before for the same project.
+func (s *Service) updateProjectRoleMembers(ctx context.Context, policies *[]*store.SCIMProjectPolicyMessage, projectID string, role api.Role, removed, added []int) error {
+	if len(removed) == 0 && len(added) == 0 {
+		return nil
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{ResourceID: &projectID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get project %q", projectID)
+	}
+	if project == nil || project.Deleted {
+		// The project is deleted after the group is provisioned, there is nothing to sync.
+		return nil
+	}
+	var projectPolicy *store.SCIMProjectPolicyMessage
+	for _, p := range *policies {
+		if p.ProjectUID == project.UID {
+			projectPolicy = p
+		}
+	}
+	if projectPolicy == nil {
+		policy, err := s.store.GetProjectPolicy(ctx, &store.GetProjectPolicyMessage{UID: &project.UID})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get IAM policy of project %q", projectID)
+		}
+		projectPolicy = &store.SCIMProjectPolicyMessage{
+			ProjectUID:        project.UID,
+			ProjectResourceID: project.ResourceID,
+			Policy:            policy,
+		}
+		*policies = append(*policies, projectPolicy)
+	}
+
+	removedSet := map[int]bool{}
+	for _, id := range removed {
+		removedSet[id] = true
+	}
+	// The policy is cached by the store, so we build a new one instead of modifying it.
+	newPolicy := &store.IAMPolicyMessage{}
+	var binding *store.PolicyBinding
+	for _, b := range projectPolicy.Policy.Bindings {
+		if b.Role != role || b.Condition.GetExpression() != "" {
+			newPolicy.Bindings = append(newPolicy.Bindings, b)
+			continue
+		}
+		binding = &store.PolicyBinding{Role: b.Role, Condition: b.Condition}
+		for _, member := range b.Members {
+			if !removedSet[member.ID] {
+				binding.Members = append(binding.Members, member)
+			}
+		}
+	}
+	if binding == nil {
+		binding = &store.PolicyBinding{Role: role}
+	}
+	existing := map[int]bool{}
+	for _, member := range binding.Members {
+		existing[member.ID] = true
+	}
+	for _, id := range added {
+		if existing[id] {
+			continue
+		}
+		user, err := s.store.GetUserByID(ctx, id)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get user %d", id)
+		}
+		if user == nil {
+			continue
+		}
+		binding.Members = append(binding.Members, user)
+		existing[id] = true
+	}
+	if role == api.Owner && len(binding.Members) == 0 {
+		return &requestError{code: http.StatusBadRequest, scimType: "mutability", err: errors.Errorf("project %q must have at least one owner", projectID)}
+	}
+	if len(binding.Members) > 0 {
+		newPolicy.Bindings = append(newPolicy.Bindings, binding)
+	}
+	projectPolicy.Policy = newPolicy
+	return nil
+}
+
+func convertToGroup(group *store.SCIMGroupMessage) *Group {
+	g := &Group{
+		Schemas:     []string{groupSchema},
+		ID:          strconv.Itoa(group.UID),
+		ExternalID:  group.ExternalID,
+		DisplayName: group.DisplayName,
+		Members:     []MemberRef{},
+		Meta: &Meta{
+			ResourceType: "Group",
+			Created:      formatTime(group.CreatedTs),
+			LastModified: formatTime(group.UpdatedTs),
+		},
+	}
+	for _, memberID := range group.MemberIDs {
+		g.Members = append(g.Members, MemberRef{Value: strconv.Itoa(memberID)})
+	}
+	return g
+}
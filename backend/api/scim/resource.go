@@ -0,0 +1,412 @@
+package scim
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+const (
+	userSchema         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	groupSchema        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	listResponseSchema = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	errorSchema        = "urn:ietf:params:scim:api:messages:2.0:Error"
+	spConfigSchema     = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+// User is the SCIM user resource, see https://datatracker.ietf.org/doc/html/rfc7643#section-4.1.
+// The user ID is the principal ID, and the user name is the email.
+type User struct {
+	Schemas      []string      `json:"schemas"`
+	ID           string        `json:"id,omitempty"`
+	ExternalID   string        `json:"externalId,omitempty"`
+	UserName     string        `json:"userName"`
+	Name         *Name         `json:"name,omitempty"`
+	DisplayName  string        `json:"displayName,omitempty"`
+	Emails       []MultiValued `json:"emails,omitempty"`
+	PhoneNumbers []MultiValued `json:"phoneNumbers,omitempty"`
+	Active       *bool         `json:"active,omitempty"`
+	Groups       []MemberRef   `json:"groups,omitempty"`
+	Meta         *Meta         `json:"meta,omitempty"`
+}
+
+// Name is the name of the SCIM user.
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// MultiValued is the multi-valued attribute such as emails and phone numbers.
+type MultiValued struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Group is the SCIM group resource, see https://datatracker.ietf.org/doc/html/rfc7643#section-4.2.
+type Group struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []MemberRef `json:"members"`
+	Meta        *Meta       `json:"meta,omitempty"`
+}
+
+// MemberRef is the reference to a group member or a group of the user.
+type MemberRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// Meta is the resource metadata.
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// ListResponse is the response of listing resources.
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// PatchRequest is the request of patching a resource, see https://datatracker.ietf.org/doc/html/rfc7644#section-3.5.2.
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is an operation of the patch request.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Error is the SCIM error response, see https://datatracker.ietf.org/doc/html/rfc7644#section-3.12.
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// primaryEmail returns the email of the user, which is the user name if it's an email,
+// otherwise the primary email.
+func (u *User) primaryEmail() string {
+	if strings.Contains(u.UserName, "@") {
+		return strings.ToLower(u.UserName)
+	}
+	for _, email := range u.Emails {
+		if email.Primary {
+			return strings.ToLower(email.Value)
+		}
+	}
+	if len(u.Emails) > 0 {
+		return strings.ToLower(u.Emails[0].Value)
+	}
+	return strings.ToLower(u.UserName)
+}
+
+// displayName returns the display name of the user, falling back to the name and the user name.
+func (u *User) displayName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name != nil {
+		if u.Name.Formatted != "" {
+			return u.Name.Formatted
+		}
+		if name := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); name != "" {
+			return name
+		}
+	}
+	return u.UserName
+}
+
+// phone returns the primary phone number of the user.
+func (u *User) phone() string {
+	for _, phone := range u.PhoneNumbers {
+		if phone.Primary {
+			return phone.Value
+		}
+	}
+	if len(u.PhoneNumbers) > 0 {
+		return u.PhoneNumbers[0].Value
+	}
+	return ""
+}
+
+var filterRegexp = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// parseFilter parses the SCIM filter. Only the equality filter on a single attribute is supported,
+// e.g. `userName eq "alice@example.com"`, which is what the identity providers use to look up resources.
+// The attribute is returned in lower case as SCIM attribute names are case-insensitive.
+func parseFilter(filter string) (string, string, error) {
+	if filter == "" {
+		return "", "", nil
+	}
+	matches := filterRegexp.FindStringSubmatch(filter)
+	if matches == nil {
+		return "", "", errors.Errorf("unsupported filter %q, only the eq operator is supported", filter)
+	}
+	value, err := strconv.Unquote(`"` + matches[2] + `"`)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "invalid filter value in %q", filter)
+	}
+	return strings.ToLower(matches[1]), value, nil
+}
+
+// parseBool parses the boolean value, which some identity providers send as a string like "False".
+func parseBool(raw json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return false, errors.Errorf("invalid boolean value %s", string(raw))
+	}
+	return strconv.ParseBool(strings.ToLower(s))
+}
+
+func parseString(raw json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", errors.Errorf("invalid string value %s", string(raw))
+	}
+	return s, nil
+}
+
+// applyUserPatch applies the add and replace operations to the user.
+// Operations on the attributes that Bytebase does not keep are ignored.
+func applyUserPatch(user *User, operations []PatchOperation) error {
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		if op != "add" && op != "replace" {
+			// Removing the user attributes is not meaningful for Bytebase principals.
+			continue
+		}
+		if operation.Path == "" {
+			// The value is an object of the attributes to replace.
+			var values map[string]json.RawMessage
+			if err := json.Unmarshal(operation.Value, &values); err != nil {
+				return errors.Errorf("invalid patch value %s", string(operation.Value))
+			}
+			for path, value := range values {
+				if err := setUserAttribute(user, path, value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := setUserAttribute(user, operation.Path, operation.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setUserAttribute(user *User, path string, value json.RawMessage) error {
+	var err error
+	switch strings.ToLower(path) {
+	case "active":
+		var active bool
+		active, err = parseBool(value)
+		user.Active = &active
+	case "username":
+		user.UserName, err = parseString(value)
+	case "displayname":
+		user.DisplayName, err = parseString(value)
+	case "name":
+		var name Name
+		if err := json.Unmarshal(value, &name); err != nil {
+			return errors.Errorf("invalid name %s", string(value))
+		}
+		clearDerivedDisplayName(user)
+		user.Name = &name
+	case "name.formatted", "name.givenname", "name.familyname":
+		clearDerivedDisplayName(user)
+		if user.Name == nil {
+			user.Name = &Name{}
+		}
+		var s string
+		s, err = parseString(value)
+		switch strings.ToLower(path) {
+		case "name.formatted":
+			user.Name.Formatted = s
+		case "name.givenname":
+			// The formatted name is derived from the given and family names again.
+			user.Name.Formatted = ""
+			user.Name.GivenName = s
+		case "name.familyname":
+			user.Name.Formatted = ""
+			user.Name.FamilyName = s
+		}
+	case "emails":
+		err = json.Unmarshal(value, &user.Emails)
+	case `emails[type eq "work"].value`, `emails[primary eq true].value`:
+		var s string
+		s, err = parseString(value)
+		user.Emails = []MultiValued{{Value: s, Primary: true}}
+	case "phonenumbers":
+		err = json.Unmarshal(value, &user.PhoneNumbers)
+	case `phonenumbers[type eq "work"].value`, `phonenumbers[type eq "mobile"].value`, `phonenumbers[primary eq true].value`:
+		var s string
+		s, err = parseString(value)
+		user.PhoneNumbers = []MultiValued{{Value: s, Primary: true}}
+	default:
+		// Ignore the attributes we do not keep, e.g. externalId, title and the enterprise extension.
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "invalid value of %q", path)
+	}
+	return nil
+}
+
+// clearDerivedDisplayName clears the display name if it's derived from the name, so that it follows the patched name.
+func clearDerivedDisplayName(user *User) {
+	if user.Name != nil && user.DisplayName == user.Name.Formatted {
+		user.DisplayName = ""
+	}
+}
+
+var memberFilterRegexp = regexp.MustCompile(`^(?i:members)\[\s*(?i:value)\s+(?i:eq)\s+"([^"]*)"\s*\]$`)
+
+// applyGroupPatch applies the operations to the group display name and the member set.
+func applyGroupPatch(group *Group, members map[string]bool, operations []PatchOperation) error {
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		path := strings.ToLower(operation.Path)
+		switch {
+		case path == "" && (op == "add" || op == "replace"):
+			var values map[string]json.RawMessage
+			if err := json.Unmarshal(operation.Value, &values); err != nil {
+				return errors.Errorf("invalid patch value %s", string(operation.Value))
+			}
+			for key, value := range values {
+				if err := setGroupAttribute(group, members, op, strings.ToLower(key), value); err != nil {
+					return err
+				}
+			}
+		case op == "add" || op == "replace":
+			if err := setGroupAttribute(group, members, op, path, operation.Value); err != nil {
+				return err
+			}
+		case op == "remove" && path == "members":
+			if len(operation.Value) == 0 {
+				for k := range members {
+					delete(members, k)
+				}
+				continue
+			}
+			refs, err := parseMemberRefs(operation.Value)
+			if err != nil {
+				return err
+			}
+			for _, ref := range refs {
+				delete(members, ref.Value)
+			}
+		case op == "remove" && memberFilterRegexp.MatchString(operation.Path):
+			delete(members, memberFilterRegexp.FindStringSubmatch(operation.Path)[1])
+		default:
+			return errors.Errorf("unsupported patch operation %q on %q", operation.Op, operation.Path)
+		}
+	}
+	return nil
+}
+
+func setGroupAttribute(group *Group, members map[string]bool, op, path string, value json.RawMessage) error {
+	switch path {
+	case "displayname":
+		displayName, err := parseString(value)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value of %q", path)
+		}
+		group.DisplayName = displayName
+	case "externalid":
+		externalID, err := parseString(value)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value of %q", path)
+		}
+		group.ExternalID = externalID
+	case "members":
+		refs, err := parseMemberRefs(value)
+		if err != nil {
+			return err
+		}
+		if op == "replace" {
+			for k := range members {
+				delete(members, k)
+			}
+		}
+		for _, ref := range refs {
+			members[ref.Value] = true
+		}
+	case "id":
+		// Some identity providers send the id along with the replaced attributes.
+	default:
+		return errors.Errorf("unsupported group attribute %q", path)
+	}
+	return nil
+}
+
+func parseMemberRefs(value json.RawMessage) ([]MemberRef, error) {
+	var refs []MemberRef
+	if err := json.Unmarshal(value, &refs); err != nil {
+		var ref MemberRef
+		if err := json.Unmarshal(value, &ref); err != nil {
+			return nil, errors.Errorf("invalid members %s", string(value))
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+var groupRoleRegexp = regexp.MustCompile(`^projects/([^/]+)/roles/([^/]+)$`)
+
+// parseGroupProjectRole returns the project and the role granted to the group members if the group display name
+// is in the format of "projects/{project}/roles/{role}", e.g. "projects/hr/roles/DEVELOPER".
+func parseGroupProjectRole(displayName string) (string, api.Role, bool) {
+	matches := groupRoleRegexp.FindStringSubmatch(displayName)
+	if matches == nil {
+		return "", "", false
+	}
+	return matches[1], api.Role(matches[2]), true
+}
+
+// diffMembers returns the members only in the old set and the members only in the new set.
+func diffMembers(oldMembers, newMembers []int) ([]int, []int) {
+	oldSet, newSet := map[int]bool{}, map[int]bool{}
+	for _, m := range oldMembers {
+		oldSet[m] = true
+	}
+	for _, m := range newMembers {
+		newSet[m] = true
+	}
+	var removed, added []int
+	for _, m := range oldMembers {
+		if !newSet[m] {
+			removed = append(removed, m)
+		}
+	}
+	for _, m := range newMembers {
+		if !oldSet[m] {
+			added = append(added, m)
+		}
+	}
+	return removed, added
+}
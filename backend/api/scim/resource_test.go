@@ -0,0 +1,248 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		filter    string
+		attribute string
+		value     string
+		wantErr   bool
+	}{
+		{
+			filter: "",
+		},
+		{
+			filter:    `userName eq "alice@example.com"`,
+			attribute: "username",
+			value:     "alice@example.com",
+		},
+		{
+			filter:    `displayName EQ "projects/hr/roles/DEVELOPER"`,
+			attribute: "displayname",
+			value:     "projects/hr/roles/DEVELOPER",
+		},
+		{
+			filter:    `displayName eq "a \"quoted\" name"`,
+			attribute: "displayname",
+			value:     `a "quoted" name`,
+		},
+		{
+			filter:  `userName co "alice"`,
+			wantErr: true,
+		},
+		{
+			filter:  `userName eq "alice" and active eq true`,
+			wantErr: true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		attribute, value, err := parseFilter(test.filter)
+		if test.wantErr {
+			a.Error(err, test.filter)
+			continue
+		}
+		a.NoError(err, test.filter)
+		a.Equal(test.attribute, attribute, test.filter)
+		a.Equal(test.value, value, test.filter)
+	}
+}
+
+func TestApplyUserPatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations string
+		want       *User
+		wantErr    bool
+	}{
+		{
+			name:       "deactivate with path",
+			operations: `[{"op": "replace", "path": "active", "value": false}]`,
+			want:       newTestUser("alice@example.com", "Alice", false),
+		},
+		{
+			name:       "deactivate with string boolean",
+			operations: `[{"op": "Replace", "path": "active", "value": "False"}]`,
+			want:       newTestUser("alice@example.com", "Alice", false),
+		},
+		{
+			name:       "replace with value object",
+			operations: `[{"op": "replace", "value": {"active": true, "displayName": "Alice Liddell", "userName": "alice@example.org"}}]`,
+			want: func() *User {
+				u := newTestUser("alice@example.org", "Alice", true)
+				u.DisplayName = "Alice Liddell"
+				return u
+			}(),
+		},
+		{
+			name:       "name follows the given name",
+			operations: `[{"op": "add", "path": "name.givenName", "value": "Alicia"}]`,
+			want: func() *User {
+				u := newTestUser("alice@example.com", "", true)
+				u.Name = &Name{GivenName: "Alicia"}
+				return u
+			}(),
+		},
+		{
+			name:       "ignore unknown attributes and remove",
+			operations: `[{"op": "add", "path": "title", "value": "Engineer"}, {"op": "remove", "path": "displayName"}]`,
+			want:       newTestUser("alice@example.com", "Alice", true),
+		},
+		{
+			name:       "invalid boolean",
+			operations: `[{"op": "replace", "path": "active", "value": "nope"}]`,
+			wantErr:    true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		var operations []PatchOperation
+		a.NoError(json.Unmarshal([]byte(test.operations), &operations), test.name)
+		user := newTestUser("alice@example.com", "Alice", true)
+		err := applyUserPatch(user, operations)
+		if test.wantErr {
+			a.Error(err, test.name)
+			continue
+		}
+		a.NoError(err, test.name)
+		a.Equal(test.want, user, test.name)
+	}
+}
+
+func TestApplyGroupPatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		operations  string
+		displayName string
+		members     map[string]bool
+		wantErr     bool
+	}{
+		{
+			name:        "add members",
+			operations:  `[{"op": "add", "path": "members", "value": [{"value": "103"}, {"value": "104"}]}]`,
+			displayName: "projects/hr/roles/DEVELOPER",
+			members:     map[string]bool{"101": true, "102": true, "103": true, "104": true},
+		},
+		{
+			name:        "remove member with filter",
+			operations:  `[{"op": "remove", "path": "members[value eq \"101\"]"}]`,
+			displayName: "projects/hr/roles/DEVELOPER",
+			members:     map[string]bool{"102": true},
+		},
+		{
+			name:        "remove members with value",
+			operations:  `[{"op": "remove", "path": "members", "value": [{"value": "102"}]}]`,
+			displayName: "projects/hr/roles/DEVELOPER",
+			members:     map[string]bool{"101": true},
+		},
+		{
+			name:        "replace members",
+			operations:  `[{"op": "replace", "path": "members", "value": [{"value": "105"}]}]`,
+			displayName: "projects/hr/roles/DEVELOPER",
+			members:     map[string]bool{"105": true},
+		},
+		{
+			name:        "replace display name with value object",
+			operations:  `[{"op": "replace", "value": {"id": "101", "displayName": "projects/hr/roles/OWNER"}}]`,
+			displayName: "projects/hr/roles/OWNER",
+			members:     map[string]bool{"101": true, "102": true},
+		},
+		{
+			name:        "remove all members",
+			operations:  `[{"op": "remove", "path": "members"}]`,
+			displayName: "projects/hr/roles/DEVELOPER",
+			members:     map[string]bool{},
+		},
+		{
+			name:       "unsupported operation",
+			operations: `[{"op": "remove", "path": "displayName"}]`,
+			wantErr:    true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		var operations []PatchOperation
+		a.NoError(json.Unmarshal([]byte(test.operations), &operations), test.name)
+		group := &Group{DisplayName: "projects/hr/roles/DEVELOPER"}
+		members := map[string]bool{"101": true, "102": true}
+		err := applyGroupPatch(group, members, operations)
+		if test.wantErr {
+			a.Error(err, test.name)
+			continue
+		}
+		a.NoError(err, test.name)
+		a.Equal(test.displayName, group.DisplayName, test.name)
+		a.Equal(test.members, members, test.name)
+	}
+}
+
+func TestParseGroupProjectRole(t *testing.T) {
+	tests := []struct {
+		displayName string
+		project     string
+		role        api.Role
+		ok          bool
+	}{
+		{
+			displayName: "projects/hr/roles/DEVELOPER",
+			project:     "hr",
+			role:        api.Developer,
+			ok:          true,
+		},
+		{
+			displayName: "projects/hr/roles/custom-auditor",
+			project:     "hr",
+			role:        api.Role("custom-auditor"),
+			ok:          true,
+		},
+		{
+			displayName: "Engineering",
+		},
+		{
+			displayName: "projects/hr/roles/DEVELOPER/extra",
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		project, role, ok := parseGroupProjectRole(test.displayName)
+		a.Equal(test.ok, ok, test.displayName)
+		a.Equal(test.project, project, test.displayName)
+		a.Equal(test.role, role, test.displayName)
+	}
+}
+
+func TestDiffMembers(t *testing.T) {
+	a := require.New(t)
+	removed, added := diffMembers([]int{101, 102, 103}, []int{103, 104})
+	a.Equal([]int{101, 102}, removed)
+	a.Equal([]int{104}, added)
+
+	removed, added = diffMembers(nil, []int{101})
+	a.Nil(removed)
+	a.Equal([]int{101}, added)
+}
+
+func newTestUser(email, name string, active bool) *User {
+	return &User{
+		Schemas:     []string{userSchema},
+		ID:          "101",
+		UserName:    email,
+		Name:        &Name{Formatted: name},
+		DisplayName: name,
+		Emails:      []MultiValued{{Value: "alice@example.com", Type: "work", Primary: true}},
+		Active:      &active,
+		Meta:        &Meta{ResourceType: "User"},
+	}
+}
@@ -0,0 +1,216 @@
+// Package scim is the package for the SCIM 2.0 provisioning endpoints, see https://datatracker.ietf.org/doc/html/rfc7644.
+// The identity provider creates, updates and deactivates the principals, and manages the groups
+// which are mapped to the project IAM policy members.
+package scim
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	// MinTokenLength is the minimum length of the SCIM bearer token.
+	MinTokenLength = 32
+
+	contentType = "application/scim+json"
+	// maxPageSize is the maximum number of resources returned by a list request.
+	maxPageSize = 200
+)
+
+// HashToken returns the hash of the SCIM bearer token, which is what we store in the setting.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// Service is the SCIM 2.0 service provider.
+type Service struct {
+	store          *store.Store
+	licenseService enterpriseAPI.LicenseService
+}
+
+// NewService creates a SCIM service.
+func NewService(store *store.Store, licenseService enterpriseAPI.LicenseService) *Service {
+	return &Service{
+		store:          store,
+		licenseService: licenseService,
+	}
+}
+
+// RegisterRoutes registers the SCIM user and group endpoints.
+func (s *Service) RegisterRoutes(g *echo.Group) {
+	g.Use(s.authenticate)
+
+	g.GET("/ServiceProviderConfig", s.getServiceProviderConfig)
+
+	g.GET("/Users", s.listUsers)
+	g.POST("/Users", s.createUser)
+	g.GET("/Users/:id", s.getUser)
+	g.PUT("/Users/:id", s.replaceUser)
+	g.PATCH("/Users/:id", s.patchUser)
+	g.DELETE("/Users/:id", s.deleteUser)
+
+	g.GET("/Groups", s.listGroups)
+	g.POST("/Groups", s.createGroup)
+	g.GET("/Groups/:id", s.getGroup)
+	g.PUT("/Groups/:id", s.replaceGroup)
+	g.PATCH("/Groups/:id", s.patchGroup)
+	g.DELETE("/Groups/:id", s.deleteGroup)
+}
+
+// authenticate checks the bearer token against the hash in the SCIM token setting.
+func (s *Service) authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := s.licenseService.IsFeatureEnabled(api.FeatureSSO); err != nil {
+			return writeError(c, http.StatusForbidden, "", err.Error())
+		}
+		token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if !ok || token == "" {
+			return writeError(c, http.StatusUnauthorized, "", "Missing bearer token")
+		}
+		settingName := api.SettingSCIMToken
+		setting, err := s.store.GetSettingV2(c.Request().Context(), &store.FindSettingMessage{Name: &settingName})
+		if err != nil {
+			return writeError(c, http.StatusInternalServerError, "", fmt.Sprintf("Failed to get SCIM token setting: %v", err))
+		}
+		if setting == nil || setting.Value == "" {
+			return writeError(c, http.StatusUnauthorized, "", "SCIM is not enabled")
+		}
+		if subtle.ConstantTimeCompare([]byte(HashToken(token)), []byte(setting.Value)) != 1 {
+			return writeError(c, http.StatusUnauthorized, "", "Invalid bearer token")
+		}
+		return next(c)
+	}
+}
+
+func (*Service) getServiceProviderConfig(c echo.Context) error {
+	return writeJSON(c, http.StatusOK, map[string]any{
+		"schemas":        []string{spConfigSchema},
+		"patch":          map[string]any{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": maxPageSize},
+		"changePassword": map[string]any{"supported": false},
+		"sort":           map[string]any{"supported": false},
+		"etag":           map[string]any{"supported": false},
+		"authenticationSchemes": []map[string]any{
+			{
+				"type":        "oauthbearertoken",
+				"name":        "OAuth Bearer Token",
+				"description": "Authentication with the SCIM token configured in the workspace setting",
+			},
+		},
+	})
+}
+
+// pagination returns the 1-based start index and the count of the list request.
+func pagination(c echo.Context) (int, int, error) {
+	startIndex, count := 1, maxPageSize
+	if v := c.QueryParam("startIndex"); v != "" {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, errors.Errorf("invalid startIndex %q", v)
+		}
+		// A start index less than 1 is interpreted as 1.
+		if i > 1 {
+			startIndex = i
+		}
+	}
+	if v := c.QueryParam("count"); v != "" {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, errors.Errorf("invalid count %q", v)
+		}
+		if i < 0 {
+			i = 0
+		}
+		if i < count {
+			count = i
+		}
+	}
+	return startIndex, count, nil
+}
+
+func parseID(c echo.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+func bindJSON(c echo.Context, v any) error {
+	if err := json.NewDecoder(c.Request().Body).Decode(v); err != nil {
+		return errors.Wrapf(err, "invalid request body")
+	}
+	return nil
+}
+
+// requestError is the error of a SCIM request with the HTTP status code and the SCIM error type.
+type requestError struct {
+	code     int
+	scimType string
+	err      error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func writeRequestError(c echo.Context, err error) error {
+	var re *requestError
+	if errors.As(err, &re) {
+		return writeError(c, re.code, re.scimType, re.err.Error())
+	}
+	return writeError(c, http.StatusInternalServerError, "", err.Error())
+}
+
+func writeJSON(c echo.Context, code int, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal response").SetInternal(err)
+	}
+	return c.Blob(code, contentType, body)
+}
+
+func writeList(c echo.Context, total, startIndex int, resources []any) error {
+	if resources == nil {
+		resources = []any{}
+	}
+	return writeJSON(c, http.StatusOK, &ListResponse{
+		Schemas:      []string{listResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func writeError(c echo.Context, code int, scimType, detail string) error {
+	return writeJSON(c, code, &Error{
+		Schemas:  []string{errorSchema},
+		Status:   strconv.Itoa(code),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+func formatTime(ts int64) string {
+	if ts == 0 {
+		return ""
+	}
+	return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,297 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/bytebase/bytebase/backend/common"
+	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func (s *Service) listUsers(c echo.Context) error {
+	ctx := c.Request().Context()
+	startIndex, count, err := pagination(c)
+	if err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidValue", err.Error())
+	}
+	attribute, value, err := parseFilter(c.QueryParam("filter"))
+	if err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidFilter", err.Error())
+	}
+
+	endUser := api.EndUser
+	find := &store.FindUserMessage{Type: &endUser, ShowDeleted: true}
+	switch attribute {
+	case "":
+	case "username", "emails", "emails.value":
+		email := strings.ToLower(value)
+		find.Email = &email
+	default:
+		return writeError(c, http.StatusBadRequest, "invalidFilter", fmt.Sprintf("unsupported filter attribute %q", attribute))
+	}
+	users, err := s.store.ListUsers(ctx, find)
+	if err != nil {
+		return writeError(c, http.StatusInternalServerError, "", fmt.Sprintf("Failed to list users: %v", err))
+	}
+
+	var resources []any
+	for i := startIndex - 1; i < len(users) && len(resources) < count; i++ {
+		resources = append(resources, convertToUser(users[i]))
+	}
+	return writeList(c, len(users), startIndex, resources)
+}
+
+func (s *Service) getUser(c echo.Context) error {
+	user, err := s.getEndUser(c)
+	if err != nil || user == nil {
+		return err
+	}
+	return writeJSON(c, http.StatusOK, convertToUser(user))
+}
+
+func (s *Service) createUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	var create User
+	if err := bindJSON(c, &create); err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidSyntax", err.Error())
+	}
+	email := create.primaryEmail()
+	if err := validateEmail(email); err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidValue", fmt.Sprintf("Invalid email %q: %v", email, err))
+	}
+	phone := create.phone()
+	if phone != "" {
+		if err := common.ValidatePhone(phone); err != nil {
+			return writeError(c, http.StatusBadRequest, "invalidValue", fmt.Sprintf("Invalid phone %q: %v", phone, err))
+		}
+	}
+	active := create.Active == nil || *create.Active
+
+	existing, err := s.store.GetUser(ctx, &store.FindUserMessage{Email: &email, ShowDeleted: true})
+	if err != nil {
+		return writeError(c, http.StatusInternalServerError, "", fmt.Sprintf("Failed to get user: %v", err))
+	}
+	if existing != nil {
+		// The deactivated user is provisioned again when the person rejoins.
+		if existing.Type != api.EndUser || !existing.MemberDeleted {
+			return writeError(c, http.StatusConflict, "uniqueness", fmt.Sprintf("User %q already exists", email))
+		}
+		user, err := s.updateUser(ctx, existing, &create)
+		if err != nil {
+			return writeRequestError(c, err)
+		}
+		return writeJSON(c, http.StatusCreated, convertToUser(user))
+	}
+
+	if active {
+		if err := s.userCountGuard(ctx); err != nil {
+			return writeRequestError(c, err)
+		}
+	}
+	// The provisioned users sign in with the identity provider, so the password is never used.
+	password, err := common.RandomString(20)
+	if err != nil {
+		return writeError(c, http.StatusInternalServerError, "", "Failed to generate random password")
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return writeError(c, http.StatusInternalServerError, "", fmt.Sprintf("Failed to generate password hash: %v", err))
+	}
+	user, err := s.store.CreateUser(ctx, &store.UserMessage{
+		Name:         create.displayName(),
+		Email:        email,
+		Phone:        phone,
+		Type:         api.EndUser,
+		PasswordHash: string(passwordHash),
+	}, api.SystemBotID)
+	if err != nil {
+		return writeError(c, http.StatusInternalServerError, "", fmt.Sprintf("Failed to create user: %v", err))
+	}
+	if !active {
+		deleted := true
+		if user, err = s.store.UpdateUser(ctx, user.ID, &store.UpdateUserMessage{Delete: &deleted}, api.SystemBotID); err != nil {
+			return writeError(c, http.StatusInternalServerError, "", fmt.Sprintf("Failed to deactivate user: %v", err))
+		}
+	}
+	return writeJSON(c, http.StatusCreated, convertToUser(user))
+}
+
+func (s *Service) replaceUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getEndUser(c)
+	if err != nil || user == nil {
+		return err
+	}
+	var replace User
+	if err := bindJSON(c, &replace); err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidSyntax", err.Error())
+	}
+	user, err = s.updateUser(ctx, user, &replace)
+	if err != nil {
+		return writeRequestError(c, err)
+	}
+	return writeJSON(c, http.StatusOK, convertToUser(user))
+}
+
+func (s *Service) patchUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getEndUser(c)
+	if err != nil || user == nil {
+		return err
+	}
+	var patch PatchRequest
+	if err := bindJSON(c, &patch); err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidSyntax", err.Error())
+	}
+	update := convertToUser(user)
+	if err := applyUserPatch(update, patch.Operations); err != nil {
+		return writeError(c, http.StatusBadRequest, "invalidValue", err.Error())
+	}
+	user, err = s.updateUser(ctx, user, update)
+	if err != nil {
+		return writeRequestError(c, err)
+	}
+	return writeJSON(c, http.StatusOK, convertToUser(user))
+}
+
+// deleteUser deactivates the user instead of deleting it, so that the history of the user is kept.
+func (s *Service) deleteUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.getEndUser(c)
+	if err != nil || user == nil {
+		return err
+	}
+	update := convertToUser(user)
+	inactive := false
+	update.Active = &inactive
+	if _, err := s.updateUser(ctx, user, update); err != nil {
+		return writeRequestError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// getEndUser gets the end user by the ID in the path. It writes the error response and returns nil if the user is not found.
+func (s *Service) getEndUser(c echo.Context) (*store.UserMessage, error) {
+	id, ok := parseID(c)
+	if !ok {
+		return nil, writeError(c, http.StatusNotFound, "", fmt.Sprintf("User %q not found", c.Param("id")))
+	}
+	user, err := s.store.GetUserByID(c.Request().Context(), id)
+	if err != nil {
+		return nil, writeError(c, http.StatusInternalServerError, "", fmt.Sprintf("Failed to get user: %v", err))
+	}
+	if user == nil || user.Type != api.EndUser {
+		return nil, writeError(c, http.StatusNotFound, "", fmt.Sprintf("User %d not found", id))
+	}
+	return user, nil
+}
+
+// updateUser updates the user to the SCIM user, and activates or deactivates the user.
+func (s *Service) updateUser(ctx context.Context, user *store.UserMessage, update *User) (*store.UserMessage, error) {
+	patch := &store.UpdateUserMessage{}
+	if email := update.primaryEmail(); email != user.Email {
+		if err := validateEmail(email); err != nil {
+			return nil, &requestError{code: http.StatusBadRequest, scimType: "invalidValue", err: errors.Wrapf(err, "invalid email %q", email)}
+		}
+		existing, err := s.store.GetUser(ctx, &store.FindUserMessage{Email: &email, ShowDeleted: true})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get user")
+		}
+		if existing != nil {
+			return nil, &requestError{code: http.StatusConflict, scimType: "uniqueness", err: errors.Errorf("user %q already exists", email)}
+		}
+		patch.Email = &email
+	}
+	if name := update.displayName(); name != user.Name {
+		patch.Name = &name
+	}
+	if phone := update.phone(); phone != user.Phone {
+		if phone != "" {
+			if err := common.ValidatePhone(phone); err != nil {
+				return nil, &requestError{code: http.StatusBadRequest, scimType: "invalidValue", err: errors.Wrapf(err, "invalid phone %q", phone)}
+			}
+		}
+		patch.Phone = &phone
+	}
+	if update.Active != nil && *update.Active == user.MemberDeleted {
+		if *update.Active {
+			if err := s.userCountGuard(ctx); err != nil {
+				return nil, err
+			}
+		} else if user.Role == api.Owner {
+			if err := s.lastOwnerGuard(ctx); err != nil {
+				return nil, err
+			}
+		}
+		deleted := !*update.Active
+		patch.Delete = &deleted
+	}
+	if patch.Email == nil && patch.Name == nil && patch.Phone == nil && patch.Delete == nil {
+		return user, nil
+	}
+	updated, err := s.store.UpdateUser(ctx, user.ID, patch, api.SystemBotID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update user %d", user.ID)
+	}
+	return updated, nil
+}
+
+func (s *Service) userCountGuard(ctx context.Context) error {
+	userLimit := s.licenseService.GetPlanLimitValue(ctx, enterpriseAPI.PlanLimitMaximumUser)
+	count, err := s.store.CountActiveUsers(ctx)
+	if err != nil {
+		return err
+	}
+	if int64(count) >= userLimit {
+		return &requestError{code: http.StatusForbidden, err: errors.Errorf("reached the maximum user count %d", userLimit)}
+	}
+	return nil
+}
+
+// lastOwnerGuard prevents the identity provider from deactivating the last workspace owner, which would lock everyone out.
+func (s *Service) lastOwnerGuard(ctx context.Context) error {
+	owner, endUser := api.Owner, api.EndUser
+	owners, err := s.store.ListUsers(ctx, &store.FindUserMessage{Role: &owner, Type: &endUser})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list workspace owners")
+	}
+	if len(owners) <= 1 {
+		return &requestError{code: http.StatusBadRequest, scimType: "mutability", err: errors.New("cannot deactivate the last workspace owner")}
+	}
+	return nil
+}
+
+func convertToUser(user *store.UserMessage) *User {
+	active := !user.MemberDeleted
+	u := &User{
+		Schemas:     []string{userSchema},
+		ID:          strconv.Itoa(user.ID),
+		UserName:    user.Email,
+		Name:        &Name{Formatted: user.Name},
+		DisplayName: user.Name,
+		Emails:      []MultiValued{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta:        &Meta{ResourceType: "User"},
+	}
+	if user.Phone != "" {
+		u.PhoneNumbers = []MultiValued{{Value: user.Phone, Type: "work", Primary: true}}
+	}
+	return u
+}
+
+func validateEmail(email string) error {
+	if _, err := mail.ParseAddress(email); err != nil {
+		return err
+	}
+	return nil
+}
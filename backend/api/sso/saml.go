@@ -0,0 +1,387 @@
+// Package sso is the package for the single sign-on endpoints served outside of the gRPC APIs.
+package sso
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/idp/saml"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	// samlRequestTTL is how long we wait for the identity provider to respond to the AuthnRequest.
+	samlRequestTTL = 10 * time.Minute
+	// samlCodeTTL is how long the code of the validated SAML response is valid for login.
+	samlCodeTTL = time.Minute
+	// maxSAMLResponseSize is the maximum size of the base64 encoded SAML response.
+	maxSAMLResponseSize = 1024 * 1024
+
+	// samlRequestCookieName is the cookie binding the RelayState to the browser starting the single sign-on.
+	samlRequestCookieName = "saml-request"
+
+	samlStatePurpose = "saml-state"
+	samlCodePurpose  = "saml-code"
+)
+
+// Service is the API endpoint for handling the SAML single sign-on.
+//
+// The service keeps no state between the requests in memory, so that any replica can serve them. The AuthnRequest ID
+// is carried by the RelayState signed with the workspace secret, and the RelayState is bound to the browser with a cookie
+// holding the nonce whose hash is signed in the RelayState. The assertion consumer service validates the SAML response
+// and hands the user info over to the login with a code signed with the workspace secret. Both the request ID and the code
+// are consumed in the metadata database shared by the replicas, so that a captured SAML response or code can not be replayed.
+type Service struct {
+	store  *store.Store
+	secret string
+}
+
+// NewService creates a SSO service.
+func NewService(store *store.Store, secret string) *Service {
+	return &Service{
+		store:  store,
+		secret: secret,
+	}
+}
+
+// idConsumer consumes the single-use IDs, and returns false if the ID was consumed already.
+// It's implemented by the store so that the IDs consumed by any replica are rejected.
+type idConsumer interface {
+	ConsumeSAMLID(ctx context.Context, id string, expireTs int64) (bool, error)
+}
+
+// samlState is the signed RelayState sent to the identity provider along with the AuthnRequest.
+type samlState struct {
+	IdentityProviderID string `json:"idp"`
+	RequestID          string `json:"requestId"`
+	// NonceHash is the hash of the nonce in the cookie of the browser starting the single sign-on.
+	// The nonce itself is not in the RelayState since the RelayState passes through the identity provider.
+	NonceHash string `json:"nonceHash"`
+	// RelayState is the relay state of the client, which is returned to the client after the login.
+	RelayState string `json:"relayState,omitempty"`
+	ExpireTs   int64  `json:"exp"`
+}
+
+// samlCode is the signed code of the validated SAML response, which is consumed by the login.
+type samlCode struct {
+	// ID is the random ID consumed by the login, so that the code can be used only once.
+	ID                 string                            `json:"id"`
+	IdentityProviderID string                            `json:"idp"`
+	UserInfo           *storepb.IdentityProviderUserInfo `json:"userInfo"`
+	ExpireTs           int64                             `json:"exp"`
+}
+
+// RegisterSAMLRoutes registers the SAML service provider endpoints, i.e. the metadata, the single sign-on
+// redirecting to the identity provider, and the assertion consumer service.
+func (s *Service) RegisterSAMLRoutes(g *echo.Group) {
+	g.GET("/metadata/:idp", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		provider, _, err := s.getSAMLIdentityProvider(ctx, c.Param("idp"))
+		if err != nil {
+			return err
+		}
+		metadata, err := provider.Metadata()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate SAML metadata").SetInternal(err)
+		}
+		return c.Blob(http.StatusOK, "application/samlmetadata+xml", metadata)
+	})
+
+	g.GET("/sso/:idp", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		idpID := c.Param("idp")
+		provider, externalURL, err := s.getSAMLIdentityProvider(ctx, idpID)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		redirectURL, nonce, err := authnRequestURL(provider, s.secret, idpID, c.QueryParam("relay_state"), now)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create SAML AuthnRequest").SetInternal(err)
+		}
+		c.SetCookie(samlRequestCookie(externalURL, idpID, nonce, now.Add(samlRequestTTL)))
+		return c.Redirect(http.StatusFound, redirectURL)
+	})
+
+	g.POST("/acs/:idp", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		idpID := c.Param("idp")
+		provider, externalURL, err := s.getSAMLIdentityProvider(ctx, idpID)
+		if err != nil {
+			return err
+		}
+		samlResponse := c.FormValue("SAMLResponse")
+		if samlResponse == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "Missing SAMLResponse")
+		}
+		if len(samlResponse) > maxSAMLResponseSize {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "SAMLResponse is too large")
+		}
+
+		cookie, err := c.Cookie(samlRequestCookieName)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "Missing SAML request cookie, the single sign-on must be started from this browser")
+		}
+		// The cookie is single-use like the request ID.
+		c.SetCookie(samlRequestCookie(externalURL, idpID, "", time.Unix(0, 0)))
+
+		code, relayState, err := consumeSAMLResponse(ctx, s.store, provider, s.secret, idpID, samlResponse, c.FormValue("RelayState"), cookie.Value, time.Now())
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid SAML response").SetInternal(err)
+		}
+
+		query := url.Values{}
+		query.Set("idp", fmt.Sprintf("%s%s", common.IdentityProviderNamePrefix, idpID))
+		query.Set("code", code)
+		if relayState != "" {
+			query.Set("state", relayState)
+		}
+		return c.Redirect(http.StatusSeeOther, fmt.Sprintf("%s/saml/callback?%s", externalURL, query.Encode()))
+	})
+}
+
+// samlRequestCookie returns the cookie of the nonce binding the RelayState to the browser, which is only sent to
+// the assertion consumer service of the identity provider.
+func samlRequestCookie(externalURL, idpID, nonce string, expires time.Time) *http.Cookie {
+	path := "/"
+	if u, err := url.Parse(saml.GetServiceProviderACSURL(externalURL, idpID)); err == nil {
+		path = u.Path
+	}
+	cookie := &http.Cookie{
+		Name:     samlRequestCookieName,
+		Value:    nonce,
+		Path:     path,
+		Expires:  expires,
+		HttpOnly: true,
+	}
+	// The SAML response is posted to the assertion consumer service by the identity provider page, i.e. cross-site,
+	// so the cookie must be SameSite=None, which requires HTTPS.
+	if strings.HasPrefix(externalURL, "https") {
+		cookie.Secure = true
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	return cookie
+}
+
+// authnRequestURL returns the URL redirecting the user to the identity provider, with the request ID
+// and the relay state of the client carried by the signed RelayState. It also returns the nonce to be set
+// in the cookie of the browser, whose hash is signed in the RelayState.
+func authnRequestURL(provider *saml.IdentityProvider, secret, idpID, relayState string, now time.Time) (string, string, error) {
+	requestID, err := saml.GenerateID()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := generateNonce()
+	if err != nil {
+		return "", "", err
+	}
+	state, err := signToken(secret, samlStatePurpose, &samlState{
+		IdentityProviderID: idpID,
+		RequestID:          requestID,
+		NonceHash:          hashNonce(nonce),
+		RelayState:         relayState,
+		ExpireTs:           now.Add(samlRequestTTL).Unix(),
+	})
+	if err != nil {
+		return "", "", err
+	}
+	redirectURL, err := provider.AuthnRequestURL(requestID, state, now)
+	if err != nil {
+		return "", "", err
+	}
+	return redirectURL, nonce, nil
+}
+
+// consumeSAMLResponse validates the SAML response against the request ID in the signed RelayState, which must be
+// bound to the nonce in the cookie, consumes the request ID, and returns the signed code of the user info and
+// the relay state of the client.
+func consumeSAMLResponse(ctx context.Context, consumer idConsumer, provider *saml.IdentityProvider, secret, idpID, samlResponse, relayState, nonce string, now time.Time) (string, string, error) {
+	state := &samlState{}
+	if err := verifyToken(secret, samlStatePurpose, relayState, state); err != nil {
+		return "", "", errors.Wrap(err, "invalid RelayState")
+	}
+	if state.IdentityProviderID != idpID {
+		return "", "", errors.Errorf("RelayState is for identity provider %q", state.IdentityProviderID)
+	}
+	if now.Unix() >= state.ExpireTs {
+		return "", "", errors.New("SAML request has expired")
+	}
+	if !hmac.Equal([]byte(hashNonce(nonce)), []byte(state.NonceHash)) {
+		return "", "", errors.New("RelayState is not started by this browser")
+	}
+	var consumeErr error
+	userInfo, err := provider.UserInfo(samlResponse, func(requestID string) bool {
+		if requestID != state.RequestID {
+			return false
+		}
+		consumed, err := consumer.ConsumeSAMLID(ctx, requestID, state.ExpireTs)
+		if err != nil {
+			consumeErr = err
+			return false
+		}
+		return consumed
+	}, now)
+	if consumeErr != nil {
+		return "", "", consumeErr
+	}
+	if err != nil {
+		return "", "", err
+	}
+	codeID, err := generateNonce()
+	if err != nil {
+		return "", "", err
+	}
+	code, err := signToken(secret, samlCodePurpose, &samlCode{
+		ID:                 codeID,
+		IdentityProviderID: idpID,
+		UserInfo:           userInfo,
+		ExpireTs:           now.Add(samlCodeTTL).Unix(),
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return code, state.RelayState, nil
+}
+
+// GetSAMLUserInfo returns the user info of the SAML response validated by the assertion consumer service
+// of the identity provider with the code, and consumes the code so that it can be used only once.
+func GetSAMLUserInfo(ctx context.Context, s *store.Store, secret, idpID, code string, now time.Time) (*storepb.IdentityProviderUserInfo, error) {
+	return getSAMLUserInfo(ctx, s, secret, idpID, code, now)
+}
+
+func getSAMLUserInfo(ctx context.Context, consumer idConsumer, secret, idpID, code string, now time.Time) (*storepb.IdentityProviderUserInfo, error) {
+	c := &samlCode{}
+	if err := verifyToken(secret, samlCodePurpose, code, c); err != nil {
+		return nil, errors.Wrap(err, "invalid SAML code")
+	}
+	if c.IdentityProviderID != idpID {
+		return nil, errors.Errorf("SAML code is for identity provider %q", c.IdentityProviderID)
+	}
+	if now.Unix() >= c.ExpireTs {
+		return nil, errors.New("SAML code has expired")
+	}
+	if c.UserInfo == nil {
+		return nil, errors.New("SAML code has no user info")
+	}
+	if c.ID == "" {
+		return nil, errors.New("SAML code has no ID")
+	}
+	consumed, err := consumer.ConsumeSAMLID(ctx, c.ID, c.ExpireTs)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, errors.New("SAML code has been used")
+	}
+	return c.UserInfo, nil
+}
+
+// generateNonce generates a random URL safe nonce.
+func generateNonce() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate nonce")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashNonce(nonce string) string {
+	h := sha256.Sum256([]byte(nonce))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// signToken returns the token of the JSON encoded value with the HMAC-SHA256 signature of the secret.
+// The purpose is signed along with the value so that a token can not be used for another purpose.
+func signToken(secret, purpose string, v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal token")
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(tokenSignature(secret, purpose, payload)), nil
+}
+
+// verifyToken verifies the signature of the token and decodes its value into v.
+func verifyToken(secret, purpose, token string, v any) error {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return errors.New("malformed token")
+	}
+	got, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return errors.New("malformed token signature")
+	}
+	if !hmac.Equal(got, tokenSignature(secret, purpose, payload)) {
+		return errors.New("token signature mismatch")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return errors.New("malformed token payload")
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return errors.Wrap(err, "failed to unmarshal token")
+	}
+	return nil
+}
+
+func tokenSignature(secret, purpose, payload string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(purpose))
+	_, _ = mac.Write([]byte{0})
+	_, _ = mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+func (s *Service) getSAMLIdentityProvider(ctx context.Context, idpID string) (*saml.IdentityProvider, string, error) {
+	idp, err := s.store.GetIdentityProvider(ctx, &store.FindIdentityProviderMessage{ResourceID: &idpID})
+	if err != nil {
+		return nil, "", echo.NewHTTPError(http.StatusInternalServerError, "Failed to get identity provider").SetInternal(err)
+	}
+	if idp == nil || idp.Deleted || idp.Type != storepb.IdentityProviderType_SAML {
+		return nil, "", echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("SAML identity provider %q not found", idpID))
+	}
+	setting, err := s.store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return nil, "", echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace setting").SetInternal(err)
+	}
+	if setting.ExternalUrl == "" {
+		return nil, "", echo.NewHTTPError(http.StatusBadRequest, "External URL is required for SAML single sign-on")
+	}
+	provider, err := NewSAMLIdentityProvider(setting.ExternalUrl, idpID, idp.Config.GetSamlConfig())
+	if err != nil {
+		return nil, "", echo.NewHTTPError(http.StatusInternalServerError, "Failed to create SAML identity provider").SetInternal(err)
+	}
+	return provider, setting.ExternalUrl, nil
+}
+
+// NewSAMLIdentityProvider creates the SAML identity provider plugin with Bytebase at the external URL as the service provider.
+func NewSAMLIdentityProvider(externalURL, idpID string, config *storepb.SAMLIdentityProviderConfig) (*saml.IdentityProvider, error) {
+	if config == nil {
+		return nil, errors.New("missing SAML config")
+	}
+	return saml.NewIdentityProvider(saml.IdentityProviderConfig{
+		EntityID:       saml.GetServiceProviderEntityID(externalURL, idpID),
+		ACSURL:         saml.GetServiceProviderACSURL(externalURL, idpID),
+		IdPEntityID:    config.EntityId,
+		SSOURL:         config.SsoUrl,
+		IdPCertificate: config.IdpCertificate,
+		SPCertificate:  config.SpCertificate,
+		SPPrivateKey:   config.SpPrivateKey,
+		NameIDFormat:   config.NameIdFormat,
+		FieldMapping:   config.FieldMapping,
+	})
+}
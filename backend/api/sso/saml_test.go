@@ -0,0 +1,163 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/idp/saml"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// fakeIDConsumer consumes the IDs in memory like the saml_consumed_id table.
+type fakeIDConsumer map[string]bool
+
+func (c fakeIDConsumer) ConsumeSAMLID(_ context.Context, id string, _ int64) (bool, error) {
+	if c[id] {
+		return false, nil
+	}
+	c[id] = true
+	return true, nil
+}
+
+func TestGetSAMLUserInfo(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	now := time.Now()
+	userInfo := &storepb.IdentityProviderUserInfo{
+		Identifier:  "alice@example.com",
+		DisplayName: "Alice",
+		Email:       "alice@example.com",
+	}
+	code, err := signToken("secret", samlCodePurpose, &samlCode{
+		ID:                 "code-id",
+		IdentityProviderID: "okta",
+		UserInfo:           userInfo,
+		ExpireTs:           now.Add(samlCodeTTL).Unix(),
+	})
+	a.NoError(err)
+
+	got, err := getSAMLUserInfo(ctx, fakeIDConsumer{}, "secret", "okta", code, now)
+	a.NoError(err)
+	a.Equal(userInfo.Identifier, got.Identifier)
+	a.Equal(userInfo.DisplayName, got.DisplayName)
+	a.Equal(userInfo.Email, got.Email)
+
+	// The code can be used only once.
+	consumer := fakeIDConsumer{}
+	_, err = getSAMLUserInfo(ctx, consumer, "secret", "okta", code, now)
+	a.NoError(err)
+	_, err = getSAMLUserInfo(ctx, consumer, "secret", "okta", code, now)
+	a.ErrorContains(err, "has been used")
+
+	_, err = getSAMLUserInfo(ctx, fakeIDConsumer{}, "other-secret", "okta", code, now)
+	a.ErrorContains(err, "signature mismatch")
+	_, err = getSAMLUserInfo(ctx, fakeIDConsumer{}, "secret", "azure", code, now)
+	a.ErrorContains(err, "is for identity provider")
+	_, err = getSAMLUserInfo(ctx, fakeIDConsumer{}, "secret", "okta", code, now.Add(samlCodeTTL))
+	a.ErrorContains(err, "expired")
+
+	// The payload can not be modified.
+	payload, signature, _ := strings.Cut(code, ".")
+	tampered, err := signToken("other-secret", samlCodePurpose, &samlCode{
+		IdentityProviderID: "okta",
+		UserInfo:           &storepb.IdentityProviderUserInfo{Identifier: "admin@example.com"},
+		ExpireTs:           now.Add(samlCodeTTL).Unix(),
+	})
+	a.NoError(err)
+	tamperedPayload, _, _ := strings.Cut(tampered, ".")
+	a.NotEqual(payload, tamperedPayload)
+	_, err = getSAMLUserInfo(ctx, fakeIDConsumer{}, "secret", "okta", tamperedPayload+"."+signature, now)
+	a.ErrorContains(err, "signature mismatch")
+
+	// The signed RelayState can not be used as the code.
+	state, err := signToken("secret", samlStatePurpose, &samlState{
+		IdentityProviderID: "okta",
+		RequestID:          "id-request",
+		ExpireTs:           now.Add(samlRequestTTL).Unix(),
+	})
+	a.NoError(err)
+	_, err = getSAMLUserInfo(ctx, fakeIDConsumer{}, "secret", "okta", state, now)
+	a.ErrorContains(err, "signature mismatch")
+}
+
+func TestConsumeSAMLResponseCookie(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	now := time.Now()
+	nonce, err := generateNonce()
+	a.NoError(err)
+	state, err := signToken("secret", samlStatePurpose, &samlState{
+		IdentityProviderID: "okta",
+		RequestID:          "id-request",
+		NonceHash:          hashNonce(nonce),
+		ExpireTs:           now.Add(samlRequestTTL).Unix(),
+	})
+	a.NoError(err)
+
+	// The RelayState captured from another browser is rejected before the SAML response is validated.
+	for _, cookie := range []string{"", "other-nonce", hashNonce(nonce)} {
+		_, _, err = consumeSAMLResponse(ctx, fakeIDConsumer{}, nil /* provider */, "secret", "okta", "response", state, cookie, now)
+		a.ErrorContains(err, "not started by this browser")
+	}
+}
+
+func TestConsumeSAMLResponseReplay(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	// The ADFS response of the SAML plugin test fixtures.
+	response, err := os.ReadFile("../../plugin/idp/saml/testdata/adfs_response.xml")
+	a.NoError(err)
+	certificate, err := os.ReadFile("../../plugin/idp/saml/testdata/adfs_cert.pem")
+	a.NoError(err)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	a.NoError(err)
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "test"}}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	a.NoError(err)
+	provider, err := saml.NewIdentityProvider(saml.IdentityProviderConfig{
+		EntityID:       "https://saml.test.nope/session/sso/saml/spentityid/dknhyszjl7",
+		ACSURL:         "https://saml.test.nope/session/sso/saml/acs/dknhyszjl7",
+		IdPEntityID:    "http://fs.spstest2.com/adfs/services/trust",
+		SSOURL:         "https://idp.example.com/sso",
+		IdPCertificate: string(certificate),
+		SPCertificate:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		SPPrivateKey:   string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		FieldMapping:   &storepb.FieldMapping{Identifier: saml.NameIDKey},
+	})
+	a.NoError(err)
+
+	now := time.Date(2017, 9, 21, 23, 28, 0, 0, time.UTC)
+	state, err := signToken("secret", samlStatePurpose, &samlState{
+		IdentityProviderID: "adfs",
+		RequestID:          "_5988bf45-1cc8-4228-b3e8-1aa8590e63d3",
+		NonceHash:          hashNonce("nonce"),
+		RelayState:         "/sql-editor",
+		ExpireTs:           now.Add(samlRequestTTL).Unix(),
+	})
+	a.NoError(err)
+	samlResponse := base64.StdEncoding.EncodeToString(response)
+
+	consumer := fakeIDConsumer{}
+	code, relayState, err := consumeSAMLResponse(ctx, consumer, provider, "secret", "adfs", samlResponse, state, "nonce", now)
+	a.NoError(err)
+	a.Equal("/sql-editor", relayState)
+	userInfo, err := getSAMLUserInfo(ctx, consumer, "secret", "adfs", code, now)
+	a.NoError(err)
+	a.Equal("paul@spstest2.com", userInfo.Identifier)
+
+	// The captured SAML response and RelayState can not be replayed, even from the same browser.
+	_, _, err = consumeSAMLResponse(ctx, consumer, provider, "secret", "adfs", samlResponse, state, "nonce", now)
+	a.ErrorContains(err, "unknown or expired request")
+}
@@ -173,6 +173,10 @@ func (in *ACLInterceptor) getProjectRoles(ctx context.Context, user *store.UserM
 	if err != nil {
 		return nil, err
 	}
+	return getUserProjectRoles(user, projectPolicy), nil
+}
+
+func getUserProjectRoles(user *store.UserMessage, projectPolicy *store.IAMPolicyMessage) map[api.Role]bool {
 	roles := map[api.Role]bool{}
 	for _, binding := range projectPolicy.Bindings {
 		for _, member := range binding.Members {
@@ -182,7 +186,7 @@ func (in *ACLInterceptor) getProjectRoles(ctx context.Context, user *store.UserM
 			}
 		}
 	}
-	return roles, nil
+	return roles
 }
 
 func getProjectIDs(req any) ([]string, error) {
@@ -214,6 +218,48 @@ func getProjectIDs(req any) ([]string, error) {
 			return nil, err
 		}
 		return []string{projectID}, nil
+	case *v1pb.AddWebhookRequest:
+		projectID, err := common.GetProjectID(request.Project)
+		if err != nil {
+			return nil, err
+		}
+		return []string{projectID}, nil
+	case *v1pb.UpdateWebhookRequest:
+		if request.Webhook == nil {
+			return nil, errors.Errorf("webhook not found")
+		}
+		projectID, _, err := common.GetProjectIDWebhookID(request.Webhook.Name)
+		if err != nil {
+			return nil, err
+		}
+		return []string{projectID}, nil
+	case *v1pb.RemoveWebhookRequest:
+		if request.Webhook == nil {
+			return nil, errors.Errorf("webhook not found")
+		}
+		projectID, _, err := common.GetProjectIDWebhookID(request.Webhook.Name)
+		if err != nil {
+			return nil, err
+		}
+		return []string{projectID}, nil
+	case *v1pb.TestWebhookRequest:
+		projectID, err := common.GetProjectID(request.Project)
+		if err != nil {
+			return nil, err
+		}
+		return []string{projectID}, nil
+	case *v1pb.ListWebhookDeliveriesRequest:
+		projectID, _, err := common.GetProjectIDWebhookID(request.Parent)
+		if err != nil {
+			return nil, err
+		}
+		return []string{projectID}, nil
+	case *v1pb.RedeliverWebhookRequest:
+		projectID, _, _, err := common.GetProjectIDWebhookIDDeliveryID(request.Name)
+		if err != nil {
+			return nil, err
+		}
+		return []string{projectID}, nil
 	}
 
 	return nil, nil
@@ -33,6 +33,12 @@ var ownerAndDBAMethods = map[string]bool{
 	"RoleService/DeleteRole":                 true,
 	"ActuatorService/UpdateActuatorInfo":     true,
 	"ActuatorService/ListDebugLog":           true,
+	"WebhookService/ListWorkspaceWebhooks":   true,
+	"WebhookService/CreateWorkspaceWebhook":  true,
+	"WebhookService/UpdateWorkspaceWebhook":  true,
+	"WebhookService/DeleteWorkspaceWebhook":  true,
+	"WebhookService/ListWebhookDeliveries":   true,
+	"WebhookService/RedeliverWebhook":        true,
 }
 
 var projectOwnerMethods = map[string]bool{
@@ -40,6 +46,12 @@ var projectOwnerMethods = map[string]bool{
 	"ProjectService/DeleteProject":           true,
 	"ProjectService/UndeleteProject":         true,
 	"ProjectService/SetIamPolicy":            true,
+	"ProjectService/AddWebhook":              true,
+	"ProjectService/UpdateWebhook":           true,
+	"ProjectService/RemoveWebhook":           true,
+	"ProjectService/TestWebhook":             true,
+	"ProjectService/ListWebhookDeliveries":   true,
+	"ProjectService/RedeliverWebhook":        true,
 	"SubscriptionService/UpdateSubscription": true,
 }
 
@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestWebhookACL(t *testing.T) {
+	tests := []struct {
+		method  string
+		request any
+		// ownerOnly is whether the method is only allowed for the workspace owner and DBA.
+		ownerOnly bool
+		project   string
+		wantErr   bool
+	}{
+		{
+			method:  "ProjectService/AddWebhook",
+			request: &v1pb.AddWebhookRequest{Project: "projects/p1"},
+			project: "p1",
+		},
+		{
+			method:  "ProjectService/UpdateWebhook",
+			request: &v1pb.UpdateWebhookRequest{Webhook: &v1pb.Webhook{Name: "projects/p1/webhooks/1"}},
+			project: "p1",
+		},
+		{
+			method:  "ProjectService/RemoveWebhook",
+			request: &v1pb.RemoveWebhookRequest{Webhook: &v1pb.Webhook{Name: "projects/p1/webhooks/1"}},
+			project: "p1",
+		},
+		{
+			method:  "ProjectService/TestWebhook",
+			request: &v1pb.TestWebhookRequest{Project: "projects/p1"},
+			project: "p1",
+		},
+		{
+			method:  "ProjectService/ListWebhookDeliveries",
+			request: &v1pb.ListWebhookDeliveriesRequest{Parent: "projects/p1/webhooks/1"},
+			project: "p1",
+		},
+		{
+			method:  "ProjectService/RedeliverWebhook",
+			request: &v1pb.RedeliverWebhookRequest{Name: "projects/p1/webhooks/1/deliveries/2"},
+			project: "p1",
+		},
+		{
+			// The workspace webhook name must not be accepted by the project method.
+			method:  "ProjectService/ListWebhookDeliveries",
+			request: &v1pb.ListWebhookDeliveriesRequest{Parent: "webhooks/1"},
+			wantErr: true,
+		},
+		{
+			method:  "ProjectService/RedeliverWebhook",
+			request: &v1pb.RedeliverWebhookRequest{Name: "webhooks/1/deliveries/2"},
+			wantErr: true,
+		},
+		{
+			method:    "WebhookService/ListWebhookDeliveries",
+			request:   &v1pb.ListWebhookDeliveriesRequest{Parent: "webhooks/1"},
+			ownerOnly: true,
+		},
+		{
+			method:    "WebhookService/RedeliverWebhook",
+			request:   &v1pb.RedeliverWebhookRequest{Name: "webhooks/1/deliveries/2"},
+			ownerOnly: true,
+		},
+	}
+
+	a := require.New(t)
+	in := &ACLInterceptor{}
+	developer := &store.UserMessage{ID: 101, Role: api.Developer}
+	for _, test := range tests {
+		if test.ownerOnly {
+			a.True(isOwnerAndDBAMethod(test.method), test.method)
+			err := in.aclInterceptorDo(context.Background(), apiPackagePrefix+test.method, test.request, developer)
+			a.Equal(codes.PermissionDenied, status.Code(err), test.method)
+			continue
+		}
+		a.True(isProjectOwnerMethod(test.method), test.method)
+		projectIDs, err := getProjectIDs(test.request)
+		if test.wantErr {
+			a.Error(err, test.method)
+			// The interceptor denies the request before looking up the project roles.
+			err := in.aclInterceptorDo(context.Background(), apiPackagePrefix+test.method, test.request, developer)
+			a.Equal(codes.PermissionDenied, status.Code(err), test.method)
+			continue
+		}
+		a.NoError(err, test.method)
+		a.Equal([]string{test.project}, projectIDs, test.method)
+	}
+}
+
+func TestGetUserProjectRoles(t *testing.T) {
+	owner := &store.UserMessage{ID: 101}
+	developer := &store.UserMessage{ID: 102}
+	outsider := &store.UserMessage{ID: 103}
+	policy := &store.IAMPolicyMessage{
+		Bindings: []*store.PolicyBinding{
+			{Role: api.Owner, Members: []*store.UserMessage{owner}},
+			{Role: api.Developer, Members: []*store.UserMessage{owner, developer}},
+		},
+	}
+
+	a := require.New(t)
+	a.Equal(map[api.Role]bool{api.Owner: true, api.Developer: true}, getUserProjectRoles(owner, policy))
+	// Only the project owner can manage the webhooks and their deliveries.
+	a.False(getUserProjectRoles(developer, policy)[api.Owner])
+	a.Empty(getUserProjectRoles(outsider, policy))
+}
@@ -0,0 +1,446 @@
+package v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/audit"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	// auditedMethodPrefix is the prefix of the methods recorded in the audit log.
+	auditedMethodPrefix = "/bytebase.v1."
+	// auditRedactedValue replaces the values of the sensitive fields.
+	auditRedactedValue = "**REDACTED**"
+	// auditMaxStringLength is the maximum length of the string fields, e.g. the statements, kept in the audit log.
+	auditMaxStringLength = 1024
+)
+
+// auditSensitiveFieldPattern matches the names of the sensitive fields not marked as INPUT_ONLY, e.g. in the settings.
+var auditSensitiveFieldPattern = regexp.MustCompile(`(?i)(password|secret|token|private_key|credential|ssl_key|api_key)`)
+
+// AuditInterceptor is the interceptor recording the mutating methods in the audit log.
+// It records the actor, the method, the resource, the redacted request and the diff of the resource for every non-read method.
+type AuditInterceptor struct {
+	store  *store.Store
+	acl    *ACLInterceptor
+	logger *audit.Logger
+
+	// readMethods caches whether the method is a read.
+	readMethods sync.Map
+}
+
+// NewAuditInterceptor returns a new audit interceptor. The methods are not recorded if the logger is nil.
+// It must come after the ACL interceptor, and the resources are snapshotted with the same ACL as the caller.
+func NewAuditInterceptor(store *store.Store, acl *ACLInterceptor, logger *audit.Logger) *AuditInterceptor {
+	return &AuditInterceptor{
+		store:  store,
+		acl:    acl,
+		logger: logger,
+	}
+}
+
+// AuditInterceptor is the unary interceptor for gRPC API.
+func (in *AuditInterceptor) AuditInterceptor(ctx context.Context, request any, serverInfo *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if in.logger == nil || in.isReadMethod(serverInfo.FullMethod) {
+		return handler(ctx, request)
+	}
+
+	entry := &audit.Entry{
+		Time:   time.Now().UTC(),
+		Actor:  in.getActor(ctx),
+		Method: serverInfo.FullMethod,
+	}
+	var getter *resourceGetter
+	if message, ok := request.(proto.Message); ok {
+		entry.Request = marshalAuditMessage(message)
+		var field string
+		field, entry.Resource = getAuditResource(message.ProtoReflect())
+		getter = getResourceGetter(serverInfo.Server, serverInfo.FullMethod, field, entry.Resource)
+	}
+
+	var before proto.Message
+	if getter != nil {
+		before, _ = in.snapshot(ctx, getter)
+	}
+	response, err := handler(ctx, request)
+	if err == nil && before != nil {
+		after, snapshotErr := in.snapshot(ctx, getter)
+		switch {
+		case after != nil:
+			entry.Diff = diffAuditMessages(before, after)
+		case status.Code(snapshotErr) == codes.NotFound:
+			// The resource is deleted, and all the fields are removed.
+			entry.Diff = diffAuditMessages(before, nil)
+		}
+	}
+	in.log(entry, err)
+	return response, err
+}
+
+// AuditStreamInterceptor is the stream interceptor for gRPC API.
+// The requests of the streams are not recorded, and the entry is recorded when the stream ends.
+func (in *AuditInterceptor) AuditStreamInterceptor(request any, ss grpc.ServerStream, serverInfo *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if in.logger == nil || in.isReadMethod(serverInfo.FullMethod) {
+		return handler(request, ss)
+	}
+
+	entry := &audit.Entry{
+		Time:   time.Now().UTC(),
+		Actor:  in.getActor(ss.Context()),
+		Method: serverInfo.FullMethod,
+	}
+	err := handler(request, ss)
+	in.log(entry, err)
+	return err
+}
+
+func (in *AuditInterceptor) log(entry *audit.Entry, err error) {
+	st := status.Convert(err)
+	entry.Status = st.Code().String()
+	if err != nil {
+		entry.Error = st.Message()
+	}
+	if err := in.logger.Log(entry); err != nil {
+		slog.Error("Failed to write the audit log", slog.String("method", entry.Method), log.BBError(err))
+	}
+}
+
+func (in *AuditInterceptor) getActor(ctx context.Context) string {
+	principalPtr := ctx.Value(common.PrincipalIDContextKey)
+	if principalPtr == nil {
+		return ""
+	}
+	principalID := principalPtr.(int)
+	user, err := in.store.GetUserByID(ctx, principalID)
+	if err != nil || user == nil {
+		slog.Warn("Failed to get the audit actor", slog.Int("principalID", principalID), log.BBError(err))
+		return ""
+	}
+	return common.FormatUserEmail(user.Email)
+}
+
+// snapshot gets the resource with the Get method of the service as the caller.
+// The caller must pass the ACL of the Get method, so that the audit log never records what the caller can't read.
+func (in *AuditInterceptor) snapshot(ctx context.Context, getter *resourceGetter) (snapshot proto.Message, err error) {
+	// The audit log must not fail the method.
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Warn("Failed to get the audit snapshot", slog.Any("panic", r))
+			snapshot, err = nil, errors.Errorf("panic: %v", r)
+		}
+	}()
+	role, ok := ctx.Value(common.RoleContextKey).(api.Role)
+	if !ok {
+		return nil, errors.New("the caller is unauthenticated")
+	}
+	if !isOwnerOrDBA(role) {
+		user, err := in.acl.getUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := in.acl.aclInterceptorDo(ctx, getter.fullMethod, getter.request, user); err != nil {
+			return nil, err
+		}
+	}
+	results := getter.method.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(getter.request)})
+	if err, _ := results[1].Interface().(error); err != nil {
+		return nil, err
+	}
+	if results[0].IsNil() {
+		return nil, errors.New("the resource is nil")
+	}
+	message, _ := results[0].Interface().(proto.Message)
+	return message, nil
+}
+
+// isReadMethod returns true if the method is not audited, i.e. the method is a read, or it's not a Bytebase v1 method.
+// The reads are the methods mapped to the HTTP GET.
+func (in *AuditInterceptor) isReadMethod(fullMethod string) bool {
+	if v, ok := in.readMethods.Load(fullMethod); ok {
+		return v.(bool)
+	}
+	isRead := true
+	if method := findMethodDescriptor(fullMethod); method != nil {
+		rule, _ := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+		isRead = rule.GetGet() != ""
+	}
+	in.readMethods.Store(fullMethod, isRead)
+	return isRead
+}
+
+// findMethodDescriptor returns the descriptor of the Bytebase v1 method, e.g. /bytebase.v1.InstanceService/UpdateInstance.
+func findMethodDescriptor(fullMethod string) protoreflect.MethodDescriptor {
+	if !strings.HasPrefix(fullMethod, auditedMethodPrefix) {
+		return nil
+	}
+	serviceName, methodName, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return nil
+	}
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil
+	}
+	service, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil
+	}
+	return service.Methods().ByName(protoreflect.Name(methodName))
+}
+
+// getAuditResource returns the resource name of the request, and the field of the Get request to get the resource.
+// The resource is the name of the request, e.g. DeleteInstance, or the name of the resource in the request, e.g. UpdateInstance.
+// Otherwise, it's the parent, project or resource of the request, e.g. SetIamPolicy.
+func getAuditResource(m protoreflect.Message) (string, string) {
+	fields := m.Descriptor().Fields()
+	if name := getStringField(m, "name"); name != "" {
+		return "name", name
+	}
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() || !m.Has(fd) {
+			continue
+		}
+		if name := getStringField(m.Get(fd).Message(), "name"); name != "" {
+			return "name", name
+		}
+	}
+	for _, field := range []string{"parent", "project", "resource"} {
+		if name := getStringField(m, field); name != "" {
+			return field, name
+		}
+	}
+	return "", ""
+}
+
+func getStringField(m protoreflect.Message, field string) string {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(field))
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() || fd.IsMap() {
+		return ""
+	}
+	return m.Get(fd).String()
+}
+
+// resourceGetter is the Get method of the resource, and the request to call it.
+type resourceGetter struct {
+	fullMethod string
+	method     reflect.Value
+	request    proto.Message
+}
+
+// getResourceGetter returns the getter of the resource mutated by the method, or nil if there is no Get method.
+// For example, it's GetInstance for UpdateInstance, and GetIamPolicy for SetIamPolicy.
+func getResourceGetter(server any, fullMethod, field, resource string) *resourceGetter {
+	if server == nil || field == "" || resource == "" {
+		return nil
+	}
+	_, noun := splitMethodName(fullMethod[strings.LastIndex(fullMethod, "/")+1:])
+	if noun == "" {
+		return nil
+	}
+	getMethodName := "Get" + noun
+	getFullMethod := fullMethod[:strings.LastIndex(fullMethod, "/")+1] + getMethodName
+	getDescriptor := findMethodDescriptor(getFullMethod)
+	if getDescriptor == nil {
+		return nil
+	}
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(getDescriptor.Input().FullName())
+	if err != nil {
+		return nil
+	}
+	getRequest := messageType.New()
+	fd := getRequest.Descriptor().Fields().ByName(protoreflect.Name(field))
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() || fd.IsMap() {
+		return nil
+	}
+	getRequest.Set(fd, protoreflect.ValueOfString(resource))
+
+	getMethod := reflect.ValueOf(server).MethodByName(getMethodName)
+	if !getMethod.IsValid() {
+		return nil
+	}
+	methodType := getMethod.Type()
+	if methodType.NumIn() != 2 || methodType.NumOut() != 2 || methodType.In(1) != reflect.TypeOf(getRequest.Interface()) {
+		return nil
+	}
+	return &resourceGetter{fullMethod: getFullMethod, method: getMethod, request: getRequest.Interface()}
+}
+
+// splitMethodName splits the method name into the verb and the noun, e.g. SetIamPolicy into Set and IamPolicy.
+func splitMethodName(method string) (string, string) {
+	for i, r := range method {
+		if i > 0 && unicode.IsUpper(r) {
+			return method[:i], method[i:]
+		}
+	}
+	return method, ""
+}
+
+// marshalAuditMessage returns the JSON of the message with the sensitive fields redacted.
+func marshalAuditMessage(message proto.Message) json.RawMessage {
+	redacted := proto.Clone(message)
+	redactAuditMessage(redacted.ProtoReflect())
+	b, err := protojson.Marshal(redacted)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// redactAuditMessage redacts the sensitive fields, and truncates the long strings of the message recursively.
+// The sensitive fields are the INPUT_ONLY fields, e.g. the passwords, and the fields with the sensitive names.
+func redactAuditMessage(m protoreflect.Message) {
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+	for _, fd := range fields {
+		isSingular := !fd.IsList() && !fd.IsMap()
+		if isSensitiveField(fd) {
+			if isSingular && fd.Kind() == protoreflect.StringKind {
+				m.Set(fd, protoreflect.ValueOfString(auditRedactedValue))
+			} else {
+				m.Clear(fd)
+			}
+			continue
+		}
+		v := m.Get(fd)
+		switch {
+		case fd.IsList():
+			if fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind {
+				list := v.List()
+				for i := 0; i < list.Len(); i++ {
+					redactAuditMessage(list.Get(i).Message())
+				}
+			}
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+					redactAuditMessage(value.Message())
+					return true
+				})
+			}
+		case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+			redactAuditMessage(v.Message())
+		case fd.Kind() == protoreflect.StringKind:
+			if s := v.String(); len(s) > auditMaxStringLength {
+				m.Set(fd, protoreflect.ValueOfString(truncateAuditString(s)))
+			}
+		}
+	}
+}
+
+func isSensitiveField(fd protoreflect.FieldDescriptor) bool {
+	behaviors, _ := proto.GetExtension(fd.Options(), annotations.E_FieldBehavior).([]annotations.FieldBehavior)
+	for _, behavior := range behaviors {
+		if behavior == annotations.FieldBehavior_INPUT_ONLY {
+			return true
+		}
+	}
+	return auditSensitiveFieldPattern.MatchString(string(fd.Name()))
+}
+
+// truncateAuditString truncates the string, and appends its hash so that the full string can still be matched.
+func truncateAuditString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return strings.ToValidUTF8(s[:auditMaxStringLength], "") + "...(sha256:" + hex.EncodeToString(sum[:]) + ")"
+}
+
+// diffAuditMessages returns the changed fields between the redacted messages.
+// The fields are flattened into the dot-separated paths of the JSON objects, and the lists are compared as a whole.
+// The nil after means the resource is deleted.
+func diffAuditMessages(before, after proto.Message) []*audit.FieldDiff {
+	beforeFields, err := flattenAuditMessage(before)
+	if err != nil {
+		return nil
+	}
+	afterFields, err := flattenAuditMessage(after)
+	if err != nil {
+		return nil
+	}
+	paths := map[string]bool{}
+	for path := range beforeFields {
+		paths[path] = true
+	}
+	for path := range afterFields {
+		paths[path] = true
+	}
+	var diffs []*audit.FieldDiff
+	for path := range paths {
+		b, a := beforeFields[path], afterFields[path]
+		if string(b) == string(a) {
+			continue
+		}
+		diffs = append(diffs, &audit.FieldDiff{Path: path, Before: b, After: a})
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs
+}
+
+func flattenAuditMessage(message proto.Message) (map[string]json.RawMessage, error) {
+	if message == nil {
+		return map[string]json.RawMessage{}, nil
+	}
+	b := marshalAuditMessage(message)
+	if b == nil {
+		return nil, errors.New("failed to marshal audit message")
+	}
+	var value any
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal audit message")
+	}
+	fields := map[string]json.RawMessage{}
+	if err := flattenAuditValue("", value, fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func flattenAuditValue(path string, value any, fields map[string]json.RawMessage) error {
+	if object, ok := value.(map[string]any); ok && len(object) > 0 {
+		for key, v := range object {
+			p := key
+			if path != "" {
+				p = path + "." + key
+			}
+			if err := flattenAuditValue(p, v, fields); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal audit field %q", path)
+	}
+	fields[path] = b
+	return nil
+}
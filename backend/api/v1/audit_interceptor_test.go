@@ -0,0 +1,171 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/component/audit"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+type fakeInstanceGetter struct {
+	instance *v1pb.Instance
+}
+
+func (s *fakeInstanceGetter) GetInstance(_ context.Context, request *v1pb.GetInstanceRequest) (*v1pb.Instance, error) {
+	if request.Name != s.instance.Name {
+		return nil, status.Errorf(codes.NotFound, "instance %q not found", request.Name)
+	}
+	return s.instance, nil
+}
+
+func TestAuditIsReadMethod(t *testing.T) {
+	a := require.New(t)
+	in := NewAuditInterceptor(nil, nil, nil)
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{method: "/bytebase.v1.InstanceService/GetInstance", want: true},
+		{method: "/bytebase.v1.InstanceService/ListInstances", want: true},
+		{method: "/bytebase.v1.InstanceService/UpdateInstance", want: false},
+		{method: "/bytebase.v1.ProjectService/SetIamPolicy", want: false},
+		{method: "/bytebase.v1.SettingService/SetSetting", want: false},
+		{method: "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo", want: true},
+	}
+	for _, test := range tests {
+		a.Equal(test.want, in.isReadMethod(test.method), test.method)
+		// The result is cached.
+		a.Equal(test.want, in.isReadMethod(test.method), test.method)
+	}
+}
+
+func TestAuditResource(t *testing.T) {
+	a := require.New(t)
+	tests := []struct {
+		request   *v1pb.UpdateInstanceRequest
+		wantField string
+		want      string
+	}{
+		{request: &v1pb.UpdateInstanceRequest{Instance: &v1pb.Instance{Name: "instances/prod"}}, wantField: "name", want: "instances/prod"},
+		{request: &v1pb.UpdateInstanceRequest{}, wantField: "", want: ""},
+	}
+	for _, test := range tests {
+		field, resource := getAuditResource(test.request.ProtoReflect())
+		a.Equal(test.wantField, field)
+		a.Equal(test.want, resource)
+	}
+	field, resource := getAuditResource((&v1pb.SetIamPolicyRequest{Project: "projects/hr", Policy: &v1pb.IamPolicy{}}).ProtoReflect())
+	a.Equal("project", field)
+	a.Equal("projects/hr", resource)
+
+	verb, noun := splitMethodName("SetIamPolicy")
+	a.Equal("Set", verb)
+	a.Equal("IamPolicy", noun)
+}
+
+func TestAuditRedaction(t *testing.T) {
+	a := require.New(t)
+	request := &v1pb.UpdateInstanceRequest{
+		Instance: &v1pb.Instance{
+			Name:  "instances/prod",
+			Title: strings.Repeat("t", auditMaxStringLength+1),
+			DataSources: []*v1pb.DataSource{
+				{Id: "admin", Username: "root", Password: "s3cret", SslKey: "key"},
+			},
+		},
+	}
+	b := marshalAuditMessage(request)
+	var got struct {
+		Instance struct {
+			Title       string
+			DataSources []map[string]any
+		}
+	}
+	a.NoError(json.Unmarshal(b, &got))
+	a.Equal(map[string]any{"id": "admin", "username": "root", "password": auditRedactedValue, "sslKey": auditRedactedValue}, got.Instance.DataSources[0])
+	a.True(strings.HasPrefix(got.Instance.Title, strings.Repeat("t", auditMaxStringLength)+"...(sha256:"))
+	// The request is not modified.
+	a.Equal("s3cret", request.Instance.DataSources[0].Password)
+}
+
+func TestAuditSnapshotDiff(t *testing.T) {
+	a := require.New(t)
+	server := &fakeInstanceGetter{instance: &v1pb.Instance{Name: "instances/prod", Title: "Prod", DataSources: []*v1pb.DataSource{{Id: "admin", Password: "old"}}}}
+	getter := getResourceGetter(server, "/bytebase.v1.InstanceService/UpdateInstance", "name", "instances/prod")
+	a.NotNil(getter)
+	a.Equal("/bytebase.v1.InstanceService/GetInstance", getter.fullMethod)
+	ctx := context.WithValue(context.Background(), common.RoleContextKey, api.Owner)
+	in := NewAuditInterceptor(nil, nil, nil)
+
+	// The unauthenticated caller can't snapshot the resource.
+	_, err := in.snapshot(context.Background(), getter)
+	a.Error(err)
+
+	before, err := in.snapshot(ctx, getter)
+	a.NoError(err)
+	server.instance = &v1pb.Instance{Name: "instances/prod", Title: "Production", ExternalLink: "https://example.com", DataSources: []*v1pb.DataSource{{Id: "admin", Password: "new"}}}
+	after, err := in.snapshot(ctx, getter)
+	a.NoError(err)
+	a.Equal([]*audit.FieldDiff{
+		{Path: "externalLink", After: json.RawMessage(`"https://example.com"`)},
+		{Path: "title", Before: json.RawMessage(`"Prod"`), After: json.RawMessage(`"Production"`)},
+	}, diffAuditMessages(before, after))
+
+	// The resource without the Get method is not snapshotted.
+	a.Nil(getResourceGetter(server, "/bytebase.v1.InstanceService/AddDataSource", "name", "instances/prod"))
+	// The missing resource is not snapshotted.
+	getter = getResourceGetter(server, "/bytebase.v1.InstanceService/DeleteInstance", "name", "instances/dev")
+	a.NotNil(getter)
+	_, err = in.snapshot(ctx, getter)
+	a.Equal(codes.NotFound, status.Code(err))
+}
+
+func TestAuditInterceptorDelete(t *testing.T) {
+	a := require.New(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := audit.NewFileSink(path)
+	a.NoError(err)
+	key := []byte("0123456789abcdef0123456789abcdef")
+	logger, err := audit.NewLogger(key, "replica-1", sink)
+	a.NoError(err)
+	in := NewAuditInterceptor(nil, nil, logger)
+
+	server := &fakeInstanceGetter{instance: &v1pb.Instance{Name: "instances/prod", Title: "Prod"}}
+	ctx := context.WithValue(context.Background(), common.RoleContextKey, api.Owner)
+	_, err = in.AuditInterceptor(ctx, &v1pb.DeleteInstanceRequest{Name: "instances/prod"}, &grpc.UnaryServerInfo{Server: server, FullMethod: "/bytebase.v1.InstanceService/DeleteInstance"},
+		func(context.Context, any) (any, error) {
+			server.instance = &v1pb.Instance{}
+			return &emptypb.Empty{}, nil
+		})
+	a.NoError(err)
+	a.NoError(logger.Close())
+
+	content, err := os.ReadFile(path)
+	a.NoError(err)
+	count, err := audit.Verify(bytes.NewReader(content), key)
+	a.NoError(err)
+	a.Equal(1, count)
+	var entry audit.Entry
+	a.NoError(json.Unmarshal(content, &entry))
+	a.Equal("replica-1", entry.Replica)
+	a.Equal("instances/prod", entry.Resource)
+	// The snapshot before the deletion is recorded.
+	a.Equal([]*audit.FieldDiff{
+		{Path: "name", Before: json.RawMessage(`"instances/prod"`)},
+		{Path: "title", Before: json.RawMessage(`"Prod"`)},
+	}, entry.Diff)
+}
@@ -21,7 +21,9 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/bytebase/bytebase/backend/api/auth"
+	"github.com/bytebase/bytebase/backend/api/sso"
 	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/component/activity"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
@@ -31,6 +33,7 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/idp/oauth2"
 	"github.com/bytebase/bytebase/backend/plugin/idp/oidc"
 	"github.com/bytebase/bytebase/backend/plugin/metric"
+	webhookPlugin "github.com/bytebase/bytebase/backend/plugin/webhook"
 	"github.com/bytebase/bytebase/backend/runner/metricreport"
 	"github.com/bytebase/bytebase/backend/store"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -46,27 +49,29 @@ var (
 // AuthService implements the auth service.
 type AuthService struct {
 	v1pb.UnimplementedAuthServiceServer
-	store          *store.Store
-	secret         string
-	tokenDuration  time.Duration
-	licenseService enterpriseAPI.LicenseService
-	metricReporter *metricreport.Reporter
-	profile        *config.Profile
-	stateCfg       *state.State
-	postCreateUser func(ctx context.Context, user *store.UserMessage, firstEndUser bool) error
+	store           *store.Store
+	secret          string
+	tokenDuration   time.Duration
+	licenseService  enterpriseAPI.LicenseService
+	metricReporter  *metricreport.Reporter
+	profile         *config.Profile
+	stateCfg        *state.State
+	activityManager *activity.Manager
+	postCreateUser  func(ctx context.Context, user *store.UserMessage, firstEndUser bool) error
 }
 
 // NewAuthService creates a new AuthService.
-func NewAuthService(store *store.Store, secret string, tokenDuration time.Duration, licenseService enterpriseAPI.LicenseService, metricReporter *metricreport.Reporter, profile *config.Profile, stateCfg *state.State, postCreateUser func(ctx context.Context, user *store.UserMessage, firstEndUser bool) error) (*AuthService, error) {
+func NewAuthService(store *store.Store, secret string, tokenDuration time.Duration, licenseService enterpriseAPI.LicenseService, metricReporter *metricreport.Reporter, profile *config.Profile, stateCfg *state.State, activityManager *activity.Manager, postCreateUser func(ctx context.Context, user *store.UserMessage, firstEndUser bool) error) (*AuthService, error) {
 	return &AuthService{
-		store:          store,
-		secret:         secret,
-		tokenDuration:  tokenDuration,
-		licenseService: licenseService,
-		metricReporter: metricReporter,
-		profile:        profile,
-		stateCfg:       stateCfg,
-		postCreateUser: postCreateUser,
+		store:           store,
+		secret:          secret,
+		tokenDuration:   tokenDuration,
+		licenseService:  licenseService,
+		metricReporter:  metricReporter,
+		profile:         profile,
+		stateCfg:        stateCfg,
+		activityManager: activityManager,
+		postCreateUser:  postCreateUser,
 	}, nil
 }
 
@@ -238,6 +243,7 @@ func (s *AuthService) CreateUser(ctx context.Context, request *v1pb.CreateUserRe
 	if _, err := s.store.CreateActivityV2(ctx, activityCreate); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create activity, error: %v", err)
 	}
+	s.postUserEvent(ctx, user.ID, api.ActivityMemberCreate, "User created", user)
 	userResponse := convertToUser(user)
 	if request.User.UserType == v1pb.UserType_SERVICE_ACCOUNT {
 		userResponse.ServiceKey = password
@@ -403,6 +409,9 @@ func (s *AuthService) UpdateUser(ctx context.Context, request *v1pb.UpdateUserRe
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update user, error: %v", err)
 	}
+	if patch.Role != nil {
+		s.postUserEvent(ctx, principalID, api.ActivityMemberRoleUpdate, "User role updated", user)
+	}
 
 	userResponse := convertToUser(user)
 	if request.User.UserType == v1pb.UserType_SERVICE_ACCOUNT && passwordPatch != nil {
@@ -434,9 +443,11 @@ func (s *AuthService) DeleteUser(ctx context.Context, request *v1pb.DeleteUserRe
 		return nil, status.Errorf(codes.PermissionDenied, "only workspace owner can delete the user %d", userID)
 	}
 
-	if _, err := s.store.UpdateUser(ctx, userID, &store.UpdateUserMessage{Delete: &deletePatch}, principalID); err != nil {
+	user, err = s.store.UpdateUser(ctx, userID, &store.UpdateUserMessage{Delete: &deletePatch}, principalID)
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
+	s.postUserEvent(ctx, principalID, api.ActivityMemberDeactivate, "User deactivated", user)
 	return &emptypb.Empty{}, nil
 }
 
@@ -471,9 +482,24 @@ func (s *AuthService) UndeleteUser(ctx context.Context, request *v1pb.UndeleteUs
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
+	s.postUserEvent(ctx, principalID, api.ActivityMemberActivate, "User activated", user)
 	return convertToUser(user), nil
 }
 
+// postUserEvent posts the user event to the workspace webhooks.
+func (s *AuthService) postUserEvent(ctx context.Context, creatorUID int, activityType api.ActivityType, title string, user *store.UserMessage) {
+	s.activityManager.PostWorkspaceEvent(ctx, creatorUID, &webhookPlugin.Context{
+		ActivityType: string(activityType),
+		Title:        fmt.Sprintf("%s - %s", title, user.Email),
+		User: &webhookPlugin.User{
+			ID:    user.ID,
+			Name:  user.Name,
+			Email: user.Email,
+			Role:  string(user.Role),
+		},
+	})
+}
+
 func convertToUser(user *store.UserMessage) *v1pb.User {
 	role := v1pb.UserRole_USER_ROLE_UNSPECIFIED
 	switch user.Role {
@@ -774,6 +800,15 @@ func (s *AuthService) getOrCreateUserWithIDP(ctx context.Context, request *v1pb.
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to get user info: %v", err)
 		}
+	} else if idp.Type == storepb.IdentityProviderType_SAML {
+		samlContext := request.IdpContext.GetSamlContext()
+		if samlContext == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "missing SAML context")
+		}
+		userInfo, err = sso.GetSAMLUserInfo(ctx, s.store, s.secret, idp.ResourceID, samlContext.Code, time.Now())
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "failed to get user info: %v", err)
+		}
 	} else {
 		return nil, status.Errorf(codes.InvalidArgument, "identity provider type %s not supported", idp.Type.String())
 	}
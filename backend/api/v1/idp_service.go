@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/bytebase/bytebase/backend/api/sso"
 	"github.com/bytebase/bytebase/backend/common"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	"github.com/bytebase/bytebase/backend/plugin/idp/ldap"
@@ -25,13 +27,15 @@ type IdentityProviderService struct {
 	v1pb.UnimplementedIdentityProviderServiceServer
 	store          *store.Store
 	licenseService enterpriseAPI.LicenseService
+	secret         string
 }
 
 // NewIdentityProviderService creates a new IdentityProviderService.
-func NewIdentityProviderService(store *store.Store, licenseService enterpriseAPI.LicenseService) *IdentityProviderService {
+func NewIdentityProviderService(store *store.Store, licenseService enterpriseAPI.LicenseService, secret string) *IdentityProviderService {
 	return &IdentityProviderService{
 		store:          store,
 		licenseService: licenseService,
+		secret:         secret,
 	}
 }
 
@@ -144,6 +148,10 @@ func (s *IdentityProviderService) UpdateIdentityProvider(ctx context.Context, re
 			if request.IdentityProvider.Config.GetLdapConfig().BindPassword == "" {
 				patch.Config.GetLdapConfig().BindPassword = identityProvider.Config.GetLdapConfig().BindPassword
 			}
+		} else if identityProvider.Type == storepb.IdentityProviderType_SAML {
+			if request.IdentityProvider.Config.GetSamlConfig().SpPrivateKey == "" {
+				patch.Config.GetSamlConfig().SpPrivateKey = identityProvider.Config.GetSamlConfig().SpPrivateKey
+			}
 		}
 	}
 
@@ -314,6 +322,39 @@ func (s *IdentityProviderService) TestIdentityProvider(ctx context.Context, requ
 			return nil, status.Errorf(codes.InvalidArgument, "failed to test connection, error: %s", err.Error())
 		}
 		_ = conn.Close()
+	} else if identityProvider.Type == v1pb.IdentityProviderType_SAML {
+		identityProviderID, err := common.GetIdentityProviderID(request.IdentityProvider.Name)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		// Retrieve SP private key from stored identity provider if not provided.
+		if request.IdentityProvider.Config.GetSamlConfig().SpPrivateKey == "" {
+			storedIdentityProvider, err := s.getIdentityProviderMessage(ctx, request.IdentityProvider.Name)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to find identity provider, error: %s", err.Error())
+			}
+			if storedIdentityProvider == nil {
+				return nil, status.Errorf(codes.Internal, "identity provider %s not found", request.IdentityProvider.Name)
+			}
+			request.IdentityProvider.Config.GetSamlConfig().SpPrivateKey = storedIdentityProvider.Config.GetSamlConfig().SpPrivateKey
+		}
+		identityProviderConfig := convertIdentityProviderConfigToStore(identityProvider.Config).GetSamlConfig()
+		samlIdentityProvider, err := sso.NewSAMLIdentityProvider(setting.ExternalUrl, identityProviderID, identityProviderConfig)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to create new SAML identity provider: %v", err)
+		}
+		// Without the code of a SAML response, we can only validate the config by building the AuthnRequest.
+		samlContext := request.GetSamlContext()
+		if samlContext.GetCode() == "" {
+			if _, err := samlIdentityProvider.AuthnRequestURL("id-test" /* requestID */, "" /* relayState */, time.Now()); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "failed to create SAML AuthnRequest, error: %s", err.Error())
+			}
+			return &v1pb.TestIdentityProviderResponse{}, nil
+		}
+		// The SAML response is validated by the assertion consumer service with the stored config.
+		if _, err := sso.GetSAMLUserInfo(ctx, s.store, s.secret, identityProviderID, samlContext.Code, time.Now()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to get user info, error: %s", err.Error())
+		}
 	} else {
 		return nil, status.Errorf(codes.InvalidArgument, "identity provider type %s not supported", identityProvider.Type.String())
 	}
@@ -418,6 +459,26 @@ func convertIdentityProviderConfigFromStore(identityProviderConfig *storepb.Iden
 				},
 			},
 		}
+	} else if v := identityProviderConfig.GetSamlConfig(); v != nil {
+		fieldMapping := v1pb.FieldMapping{
+			Identifier:  v.FieldMapping.Identifier,
+			DisplayName: v.FieldMapping.DisplayName,
+			Email:       v.FieldMapping.Email,
+			Phone:       v.FieldMapping.Phone,
+		}
+		return &v1pb.IdentityProviderConfig{
+			Config: &v1pb.IdentityProviderConfig_SamlConfig{
+				SamlConfig: &v1pb.SAMLIdentityProviderConfig{
+					EntityId:       v.EntityId,
+					SsoUrl:         v.SsoUrl,
+					IdpCertificate: v.IdpCertificate,
+					SpCertificate:  v.SpCertificate,
+					SpPrivateKey:   "", // SECURITY: We do not expose the SP private key
+					NameIdFormat:   v.NameIdFormat,
+					FieldMapping:   &fieldMapping,
+				},
+			},
+		}
 	}
 	return nil
 }
@@ -486,6 +547,26 @@ func convertIdentityProviderConfigToStore(identityProviderConfig *v1pb.IdentityP
 				},
 			},
 		}
+	} else if v := identityProviderConfig.GetSamlConfig(); v != nil {
+		fieldMapping := storepb.FieldMapping{
+			Identifier:  v.FieldMapping.Identifier,
+			DisplayName: v.FieldMapping.DisplayName,
+			Email:       v.FieldMapping.Email,
+			Phone:       v.FieldMapping.Phone,
+		}
+		return &storepb.IdentityProviderConfig{
+			Config: &storepb.IdentityProviderConfig_SamlConfig{
+				SamlConfig: &storepb.SAMLIdentityProviderConfig{
+					EntityId:       v.EntityId,
+					SsoUrl:         v.SsoUrl,
+					IdpCertificate: v.IdpCertificate,
+					SpCertificate:  v.SpCertificate,
+					SpPrivateKey:   v.SpPrivateKey,
+					NameIdFormat:   v.NameIdFormat,
+					FieldMapping:   &fieldMapping,
+				},
+			},
+		}
 	} else {
 		return nil
 	}
@@ -505,6 +586,10 @@ func validIdentityProviderConfig(identityProviderType v1pb.IdentityProviderType,
 		if identityProviderConfig.GetLdapConfig() == nil {
 			return errors.Errorf("unexpected provider config value")
 		}
+	} else if identityProviderType == v1pb.IdentityProviderType_SAML {
+		if identityProviderConfig.GetSamlConfig() == nil || identityProviderConfig.GetSamlConfig().FieldMapping == nil {
+			return errors.Errorf("unexpected provider config value")
+		}
 	} else {
 		return errors.Errorf("unexpected provider type %s", identityProviderType)
 	}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/activity"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	"github.com/bytebase/bytebase/backend/component/secret"
 	"github.com/bytebase/bytebase/backend/component/state"
@@ -22,6 +23,7 @@ import (
 	metricAPI "github.com/bytebase/bytebase/backend/metric"
 	"github.com/bytebase/bytebase/backend/plugin/metric"
 	pgparser "github.com/bytebase/bytebase/backend/plugin/parser/pg"
+	webhookPlugin "github.com/bytebase/bytebase/backend/plugin/webhook"
 	"github.com/bytebase/bytebase/backend/runner/metricreport"
 	"github.com/bytebase/bytebase/backend/runner/schemasync"
 	"github.com/bytebase/bytebase/backend/store"
@@ -32,25 +34,27 @@ import (
 // InstanceService implements the instance service.
 type InstanceService struct {
 	v1pb.UnimplementedInstanceServiceServer
-	store          *store.Store
-	licenseService enterpriseAPI.LicenseService
-	metricReporter *metricreport.Reporter
-	secret         string
-	stateCfg       *state.State
-	dbFactory      *dbfactory.DBFactory
-	schemaSyncer   *schemasync.Syncer
+	store           *store.Store
+	licenseService  enterpriseAPI.LicenseService
+	metricReporter  *metricreport.Reporter
+	secret          string
+	stateCfg        *state.State
+	dbFactory       *dbfactory.DBFactory
+	schemaSyncer    *schemasync.Syncer
+	activityManager *activity.Manager
 }
 
 // NewInstanceService creates a new InstanceService.
-func NewInstanceService(store *store.Store, licenseService enterpriseAPI.LicenseService, metricReporter *metricreport.Reporter, secret string, stateCfg *state.State, dbFactory *dbfactory.DBFactory, schemaSyncer *schemasync.Syncer) *InstanceService {
+func NewInstanceService(store *store.Store, licenseService enterpriseAPI.LicenseService, metricReporter *metricreport.Reporter, secret string, stateCfg *state.State, dbFactory *dbfactory.DBFactory, schemaSyncer *schemasync.Syncer, activityManager *activity.Manager) *InstanceService {
 	return &InstanceService{
-		store:          store,
-		licenseService: licenseService,
-		metricReporter: metricReporter,
-		secret:         secret,
-		stateCfg:       stateCfg,
-		dbFactory:      dbFactory,
-		schemaSyncer:   schemaSyncer,
+		store:           store,
+		licenseService:  licenseService,
+		metricReporter:  metricReporter,
+		secret:          secret,
+		stateCfg:        stateCfg,
+		dbFactory:       dbFactory,
+		schemaSyncer:    schemaSyncer,
+		activityManager: activityManager,
 	}
 }
 
@@ -162,16 +166,33 @@ func (s *InstanceService) CreateInstance(ctx context.Context, request *v1pb.Crea
 			"engine": instance.Engine,
 		},
 	})
+	s.postInstanceEvent(ctx, api.ActivityInstanceCreate, "Instance created", instance)
 
 	return convertToInstance(instance), nil
 }
 
+func (s *InstanceService) postInstanceEvent(ctx context.Context, activityType api.ActivityType, title string, instance *store.InstanceMessage) {
+	s.activityManager.PostWorkspaceEvent(ctx, ctx.Value(common.PrincipalIDContextKey).(int), &webhookPlugin.Context{
+		ActivityType: string(activityType),
+		Title:        fmt.Sprintf("%s - %s", title, instance.Title),
+		Instance: &webhookPlugin.Instance{
+			ID:          instance.ResourceID,
+			Name:        instance.Title,
+			Engine:      instance.Engine.String(),
+			Environment: instance.EnvironmentID,
+		},
+	})
+}
+
 func (s *InstanceService) checkDataSource(instance *store.InstanceMessage, dataSource *store.DataSourceMessage) error {
 	password, err := common.Unobfuscate(dataSource.ObfuscatedPassword, s.secret)
 	if err != nil {
 		return err
 	}
-	if ok, _ := secret.GetExternalSecretURL(password); !ok {
+	if err := dbfactory.ValidateAuthenticationType(instance.Engine, dataSource.AuthenticationType, password); err != nil {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if !secret.IsExternalSecret(password) {
 		return nil
 	}
 	if err := s.licenseService.IsFeatureEnabledForInstance(api.FeatureExternalSecretManager, instance); err != nil {
@@ -294,7 +315,7 @@ func (s *InstanceService) syncSlowQueriesForInstance(ctx context.Context, instan
 
 func (s *InstanceService) syncSlowQueriesImpl(ctx context.Context, project *store.ProjectMessage, instance *store.InstanceMessage) error {
 	switch instance.Engine {
-	case storepb.Engine_MYSQL:
+	case storepb.Engine_MYSQL, storepb.Engine_TIDB:
 		driver, err := s.dbFactory.GetAdminDatabaseDriver(ctx, instance, nil /* database */)
 		if err != nil {
 			return err
@@ -313,7 +334,7 @@ func (s *InstanceService) syncSlowQueriesImpl(ctx context.Context, project *stor
 			message.ProjectID = project.ResourceID
 		}
 		s.stateCfg.InstanceSlowQuerySyncChan <- message
-	case storepb.Engine_POSTGRES:
+	case storepb.Engine_POSTGRES, storepb.Engine_MSSQL, storepb.Engine_ORACLE:
 		findDatabase := &store.FindDatabaseMessage{
 			InstanceID: &instance.ResourceID,
 		}
@@ -330,7 +351,7 @@ func (s *InstanceService) syncSlowQueriesImpl(ctx context.Context, project *stor
 			if database.SyncState != api.OK {
 				continue
 			}
-			if pgparser.IsSystemDatabase(database.DatabaseName) {
+			if instance.Engine == storepb.Engine_POSTGRES && pgparser.IsSystemDatabase(database.DatabaseName) {
 				continue
 			}
 			if err := func() error {
@@ -388,7 +409,7 @@ func (s *InstanceService) syncSlowQueriesForProject(ctx context.Context, project
 		}
 
 		switch instance.Engine {
-		case storepb.Engine_MYSQL, storepb.Engine_POSTGRES:
+		case storepb.Engine_MYSQL, storepb.Engine_POSTGRES, storepb.Engine_TIDB, storepb.Engine_MSSQL, storepb.Engine_ORACLE:
 			if instance.Deleted {
 				continue
 			}
@@ -472,6 +493,7 @@ func (s *InstanceService) DeleteInstance(ctx context.Context, request *v1pb.Dele
 	}, -1 /* don't need to pass the instance limition */); err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
+	s.postInstanceEvent(ctx, api.ActivityInstanceDelete, "Instance deleted", instance)
 
 	return &emptypb.Empty{}, nil
 }
@@ -495,6 +517,7 @@ func (s *InstanceService) UndeleteInstance(ctx context.Context, request *v1pb.Un
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
+	s.postInstanceEvent(ctx, api.ActivityInstanceUndelete, "Instance restored", ins)
 
 	return convertToInstance(ins), nil
 }
@@ -713,11 +736,20 @@ func (s *InstanceService) UpdateDataSource(ctx context.Context, request *v1pb.Up
 			obfuscated := common.Obfuscate(request.DataSource.SshPrivateKey, s.secret)
 			patch.SSHObfuscatedPrivateKey = &obfuscated
 			dataSource.SSHObfuscatedPrivateKey = obfuscated
+		case "authentication_type":
+			authenticationType := convertDataSourceAuthenticationType(request.DataSource.AuthenticationType)
+			patch.AuthenticationType = &authenticationType
+			dataSource.AuthenticationType = authenticationType
 		default:
 			return nil, status.Errorf(codes.InvalidArgument, `unsupport update_mask "%s"`, path)
 		}
 	}
 
+	if patch.ObfuscatedPassword != nil || patch.AuthenticationType != nil {
+		if err := s.checkDataSource(instance, &dataSource); err != nil {
+			return nil, err
+		}
+	}
 	if patch.SSHHost != nil || patch.SSHPort != nil || patch.SSHUser != nil || patch.SSHObfuscatedPassword != nil || patch.SSHObfuscatedPrivateKey != nil {
 		if err := s.licenseService.IsFeatureEnabledForInstance(api.FeatureInstanceSSHConnection, instance); err != nil {
 			return nil, status.Errorf(codes.PermissionDenied, err.Error())
@@ -888,6 +920,7 @@ func convertToInstance(instance *store.InstanceMessage) *v1pb.Instance {
 			AuthenticationDatabase: ds.AuthenticationDatabase,
 			Sid:                    ds.SID,
 			ServiceName:            ds.ServiceName,
+			AuthenticationType:     convertToDataSourceAuthenticationType(ds.AuthenticationType),
 		})
 	}
 	return &v1pb.Instance{
@@ -966,9 +999,38 @@ func (s *InstanceService) convertToDataSourceMessage(dataSource *v1pb.DataSource
 		SSHUser:                 dataSource.SshUser,
 		SSHObfuscatedPassword:   common.Obfuscate(dataSource.SshPassword, s.secret),
 		SSHObfuscatedPrivateKey: common.Obfuscate(dataSource.SshPrivateKey, s.secret),
+		AuthenticationType:      convertDataSourceAuthenticationType(dataSource.AuthenticationType),
 	}, nil
 }
 
+func convertToDataSourceAuthenticationType(authenticationType storepb.DataSourceOptions_AuthenticationType) v1pb.DataSource_AuthenticationType {
+	switch authenticationType {
+	case storepb.DataSourceOptions_PASSWORD:
+		return v1pb.DataSource_PASSWORD
+	case storepb.DataSourceOptions_AWS_RDS_IAM:
+		return v1pb.DataSource_AWS_RDS_IAM
+	case storepb.DataSourceOptions_GOOGLE_CLOUD_SQL_IAM:
+		return v1pb.DataSource_GOOGLE_CLOUD_SQL_IAM
+	case storepb.DataSourceOptions_AZURE_IAM:
+		return v1pb.DataSource_AZURE_IAM
+	}
+	return v1pb.DataSource_AUTHENTICATION_UNSPECIFIED
+}
+
+func convertDataSourceAuthenticationType(authenticationType v1pb.DataSource_AuthenticationType) storepb.DataSourceOptions_AuthenticationType {
+	switch authenticationType {
+	case v1pb.DataSource_PASSWORD:
+		return storepb.DataSourceOptions_PASSWORD
+	case v1pb.DataSource_AWS_RDS_IAM:
+		return storepb.DataSourceOptions_AWS_RDS_IAM
+	case v1pb.DataSource_GOOGLE_CLOUD_SQL_IAM:
+		return storepb.DataSourceOptions_GOOGLE_CLOUD_SQL_IAM
+	case v1pb.DataSource_AZURE_IAM:
+		return storepb.DataSourceOptions_AZURE_IAM
+	}
+	return storepb.DataSourceOptions_AUTHENTICATION_UNSPECIFIED
+}
+
 func (s *InstanceService) instanceCountGuard(ctx context.Context) error {
 	instanceLimit := s.licenseService.GetPlanLimitValue(ctx, enterpriseAPI.PlanLimitMaximumInstance)
 
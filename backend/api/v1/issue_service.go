@@ -799,6 +799,9 @@ func (s *IssueService) ApproveIssue(ctx context.Context, request *v1pb.ApproveIs
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update issue, error: %v", err)
 	}
+	if approved {
+		metricAPI.ObserveApprovalWait(string(issue.Type), time.Since(issue.CreatedTime))
+	}
 
 	// Grant the privilege if the issue is approved.
 	if approved && issue.Type == api.IssueGrantRequest {
@@ -1327,6 +1330,21 @@ func (s *IssueService) BatchUpdateIssuesStatus(ctx context.Context, request *v1p
 		return nil, status.Errorf(codes.Internal, "failed to batch update issues, err: %v", err)
 	}
 
+	if newStatus == api.IssueCanceled {
+		for _, issue := range issues {
+			if issue.PipelineUID == nil {
+				continue
+			}
+			tasks, err := s.store.ListTasks(ctx, &api.TaskFind{PipelineID: issue.PipelineUID})
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to list tasks, err: %v", err)
+			}
+			for _, task := range tasks {
+				s.stateCfg.TaskCleanupChan <- task.ID
+			}
+		}
+	}
+
 	if err := func() error {
 		var errs error
 		for _, issue := range issues {
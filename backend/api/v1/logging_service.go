@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strconv"
@@ -328,25 +329,23 @@ func (s *LoggingService) ExportLogs(ctx context.Context, request *v1pb.ExportLog
 		}})
 	}
 
-	var content []byte
+	var buf bytes.Buffer
+	var exporter resultExporter
 	switch request.Format {
 	case v1pb.ExportFormat_CSV:
-		if content, err = exportCSV(result); err != nil {
-			return nil, err
-		}
+		exporter = &csvExporter{w: &buf}
 	case v1pb.ExportFormat_JSON:
-		if content, err = exportJSON(result); err != nil {
-			return nil, err
-		}
+		exporter = &jsonExporter{w: &buf}
 	case v1pb.ExportFormat_XLSX:
-		if content, err = exportXLSX(result); err != nil {
-			return nil, err
-		}
+		exporter = &xlsxExporter{w: &buf}
 	default:
 		return nil, status.Errorf(codes.InvalidArgument, "unsupported export format: %s", request.Format.String())
 	}
+	if err := exportResult(exporter, result); err != nil {
+		return nil, err
+	}
 
-	return &v1pb.ExportLogsResponse{Content: content}, nil
+	return &v1pb.ExportLogsResponse{Content: buf.Bytes()}, nil
 }
 
 func convertToLogEntity(ctx context.Context, db *store.Store, activity *store.ActivityMessage) (*v1pb.LogEntity, error) {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/google/cel-go/cel"
 	"github.com/pkg/errors"
@@ -17,9 +18,11 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/activity"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	webhookPlugin "github.com/bytebase/bytebase/backend/plugin/webhook"
 	"github.com/bytebase/bytebase/backend/store"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
@@ -31,15 +34,17 @@ var defaultWorkspaceResourceID = 1
 // OrgPolicyService implements the workspace policy service.
 type OrgPolicyService struct {
 	v1pb.UnimplementedOrgPolicyServiceServer
-	store          *store.Store
-	licenseService enterpriseAPI.LicenseService
+	store           *store.Store
+	licenseService  enterpriseAPI.LicenseService
+	activityManager *activity.Manager
 }
 
 // NewOrgPolicyService creates a new OrgPolicyService.
-func NewOrgPolicyService(store *store.Store, licenseService enterpriseAPI.LicenseService) *OrgPolicyService {
+func NewOrgPolicyService(store *store.Store, licenseService enterpriseAPI.LicenseService, activityManager *activity.Manager) *OrgPolicyService {
 	return &OrgPolicyService{
-		store:          store,
-		licenseService: licenseService,
+		store:           store,
+		licenseService:  licenseService,
+		activityManager: activityManager,
 	}
 }
 
@@ -172,13 +177,14 @@ func (s *OrgPolicyService) UpdatePolicy(ctx context.Context, request *v1pb.Updat
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
+	s.postPolicyEvent(ctx, api.ActivityPolicyUpdate, "Policy updated", response)
 
 	return response, nil
 }
 
 // DeletePolicy deletes a policy for a specific resource.
 func (s *OrgPolicyService) DeletePolicy(ctx context.Context, request *v1pb.DeletePolicyRequest) (*emptypb.Empty, error) {
-	policy, _, err := s.findPolicyMessage(ctx, request.Name)
+	policy, parent, err := s.findPolicyMessage(ctx, request.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -186,10 +192,25 @@ func (s *OrgPolicyService) DeletePolicy(ctx context.Context, request *v1pb.Delet
 	if err := s.store.DeletePolicyV2(ctx, policy); err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
+	if response, err := convertToPolicy(parent, policy); err == nil {
+		s.postPolicyEvent(ctx, api.ActivityPolicyDelete, "Policy deleted", response)
+	}
 
 	return &emptypb.Empty{}, nil
 }
 
+func (s *OrgPolicyService) postPolicyEvent(ctx context.Context, activityType api.ActivityType, title string, policy *v1pb.Policy) {
+	s.activityManager.PostWorkspaceEvent(ctx, ctx.Value(common.PrincipalIDContextKey).(int), &webhookPlugin.Context{
+		ActivityType: string(activityType),
+		Title:        fmt.Sprintf("%s - %s", title, policy.Name),
+		Policy: &webhookPlugin.Policy{
+			Name:    policy.Name,
+			Type:    policy.Type.String(),
+			Enforce: policy.Enforce,
+		},
+	})
+}
+
 // findPolicyMessage finds the policy and the parent name by the policy name.
 func (s *OrgPolicyService) findPolicyMessage(ctx context.Context, policyName string) (*store.PolicyMessage, string, error) {
 	tokens := strings.Split(policyName, common.PolicyNamePrefix)
@@ -417,6 +438,7 @@ func (s *OrgPolicyService) createPolicyMessage(ctx context.Context, creatorID in
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
+	s.postPolicyEvent(ctx, api.ActivityPolicyCreate, "Policy created", response)
 
 	return response, nil
 }
@@ -658,6 +680,26 @@ func (s *OrgPolicyService) convertPolicyPayloadToString(policy *v1pb.Policy) (st
 			return "", errors.Wrap(err, "failed to marshal masking exception policy")
 		}
 		return string(payloadBytes), nil
+	case v1pb.PolicyType_MIGRATION_TIMEOUT:
+		v1MigrationTimeoutPolicy := policy.GetMigrationTimeoutPolicy()
+		if err := validateMigrationTimeoutPolicy(v1MigrationTimeoutPolicy); err != nil {
+			return "", status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		payloadBytes, err := protojson.Marshal(convertToStorePBMigrationTimeoutPolicy(v1MigrationTimeoutPolicy))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal migration timeout policy")
+		}
+		return string(payloadBytes), nil
+	case v1pb.PolicyType_CONCURRENCY:
+		v1ConcurrencyPolicy := policy.GetConcurrencyPolicy()
+		if err := validateConcurrencyPolicy(v1ConcurrencyPolicy); err != nil {
+			return "", status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		payloadBytes, err := protojson.Marshal(convertToStorePBConcurrencyPolicy(v1ConcurrencyPolicy))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal concurrency policy")
+		}
+		return string(payloadBytes), nil
 	}
 
 	return "", status.Errorf(codes.InvalidArgument, "invalid policy %v", policy.Type)
@@ -774,6 +816,24 @@ func convertToPolicy(parentPath string, policyMessage *store.PolicyMessage) (*v1
 		policy.Policy = &v1pb.Policy_MaskingExceptionPolicy{
 			MaskingExceptionPolicy: payload,
 		}
+	case api.PolicyTypeMigrationTimeout:
+		pType = v1pb.PolicyType_MIGRATION_TIMEOUT
+		migrationTimeoutPolicy := &storepb.MigrationTimeoutPolicy{}
+		if err := protojson.Unmarshal([]byte(policyMessage.Payload), migrationTimeoutPolicy); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal migration timeout policy")
+		}
+		policy.Policy = &v1pb.Policy_MigrationTimeoutPolicy{
+			MigrationTimeoutPolicy: convertToV1PBMigrationTimeoutPolicy(migrationTimeoutPolicy),
+		}
+	case api.PolicyTypeConcurrency:
+		pType = v1pb.PolicyType_CONCURRENCY
+		concurrencyPolicy := &storepb.ConcurrencyPolicy{}
+		if err := protojson.Unmarshal([]byte(policyMessage.Payload), concurrencyPolicy); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal concurrency policy")
+		}
+		policy.Policy = &v1pb.Policy_ConcurrencyPolicy{
+			ConcurrencyPolicy: convertToV1PBConcurrencyPolicy(concurrencyPolicy),
+		}
 	}
 
 	policy.Type = pType
@@ -1102,6 +1162,66 @@ func convertToStorePBRolloutPolicy(policy *v1pb.RolloutPolicy) *storepb.RolloutP
 	}
 }
 
+func convertToStorePBMigrationTimeoutPolicy(policy *v1pb.MigrationTimeoutPolicy) *storepb.MigrationTimeoutPolicy {
+	return &storepb.MigrationTimeoutPolicy{
+		LockTimeout:      policy.LockTimeout,
+		StatementTimeout: policy.StatementTimeout,
+		MaxRetries:       policy.MaxRetries,
+	}
+}
+
+func convertToV1PBMigrationTimeoutPolicy(policy *storepb.MigrationTimeoutPolicy) *v1pb.MigrationTimeoutPolicy {
+	return &v1pb.MigrationTimeoutPolicy{
+		LockTimeout:      policy.LockTimeout,
+		StatementTimeout: policy.StatementTimeout,
+		MaxRetries:       policy.MaxRetries,
+	}
+}
+
+func convertToStorePBConcurrencyPolicy(policy *v1pb.ConcurrencyPolicy) *storepb.ConcurrencyPolicy {
+	p := &storepb.ConcurrencyPolicy{
+		MaxRunningTaskRuns: policy.MaxRunningTaskRuns,
+	}
+	for _, window := range policy.MaintenanceWindows {
+		p.MaintenanceWindows = append(p.MaintenanceWindows, &storepb.MaintenanceWindow{
+			Days:      window.Days,
+			StartTime: window.StartTime,
+			Duration:  window.Duration,
+			TimeZone:  window.TimeZone,
+		})
+	}
+	for _, group := range policy.ExclusionGroups {
+		p.ExclusionGroups = append(p.ExclusionGroups, &storepb.ExclusionGroup{
+			Title:     group.Title,
+			Instances: group.Instances,
+			Scope:     storepb.ExclusionGroup_Scope(group.Scope),
+		})
+	}
+	return p
+}
+
+func convertToV1PBConcurrencyPolicy(policy *storepb.ConcurrencyPolicy) *v1pb.ConcurrencyPolicy {
+	p := &v1pb.ConcurrencyPolicy{
+		MaxRunningTaskRuns: policy.MaxRunningTaskRuns,
+	}
+	for _, window := range policy.MaintenanceWindows {
+		p.MaintenanceWindows = append(p.MaintenanceWindows, &v1pb.MaintenanceWindow{
+			Days:      window.Days,
+			StartTime: window.StartTime,
+			Duration:  window.Duration,
+			TimeZone:  window.TimeZone,
+		})
+	}
+	for _, group := range policy.ExclusionGroups {
+		p.ExclusionGroups = append(p.ExclusionGroups, &v1pb.ExclusionGroup{
+			Title:     group.Title,
+			Instances: group.Instances,
+			Scope:     v1pb.ExclusionGroup_Scope(group.Scope),
+		})
+	}
+	return p
+}
+
 func convertToPipelineApprovalPolicyPayload(policy *v1pb.DeploymentApprovalPolicy) (*api.PipelineApprovalPolicy, error) {
 	var strategy api.PipelineApprovalValue
 	switch policy.DefaultStrategy {
@@ -1344,10 +1464,90 @@ func convertPolicyType(pType string) (api.PolicyType, error) {
 		return api.PolicyTypeSlowQuery, nil
 	case v1pb.PolicyType_DISABLE_COPY_DATA.String():
 		return api.PolicyTypeDisableCopyData, nil
+	case v1pb.PolicyType_MIGRATION_TIMEOUT.String():
+		return api.PolicyTypeMigrationTimeout, nil
+	case v1pb.PolicyType_CONCURRENCY.String():
+		return api.PolicyTypeConcurrency, nil
 	}
 	return policyType, errors.Errorf("invalid policy type %v", pType)
 }
 
+// maxMigrationRetries is the max number of retries allowed in the migration timeout policy.
+const maxMigrationRetries = 10
+
+// validateMigrationTimeoutPolicy validates the migration timeout policy.
+func validateMigrationTimeoutPolicy(policy *v1pb.MigrationTimeoutPolicy) error {
+	if policy == nil {
+		return errors.Errorf("migration timeout policy must be set")
+	}
+	for name, d := range map[string]*durationpb.Duration{
+		"lock_timeout":      policy.LockTimeout,
+		"statement_timeout": policy.StatementTimeout,
+	} {
+		if d == nil {
+			continue
+		}
+		if err := d.CheckValid(); err != nil {
+			return errors.Wrapf(err, "invalid %s", name)
+		}
+		if d.AsDuration() < 0 {
+			return errors.Errorf("%s cannot be negative", name)
+		}
+	}
+	if policy.MaxRetries < 0 || policy.MaxRetries > maxMigrationRetries {
+		return errors.Errorf("max_retries must be between 0 and %d", maxMigrationRetries)
+	}
+	return nil
+}
+
+// validateConcurrencyPolicy validates the concurrency policy.
+func validateConcurrencyPolicy(policy *v1pb.ConcurrencyPolicy) error {
+	if policy == nil {
+		return errors.Errorf("concurrency policy must be set")
+	}
+	if policy.MaxRunningTaskRuns < 0 {
+		return errors.Errorf("max_running_task_runs cannot be negative")
+	}
+	for _, window := range policy.MaintenanceWindows {
+		for _, day := range window.Days {
+			if day < 0 || day > 6 {
+				return errors.Errorf("invalid maintenance window day %d, must be between 0 and 6", day)
+			}
+		}
+		if _, err := time.Parse("15:04", window.StartTime); err != nil {
+			return errors.Errorf("invalid maintenance window start time %q, must be in the format of HH:MM", window.StartTime)
+		}
+		if err := window.Duration.CheckValid(); err != nil {
+			return errors.Wrapf(err, "invalid maintenance window duration")
+		}
+		if d := window.Duration.AsDuration(); d <= 0 || d > 7*24*time.Hour {
+			return errors.Errorf("maintenance window duration must be positive and at most 7 days")
+		}
+		if _, err := time.LoadLocation(window.TimeZone); err != nil {
+			return errors.Errorf("invalid maintenance window time zone %q", window.TimeZone)
+		}
+	}
+	titles := map[string]bool{}
+	for _, group := range policy.ExclusionGroups {
+		if group.Title == "" {
+			return errors.Errorf("exclusion group title cannot be empty")
+		}
+		if titles[group.Title] {
+			return errors.Errorf("duplicate exclusion group title %q", group.Title)
+		}
+		titles[group.Title] = true
+		if group.Scope == v1pb.ExclusionGroup_SCOPE_UNSPECIFIED {
+			return errors.Errorf("exclusion group %q must have scope set", group.Title)
+		}
+		for _, instance := range group.Instances {
+			if _, err := common.GetInstanceID(instance); err != nil {
+				return errors.Wrapf(err, "invalid instance %q in exclusion group %q", instance, group.Title)
+			}
+		}
+	}
+	return nil
+}
+
 // validateSQLReviewPolicy validates the SQL review rule.
 func validateSQLReviewPolicy(policy *v1pb.SQLReviewPolicy) error {
 	if policy.Name == "" || len(policy.Rules) == 0 {
@@ -820,27 +820,9 @@ func (s *ProjectService) UpdateWebhook(ctx context.Context, request *v1pb.Update
 		return nil, status.Errorf(codes.NotFound, "webhook %q not found", request.Webhook.Url)
 	}
 
-	update := &store.UpdateProjectWebhookMessage{}
-	for _, path := range request.UpdateMask.Paths {
-		switch path {
-		case "type":
-			return nil, status.Errorf(codes.InvalidArgument, "type cannot be updated")
-		case "title":
-			update.Title = &request.Webhook.Title
-		case "url":
-			update.URL = &request.Webhook.Url
-		case "notification_type":
-			types, err := convertToActivityTypeStrings(request.Webhook.NotificationTypes)
-			if err != nil {
-				return nil, status.Errorf(codes.InvalidArgument, err.Error())
-			}
-			if len(types) == 0 {
-				return nil, status.Errorf(codes.InvalidArgument, "notification types should not be empty")
-			}
-			update.ActivityList = types
-		default:
-			return nil, status.Errorf(codes.InvalidArgument, "invalid field %q", path)
-		}
+	update, err := convertToUpdateProjectWebhookMessage(request.Webhook, request.UpdateMask.Paths)
+	if err != nil {
+		return nil, err
 	}
 
 	if _, err := s.store.UpdateProjectWebhookV2(ctx, ctx.Value(common.PrincipalIDContextKey).(int), project.ResourceID, webhook.ID, update); err != nil {
@@ -937,7 +919,7 @@ func (s *ProjectService) TestWebhook(ctx context.Context, request *v1pb.TestWebh
 	}
 
 	resp := &v1pb.TestWebhookResponse{}
-	err = webhookPlugin.Post(
+	_, err = webhookPlugin.Post(
 		webhook.Type,
 		webhookPlugin.Context{
 			URL:          webhook.URL,
@@ -951,6 +933,7 @@ func (s *ProjectService) TestWebhook(ctx context.Context, request *v1pb.TestWebh
 			CreatorEmail: api.SystemBotEmail,
 			CreatedTs:    time.Now().Unix(),
 			Project:      &webhookPlugin.Project{Name: project.Title},
+			Secret:       webhook.SigningSecret,
 		},
 	)
 	if err != nil {
@@ -960,6 +943,66 @@ func (s *ProjectService) TestWebhook(ctx context.Context, request *v1pb.TestWebh
 	return resp, nil
 }
 
+// ListWebhookDeliveries lists the recent deliveries of a webhook.
+func (s *ProjectService) ListWebhookDeliveries(ctx context.Context, request *v1pb.ListWebhookDeliveriesRequest) (*v1pb.ListWebhookDeliveriesResponse, error) {
+	projectID, webhookID, err := common.GetProjectIDWebhookID(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	project, webhook, err := s.getProjectWebhook(ctx, projectID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return listWebhookDeliveries(ctx, s.store, fmt.Sprintf("%s%s/%s%d", common.ProjectNamePrefix, project.ResourceID, common.WebhookIDPrefix, webhook.ID), webhook.ID, request)
+}
+
+// RedeliverWebhook schedules a delivery to be delivered again as soon as possible.
+func (s *ProjectService) RedeliverWebhook(ctx context.Context, request *v1pb.RedeliverWebhookRequest) (*v1pb.WebhookDelivery, error) {
+	projectID, webhookID, deliveryID, err := common.GetProjectIDWebhookIDDeliveryID(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	deliveryUID, err := strconv.Atoi(deliveryID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid delivery id %q", deliveryID)
+	}
+	project, webhook, err := s.getProjectWebhook(ctx, projectID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return redeliverWebhook(ctx, s.store, fmt.Sprintf("%s%s/%s%d", common.ProjectNamePrefix, project.ResourceID, common.WebhookIDPrefix, webhook.ID), webhook.ID, deliveryUID)
+}
+
+func (s *ProjectService) getProjectWebhook(ctx context.Context, projectID, webhookID string) (*store.ProjectMessage, *store.ProjectWebhookMessage, error) {
+	webhookIDInt, err := strconv.Atoi(webhookID)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid webhook id %q", webhookID)
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{
+		ResourceID: &projectID,
+	})
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if project == nil {
+		return nil, nil, status.Errorf(codes.NotFound, "project %q not found", projectID)
+	}
+	if project.Deleted {
+		return nil, nil, status.Errorf(codes.NotFound, "project %q has been deleted", projectID)
+	}
+	webhook, err := s.store.GetProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
+		ProjectID: &project.UID,
+		ID:        &webhookIDInt,
+	})
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if webhook == nil {
+		return nil, nil, status.Errorf(codes.NotFound, "webhook %q not found", webhookID)
+	}
+	return project, webhook, nil
+}
+
 func (s *ProjectService) findProjectRepository(ctx context.Context, projectName string) (*store.RepositoryMessage, error) {
 	project, err := s.getProjectMessage(ctx, projectName)
 	if err != nil {
@@ -2311,13 +2354,42 @@ func convertToStoreProjectWebhookMessage(webhook *v1pb.Webhook) (*store.ProjectW
 		return nil, err
 	}
 	return &store.ProjectWebhookMessage{
-		Type:         tp,
-		URL:          webhook.Url,
-		Title:        webhook.Title,
-		ActivityList: activityTypes,
+		Type:          tp,
+		URL:           webhook.Url,
+		Title:         webhook.Title,
+		ActivityList:  activityTypes,
+		SigningSecret: webhook.SigningSecret,
 	}, nil
 }
 
+func convertToUpdateProjectWebhookMessage(webhook *v1pb.Webhook, paths []string) (*store.UpdateProjectWebhookMessage, error) {
+	update := &store.UpdateProjectWebhookMessage{}
+	for _, path := range paths {
+		switch path {
+		case "type":
+			return nil, status.Errorf(codes.InvalidArgument, "type cannot be updated")
+		case "title":
+			update.Title = &webhook.Title
+		case "url":
+			update.URL = &webhook.Url
+		case "notification_type":
+			types, err := convertToActivityTypeStrings(webhook.NotificationTypes)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			if len(types) == 0 {
+				return nil, status.Errorf(codes.InvalidArgument, "notification types should not be empty")
+			}
+			update.ActivityList = types
+		case "signing_secret":
+			update.SigningSecret = &webhook.SigningSecret
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid field %q", path)
+		}
+	}
+	return update, nil
+}
+
 func convertToActivityTypeStrings(types []v1pb.Activity_Type) ([]string, error) {
 	var result []string
 	for _, tp := range types {
@@ -2366,6 +2438,26 @@ func convertToActivityTypeStrings(types []v1pb.Activity_Type) ([]string, error)
 			result = append(result, string(api.ActivitySQLEditorQuery))
 		case v1pb.Activity_TYPE_DATABASE_RECOVERY_PITR_DONE:
 			result = append(result, string(api.ActivityDatabaseRecoveryPITRDone))
+		case v1pb.Activity_TYPE_SQL_EXPORT:
+			result = append(result, string(api.ActivitySQLExport))
+		case v1pb.Activity_TYPE_DATABASE_CREATE:
+			result = append(result, string(api.ActivityDatabaseCreate))
+		case v1pb.Activity_TYPE_DATABASE_DELETE:
+			result = append(result, string(api.ActivityDatabaseDelete))
+		case v1pb.Activity_TYPE_DATABASE_ANOMALY_CREATE:
+			result = append(result, string(api.ActivityAnomalyCreate))
+		case v1pb.Activity_TYPE_INSTANCE_CREATE:
+			result = append(result, string(api.ActivityInstanceCreate))
+		case v1pb.Activity_TYPE_INSTANCE_DELETE:
+			result = append(result, string(api.ActivityInstanceDelete))
+		case v1pb.Activity_TYPE_INSTANCE_UNDELETE:
+			result = append(result, string(api.ActivityInstanceUndelete))
+		case v1pb.Activity_TYPE_POLICY_CREATE:
+			result = append(result, string(api.ActivityPolicyCreate))
+		case v1pb.Activity_TYPE_POLICY_UPDATE:
+			result = append(result, string(api.ActivityPolicyUpdate))
+		case v1pb.Activity_TYPE_POLICY_DELETE:
+			result = append(result, string(api.ActivityPolicyDelete))
 		default:
 			return nil, common.Errorf(common.Invalid, "unsupported activity type: %v", tp)
 		}
@@ -2419,6 +2511,26 @@ func convertNotificationTypeStrings(types []string) []v1pb.Activity_Type {
 			result = append(result, v1pb.Activity_TYPE_SQL_EDITOR_QUERY)
 		case string(api.ActivityDatabaseRecoveryPITRDone):
 			result = append(result, v1pb.Activity_TYPE_DATABASE_RECOVERY_PITR_DONE)
+		case string(api.ActivitySQLExport):
+			result = append(result, v1pb.Activity_TYPE_SQL_EXPORT)
+		case string(api.ActivityDatabaseCreate):
+			result = append(result, v1pb.Activity_TYPE_DATABASE_CREATE)
+		case string(api.ActivityDatabaseDelete):
+			result = append(result, v1pb.Activity_TYPE_DATABASE_DELETE)
+		case string(api.ActivityAnomalyCreate):
+			result = append(result, v1pb.Activity_TYPE_DATABASE_ANOMALY_CREATE)
+		case string(api.ActivityInstanceCreate):
+			result = append(result, v1pb.Activity_TYPE_INSTANCE_CREATE)
+		case string(api.ActivityInstanceDelete):
+			result = append(result, v1pb.Activity_TYPE_INSTANCE_DELETE)
+		case string(api.ActivityInstanceUndelete):
+			result = append(result, v1pb.Activity_TYPE_INSTANCE_UNDELETE)
+		case string(api.ActivityPolicyCreate):
+			result = append(result, v1pb.Activity_TYPE_POLICY_CREATE)
+		case string(api.ActivityPolicyUpdate):
+			result = append(result, v1pb.Activity_TYPE_POLICY_UPDATE)
+		case string(api.ActivityPolicyDelete):
+			result = append(result, v1pb.Activity_TYPE_POLICY_DELETE)
 		default:
 			result = append(result, v1pb.Activity_TYPE_UNSPECIFIED)
 		}
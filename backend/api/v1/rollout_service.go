@@ -18,6 +18,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/activity"
+	"github.com/bytebase/bytebase/backend/component/batchdml"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
@@ -170,8 +171,7 @@ func (s *RolloutService) CreatePlan(ctx context.Context, request *v1pb.CreatePla
 		return nil, status.Errorf(codes.Internal, "failed to create plan check runs, error: %v", err)
 	}
 
-	// Tickle plan check scheduler.
-	s.stateCfg.PlanCheckTickleChan <- 0
+	s.stateCfg.TicklePlanCheck()
 
 	return convertToPlan(plan), nil
 }
@@ -311,8 +311,7 @@ func (s *RolloutService) CreateRollout(ctx context.Context, request *v1pb.Create
 		return nil, status.Errorf(codes.Internal, "failed to convert to rollout, error: %v", err)
 	}
 
-	// Tickle task run scheduler.
-	s.stateCfg.TaskRunTickleChan <- 0
+	s.stateCfg.TickleTaskRun()
 
 	return rolloutV1, nil
 }
@@ -362,8 +361,7 @@ func (s *RolloutService) RunPlanChecks(ctx context.Context, request *v1pb.RunPla
 		return nil, status.Errorf(codes.Internal, "failed to create plan check runs, error: %v", err)
 	}
 
-	// Tickle plan check scheduler.
-	s.stateCfg.PlanCheckTickleChan <- 0
+	s.stateCfg.TicklePlanCheck()
 
 	return &v1pb.RunPlanChecksResponse{}, nil
 }
@@ -395,11 +393,36 @@ func (s *RolloutService) ListTaskRuns(ctx context.Context, request *v1pb.ListTas
 	}
 
 	return &v1pb.ListTaskRunsResponse{
-		TaskRuns:      convertToTaskRuns(s.stateCfg, taskRuns),
+		TaskRuns:      convertToTaskRuns(taskRuns),
 		NextPageToken: "",
 	}, nil
 }
 
+// GetTaskRunLog gets the task run log.
+func (s *RolloutService) GetTaskRunLog(ctx context.Context, request *v1pb.GetTaskRunLogRequest) (*v1pb.TaskRunLog, error) {
+	projectID, rolloutID, _, taskID, taskRunID, err := common.GetProjectIDRolloutIDStageIDTaskIDTaskRunID(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	taskRuns, err := s.store.ListTaskRunsV2(ctx, &store.FindTaskRunMessage{
+		UIDs:        &[]int{taskRunID},
+		TaskUID:     &taskID,
+		PipelineUID: &rolloutID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list task runs, error: %v", err)
+	}
+	if len(taskRuns) == 0 || taskRuns[0].ProjectID != projectID {
+		return nil, status.Errorf(codes.NotFound, "task run %q not found", request.Parent)
+	}
+
+	logs, err := s.store.ListTaskRunLogs(ctx, taskRunID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list task run logs, error: %v", err)
+	}
+	return convertToTaskRunLog(request.Parent, logs), nil
+}
+
 // BatchRunTasks runs tasks in batch.
 func (s *RolloutService) BatchRunTasks(ctx context.Context, request *v1pb.BatchRunTasksRequest) (*v1pb.BatchRunTasksResponse, error) {
 	if len(request.Tasks) == 0 {
@@ -530,8 +553,7 @@ func (s *RolloutService) BatchRunTasks(ctx context.Context, request *v1pb.BatchR
 		slog.Error("failed to batch create activities for running tasks", log.BBError(err))
 	}
 
-	// Tickle task run scheduler.
-	s.stateCfg.TaskRunTickleChan <- 0
+	s.stateCfg.TickleTaskRun()
 
 	return &v1pb.BatchRunTasksResponse{}, nil
 }
@@ -599,6 +621,7 @@ func (s *RolloutService) BatchSkipTasks(ctx context.Context, request *v1pb.Batch
 
 	for _, task := range tasksToSkip {
 		s.stateCfg.TaskSkippedOrDoneChan <- task.ID
+		s.stateCfg.TaskCleanupChan <- task.ID
 	}
 
 	if err := s.activityManager.BatchCreateActivitiesForSkipTasks(ctx, tasksToSkip, issue, request.Reason, updaterID); err != nil {
@@ -1035,6 +1058,9 @@ func validateSteps(steps []*v1pb.Plan_Step) error {
 				} else {
 					return errors.Errorf("unknown target %q", config.Target)
 				}
+				if err := validateBatchConfig(config); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -1047,6 +1073,26 @@ func validateSteps(steps []*v1pb.Plan_Step) error {
 	return nil
 }
 
+func validateBatchConfig(config *v1pb.Plan_ChangeDatabaseConfig) error {
+	c := config.BatchConfig
+	if c == nil {
+		return nil
+	}
+	if config.Type != v1pb.Plan_ChangeDatabaseConfig_DATA {
+		return errors.Errorf("batch config is only supported by the DATA change")
+	}
+	if config.RollbackEnabled {
+		return errors.Errorf("batch config cannot be used with rollback enabled")
+	}
+	if c.BatchSize < 0 || c.BatchSize > batchdml.MaxBatchSize {
+		return errors.Errorf("batch size must be between 0 and %d", batchdml.MaxBatchSize)
+	}
+	if c.Sleep.AsDuration() < 0 || c.MaxReplicaLag.AsDuration() < 0 {
+		return errors.Errorf("batch sleep and max replica lag cannot be negative")
+	}
+	return nil
+}
+
 // GetPipelineCreate gets a pipeline create message from a plan.
 func GetPipelineCreate(ctx context.Context, s *store.Store, licenseService enterpriseAPI.LicenseService, dbFactory *dbfactory.DBFactory, steps []*storepb.PlanConfig_Step, project *store.ProjectMessage) (*store.PipelineMessage, error) {
 	pipelineCreate := &store.PipelineMessage{
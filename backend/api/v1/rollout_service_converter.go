@@ -12,7 +12,6 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bytebase/bytebase/backend/common"
-	"github.com/bytebase/bytebase/backend/component/state"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/store"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -126,10 +125,22 @@ func convertToPlanSpecChangeDatabaseConfig(config *storepb.PlanConfig_Spec_Chang
 			SchemaVersion:   c.SchemaVersion,
 			RollbackEnabled: c.RollbackEnabled,
 			RollbackDetail:  convertToPlanSpecChangeDatabaseConfigRollbackDetail(c.RollbackDetail),
+			BatchConfig:     convertToPlanSpecChangeDatabaseConfigBatchConfig(c.BatchConfig),
 		},
 	}
 }
 
+func convertToPlanSpecChangeDatabaseConfigBatchConfig(c *storepb.PlanConfig_ChangeDatabaseConfig_BatchConfig) *v1pb.Plan_ChangeDatabaseConfig_BatchConfig {
+	if c == nil {
+		return nil
+	}
+	return &v1pb.Plan_ChangeDatabaseConfig_BatchConfig{
+		BatchSize:     c.BatchSize,
+		Sleep:         c.Sleep,
+		MaxReplicaLag: c.MaxReplicaLag,
+	}
+}
+
 func convertToPlanSpecChangeDatabaseConfigRollbackDetail(d *storepb.PlanConfig_ChangeDatabaseConfig_RollbackDetail) *v1pb.Plan_ChangeDatabaseConfig_RollbackDetail {
 	if d == nil {
 		return nil
@@ -253,10 +264,22 @@ func convertPlanSpecChangeDatabaseConfig(config *v1pb.Plan_Spec_ChangeDatabaseCo
 			Type:            storepb.PlanConfig_ChangeDatabaseConfig_Type(c.Type),
 			SchemaVersion:   c.SchemaVersion,
 			RollbackEnabled: c.RollbackEnabled,
+			BatchConfig:     convertPlanSpecChangeDatabaseConfigBatchConfig(c.BatchConfig),
 		},
 	}
 }
 
+func convertPlanSpecChangeDatabaseConfigBatchConfig(c *v1pb.Plan_ChangeDatabaseConfig_BatchConfig) *storepb.PlanConfig_ChangeDatabaseConfig_BatchConfig {
+	if c == nil {
+		return nil
+	}
+	return &storepb.PlanConfig_ChangeDatabaseConfig_BatchConfig{
+		BatchSize:     c.BatchSize,
+		Sleep:         c.Sleep,
+		MaxReplicaLag: c.MaxReplicaLag,
+	}
+}
+
 func convertPlanSpecRestoreDatabaseConfig(config *v1pb.Plan_Spec_RestoreDatabaseConfig) *storepb.PlanConfig_Spec_RestoreDatabaseConfig {
 	c := config.RestoreDatabaseConfig
 	storeConfig := &storepb.PlanConfig_Spec_RestoreDatabaseConfig{
@@ -429,10 +452,10 @@ func convertToPlanCheckRunResultStatus(status storepb.PlanCheckRunResult_Result_
 	return v1pb.PlanCheckRun_Result_STATUS_UNSPECIFIED
 }
 
-func convertToTaskRuns(stateCfg *state.State, taskRuns []*store.TaskRunMessage) []*v1pb.TaskRun {
+func convertToTaskRuns(taskRuns []*store.TaskRunMessage) []*v1pb.TaskRun {
 	var taskRunsV1 []*v1pb.TaskRun
 	for _, taskRun := range taskRuns {
-		taskRunsV1 = append(taskRunsV1, convertToTaskRun(stateCfg, taskRun))
+		taskRunsV1 = append(taskRunsV1, convertToTaskRun(taskRun))
 	}
 	return taskRunsV1
 }
@@ -456,7 +479,33 @@ func convertToTaskRunStatus(status api.TaskRunStatus) v1pb.TaskRun_Status {
 	}
 }
 
-func convertToTaskRun(stateCfg *state.State, taskRun *store.TaskRunMessage) *v1pb.TaskRun {
+func convertToTaskRunLog(parent string, logs []*store.TaskRunLog) *v1pb.TaskRunLog {
+	taskRunLog := &v1pb.TaskRunLog{
+		Name: fmt.Sprintf("%s/log", parent),
+	}
+	for _, l := range logs {
+		entry := &v1pb.TaskRunLogEntry{
+			LogTime: timestamppb.New(time.Unix(l.CreatedTs, 0)),
+		}
+		switch l.Payload.Type {
+		case storepb.TaskRunLog_EXECUTE_ATTEMPT:
+			entry.Type = v1pb.TaskRunLogEntry_EXECUTE_ATTEMPT
+			if a := l.Payload.ExecuteAttempt; a != nil {
+				entry.ExecuteAttempt = &v1pb.TaskRunLogEntry_ExecuteAttempt{
+					Attempt:     a.Attempt,
+					Duration:    a.Duration,
+					Error:       a.Error,
+					LockTimeout: a.LockTimeout,
+					RetryDelay:  a.RetryDelay,
+				}
+			}
+		}
+		taskRunLog.Entries = append(taskRunLog.Entries, entry)
+	}
+	return taskRunLog
+}
+
+func convertToTaskRun(taskRun *store.TaskRunMessage) *v1pb.TaskRun {
 	t := &v1pb.TaskRun{
 		Name:          fmt.Sprintf("%s%s/%s%d/%s%d/%s%d/%s%d", common.ProjectNamePrefix, taskRun.ProjectID, common.RolloutPrefix, taskRun.PipelineUID, common.StagePrefix, taskRun.StageUID, common.TaskPrefix, taskRun.TaskUID, common.TaskRunPrefix, taskRun.ID),
 		Uid:           fmt.Sprintf("%d", taskRun.ID),
@@ -472,10 +521,11 @@ func convertToTaskRun(stateCfg *state.State, taskRun *store.TaskRunMessage) *v1p
 		SchemaVersion: taskRun.ResultProto.Version,
 	}
 
-	if v, ok := stateCfg.TaskRunExecutionStatuses.Load(taskRun.ID); ok {
-		s := v.(state.TaskRunExecutionStatus)
-		t.ExecutionStatus = s.ExecutionStatus
-		t.ExecutionStatusUpdateTime = timestamppb.New(s.UpdateTime)
+	// The execution status is left over if the leader executing the task run exits unexpectedly.
+	if e := taskRun.Execution; e != nil && taskRun.Status == api.TaskRunRunning {
+		t.ExecutionStatus = e.ExecutionStatus
+		t.ExecutionStatusUpdateTime = timestamppb.New(time.Unix(e.UpdatedTs, 0))
+		t.ExecutionDetail = e.ExecutionDetail
 	}
 
 	return t
@@ -79,6 +79,7 @@ func transformDeploymentConfigTargetToSteps(ctx context.Context, s *store.Store,
 						SchemaVersion:   c.SchemaVersion,
 						RollbackEnabled: c.RollbackEnabled,
 						RollbackDetail:  c.RollbackDetail,
+						BatchConfig:     c.BatchConfig,
 					},
 				},
 			})
@@ -445,6 +446,7 @@ func getTaskCreatesFromChangeDatabaseConfigDatabaseTarget(ctx context.Context, s
 			SchemaVersion:     getOrDefaultSchemaVersion(c.SchemaVersion),
 			RollbackEnabled:   c.RollbackEnabled,
 			RollbackSQLStatus: api.RollbackSQLStatusPending,
+			BatchConfig:       convertToTaskBatchConfig(c.BatchConfig),
 		}
 		if c.RollbackDetail != nil {
 			issueID, err := common.GetIssueID(c.RollbackDetail.RollbackFromIssue)
@@ -635,6 +637,7 @@ func getTaskCreatesFromChangeDatabaseConfigDatabaseGroupStatements(db *store.Dat
 				RollbackEnabled:   c.RollbackEnabled,
 				RollbackSQLStatus: api.RollbackSQLStatusPending,
 				SchemaGroupName:   schemaGroupName,
+				BatchConfig:       convertToTaskBatchConfig(c.BatchConfig),
 			}
 
 			bytes, err := json.Marshal(payload)
@@ -1009,3 +1012,14 @@ func getOrDefaultSchemaVersionWithSuffix(v string, suffix string) string {
 	}
 	return common.DefaultMigrationVersion().Version + suffix
 }
+
+func convertToTaskBatchConfig(c *storepb.PlanConfig_ChangeDatabaseConfig_BatchConfig) *api.TaskBatchConfig {
+	if c == nil {
+		return nil
+	}
+	return &api.TaskBatchConfig{
+		BatchSize:       int(c.BatchSize),
+		SleepMs:         c.Sleep.AsDuration().Milliseconds(),
+		MaxReplicaLagMs: c.MaxReplicaLag.AsDuration().Milliseconds(),
+	}
+}
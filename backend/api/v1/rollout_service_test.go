@@ -5,9 +5,11 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
 func TestGetStatementsFromSchemaGroups(t *testing.T) {
@@ -136,3 +138,28 @@ ALTER TABLE singleton ADD COLUMN num INT;`,
 		a.Equal(tc.expectedSchemaGroupNames, schemaGroupNames, tc.name)
 	}
 }
+
+func TestConvertToTaskRunExecution(t *testing.T) {
+	a := require.New(t)
+	taskRun := &store.TaskRunMessage{
+		Status:      api.TaskRunRunning,
+		ResultProto: &storepb.TaskRunResult{},
+		Creator:     &store.UserMessage{},
+		Updater:     &store.UserMessage{},
+		Execution: &store.TaskRunExecutionMessage{
+			ExecutionStatus: v1pb.TaskRun_EXECUTING,
+			ExecutionDetail: &v1pb.TaskRun_ExecutionDetail{TotalBatches: 10, CompletedBatches: 3},
+			UpdatedTs:       1700000000,
+		},
+	}
+	got := convertToTaskRun(taskRun)
+	a.Equal(v1pb.TaskRun_EXECUTING, got.ExecutionStatus)
+	a.Equal(int64(1700000000), got.ExecutionStatusUpdateTime.AsTime().Unix())
+	a.Equal(int64(3), got.ExecutionDetail.CompletedBatches)
+
+	// The execution status left over by the leader exiting unexpectedly is ignored once the task run is not running.
+	taskRun.Status = api.TaskRunCanceled
+	got = convertToTaskRun(taskRun)
+	a.Equal(v1pb.TaskRun_EXECUTION_STATUS_UNSPECIFIED, got.ExecutionStatus)
+	a.Nil(got.ExecutionDetail)
+}
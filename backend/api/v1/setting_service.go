@@ -17,6 +17,7 @@ import (
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protocmp"
 
+	"github.com/bytebase/bytebase/backend/api/scim"
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/state"
@@ -283,6 +284,17 @@ func (s *SettingService) SetSetting(ctx context.Context, request *v1pb.SetSettin
 			return nil, status.Errorf(codes.PermissionDenied, err.Error())
 		}
 		storeSettingValue = request.Setting.Value.GetStringValue()
+	case api.SettingSCIMToken:
+		if err := s.licenseService.IsFeatureEnabled(api.FeatureSSO); err != nil {
+			return nil, status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		// An empty token disables the SCIM provisioning.
+		if token := request.Setting.Value.GetStringValue(); token != "" {
+			if len(token) < scim.MinTokenLength {
+				return nil, status.Errorf(codes.InvalidArgument, "SCIM token must have at least %d characters", scim.MinTokenLength)
+			}
+			storeSettingValue = scim.HashToken(token)
+		}
 	case api.SettingPluginAgent:
 		payload := new(storepb.AgentPluginSetting)
 		if err := convertV1PbToStorePb(request.Setting.Value.GetAgentPluginSettingValue(), payload); err != nil {
@@ -502,6 +514,14 @@ func convertV1PbToStorePb(inputPB, outputPB protoreflect.ProtoMessage) error {
 func (s *SettingService) convertToSettingMessage(ctx context.Context, setting *store.SettingMessage) (*v1pb.Setting, error) {
 	settingName := fmt.Sprintf("%s%s", common.SettingNamePrefix, setting.Name)
 	switch setting.Name {
+	case api.SettingSCIMToken:
+		// SECURITY: We do not expose the SCIM token hash.
+		return &v1pb.Setting{
+			Name: settingName,
+			Value: &v1pb.Value{
+				Value: &v1pb.Value_StringValue{},
+			},
+		}, nil
 	case api.SettingWorkspaceMailDelivery:
 		storeValue := new(storepb.SMTPMailDeliverySetting)
 		if err := protojson.Unmarshal([]byte(setting.Value), storeValue); err != nil {
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -20,7 +21,6 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
-	"github.com/xuri/excelize/v2"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -29,15 +29,19 @@ import (
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/activity"
+	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/metric"
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
 	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	"github.com/bytebase/bytebase/backend/plugin/parser/base"
 	pgparser "github.com/bytebase/bytebase/backend/plugin/parser/pg"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/transform"
+	webhookPlugin "github.com/bytebase/bytebase/backend/plugin/webhook"
 	"github.com/bytebase/bytebase/backend/runner/schemasync"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
@@ -61,6 +65,8 @@ type SQLService struct {
 	dbFactory       *dbfactory.DBFactory
 	activityManager *activity.Manager
 	licenseService  enterpriseAPI.LicenseService
+	profile         *config.Profile
+	queryCursors    *queryCursorManager
 }
 
 // NewSQLService creates a SQLService.
@@ -70,6 +76,7 @@ func NewSQLService(
 	dbFactory *dbfactory.DBFactory,
 	activityManager *activity.Manager,
 	licenseService enterpriseAPI.LicenseService,
+	profile *config.Profile,
 ) *SQLService {
 	return &SQLService{
 		store:           store,
@@ -77,6 +84,8 @@ func NewSQLService(
 		dbFactory:       dbFactory,
 		activityManager: activityManager,
 		licenseService:  licenseService,
+		profile:         profile,
+		queryCursors:    newQueryCursorManager(),
 	}
 }
 
@@ -269,7 +278,10 @@ func (s *SQLService) preAdminExecute(ctx context.Context, request *v1pb.AdminExe
 
 // Export exports the SQL query result.
 func (s *SQLService) Export(ctx context.Context, request *v1pb.ExportRequest) (*v1pb.ExportResponse, error) {
-	user, instance, database, _, _, sensitiveSchemaInfo, err := s.preCheck(ctx, request.Name, request.ConnectionDatabase, request.Statement, request.Limit, false /* isAdmin */, false /* isExport */)
+	if err := validateExportRequest(request); err != nil {
+		return nil, err
+	}
+	user, instance, database, _, _, sensitiveSchemaInfo, err := s.preCheck(ctx, request.Name, request.ConnectionDatabase, request.Statement, request.Limit, request.Admin, false /* isExport */)
 	if err != nil {
 		return nil, err
 	}
@@ -290,21 +302,43 @@ func (s *SQLService) Export(ctx context.Context, request *v1pb.ExportRequest) (*
 		return nil, err
 	}
 
-	bytes, durationNs, exportErr := s.doExport(ctx, request, instance, database, sensitiveSchemaInfo)
+	var buf bytes.Buffer
+	durationNs, exportErr := s.doExportTo(ctx, request, instance, database, sensitiveSchemaInfo, &buf)
 
 	if err := s.postExport(ctx, activity, durationNs, exportErr); err != nil {
 		return nil, err
 	}
+	s.postExportEvent(ctx, user, instance, request, exportErr)
 
 	if exportErr != nil {
 		return nil, exportErr
 	}
 
 	return &v1pb.ExportResponse{
-		Content: bytes,
+		Content: buf.Bytes(),
 	}, nil
 }
 
+// postExportEvent posts the export event to the workspace webhooks.
+func (s *SQLService) postExportEvent(ctx context.Context, user *store.UserMessage, instance *store.InstanceMessage, request *v1pb.ExportRequest, exportErr error) {
+	webhookCtx := &webhookPlugin.Context{
+		ActivityType: string(api.ActivitySQLExport),
+		Level:        webhookPlugin.WebhookInfo,
+		Title:        fmt.Sprintf("SQL exported - %s", instance.Title),
+		Export: &webhookPlugin.Export{
+			Instance:  instance.ResourceID,
+			Database:  request.ConnectionDatabase,
+			Statement: request.Statement,
+			Format:    request.Format.String(),
+		},
+	}
+	if exportErr != nil {
+		webhookCtx.Level = webhookPlugin.WebhookError
+		webhookCtx.Export.Error = exportErr.Error()
+	}
+	s.activityManager.PostWorkspaceEvent(ctx, user.ID, webhookCtx)
+}
+
 func (s *SQLService) postExport(ctx context.Context, activity *store.ActivityMessage, durationNs int64, queryErr error) error {
 	// Update the activity
 	var payload api.ActivitySQLExportPayload
@@ -345,104 +379,6 @@ func (s *SQLService) postExport(ctx context.Context, activity *store.ActivityMes
 	return nil
 }
 
-func (s *SQLService) doExport(ctx context.Context, request *v1pb.ExportRequest, instance *store.InstanceMessage, database *store.DatabaseMessage, sensitiveSchemaInfo *base.SensitiveSchemaInfo) ([]byte, int64, error) {
-	// Don't anonymize data for exporting data using admin mode.
-	if request.Admin {
-		sensitiveSchemaInfo = nil
-	}
-
-	driver, err := s.dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, database)
-	if err != nil {
-		return nil, 0, err
-	}
-	defer driver.Close(ctx)
-
-	sqlDB := driver.GetDB()
-	var conn *sql.Conn
-	if sqlDB != nil {
-		conn, err = sqlDB.Conn(ctx)
-		if err != nil {
-			return nil, 0, err
-		}
-		defer conn.Close()
-	}
-
-	start := time.Now().UnixNano()
-	result, err := driver.QueryConn(ctx, conn, request.Statement, &db.QueryContext{
-		Limit:               int(request.Limit),
-		ReadOnly:            true,
-		CurrentDatabase:     request.ConnectionDatabase,
-		SensitiveSchemaInfo: sensitiveSchemaInfo,
-		EnableSensitive:     s.licenseService.IsFeatureEnabledForInstance(api.FeatureSensitiveData, instance) == nil,
-	})
-	durationNs := time.Now().UnixNano() - start
-	if err != nil {
-		return nil, durationNs, err
-	}
-	if len(result) != 1 {
-		return nil, durationNs, errors.Errorf("expecting 1 result, but got %d", len(result))
-	}
-
-	var content []byte
-	switch request.Format {
-	case v1pb.ExportFormat_CSV:
-		if content, err = exportCSV(result[0]); err != nil {
-			return nil, durationNs, err
-		}
-	case v1pb.ExportFormat_JSON:
-		if content, err = exportJSON(result[0]); err != nil {
-			return nil, durationNs, err
-		}
-	case v1pb.ExportFormat_SQL:
-		resourceList, err := s.extractResourceList(ctx, instance.Engine, request.ConnectionDatabase, request.Statement, instance)
-		if err != nil {
-			return nil, 0, status.Errorf(codes.InvalidArgument, "failed to extract resource list: %v", err)
-		}
-		statementPrefix, err := getSQLStatementPrefix(instance.Engine, resourceList, result[0].ColumnNames)
-		if err != nil {
-			return nil, 0, err
-		}
-		if content, err = exportSQL(instance.Engine, statementPrefix, result[0]); err != nil {
-			return nil, durationNs, err
-		}
-	case v1pb.ExportFormat_XLSX:
-		if content, err = exportXLSX(result[0]); err != nil {
-			return nil, durationNs, err
-		}
-	default:
-		return nil, durationNs, status.Errorf(codes.InvalidArgument, "unsupported export format: %s", request.Format.String())
-	}
-	return content, durationNs, nil
-}
-
-func exportCSV(result *v1pb.QueryResult) ([]byte, error) {
-	var buf bytes.Buffer
-	if _, err := buf.WriteString(strings.Join(result.ColumnNames, ",")); err != nil {
-		return nil, err
-	}
-	if err := buf.WriteByte('\n'); err != nil {
-		return nil, err
-	}
-	for i, row := range result.Rows {
-		for i, value := range row.Values {
-			if i != 0 {
-				if err := buf.WriteByte(','); err != nil {
-					return nil, err
-				}
-			}
-			if _, err := buf.Write(convertValueToBytesInCSV(value)); err != nil {
-				return nil, err
-			}
-		}
-		if i != len(result.Rows)-1 {
-			if err := buf.WriteByte('\n'); err != nil {
-				return nil, err
-			}
-		}
-	}
-	return buf.Bytes(), nil
-}
-
 func convertValueToBytesInCSV(value *v1pb.RowValue) []byte {
 	switch value.Kind.(type) {
 	case *v1pb.RowValue_StringValue:
@@ -517,35 +453,6 @@ func getSQLStatementPrefix(engine storepb.Engine, resourceList []base.SchemaReso
 	return s, nil
 }
 
-func exportSQL(engine storepb.Engine, statementPrefix string, result *v1pb.QueryResult) ([]byte, error) {
-	var buf bytes.Buffer
-	for i, row := range result.Rows {
-		if _, err := buf.WriteString(statementPrefix); err != nil {
-			return nil, err
-		}
-		for i, value := range row.Values {
-			if i != 0 {
-				if err := buf.WriteByte(','); err != nil {
-					return nil, err
-				}
-			}
-			if _, err := buf.Write(convertValueToBytesInSQL(engine, value)); err != nil {
-				return nil, err
-			}
-		}
-		if i != len(result.Rows)-1 {
-			if _, err := buf.WriteString(");\n"); err != nil {
-				return nil, err
-			}
-		} else {
-			if _, err := buf.WriteString(");"); err != nil {
-				return nil, err
-			}
-		}
-	}
-	return buf.Bytes(), nil
-}
-
 func convertValueToBytesInSQL(engine storepb.Engine, value *v1pb.RowValue) []byte {
 	switch value.Kind.(type) {
 	case *v1pb.RowValue_StringValue:
@@ -653,18 +560,6 @@ func convertValueValueToBytes(value *structpb.Value) []byte {
 	}
 }
 
-func exportJSON(result *v1pb.QueryResult) ([]byte, error) {
-	var results []map[string]any
-	for _, row := range result.Rows {
-		m := make(map[string]any)
-		for i, value := range row.Values {
-			m[result.ColumnNames[i]] = convertValueToStringInJSON(value)
-		}
-		results = append(results, m)
-	}
-	return json.MarshalIndent(results, "", "  ")
-}
-
 func convertValueToStringInJSON(value *v1pb.RowValue) string {
 	switch value.Kind.(type) {
 	case *v1pb.RowValue_StringValue:
@@ -701,40 +596,6 @@ const (
 	excelMaxColumn = 18278
 )
 
-func exportXLSX(result *v1pb.QueryResult) ([]byte, error) {
-	f := excelize.NewFile()
-	defer f.Close()
-	index, err := f.NewSheet("Sheet1")
-	if err != nil {
-		return nil, err
-	}
-	var columnPrefixes []string
-	for i, columnName := range result.ColumnNames {
-		columnPrefix, err := getExcelColumnName(i)
-		if err != nil {
-			return nil, err
-		}
-		columnPrefixes = append(columnPrefixes, columnPrefix)
-		if err := f.SetCellValue(sheet1Name, fmt.Sprintf("%s1", columnPrefix), columnName); err != nil {
-			return nil, err
-		}
-	}
-	for i, row := range result.Rows {
-		for j, value := range row.Values {
-			columnName := fmt.Sprintf("%s%d", columnPrefixes[j], i+2)
-			if err := f.SetCellValue("Sheet1", columnName, convertValueToStringInXLSX(value)); err != nil {
-				return nil, err
-			}
-		}
-	}
-	f.SetActiveSheet(index)
-	excelBytes, err := f.WriteToBuffer()
-	if err != nil {
-		return nil, err
-	}
-	return excelBytes.Bytes(), nil
-}
-
 func getExcelColumnName(index int) (string, error) {
 	if index >= excelMaxColumn {
 		return "", errors.Errorf("index cannot be greater than %v (column ZZZ)", excelMaxColumn)
@@ -868,6 +729,10 @@ func (s *SQLService) Check(ctx context.Context, request *v1pb.CheckRequest) (*v1
 //  2. do query
 //  3. post-query
 func (s *SQLService) Query(ctx context.Context, request *v1pb.QueryRequest) (*v1pb.QueryResponse, error) {
+	// The cursor is held by this replica, but FetchMore may be served by another replica in HA mode.
+	if request.PageSize > 0 && s.profile.HA {
+		return nil, status.Errorf(codes.FailedPrecondition, "paginated query is not supported in HA mode")
+	}
 	user, instance, database, adviceStatus, adviceList, sensitiveSchemaInfo, err := s.preCheck(ctx, request.Name, request.ConnectionDatabase, request.Statement, request.Limit, false /* isAdmin */, false /* isExport */)
 	if err != nil {
 		return nil, err
@@ -900,7 +765,11 @@ func (s *SQLService) Query(ctx context.Context, request *v1pb.QueryRequest) (*v1
 	var queryErr error
 	var durationNs int64
 	if adviceStatus != advisor.Error {
-		results, durationNs, queryErr = s.doQuery(ctx, request, instance, database, sensitiveSchemaInfo)
+		if request.PageSize > 0 {
+			results, durationNs, queryErr = s.doPaginatedQuery(ctx, request, user, instance, database, sensitiveSchemaInfo)
+		} else {
+			results, durationNs, queryErr = s.doQuery(ctx, request, instance, database, sensitiveSchemaInfo)
+		}
 	}
 
 	err = s.postQuery(ctx, activity, durationNs, queryErr)
@@ -911,6 +780,7 @@ func (s *SQLService) Query(ctx context.Context, request *v1pb.QueryRequest) (*v1
 	if queryErr != nil {
 		return nil, queryErr
 	}
+	metric.AddSQLQuery(instance.Engine.String(), metric.SQLQueryKindQuery, countResultRows(results))
 
 	// AllowExport is a validate only check.
 	_, _, _, _, _, _, err = s.preCheck(ctx, request.Name, request.ConnectionDatabase, request.Statement, request.Limit, false /* isAdmin */, true /* isExport */)
@@ -922,6 +792,11 @@ func (s *SQLService) Query(ctx context.Context, request *v1pb.QueryRequest) (*v1
 	}
 
 	if proto.Size(response) > maximumSQLResultSize {
+		for _, result := range response.Results {
+			if result.NextCursor != "" {
+				s.queryCursors.remove(result.NextCursor)
+			}
+		}
 		response.Results = []*v1pb.QueryResult{
 			{
 				Error: fmt.Sprintf("Output of query exceeds max allowed output size of %dMB", maximumSQLResultSize/1024/1024),
@@ -1021,6 +896,15 @@ func (s *SQLService) doQuery(ctx context.Context, request *v1pb.QueryRequest, in
 	return results, time.Now().UnixNano() - start, err
 }
 
+// countResultRows counts the rows in the results.
+func countResultRows(results []*v1pb.QueryResult) int64 {
+	var count int64
+	for _, result := range results {
+		count += int64(len(result.Rows))
+	}
+	return count
+}
+
 // sanitizeResults sanitizes the strings in the results by replacing all the invalid UTF-8 characters with its hexadecimal representation.
 func sanitizeResults(results []*v1pb.QueryResult) {
 	for _, result := range results {
@@ -1034,6 +918,14 @@ func sanitizeResults(results []*v1pb.QueryResult) {
 	}
 }
 
+// checkAdminMode checks if the caller is admin for exporting with admin mode, in which the data is not masked.
+func checkAdminMode(user *store.UserMessage, isAdmin bool) error {
+	if isAdmin && user.Role != api.Owner && user.Role != api.DBA {
+		return status.Errorf(codes.PermissionDenied, "only workspace owner and DBA can export data using admin mode")
+	}
+	return nil
+}
+
 // preCheck does the following:
 //  1. Validate the request.
 //     i. Check if the instance exists.
@@ -1065,12 +957,12 @@ func (s *SQLService) preCheck(ctx context.Context, instanceName, connectionDatab
 		dataShare = maybeDatabase.DataShare
 	}
 
-	if s.licenseService.IsFeatureEnabled(api.FeatureAccessControl) == nil {
-		// Check if the caller is admin for exporting with admin mode.
-		if isAdmin && (user.Role != api.Owner && user.Role != api.DBA) {
-			return nil, nil, nil, advisor.Success, nil, nil, status.Errorf(codes.PermissionDenied, "only workspace owner and DBA can export data using admin mode")
-		}
+	// The admin mode skips the data masking, which doesn't depend on the access control.
+	if err := checkAdminMode(user, isAdmin); err != nil {
+		return nil, nil, nil, advisor.Success, nil, nil, err
+	}
 
+	if s.licenseService.IsFeatureEnabled(api.FeatureAccessControl) == nil {
 		// Check if the environment is open for query privileges.
 		result, err := s.checkWorkspaceIAMPolicy(ctx, environment, isExport)
 		if err != nil {
@@ -1131,6 +1023,8 @@ func (s *SQLService) preCheck(ctx context.Context, instanceName, connectionDatab
 			for _, resource := range resources {
 				databaseMap[resource.Database] = true
 			}
+		case storepb.Engine_MONGODB, storepb.Engine_REDIS:
+			databaseMap[connectionDatabase] = true
 		case storepb.Engine_MSSQL:
 			resources, err := base.ExtractResourceList(storepb.Engine_MSSQL, connectionDatabase, "dbo", statement)
 			if err != nil {
@@ -1233,9 +1127,21 @@ func (s *SQLService) getSensitiveSchemaInfo(ctx context.Context, instance *store
 	// we store the projectResourceID - maskingExceptionPolicy in a map.
 	maskingExceptionPolicyMap := make(map[string]*storepb.MaskingExceptionPolicy)
 
+	semanticTypeSetting, err := s.store.GetSemanticTypesSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find semantic types setting")
+	}
+
+	maskingAlgorithmSetting, err := s.store.GetMaskingAlgorithmSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find masking algorithm setting")
+	}
+
 	m := newEmptyMaskingLevelEvaluator().
 		withMaskingRulePolicy(maskingRulePolicy).
-		withDataClassificationSetting(classificationSetting)
+		withDataClassificationSetting(classificationSetting).
+		withSemanticTypeSetting(semanticTypeSetting).
+		withMaskingAlgorithmSetting(maskingAlgorithmSetting)
 
 	for _, name := range databaseList {
 		databaseName := name
@@ -1342,6 +1248,8 @@ func (s *SQLService) getSensitiveSchemaInfo(ctx context.Context, instance *store
 			return nil, status.Errorf(codes.Internal, "Failed to find schema for database %q in instance %q: %v", databaseName, instance.Title, err)
 		}
 
+		semanticTypeIDMap := buildSemanticTypeIDMap(dbSchema.Config)
+
 		if instance.Engine == storepb.Engine_ORACLE || instance.Engine == storepb.Engine_DM {
 			for _, schema := range dbSchema.Metadata.Schemas {
 				databaseSchema := base.DatabaseSchema{
@@ -1368,7 +1276,7 @@ func (s *SQLService) getSensitiveSchemaInfo(ctx context.Context, instance *store
 						}
 						tableSchema.ColumnList = append(tableSchema.ColumnList, base.ColumnInfo{
 							Name:              column.Name,
-							MaskingAttributes: base.NewMaskingAttributes(maskingLevel),
+							MaskingAttributes: base.NewMaskingAttributesWithMasker(maskingLevel, m.getMaskerOfSemanticType(semanticTypeIDMap[maskingPolicyKey{schema: schema.Name, table: table.Name, column: column.Name}], maskingLevel)),
 						})
 					}
 					schemaSchema.TableList = append(schemaSchema.TableList, tableSchema)
@@ -1379,6 +1287,45 @@ func (s *SQLService) getSensitiveSchemaInfo(ctx context.Context, instance *store
 			continue
 		}
 
+		if instance.Engine == storepb.Engine_MONGODB || instance.Engine == storepb.Engine_REDIS {
+			// There is no column metadata for MongoDB and Redis, so we only evaluate the columns in the masking policy and the database config.
+			// For Redis, the table name is the key and the column name is ignored.
+			databaseSchema := base.DatabaseSchema{
+				Name:       databaseName,
+				SchemaList: []base.SchemaSchema{},
+			}
+			for _, schemalessSchema := range getSchemalessSchemaList(maskingPolicyMap, semanticTypeIDMap) {
+				schemaSchema := base.SchemaSchema{
+					Name:      schemalessSchema.Name,
+					TableList: []base.TableSchema{},
+				}
+				for _, table := range schemalessSchema.TableList {
+					tableSchema := base.TableSchema{
+						Name:       table.Name,
+						ColumnList: []base.ColumnInfo{},
+					}
+					for _, column := range table.ColumnList {
+						maskingLevel, err := m.evaluateMaskingLevelOfColumn(database, schemaSchema.Name, table.Name, column.Name, "" /* classification */, project.DataClassificationConfigID, maskingPolicyMap, maskingExceptionContainsCurrentPrincipal)
+						if err != nil {
+							return nil, errors.Wrapf(err, "failed to evaluate masking level of database %q, schema %q, table %q, column %q", databaseName, schemaSchema.Name, table.Name, column.Name)
+						}
+						sensitive := maskingLevel == storepb.MaskingLevel_FULL || maskingLevel == storepb.MaskingLevel_PARTIAL
+						if sensitive {
+							isEmpty = false
+						}
+						tableSchema.ColumnList = append(tableSchema.ColumnList, base.ColumnInfo{
+							Name:              column.Name,
+							MaskingAttributes: base.NewMaskingAttributesWithMasker(maskingLevel, m.getMaskerOfSemanticType(semanticTypeIDMap[maskingPolicyKey{schema: schemaSchema.Name, table: table.Name, column: column.Name}], maskingLevel)),
+						})
+					}
+					schemaSchema.TableList = append(schemaSchema.TableList, tableSchema)
+				}
+				databaseSchema.SchemaList = append(databaseSchema.SchemaList, schemaSchema)
+			}
+			result.DatabaseList = append(result.DatabaseList, databaseSchema)
+			continue
+		}
+
 		databaseSchema := base.DatabaseSchema{
 			Name:       databaseName,
 			SchemaList: []base.SchemaSchema{},
@@ -1405,7 +1352,7 @@ func (s *SQLService) getSensitiveSchemaInfo(ctx context.Context, instance *store
 					}
 					tableSchema.ColumnList = append(tableSchema.ColumnList, base.ColumnInfo{
 						Name:              column.Name,
-						MaskingAttributes: base.NewMaskingAttributes(maskingLevel),
+						MaskingAttributes: base.NewMaskingAttributesWithMasker(maskingLevel, m.getMaskerOfSemanticType(semanticTypeIDMap[maskingPolicyKey{schema: schema.Name, table: table.Name, column: column.Name}], maskingLevel)),
 					})
 				}
 				schemaSchema.TableList = append(schemaSchema.TableList, tableSchema)
@@ -1430,14 +1377,77 @@ func (s *SQLService) getSensitiveSchemaInfo(ctx context.Context, instance *store
 	return result, nil
 }
 
+// buildSemanticTypeIDMap returns the map from the column to its semantic type id in the database config.
+func buildSemanticTypeIDMap(config *storepb.DatabaseConfig) map[maskingPolicyKey]string {
+	semanticTypeIDMap := make(map[maskingPolicyKey]string)
+	for _, schemaConfig := range config.GetSchemaConfigs() {
+		for _, tableConfig := range schemaConfig.TableConfigs {
+			for _, columnConfig := range tableConfig.ColumnConfigs {
+				if columnConfig.SemanticTypeId == "" {
+					continue
+				}
+				semanticTypeIDMap[maskingPolicyKey{
+					schema: schemaConfig.Name,
+					table:  tableConfig.Name,
+					column: columnConfig.Name,
+				}] = columnConfig.SemanticTypeId
+			}
+		}
+	}
+	return semanticTypeIDMap
+}
+
+// getSchemalessSchemaList returns the schemas, tables and columns appearing in the masking policy or the database config, ordered by name.
+// It's used for the databases without column metadata, such as MongoDB.
+func getSchemalessSchemaList(maskingPolicyMap map[maskingPolicyKey]*storepb.MaskData, semanticTypeIDMap map[maskingPolicyKey]string) []base.SchemaSchema {
+	keySet := make(map[maskingPolicyKey]bool)
+	for key := range maskingPolicyMap {
+		keySet[key] = true
+	}
+	for key := range semanticTypeIDMap {
+		keySet[key] = true
+	}
+	var keys []maskingPolicyKey
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].schema != keys[j].schema {
+			return keys[i].schema < keys[j].schema
+		}
+		if keys[i].table != keys[j].table {
+			return keys[i].table < keys[j].table
+		}
+		return keys[i].column < keys[j].column
+	})
+
+	var schemaList []base.SchemaSchema
+	for _, key := range keys {
+		if len(schemaList) == 0 || schemaList[len(schemaList)-1].Name != key.schema {
+			schemaList = append(schemaList, base.SchemaSchema{Name: key.schema})
+		}
+		schema := &schemaList[len(schemaList)-1]
+		if len(schema.TableList) == 0 || schema.TableList[len(schema.TableList)-1].Name != key.table {
+			schema.TableList = append(schema.TableList, base.TableSchema{Name: key.table})
+		}
+		table := &schema.TableList[len(schema.TableList)-1]
+		table.ColumnList = append(table.ColumnList, base.ColumnInfo{Name: key.column})
+	}
+	return schemaList
+}
+
 type maskingLevelEvaluator struct {
 	maskingRules            []*storepb.MaskingRulePolicy_MaskingRule
 	dataClassificationIDMap map[string]*storepb.DataClassificationSetting_DataClassificationConfig
+	semanticTypeIDMap       map[string]*storepb.SemanticTypeSetting_SemanticType
+	maskingAlgorithmIDMap   map[string]*storepb.MaskingAlgorithmSetting_Algorithm
 }
 
 func newEmptyMaskingLevelEvaluator() *maskingLevelEvaluator {
 	return &maskingLevelEvaluator{
 		dataClassificationIDMap: make(map[string]*storepb.DataClassificationSetting_DataClassificationConfig),
+		semanticTypeIDMap:       make(map[string]*storepb.SemanticTypeSetting_SemanticType),
+		maskingAlgorithmIDMap:   make(map[string]*storepb.MaskingAlgorithmSetting_Algorithm),
 	}
 }
 
@@ -1462,6 +1472,49 @@ func (m *maskingLevelEvaluator) withDataClassificationSetting(dataClassification
 	return m
 }
 
+func (m *maskingLevelEvaluator) withSemanticTypeSetting(semanticTypeSetting *storepb.SemanticTypeSetting) *maskingLevelEvaluator {
+	if semanticTypeSetting == nil {
+		return m
+	}
+	for _, semanticType := range semanticTypeSetting.Types {
+		m.semanticTypeIDMap[semanticType.Id] = semanticType
+	}
+	return m
+}
+
+func (m *maskingLevelEvaluator) withMaskingAlgorithmSetting(maskingAlgorithmSetting *storepb.MaskingAlgorithmSetting) *maskingLevelEvaluator {
+	if maskingAlgorithmSetting == nil {
+		return m
+	}
+	for _, algorithm := range maskingAlgorithmSetting.Algorithms {
+		m.maskingAlgorithmIDMap[algorithm.Id] = algorithm
+	}
+	return m
+}
+
+// getMaskerOfSemanticType returns the masker of the masking algorithm configured in the semantic type for the masking level.
+// It returns nil if there is no such algorithm, and the caller should fall back to the default masker of the masking level.
+func (m *maskingLevelEvaluator) getMaskerOfSemanticType(semanticTypeID string, maskingLevel storepb.MaskingLevel) masker.Masker {
+	semanticType, ok := m.semanticTypeIDMap[semanticTypeID]
+	if !ok {
+		return nil
+	}
+	var algorithmID string
+	switch maskingLevel {
+	case storepb.MaskingLevel_FULL:
+		algorithmID = semanticType.FullMaskAlgorithmId
+	case storepb.MaskingLevel_PARTIAL:
+		algorithmID = semanticType.PartialMaskAlgorithmId
+	default:
+		return nil
+	}
+	algorithm, ok := m.maskingAlgorithmIDMap[algorithmID]
+	if !ok {
+		return nil
+	}
+	return masker.NewMasker(algorithm)
+}
+
 func (m *maskingLevelEvaluator) getDataClassificationConfig(classificationID string) *storepb.DataClassificationSetting_DataClassificationConfig {
 	return m.dataClassificationIDMap[classificationID]
 }
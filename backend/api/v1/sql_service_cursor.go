@@ -0,0 +1,385 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/metric"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	"github.com/bytebase/bytebase/backend/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+const (
+	// queryCursorIdleTimeout is the time after which the query cursor is closed if it's not fetched.
+	queryCursorIdleTimeout = 5 * time.Minute
+	// maxQueryCursorsPerUser is the maximum number of the open query cursors of a user.
+	// Each cursor holds a database connection.
+	maxQueryCursorsPerUser = 5
+)
+
+// FetchMore fetches the next page of the rows of a paginated query.
+func (s *SQLService) FetchMore(ctx context.Context, request *v1pb.FetchMoreRequest) (*v1pb.FetchMoreResponse, error) {
+	principalID, ok := ctx.Value(common.PrincipalIDContextKey).(int)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "principal ID not found")
+	}
+	cursor, ok := s.queryCursors.get(request.Cursor, principalID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "cursor %q not found, it may have expired", request.Cursor)
+	}
+
+	cursor.mu.Lock()
+	defer cursor.mu.Unlock()
+	// Stop the idle timer during the fetch. The cursor is being closed if the timer has fired.
+	if !cursor.timer.Stop() {
+		return nil, status.Errorf(codes.NotFound, "cursor %q not found, it may have expired", request.Cursor)
+	}
+	result, more, err := cursor.fetch()
+	if err != nil || !more {
+		s.queryCursors.remove(cursor.id)
+	} else {
+		cursor.timer.Reset(queryCursorIdleTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	metric.AddSQLQueryRows(cursor.engine, metric.SQLQueryKindQuery, int64(len(result.Rows)))
+	if more {
+		result.NextCursor = cursor.id
+	}
+	sanitizeResults([]*v1pb.QueryResult{result})
+
+	response := &v1pb.FetchMoreResponse{
+		Result: result,
+	}
+	if proto.Size(response) > maximumSQLResultSize {
+		s.queryCursors.remove(cursor.id)
+		return nil, status.Errorf(codes.ResourceExhausted, "Output of query exceeds max allowed output size of %dMB, please use a smaller page size", maximumSQLResultSize/1024/1024)
+	}
+	return response, nil
+}
+
+// doPaginatedQuery runs the query with a cursor, and returns the first page of the rows.
+// The cursor holds the database connection until all the rows are fetched, the idle timeout or the query timeout.
+func (s *SQLService) doPaginatedQuery(ctx context.Context, request *v1pb.QueryRequest, user *store.UserMessage, instance *store.InstanceMessage, database *store.DatabaseMessage, sensitiveSchemaInfo *base.SensitiveSchemaInfo) ([]*v1pb.QueryResult, int64, error) {
+	if singleSQLs, err := base.SplitMultiSQL(instance.Engine, request.Statement); err == nil {
+		count := 0
+		for _, singleSQL := range singleSQLs {
+			if !singleSQL.Empty {
+				count++
+			}
+		}
+		if count > 1 {
+			return nil, 0, status.Errorf(codes.InvalidArgument, "paginated query can only have one statement, but got %d", count)
+		}
+	}
+	if s.queryCursors.count(user.ID) >= maxQueryCursorsPerUser {
+		return nil, 0, status.Errorf(codes.ResourceExhausted, "too many open cursors, the cursors are closed after fetching all the rows or being idle for %v", queryCursorIdleTimeout)
+	}
+
+	driver, err := s.dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return nil, 0, err
+	}
+	var conn *sql.Conn
+	if sqlDB := driver.GetDB(); sqlDB != nil {
+		conn, err = sqlDB.Conn(ctx)
+		if err != nil {
+			driver.Close(ctx)
+			return nil, 0, err
+		}
+	}
+
+	timeout := defaultTimeout
+	if request.Timeout != nil {
+		timeout = request.Timeout.AsDuration()
+	}
+	// The cursor outlives the request, and the timeout applies to the whole lifetime of the cursor.
+	cursorCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	cursor := &queryCursor{
+		id:       uuid.NewString(),
+		userID:   user.ID,
+		engine:   instance.Engine.String(),
+		pageSize: int(request.PageSize),
+		ctx:      cursorCtx,
+		cancel:   cancel,
+		pages:    make(chan *queryPage),
+		next:     make(chan struct{}),
+	}
+	go cursor.run(driver, conn, request.Statement, &db.QueryContext{
+		Limit:               int(request.Limit),
+		ReadOnly:            true,
+		CurrentDatabase:     request.ConnectionDatabase,
+		SensitiveSchemaInfo: sensitiveSchemaInfo,
+		EnableSensitive:     s.licenseService.IsFeatureEnabledForInstance(api.FeatureSensitiveData, instance) == nil,
+		EngineVersion:       instance.EngineVersion,
+	})
+
+	start := time.Now()
+	result, more, err := cursor.fetch()
+	durationNs := time.Since(start).Nanoseconds()
+	if err != nil {
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, durationNs, errors.Errorf("timeout reached: %v", timeout)
+		}
+		return nil, durationNs, err
+	}
+	if more {
+		if err := s.queryCursors.add(cursor); err != nil {
+			cancel()
+			return nil, durationNs, err
+		}
+		result.NextCursor = cursor.id
+	} else {
+		cancel()
+	}
+	result.Latency = durationpb.New(time.Duration(durationNs))
+	if result.Statement == "" {
+		result.Statement = request.Statement
+	}
+	results := []*v1pb.QueryResult{result}
+	sanitizeResults(results)
+	return results, durationNs, nil
+}
+
+// queryPage is a page of the rows read by the query of the cursor.
+type queryPage struct {
+	result *v1pb.QueryResult
+	rows   []*v1pb.QueryRow
+
+	// done is true after the query returns, and results and err are the return values of the query.
+	done    bool
+	results []*v1pb.QueryResult
+	err     error
+}
+
+// queryCursor holds the database connection of a paginated query, and reads the next page of the rows on fetch.
+// The cursors are held in memory, so that FetchMore must be served by the same replica as the query,
+// and the paginated query is rejected in HA mode.
+type queryCursor struct {
+	id       string
+	userID   int
+	engine   string
+	pageSize int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	// The query sends a page to pages, and waits on next before reading the next page.
+	pages chan *queryPage
+	next  chan struct{}
+	// header is the result of the query with the columns but no rows.
+	header  *v1pb.QueryResult
+	started bool
+	// rows are the remaining rows if the driver returns all the rows at once instead of page by page.
+	rows []*v1pb.QueryRow
+
+	// mu serializes the fetches.
+	mu    sync.Mutex
+	timer *time.Timer
+}
+
+// run runs the query and sends the pages of the rows to the cursor.
+// The rows are masked by the driver while they are read, in the same way for all the pages.
+func (c *queryCursor) run(driver db.Driver, conn *sql.Conn, statement string, queryContext *db.QueryContext) {
+	defer close(c.pages)
+	defer driver.Close(context.Background())
+	if conn != nil {
+		defer conn.Close()
+	}
+
+	var cursorResult *v1pb.QueryResult
+	queryContext.RowBatchSize = c.pageSize
+	queryContext.RowBatchHandler = func(result *v1pb.QueryResult, rows []*v1pb.QueryRow) error {
+		if cursorResult == nil {
+			cursorResult = result
+		} else if cursorResult != result {
+			return errors.Errorf("paginated query can only have one statement")
+		}
+		select {
+		case c.pages <- &queryPage{result: result, rows: rows}:
+		case <-c.ctx.Done():
+			return c.ctx.Err()
+		}
+		select {
+		case <-c.next:
+			return nil
+		case <-c.ctx.Done():
+			return c.ctx.Err()
+		}
+	}
+	results, err := driver.QueryConn(c.ctx, conn, statement, queryContext)
+	select {
+	case c.pages <- &queryPage{done: true, results: results, err: err}:
+	case <-c.ctx.Done():
+	}
+}
+
+// fetch returns the next page of the rows, and whether there may be more rows.
+func (c *queryCursor) fetch() (*v1pb.QueryResult, bool, error) {
+	if c.rows != nil {
+		return c.fetchInMemory(), len(c.rows) > 0, nil
+	}
+
+	page, err := c.readPage()
+	if err != nil {
+		return nil, false, err
+	}
+	if page.done {
+		if page.err != nil {
+			return nil, false, page.err
+		}
+		if len(page.results) != 1 {
+			return nil, false, errors.Errorf("expecting 1 result, but got %d", len(page.results))
+		}
+		result := page.results[0]
+		if c.header == nil {
+			// The driver returns all the rows at once, so we page through the rows in memory.
+			c.header = result
+			c.rows = result.Rows
+			first := c.fetchInMemory()
+			first.Error = result.Error
+			return first, len(c.rows) > 0, nil
+		}
+		// The previous page is the last page.
+		last := newQueryPageResult(c.header, nil)
+		last.Error = result.Error
+		return last, false, nil
+	}
+
+	c.header = page.result
+	result := newQueryPageResult(page.result, page.rows)
+	if len(page.rows) == c.pageSize {
+		return result, true, nil
+	}
+	// A partial page is the last page, so finish the query to release the connection now.
+	last, err := c.readPage()
+	if err != nil {
+		return nil, false, err
+	}
+	if last.err != nil {
+		return nil, false, last.err
+	}
+	if len(last.results) == 1 {
+		result.Error = last.results[0].Error
+	}
+	return result, false, nil
+}
+
+// readPage reads the next page from the query.
+func (c *queryCursor) readPage() (*queryPage, error) {
+	if c.started {
+		// Let the query read the next page.
+		select {
+		case c.next <- struct{}{}:
+		case <-c.ctx.Done():
+			return nil, c.ctx.Err()
+		}
+	}
+	c.started = true
+	page, ok := <-c.pages
+	if !ok {
+		if err := c.ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.Errorf("cursor is closed")
+	}
+	return page, nil
+}
+
+func (c *queryCursor) fetchInMemory() *v1pb.QueryResult {
+	n := min(c.pageSize, len(c.rows))
+	result := newQueryPageResult(c.header, c.rows[:n])
+	c.rows = c.rows[n:]
+	return result
+}
+
+func newQueryPageResult(header *v1pb.QueryResult, rows []*v1pb.QueryRow) *v1pb.QueryResult {
+	return &v1pb.QueryResult{
+		ColumnNames:     header.ColumnNames,
+		ColumnTypeNames: header.ColumnTypeNames,
+		Rows:            rows,
+		Masked:          header.Masked,
+		Sensitive:       header.Sensitive,
+		Statement:       header.Statement,
+	}
+}
+
+// queryCursorManager manages the open query cursors.
+type queryCursorManager struct {
+	sync.Mutex
+	cursors map[string]*queryCursor
+}
+
+func newQueryCursorManager() *queryCursorManager {
+	return &queryCursorManager{
+		cursors: make(map[string]*queryCursor),
+	}
+}
+
+// add adds the cursor if the user doesn't have too many open cursors, and closes it after the idle timeout.
+func (m *queryCursorManager) add(cursor *queryCursor) error {
+	m.Lock()
+	defer m.Unlock()
+	if m.countLocked(cursor.userID) >= maxQueryCursorsPerUser {
+		return status.Errorf(codes.ResourceExhausted, "too many open cursors, the cursors are closed after fetching all the rows or being idle for %v", queryCursorIdleTimeout)
+	}
+	m.cursors[cursor.id] = cursor
+	cursor.timer = time.AfterFunc(queryCursorIdleTimeout, func() {
+		m.remove(cursor.id)
+	})
+	return nil
+}
+
+// get gets the cursor of the user.
+func (m *queryCursorManager) get(id string, userID int) (*queryCursor, bool) {
+	m.Lock()
+	defer m.Unlock()
+	cursor, ok := m.cursors[id]
+	if !ok || cursor.userID != userID {
+		return nil, false
+	}
+	return cursor, true
+}
+
+// remove closes the cursor and removes it.
+func (m *queryCursorManager) remove(id string) {
+	m.Lock()
+	defer m.Unlock()
+	cursor, ok := m.cursors[id]
+	if !ok {
+		return
+	}
+	delete(m.cursors, id)
+	cursor.timer.Stop()
+	cursor.cancel()
+}
+
+func (m *queryCursorManager) count(userID int) int {
+	m.Lock()
+	defer m.Unlock()
+	return m.countLocked(userID)
+}
+
+func (m *queryCursorManager) countLocked(userID int) int {
+	count := 0
+	for _, cursor := range m.cursors {
+		if cursor.userID == userID {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bytebase/bytebase/backend/component/config"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+type fakeQueryDriver struct {
+	db.Driver
+	rows []*v1pb.QueryRow
+	// batched is true if the driver passes the rows to the row batch handler.
+	batched bool
+}
+
+func (d *fakeQueryDriver) QueryConn(_ context.Context, _ *sql.Conn, statement string, queryContext *db.QueryContext) ([]*v1pb.QueryResult, error) {
+	result := &v1pb.QueryResult{
+		ColumnNames:     []string{"id"},
+		ColumnTypeNames: []string{"INT"},
+		Statement:       statement,
+	}
+	if !d.batched {
+		result.Rows = d.rows
+		return []*v1pb.QueryResult{result}, nil
+	}
+	for i := 0; i < len(d.rows); i += queryContext.RowBatchSize {
+		if err := queryContext.RowBatchHandler(result, d.rows[i:min(i+queryContext.RowBatchSize, len(d.rows))]); err != nil {
+			return nil, err
+		}
+	}
+	return []*v1pb.QueryResult{result}, nil
+}
+
+func (*fakeQueryDriver) Close(context.Context) error {
+	return nil
+}
+
+func TestQueryCursorFetch(t *testing.T) {
+	tests := []struct {
+		rows     int
+		batched  bool
+		pageSize int
+		want     []int
+	}{
+		{rows: 5, batched: true, pageSize: 2, want: []int{2, 2, 1}},
+		{rows: 4, batched: true, pageSize: 2, want: []int{2, 2, 0}},
+		{rows: 1, batched: true, pageSize: 2, want: []int{1}},
+		{rows: 5, batched: false, pageSize: 2, want: []int{2, 2, 1}},
+		{rows: 0, batched: false, pageSize: 2, want: []int{0}},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		var rows []*v1pb.QueryRow
+		for i := 0; i < test.rows; i++ {
+			rows = append(rows, &v1pb.QueryRow{Values: []*v1pb.RowValue{{Kind: &v1pb.RowValue_Int64Value{Int64Value: int64(i)}}}})
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cursor := &queryCursor{
+			pageSize: test.pageSize,
+			ctx:      ctx,
+			cancel:   cancel,
+			pages:    make(chan *queryPage),
+			next:     make(chan struct{}),
+		}
+		go cursor.run(&fakeQueryDriver{rows: rows, batched: test.batched}, nil, "SELECT id FROM t", &db.QueryContext{})
+
+		var got []int
+		var fetched []*v1pb.QueryRow
+		for more := true; more; {
+			result, hasMore, err := cursor.fetch()
+			a.NoError(err)
+			a.Equal([]string{"id"}, result.ColumnNames)
+			a.Equal("SELECT id FROM t", result.Statement)
+			got = append(got, len(result.Rows))
+			fetched = append(fetched, result.Rows...)
+			more = hasMore
+		}
+		cancel()
+		a.Equal(test.want, got)
+		a.Equal(rows, fetched)
+	}
+}
+
+func TestQueryCursorManager(t *testing.T) {
+	a := require.New(t)
+	m := newQueryCursorManager()
+	for i := 0; i < maxQueryCursorsPerUser; i++ {
+		_, cancel := context.WithCancel(context.Background())
+		a.NoError(m.add(&queryCursor{id: string(rune('a' + i)), userID: 1, cancel: cancel}))
+	}
+	_, cancel := context.WithCancel(context.Background())
+	a.Error(m.add(&queryCursor{id: "z", userID: 1, cancel: cancel}))
+	a.NoError(m.add(&queryCursor{id: "z", userID: 2, cancel: cancel}))
+
+	_, ok := m.get("a", 1)
+	a.True(ok)
+	// The cursor is only visible to its owner.
+	_, ok = m.get("a", 2)
+	a.False(ok)
+
+	m.remove("a")
+	_, ok = m.get("a", 1)
+	a.False(ok)
+	a.Equal(maxQueryCursorsPerUser-1, m.count(1))
+}
+
+func TestPaginatedQueryHA(t *testing.T) {
+	a := require.New(t)
+	s := NewSQLService(nil, nil, nil, nil, nil, &config.Profile{HA: true})
+	// The cursor can not be fetched from the other replicas.
+	_, err := s.Query(context.Background(), &v1pb.QueryRequest{Name: "instances/prod", Statement: "SELECT 1", PageSize: 10})
+	a.Equal(codes.FailedPrecondition, status.Code(err))
+}
@@ -0,0 +1,605 @@
+package v1
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/metric"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+const (
+	// exportBatchSize is the number of rows read from the database at a time for exporting.
+	exportBatchSize = 1000
+	// exportStreamChunkSize is the maximum size of the content in an export stream response.
+	exportStreamChunkSize = 1024 * 1024
+	// exportParquetRowGroupLength is the maximum number of rows in a row group of the exported Parquet file.
+	exportParquetRowGroupLength = 64 * 1024
+)
+
+// ExportStream exports the SQL query result in chunks.
+func (s *SQLService) ExportStream(request *v1pb.ExportRequest, server v1pb.SQLService_ExportStreamServer) error {
+	ctx := server.Context()
+	if err := validateExportRequest(request); err != nil {
+		return err
+	}
+	user, instance, database, _, _, sensitiveSchemaInfo, err := s.preCheck(ctx, request.Name, request.ConnectionDatabase, request.Statement, request.Limit, request.Admin, false /* isExport */)
+	if err != nil {
+		return err
+	}
+
+	databaseID := 0
+	if database != nil {
+		databaseID = database.UID
+	}
+	// Create export activity.
+	level := api.ActivityInfo
+	activity, err := s.createExportActivity(ctx, user, level, instance.UID, api.ActivitySQLExportPayload{
+		Statement:    request.Statement,
+		InstanceID:   instance.UID,
+		DatabaseID:   databaseID,
+		DatabaseName: request.ConnectionDatabase,
+	})
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriterSize(&exportStreamWriter{server: server}, exportStreamChunkSize)
+	durationNs, exportErr := s.doExportTo(ctx, request, instance, database, sensitiveSchemaInfo, w)
+	if exportErr == nil {
+		exportErr = w.Flush()
+	}
+
+	// The export activity must be updated even if the client cancels the stream.
+	ctx = context.WithoutCancel(ctx)
+	if err := s.postExport(ctx, activity, durationNs, exportErr); err != nil {
+		return err
+	}
+	s.postExportEvent(ctx, user, instance, request, exportErr)
+
+	return exportErr
+}
+
+// exportStreamWriter sends the content written to it in the export stream responses.
+type exportStreamWriter struct {
+	server v1pb.SQLService_ExportStreamServer
+}
+
+func (w *exportStreamWriter) Write(p []byte) (int, error) {
+	for i := 0; i < len(p); i += exportStreamChunkSize {
+		// Clone the content because the response may be used after Send returns, and the caller may reuse p.
+		chunk := bytes.Clone(p[i:min(i+exportStreamChunkSize, len(p))])
+		if err := w.server.Send(&v1pb.ExportResponse{Content: chunk}); err != nil {
+			return i, err
+		}
+	}
+	return len(p), nil
+}
+
+func validateExportRequest(request *v1pb.ExportRequest) error {
+	if request.Password != "" && !request.Zip {
+		return status.Errorf(codes.InvalidArgument, "password can only be set for the zip export")
+	}
+	return nil
+}
+
+// doExportTo exports the query result to w.
+// The rows are read from the database in batches and written to w while they are read, so that the whole result is never held in memory.
+func (s *SQLService) doExportTo(ctx context.Context, request *v1pb.ExportRequest, instance *store.InstanceMessage, database *store.DatabaseMessage, sensitiveSchemaInfo *base.SensitiveSchemaInfo, w io.Writer) (int64, error) {
+	// Don't anonymize data for exporting data using admin mode, which is checked to be used by the admins in preCheck.
+	if request.Admin {
+		sensitiveSchemaInfo = nil
+	}
+
+	countingWriter := common.NewCountingWriter(w)
+	var fileWriter io.Writer = countingWriter
+	var zipWriter *common.ZipWriter
+	if request.Zip {
+		zw, err := common.NewZipWriter(w, fmt.Sprintf("export.%s", strings.ToLower(request.Format.String())), request.Password)
+		if err != nil {
+			return 0, err
+		}
+		fileWriter, zipWriter = zw, zw
+	}
+	exporter, err := s.newResultExporter(ctx, request, instance, fileWriter)
+	if err != nil {
+		return 0, err
+	}
+	defer exporter.close()
+
+	driver, err := s.dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return 0, err
+	}
+	defer driver.Close(ctx)
+
+	sqlDB := driver.GetDB()
+	var conn *sql.Conn
+	if sqlDB != nil {
+		conn, err = sqlDB.Conn(ctx)
+		if err != nil {
+			return 0, err
+		}
+		defer conn.Close()
+	}
+
+	var exportResult *v1pb.QueryResult
+	var rowCount int64
+	start := time.Now().UnixNano()
+	results, err := driver.QueryConn(ctx, conn, request.Statement, &db.QueryContext{
+		Limit:               int(request.Limit),
+		ReadOnly:            true,
+		CurrentDatabase:     request.ConnectionDatabase,
+		SensitiveSchemaInfo: sensitiveSchemaInfo,
+		EnableSensitive:     s.licenseService.IsFeatureEnabledForInstance(api.FeatureSensitiveData, instance) == nil,
+		RowBatchHandler: func(result *v1pb.QueryResult, rows []*v1pb.QueryRow) error {
+			if exportResult == nil {
+				exportResult = result
+			} else if exportResult != result {
+				return errors.Errorf("expecting 1 result, but got more")
+			}
+			rowCount += int64(len(rows))
+			return exporter.write(result, rows)
+		},
+		RowBatchSize: exportBatchSize,
+	})
+	durationNs := time.Now().UnixNano() - start
+	if err != nil {
+		return durationNs, err
+	}
+	if len(results) != 1 {
+		return durationNs, errors.Errorf("expecting 1 result, but got %d", len(results))
+	}
+	if results[0].Error != "" {
+		return durationNs, errors.New(results[0].Error)
+	}
+	if exportResult == nil {
+		// The driver doesn't read the rows in batches and returns all of them in the result.
+		if err := exporter.write(results[0], results[0].Rows); err != nil {
+			return durationNs, err
+		}
+		rowCount = int64(len(results[0].Rows))
+	}
+	if err := exporter.finish(); err != nil {
+		return durationNs, err
+	}
+	if zipWriter != nil {
+		if err := zipWriter.Close(); err != nil {
+			return durationNs, err
+		}
+	}
+	metric.AddSQLQuery(instance.Engine.String(), metric.SQLQueryKindExport, rowCount)
+	metric.AddSQLExportBytes(instance.Engine.String(), request.Format.String(), countingWriter.Count())
+	return durationNs, nil
+}
+
+// resultExporter writes the query result in an export format.
+// The rows are written in batches, and the columns of the result are known on the first write.
+type resultExporter interface {
+	// write writes a batch of the rows of the result.
+	// It's called at least once, even if the result has no rows.
+	write(result *v1pb.QueryResult, rows []*v1pb.QueryRow) error
+	// finish writes the rest of the file after all the rows are written.
+	finish() error
+	// close releases the resources held by the exporter.
+	close()
+}
+
+// exportResult exports the query result with all the rows in it.
+func exportResult(exporter resultExporter, result *v1pb.QueryResult) error {
+	defer exporter.close()
+	if err := exporter.write(result, result.Rows); err != nil {
+		return err
+	}
+	return exporter.finish()
+}
+
+func (s *SQLService) newResultExporter(ctx context.Context, request *v1pb.ExportRequest, instance *store.InstanceMessage, w io.Writer) (resultExporter, error) {
+	switch request.Format {
+	case v1pb.ExportFormat_CSV:
+		return &csvExporter{w: w}, nil
+	case v1pb.ExportFormat_JSON:
+		return &jsonExporter{w: w}, nil
+	case v1pb.ExportFormat_SQL:
+		resourceList, err := s.extractResourceList(ctx, instance.Engine, request.ConnectionDatabase, request.Statement, instance)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to extract resource list: %v", err)
+		}
+		return &sqlExporter{w: w, engine: instance.Engine, resourceList: resourceList}, nil
+	case v1pb.ExportFormat_XLSX:
+		return &xlsxExporter{w: w}, nil
+	case v1pb.ExportFormat_PARQUET:
+		return &parquetExporter{w: w}, nil
+	case v1pb.ExportFormat_JSONL:
+		return &jsonlExporter{w: w}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported export format: %s", request.Format.String())
+	}
+}
+
+type csvExporter struct {
+	w       io.Writer
+	started bool
+}
+
+func (e *csvExporter) write(result *v1pb.QueryResult, rows []*v1pb.QueryRow) error {
+	if !e.started {
+		e.started = true
+		if _, err := io.WriteString(e.w, strings.Join(result.ColumnNames, ",")); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		buf := []byte{'\n'}
+		for i, value := range row.Values {
+			if i != 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(buf, convertValueToBytesInCSV(value)...)
+		}
+		if _, err := e.w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*csvExporter) finish() error {
+	return nil
+}
+
+func (*csvExporter) close() {}
+
+type jsonExporter struct {
+	w        io.Writer
+	rowCount int
+}
+
+func (e *jsonExporter) write(result *v1pb.QueryResult, rows []*v1pb.QueryRow) error {
+	for _, row := range rows {
+		// Indent the object as an element of the array.
+		content, err := json.MarshalIndent(convertRowToJSONObject(result.ColumnNames, row), "  ", "  ")
+		if err != nil {
+			return err
+		}
+		separator := ",\n  "
+		if e.rowCount == 0 {
+			separator = "[\n  "
+		}
+		if _, err := io.WriteString(e.w, separator); err != nil {
+			return err
+		}
+		if _, err := e.w.Write(content); err != nil {
+			return err
+		}
+		e.rowCount++
+	}
+	return nil
+}
+
+func (e *jsonExporter) finish() error {
+	end := "\n]"
+	if e.rowCount == 0 {
+		end = "[]"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+func (*jsonExporter) close() {}
+
+type jsonlExporter struct {
+	w io.Writer
+}
+
+func (e *jsonlExporter) write(result *v1pb.QueryResult, rows []*v1pb.QueryRow) error {
+	for _, row := range rows {
+		content, err := json.Marshal(convertRowToJSONObject(result.ColumnNames, row))
+		if err != nil {
+			return err
+		}
+		if _, err := e.w.Write(append(content, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*jsonlExporter) finish() error {
+	return nil
+}
+
+func (*jsonlExporter) close() {}
+
+func convertRowToJSONObject(columnNames []string, row *v1pb.QueryRow) map[string]any {
+	m := make(map[string]any)
+	for i, value := range row.Values {
+		m[columnNames[i]] = convertValueToStringInJSON(value)
+	}
+	return m
+}
+
+type sqlExporter struct {
+	w               io.Writer
+	engine          storepb.Engine
+	resourceList    []base.SchemaResource
+	statementPrefix string
+	rowCount        int
+}
+
+func (e *sqlExporter) write(result *v1pb.QueryResult, rows []*v1pb.QueryRow) error {
+	if e.statementPrefix == "" {
+		statementPrefix, err := getSQLStatementPrefix(e.engine, e.resourceList, result.ColumnNames)
+		if err != nil {
+			return err
+		}
+		e.statementPrefix = statementPrefix
+	}
+	for _, row := range rows {
+		var buf []byte
+		if e.rowCount != 0 {
+			buf = append(buf, '\n')
+		}
+		buf = append(buf, e.statementPrefix...)
+		for i, value := range row.Values {
+			if i != 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(buf, convertValueToBytesInSQL(e.engine, value)...)
+		}
+		buf = append(buf, ");"...)
+		if _, err := e.w.Write(buf); err != nil {
+			return err
+		}
+		e.rowCount++
+	}
+	return nil
+}
+
+func (*sqlExporter) finish() error {
+	return nil
+}
+
+func (*sqlExporter) close() {}
+
+// xlsxExporter writes the rows with the excelize stream writer, which keeps the rows in a temporary file when they are too large to fit in memory.
+// The file is written to w on finish because the XLSX file is a ZIP archive with the sheet in the middle.
+type xlsxExporter struct {
+	w        io.Writer
+	file     *excelize.File
+	stream   *excelize.StreamWriter
+	rowCount int
+}
+
+func (e *xlsxExporter) write(result *v1pb.QueryResult, rows []*v1pb.QueryRow) error {
+	if e.file == nil {
+		if len(result.ColumnNames) > 0 {
+			// Check the number of columns.
+			if _, err := getExcelColumnName(len(result.ColumnNames) - 1); err != nil {
+				return err
+			}
+		}
+		e.file = excelize.NewFile()
+		stream, err := e.file.NewStreamWriter(sheet1Name)
+		if err != nil {
+			return err
+		}
+		e.stream = stream
+		var header []any
+		for _, columnName := range result.ColumnNames {
+			header = append(header, columnName)
+		}
+		if err := e.stream.SetRow("A1", header); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		var values []any
+		for _, value := range row.Values {
+			values = append(values, convertValueToStringInXLSX(value))
+		}
+		if err := e.stream.SetRow(fmt.Sprintf("A%d", e.rowCount+2), values); err != nil {
+			return err
+		}
+		e.rowCount++
+	}
+	return nil
+}
+
+func (e *xlsxExporter) finish() error {
+	if e.file == nil {
+		return nil
+	}
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+	return e.file.Write(e.w)
+}
+
+func (e *xlsxExporter) close() {
+	if e.file != nil {
+		e.file.Close()
+	}
+}
+
+type parquetExporter struct {
+	w       io.Writer
+	writer  *pqarrow.FileWriter
+	builder *array.RecordBuilder
+}
+
+func (e *parquetExporter) write(result *v1pb.QueryResult, rows []*v1pb.QueryRow) error {
+	if e.writer == nil {
+		schema := getParquetSchema(result.ColumnNames, rows)
+		properties := parquet.NewWriterProperties(
+			parquet.WithCompression(compress.Codecs.Snappy),
+			parquet.WithMaxRowGroupLength(exportParquetRowGroupLength),
+		)
+		// Hide the Close method of w, because the Parquet writer closes its writer if it's an io.Closer.
+		writer, err := pqarrow.NewFileWriter(schema, struct{ io.Writer }{e.w}, properties, pqarrow.DefaultWriterProps())
+		if err != nil {
+			return errors.Wrap(err, "failed to create parquet writer")
+		}
+		e.writer = writer
+		e.builder = array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	fields := e.builder.Schema().Fields()
+	for _, row := range rows {
+		for i, value := range row.Values {
+			if err := appendParquetValue(e.builder.Field(i), value); err != nil {
+				return errors.Wrapf(err, "failed to write column %q", fields[i].Name)
+			}
+		}
+	}
+	record := e.builder.NewRecord()
+	defer record.Release()
+	// Buffer the rows in the row group until the row group is full, so that the row groups are not too small.
+	return e.writer.WriteBuffered(record)
+}
+
+func (e *parquetExporter) finish() error {
+	if e.writer == nil {
+		return nil
+	}
+	return e.writer.Close()
+}
+
+func (e *parquetExporter) close() {
+	if e.builder != nil {
+		e.builder.Release()
+	}
+}
+
+// getParquetSchema gets the schema of the Parquet file.
+// The column types are inferred from the values in the first batch of rows, and the column is a string column if the types are unknown or mixed.
+func getParquetSchema(columnNames []string, rows []*v1pb.QueryRow) *arrow.Schema {
+	var fields []arrow.Field
+	names := make(map[string]int)
+	for i, columnName := range columnNames {
+		// The Parquet column names must be unique.
+		name := columnName
+		if count := names[columnName]; count > 0 {
+			name = fmt.Sprintf("%s_%d", columnName, count)
+		}
+		names[columnName]++
+
+		var dataType arrow.DataType
+		for _, row := range rows {
+			if i >= len(row.Values) {
+				continue
+			}
+			valueType := getParquetDataType(row.Values[i])
+			if valueType == nil {
+				continue
+			}
+			if dataType == nil {
+				dataType = valueType
+			} else if !arrow.TypeEqual(dataType, valueType) {
+				dataType = arrow.BinaryTypes.String
+				break
+			}
+		}
+		if dataType == nil {
+			dataType = arrow.BinaryTypes.String
+		}
+		fields = append(fields, arrow.Field{Name: name, Type: dataType, Nullable: true})
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+// getParquetDataType returns the Parquet data type of the value, or nil for the null value.
+func getParquetDataType(value *v1pb.RowValue) arrow.DataType {
+	switch value.Kind.(type) {
+	case *v1pb.RowValue_NullValue:
+		return nil
+	case *v1pb.RowValue_BoolValue:
+		return arrow.FixedWidthTypes.Boolean
+	case *v1pb.RowValue_Int32Value, *v1pb.RowValue_Int64Value:
+		return arrow.PrimitiveTypes.Int64
+	case *v1pb.RowValue_Uint32Value, *v1pb.RowValue_Uint64Value:
+		return arrow.PrimitiveTypes.Uint64
+	case *v1pb.RowValue_FloatValue, *v1pb.RowValue_DoubleValue:
+		return arrow.PrimitiveTypes.Float64
+	case *v1pb.RowValue_BytesValue:
+		return arrow.BinaryTypes.Binary
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+func appendParquetValue(builder array.Builder, value *v1pb.RowValue) error {
+	if _, ok := value.Kind.(*v1pb.RowValue_NullValue); ok {
+		builder.AppendNull()
+		return nil
+	}
+	switch b := builder.(type) {
+	case *array.StringBuilder:
+		b.Append(convertValueToStringInJSON(value))
+		return nil
+	case *array.BooleanBuilder:
+		if v, ok := value.Kind.(*v1pb.RowValue_BoolValue); ok {
+			b.Append(v.BoolValue)
+			return nil
+		}
+	case *array.Int64Builder:
+		switch v := value.Kind.(type) {
+		case *v1pb.RowValue_Int32Value:
+			b.Append(int64(v.Int32Value))
+			return nil
+		case *v1pb.RowValue_Int64Value:
+			b.Append(v.Int64Value)
+			return nil
+		}
+	case *array.Uint64Builder:
+		switch v := value.Kind.(type) {
+		case *v1pb.RowValue_Uint32Value:
+			b.Append(uint64(v.Uint32Value))
+			return nil
+		case *v1pb.RowValue_Uint64Value:
+			b.Append(v.Uint64Value)
+			return nil
+		}
+	case *array.Float64Builder:
+		switch v := value.Kind.(type) {
+		case *v1pb.RowValue_FloatValue:
+			b.Append(float64(v.FloatValue))
+			return nil
+		case *v1pb.RowValue_DoubleValue:
+			b.Append(v.DoubleValue)
+			return nil
+		}
+	case *array.BinaryBuilder:
+		if v, ok := value.Kind.(*v1pb.RowValue_BytesValue); ok {
+			b.Append(v.BytesValue)
+			return nil
+		}
+	}
+	return errors.Errorf("unexpected value type %T for column type %s", value.Kind, builder.Type())
+}
@@ -1,12 +1,22 @@
 package v1
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/genproto/googleapis/type/expr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/parser/base"
 	"github.com/bytebase/bytebase/backend/store"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -186,12 +196,145 @@ func TestExportSQL(t *testing.T) {
 	a := assert.New(t)
 
 	for _, test := range tests {
-		got, err := exportSQL(test.engine, test.statementPrefix, test.result)
+		var buf bytes.Buffer
+		err := exportResult(&sqlExporter{w: &buf, engine: test.engine, statementPrefix: test.statementPrefix}, test.result)
 		a.NoError(err)
-		a.Equal(test.want, string(got))
+		a.Equal(test.want, buf.String())
 	}
 }
 
+func TestExportInBatches(t *testing.T) {
+	a := require.New(t)
+	result := &v1pb.QueryResult{
+		ColumnNames: []string{"id", "name"},
+		Rows: []*v1pb.QueryRow{
+			{Values: []*v1pb.RowValue{{Kind: &v1pb.RowValue_Int64Value{Int64Value: 1}}, {Kind: &v1pb.RowValue_StringValue{StringValue: "a"}}}},
+			{Values: []*v1pb.RowValue{{Kind: &v1pb.RowValue_Int64Value{Int64Value: 2}}, {Kind: &v1pb.RowValue_StringValue{StringValue: "b\"c"}}}},
+			{Values: []*v1pb.RowValue{{Kind: &v1pb.RowValue_Int64Value{Int64Value: 3}}, {Kind: &v1pb.RowValue_NullValue{}}}},
+		},
+	}
+	emptyResult := &v1pb.QueryResult{ColumnNames: result.ColumnNames}
+
+	tests := []struct {
+		newExporter func(buf *bytes.Buffer) resultExporter
+		want        string
+		wantEmpty   string
+	}{
+		{
+			newExporter: func(buf *bytes.Buffer) resultExporter { return &csvExporter{w: buf} },
+			want:        "id,name\n1,\"a\"\n2,\"b\"\"c\"\n3,",
+			wantEmpty:   "id,name",
+		},
+		{
+			newExporter: func(buf *bytes.Buffer) resultExporter { return &jsonExporter{w: buf} },
+			want:        "[\n  {\n    \"id\": \"1\",\n    \"name\": \"a\"\n  },\n  {\n    \"id\": \"2\",\n    \"name\": \"b\\\"c\"\n  },\n  {\n    \"id\": \"3\",\n    \"name\": \"null\"\n  }\n]",
+			wantEmpty:   "[]",
+		},
+		{
+			newExporter: func(buf *bytes.Buffer) resultExporter { return &jsonlExporter{w: buf} },
+			want:        "{\"id\":\"1\",\"name\":\"a\"}\n{\"id\":\"2\",\"name\":\"b\\\"c\"}\n{\"id\":\"3\",\"name\":\"null\"}\n",
+			wantEmpty:   "",
+		},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		a.NoError(exportResult(test.newExporter(&buf), result))
+		a.Equal(test.want, buf.String())
+
+		// Writing the rows in batches produces the same content.
+		buf.Reset()
+		exporter := test.newExporter(&buf)
+		a.NoError(exporter.write(result, result.Rows[:2]))
+		a.NoError(exporter.write(result, result.Rows[2:]))
+		a.NoError(exporter.finish())
+		exporter.close()
+		a.Equal(test.want, buf.String())
+
+		buf.Reset()
+		a.NoError(exportResult(test.newExporter(&buf), emptyResult))
+		a.Equal(test.wantEmpty, buf.String())
+	}
+}
+
+func TestCheckAdminMode(t *testing.T) {
+	a := require.New(t)
+	tests := []struct {
+		role    api.Role
+		isAdmin bool
+		denied  bool
+	}{
+		{role: api.Owner, isAdmin: true},
+		{role: api.DBA, isAdmin: true},
+		// The non-admin caller can not export the unmasked data with admin mode.
+		{role: api.Developer, isAdmin: true, denied: true},
+		{role: api.Developer, isAdmin: false},
+	}
+	for _, test := range tests {
+		err := checkAdminMode(&store.UserMessage{Role: test.role}, test.isAdmin)
+		if test.denied {
+			a.Equal(codes.PermissionDenied, status.Code(err), test.role)
+			continue
+		}
+		a.NoError(err, test.role)
+	}
+}
+
+func TestExportParquet(t *testing.T) {
+	a := require.New(t)
+	result := &v1pb.QueryResult{
+		ColumnNames: []string{"id", "name", "id"},
+		Rows: []*v1pb.QueryRow{
+			{Values: []*v1pb.RowValue{{Kind: &v1pb.RowValue_Int64Value{Int64Value: 1}}, {Kind: &v1pb.RowValue_StringValue{StringValue: "a"}}, {Kind: &v1pb.RowValue_StringValue{StringValue: "x"}}}},
+			{Values: []*v1pb.RowValue{{Kind: &v1pb.RowValue_NullValue{}}, {Kind: &v1pb.RowValue_StringValue{StringValue: "b"}}, {Kind: &v1pb.RowValue_BoolValue{BoolValue: true}}}},
+			{Values: []*v1pb.RowValue{{Kind: &v1pb.RowValue_Int64Value{Int64Value: 3}}, {Kind: &v1pb.RowValue_NullValue{}}, {Kind: &v1pb.RowValue_NullValue{}}}},
+		},
+	}
+	var buf bytes.Buffer
+	exporter := &parquetExporter{w: &buf}
+	a.NoError(exporter.write(result, result.Rows[:2]))
+	a.NoError(exporter.write(result, result.Rows[2:]))
+	a.NoError(exporter.finish())
+	exporter.close()
+
+	reader, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	a.NoError(err)
+	defer reader.Close()
+	fileReader, err := pqarrow.NewFileReader(reader, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	a.NoError(err)
+	table, err := fileReader.ReadTable(context.Background())
+	a.NoError(err)
+	defer table.Release()
+
+	schema := table.Schema()
+	a.Equal(int64(3), table.NumRows())
+	a.Equal("id", schema.Field(0).Name)
+	a.Equal(arrow.PrimitiveTypes.Int64, schema.Field(0).Type)
+	a.Equal("name", schema.Field(1).Name)
+	a.Equal(arrow.BinaryTypes.String, schema.Field(1).Type)
+	// The duplicate column name is renamed, and the mixed types fall back to string.
+	a.Equal("id_1", schema.Field(2).Name)
+	a.Equal(arrow.BinaryTypes.String, schema.Field(2).Type)
+
+	ids := table.Column(0).Data().Chunk(0).(*array.Int64)
+	a.Equal(int64(1), ids.Value(0))
+	a.True(ids.IsNull(1))
+	a.Equal(int64(3), ids.Value(2))
+	names := table.Column(1).Data().Chunk(0).(*array.String)
+	a.Equal("b", names.Value(1))
+	a.True(names.IsNull(2))
+	others := table.Column(2).Data().Chunk(0).(*array.String)
+	a.Equal("x", others.Value(0))
+	a.Equal("true", others.Value(1))
+	a.True(others.IsNull(2))
+
+	// The column types can't change after the first batch.
+	result = &v1pb.QueryResult{ColumnNames: []string{"id"}}
+	exporter = &parquetExporter{w: &bytes.Buffer{}}
+	defer exporter.close()
+	a.NoError(exporter.write(result, []*v1pb.QueryRow{{Values: []*v1pb.RowValue{{Kind: &v1pb.RowValue_Int64Value{Int64Value: 1}}}}}))
+	a.Error(exporter.write(result, []*v1pb.QueryRow{{Values: []*v1pb.RowValue{{Kind: &v1pb.RowValue_StringValue{StringValue: "2"}}}}}))
+}
+
 func TestEncodeToBase64String(t *testing.T) {
 	tests := []struct {
 		input string
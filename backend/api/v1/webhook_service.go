@@ -0,0 +1,253 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// WebhookService implements the workspace webhook service.
+// The workspace webhooks receive the events of all projects and the workspace events.
+type WebhookService struct {
+	v1pb.UnimplementedWebhookServiceServer
+	store *store.Store
+}
+
+// NewWebhookService creates a new WebhookService.
+func NewWebhookService(store *store.Store) *WebhookService {
+	return &WebhookService{
+		store: store,
+	}
+}
+
+// ListWorkspaceWebhooks lists the workspace webhooks.
+func (s *WebhookService) ListWorkspaceWebhooks(ctx context.Context, _ *v1pb.ListWorkspaceWebhooksRequest) (*v1pb.ListWorkspaceWebhooksResponse, error) {
+	webhooks, err := s.store.FindProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{Workspace: true})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list workspace webhooks, error: %v", err)
+	}
+	response := &v1pb.ListWorkspaceWebhooksResponse{}
+	for _, webhook := range webhooks {
+		response.Webhooks = append(response.Webhooks, convertToWorkspaceWebhook(webhook))
+	}
+	return response, nil
+}
+
+// CreateWorkspaceWebhook creates a workspace webhook.
+func (s *WebhookService) CreateWorkspaceWebhook(ctx context.Context, request *v1pb.CreateWorkspaceWebhookRequest) (*v1pb.Webhook, error) {
+	if request.Webhook == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "webhook must be set")
+	}
+	setting, err := s.store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace setting: %v", err)
+	}
+	if setting.ExternalUrl == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, setupExternalURLError)
+	}
+
+	create, err := convertToStoreProjectWebhookMessage(request.Webhook)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if len(create.ActivityList) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "notification types should not be empty")
+	}
+	webhook, err := s.store.CreateWorkspaceWebhook(ctx, ctx.Value(common.PrincipalIDContextKey).(int), create)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create workspace webhook, error: %v", err)
+	}
+	return convertToWorkspaceWebhook(webhook), nil
+}
+
+// UpdateWorkspaceWebhook updates a workspace webhook.
+func (s *WebhookService) UpdateWorkspaceWebhook(ctx context.Context, request *v1pb.UpdateWorkspaceWebhookRequest) (*v1pb.Webhook, error) {
+	if request.Webhook == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "webhook must be set")
+	}
+	if request.UpdateMask == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "update_mask must be set")
+	}
+	webhook, err := s.getWorkspaceWebhook(ctx, request.Webhook.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	update, err := convertToUpdateProjectWebhookMessage(request.Webhook, request.UpdateMask.Paths)
+	if err != nil {
+		return nil, err
+	}
+	webhook, err = s.store.UpdateProjectWebhookV2(ctx, ctx.Value(common.PrincipalIDContextKey).(int), "" /* projectResourceID */, webhook.ID, update)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update workspace webhook, error: %v", err)
+	}
+	return convertToWorkspaceWebhook(webhook), nil
+}
+
+// DeleteWorkspaceWebhook deletes a workspace webhook.
+func (s *WebhookService) DeleteWorkspaceWebhook(ctx context.Context, request *v1pb.DeleteWorkspaceWebhookRequest) (*emptypb.Empty, error) {
+	webhook, err := s.getWorkspaceWebhook(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.DeleteProjectWebhookV2(ctx, "" /* projectResourceID */, webhook.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete workspace webhook, error: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListWebhookDeliveries lists the recent deliveries of a workspace webhook.
+func (s *WebhookService) ListWebhookDeliveries(ctx context.Context, request *v1pb.ListWebhookDeliveriesRequest) (*v1pb.ListWebhookDeliveriesResponse, error) {
+	webhook, err := s.getWorkspaceWebhook(ctx, request.Parent)
+	if err != nil {
+		return nil, err
+	}
+	return listWebhookDeliveries(ctx, s.store, fmt.Sprintf("%s%d", common.WebhookIDPrefix, webhook.ID), webhook.ID, request)
+}
+
+// RedeliverWebhook schedules a delivery of a workspace webhook to be delivered again as soon as possible.
+func (s *WebhookService) RedeliverWebhook(ctx context.Context, request *v1pb.RedeliverWebhookRequest) (*v1pb.WebhookDelivery, error) {
+	webhookID, deliveryID, err := common.GetWorkspaceWebhookIDDeliveryID(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	webhook, err := s.getWorkspaceWebhook(ctx, fmt.Sprintf("%s%d", common.WebhookIDPrefix, webhookID))
+	if err != nil {
+		return nil, err
+	}
+	return redeliverWebhook(ctx, s.store, fmt.Sprintf("%s%d", common.WebhookIDPrefix, webhook.ID), webhook.ID, deliveryID)
+}
+
+func (s *WebhookService) getWorkspaceWebhook(ctx context.Context, name string) (*store.ProjectWebhookMessage, error) {
+	webhookID, err := common.GetWorkspaceWebhookID(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	webhook, err := s.store.GetProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
+		ID:        &webhookID,
+		Workspace: true,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if webhook == nil {
+		return nil, status.Errorf(codes.NotFound, "webhook %q not found", name)
+	}
+	return webhook, nil
+}
+
+// listWebhookDeliveries lists the deliveries of the webhook, which is shared by the project and workspace webhooks.
+func listWebhookDeliveries(ctx context.Context, stores *store.Store, webhookName string, webhookUID int, request *v1pb.ListWebhookDeliveriesRequest) (*v1pb.ListWebhookDeliveriesResponse, error) {
+	var limit, offset int
+	if request.PageToken != "" {
+		var pageToken storepb.PageToken
+		if err := unmarshalPageToken(request.PageToken, &pageToken); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+		}
+		if pageToken.Limit < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "page size cannot be negative")
+		}
+		limit = int(pageToken.Limit)
+		offset = int(pageToken.Offset)
+	} else {
+		limit = int(request.PageSize)
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+	limitPlusOne := limit + 1
+
+	deliveries, err := stores.ListWebhookDeliveries(ctx, &store.FindWebhookDeliveryMessage{
+		WebhookUID: &webhookUID,
+		Limit:      &limitPlusOne,
+		Offset:     &offset,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webhook deliveries, error: %v", err)
+	}
+
+	resp := &v1pb.ListWebhookDeliveriesResponse{}
+	if len(deliveries) == limitPlusOne {
+		nextPageToken, err := getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+		resp.NextPageToken = nextPageToken
+		deliveries = deliveries[:limit]
+	}
+	for _, delivery := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, convertToWebhookDelivery(webhookName, delivery))
+	}
+	return resp, nil
+}
+
+// redeliverWebhook resets the delivery of the webhook to pending, which is shared by the project and workspace webhooks.
+func redeliverWebhook(ctx context.Context, stores *store.Store, webhookName string, webhookUID int, deliveryUID int) (*v1pb.WebhookDelivery, error) {
+	delivery, err := stores.GetWebhookDelivery(ctx, &store.FindWebhookDeliveryMessage{
+		UID:        &deliveryUID,
+		WebhookUID: &webhookUID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if delivery == nil {
+		return nil, status.Errorf(codes.NotFound, "webhook delivery %q not found", fmt.Sprintf("%s/%s%d", webhookName, common.WebhookDeliveryPrefix, deliveryUID))
+	}
+
+	// Reset the attempts so that the redelivery gets the full retries.
+	pending, attempt, nextAttemptTs := store.WebhookDeliveryPending, 0, time.Now().Unix()
+	delivery, err = stores.UpdateWebhookDelivery(ctx, &store.UpdateWebhookDeliveryMessage{
+		UID:           delivery.UID,
+		Status:        &pending,
+		Attempt:       &attempt,
+		NextAttemptTs: &nextAttemptTs,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	return convertToWebhookDelivery(webhookName, delivery), nil
+}
+
+func convertToWorkspaceWebhook(webhook *store.ProjectWebhookMessage) *v1pb.Webhook {
+	return &v1pb.Webhook{
+		Name:              fmt.Sprintf("%s%d", common.WebhookIDPrefix, webhook.ID),
+		Type:              convertWebhookTypeString(webhook.Type),
+		Title:             webhook.Title,
+		Url:               webhook.URL,
+		NotificationTypes: convertNotificationTypeStrings(webhook.ActivityList),
+	}
+}
+
+func convertToWebhookDelivery(webhookName string, delivery *store.WebhookDeliveryMessage) *v1pb.WebhookDelivery {
+	v1Delivery := &v1pb.WebhookDelivery{
+		Name:         fmt.Sprintf("%s/%s%d", webhookName, common.WebhookDeliveryPrefix, delivery.UID),
+		Attempt:      int32(delivery.Attempt),
+		ResponseCode: int32(delivery.ResponseCode),
+		Error:        delivery.Error,
+		CreateTime:   timestamppb.New(time.Unix(delivery.CreatedTs, 0)),
+		UpdateTime:   timestamppb.New(time.Unix(delivery.UpdatedTs, 0)),
+	}
+	switch delivery.Status {
+	case store.WebhookDeliveryPending:
+		v1Delivery.Status = v1pb.WebhookDelivery_PENDING
+		v1Delivery.NextAttemptTime = timestamppb.New(time.Unix(delivery.NextAttemptTs, 0))
+	case store.WebhookDeliveryDelivered:
+		v1Delivery.Status = v1pb.WebhookDelivery_DELIVERED
+	case store.WebhookDeliveryDead:
+		v1Delivery.Status = v1pb.WebhookDelivery_DEAD
+	}
+	return v1Delivery
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/bytebase/bytebase/backend/component/audit"
+)
+
+func init() {
+	rootCmd.AddCommand(verifyAuditLogCmd)
+}
+
+var verifyAuditLogCmd = &cobra.Command{
+	Use:   "verify-audit-log",
+	Short: "Verify the audit log in --audit-log-file with the key in --audit-log-key-file",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if flags.auditLogFile == "" || flags.auditLogKeyFile == "" {
+			return errors.New("--audit-log-file and --audit-log-key-file are required")
+		}
+		key, err := audit.ReadKeyFile(flags.auditLogKeyFile)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(flags.auditLogFile)
+		if err != nil {
+			return errors.Wrapf(err, "failed to open audit log file %q", flags.auditLogFile)
+		}
+		defer file.Close()
+		count, err := audit.Verify(file, key)
+		if err != nil {
+			return errors.Wrapf(err, "audit log is invalid after %d verified entries", count)
+		}
+		fmt.Printf("Verified %d audit log entries\n", count)
+		return nil
+	},
+}
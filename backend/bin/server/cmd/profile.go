@@ -27,6 +27,7 @@ func getBaseProfile(dataDir string) config.Profile {
 		SampleDatabasePort:   sampleDatabasePort,
 		Readonly:             flags.readonly,
 		SaaS:                 flags.saas,
+		HA:                   flags.ha,
 		DataDir:              dataDir,
 		ResourceDir:          common.GetResourceDir(dataDir),
 		DemoName:             flags.demoName,
@@ -37,6 +38,12 @@ func getBaseProfile(dataDir string) config.Profile {
 		BackupRegion:         flags.backupRegion,
 		BackupBucket:         flags.backupBucket,
 		BackupCredentialFile: flags.backupCredential,
+		OTLPEndpoint:         flags.otlpEndpoint,
+		OTLPInsecure:         flags.otlpInsecure,
+		AuditLogFile:         flags.auditLogFile,
+		AuditLogSyslog:       flags.auditLogSyslog,
+		AuditLogURL:          flags.auditLogURL,
+		AuditLogKeyFile:      flags.auditLogKeyFile,
 		LastActiveTs:         time.Now().Unix(),
 	}
 }
@@ -0,0 +1,35 @@
+package common
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+var (
+	_ io.Writer = (*CountingWriter)(nil)
+)
+
+// CountingWriter is a writer that counts the written bytes in a thread safe way.
+type CountingWriter struct {
+	w     io.Writer
+	count int64
+}
+
+// NewCountingWriter creates a new CountingWriter from an existing io.Writer.
+func NewCountingWriter(w io.Writer) *CountingWriter {
+	return &CountingWriter{w: w}
+}
+
+// Write implements the io.Writer interface.
+func (w *CountingWriter) Write(buf []byte) (int, error) {
+	n, err := w.w.Write(buf)
+	if n >= 0 {
+		atomic.AddInt64(&w.count, int64(n))
+	}
+	return n, err
+}
+
+// Count returns the number of written bytes.
+func (w *CountingWriter) Count() int64 {
+	return atomic.LoadInt64(&w.count)
+}
@@ -33,6 +33,7 @@ const (
 	RolePrefix                   = "roles/"
 	SecretNamePrefix             = "secrets/"
 	WebhookIDPrefix              = "webhooks/"
+	WebhookDeliveryPrefix        = "deliveries/"
 	SheetIDPrefix                = "sheets/"
 	DatabaseGroupNamePrefix      = "databaseGroups/"
 	SchemaGroupNamePrefix        = "schemaGroups/"
@@ -88,6 +89,37 @@ func GetProjectIDWebhookID(name string) (string, string, error) {
 	return tokens[0], tokens[1], nil
 }
 
+// GetProjectIDWebhookIDDeliveryID returns the project ID, webhook ID and delivery ID from a resource name.
+func GetProjectIDWebhookIDDeliveryID(name string) (string, string, string, error) {
+	tokens, err := GetNameParentTokens(name, ProjectNamePrefix, WebhookIDPrefix, WebhookDeliveryPrefix)
+	if err != nil {
+		return "", "", "", err
+	}
+	return tokens[0], tokens[1], tokens[2], nil
+}
+
+// GetWorkspaceWebhookID returns the workspace webhook ID from a resource name.
+func GetWorkspaceWebhookID(name string) (int, error) {
+	return GetUIDFromName(name, WebhookIDPrefix)
+}
+
+// GetWorkspaceWebhookIDDeliveryID returns the workspace webhook ID and delivery ID from a resource name.
+func GetWorkspaceWebhookIDDeliveryID(name string) (int, int, error) {
+	tokens, err := GetNameParentTokens(name, WebhookIDPrefix, WebhookDeliveryPrefix)
+	if err != nil {
+		return 0, 0, err
+	}
+	webhookID, err := strconv.Atoi(tokens[0])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid webhook ID %q", tokens[0])
+	}
+	deliveryID, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid delivery ID %q", tokens[1])
+	}
+	return webhookID, deliveryID, nil
+}
+
 func GetProjectIDDeploymentConfigID(name string) (string, string, error) {
 	tokens, err := GetNameParentTokens(name, ProjectNamePrefix, DeploymentConfigPrefix)
 	if err != nil {
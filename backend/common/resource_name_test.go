@@ -37,3 +37,26 @@ func TestGetRiskID(t *testing.T) {
 		a.Equal(test.want, got)
 	}
 }
+
+func TestGetProjectIDWebhookIDDeliveryID(t *testing.T) {
+	projectID, webhookID, deliveryID, err := GetProjectIDWebhookIDDeliveryID("projects/p1/webhooks/101/deliveries/102")
+	require.NoError(t, err)
+	require.Equal(t, "p1", projectID)
+	require.Equal(t, "101", webhookID)
+	require.Equal(t, "102", deliveryID)
+
+	_, _, _, err = GetProjectIDWebhookIDDeliveryID("projects/p1/webhooks/101")
+	require.Error(t, err)
+}
+
+func TestGetWorkspaceWebhookIDDeliveryID(t *testing.T) {
+	webhookID, deliveryID, err := GetWorkspaceWebhookIDDeliveryID("webhooks/101/deliveries/102")
+	require.NoError(t, err)
+	require.Equal(t, 101, webhookID)
+	require.Equal(t, 102, deliveryID)
+
+	_, _, err = GetWorkspaceWebhookIDDeliveryID("projects/p1/webhooks/101/deliveries/102")
+	require.Error(t, err)
+	_, _, err = GetWorkspaceWebhookIDDeliveryID("webhooks/w1/deliveries/102")
+	require.Error(t, err)
+}
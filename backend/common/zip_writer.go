@@ -0,0 +1,218 @@
+package common
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/binary"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	_ io.WriteCloser = (*ZipWriter)(nil)
+)
+
+const (
+	// The WinZip AES encryption, see https://www.winzip.com/en/support/aes-encryption/.
+	zipMethodWinZipAES     = 99
+	zipExtraIDWinZipAES    = 0x9901
+	zipVersionWinZipAES    = 51
+	zipWinZipAESVersionAE2 = 2
+	zipWinZipAESStrength   = 3 // AES-256.
+	zipAESKeySize          = 32
+	zipAESSaltSize         = 16
+	zipAESVerifierSize     = 2
+	zipAESAuthCodeSize     = 10
+	zipAESIterations       = 1000
+
+	zipFlagEncrypted      = 0x1
+	zipFlagDataDescriptor = 0x8
+	zipCreatorUnix        = 3 << 8
+)
+
+// ZipWriter writes a single file into a ZIP archive as a stream.
+// The file is encrypted with AES-256 (WinZip AE-2) if the password is not empty.
+type ZipWriter struct {
+	zw *zip.Writer
+	// w writes the file content, and it's the deflate writer if the file is encrypted.
+	w io.Writer
+
+	// The following fields are only set if the file is encrypted.
+	header    *zip.FileHeader
+	deflate   *flate.Writer
+	encrypter *zipAESEncrypter
+	size      int64
+}
+
+// NewZipWriter creates a ZIP archive with a file with the name and writes it to w.
+// The caller must call Close to finish the archive.
+func NewZipWriter(w io.Writer, name, password string) (*ZipWriter, error) {
+	zw := zip.NewWriter(w)
+	if password == "" {
+		fw, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create zip file %q", name)
+		}
+		return &ZipWriter{zw: zw, w: fw}, nil
+	}
+
+	// archive/zip doesn't support encryption, so we write the encrypted data as the raw file content.
+	// The sizes are unknown until the file is written, so they are written in the data descriptor after the file content.
+	modifiedDate, modifiedTime := timeToMSDOSTime(time.Now())
+	header := &zip.FileHeader{
+		Name:           name,
+		CreatorVersion: zipCreatorUnix | zipVersionWinZipAES,
+		ReaderVersion:  zipVersionWinZipAES,
+		Flags:          zipFlagEncrypted | zipFlagDataDescriptor,
+		Method:         zipMethodWinZipAES,
+		ModifiedDate:   modifiedDate,
+		ModifiedTime:   modifiedTime,
+		Extra:          winZipAESExtra(zip.Deflate),
+	}
+	raw, err := zw.CreateRaw(header)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create zip file %q", name)
+	}
+	encrypter, err := newZipAESEncrypter(raw, password)
+	if err != nil {
+		return nil, err
+	}
+	deflate, err := flate.NewWriter(encrypter, flate.DefaultCompression)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create deflate writer")
+	}
+	return &ZipWriter{
+		zw:        zw,
+		w:         deflate,
+		header:    header,
+		deflate:   deflate,
+		encrypter: encrypter,
+	}, nil
+}
+
+// Write writes the file content.
+func (z *ZipWriter) Write(p []byte) (int, error) {
+	n, err := z.w.Write(p)
+	z.size += int64(n)
+	return n, err
+}
+
+// Close finishes the file and the ZIP archive. It doesn't close the underlying writer.
+func (z *ZipWriter) Close() error {
+	if z.encrypter != nil {
+		if err := z.deflate.Close(); err != nil {
+			return errors.Wrap(err, "failed to close deflate writer")
+		}
+		if err := z.encrypter.close(); err != nil {
+			return err
+		}
+		// The CRC-32 is not used in AE-2, and the authentication code verifies the file instead.
+		z.header.CRC32 = 0
+		z.header.CompressedSize64 = uint64(zipAESSaltSize + zipAESVerifierSize + z.encrypter.size + zipAESAuthCodeSize)
+		z.header.UncompressedSize64 = uint64(z.size)
+		z.header.CompressedSize = uint32(min(z.header.CompressedSize64, uint64(^uint32(0))))
+		z.header.UncompressedSize = uint32(min(z.header.UncompressedSize64, uint64(^uint32(0))))
+	}
+	if err := z.zw.Close(); err != nil {
+		return errors.Wrap(err, "failed to close zip writer")
+	}
+	return nil
+}
+
+// winZipAESExtra returns the extra field of the WinZip AES encryption.
+func winZipAESExtra(method uint16) []byte {
+	extra := make([]byte, 11)
+	binary.LittleEndian.PutUint16(extra[0:], zipExtraIDWinZipAES)
+	binary.LittleEndian.PutUint16(extra[2:], 7)
+	binary.LittleEndian.PutUint16(extra[4:], zipWinZipAESVersionAE2)
+	copy(extra[6:], "AE")
+	extra[8] = zipWinZipAESStrength
+	binary.LittleEndian.PutUint16(extra[9:], method)
+	return extra
+}
+
+// zipAESEncrypter encrypts the data with AES in CTR mode as WinZip does, and authenticates the encrypted data with HMAC-SHA1.
+type zipAESEncrypter struct {
+	w      io.Writer
+	block  cipher.Block
+	mac    hash.Hash
+	nonce  [aes.BlockSize]byte
+	stream [aes.BlockSize]byte
+	// offset is the offset of the next byte to use in the key stream block.
+	offset int
+	size   int64
+}
+
+func newZipAESEncrypter(w io.Writer, password string) (*zipAESEncrypter, error) {
+	salt := make([]byte, zipAESSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+	key := pbkdf2.Key([]byte(password), salt, zipAESIterations, 2*zipAESKeySize+zipAESVerifierSize, sha1.New)
+	block, err := aes.NewCipher(key[:zipAESKeySize])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	// The salt and the password verifier precede the encrypted data.
+	if _, err := w.Write(salt); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(key[2*zipAESKeySize:]); err != nil {
+		return nil, err
+	}
+	return &zipAESEncrypter{
+		w:      w,
+		block:  block,
+		mac:    hmac.New(sha1.New, key[zipAESKeySize:2*zipAESKeySize]),
+		offset: aes.BlockSize,
+	}, nil
+}
+
+func (e *zipAESEncrypter) Write(p []byte) (int, error) {
+	buf := make([]byte, len(p))
+	for i := range p {
+		if e.offset == aes.BlockSize {
+			// WinZip uses a little-endian counter starting from 1, which differs from cipher.NewCTR.
+			for j := range e.nonce {
+				e.nonce[j]++
+				if e.nonce[j] != 0 {
+					break
+				}
+			}
+			e.block.Encrypt(e.stream[:], e.nonce[:])
+			e.offset = 0
+		}
+		buf[i] = p[i] ^ e.stream[e.offset]
+		e.offset++
+	}
+	e.mac.Write(buf)
+	n, err := e.w.Write(buf)
+	e.size += int64(n)
+	return n, err
+}
+
+// close writes the authentication code after the encrypted data.
+func (e *zipAESEncrypter) close() error {
+	_, err := e.w.Write(e.mac.Sum(nil)[:zipAESAuthCodeSize])
+	return err
+}
+
+// timeToMSDOSTime converts the time to the MS-DOS date and time used in the ZIP file header.
+func timeToMSDOSTime(t time.Time) (uint16, uint16) {
+	date := uint16(t.Day() + int(t.Month())<<5 + (t.Year()-1980)<<9)
+	clock := uint16(t.Second()/2 + t.Minute()<<5 + t.Hour()<<11)
+	return date, clock
+}
@@ -0,0 +1,91 @@
+package common
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+func TestZipWriter(t *testing.T) {
+	a := require.New(t)
+	content := strings.Repeat("id,name\n1,bytebase\n", 10000)
+
+	var buf bytes.Buffer
+	w, err := NewZipWriter(&buf, "export.csv", "")
+	a.NoError(err)
+	_, err = io.WriteString(w, content)
+	a.NoError(err)
+	a.NoError(w.Close())
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	a.NoError(err)
+	a.Len(r.File, 1)
+	a.Equal("export.csv", r.File[0].Name)
+	f, err := r.File[0].Open()
+	a.NoError(err)
+	got, err := io.ReadAll(f)
+	a.NoError(err)
+	a.Equal(content, string(got))
+}
+
+func TestZipWriterWithPassword(t *testing.T) {
+	a := require.New(t)
+	content := strings.Repeat("id,name\n1,bytebase\n", 10000)
+	password := "secret"
+
+	var buf bytes.Buffer
+	w, err := NewZipWriter(&buf, "export.csv", password)
+	a.NoError(err)
+	// Write in small chunks to cover the key stream blocks spanning the writes.
+	for i := 0; i < len(content); i += 7 {
+		_, err = io.WriteString(w, content[i:min(i+7, len(content))])
+		a.NoError(err)
+	}
+	a.NoError(w.Close())
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	a.NoError(err)
+	a.Len(r.File, 1)
+	file := r.File[0]
+	a.Equal("export.csv", file.Name)
+	a.Equal(uint16(zipMethodWinZipAES), file.Method)
+	a.Equal(uint16(zipFlagEncrypted), file.Flags&zipFlagEncrypted)
+	a.Equal(winZipAESExtra(zip.Deflate), file.Extra)
+	a.Equal(uint64(len(content)), file.UncompressedSize64)
+
+	raw, err := file.OpenRaw()
+	a.NoError(err)
+	data, err := io.ReadAll(raw)
+	a.NoError(err)
+	a.Equal(file.CompressedSize64, uint64(len(data)))
+
+	salt := data[:zipAESSaltSize]
+	verifier := data[zipAESSaltSize : zipAESSaltSize+zipAESVerifierSize]
+	encrypted := data[zipAESSaltSize+zipAESVerifierSize : len(data)-zipAESAuthCodeSize]
+	authCode := data[len(data)-zipAESAuthCodeSize:]
+	key := pbkdf2.Key([]byte(password), salt, zipAESIterations, 2*zipAESKeySize+zipAESVerifierSize, sha1.New)
+	a.Equal(key[2*zipAESKeySize:], verifier)
+	mac := hmac.New(sha1.New, key[zipAESKeySize:2*zipAESKeySize])
+	mac.Write(encrypted)
+	a.Equal(mac.Sum(nil)[:zipAESAuthCodeSize], authCode)
+
+	// The encryption is symmetric.
+	block, err := aes.NewCipher(key[:zipAESKeySize])
+	a.NoError(err)
+	var decrypted bytes.Buffer
+	decrypter := &zipAESEncrypter{w: &decrypted, block: block, mac: hmac.New(sha1.New, nil), offset: aes.BlockSize}
+	_, err = decrypter.Write(encrypted)
+	a.NoError(err)
+	got, err := io.ReadAll(flate.NewReader(&decrypted))
+	a.NoError(err)
+	a.Equal(content, string(got))
+}
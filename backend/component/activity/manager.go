@@ -17,8 +17,8 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 
-	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/state"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/webhook"
 	"github.com/bytebase/bytebase/backend/store"
@@ -29,7 +29,8 @@ import (
 
 // Manager is the activity manager.
 type Manager struct {
-	store *store.Store
+	store    *store.Store
+	stateCfg *state.State
 }
 
 // Metadata is the activity metadata.
@@ -38,9 +39,10 @@ type Metadata struct {
 }
 
 // NewManager creates an activity manager.
-func NewManager(store *store.Store) *Manager {
+func NewManager(store *store.Store, stateCfg *state.State) *Manager {
 	return &Manager{
-		store: store,
+		store:    store,
+		stateCfg: stateCfg,
 	}
 }
 
@@ -79,10 +81,7 @@ func (m *Manager) BatchCreateActivitiesForRunTasks(ctx context.Context, tasks []
 	anyActivity := activityList[0]
 
 	activityType := api.ActivityPipelineTaskRunStatusUpdate
-	webhookList, err := m.store.FindProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
-		ProjectID:    &issue.Project.UID,
-		ActivityType: &activityType,
-	})
+	webhookList, err := m.findWebhooks(ctx, issue.Project.UID, activityType)
 	if err != nil {
 		return errors.Wrapf(err, "failed to find project webhook after changing the issue status: %v", issue.Title)
 	}
@@ -123,10 +122,7 @@ func (m *Manager) BatchCreateActivitiesForRunTasks(ctx context.Context, tasks []
 		CreatorName:  user.Name,
 		CreatorEmail: user.Email,
 	}
-	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, &webhookCtx, webhookList)
-
-	return nil
+	return m.enqueueWebhookDeliveries(ctx, &webhookCtx, webhookList)
 }
 
 // BatchCreateActivitiesForSkipTasks creates activities for skipping tasks.
@@ -164,10 +160,7 @@ func (m *Manager) BatchCreateActivitiesForSkipTasks(ctx context.Context, tasks [
 	anyActivity := activityList[0]
 
 	activityType := api.ActivityPipelineTaskRunStatusUpdate
-	webhookList, err := m.store.FindProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
-		ProjectID:    &issue.Project.UID,
-		ActivityType: &activityType,
-	})
+	webhookList, err := m.findWebhooks(ctx, issue.Project.UID, activityType)
 	if err != nil {
 		return errors.Wrapf(err, "failed to find project webhook after changing the issue status: %v", issue.Title)
 	}
@@ -207,10 +200,7 @@ func (m *Manager) BatchCreateActivitiesForSkipTasks(ctx context.Context, tasks [
 		CreatorName:  user.Name,
 		CreatorEmail: user.Email,
 	}
-	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, &webhookCtx, webhookList)
-
-	return nil
+	return m.enqueueWebhookDeliveries(ctx, &webhookCtx, webhookList)
 }
 
 // BatchCreateActivitiesForCancelTaskRuns creates activities for cancelling task runs.
@@ -248,10 +238,7 @@ func (m *Manager) BatchCreateActivitiesForCancelTaskRuns(ctx context.Context, ta
 	anyActivity := activityList[0]
 
 	activityType := api.ActivityPipelineTaskRunStatusUpdate
-	webhookList, err := m.store.FindProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
-		ProjectID:    &issue.Project.UID,
-		ActivityType: &activityType,
-	})
+	webhookList, err := m.findWebhooks(ctx, issue.Project.UID, activityType)
 	if err != nil {
 		return errors.Wrapf(err, "failed to find project webhook after changing the issue status: %v", issue.Title)
 	}
@@ -292,10 +279,7 @@ func (m *Manager) BatchCreateActivitiesForCancelTaskRuns(ctx context.Context, ta
 		CreatorName:  user.Name,
 		CreatorEmail: user.Email,
 	}
-	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, &webhookCtx, webhookList)
-
-	return nil
+	return m.enqueueWebhookDeliveries(ctx, &webhookCtx, webhookList)
 }
 
 // BatchCreateTaskStatusUpdateApprovalActivity creates a batch task status update activities for task approvals.
@@ -333,10 +317,7 @@ func (m *Manager) BatchCreateTaskStatusUpdateApprovalActivity(ctx context.Contex
 	anyActivity := activityList[0]
 
 	activityType := api.ActivityPipelineTaskStatusUpdate
-	webhookList, err := m.store.FindProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
-		ProjectID:    &issue.Project.UID,
-		ActivityType: &activityType,
-	})
+	webhookList, err := m.findWebhooks(ctx, issue.Project.UID, activityType)
 	if err != nil {
 		return errors.Wrapf(err, "failed to find project webhook after changing the issue status: %v", issue.Title)
 	}
@@ -376,10 +357,7 @@ func (m *Manager) BatchCreateTaskStatusUpdateApprovalActivity(ctx context.Contex
 		CreatorName:  user.Name,
 		CreatorEmail: user.Email,
 	}
-	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, &webhookCtx, webhookList)
-
-	return nil
+	return m.enqueueWebhookDeliveries(ctx, &webhookCtx, webhookList)
 }
 
 // CreateActivity creates an activity.
@@ -402,10 +380,7 @@ func (m *Manager) CreateActivity(ctx context.Context, create *store.ActivityMess
 		}
 	}
 
-	webhookList, err := m.store.FindProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
-		ProjectID:    &meta.Issue.Project.UID,
-		ActivityType: &create.Type,
-	})
+	webhookList, err := m.findWebhooks(ctx, meta.Issue.Project.UID, create.Type)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find project webhook after changing the issue status: %v", meta.Issue.Title)
 	}
@@ -423,37 +398,113 @@ func (m *Manager) CreateActivity(ctx context.Context, create *store.ActivityMess
 
 	webhookCtx, err := m.getWebhookContext(ctx, activity, meta, updater)
 	if err != nil {
-		slog.Warn("Failed to get webhook context",
-			slog.String("issue_name", meta.Issue.Title),
-			log.BBError(err))
-		return activity, nil
+		return nil, errors.Wrapf(err, "failed to get webhook context after changing the issue status: %v", meta.Issue.Title)
+	}
+	if err := m.enqueueWebhookDeliveries(ctx, webhookCtx, webhookList); err != nil {
+		return nil, err
 	}
-	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, webhookCtx, webhookList)
 
 	return activity, nil
 }
 
-func postWebhookList(ctx context.Context, webhookCtx *webhook.Context, webhookList []*store.ProjectWebhookMessage) {
+// findWebhooks finds the webhooks of the project and the workspace webhooks subscribing to the activity type.
+func (m *Manager) findWebhooks(ctx context.Context, projectUID int, activityType api.ActivityType) ([]*store.ProjectWebhookMessage, error) {
+	projectWebhooks, err := m.store.FindProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
+		ProjectID:    &projectUID,
+		ActivityType: &activityType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	workspaceWebhooks, err := m.store.FindProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
+		Workspace:    true,
+		ActivityType: &activityType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(projectWebhooks, workspaceWebhooks...), nil
+}
+
+// PostWorkspaceEvent posts the event to the workspace webhooks subscribing to its activity type.
+// It is used for the events not bound to any project, e.g. instance, policy and user changes.
+// Failures are only logged because the event is a side effect of the operation.
+func (m *Manager) PostWorkspaceEvent(ctx context.Context, creatorUID int, webhookCtx *webhook.Context) {
+	activityType := api.ActivityType(webhookCtx.ActivityType)
+	webhookList, err := m.store.FindProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
+		Workspace:    true,
+		ActivityType: &activityType,
+	})
+	if err != nil {
+		slog.Warn("Failed to find workspace webhooks",
+			slog.String("activity type", webhookCtx.ActivityType),
+			log.BBError(err))
+		return
+	}
+	if len(webhookList) == 0 {
+		return
+	}
+
+	creator, err := m.store.GetUserByID(ctx, creatorUID)
+	if err != nil {
+		slog.Warn("Failed to find the creator of the workspace event",
+			slog.String("activity type", webhookCtx.ActivityType),
+			log.BBError(err))
+		return
+	}
+	if creator != nil {
+		webhookCtx.CreatorID = creator.ID
+		webhookCtx.CreatorName = creator.Name
+		webhookCtx.CreatorEmail = creator.Email
+	}
+	if webhookCtx.Level == "" {
+		webhookCtx.Level = webhook.WebhookInfo
+	}
+	if webhookCtx.Link == "" {
+		setting, err := m.store.GetWorkspaceGeneralSetting(ctx)
+		if err != nil {
+			slog.Warn("Failed to get workspace setting",
+				slog.String("activity type", webhookCtx.ActivityType),
+				log.BBError(err))
+			return
+		}
+		webhookCtx.Link = setting.ExternalUrl
+	}
+	if err := m.enqueueWebhookDeliveries(ctx, webhookCtx, webhookList); err != nil {
+		slog.Error("Failed to enqueue the workspace event, the event is lost",
+			slog.String("activity type", webhookCtx.ActivityType),
+			slog.String("title", webhookCtx.Title),
+			log.BBError(err))
+	}
+}
+
+// enqueueWebhookDeliveries stores the deliveries of the webhook event in the outbox.
+// The webhook delivery runner posts them to the external endpoints and retries on failure.
+// The error is returned to the caller, so that the lost event fails the operation visibly.
+func (m *Manager) enqueueWebhookDeliveries(ctx context.Context, webhookCtx *webhook.Context, webhookList []*store.ProjectWebhookMessage) error {
+	if len(webhookList) == 0 {
+		return nil
+	}
+	now := time.Now().Unix()
+	webhookCtx.CreatedTs = now
+	payload, err := json.Marshal(webhookCtx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal webhook context of %q", webhookCtx.Title)
+	}
+	var creates []*store.WebhookDeliveryMessage
 	for _, hook := range webhookList {
-		webhookCtx := *webhookCtx
-		webhookCtx.URL = hook.URL
-		webhookCtx.CreatedTs = time.Now().Unix()
-		go func(webhookCtx *webhook.Context, hook *store.ProjectWebhookMessage) {
-			if err := common.Retry(ctx, func() error {
-				return webhook.Post(hook.Type, *webhookCtx)
-			}); err != nil {
-				// The external webhook endpoint might be invalid which is out of our code control, so we just emit a warning
-				slog.Warn("Failed to post webhook event on activity",
-					slog.String("webhook type", hook.Type),
-					slog.String("webhook name", hook.Title),
-					slog.String("activity type", webhookCtx.ActivityType),
-					slog.String("title", webhookCtx.Title),
-					log.BBError(err))
-				return
-			}
-		}(&webhookCtx, hook)
+		creates = append(creates, &store.WebhookDeliveryMessage{
+			WebhookUID:    hook.ID,
+			Status:        store.WebhookDeliveryPending,
+			Payload:       string(payload),
+			NextAttemptTs: now,
+		})
 	}
+	if err := m.store.CreateWebhookDeliveries(ctx, creates...); err != nil {
+		return errors.Wrapf(err, "failed to create webhook deliveries of %q", webhookCtx.Title)
+	}
+	m.stateCfg.TickleWebhookDelivery()
+	return nil
 }
 
 func (m *Manager) getWebhookContext(ctx context.Context, activity *store.ActivityMessage, meta *Metadata, updater *store.UserMessage) (*webhook.Context, error) {
@@ -0,0 +1,240 @@
+// Package audit records the tamper-evident audit log of the API mutations.
+//
+// Each entry carries the hash of its previous entry, and its own hash is the HMAC of the previous hash and its content
+// keyed with the audit log key. Modifying, inserting or deleting any entry in the log breaks the chain, which is
+// detected by Verify, and the chain can't be rebuilt after tampering without the key.
+//
+// Each replica logs its own chain, identified by the replica ID in the entries, so that the entries of the replicas
+// sharing a sink, e.g. syslog, are verified separately. The replica ID is kept when the chain is resumed after restarts.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+const (
+	// maxEntrySize is the maximum size of an entry line when reading the log.
+	maxEntrySize = 16 * 1024 * 1024
+	// minKeySize is the minimum size of the audit log key.
+	minKeySize = 32
+)
+
+// Entry is an audit log entry.
+type Entry struct {
+	// Replica is the ID of the replica logging the chain.
+	Replica string `json:"replica"`
+	// Sequence is the position of the entry in the chain, starting from 1.
+	Sequence int64     `json:"sequence"`
+	Time     time.Time `json:"time"`
+	// Actor is the principal calling the method, e.g. users/alice@example.com.
+	Actor string `json:"actor"`
+	// Method is the full gRPC method name, e.g. /bytebase.v1.InstanceService/UpdateInstance.
+	Method string `json:"method"`
+	// Resource is the name of the resource the method mutates, e.g. instances/prod.
+	Resource string `json:"resource,omitempty"`
+	// Request is the JSON of the request with the sensitive fields redacted.
+	Request json.RawMessage `json:"request,omitempty"`
+	// Diff is the changed fields of the resource.
+	Diff []*FieldDiff `json:"diff,omitempty"`
+	// Status is the gRPC status code of the method.
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// PrevHash is the hash of the previous entry in the chain.
+	PrevHash string `json:"prevHash"`
+	// Hash is the hex HMAC-SHA256 of the previous hash and the entry with an empty hash.
+	Hash string `json:"hash"`
+}
+
+// FieldDiff is the change of a field of the resource. The missing before or after means the field is added or removed.
+type FieldDiff struct {
+	Path   string          `json:"path"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// computeHash returns the hash of the entry chained to its previous hash.
+func (e *Entry) computeHash(key []byte) (string, error) {
+	c := *e
+	c.Hash = ""
+	b, err := json.Marshal(&c)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal audit entry")
+	}
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(e.PrevHash))
+	_, _ = h.Write(b)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Sink writes the audit log entries somewhere, e.g. a file or a SIEM.
+type Sink interface {
+	// Write writes an entry marshaled as a single line of JSON without the trailing newline.
+	Write(line []byte) error
+	Close() error
+}
+
+// chainSink is the sink persisting the chain, so that the logger can resume the chain after restarts.
+type chainSink interface {
+	lastEntry() (*Entry, error)
+}
+
+// Logger appends the entries to the hash chain and writes them to the sinks.
+type Logger struct {
+	key     []byte
+	replica string
+	sinks   []Sink
+
+	mu       sync.Mutex
+	sequence int64
+	lastHash string
+	closed   bool
+}
+
+// NewLogger creates a logger of the replica writing to the sinks, and the chain is keyed with the key.
+// The chain continues from the last entry of the first sink persisting it, e.g. the file sink, which is local to the
+// replica, so that the chain is not broken by the restarts, and the replica ID of the chain is kept.
+func NewLogger(key []byte, replica string, sinks ...Sink) (*Logger, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	l := &Logger{key: key, replica: replica, sinks: sinks}
+	for _, sink := range sinks {
+		cs, ok := sink.(chainSink)
+		if !ok {
+			continue
+		}
+		last, err := cs.lastEntry()
+		if err != nil {
+			return nil, err
+		}
+		if last != nil {
+			l.replica = last.Replica
+			l.sequence = last.Sequence
+			l.lastHash = last.Hash
+		}
+		break
+	}
+	return l, nil
+}
+
+// Log chains the entry and writes it to all the sinks.
+// The entry is chained even if some sinks fail, and the error of the sinks is returned.
+func (l *Logger) Log(entry *Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return errors.New("audit logger is closed")
+	}
+
+	entry.Replica = l.replica
+	entry.Sequence = l.sequence + 1
+	entry.PrevHash = l.lastHash
+	hash, err := entry.computeHash(l.key)
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal audit entry")
+	}
+	l.sequence = entry.Sequence
+	l.lastHash = entry.Hash
+
+	var errs error
+	for _, sink := range l.sinks {
+		errs = multierr.Append(errs, sink.Write(line))
+	}
+	return errs
+}
+
+// Close closes all the sinks. The entries logged after closing are rejected.
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	var errs error
+	for _, sink := range l.sinks {
+		errs = multierr.Append(errs, sink.Close())
+	}
+	return errs
+}
+
+// Verify verifies the chains of the audit log in JSON Lines with the key, and returns the number of the verified entries.
+// The entries of each replica are chained in order, and the first entry of each replica is trusted as the start of the
+// chain, so that a rotated log can be verified on its own.
+func Verify(r io.Reader, key []byte) (int, error) {
+	if err := checkKey(key); err != nil {
+		return 0, err
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+	// lastEntries is the last entry of each replica.
+	lastEntries := map[string]*Entry{}
+	count := 0
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return count, errors.Wrapf(err, "failed to unmarshal audit entry after %d entries", count)
+		}
+		if prev, ok := lastEntries[entry.Replica]; ok {
+			if entry.Sequence != prev.Sequence+1 {
+				return count, errors.Errorf("audit entry sequence %d of replica %q follows %d, entries are missing", entry.Sequence, entry.Replica, prev.Sequence)
+			}
+			if entry.PrevHash != prev.Hash {
+				return count, errors.Errorf("audit entry %d of replica %q doesn't chain to the previous entry", entry.Sequence, entry.Replica)
+			}
+		}
+		hash, err := entry.computeHash(key)
+		if err != nil {
+			return count, err
+		}
+		if !hmac.Equal([]byte(hash), []byte(entry.Hash)) {
+			return count, errors.Errorf("audit entry %d of replica %q is tampered, hash mismatch", entry.Sequence, entry.Replica)
+		}
+		lastEntries[entry.Replica] = entry
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		return count, errors.Wrap(err, "failed to read audit log")
+	}
+	return count, nil
+}
+
+func checkKey(key []byte) error {
+	if len(key) < minKeySize {
+		return errors.Errorf("the audit log key must be at least %d bytes", minKeySize)
+	}
+	return nil
+}
+
+// ReadKeyFile reads the audit log key from the file. The surrounding whitespaces are trimmed.
+func ReadKeyFile(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read audit log key file %q", path)
+	}
+	key := bytes.TrimSpace(b)
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
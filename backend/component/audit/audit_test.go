@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func writeEntries(t *testing.T, logger *Logger, methods ...string) {
+	for _, method := range methods {
+		require.NoError(t, logger.Log(&Entry{
+			Time:    time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+			Actor:   "users/alice@example.com",
+			Method:  method,
+			Request: []byte(`{"name": "instances/<prod>"}`),
+			Diff:    []*FieldDiff{{Path: "title", Before: []byte(`"Prod"`)}},
+			Status:  "OK",
+		}))
+	}
+}
+
+func TestChain(t *testing.T) {
+	a := require.New(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewFileSink(path)
+	a.NoError(err)
+	_, err = NewLogger([]byte("short"), "replica-1", sink)
+	a.ErrorContains(err, "at least 32 bytes")
+	logger, err := NewLogger(testKey, "replica-1", sink)
+	a.NoError(err)
+	writeEntries(t, logger, "/bytebase.v1.InstanceService/UpdateInstance", "/bytebase.v1.SettingService/SetSetting")
+	a.NoError(logger.Close())
+	a.Error(logger.Log(&Entry{}))
+
+	// The chain is resumed from the file by the restarted replica.
+	sink, err = NewFileSink(path)
+	a.NoError(err)
+	logger, err = NewLogger(testKey, "replica-2", sink)
+	a.NoError(err)
+	writeEntries(t, logger, "/bytebase.v1.ProjectService/SetIamPolicy")
+	a.NoError(logger.Close())
+
+	content, err := os.ReadFile(path)
+	a.NoError(err)
+	count, err := Verify(bytes.NewReader(content), testKey)
+	a.NoError(err)
+	a.Equal(3, count)
+	a.NotContains(string(content), "replica-2")
+	// The chain can't be verified without the key.
+	_, err = Verify(bytes.NewReader(content), []byte("fedcba9876543210fedcba9876543210"))
+	a.ErrorContains(err, "audit entry 1 of replica \"replica-1\" is tampered")
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	a.Len(lines, 3)
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{
+			name:  "tampered",
+			lines: []string{lines[0], strings.Replace(lines[1], "SetSetting", "GetSetting", 1), lines[2]},
+			want:  "audit entry 2 of replica \"replica-1\" is tampered",
+		},
+		{
+			name:  "deleted",
+			lines: []string{lines[0], lines[2]},
+			want:  "entries are missing",
+		},
+		{
+			name:  "reordered",
+			lines: []string{lines[1], lines[0]},
+			want:  "entries are missing",
+		},
+	}
+	for _, test := range tests {
+		_, err := Verify(strings.NewReader(strings.Join(test.lines, "\n")), testKey)
+		a.ErrorContains(err, test.want, test.name)
+	}
+	// The rotated log is verified on its own.
+	count, err = Verify(strings.NewReader(strings.Join(lines[1:], "\n")), testKey)
+	a.NoError(err)
+	a.Equal(2, count)
+}
+
+func TestChainReplicas(t *testing.T) {
+	a := require.New(t)
+	// The replicas write to the same sink, e.g. syslog.
+	var lines []string
+	sink := &memorySink{lines: &lines}
+	logger1, err := NewLogger(testKey, "replica-1", sink)
+	a.NoError(err)
+	logger2, err := NewLogger(testKey, "replica-2", sink)
+	a.NoError(err)
+	writeEntries(t, logger1, "/bytebase.v1.InstanceService/UpdateInstance")
+	writeEntries(t, logger2, "/bytebase.v1.InstanceService/UpdateInstance")
+	writeEntries(t, logger1, "/bytebase.v1.SettingService/SetSetting")
+	writeEntries(t, logger2, "/bytebase.v1.SettingService/SetSetting")
+	writeEntries(t, logger1, "/bytebase.v1.ProjectService/SetIamPolicy")
+
+	count, err := Verify(strings.NewReader(strings.Join(lines, "\n")), testKey)
+	a.NoError(err)
+	a.Equal(5, count)
+	_, err = Verify(strings.NewReader(strings.Join([]string{lines[0], lines[1], lines[3], lines[4]}, "\n")), testKey)
+	a.ErrorContains(err, "audit entry sequence 3 of replica \"replica-1\" follows 1")
+}
+
+type memorySink struct {
+	lines *[]string
+}
+
+func (s *memorySink) Write(line []byte) error {
+	*s.lines = append(*s.lines, string(line))
+	return nil
+}
+
+func (*memorySink) Close() error {
+	return nil
+}
@@ -0,0 +1,207 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"log/syslog"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+)
+
+// FileSink appends the entries to a file in JSON Lines. It persists the chain.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink opens the file to append the entries to, and creates it if not exists.
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open audit log file %q", path)
+	}
+	return &FileSink{file: file}, nil
+}
+
+// Write appends the entry line to the file.
+func (s *FileSink) Write(line []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return errors.Wrapf(err, "failed to write audit log file %q", s.file.Name())
+	}
+	return nil
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// lastEntry returns the last entry in the file, or nil if the file is empty.
+func (s *FileSink) lastEntry() (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := os.Open(s.file.Name())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open audit log file %q", s.file.Name())
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+	var last []byte
+	for scanner.Scan() {
+		if len(scanner.Bytes()) > 0 {
+			last = append(last[:0], scanner.Bytes()...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read audit log file %q", s.file.Name())
+	}
+	if last == nil {
+		return nil, nil
+	}
+	entry := &Entry{}
+	if err := json.Unmarshal(last, entry); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the last entry of audit log file %q", s.file.Name())
+	}
+	return entry, nil
+}
+
+// SyslogSink writes the entries to syslog.
+type SyslogSink struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogSink connects to the syslog address, which is "local" for the local syslog daemon,
+// or udp://host:port or tcp://host:port for a remote one.
+func NewSyslogSink(address string) (*SyslogSink, error) {
+	var network, raddr string
+	if address != "local" {
+		u, err := url.Parse(address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid syslog address %q", address)
+		}
+		if u.Scheme != "udp" && u.Scheme != "tcp" {
+			return nil, errors.Errorf("invalid syslog address %q, the scheme must be udp or tcp", address)
+		}
+		network, raddr = u.Scheme, u.Host
+	}
+	writer, err := syslog.Dial(network, raddr, syslog.LOG_INFO|syslog.LOG_AUTH, "bytebase-audit")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to syslog %q", address)
+	}
+	return &SyslogSink{writer: writer}, nil
+}
+
+// Write writes the entry line to syslog.
+func (s *SyslogSink) Write(line []byte) error {
+	if err := s.writer.Info(string(line)); err != nil {
+		return errors.Wrap(err, "failed to write audit log to syslog")
+	}
+	return nil
+}
+
+// Close closes the connection to syslog.
+func (s *SyslogSink) Close() error {
+	return s.writer.Close()
+}
+
+const (
+	httpSinkBufferSize = 1000
+	httpSinkRetries    = 3
+	httpSinkTimeout    = 10 * time.Second
+)
+
+// HTTPSink posts the entries to an HTTP endpoint, e.g. the HTTP event collector of a SIEM.
+// The entries are posted asynchronously in order, so that the slow endpoint doesn't block the API.
+// The entries are dropped if the buffer is full, which is detected by the sequence gap on the receiver.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+	lines  chan []byte
+	done   chan struct{}
+}
+
+// NewHTTPSink creates a sink posting the entries to the URL.
+func NewHTTPSink(rawURL string) (*HTTPSink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, errors.Errorf("invalid audit log URL %q", rawURL)
+	}
+	s := &HTTPSink{
+		url:    rawURL,
+		client: &http.Client{Timeout: httpSinkTimeout},
+		lines:  make(chan []byte, httpSinkBufferSize),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+// Write queues the entry line to post.
+func (s *HTTPSink) Write(line []byte) error {
+	select {
+	case s.lines <- line:
+		return nil
+	default:
+		return errors.New("audit log HTTP sink buffer is full, the entry is dropped")
+	}
+}
+
+// Close posts the queued entries and stops the sink.
+func (s *HTTPSink) Close() error {
+	close(s.lines)
+	<-s.done
+	return nil
+}
+
+func (s *HTTPSink) run() {
+	defer close(s.done)
+	for line := range s.lines {
+		var err error
+		for i := 0; i < httpSinkRetries; i++ {
+			if i > 0 {
+				time.Sleep(time.Duration(i) * time.Second)
+			}
+			if err = s.post(line); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			slog.Warn("Failed to post the audit log entry", log.BBError(err))
+		}
+	}
+}
+
+func (s *HTTPSink) post(line []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), httpSinkTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(line))
+	if err != nil {
+		return errors.Wrap(err, "failed to create audit log request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to post audit log to %q", s.url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("failed to post audit log to %q, status %s", s.url, resp.Status)
+	}
+	return nil
+}
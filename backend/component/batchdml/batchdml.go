@@ -0,0 +1,291 @@
+// Package batchdml executes a large UPDATE or DELETE statement in batches.
+//
+// The statement must change a single table with a primary key. The execution walks the primary key in ascending
+// order and executes the statement restricted to a key range of at most BatchSize rows in each batch, which commits
+// on its own, so that the locks are held briefly and the replicas can keep up. The last key of the completed batches
+// is the checkpoint to resume the execution from, which is saved to a progress table on the target database in the
+// transaction of each batch.
+package batchdml
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	// DefaultBatchSize is the batch size if it's not configured.
+	DefaultBatchSize = 1000
+	// MaxBatchSize is the max batch size.
+	MaxBatchSize = 100000
+	// replicaLagCheckInterval is the interval to check the replica lag while the execution is paused.
+	replicaLagCheckInterval = 5 * time.Second
+)
+
+// Config is the config of the batched execution.
+type Config struct {
+	// ID identifies the execution on the target database, e.g. the task ID. It names the progress table.
+	ID string
+	// BatchSize is the max number of rows in a batch.
+	BatchSize int
+	// Sleep is the pause between batches.
+	Sleep time.Duration
+	// MaxReplicaLag pauses the execution while the replica lag is greater than it. Zero disables the check.
+	MaxReplicaLag time.Duration
+	// GetReplicaLag gets the current replica lag. It's required if MaxReplicaLag is set.
+	GetReplicaLag func(ctx context.Context) (time.Duration, error)
+}
+
+// Checkpoint is the progress of the completed batches, which is saved to the progress table.
+type Checkpoint struct {
+	// LastKey is the primary key of the last row in the completed batches in text. Nil means no batch is completed.
+	LastKey          []string
+	CompletedBatches int64
+	AffectedRows     int64
+	// Done is true if all batches are completed.
+	Done bool
+}
+
+// Progress is the progress of the execution.
+type Progress struct {
+	// TotalBatches is the estimated number of batches.
+	TotalBatches     int64
+	CompletedBatches int64
+	AffectedRows     int64
+	// ReplicaLag is set if the execution is paused for the replica lag.
+	ReplicaLag time.Duration
+}
+
+// Execution is the batched execution of an UPDATE or DELETE statement.
+type Execution struct {
+	db      *sql.DB
+	dialect dialect
+	config  Config
+	table   string
+	key     primaryKey
+	// progressTable is the table keeping the checkpoint on the target database.
+	progressTable string
+	statementHash string
+}
+
+// dialect is implemented by the supported engines.
+type dialect interface {
+	// getPrimaryKey gets the primary key of the table changed by the statement.
+	getPrimaryKey(ctx context.Context, db *sql.DB) (primaryKey, error)
+	// getEstimatedRows gets the estimated number of rows in the table.
+	getEstimatedRows(ctx context.Context, db *sql.DB) (int64, error)
+	// boundaryQuery returns the query of the last key in the next batch, whose arguments are the last key of the
+	// previous batch if hasLowerBound.
+	boundaryQuery(key primaryKey, batchSize int, hasLowerBound bool) string
+	// batchStatement returns the statement restricted to the key range, whose arguments are the exclusive lower bound
+	// if hasLowerBound followed by the inclusive upper bound if hasUpperBound.
+	batchStatement(key primaryKey, hasLowerBound, hasUpperBound bool) (string, error)
+	// tableName returns the name of the table changed by the statement.
+	tableName() string
+	// progressTableName returns the name of the progress table in the schema of the table changed by the statement.
+	progressTableName(id string) string
+	// assignedColumns returns the columns assigned by the UPDATE statement.
+	assignedColumns() []string
+	// placeholder returns the i-th parameter placeholder starting from 1.
+	placeholder(i int) string
+}
+
+type primaryKey []column
+
+type column struct {
+	name string
+	// dataType is used by PostgreSQL to cast the key values in text.
+	dataType string
+}
+
+// NewExecution creates the batched execution of the statement, which must be a single UPDATE or DELETE statement
+// changing a table with the primary key.
+func NewExecution(ctx context.Context, engine storepb.Engine, db *sql.DB, statement string, config Config) (*Execution, error) {
+	var d dialect
+	var err error
+	switch engine {
+	case storepb.Engine_MYSQL, storepb.Engine_MARIADB:
+		d, err = newMySQLDialect(statement)
+	case storepb.Engine_POSTGRES:
+		d, err = newPostgresDialect(statement)
+	default:
+		return nil, errors.Errorf("batched execution doesn't support engine %s", engine)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+	if config.BatchSize > MaxBatchSize {
+		return nil, errors.Errorf("batch size %d exceeds the max batch size %d", config.BatchSize, MaxBatchSize)
+	}
+	if config.ID == "" {
+		return nil, errors.Errorf("the execution ID is required")
+	}
+	if config.MaxReplicaLag > 0 && config.GetReplicaLag == nil {
+		return nil, errors.Errorf("the replica lag check is not available")
+	}
+	key, err := d.getPrimaryKey(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) == 0 {
+		return nil, errors.Errorf("batched execution requires the primary key, but table %s has no primary key", d.tableName())
+	}
+	if err := checkAssignments(engine, key, d.assignedColumns()); err != nil {
+		return nil, err
+	}
+	return &Execution{
+		db:            db,
+		dialect:       d,
+		config:        config,
+		table:         d.tableName(),
+		key:           key,
+		progressTable: d.progressTableName(config.ID),
+		statementHash: getStatementHash(statement),
+	}, nil
+}
+
+// checkAssignments checks the statement doesn't assign to the primary key. The batches walk the primary key, so the
+// rows moved after the current batch would be changed again, and the rows moved before it would be skipped.
+func checkAssignments(engine storepb.Engine, key primaryKey, assignedColumns []string) error {
+	for _, assigned := range assignedColumns {
+		for _, c := range key {
+			// The column names are case-insensitive in MySQL. PostgreSQL folds the unquoted names to lower case.
+			if assigned == c.name || (engine != storepb.Engine_POSTGRES && strings.EqualFold(assigned, c.name)) {
+				return errors.Errorf("batched execution doesn't support updating the primary key column %q", c.name)
+			}
+		}
+	}
+	return nil
+}
+
+// Table returns the name of the table changed by the statement.
+func (e *Execution) Table() string {
+	return e.table
+}
+
+// Run executes the batches after the checkpoint in the progress table, and calls report after each batch with the
+// updated checkpoint. The progress table is dropped after all batches are completed.
+func (e *Execution) Run(ctx context.Context, report func(Checkpoint, Progress) error) error {
+	checkpoint, err := e.loadCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	if checkpoint.Done {
+		return e.dropProgressTable(ctx)
+	}
+	estimatedRows, err := e.dialect.getEstimatedRows(ctx, e.db)
+	if err != nil {
+		return err
+	}
+	progress := Progress{
+		CompletedBatches: checkpoint.CompletedBatches,
+		AffectedRows:     checkpoint.AffectedRows,
+	}
+	updateTotalBatches := func() {
+		progress.TotalBatches = (estimatedRows + int64(e.config.BatchSize) - 1) / int64(e.config.BatchSize)
+		// The estimation may be stale.
+		if progress.TotalBatches < progress.CompletedBatches+1 {
+			progress.TotalBatches = progress.CompletedBatches + 1
+		}
+	}
+	updateTotalBatches()
+
+	for !checkpoint.Done {
+		if err := e.waitForReplicas(ctx, func(lag time.Duration) error {
+			progress.ReplicaLag = lag
+			return report(checkpoint, progress)
+		}); err != nil {
+			return err
+		}
+		progress.ReplicaLag = 0
+
+		upperKey, err := e.getUpperKey(ctx, checkpoint.LastKey)
+		if err != nil {
+			return err
+		}
+		statement, err := e.dialect.batchStatement(e.key, checkpoint.LastKey != nil, upperKey != nil)
+		if err != nil {
+			return err
+		}
+		var args []any
+		for _, v := range checkpoint.LastKey {
+			args = append(args, v)
+		}
+		for _, v := range upperKey {
+			args = append(args, v)
+		}
+		if checkpoint, err = e.executeBatch(ctx, statement, args, upperKey, checkpoint); err != nil {
+			return err
+		}
+		progress.CompletedBatches = checkpoint.CompletedBatches
+		progress.AffectedRows = checkpoint.AffectedRows
+		updateTotalBatches()
+		if checkpoint.Done {
+			progress.TotalBatches = progress.CompletedBatches
+		}
+		if err := report(checkpoint, progress); err != nil {
+			return err
+		}
+
+		if !checkpoint.Done && e.config.Sleep > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(e.config.Sleep):
+			}
+		}
+	}
+	return e.dropProgressTable(ctx)
+}
+
+// getUpperKey gets the last key of the next batch. It returns nil if the next batch is the last batch.
+func (e *Execution) getUpperKey(ctx context.Context, lastKey []string) ([]string, error) {
+	var args []any
+	for _, v := range lastKey {
+		args = append(args, v)
+	}
+	values := make([]string, len(e.key))
+	dest := make([]any, len(e.key))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := e.db.QueryRowContext(ctx, e.dialect.boundaryQuery(e.key, e.config.BatchSize, lastKey != nil), args...).Scan(dest...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get the key range of the next batch")
+	}
+	return values, nil
+}
+
+// waitForReplicas blocks while the replica lag is greater than MaxReplicaLag, and calls paused with the lag.
+func (e *Execution) waitForReplicas(ctx context.Context, paused func(time.Duration) error) error {
+	if e.config.MaxReplicaLag <= 0 {
+		return nil
+	}
+	for {
+		lag, err := e.config.GetReplicaLag(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the replica lag")
+		}
+		if lag <= e.config.MaxReplicaLag {
+			return nil
+		}
+		if err := paused(lag); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(replicaLagCheckInterval):
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package batchdml
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestNewPostgresDialect(t *testing.T) {
+	tests := []struct {
+		statement string
+		table     string
+		wantErr   bool
+	}{
+		{
+			statement: "UPDATE t SET c = 1 WHERE c = 0;",
+			table:     `"t"`,
+		},
+		{
+			statement: `DELETE FROM "Public"."Order" WHERE created < now() - interval '1 year';`,
+			table:     `"Public"."Order"`,
+		},
+		{
+			statement: "UPDATE t SET c = s.c FROM s WHERE s.id = t.id;",
+			wantErr:   true,
+		},
+		{
+			statement: "DELETE FROM t WHERE c = 0 RETURNING id;",
+			wantErr:   true,
+		},
+		{
+			statement: "DELETE FROM t; DELETE FROM s;",
+			wantErr:   true,
+		},
+		{
+			statement: "INSERT INTO t VALUES (1);",
+			wantErr:   true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		d, err := newPostgresDialect(test.statement)
+		if test.wantErr {
+			a.Error(err, test.statement)
+			continue
+		}
+		a.NoError(err, test.statement)
+		a.Equal(test.table, d.tableName())
+	}
+}
+
+func TestPostgresBatchStatement(t *testing.T) {
+	a := require.New(t)
+	key := primaryKey{{name: "a", dataType: "integer"}, {name: "b", dataType: "text"}}
+
+	d, err := newPostgresDialect("UPDATE t SET c = 1 WHERE c = 0 OR d = 0")
+	a.NoError(err)
+	got, err := d.batchStatement(key, true, true)
+	a.NoError(err)
+	a.Equal(`UPDATE t SET c = 1 WHERE (c = 0 OR d = 0) AND ((a, b) > ($1::text::int, $2::text::text) AND (a, b) <= ($3::text::int, $4::text::text))`, got)
+	// The original statement is kept.
+	got, err = d.batchStatement(key, false, false)
+	a.NoError(err)
+	a.Equal(`UPDATE t SET c = 1 WHERE c = 0 OR d = 0`, got)
+
+	d, err = newPostgresDialect("DELETE FROM t")
+	a.NoError(err)
+	got, err = d.batchStatement(key[:1], false, true)
+	a.NoError(err)
+	a.Equal(`DELETE FROM t WHERE a <= $1::text::int`, got)
+
+	a.Equal(`SELECT "a"::TEXT, "b"::TEXT FROM "t" WHERE ("a", "b") > ($1::TEXT::integer, $2::TEXT::text) ORDER BY "a", "b" LIMIT 1 OFFSET 99`, d.boundaryQuery(key, 100, true))
+}
+
+func TestNewMySQLDialect(t *testing.T) {
+	tests := []struct {
+		statement string
+		table     string
+		wantErr   bool
+	}{
+		{
+			statement: "UPDATE t SET c = 1 WHERE c = 0;",
+			table:     "`t`",
+		},
+		{
+			statement: "DELETE FROM db.t WHERE c = 0;",
+			table:     "`db`.`t`",
+		},
+		{
+			statement: "UPDATE t, s SET t.c = s.c WHERE s.id = t.id;",
+			wantErr:   true,
+		},
+		{
+			statement: "DELETE FROM t WHERE c = 0 ORDER BY id LIMIT 10;",
+			wantErr:   true,
+		},
+		{
+			statement: "DELETE t FROM t JOIN s ON s.id = t.id;",
+			wantErr:   true,
+		},
+		{
+			statement: "SELECT * FROM t;",
+			wantErr:   true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		d, err := newMySQLDialect(test.statement)
+		if test.wantErr {
+			a.Error(err, test.statement)
+			continue
+		}
+		a.NoError(err, test.statement)
+		a.Equal(test.table, d.tableName())
+	}
+}
+
+func TestMySQLBatchStatement(t *testing.T) {
+	a := require.New(t)
+	key := primaryKey{{name: "a"}, {name: "b"}}
+
+	d, err := newMySQLDialect("UPDATE t SET c = 1 WHERE c = 0 OR d = 0")
+	a.NoError(err)
+	got, err := d.batchStatement(key, true, true)
+	a.NoError(err)
+	a.Equal("UPDATE `t` SET `c`=1 WHERE (`c`=0 OR `d`=0) AND ROW(`a`,`b`)>ROW(?,?) AND ROW(`a`,`b`)<=ROW(?,?)", got)
+
+	d, err = newMySQLDialect("DELETE FROM t")
+	a.NoError(err)
+	got, err = d.batchStatement(key, true, false)
+	a.NoError(err)
+	a.Equal("DELETE FROM `t` WHERE ROW(`a`,`b`)>ROW(?,?)", got)
+
+	a.Equal("SELECT `a`, `b` FROM `t` ORDER BY `a`, `b` LIMIT 1 OFFSET 999", d.boundaryQuery(key, 1000, false))
+}
+
+func TestCheckAssignments(t *testing.T) {
+	tests := []struct {
+		engine    storepb.Engine
+		statement string
+		wantErr   bool
+	}{
+		{engine: storepb.Engine_MYSQL, statement: "UPDATE t SET c = c + 1 WHERE c > 0"},
+		{engine: storepb.Engine_MYSQL, statement: "DELETE FROM t WHERE c > 0"},
+		{engine: storepb.Engine_MYSQL, statement: "UPDATE t SET id = id + 100", wantErr: true},
+		{engine: storepb.Engine_MYSQL, statement: "UPDATE t SET c = 1, t.ID = 2", wantErr: true},
+		{engine: storepb.Engine_POSTGRES, statement: "UPDATE t SET c = c + 1 WHERE c > 0"},
+		{engine: storepb.Engine_POSTGRES, statement: "UPDATE t SET id = id + 100", wantErr: true},
+		{engine: storepb.Engine_POSTGRES, statement: "UPDATE t SET (c, id) = (1, 2)", wantErr: true},
+		{engine: storepb.Engine_POSTGRES, statement: `UPDATE t SET "ID" = 1`},
+	}
+
+	a := require.New(t)
+	key := primaryKey{{name: "id"}}
+	for _, test := range tests {
+		var d dialect
+		var err error
+		if test.engine == storepb.Engine_POSTGRES {
+			d, err = newPostgresDialect(test.statement)
+		} else {
+			d, err = newMySQLDialect(test.statement)
+		}
+		a.NoError(err, test.statement)
+		err = checkAssignments(test.engine, key, d.assignedColumns())
+		if test.wantErr {
+			a.Error(err, test.statement)
+		} else {
+			a.NoError(err, test.statement)
+		}
+	}
+}
+
+func TestRunSavesCheckpointWithBatch(t *testing.T) {
+	a := require.New(t)
+	fake := &fakeDB{
+		query: func(query string) [][]driver.Value {
+			switch {
+			case strings.Contains(query, "KEY_COLUMN_USAGE"):
+				return [][]driver.Value{{"id"}}
+			case strings.Contains(query, "TABLE_ROWS"):
+				return [][]driver.Value{{int64(3)}}
+			case strings.Contains(query, "WHERE (`id`) > (?)"):
+				// The last batch.
+				return nil
+			case strings.Contains(query, "OFFSET"):
+				return [][]driver.Value{{"2"}}
+			}
+			return nil
+		},
+	}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	execution, err := NewExecution(context.Background(), storepb.Engine_MYSQL, db, "UPDATE t SET c = c + 1", Config{ID: "101", BatchSize: 2})
+	a.NoError(err)
+	var checkpoints []Checkpoint
+	a.NoError(execution.Run(context.Background(), func(checkpoint Checkpoint, _ Progress) error {
+		checkpoints = append(checkpoints, checkpoint)
+		return nil
+	}))
+	a.Equal([]Checkpoint{
+		{LastKey: []string{"2"}, CompletedBatches: 1, AffectedRows: 1},
+		{LastKey: []string{"2"}, CompletedBatches: 2, AffectedRows: 2, Done: true},
+	}, checkpoints)
+	a.Equal([]string{
+		"CREATE TABLE IF NOT EXISTS `_bb_batch_101` " + progressTableColumns,
+		"BEGIN",
+		"UPDATE `t` SET `c`=`c`+1 WHERE (`id`)<=(?)",
+		"DELETE FROM `_bb_batch_101`",
+		"INSERT INTO `_bb_batch_101` (statement_hash, last_key, completed_batches, affected_rows, done) VALUES (?, ?, ?, ?, ?)",
+		"COMMIT",
+		"BEGIN",
+		"UPDATE `t` SET `c`=`c`+1 WHERE (`id`)>(?)",
+		"DELETE FROM `_bb_batch_101`",
+		"INSERT INTO `_bb_batch_101` (statement_hash, last_key, completed_batches, affected_rows, done) VALUES (?, ?, ?, ?, ?)",
+		"COMMIT",
+		"DROP TABLE IF EXISTS `_bb_batch_101`",
+	}, fake.statements)
+}
+
+func TestRunRollsBackCheckpointWithBatch(t *testing.T) {
+	a := require.New(t)
+	fake := &fakeDB{
+		query: func(query string) [][]driver.Value {
+			if strings.Contains(query, "KEY_COLUMN_USAGE") {
+				return [][]driver.Value{{"id"}}
+			}
+			if strings.Contains(query, "TABLE_ROWS") {
+				return [][]driver.Value{{int64(3)}}
+			}
+			return nil
+		},
+		exec: func(statement string) error {
+			if strings.HasPrefix(statement, "INSERT INTO") {
+				return errors.New("disk full")
+			}
+			return nil
+		},
+	}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	execution, err := NewExecution(context.Background(), storepb.Engine_MYSQL, db, "DELETE FROM t", Config{ID: "101"})
+	a.NoError(err)
+	err = execution.Run(context.Background(), func(Checkpoint, Progress) error {
+		return nil
+	})
+	a.ErrorContains(err, "disk full")
+	// The batch is rolled back with the checkpoint, and the progress table is kept to resume from.
+	a.Equal("ROLLBACK", fake.statements[len(fake.statements)-1])
+}
+
+// fakeDB is the database/sql connector recording the executed statements and the transactions.
+type fakeDB struct {
+	// query returns the rows of the query.
+	query func(query string) [][]driver.Value
+	exec  func(statement string) error
+
+	statements []string
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{db: f}, nil
+}
+
+func (*fakeDB) Driver() driver.Driver {
+	return nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (*fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (*fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.db.statements = append(c.db.statements, "BEGIN")
+	return &fakeTx{db: c.db}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.db.statements = append(c.db.statements, query)
+	if c.db.exec != nil {
+		if err := c.db.exec(query); err != nil {
+			return nil, err
+		}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	rows := c.db.query(query)
+	columns := 1
+	if len(rows) > 0 {
+		columns = len(rows[0])
+	}
+	return &fakeRows{columns: columns, rows: rows}, nil
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.db.statements = append(tx.db.statements, "COMMIT")
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.db.statements = append(tx.db.statements, "ROLLBACK")
+	return nil
+}
+
+type fakeRows struct {
+	columns int
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return make([]string, r.columns)
+}
+
+func (*fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
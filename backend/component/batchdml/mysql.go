@@ -0,0 +1,186 @@
+package batchdml
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/opcode"
+	"github.com/pkg/errors"
+
+	tidbparser "github.com/bytebase/bytebase/backend/plugin/parser/tidb"
+)
+
+type mysqlDialect struct {
+	stmt  ast.StmtNode
+	table *ast.TableName
+	// where points to the WHERE clause of the statement, which is restricted to the key range of the batch.
+	where *ast.ExprNode
+}
+
+func newMySQLDialect(statement string) (*mysqlDialect, error) {
+	nodes, err := tidbparser.ParseTiDB(statement, "", "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse statement")
+	}
+	if len(nodes) != 1 {
+		return nil, errors.Errorf("batched execution requires a single UPDATE or DELETE statement, but got %d statements", len(nodes))
+	}
+	d := &mysqlDialect{stmt: nodes[0]}
+	var tableRefs *ast.TableRefsClause
+	switch stmt := nodes[0].(type) {
+	case *ast.UpdateStmt:
+		if stmt.With != nil || stmt.Order != nil || stmt.Limit != nil || stmt.MultipleTable {
+			return nil, errors.Errorf("batched execution doesn't support UPDATE with WITH, ORDER BY, LIMIT or multiple tables")
+		}
+		tableRefs, d.where = stmt.TableRefs, &stmt.Where
+	case *ast.DeleteStmt:
+		if stmt.With != nil || stmt.Order != nil || stmt.Limit != nil || stmt.IsMultiTable {
+			return nil, errors.Errorf("batched execution doesn't support DELETE with WITH, ORDER BY, LIMIT or multiple tables")
+		}
+		tableRefs, d.where = stmt.TableRefs, &stmt.Where
+	default:
+		return nil, errors.Errorf("batched execution only supports UPDATE and DELETE statements")
+	}
+	if tableRefs == nil || tableRefs.TableRefs == nil || tableRefs.TableRefs.Right != nil {
+		return nil, errors.Errorf("batched execution only supports the statement changing a single table")
+	}
+	source, ok := tableRefs.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return nil, errors.Errorf("batched execution only supports the statement changing a single table")
+	}
+	if d.table, ok = source.Source.(*ast.TableName); !ok {
+		return nil, errors.Errorf("batched execution only supports the statement changing a single table")
+	}
+	return d, nil
+}
+
+func (d *mysqlDialect) tableName() string {
+	if d.table.Schema.O == "" {
+		return quoteMySQLIdentifier(d.table.Name.O)
+	}
+	return quoteMySQLIdentifier(d.table.Schema.O) + "." + quoteMySQLIdentifier(d.table.Name.O)
+}
+
+func (d *mysqlDialect) progressTableName(id string) string {
+	name := quoteMySQLIdentifier(fmt.Sprintf("_bb_batch_%s", id))
+	if d.table.Schema.O == "" {
+		return name
+	}
+	return quoteMySQLIdentifier(d.table.Schema.O) + "." + name
+}
+
+func (d *mysqlDialect) assignedColumns() []string {
+	update, ok := d.stmt.(*ast.UpdateStmt)
+	if !ok {
+		return nil
+	}
+	var columns []string
+	for _, assignment := range update.List {
+		columns = append(columns, assignment.Column.Name.O)
+	}
+	return columns
+}
+
+func (*mysqlDialect) placeholder(int) string {
+	return "?"
+}
+
+func (d *mysqlDialect) getPrimaryKey(ctx context.Context, db *sql.DB) (primaryKey, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT COLUMN_NAME FROM information_schema.KEY_COLUMN_USAGE
+		WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ? AND CONSTRAINT_NAME = 'PRIMARY'
+		ORDER BY ORDINAL_POSITION`, d.table.Schema.O, d.table.Name.O)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get primary key of %s", d.tableName())
+	}
+	defer rows.Close()
+	var key primaryKey
+	for rows.Next() {
+		var c column
+		if err := rows.Scan(&c.name); err != nil {
+			return nil, err
+		}
+		key = append(key, c)
+	}
+	return key, rows.Err()
+}
+
+func (d *mysqlDialect) getEstimatedRows(ctx context.Context, db *sql.DB) (int64, error) {
+	var rows sql.NullInt64
+	if err := db.QueryRowContext(ctx, `
+		SELECT TABLE_ROWS FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ?`, d.table.Schema.O, d.table.Name.O).Scan(&rows); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.Errorf("table %s not found", d.tableName())
+		}
+		return 0, errors.Wrapf(err, "failed to estimate rows of %s", d.tableName())
+	}
+	return rows.Int64, nil
+}
+
+func (d *mysqlDialect) boundaryQuery(key primaryKey, batchSize int, hasLowerBound bool) string {
+	var columns []string
+	for _, c := range key {
+		columns = append(columns, quoteMySQLIdentifier(c.name))
+	}
+	where := ""
+	if hasLowerBound {
+		where = fmt.Sprintf(" WHERE %s", mysqlKeyRange(key, ">"))
+	}
+	return fmt.Sprintf("SELECT %s FROM %s%s ORDER BY %s LIMIT 1 OFFSET %d", strings.Join(columns, ", "), d.tableName(), where, strings.Join(columns, ", "), batchSize-1)
+}
+
+func (d *mysqlDialect) batchStatement(key primaryKey, hasLowerBound, hasUpperBound bool) (string, error) {
+	var conditions []string
+	if hasLowerBound {
+		conditions = append(conditions, mysqlKeyRange(key, ">"))
+	}
+	if hasUpperBound {
+		conditions = append(conditions, mysqlKeyRange(key, "<="))
+	}
+
+	original := *d.where
+	defer func() {
+		*d.where = original
+	}()
+	if len(conditions) > 0 {
+		// Parse the key range condition and AND it with the original WHERE clause.
+		nodes, err := tidbparser.ParseTiDB(fmt.Sprintf("SELECT 1 FROM DUAL WHERE %s", strings.Join(conditions, " AND ")), "", "")
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to parse the key range condition")
+		}
+		keyRange := nodes[0].(*ast.SelectStmt).Where
+		if original == nil {
+			*d.where = keyRange
+		} else {
+			*d.where = &ast.BinaryOperationExpr{
+				Op: opcode.LogicAnd,
+				L:  &ast.ParenthesesExpr{Expr: original},
+				R:  keyRange,
+			}
+		}
+	}
+	var buf strings.Builder
+	if err := d.stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &buf)); err != nil {
+		return "", errors.Wrapf(err, "failed to restore statement")
+	}
+	return buf.String(), nil
+}
+
+// mysqlKeyRange returns the row comparison of the key with the parameters.
+func mysqlKeyRange(key primaryKey, op string) string {
+	var columns, params []string
+	for _, c := range key {
+		columns = append(columns, quoteMySQLIdentifier(c.name))
+		params = append(params, "?")
+	}
+	return fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), op, strings.Join(params, ", "))
+}
+
+func quoteMySQLIdentifier(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
@@ -0,0 +1,180 @@
+package batchdml
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	pgquery "github.com/pganalyze/pg_query_go/v4"
+	"github.com/pkg/errors"
+)
+
+type postgresDialect struct {
+	tree *pgquery.ParseResult
+	// relation is the table changed by the statement.
+	relation *pgquery.RangeVar
+	// where points to the WHERE clause of the statement, which is restricted to the key range of the batch.
+	where **pgquery.Node
+}
+
+func newPostgresDialect(statement string) (*postgresDialect, error) {
+	tree, err := pgquery.Parse(statement)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse statement")
+	}
+	if len(tree.GetStmts()) != 1 {
+		return nil, errors.Errorf("batched execution requires a single UPDATE or DELETE statement, but got %d statements", len(tree.GetStmts()))
+	}
+	d := &postgresDialect{tree: tree}
+	stmt := tree.GetStmts()[0].GetStmt()
+	switch {
+	case stmt.GetUpdateStmt() != nil:
+		update := stmt.GetUpdateStmt()
+		if update.GetWithClause() != nil || len(update.GetFromClause()) > 0 || len(update.GetReturningList()) > 0 {
+			return nil, errors.Errorf("batched execution doesn't support UPDATE with WITH, FROM or RETURNING")
+		}
+		d.relation, d.where = update.GetRelation(), &update.WhereClause
+	case stmt.GetDeleteStmt() != nil:
+		del := stmt.GetDeleteStmt()
+		if del.GetWithClause() != nil || len(del.GetUsingClause()) > 0 || len(del.GetReturningList()) > 0 {
+			return nil, errors.Errorf("batched execution doesn't support DELETE with WITH, USING or RETURNING")
+		}
+		d.relation, d.where = del.GetRelation(), &del.WhereClause
+	default:
+		return nil, errors.Errorf("batched execution only supports UPDATE and DELETE statements")
+	}
+	if d.isCurrentOf() {
+		return nil, errors.Errorf("batched execution doesn't support WHERE CURRENT OF")
+	}
+	return d, nil
+}
+
+func (d *postgresDialect) isCurrentOf() bool {
+	return *d.where != nil && (*d.where).GetCurrentOfExpr() != nil
+}
+
+func (d *postgresDialect) tableName() string {
+	if d.relation.GetSchemaname() == "" {
+		return quoteIdentifier(d.relation.GetRelname())
+	}
+	return quoteIdentifier(d.relation.GetSchemaname()) + "." + quoteIdentifier(d.relation.GetRelname())
+}
+
+func (d *postgresDialect) progressTableName(id string) string {
+	name := quoteIdentifier(fmt.Sprintf("_bb_batch_%s", id))
+	if d.relation.GetSchemaname() == "" {
+		return name
+	}
+	return quoteIdentifier(d.relation.GetSchemaname()) + "." + name
+}
+
+func (d *postgresDialect) assignedColumns() []string {
+	update := d.tree.GetStmts()[0].GetStmt().GetUpdateStmt()
+	if update == nil {
+		return nil
+	}
+	var columns []string
+	for _, target := range update.GetTargetList() {
+		columns = append(columns, target.GetResTarget().GetName())
+	}
+	return columns
+}
+
+func (*postgresDialect) placeholder(i int) string {
+	return fmt.Sprintf("$%d", i)
+}
+
+func (d *postgresDialect) getPrimaryKey(ctx context.Context, db *sql.DB) (primaryKey, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT a.attname, format_type(a.atttypid, a.atttypmod)
+		FROM pg_index i JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = $1::regclass AND i.indisprimary
+		ORDER BY array_position(i.indkey::SMALLINT[], a.attnum)`, d.tableName())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get primary key of %s", d.tableName())
+	}
+	defer rows.Close()
+	var key primaryKey
+	for rows.Next() {
+		var c column
+		if err := rows.Scan(&c.name, &c.dataType); err != nil {
+			return nil, err
+		}
+		key = append(key, c)
+	}
+	return key, rows.Err()
+}
+
+func (d *postgresDialect) getEstimatedRows(ctx context.Context, db *sql.DB) (int64, error) {
+	var rows int64
+	if err := db.QueryRowContext(ctx, `SELECT GREATEST(reltuples::BIGINT, 0) FROM pg_class WHERE oid = $1::regclass`, d.tableName()).Scan(&rows); err != nil {
+		return 0, errors.Wrapf(err, "failed to estimate rows of %s", d.tableName())
+	}
+	return rows, nil
+}
+
+func (d *postgresDialect) boundaryQuery(key primaryKey, batchSize int, hasLowerBound bool) string {
+	var columns, orderBy []string
+	for _, c := range key {
+		columns = append(columns, quoteIdentifier(c.name)+"::TEXT")
+		orderBy = append(orderBy, quoteIdentifier(c.name))
+	}
+	where := ""
+	if hasLowerBound {
+		where = fmt.Sprintf(" WHERE %s", postgresKeyRange(key, ">", 1))
+	}
+	return fmt.Sprintf("SELECT %s FROM %s%s ORDER BY %s LIMIT 1 OFFSET %d", strings.Join(columns, ", "), d.tableName(), where, strings.Join(orderBy, ", "), batchSize-1)
+}
+
+func (d *postgresDialect) batchStatement(key primaryKey, hasLowerBound, hasUpperBound bool) (string, error) {
+	var conditions []string
+	if hasLowerBound {
+		conditions = append(conditions, postgresKeyRange(key, ">", 1))
+	}
+	if hasUpperBound {
+		offset := 1
+		if hasLowerBound {
+			offset += len(key)
+		}
+		conditions = append(conditions, postgresKeyRange(key, "<=", offset))
+	}
+	if len(conditions) == 0 {
+		return pgquery.Deparse(d.tree)
+	}
+
+	// Parse the key range condition and AND it with the original WHERE clause.
+	rangeTree, err := pgquery.Parse(fmt.Sprintf("SELECT WHERE %s", strings.Join(conditions, " AND ")))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse the key range condition")
+	}
+	keyRange := rangeTree.GetStmts()[0].GetStmt().GetSelectStmt().GetWhereClause()
+	original := *d.where
+	defer func() {
+		*d.where = original
+	}()
+	if original == nil {
+		*d.where = keyRange
+	} else {
+		*d.where = pgquery.MakeBoolExprNode(pgquery.BoolExprType_AND_EXPR, []*pgquery.Node{original, keyRange}, 0)
+	}
+	s, err := pgquery.Deparse(d.tree)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to deparse statement")
+	}
+	return s, nil
+}
+
+// postgresKeyRange returns the row comparison of the key with the parameters starting from $offset in text.
+func postgresKeyRange(key primaryKey, op string, offset int) string {
+	var columns, params []string
+	for i, c := range key {
+		columns = append(columns, quoteIdentifier(c.name))
+		params = append(params, fmt.Sprintf("$%d::TEXT::%s", offset+i, c.dataType))
+	}
+	return fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), op, strings.Join(params, ", "))
+}
+
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
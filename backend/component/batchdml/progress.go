@@ -0,0 +1,123 @@
+package batchdml
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The checkpoint is saved to the progress table on the target database in the same transaction as the batch, so
+// that a committed batch is never executed again when the execution resumes. The table is dropped once all batches
+// are completed.
+
+// progressTableColumns is the definition of the progress table, which is supported by both MySQL and PostgreSQL.
+const progressTableColumns = "(statement_hash CHAR(64) NOT NULL, last_key TEXT, completed_batches BIGINT NOT NULL, affected_rows BIGINT NOT NULL, done BOOLEAN NOT NULL)"
+
+// getStatementHash returns the hash of the statement identifying the execution in the progress table, which doesn't
+// keep the statement itself because it may contain the secrets.
+func getStatementHash(statement string) string {
+	h := sha256.Sum256([]byte(statement))
+	return hex.EncodeToString(h[:])
+}
+
+// loadCheckpoint creates the progress table if it doesn't exist and loads the checkpoint from it. The checkpoint is
+// discarded if it is saved by another statement.
+func (e *Execution) loadCheckpoint(ctx context.Context) (Checkpoint, error) {
+	if _, err := e.db.ExecContext(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s %s", e.progressTable, progressTableColumns)); err != nil {
+		return Checkpoint{}, errors.Wrapf(err, "failed to create progress table %s", e.progressTable)
+	}
+	var statementHash string
+	var lastKey sql.NullString
+	var checkpoint Checkpoint
+	if err := e.db.QueryRowContext(ctx, fmt.Sprintf("SELECT statement_hash, last_key, completed_batches, affected_rows, done FROM %s", e.progressTable)).Scan(
+		&statementHash, &lastKey, &checkpoint.CompletedBatches, &checkpoint.AffectedRows, &checkpoint.Done); err != nil {
+		if err == sql.ErrNoRows {
+			return Checkpoint{}, nil
+		}
+		return Checkpoint{}, errors.Wrapf(err, "failed to load the checkpoint from %s", e.progressTable)
+	}
+	if statementHash != e.statementHash {
+		return Checkpoint{}, nil
+	}
+	if lastKey.Valid {
+		if err := json.Unmarshal([]byte(lastKey.String), &checkpoint.LastKey); err != nil {
+			return Checkpoint{}, errors.Wrapf(err, "invalid checkpoint in %s", e.progressTable)
+		}
+		if len(checkpoint.LastKey) != len(e.key) {
+			return Checkpoint{}, errors.Errorf("the checkpoint doesn't match the primary key of table %s", e.table)
+		}
+	}
+	return checkpoint, nil
+}
+
+// saveCheckpoint replaces the checkpoint in the progress table in the transaction of the batch.
+func (e *Execution) saveCheckpoint(ctx context.Context, tx *sql.Tx, checkpoint Checkpoint) error {
+	var lastKey sql.NullString
+	if checkpoint.LastKey != nil {
+		b, err := json.Marshal(checkpoint.LastKey)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal the checkpoint")
+		}
+		lastKey = sql.NullString{String: string(b), Valid: true}
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s", e.progressTable)); err != nil {
+		return errors.Wrapf(err, "failed to save the checkpoint to %s", e.progressTable)
+	}
+	var placeholders []string
+	for i := 1; i <= 5; i++ {
+		placeholders = append(placeholders, e.dialect.placeholder(i))
+	}
+	if _, err := tx.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s (statement_hash, last_key, completed_batches, affected_rows, done) VALUES (%s)", e.progressTable, strings.Join(placeholders, ", ")),
+		e.statementHash, lastKey, checkpoint.CompletedBatches, checkpoint.AffectedRows, checkpoint.Done); err != nil {
+		return errors.Wrapf(err, "failed to save the checkpoint to %s", e.progressTable)
+	}
+	return nil
+}
+
+// executeBatch executes the batch and saves the checkpoint after it in a transaction. It returns the updated
+// checkpoint.
+func (e *Execution) executeBatch(ctx context.Context, statement string, args []any, upperKey []string, checkpoint Checkpoint) (Checkpoint, error) {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return checkpoint, errors.Wrapf(err, "failed to begin batch %d", checkpoint.CompletedBatches+1)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, statement, args...)
+	if err != nil {
+		return checkpoint, errors.Wrapf(err, "failed to execute batch %d", checkpoint.CompletedBatches+1)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return checkpoint, errors.Wrapf(err, "failed to get the affected rows of batch %d", checkpoint.CompletedBatches+1)
+	}
+	next := checkpoint
+	next.CompletedBatches++
+	next.AffectedRows += rowsAffected
+	if upperKey == nil {
+		next.Done = true
+	} else {
+		next.LastKey = upperKey
+	}
+	if err := e.saveCheckpoint(ctx, tx, next); err != nil {
+		return checkpoint, err
+	}
+	if err := tx.Commit(); err != nil {
+		return checkpoint, errors.Wrapf(err, "failed to commit batch %d", checkpoint.CompletedBatches+1)
+	}
+	return next, nil
+}
+
+func (e *Execution) dropProgressTable(ctx context.Context) error {
+	if _, err := e.db.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", e.progressTable)); err != nil {
+		return errors.Wrapf(err, "failed to drop progress table %s", e.progressTable)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package batchdml
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// GetPostgresReplicaLag gets the max replay lag of the streaming replicas from the primary.
+func GetPostgresReplicaLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	var seconds float64
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(EXTRACT(EPOCH FROM MAX(replay_lag)), 0) FROM pg_stat_replication`).Scan(&seconds); err != nil {
+		return 0, errors.Wrapf(err, "failed to get the replication lag")
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// GetMySQLReplicaLag gets the replication lag of the replica. It returns zero if the server is not a replica.
+func GetMySQLReplicaLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	// SHOW SLAVE STATUS is deprecated since MySQL 8.0.22 in favor of SHOW REPLICA STATUS, which is not supported by
+	// the older versions and MariaDB before 10.5.1.
+	rows, err := db.QueryContext(ctx, "SHOW REPLICA STATUS")
+	if err != nil {
+		if rows, err = db.QueryContext(ctx, "SHOW SLAVE STATUS"); err != nil {
+			return 0, errors.Wrapf(err, "failed to get the replica status")
+		}
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	var lag time.Duration
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return 0, err
+		}
+		for i, column := range columns {
+			if !strings.EqualFold(column, "Seconds_Behind_Source") && !strings.EqualFold(column, "Seconds_Behind_Master") {
+				continue
+			}
+			if !values[i].Valid {
+				return 0, errors.Errorf("the replication is not running")
+			}
+			seconds, err := strconv.ParseInt(values[i].String, 10, 64)
+			if err != nil {
+				return 0, errors.Wrapf(err, "invalid replication lag %q", values[i].String)
+			}
+			if d := time.Duration(seconds) * time.Second; d > lag {
+				lag = d
+			}
+		}
+	}
+	return lag, rows.Err()
+}
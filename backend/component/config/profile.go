@@ -32,6 +32,9 @@ type Profile struct {
 	Readonly bool
 	// When we are running in SaaS mode, some features are not allowed to edit by users.
 	SaaS bool
+	// HA is true if we are running as one of the multiple replicas sharing the metadata database.
+	// The state held in the memory of a replica, e.g. the query cursors, is not available on the other replicas.
+	HA bool
 	// DataDir is the directory stores the data including Bytebase's own database, backups, etc.
 	DataDir string
 	// ResourceDir is the directory stores the resources including embedded postgres, mysqlutil, mongoutil and etc.
@@ -60,6 +63,18 @@ type Profile struct {
 	MetricConnectionKey string
 	// EnableMetric will enable the metric collector.
 	EnableMetric bool
+	// OTLPEndpoint is the OTLP gRPC endpoint to export the traces to. Empty means tracing is disabled.
+	OTLPEndpoint string
+	// OTLPInsecure connects to the OTLP endpoint without TLS.
+	OTLPInsecure bool
+	// AuditLogFile is the file to append the audit log to. The audit log is disabled if no sink is configured.
+	AuditLogFile string
+	// AuditLogSyslog is the syslog address to write the audit log to.
+	AuditLogSyslog string
+	// AuditLogURL is the HTTP endpoint to post the audit log to.
+	AuditLogURL string
+	// AuditLogKeyFile is the file of the key to chain the audit log with, which is required by the audit log.
+	AuditLogKeyFile string
 
 	// Test only flag to skip generating onboarding data.
 	TestOnlySkipOnboardingData bool
@@ -7,6 +7,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/component/secret"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/metric"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
@@ -20,16 +21,22 @@ type DBFactory struct {
 	mongoBinDir string
 	dataDir     string
 	secret      string
+	// secretManager resolves the data source passwords referring to the external secrets.
+	secretManager *secret.Manager
+	// iamAuthenticator mints the authentication tokens of the data sources using the cloud IAM authentication.
+	iamAuthenticator *iamAuthenticator
 }
 
 // New creates a new database driver factory.
-func New(mysqlBinDir, mongoBinDir, pgBinDir, dataDir, secret string) *DBFactory {
+func New(mysqlBinDir, mongoBinDir, pgBinDir, dataDir, secret string, secretManager *secret.Manager) *DBFactory {
 	return &DBFactory{
-		mysqlBinDir: mysqlBinDir,
-		mongoBinDir: mongoBinDir,
-		pgBinDir:    pgBinDir,
-		dataDir:     dataDir,
-		secret:      secret,
+		mysqlBinDir:      mysqlBinDir,
+		mongoBinDir:      mongoBinDir,
+		pgBinDir:         pgBinDir,
+		dataDir:          dataDir,
+		secret:           secret,
+		secretManager:    secretManager,
+		iamAuthenticator: newIAMAuthenticator(),
 	}
 }
 
@@ -148,11 +155,30 @@ func (d *DBFactory) GetDataSourceDriver(ctx context.Context, engine storepb.Engi
 	if err != nil {
 		return nil, err
 	}
-	updatedPassword, err := secret.ReplaceExternalSecret(password)
-	if err != nil {
-		return nil, err
+	username := dataSource.Username
+	credential := &secret.Credential{}
+	var authTokenProvider func(ctx context.Context) (string, error)
+	if isIAMAuthentication(dataSource.AuthenticationType) {
+		if authTokenProvider, err = d.iamAuthenticator.getTokenProvider(engine, dataSource.AuthenticationType, dataSource.Host, dataSource.Port, username); err != nil {
+			return nil, err
+		}
+		if sslCA, err = getIAMSslCA(dataSource.AuthenticationType, sslCA); err != nil {
+			return nil, err
+		}
+		// The drivers mint a fresh token for each connection, and the first token is the password for the
+		// command-line tools such as mysqldump and pg_dump.
+		if password, err = authTokenProvider(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		if credential, err = d.secretManager.Resolve(ctx, password); err != nil {
+			return nil, err
+		}
+		if credential.Username != "" {
+			username = credential.Username
+		}
+		password = credential.Password
 	}
-	password = updatedPassword
 	sshConfig := db.SSHConfig{
 		Host:       dataSource.SSHHost,
 		Port:       dataSource.SSHPort,
@@ -168,7 +194,7 @@ func (d *DBFactory) GetDataSourceDriver(ctx context.Context, engine storepb.Engi
 			BinlogDir: common.GetBinlogAbsDir(d.dataDir, instanceUID),
 		},
 		db.ConnectionConfig{
-			Username: dataSource.Username,
+			Username: username,
 			Password: password,
 			TLSConfig: db.TLSConfig{
 				SslCA:   sslCA,
@@ -186,14 +212,38 @@ func (d *DBFactory) GetDataSourceDriver(ctx context.Context, engine storepb.Engi
 			SSHConfig:              sshConfig,
 			ReadOnly:               readOnly,
 			SchemaTenantMode:       schemaTenantMode,
+			AuthTokenProvider:      authTokenProvider,
 		},
 		db.ConnectionContext{
 			InstanceID: instanceID,
 		},
 	)
 	if err != nil {
+		credential.Release()
+		metric.IncConnectionFailure(instanceID, engine.String())
 		return nil, err
 	}
+	if credential.HasLease() {
+		return &leasedDriver{Driver: driver, credential: credential}, nil
+	}
 
 	return driver, nil
 }
+
+// leasedDriver is the driver connected with a leased credential, which is released when the driver is closed.
+type leasedDriver struct {
+	db.Driver
+	credential *secret.Credential
+}
+
+// Close closes the driver and revokes the credential lease.
+func (d *leasedDriver) Close(ctx context.Context) error {
+	err := d.Driver.Close(ctx)
+	d.credential.Release()
+	return err
+}
+
+// Unwrap returns the wrapped driver, so that db.UnwrapDriver returns the driver of the engine for the type assertions.
+func (d *leasedDriver) Unwrap() db.Driver {
+	return d.Driver
+}
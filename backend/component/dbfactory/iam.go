@@ -0,0 +1,319 @@
+package dbfactory
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	// awsRDSTokenExpiration is the lifetime of the RDS IAM authentication token, 15 minutes at most.
+	awsRDSTokenExpiration = 15 * time.Minute
+	awsRDSService         = "rds-db"
+	// emptyPayloadHash is the SHA-256 hash of the empty payload.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	googleCloudSQLLoginScope = "https://www.googleapis.com/auth/sqlservice.login"
+
+	azurePostgresScope = "https://ossrdbms-aad.database.windows.net/.default"
+	azureSQLScope      = "https://database.windows.net/.default"
+	// azureTokenRefreshWindow is how long before the access token expires that we get a new one.
+	azureTokenRefreshWindow = 5 * time.Minute
+	azureLoginEndpoint      = "https://login.microsoftonline.com"
+	azureIMDSEndpoint       = "http://169.254.169.254/metadata/identity/oauth2/token"
+	azureHTTPTimeout        = 30 * time.Second
+)
+
+var (
+	// awsCA is the CA bundle verifying the AWS RDS server certificates.
+	//go:embed certs/aws.pem
+	awsCA string
+	// azureCA is the CA bundle verifying the Azure database server certificates.
+	//go:embed certs/azure.pem
+	azureCA string
+)
+
+// ValidateAuthenticationType validates the authentication type of the data source.
+// The cloud IAM authentication types mint the token for each connection, so the password must be empty.
+func ValidateAuthenticationType(engine storepb.Engine, authenticationType storepb.DataSourceOptions_AuthenticationType, password string) error {
+	var engines []storepb.Engine
+	switch authenticationType {
+	case storepb.DataSourceOptions_AUTHENTICATION_UNSPECIFIED, storepb.DataSourceOptions_PASSWORD:
+		return nil
+	case storepb.DataSourceOptions_AWS_RDS_IAM:
+		engines = []storepb.Engine{storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_POSTGRES}
+	case storepb.DataSourceOptions_GOOGLE_CLOUD_SQL_IAM:
+		engines = []storepb.Engine{storepb.Engine_MYSQL, storepb.Engine_POSTGRES}
+	case storepb.DataSourceOptions_AZURE_IAM:
+		engines = []storepb.Engine{storepb.Engine_MSSQL, storepb.Engine_POSTGRES}
+	default:
+		return errors.Errorf("unsupported authentication type %q", authenticationType)
+	}
+	supported := false
+	for _, e := range engines {
+		if e == engine {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return errors.Errorf("authentication type %q is not supported for %s", authenticationType, engine)
+	}
+	if password != "" {
+		return errors.Errorf("password must be empty for authentication type %q", authenticationType)
+	}
+	return nil
+}
+
+// isIAMAuthentication returns true if the data source connects with the cloud IAM authentication token.
+func isIAMAuthentication(authenticationType storepb.DataSourceOptions_AuthenticationType) bool {
+	switch authenticationType {
+	case storepb.DataSourceOptions_AWS_RDS_IAM, storepb.DataSourceOptions_GOOGLE_CLOUD_SQL_IAM, storepb.DataSourceOptions_AZURE_IAM:
+		return true
+	default:
+		return false
+	}
+}
+
+// getIAMSslCA returns the CA verifying the server certificate for the cloud IAM authentication.
+// The authentication token is sent in cleartext, so the connection must use the verified TLS. The configured CA is
+// used if any, and otherwise the CA bundle of the cloud provider. Each Cloud SQL instance has its own server CA, so
+// it must be configured.
+func getIAMSslCA(authenticationType storepb.DataSourceOptions_AuthenticationType, sslCA string) (string, error) {
+	if sslCA != "" {
+		return sslCA, nil
+	}
+	switch authenticationType {
+	case storepb.DataSourceOptions_AWS_RDS_IAM:
+		return awsCA, nil
+	case storepb.DataSourceOptions_AZURE_IAM:
+		return azureCA, nil
+	case storepb.DataSourceOptions_GOOGLE_CLOUD_SQL_IAM:
+		return "", errors.Errorf("the server CA certificate of the Cloud SQL instance is required for authentication type %q", authenticationType)
+	default:
+		return "", errors.Errorf("authentication type %q does not use the authentication token", authenticationType)
+	}
+}
+
+// iamAuthenticator mints the short-lived cloud IAM authentication tokens used as the database passwords.
+// The cloud credentials are loaded from the environment on the first use, so that nothing is touched if
+// no data source uses the IAM authentication.
+type iamAuthenticator struct {
+	client *http.Client
+	now    func() time.Time
+	// azureLoginEndpoint and azureIMDSEndpoint are overridden in tests.
+	azureLoginEndpoint string
+	azureIMDSEndpoint  string
+
+	mu        sync.Mutex
+	awsConfig *aws.Config
+	gcpSource oauth2.TokenSource
+	// azureTokens caches the Azure access tokens by scope.
+	azureTokens map[string]*oauth2.Token
+}
+
+func newIAMAuthenticator() *iamAuthenticator {
+	return &iamAuthenticator{
+		client:             &http.Client{Timeout: azureHTTPTimeout},
+		now:                time.Now,
+		azureLoginEndpoint: azureLoginEndpoint,
+		azureIMDSEndpoint:  azureIMDSEndpoint,
+		azureTokens:        map[string]*oauth2.Token{},
+	}
+}
+
+// getTokenProvider returns the function minting a fresh authentication token for each connection.
+func (a *iamAuthenticator) getTokenProvider(engine storepb.Engine, authenticationType storepb.DataSourceOptions_AuthenticationType, host, port, username string) (func(ctx context.Context) (string, error), error) {
+	if err := ValidateAuthenticationType(engine, authenticationType, ""); err != nil {
+		return nil, err
+	}
+	switch authenticationType {
+	case storepb.DataSourceOptions_AWS_RDS_IAM:
+		return func(ctx context.Context) (string, error) {
+			return a.getAWSRDSToken(ctx, host, port, username)
+		}, nil
+	case storepb.DataSourceOptions_GOOGLE_CLOUD_SQL_IAM:
+		return a.getGoogleCloudSQLToken, nil
+	case storepb.DataSourceOptions_AZURE_IAM:
+		scope := azureSQLScope
+		if engine == storepb.Engine_POSTGRES {
+			scope = azurePostgresScope
+		}
+		return func(ctx context.Context) (string, error) {
+			return a.getAzureToken(ctx, scope)
+		}, nil
+	default:
+		return nil, errors.Errorf("authentication type %q does not use the authentication token", authenticationType)
+	}
+}
+
+func (a *iamAuthenticator) getAWSConfig(ctx context.Context) (aws.Config, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.awsConfig != nil {
+		return *a.awsConfig, nil
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return aws.Config{}, errors.Wrap(err, "failed to load AWS config")
+	}
+	a.awsConfig = &cfg
+	return cfg, nil
+}
+
+// getAWSRDSToken generates the RDS IAM authentication token, which is the presigned connect request.
+// See https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/UsingWithRDS.IAMDBAuth.Connecting.html.
+func (a *iamAuthenticator) getAWSRDSToken(ctx context.Context, host, port, username string) (string, error) {
+	cfg, err := a.getAWSConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	region := getRDSRegion(host)
+	if region == "" {
+		region = cfg.Region
+	}
+	if region == "" {
+		return "", errors.Errorf("cannot determine the AWS region of %q, set AWS_REGION", host)
+	}
+	credentials, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve AWS credentials")
+	}
+
+	query := url.Values{}
+	query.Set("Action", "connect")
+	query.Set("DBUser", username)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(awsRDSTokenExpiration/time.Second)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://%s:%s/?%s", host, port, query.Encode()), nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid RDS endpoint %s:%s", host, port)
+	}
+	signedURL, _, err := v4.NewSigner().PresignHTTP(ctx, credentials, req, emptyPayloadHash, awsRDSService, region, a.now())
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign the RDS authentication token")
+	}
+	return strings.TrimPrefix(signedURL, "https://"), nil
+}
+
+// getRDSRegion gets the region from the RDS endpoint, e.g. us-east-1 from mydb.123456789012.us-east-1.rds.amazonaws.com.
+func getRDSRegion(host string) string {
+	parts := strings.Split(host, ".")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] == "rds" {
+			return parts[i-1]
+		}
+	}
+	return ""
+}
+
+// getGoogleCloudSQLToken gets the OAuth2 access token of the application default credentials.
+// See https://cloud.google.com/sql/docs/postgres/iam-logins.
+func (a *iamAuthenticator) getGoogleCloudSQLToken(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	if a.gcpSource == nil {
+		// The token source must outlive the request context, which is canceled after the connection is opened.
+		source, err := google.DefaultTokenSource(context.Background(), googleCloudSQLLoginScope)
+		if err != nil {
+			a.mu.Unlock()
+			return "", errors.Wrap(err, "failed to find Google application default credentials")
+		}
+		a.gcpSource = source
+	}
+	source := a.gcpSource
+	a.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	token, err := source.Token()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get Google Cloud SQL access token")
+	}
+	return token.AccessToken, nil
+}
+
+type azureTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	// ExpiresIn is a number from Microsoft Entra ID but a string from the instance metadata service.
+	ExpiresIn        json.RawMessage `json:"expires_in"`
+	Error            string          `json:"error"`
+	ErrorDescription string          `json:"error_description"`
+}
+
+// getAzureToken gets the Microsoft Entra ID access token of the scope. It uses the client credentials of the
+// service principal if AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_CLIENT_SECRET are set, otherwise the managed
+// identity, whose client ID is AZURE_CLIENT_ID if it's user-assigned.
+func (a *iamAuthenticator) getAzureToken(ctx context.Context, scope string) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if token, ok := a.azureTokens[scope]; ok && a.now().Add(azureTokenRefreshWindow).Before(token.Expiry) {
+		return token.AccessToken, nil
+	}
+
+	tenantID, clientID, clientSecret := os.Getenv("AZURE_TENANT_ID"), os.Getenv("AZURE_CLIENT_ID"), os.Getenv("AZURE_CLIENT_SECRET")
+	var req *http.Request
+	var err error
+	if tenantID != "" && clientID != "" && clientSecret != "" {
+		form := url.Values{}
+		form.Set("grant_type", "client_credentials")
+		form.Set("client_id", clientID)
+		form.Set("client_secret", clientSecret)
+		form.Set("scope", scope)
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s/oauth2/v2.0/token", a.azureLoginEndpoint, url.PathEscape(tenantID)), strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to create Azure token request")
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		query := url.Values{}
+		query.Set("api-version", "2018-02-01")
+		query.Set("resource", strings.TrimSuffix(scope, "/.default"))
+		if clientID != "" {
+			query.Set("client_id", clientID)
+		}
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?%s", a.azureIMDSEndpoint, query.Encode()), nil)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to create Azure managed identity token request")
+		}
+		req.Header.Set("Metadata", "true")
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get Azure access token")
+	}
+	defer response.Body.Close()
+	var r azureTokenResponse
+	if err := json.NewDecoder(response.Body).Decode(&r); err != nil {
+		return "", errors.Wrap(err, "failed to decode Azure token response")
+	}
+	if response.StatusCode != http.StatusOK || r.AccessToken == "" {
+		return "", errors.Errorf("failed to get Azure access token, status %d: %s %s", response.StatusCode, r.Error, r.ErrorDescription)
+	}
+	expiresIn, err := strconv.ParseInt(strings.Trim(string(r.ExpiresIn), `"`), 10, 64)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid expires_in %s of Azure access token", r.ExpiresIn)
+	}
+	a.azureTokens[scope] = &oauth2.Token{
+		AccessToken: r.AccessToken,
+		Expiry:      a.now().Add(time.Duration(expiresIn) * time.Second),
+	}
+	return r.AccessToken, nil
+}
@@ -0,0 +1,187 @@
+package dbfactory
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/component/secret"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestValidateAuthenticationType(t *testing.T) {
+	tests := []struct {
+		engine             storepb.Engine
+		authenticationType storepb.DataSourceOptions_AuthenticationType
+		password           string
+		wantErr            bool
+	}{
+		{storepb.Engine_MYSQL, storepb.DataSourceOptions_AUTHENTICATION_UNSPECIFIED, "secret", false},
+		{storepb.Engine_ORACLE, storepb.DataSourceOptions_PASSWORD, "secret", false},
+		{storepb.Engine_MYSQL, storepb.DataSourceOptions_AWS_RDS_IAM, "", false},
+		{storepb.Engine_MARIADB, storepb.DataSourceOptions_AWS_RDS_IAM, "", false},
+		{storepb.Engine_POSTGRES, storepb.DataSourceOptions_GOOGLE_CLOUD_SQL_IAM, "", false},
+		{storepb.Engine_MSSQL, storepb.DataSourceOptions_AZURE_IAM, "", false},
+		{storepb.Engine_MYSQL, storepb.DataSourceOptions_AWS_RDS_IAM, "secret", true},
+		{storepb.Engine_MSSQL, storepb.DataSourceOptions_AWS_RDS_IAM, "", true},
+		{storepb.Engine_MYSQL, storepb.DataSourceOptions_AZURE_IAM, "", true},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		err := ValidateAuthenticationType(test.engine, test.authenticationType, test.password)
+		if test.wantErr {
+			a.Error(err, "%s %s", test.engine, test.authenticationType)
+		} else {
+			a.NoError(err, "%s %s", test.engine, test.authenticationType)
+		}
+	}
+}
+
+func TestGetIAMSslCA(t *testing.T) {
+	a := require.New(t)
+
+	sslCA, err := getIAMSslCA(storepb.DataSourceOptions_GOOGLE_CLOUD_SQL_IAM, "configured")
+	a.NoError(err)
+	a.Equal("configured", sslCA)
+
+	for _, authenticationType := range []storepb.DataSourceOptions_AuthenticationType{storepb.DataSourceOptions_AWS_RDS_IAM, storepb.DataSourceOptions_AZURE_IAM} {
+		sslCA, err := getIAMSslCA(authenticationType, "")
+		a.NoError(err)
+		tlsConfig, err := db.TLSConfig{SslCA: sslCA}.GetSslConfig()
+		a.NoError(err, authenticationType)
+		a.NotNil(tlsConfig, authenticationType)
+	}
+
+	_, err = getIAMSslCA(storepb.DataSourceOptions_GOOGLE_CLOUD_SQL_IAM, "")
+	a.Error(err)
+	_, err = getIAMSslCA(storepb.DataSourceOptions_PASSWORD, "")
+	a.Error(err)
+}
+
+func TestGetRDSRegion(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"mydb.123456789012.us-east-1.rds.amazonaws.com", "us-east-1"},
+		{"mycluster.cluster-ro-abc.eu-west-2.rds.amazonaws.com.cn", "eu-west-2"},
+		{"10.0.0.1", ""},
+		{"localhost", ""},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, getRDSRegion(test.host), test.host)
+	}
+}
+
+func TestGetAWSRDSToken(t *testing.T) {
+	a := require.New(t)
+	authenticator := newIAMAuthenticator()
+	authenticator.now = func() time.Time { return time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC) }
+	authenticator.awsConfig = &aws.Config{
+		Region:      "us-west-2",
+		Credentials: credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "SECRET", ""),
+	}
+
+	token, err := authenticator.getAWSRDSToken(context.Background(), "mydb.123456789012.us-east-1.rds.amazonaws.com", "3306", "bytebase")
+	a.NoError(err)
+	a.True(strings.HasPrefix(token, "mydb.123456789012.us-east-1.rds.amazonaws.com:3306/?"))
+	u, err := url.Parse("https://" + token)
+	a.NoError(err)
+	query := u.Query()
+	a.Equal("connect", query.Get("Action"))
+	a.Equal("bytebase", query.Get("DBUser"))
+	a.Equal("900", query.Get("X-Amz-Expires"))
+	a.Equal("AKIDEXAMPLE/20231101/us-east-1/rds-db/aws4_request", query.Get("X-Amz-Credential"))
+	a.NotEmpty(query.Get("X-Amz-Signature"))
+}
+
+func TestGetAzureToken(t *testing.T) {
+	a := require.New(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		a.Equal("/tenant/oauth2/v2.0/token", r.URL.Path)
+		a.NoError(r.ParseForm())
+		a.Equal("client_credentials", r.PostForm.Get("grant_type"))
+		a.Equal("client", r.PostForm.Get("client_id"))
+		a.Equal("client-secret", r.PostForm.Get("client_secret"))
+		a.Equal(azurePostgresScope, r.PostForm.Get("scope"))
+		_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+	}))
+	defer server.Close()
+	t.Setenv("AZURE_TENANT_ID", "tenant")
+	t.Setenv("AZURE_CLIENT_ID", "client")
+	t.Setenv("AZURE_CLIENT_SECRET", "client-secret")
+
+	now := time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)
+	authenticator := newIAMAuthenticator()
+	authenticator.now = func() time.Time { return now }
+	authenticator.azureLoginEndpoint = server.URL
+	provider, err := authenticator.getTokenProvider(storepb.Engine_POSTGRES, storepb.DataSourceOptions_AZURE_IAM, "db.postgres.database.azure.com", "5432", "bytebase")
+	a.NoError(err)
+
+	token, err := provider(context.Background())
+	a.NoError(err)
+	a.Equal("token", token)
+	// The cached token is used until it's about to expire.
+	now = now.Add(50 * time.Minute)
+	_, err = provider(context.Background())
+	a.NoError(err)
+	a.Equal(1, requests)
+	now = now.Add(6 * time.Minute)
+	_, err = provider(context.Background())
+	a.NoError(err)
+	a.Equal(2, requests)
+}
+
+func TestGetAzureManagedIdentityToken(t *testing.T) {
+	a := require.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("true", r.Header.Get("Metadata"))
+		a.Equal("https://database.windows.net", r.URL.Query().Get("resource"))
+		_, _ = w.Write([]byte(`{"access_token":"managed-identity-token","expires_in":"3599"}`))
+	}))
+	defer server.Close()
+	t.Setenv("AZURE_TENANT_ID", "")
+	t.Setenv("AZURE_CLIENT_ID", "")
+	t.Setenv("AZURE_CLIENT_SECRET", "")
+
+	authenticator := newIAMAuthenticator()
+	authenticator.azureIMDSEndpoint = server.URL
+	token, err := authenticator.getAzureToken(context.Background(), azureSQLScope)
+	a.NoError(err)
+	a.Equal("managed-identity-token", token)
+}
+
+type fakeDriver struct {
+	db.Driver
+	closed bool
+}
+
+func (d *fakeDriver) Close(context.Context) error {
+	d.closed = true
+	return nil
+}
+
+func TestLeasedDriver(t *testing.T) {
+	a := require.New(t)
+	fake := &fakeDriver{}
+	driver := &leasedDriver{Driver: fake, credential: &secret.Credential{}}
+
+	// The type assertions on the driver of the engine see through the lease.
+	a.Same(fake, db.UnwrapDriver(driver))
+	a.NoError(driver.Close(context.Background()))
+	a.True(fake.closed)
+}
@@ -0,0 +1,186 @@
+// Package leader elects the leader among the Bytebase replicas sharing the same metadata database.
+// Only the leader runs the runners, e.g. the task scheduler and the schema syncer.
+package leader
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+)
+
+const (
+	// RunnerLeaseName is the name of the lease held by the leader running the runners.
+	RunnerLeaseName = "runner"
+
+	defaultLeaseTTL      = 30 * time.Second
+	defaultRenewInterval = 10 * time.Second
+	renewTimeout         = 5 * time.Second
+	releaseTimeout       = 5 * time.Second
+)
+
+// leaseStore is the store of the leader leases.
+type leaseStore interface {
+	AcquireLeaderLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	ReleaseLeaderLease(ctx context.Context, name, holder string) error
+}
+
+// Elector elects the leader with a lease in the metadata database.
+// The leader renews the lease periodically, and another replica takes over after the lease expires.
+type Elector struct {
+	store         leaseStore
+	name          string
+	holder        string
+	ttl           time.Duration
+	renewInterval time.Duration
+
+	isLeader atomic.Bool
+}
+
+// NewElector creates a new elector campaigning for the lease with the name.
+func NewElector(store leaseStore, name string) *Elector {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return &Elector{
+		store:         store,
+		name:          name,
+		holder:        fmt.Sprintf("%s-%s", hostname, uuid.NewString()),
+		ttl:           defaultLeaseTTL,
+		renewInterval: defaultRenewInterval,
+	}
+}
+
+// Holder returns the identity of the replica in the election.
+func (e *Elector) Holder() string {
+	return e.holder
+}
+
+// IsLeader returns whether the replica is the leader.
+func (e *Elector) IsLeader() bool {
+	return e.isLeader.Load()
+}
+
+// Run campaigns for the leader until the context is canceled, and runs lead while the replica is the leader.
+// The context passed to lead is canceled when the replica steps down, and lead must return after that.
+// The replica keeps renewing the lease until lead returns, so that another replica doesn't take over while the work of
+// the leader, e.g. the executing task runs, is draining.
+// If lead returns by itself, the replica steps down and campaigns again.
+func (e *Elector) Run(ctx context.Context, wg *sync.WaitGroup, lead func(ctx context.Context)) {
+	defer wg.Done()
+	ticker := time.NewTicker(e.renewInterval)
+	defer ticker.Stop()
+
+	var current *leadership
+	var renewedAt time.Time
+	stepDown := func() {
+		if current == nil {
+			return
+		}
+		e.isLeader.Store(false)
+		current.cancel()
+		e.drain(current)
+		current = nil
+		// Release the lease so that another replica takes over without waiting for the expiration.
+		releaseCtx, releaseCancel := context.WithTimeout(context.Background(), releaseTimeout)
+		defer releaseCancel()
+		if err := e.store.ReleaseLeaderLease(releaseCtx, e.name, e.holder); err != nil {
+			slog.Warn("failed to release leader lease", slog.String("name", e.name), log.BBError(err))
+		}
+	}
+	defer stepDown()
+
+	for {
+		acquired, err := e.store.AcquireLeaderLease(ctx, e.name, e.holder, e.ttl)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("failed to renew leader lease", slog.String("name", e.name), log.BBError(err))
+			// Step down before the lease may expire at the next renewal, so that two leaders never run at the same time.
+			if current != nil && time.Since(renewedAt)+e.renewInterval >= e.ttl {
+				slog.Warn("stepping down as the leader lease may expire", slog.String("name", e.name), slog.String("holder", e.holder))
+				stepDown()
+			}
+		case acquired:
+			renewedAt = time.Now()
+			if current == nil {
+				slog.Info("became the leader", slog.String("name", e.name), slog.String("holder", e.holder))
+				e.isLeader.Store(true)
+				current = startLeadership(ctx, lead)
+			}
+		default:
+			if current != nil {
+				slog.Warn("lost the leader lease", slog.String("name", e.name), slog.String("holder", e.holder))
+				stepDown()
+			}
+		}
+
+		var done chan struct{}
+		if current != nil {
+			done = current.done
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			slog.Warn("leader exited, stepping down", slog.String("name", e.name), slog.String("holder", e.holder))
+			stepDown()
+			// Wait for the next tick to campaign again.
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain waits for lead to return while renewing the lease.
+func (e *Elector) drain(l *leadership) {
+	ticker := time.NewTicker(e.renewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-ticker.C:
+			// The context of Run may be canceled already on shutdown.
+			renewCtx, renewCancel := context.WithTimeout(context.Background(), renewTimeout)
+			acquired, err := e.store.AcquireLeaderLease(renewCtx, e.name, e.holder, e.ttl)
+			renewCancel()
+			if err != nil {
+				slog.Warn("failed to renew leader lease while stepping down", slog.String("name", e.name), log.BBError(err))
+			} else if !acquired {
+				slog.Error("lost the leader lease before the leader exited", slog.String("name", e.name), slog.String("holder", e.holder))
+			}
+		}
+	}
+}
+
+// leadership is the term of the replica as the leader.
+type leadership struct {
+	cancel context.CancelFunc
+	// done is closed after lead returns.
+	done chan struct{}
+}
+
+func startLeadership(ctx context.Context, lead func(ctx context.Context)) *leadership {
+	leadCtx, cancel := context.WithCancel(ctx)
+	l := &leadership{cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(l.done)
+		lead(leadCtx)
+	}()
+	return l
+}
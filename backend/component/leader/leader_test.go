@@ -0,0 +1,165 @@
+package leader
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLeaseStore struct {
+	sync.Mutex
+	holder   string
+	expireAt time.Time
+	fail     atomic.Bool
+}
+
+func (s *fakeLeaseStore) AcquireLeaderLease(_ context.Context, _, holder string, ttl time.Duration) (bool, error) {
+	if s.fail.Load() {
+		return false, errors.New("connection refused")
+	}
+	s.Lock()
+	defer s.Unlock()
+	if s.holder != holder && time.Now().Before(s.expireAt) {
+		return false, nil
+	}
+	s.holder, s.expireAt = holder, time.Now().Add(ttl)
+	return true, nil
+}
+
+func (s *fakeLeaseStore) ReleaseLeaderLease(_ context.Context, _, holder string) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.holder == holder {
+		s.holder = ""
+	}
+	return nil
+}
+
+func newTestElector(store leaseStore) *Elector {
+	e := NewElector(store, RunnerLeaseName)
+	e.ttl = 300 * time.Millisecond
+	e.renewInterval = 20 * time.Millisecond
+	return e
+}
+
+func TestElectorFailover(t *testing.T) {
+	a := require.New(t)
+	store := &fakeLeaseStore{}
+	var leading atomic.Int32
+	var overlapped atomic.Bool
+	lead := func(ctx context.Context) {
+		if leading.Add(1) > 1 {
+			overlapped.Store(true)
+		}
+		<-ctx.Done()
+		leading.Add(-1)
+	}
+
+	var wg sync.WaitGroup
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	e1 := newTestElector(store)
+	wg.Add(1)
+	go e1.Run(ctx1, &wg, lead)
+	a.Eventually(e1.IsLeader, time.Second, 10*time.Millisecond)
+
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	e2 := newTestElector(store)
+	wg.Add(1)
+	go e2.Run(ctx2, &wg, lead)
+	time.Sleep(100 * time.Millisecond)
+	a.False(e2.IsLeader())
+
+	// The second replica takes over after the leader shuts down.
+	cancel1()
+	a.Eventually(e2.IsLeader, time.Second, 10*time.Millisecond)
+	a.False(e1.IsLeader())
+	cancel2()
+	wg.Wait()
+	a.False(overlapped.Load(), "two leaders at the same time")
+	a.Equal(int32(0), leading.Load())
+}
+
+func TestElectorStepDownOnRenewalFailure(t *testing.T) {
+	a := require.New(t)
+	store := &fakeLeaseStore{}
+	e := newTestElector(store)
+	stopped := make(chan struct{})
+
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wg.Add(1)
+	go e.Run(ctx, &wg, func(ctx context.Context) {
+		<-ctx.Done()
+		close(stopped)
+	})
+	a.Eventually(e.IsLeader, time.Second, 10*time.Millisecond)
+
+	store.fail.Store(true)
+	failedAt := time.Now()
+	<-stopped
+	// The leader steps down before the lease expires.
+	a.Less(time.Since(failedAt), e.ttl)
+	a.False(e.IsLeader())
+	cancel()
+	wg.Wait()
+}
+
+func TestElectorKeepsLeaseUntilLeaderDrains(t *testing.T) {
+	a := require.New(t)
+	store := &fakeLeaseStore{}
+	var leading atomic.Int32
+	var overlapped atomic.Bool
+	drained := make(chan struct{})
+	e1 := newTestElector(store)
+	lead1 := func(ctx context.Context) {
+		leading.Add(1)
+		<-ctx.Done()
+		// The in-flight task run takes longer than the lease TTL to exit after the cancellation.
+		time.Sleep(3 * e1.ttl)
+		leading.Add(-1)
+		close(drained)
+	}
+	lead2 := func(ctx context.Context) {
+		if leading.Add(1) > 1 {
+			overlapped.Store(true)
+		}
+		<-ctx.Done()
+		leading.Add(-1)
+	}
+
+	var wg sync.WaitGroup
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	wg.Add(1)
+	go e1.Run(ctx1, &wg, lead1)
+	a.Eventually(e1.IsLeader, time.Second, 10*time.Millisecond)
+
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	e2 := newTestElector(store)
+	wg.Add(1)
+	go e2.Run(ctx2, &wg, lead2)
+
+	// The second replica doesn't take over while the task run of the leader is draining.
+	cancel1()
+	a.Never(func() bool {
+		select {
+		case <-drained:
+			return false
+		default:
+			return e2.IsLeader()
+		}
+	}, 2*e1.ttl, 10*time.Millisecond)
+	<-drained
+	a.Eventually(e2.IsLeader, time.Second, 10*time.Millisecond)
+	cancel2()
+	wg.Wait()
+	a.False(overlapped.Load(), "two leaders at the same time")
+	a.Equal(int32(0), leading.Load())
+}
@@ -0,0 +1,247 @@
+package pgosc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type column struct {
+	name     string
+	dataType string
+}
+
+type primaryKey []column
+
+// names returns the quoted comma-separated column names.
+func (k primaryKey) names() string {
+	var names []string
+	for _, column := range k {
+		names = append(names, column.name)
+	}
+	return quoteIdentifiers(names)
+}
+
+type foreignKey struct {
+	name       string
+	definition string
+}
+
+// querier is implemented by *sql.DB and *sql.Tx.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func (m *Migration) getPrimaryKey(ctx context.Context, table string) (primaryKey, error) {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT a.attname, format_type(a.atttypid, a.atttypmod)
+		FROM pg_index i JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = $1::regclass AND i.indisprimary
+		ORDER BY array_position(i.indkey::SMALLINT[], a.attnum)`, table)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get primary key of %s", table)
+	}
+	defer rows.Close()
+	var key primaryKey
+	for rows.Next() {
+		var c column
+		if err := rows.Scan(&c.name, &c.dataType); err != nil {
+			return nil, err
+		}
+		key = append(key, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(key) == 0 {
+		return nil, errors.Errorf("online schema change requires the primary key, but table %s has no primary key", table)
+	}
+	return key, nil
+}
+
+// getCommonColumns gets the columns of the original table that still exist in the shadow table, which are copied
+// to the shadow table. The generated columns are computed instead of copied.
+func (m *Migration) getCommonColumns(ctx context.Context, shadowTable string) ([]string, error) {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT a.attname FROM pg_attribute a
+		WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped AND a.attgenerated = ''
+		AND EXISTS (
+			SELECT 1 FROM pg_attribute s
+			WHERE s.attrelid = $2::regclass AND s.attname = a.attname AND NOT s.attisdropped AND s.attgenerated = ''
+		)
+		ORDER BY a.attnum`, m.Table(), shadowTable)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get columns of %s", m.Table())
+	}
+	defer rows.Close()
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns = append(columns, name)
+	}
+	return columns, rows.Err()
+}
+
+func (m *Migration) getForeignKeys(ctx context.Context) ([]foreignKey, error) {
+	rows, err := m.db.QueryContext(ctx, `SELECT conname, pg_get_constraintdef(oid) FROM pg_constraint WHERE conrelid = $1::regclass AND contype = 'f' ORDER BY conname`, m.Table())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get foreign keys of %s", m.Table())
+	}
+	defer rows.Close()
+	var foreignKeys []foreignKey
+	for rows.Next() {
+		var fk foreignKey
+		if err := rows.Scan(&fk.name, &fk.definition); err != nil {
+			return nil, err
+		}
+		foreignKeys = append(foreignKeys, fk)
+	}
+	return foreignKeys, rows.Err()
+}
+
+// getSequenceStatements moves the ownership of the serial sequences to the shadow table, so that they are not
+// dropped with the original table, and advances the identity sequences of the shadow table.
+func (m *Migration) getSequenceStatements(ctx context.Context, q querier) ([]string, error) {
+	type sequence struct {
+		dependency string
+		name       string
+		column     string
+	}
+	rows, err := q.QueryContext(ctx, `
+		SELECT d.deptype, s.oid::regclass::TEXT, a.attname
+		FROM pg_depend d
+		JOIN pg_class s ON s.oid = d.objid AND s.relkind = 'S'
+		JOIN pg_attribute a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+		WHERE d.classid = 'pg_class'::regclass AND d.refclassid = 'pg_class'::regclass
+		AND d.refobjid = $1::regclass AND d.deptype IN ('a', 'i')
+		ORDER BY a.attnum`, m.Table())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get sequences of %s", m.Table())
+	}
+	defer rows.Close()
+	var sequences []sequence
+	for rows.Next() {
+		var s sequence
+		if err := rows.Scan(&s.dependency, &s.name, &s.column); err != nil {
+			return nil, err
+		}
+		sequences = append(sequences, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	shadowTable := quoteTable(m.schema, m.shadowTable())
+	var statements []string
+	for _, s := range sequences {
+		var shadowSequence sql.NullString
+		if err := q.QueryRowContext(ctx, `
+			SELECT pg_get_serial_sequence($1, a.attname) FROM pg_attribute a
+			WHERE a.attrelid = $2::regclass AND a.attname = $3 AND NOT a.attisdropped`, shadowTable, shadowTable, s.column).Scan(&shadowSequence); err != nil {
+			if err == sql.ErrNoRows {
+				// The column is dropped by the migration.
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get sequence of column %q", s.column)
+		}
+		switch s.dependency {
+		case "a":
+			statements = append(statements, fmt.Sprintf("ALTER SEQUENCE %s OWNED BY %s.%s", s.name, shadowTable, quoteIdentifier(s.column)))
+		case "i":
+			if !shadowSequence.Valid || shadowSequence.String == s.name {
+				continue
+			}
+			var lastValue int64
+			var isCalled bool
+			if err := q.QueryRowContext(ctx, fmt.Sprintf("SELECT last_value, is_called FROM %s", s.name)).Scan(&lastValue, &isCalled); err != nil {
+				return nil, errors.Wrapf(err, "failed to get the value of sequence %s", s.name)
+			}
+			statements = append(statements, fmt.Sprintf("SELECT setval(%s, %d, %t)", quoteLiteral(shadowSequence.String), lastValue, isCalled))
+		}
+	}
+	return statements, nil
+}
+
+// getIndexRenameStatements renames the indexes of the shadow table to the names of the matching indexes of the
+// original table, whose indexes are renamed to _<index>_del. The indexes added by the migration keep the names.
+func (m *Migration) getIndexRenameStatements(ctx context.Context, q querier) ([]string, error) {
+	type index struct {
+		name       string
+		definition string
+	}
+	listIndexes := func(table string) ([]index, error) {
+		// The definition without the index and table names, e.g. UNIQUE USING btree (id).
+		rows, err := q.QueryContext(ctx, `
+			SELECT c.relname, CASE WHEN i.indisunique THEN 'UNIQUE' ELSE '' END || substring(pg_get_indexdef(i.indexrelid) FROM ' USING .*$')
+			FROM pg_index i JOIN pg_class c ON c.oid = i.indexrelid
+			WHERE i.indrelid = $1::regclass
+			ORDER BY c.relname`, table)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get indexes of %s", table)
+		}
+		defer rows.Close()
+		var indexes []index
+		for rows.Next() {
+			var i index
+			if err := rows.Scan(&i.name, &i.definition); err != nil {
+				return nil, err
+			}
+			indexes = append(indexes, i)
+		}
+		return indexes, rows.Err()
+	}
+	indexes, err := listIndexes(m.Table())
+	if err != nil {
+		return nil, err
+	}
+	shadowIndexes, err := listIndexes(quoteTable(m.schema, m.shadowTable()))
+	if err != nil {
+		return nil, err
+	}
+
+	var renameOld, renameShadow []string
+	matched := map[string]bool{}
+	for _, i := range indexes {
+		oldName := fmt.Sprintf("_%s_del", i.name)
+		if len(oldName) > maxIdentifierLength {
+			continue
+		}
+		for _, s := range shadowIndexes {
+			if matched[s.name] || s.definition != i.definition {
+				continue
+			}
+			matched[s.name] = true
+			renameOld = append(renameOld, fmt.Sprintf("ALTER INDEX %s RENAME TO %s", quoteTable(m.schema, i.name), quoteIdentifier(oldName)))
+			renameShadow = append(renameShadow, fmt.Sprintf("ALTER INDEX %s RENAME TO %s", quoteTable(m.schema, s.name), quoteIdentifier(i.name)))
+			break
+		}
+	}
+	return append(renameOld, renameShadow...), nil
+}
+
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func quoteIdentifiers(ss []string) string {
+	var quoted []string
+	for _, s := range ss {
+		quoted = append(quoted, quoteIdentifier(s))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func quoteTable(schema, table string) string {
+	return quoteIdentifier(schema) + "." + quoteIdentifier(table)
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
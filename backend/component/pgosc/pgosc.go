@@ -0,0 +1,481 @@
+// Package pgosc implements the online schema change of PostgreSQL tables.
+//
+// Like gh-ost, the migration builds a shadow table with the new schema and backfills it from the original table in
+// batches. The concurrent changes are captured by a trigger on the original table, which applies each row change to
+// the shadow table. The cutover atomically swaps the tables by renaming them in a transaction, and the original
+// table is kept as _<table>_del. If the sync or the cutover fails, the shadow table and the trigger are dropped.
+package pgosc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	pgquery "github.com/pganalyze/pg_query_go/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxIdentifierLength is the max identifier length of PostgreSQL.
+	maxIdentifierLength = 63
+	defaultBatchSize    = 1000
+	// cutoverLockTimeout is the lock timeout of the cutover, which fails fast instead of queueing the traffic
+	// behind the ACCESS EXCLUSIVE lock.
+	cutoverLockTimeout = 3 * time.Second
+	cutoverMaxRetries  = 10
+	// cleanupTimeout is the timeout of dropping the shadow table and the trigger after the migration fails.
+	cleanupTimeout = time.Minute
+)
+
+// Progress is the progress of the backfill.
+type Progress struct {
+	// TotalRows is the estimated row count of the original table.
+	TotalRows  int64
+	CopiedRows int64
+}
+
+// Migration is the online schema change of a PostgreSQL table.
+type Migration struct {
+	db        *sql.DB
+	schema    string
+	table     string
+	batchSize int
+	// alterShadowStatement is the ALTER TABLE statement applied to the shadow table.
+	alterShadowStatement string
+}
+
+// NewMigration creates the online schema change of the ALTER TABLE statement, which must be a single ALTER TABLE
+// statement. The table is in the current schema if the statement doesn't specify the schema.
+func NewMigration(ctx context.Context, db *sql.DB, statement string) (*Migration, error) {
+	schema, table, err := GetTableFromStatement(statement)
+	if err != nil {
+		return nil, err
+	}
+	if schema == "" {
+		if err := db.QueryRowContext(ctx, "SELECT current_schema()").Scan(&schema); err != nil {
+			return nil, errors.Wrap(err, "failed to get the current schema")
+		}
+	}
+	m := &Migration{
+		db:        db,
+		schema:    schema,
+		table:     table,
+		batchSize: defaultBatchSize,
+	}
+	if len(m.syncTruncateTrigger()) > maxIdentifierLength {
+		return nil, errors.Errorf("table name %q is too long for the online schema change", table)
+	}
+	if m.alterShadowStatement, err = rewriteStatement(statement, schema, m.shadowTable()); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GetTableFromStatement gets the schema and table altered by the statement. It returns an error if the statement
+// is not a single ALTER TABLE statement supported by the online schema change.
+func GetTableFromStatement(statement string) (string, string, error) {
+	alter, _, err := parseAlterTable(statement)
+	if err != nil {
+		return "", "", err
+	}
+	return alter.GetRelation().GetSchemaname(), alter.GetRelation().GetRelname(), nil
+}
+
+func parseAlterTable(statement string) (*pgquery.AlterTableStmt, *pgquery.ParseResult, error) {
+	tree, err := pgquery.Parse(statement)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse statement")
+	}
+	if len(tree.GetStmts()) != 1 {
+		return nil, nil, errors.Errorf("online schema change requires a single ALTER TABLE statement, but got %d statements", len(tree.GetStmts()))
+	}
+	alter := tree.GetStmts()[0].GetStmt().GetAlterTableStmt()
+	if alter == nil || alter.GetObjtype() != pgquery.ObjectType_OBJECT_TABLE {
+		return nil, nil, errors.Errorf("online schema change only supports ALTER TABLE statement")
+	}
+	for _, node := range alter.GetCmds() {
+		cmd := node.GetAlterTableCmd()
+		switch cmd.GetSubtype() {
+		case pgquery.AlterTableType_AT_AlterColumnType:
+			// The backfill copies the values with the assignment cast, so the USING expression cannot be honored.
+			if cmd.GetDef().GetColumnDef().GetRawDefault() != nil {
+				return nil, nil, errors.Errorf("online schema change doesn't support ALTER COLUMN TYPE with USING expression")
+			}
+		case pgquery.AlterTableType_AT_AttachPartition, pgquery.AlterTableType_AT_DetachPartition,
+			pgquery.AlterTableType_AT_AddInherit, pgquery.AlterTableType_AT_DropInherit,
+			pgquery.AlterTableType_AT_SetTableSpace, pgquery.AlterTableType_AT_ChangeOwner:
+			return nil, nil, errors.Errorf("online schema change doesn't support %s", strings.TrimPrefix(cmd.GetSubtype().String(), "AT_"))
+		}
+	}
+	return alter, tree, nil
+}
+
+// rewriteStatement rewrites the ALTER TABLE statement to alter the shadow table.
+func rewriteStatement(statement, schema, shadowTable string) (string, error) {
+	alter, tree, err := parseAlterTable(statement)
+	if err != nil {
+		return "", err
+	}
+	alter.Relation.Schemaname = schema
+	alter.Relation.Relname = shadowTable
+	alter.Relation.Inh = true
+	alter.MissingOk = false
+	s, err := pgquery.Deparse(tree)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to deparse statement")
+	}
+	return s, nil
+}
+
+// Table returns the quoted name of the table.
+func (m *Migration) Table() string {
+	return quoteTable(m.schema, m.table)
+}
+
+func (m *Migration) shadowTable() string {
+	return fmt.Sprintf("_%s_gho", m.table)
+}
+
+func (m *Migration) oldTable() string {
+	return fmt.Sprintf("_%s_del", m.table)
+}
+
+// syncTrigger is the name of the trigger and its function that sync the changes to the shadow table.
+func (m *Migration) syncTrigger() string {
+	return fmt.Sprintf("_%s_bbsync", m.table)
+}
+
+func (m *Migration) syncTruncateTrigger() string {
+	return fmt.Sprintf("_%s_bbsync_truncate", m.table)
+}
+
+// Check checks whether the table supports the online schema change.
+func (m *Migration) Check(ctx context.Context) error {
+	var version int
+	if err := m.db.QueryRowContext(ctx, "SELECT current_setting('server_version_num')::INT").Scan(&version); err != nil {
+		return errors.Wrap(err, "failed to get server version")
+	}
+	// The generated columns are introduced in PostgreSQL 12.
+	if version < 120000 {
+		return errors.Errorf("online schema change requires PostgreSQL 12 or later")
+	}
+	var relkind string
+	if err := m.db.QueryRowContext(ctx, `
+		SELECT c.relkind FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relname = $2`, m.schema, m.table).Scan(&relkind); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.Errorf("table %s not found", m.Table())
+		}
+		return errors.Wrapf(err, "failed to get table %s", m.Table())
+	}
+	if relkind != "r" {
+		return errors.Errorf("online schema change only supports ordinary tables, %s is not", m.Table())
+	}
+	if _, err := m.getPrimaryKey(ctx, m.Table()); err != nil {
+		return err
+	}
+	var exists bool
+	if err := m.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace WHERE n.nspname = $1 AND c.relname = $2)`, m.schema, m.oldTable()).Scan(&exists); err != nil {
+		return errors.Wrapf(err, "failed to check table %s", m.oldTable())
+	}
+	if exists {
+		return errors.Errorf("table %s already exists, drop it before the online schema change", quoteTable(m.schema, m.oldTable()))
+	}
+
+	// The foreign keys, views and rules referencing the original table still reference it after the cutover.
+	var count int
+	if err := m.db.QueryRowContext(ctx, `SELECT count(*) FROM pg_constraint WHERE contype = 'f' AND confrelid = $1::regclass`, m.Table()).Scan(&count); err != nil {
+		return errors.Wrapf(err, "failed to check foreign keys referencing %s", m.Table())
+	}
+	if count > 0 {
+		return errors.Errorf("online schema change doesn't support table %s referenced by foreign keys", m.Table())
+	}
+	if err := m.db.QueryRowContext(ctx, `
+		SELECT count(DISTINCT r.ev_class) FROM pg_depend d JOIN pg_rewrite r ON r.oid = d.objid
+		WHERE d.classid = 'pg_rewrite'::regclass AND d.refobjid = $1::regclass AND r.ev_class <> $1::regclass`, m.Table()).Scan(&count); err != nil {
+		return errors.Wrapf(err, "failed to check views depending on %s", m.Table())
+	}
+	if count > 0 {
+		return errors.Errorf("online schema change doesn't support table %s used by views", m.Table())
+	}
+	if err := m.db.QueryRowContext(ctx, `SELECT count(*) FROM pg_trigger WHERE tgrelid = $1::regclass AND NOT tgisinternal AND tgname NOT IN ($2, $3)`, m.Table(), m.syncTrigger(), m.syncTruncateTrigger()).Scan(&count); err != nil {
+		return errors.Wrapf(err, "failed to check triggers of %s", m.Table())
+	}
+	if count > 0 {
+		return errors.Errorf("online schema change doesn't support table %s with triggers", m.Table())
+	}
+	return nil
+}
+
+// Sync creates the shadow table with the new schema, starts capturing the changes of the original table and
+// backfills the shadow table. After it returns, the trigger keeps the shadow table in sync until the cutover.
+// It cleans up the shadow table and the trigger if it fails.
+func (m *Migration) Sync(ctx context.Context, report func(Progress)) (err error) {
+	if err := m.Check(ctx); err != nil {
+		return err
+	}
+	// Clean up the leftovers of the previous attempts.
+	if err := m.Cleanup(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = m.cleanupAfterFailure(err)
+		}
+	}()
+
+	shadowTable := quoteTable(m.schema, m.shadowTable())
+	if _, err := m.db.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING ALL)", shadowTable, m.Table())); err != nil {
+		return errors.Wrapf(err, "failed to create shadow table %s", shadowTable)
+	}
+	// LIKE doesn't copy the foreign keys.
+	foreignKeys, err := m.getForeignKeys(ctx)
+	if err != nil {
+		return err
+	}
+	for _, fk := range foreignKeys {
+		if _, err := m.db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s", shadowTable, quoteIdentifier(fk.name), fk.definition)); err != nil {
+			return errors.Wrapf(err, "failed to add foreign key %q to shadow table", fk.name)
+		}
+	}
+	if _, err := m.db.ExecContext(ctx, m.alterShadowStatement); err != nil {
+		return errors.Wrapf(err, "failed to alter shadow table")
+	}
+
+	primaryKey, err := m.getPrimaryKey(ctx, m.Table())
+	if err != nil {
+		return err
+	}
+	shadowPrimaryKey, err := m.getPrimaryKey(ctx, shadowTable)
+	if err != nil {
+		return errors.Wrapf(err, "the statement must keep the primary key")
+	}
+	if primaryKey.names() != shadowPrimaryKey.names() {
+		return errors.Errorf("online schema change doesn't support changing the primary key")
+	}
+	columns, err := m.getCommonColumns(ctx, shadowTable)
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.db.ExecContext(ctx, m.syncTriggerStatement(primaryKey, columns)); err != nil {
+		return errors.Wrapf(err, "failed to create sync trigger")
+	}
+	return m.backfill(ctx, primaryKey, columns, report)
+}
+
+func (m *Migration) backfill(ctx context.Context, primaryKey primaryKey, columns []string, report func(Progress)) error {
+	var progress Progress
+	if err := m.db.QueryRowContext(ctx, `SELECT GREATEST(reltuples::BIGINT, 0) FROM pg_class WHERE oid = $1::regclass`, m.Table()).Scan(&progress.TotalRows); err != nil {
+		return errors.Wrapf(err, "failed to estimate rows of %s", m.Table())
+	}
+	report(progress)
+
+	var lastKey []any
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		query := m.backfillStatement(primaryKey, columns, lastKey != nil)
+		values := make([]sql.NullString, len(primaryKey))
+		dest := []any{new(int64)}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := m.db.QueryRowContext(ctx, query, lastKey...).Scan(dest...); err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to backfill shadow table")
+		}
+		progress.CopiedRows += *dest[0].(*int64)
+		if progress.CopiedRows > progress.TotalRows {
+			progress.TotalRows = progress.CopiedRows
+		}
+		report(progress)
+		lastKey = nil
+		for _, v := range values {
+			lastKey = append(lastKey, v.String)
+		}
+	}
+}
+
+// backfillStatement copies the next batch of rows after the last primary key. The rows are locked in SHARE mode
+// so that a concurrent delete cannot happen between reading the row and copying it, which would resurrect the row.
+// The rows already written by the trigger are newer and skipped.
+func (m *Migration) backfillStatement(primaryKey primaryKey, columns []string, hasLastKey bool) string {
+	where := ""
+	if hasLastKey {
+		var placeholders []string
+		for i, column := range primaryKey {
+			placeholders = append(placeholders, fmt.Sprintf("$%d::TEXT::%s", i+1, column.dataType))
+		}
+		where = fmt.Sprintf("WHERE (%s) > (%s)", primaryKey.names(), strings.Join(placeholders, ", "))
+	}
+	var descending, lastKey []string
+	for _, column := range primaryKey {
+		descending = append(descending, quoteIdentifier(column.name)+" DESC")
+		lastKey = append(lastKey, quoteIdentifier(column.name)+"::TEXT")
+	}
+	columnList := quoteIdentifiers(columns)
+	return fmt.Sprintf(`WITH batch AS (
+	SELECT %s FROM %s %s ORDER BY %s LIMIT %d FOR SHARE
+), copied AS (
+	INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE SELECT %s FROM batch ON CONFLICT (%s) DO NOTHING
+)
+SELECT (SELECT count(*) FROM batch), %s FROM batch ORDER BY %s LIMIT 1`,
+		columnList, m.Table(), where, primaryKey.names(), m.batchSize,
+		quoteTable(m.schema, m.shadowTable()), columnList, columnList, primaryKey.names(),
+		strings.Join(lastKey, ", "), strings.Join(descending, ", "))
+}
+
+// syncTriggerStatement creates the trigger applying the row changes of the original table to the shadow table.
+func (m *Migration) syncTriggerStatement(primaryKey primaryKey, columns []string) string {
+	shadowTable := quoteTable(m.schema, m.shadowTable())
+	function := quoteTable(m.schema, m.syncTrigger())
+	var oldKey, newKey, newValues, updates []string
+	for _, column := range primaryKey {
+		oldKey = append(oldKey, "OLD."+quoteIdentifier(column.name))
+		newKey = append(newKey, "NEW."+quoteIdentifier(column.name))
+	}
+	isKey := map[string]bool{}
+	for _, column := range primaryKey {
+		isKey[column.name] = true
+	}
+	for _, column := range columns {
+		newValues = append(newValues, "NEW."+quoteIdentifier(column))
+		if !isKey[column] {
+			updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", quoteIdentifier(column), quoteIdentifier(column)))
+		}
+	}
+	onConflict := "DO NOTHING"
+	if len(updates) > 0 {
+		onConflict = "DO UPDATE SET " + strings.Join(updates, ", ")
+	}
+	return fmt.Sprintf(`CREATE FUNCTION %s() RETURNS trigger LANGUAGE plpgsql AS $bbsync$
+BEGIN
+	IF TG_OP = 'TRUNCATE' THEN
+		TRUNCATE %s;
+		RETURN NULL;
+	END IF;
+	IF TG_OP = 'DELETE' OR (TG_OP = 'UPDATE' AND (%s) IS DISTINCT FROM (%s)) THEN
+		DELETE FROM %s WHERE (%s) = (%s);
+	END IF;
+	IF TG_OP <> 'DELETE' THEN
+		INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE VALUES (%s) ON CONFLICT (%s) %s;
+	END IF;
+	RETURN NULL;
+END
+$bbsync$;
+CREATE TRIGGER %s AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE PROCEDURE %s();
+CREATE TRIGGER %s AFTER TRUNCATE ON %s FOR EACH STATEMENT EXECUTE PROCEDURE %s();`,
+		function,
+		shadowTable,
+		strings.Join(oldKey, ", "), strings.Join(newKey, ", "),
+		shadowTable, primaryKey.names(), strings.Join(oldKey, ", "),
+		shadowTable, quoteIdentifiers(columns), strings.Join(newValues, ", "), primaryKey.names(), onConflict,
+		quoteIdentifier(m.syncTrigger()), m.Table(), function,
+		quoteIdentifier(m.syncTruncateTrigger()), m.Table(), function,
+	)
+}
+
+// Cutover swaps the original table and the shadow table atomically. It retries if it times out acquiring the
+// lock of the original table. It cleans up the shadow table and the trigger if it fails, so the sync must be
+// run again.
+func (m *Migration) Cutover(ctx context.Context) (err error) {
+	defer func() {
+		if err != nil {
+			err = m.cleanupAfterFailure(err)
+		}
+	}()
+	for i := 0; i < cutoverMaxRetries; i++ {
+		if err = m.cutoverOnce(ctx); err == nil || !isLockTimeout(err) {
+			return err
+		}
+		select {
+		case <-time.After(time.Duration(i+1) * time.Second):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return errors.Wrapf(err, "failed to cutover after %d attempts", cutoverMaxRetries)
+}
+
+func (m *Migration) cutoverOnce(ctx context.Context) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	shadowTable := quoteTable(m.schema, m.shadowTable())
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL lock_timeout = '%dms'", cutoverLockTimeout.Milliseconds())); err != nil {
+		return err
+	}
+	// Block the writes so that the shadow table is identical to the original table.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("LOCK TABLE %s, %s IN ACCESS EXCLUSIVE MODE", m.Table(), shadowTable)); err != nil {
+		return errors.Wrapf(err, "failed to lock table %s", m.Table())
+	}
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM pg_trigger WHERE tgrelid = $1::regclass AND tgname = $2)`, m.Table(), m.syncTrigger()).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("sync trigger of %s not found, the shadow table may be stale", m.Table())
+	}
+
+	statements := []string{
+		fmt.Sprintf("DROP FUNCTION %s() CASCADE", quoteTable(m.schema, m.syncTrigger())),
+	}
+	sequenceStatements, err := m.getSequenceStatements(ctx, tx)
+	if err != nil {
+		return err
+	}
+	statements = append(statements, sequenceStatements...)
+	indexStatements, err := m.getIndexRenameStatements(ctx, tx)
+	if err != nil {
+		return err
+	}
+	statements = append(statements,
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", m.Table(), quoteIdentifier(m.oldTable())),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", shadowTable, quoteIdentifier(m.table)),
+	)
+	statements = append(statements, indexStatements...)
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return errors.Wrapf(err, "failed to execute %q", statement)
+		}
+	}
+	return tx.Commit()
+}
+
+// Cleanup drops the sync trigger and the shadow table.
+func (m *Migration) Cleanup(ctx context.Context) error {
+	for _, statement := range []string{
+		fmt.Sprintf("DROP FUNCTION IF EXISTS %s() CASCADE", quoteTable(m.schema, m.syncTrigger())),
+		fmt.Sprintf("DROP TABLE IF EXISTS %s", quoteTable(m.schema, m.shadowTable())),
+	} {
+		if _, err := m.db.ExecContext(ctx, statement); err != nil {
+			return errors.Wrapf(err, "failed to execute %q", statement)
+		}
+	}
+	return nil
+}
+
+// cleanupAfterFailure cleans up after the migration fails with err. It uses a new context because the ctx of the
+// migration may be canceled.
+func (m *Migration) cleanupAfterFailure(err error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+	if cleanupErr := m.Cleanup(ctx); cleanupErr != nil {
+		return errors.Wrapf(err, "failed to clean up: %v", cleanupErr)
+	}
+	return err
+}
+
+func isLockTimeout(err error) bool {
+	// lock_not_available.
+	return strings.Contains(err.Error(), "SQLSTATE 55P03")
+}
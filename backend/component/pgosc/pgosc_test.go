@@ -0,0 +1,304 @@
+package pgosc
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTableFromStatement(t *testing.T) {
+	tests := []struct {
+		statement string
+		schema    string
+		table     string
+		wantErr   bool
+	}{
+		{
+			statement: "ALTER TABLE t ADD COLUMN c INT NOT NULL DEFAULT 0;",
+			table:     "t",
+		},
+		{
+			statement: `ALTER TABLE ONLY "Public"."Order" ALTER COLUMN amount TYPE BIGINT, ADD CONSTRAINT c CHECK (amount > 0);`,
+			schema:    "Public",
+			table:     "Order",
+		},
+		{
+			statement: "ALTER TABLE t ALTER COLUMN c TYPE INT USING c::INT;",
+			wantErr:   true,
+		},
+		{
+			statement: "ALTER TABLE t ADD COLUMN a INT; ALTER TABLE t ADD COLUMN b INT;",
+			wantErr:   true,
+		},
+		{
+			statement: "ALTER TABLE t RENAME COLUMN a TO b;",
+			wantErr:   true,
+		},
+		{
+			statement: "ALTER INDEX i SET TABLESPACE s;",
+			wantErr:   true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		schema, table, err := GetTableFromStatement(test.statement)
+		if test.wantErr {
+			a.Error(err, test.statement)
+			continue
+		}
+		a.NoError(err, test.statement)
+		a.Equal(test.schema, schema)
+		a.Equal(test.table, table)
+	}
+}
+
+func TestRewriteStatement(t *testing.T) {
+	a := require.New(t)
+	got, err := rewriteStatement("ALTER TABLE IF EXISTS ONLY t ADD COLUMN c INT NOT NULL DEFAULT 0, DROP COLUMN d", "public", "_t_gho")
+	a.NoError(err)
+	a.Equal(`ALTER TABLE public._t_gho ADD COLUMN c int NOT NULL DEFAULT 0, DROP d`, got)
+}
+
+func TestBackfillStatement(t *testing.T) {
+	a := require.New(t)
+	m := &Migration{schema: "public", table: "t", batchSize: 100}
+	key := primaryKey{{name: "a", dataType: "integer"}, {name: "b", dataType: "text"}}
+	want := `WITH batch AS (
+	SELECT "a", "b", "c" FROM "public"."t" WHERE ("a", "b") > ($1::TEXT::integer, $2::TEXT::text) ORDER BY "a", "b" LIMIT 100 FOR SHARE
+), copied AS (
+	INSERT INTO "public"."_t_gho" ("a", "b", "c") OVERRIDING SYSTEM VALUE SELECT "a", "b", "c" FROM batch ON CONFLICT ("a", "b") DO NOTHING
+)
+SELECT (SELECT count(*) FROM batch), "a"::TEXT, "b"::TEXT FROM batch ORDER BY "a" DESC, "b" DESC LIMIT 1`
+	a.Equal(want, m.backfillStatement(key, []string{"a", "b", "c"}, true))
+}
+
+func TestSyncTriggerStatement(t *testing.T) {
+	a := require.New(t)
+	m := &Migration{schema: "public", table: "t"}
+	want := `CREATE FUNCTION "public"."_t_bbsync"() RETURNS trigger LANGUAGE plpgsql AS $bbsync$
+BEGIN
+	IF TG_OP = 'TRUNCATE' THEN
+		TRUNCATE "public"."_t_gho";
+		RETURN NULL;
+	END IF;
+	IF TG_OP = 'DELETE' OR (TG_OP = 'UPDATE' AND (OLD."id") IS DISTINCT FROM (NEW."id")) THEN
+		DELETE FROM "public"."_t_gho" WHERE ("id") = (OLD."id");
+	END IF;
+	IF TG_OP <> 'DELETE' THEN
+		INSERT INTO "public"."_t_gho" ("id", "name") OVERRIDING SYSTEM VALUE VALUES (NEW."id", NEW."name") ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name";
+	END IF;
+	RETURN NULL;
+END
+$bbsync$;
+CREATE TRIGGER "_t_bbsync" AFTER INSERT OR UPDATE OR DELETE ON "public"."t" FOR EACH ROW EXECUTE PROCEDURE "public"."_t_bbsync"();
+CREATE TRIGGER "_t_bbsync_truncate" AFTER TRUNCATE ON "public"."t" FOR EACH STATEMENT EXECUTE PROCEDURE "public"."_t_bbsync"();`
+	a.Equal(want, m.syncTriggerStatement(primaryKey{{name: "id", dataType: "bigint"}}, []string{"id", "name"}))
+}
+
+var cleanupStatements = []string{
+	`DROP FUNCTION IF EXISTS "public"."_t_bbsync"() CASCADE`,
+	`DROP TABLE IF EXISTS "public"."_t_gho"`,
+}
+
+func TestSyncCanceled(t *testing.T) {
+	a := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fake := newFakeSyncDB()
+	fake.onQuery = func(query string) error {
+		if strings.HasPrefix(query, "WITH batch AS") {
+			// Cancel the task during the backfill.
+			cancel()
+		}
+		return nil
+	}
+	m := newTestMigration(t, fake)
+
+	err := m.Sync(ctx, func(Progress) {})
+	a.ErrorIs(err, context.Canceled)
+	a.Equal(cleanupStatements, fake.lastStatements(len(cleanupStatements)))
+	a.Contains(fake.statements, m.syncTriggerStatement(primaryKey{{name: "id", dataType: "integer"}}, []string{"id", "name"}))
+}
+
+func TestSyncFailed(t *testing.T) {
+	a := require.New(t)
+	fake := newFakeSyncDB()
+	fake.onExec = func(statement string) error {
+		if strings.HasPrefix(statement, "ALTER TABLE") {
+			return errors.New("column already exists")
+		}
+		return nil
+	}
+	m := newTestMigration(t, fake)
+
+	err := m.Sync(context.Background(), func(Progress) {})
+	a.ErrorContains(err, "column already exists")
+	a.Equal(cleanupStatements, fake.lastStatements(len(cleanupStatements)))
+}
+
+func TestCutoverFailed(t *testing.T) {
+	a := require.New(t)
+	fake := newFakeSyncDB()
+	fake.onExec = func(statement string) error {
+		if strings.HasPrefix(statement, "LOCK TABLE") {
+			return errors.New("permission denied")
+		}
+		return nil
+	}
+	m := newTestMigration(t, fake)
+
+	err := m.Cutover(context.Background())
+	a.ErrorContains(err, "permission denied")
+	a.Equal(cleanupStatements, fake.lastStatements(len(cleanupStatements)))
+}
+
+func newTestMigration(t *testing.T, fake *fakeDB) *Migration {
+	db := sql.OpenDB(fake)
+	t.Cleanup(func() {
+		db.Close()
+	})
+	m, err := NewMigration(context.Background(), db, "ALTER TABLE public.t ADD COLUMN c INT")
+	require.NoError(t, err)
+	return m
+}
+
+// newFakeSyncDB returns the database with the table public.t(id, name) which supports the online schema change.
+func newFakeSyncDB() *fakeDB {
+	return &fakeDB{
+		results: []fakeResult{
+			{query: "server_version_num", rows: [][]driver.Value{{int64(150000)}}},
+			{query: "SELECT c.relkind", rows: [][]driver.Value{{"r"}}},
+			{query: "i.indisprimary", rows: [][]driver.Value{{"id", "integer"}}},
+			{query: "pg_get_constraintdef"},
+			{query: "a.attgenerated", rows: [][]driver.Value{{"id"}, {"name"}}},
+			{query: "reltuples", rows: [][]driver.Value{{int64(2)}}},
+			{query: "WITH batch AS", rows: [][]driver.Value{{int64(2), "2"}}},
+			{query: "SELECT EXISTS", rows: [][]driver.Value{{false}}},
+			{query: "count(", rows: [][]driver.Value{{int64(0)}}},
+		},
+	}
+}
+
+type fakeResult struct {
+	// query is the substring of the query.
+	query string
+	rows  [][]driver.Value
+}
+
+// fakeDB is the database/sql connector recording the executed statements and answering the queries with the
+// first result matching the query.
+type fakeDB struct {
+	results []fakeResult
+	onExec  func(statement string) error
+	onQuery func(query string) error
+
+	mu         sync.Mutex
+	statements []string
+}
+
+func (f *fakeDB) lastStatements(n int) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.statements) < n {
+		return f.statements
+	}
+	return f.statements[len(f.statements)-n:]
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{db: f}, nil
+}
+
+func (f *fakeDB) Driver() driver.Driver {
+	return nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (*fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (*fakeConn) Close() error {
+	return nil
+}
+
+func (*fakeConn) Begin() (driver.Tx, error) {
+	return &fakeTx{}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.db.mu.Lock()
+	c.db.statements = append(c.db.statements, query)
+	c.db.mu.Unlock()
+	if c.db.onExec != nil {
+		if err := c.db.onExec(query); err != nil {
+			return nil, err
+		}
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if c.db.onQuery != nil {
+		if err := c.db.onQuery(query); err != nil {
+			return nil, err
+		}
+	}
+	for _, result := range c.db.results {
+		if strings.Contains(query, result.query) {
+			return &fakeRows{rows: result.rows}, nil
+		}
+	}
+	return nil, errors.Errorf("unexpected query %q", query)
+}
+
+type fakeTx struct{}
+
+func (*fakeTx) Commit() error {
+	return nil
+}
+
+func (*fakeTx) Rollback() error {
+	return nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return []string{"column"}
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (*fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,144 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/pkg/errors"
+)
+
+const awsSecretsManagerService = "secretsmanager"
+
+// AWSSecretsManagerProvider gets the secrets from AWS Secrets Manager.
+// The reference is <secret-id>#<key>, where the secret ID is the name or the ARN of the secret.
+// The key selects the field of the JSON secret string, and the whole secret string is used if omitted.
+type AWSSecretsManagerProvider struct {
+	// endpoint overrides the regional endpoint, e.g. for the VPC endpoint or tests.
+	endpoint string
+	client   *http.Client
+	signer   *v4.Signer
+
+	mu sync.Mutex
+	// cfg is loaded on the first use so that the AWS credential chain is not touched if the provider is not used.
+	cfg *aws.Config
+}
+
+// NewAWSSecretsManagerProvider creates an AWS Secrets Manager provider. The endpoint defaults to
+// AWS_ENDPOINT_URL_SECRETS_MANAGER, or the regional endpoint if not set.
+func NewAWSSecretsManagerProvider(endpoint string) *AWSSecretsManagerProvider {
+	if endpoint == "" {
+		endpoint = os.Getenv("AWS_ENDPOINT_URL_SECRETS_MANAGER")
+	}
+	return &AWSSecretsManagerProvider{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{Timeout: httpTimeout},
+		signer:   v4.NewSigner(),
+	}
+}
+
+// NewAWSSecretsManagerProviderWithConfig creates an AWS Secrets Manager provider with the AWS config.
+func NewAWSSecretsManagerProviderWithConfig(endpoint string, cfg aws.Config) *AWSSecretsManagerProvider {
+	p := NewAWSSecretsManagerProvider(endpoint)
+	p.cfg = &cfg
+	return p
+}
+
+func (p *AWSSecretsManagerProvider) getConfig(ctx context.Context) (aws.Config, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cfg != nil {
+		return *p.cfg, nil
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return aws.Config{}, errors.Wrap(err, "failed to load AWS config")
+	}
+	if cfg.Region == "" {
+		return aws.Config{}, errors.New("AWS region is not configured, set AWS_REGION")
+	}
+	p.cfg = &cfg
+	return cfg, nil
+}
+
+type getSecretValueResponse struct {
+	SecretString string `json:"SecretString"`
+	Message      string `json:"message"`
+	Type         string `json:"__type"`
+}
+
+// GetSecret gets the current version of the secret.
+func (p *AWSSecretsManagerProvider) GetSecret(ctx context.Context, reference string) (*Secret, error) {
+	secretID, key, _ := strings.Cut(reference, "#")
+	if secretID == "" {
+		return nil, errors.Errorf("invalid AWS secret reference %q, expect <secret-id>#<key>", reference)
+	}
+	cfg, err := p.getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve AWS credentials")
+	}
+
+	body, err := json.Marshal(map[string]string{"SecretId": secretID})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := p.endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", awsSecretsManagerService, cfg.Region)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid AWS Secrets Manager endpoint %q", endpoint)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	payloadHash := sha256.Sum256(body)
+	if err := p.signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(payloadHash[:]), awsSecretsManagerService, cfg.Region, time.Now()); err != nil {
+		return nil, errors.Wrap(err, "failed to sign AWS request")
+	}
+
+	response, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get AWS secret %q", secretID)
+	}
+	defer response.Body.Close()
+	var r getSecretValueResponse
+	if err := json.NewDecoder(response.Body).Decode(&r); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode AWS Secrets Manager response")
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to get AWS secret %q, status %d: %s %s", secretID, response.StatusCode, r.Type, r.Message)
+	}
+
+	if key == "" {
+		return &Secret{Value: r.SecretString}, nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(r.SecretString), &fields); err != nil {
+		return nil, errors.Errorf("AWS secret %q is not JSON, cannot get key %q", secretID, key)
+	}
+	value, ok := fields[key]
+	if !ok {
+		return nil, errors.Errorf("key %q not found in AWS secret %q", key, secretID)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.Errorf("key %q of AWS secret %q is not a string", key, secretID)
+	}
+	return &Secret{Value: s}, nil
+}
@@ -1,77 +1,247 @@
 // Package secret includes the component of getting secrets from external sources.
+//
+// A data source password in the form of {{reference}} is resolved by the secret provider of the reference scheme:
+//
+//   - {{https://secretmanager.googleapis.com/...}}: the GCP-style URL whose response has the base64 encoded payload.data.
+//   - {{vault:<mount>/<path>#<key>}}: the key of the HashiCorp Vault KV v2 secret.
+//   - {{vault-database:<mount>/<role>}}: the short-lived credential leased from the Vault database secrets engine.
+//   - {{aws-secretsmanager:<secret-id>#<key>}}: the AWS Secrets Manager secret, or the key of it if the secret is JSON.
+//
+// The Vault provider is configured by VAULT_ADDR, VAULT_NAMESPACE, and either VAULT_TOKEN or VAULT_ROLE_ID and
+// VAULT_SECRET_ID for AppRole authentication. The AWS provider uses the default credential chain and region.
 package secret
 
 import (
-	"encoding/base64"
-	"encoding/json"
-	"net/http"
-	"net/url"
+	"context"
+	"log/slog"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common/log"
 )
 
-var secretCache = make(map[string]string)
+const (
+	// defaultCacheTTL is how long a secret is cached before it's fetched again, so that the rotated secrets are picked up.
+	defaultCacheTTL = 5 * time.Minute
+	// leaseRevokeTimeout is the timeout of revoking the lease when the credential is released.
+	leaseRevokeTimeout = 10 * time.Second
+)
 
-// ReplaceExternalSecret replaces the secret with external secret.
-func ReplaceExternalSecret(secret string) (string, error) {
-	ok, secretURL := GetExternalSecretURL(secret)
-	if !ok {
-		return secret, nil
-	}
-	if v, ok := secretCache[secretURL]; ok {
-		return v, nil
-	}
-	secret, err := getSecretFromURL(secretURL)
-	if err != nil {
-		return "", err
+// Provider is the external secret provider.
+type Provider interface {
+	// GetSecret gets the secret by the reference without the scheme.
+	GetSecret(ctx context.Context, reference string) (*Secret, error)
+}
+
+// LeaseProvider is the provider whose secrets are leased and must be renewed before they expire.
+type LeaseProvider interface {
+	Provider
+	// RenewLease renews the lease and returns the new lease duration.
+	RenewLease(ctx context.Context, leaseID string, increment time.Duration) (time.Duration, error)
+	// RevokeLease revokes the lease so that the credential is no longer valid.
+	RevokeLease(ctx context.Context, leaseID string) error
+}
+
+// Secret is the secret returned by the provider.
+type Secret struct {
+	// Username is the username of the leased database credential. It's empty for the other secrets.
+	Username string
+	Value    string
+	// TTL overrides the cache TTL if positive.
+	TTL time.Duration
+	// The lease of the secret. The leased secrets are never cached.
+	LeaseID       string
+	LeaseDuration time.Duration
+	Renewable     bool
+}
+
+// Credential is the resolved credential of a data source.
+type Credential struct {
+	// Username overrides the data source username if not empty.
+	Username string
+	Password string
+
+	stop    chan struct{}
+	release sync.Once
+	revoke  func()
+}
+
+// HasLease returns whether the credential is leased, in which case it must be released after use.
+func (c *Credential) HasLease() bool {
+	return c.revoke != nil
+}
+
+// Release stops renewing the lease and revokes it. It's a no-op for the credentials without a lease.
+func (c *Credential) Release() {
+	if c.revoke == nil {
+		return
 	}
-	secretCache[secretURL] = secret
-	return secret, err
+	c.release.Do(func() {
+		close(c.stop)
+		c.revoke()
+	})
+}
+
+type cacheEntry struct {
+	value      string
+	expireTime time.Time
+}
+
+// Manager resolves the secret references with the registered providers and caches the secrets.
+type Manager struct {
+	providers map[string]Provider
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]*cacheEntry
 }
 
-// GetExternalSecretURL gets external secret URL from secret.
-func GetExternalSecretURL(secret string) (bool, string) {
-	if !strings.HasPrefix(secret, "{{") {
-		return false, ""
+// NewManager creates a secret manager with the providers keyed by the reference scheme.
+func NewManager(providers map[string]Provider) *Manager {
+	return &Manager{
+		providers: providers,
+		ttl:       defaultCacheTTL,
+		now:       time.Now,
+		cache:     map[string]*cacheEntry{},
 	}
-	if !strings.HasSuffix(secret, "}}") {
-		return false, ""
+}
+
+// NewManagerFromEnv creates a secret manager with the URL, Vault and AWS Secrets Manager providers.
+// The Vault providers are only registered if VAULT_ADDR is set.
+func NewManagerFromEnv() *Manager {
+	urlProvider := NewURLProvider()
+	providers := map[string]Provider{
+		"http":               urlProvider,
+		"https":              urlProvider,
+		"aws-secretsmanager": NewAWSSecretsManagerProvider(""),
 	}
-	s := secret[2 : len(secret)-2]
-	if _, err := url.ParseRequestURI(s); err != nil {
-		return false, ""
+	if vault, ok := NewVaultClientFromEnv(); ok {
+		providers["vault"] = NewVaultKVProvider(vault)
+		providers["vault-database"] = NewVaultDatabaseProvider(vault)
 	}
-	return true, s
+	return NewManager(providers)
 }
 
-type payload struct {
-	Data string `json:"data"`
+// IsExternalSecret returns whether the secret is a reference to the external secret.
+func IsExternalSecret(secret string) bool {
+	_, _, ok := parseReference(secret)
+	return ok
 }
 
-type accessResponse struct {
-	Payload payload `json:"payload"`
+// parseReference parses the {{scheme:reference}} into the scheme and the reference. The reference of the URL
+// scheme is the whole URL.
+func parseReference(secret string) (string, string, bool) {
+	if !strings.HasPrefix(secret, "{{") || !strings.HasSuffix(secret, "}}") {
+		return "", "", false
+	}
+	s := strings.TrimSpace(secret[2 : len(secret)-2])
+	scheme, reference, ok := strings.Cut(s, ":")
+	if !ok || scheme == "" || reference == "" {
+		return "", "", false
+	}
+	switch scheme {
+	case "http", "https":
+		return scheme, s, true
+	default:
+		return scheme, reference, true
+	}
 }
 
-func getSecretFromURL(secretURL string) (string, error) {
-	response, err := http.Get(secretURL)
-	if err != nil {
-		return "", errors.Wrapf(err, "failed to get secret from %q", secretURL)
+// Resolve resolves the password if it's an external secret reference, otherwise it returns the password as is.
+// The caller must release the credential if it has a lease.
+func (m *Manager) Resolve(ctx context.Context, password string) (*Credential, error) {
+	scheme, reference, ok := parseReference(password)
+	if !ok {
+		return &Credential{Password: password}, nil
+	}
+	provider, ok := m.providers[scheme]
+	if !ok {
+		return nil, errors.Errorf("secret provider %q is not configured", scheme)
+	}
+	if leaseProvider, ok := provider.(LeaseProvider); ok {
+		return m.lease(ctx, leaseProvider, reference)
 	}
-	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		return "", errors.Wrapf(err, "failed to get secret from %q status %v", secretURL, response.StatusCode)
+	key := scheme + ":" + reference
+	now := m.now()
+	m.mu.Lock()
+	entry := m.cache[key]
+	m.mu.Unlock()
+	if entry != nil && now.Before(entry.expireTime) {
+		return &Credential{Password: entry.value}, nil
 	}
 
-	var r accessResponse
-	decoder := json.NewDecoder(response.Body)
-	if err := decoder.Decode(&r); err != nil {
-		return "", errors.Wrapf(err, "failed to decode JSON response")
+	secret, err := provider.GetSecret(ctx, reference)
+	if err != nil {
+		if entry != nil {
+			// Keep using the stale secret while the provider is unavailable, it's still valid unless rotated.
+			slog.Warn("failed to refresh the external secret, using the cached one", slog.String("scheme", scheme), log.BBError(err))
+			return &Credential{Password: entry.value}, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get secret from %s provider", scheme)
 	}
-	secret, err := base64.StdEncoding.DecodeString(r.Payload.Data)
+	ttl := m.ttl
+	if secret.TTL > 0 {
+		ttl = secret.TTL
+	}
+	m.mu.Lock()
+	m.cache[key] = &cacheEntry{value: secret.Value, expireTime: now.Add(ttl)}
+	m.mu.Unlock()
+	return &Credential{Password: secret.Value}, nil
+}
+
+func (m *Manager) lease(ctx context.Context, provider LeaseProvider, reference string) (*Credential, error) {
+	secret, err := provider.GetSecret(ctx, reference)
 	if err != nil {
-		panic(err)
+		return nil, errors.Wrapf(err, "failed to lease secret %q", reference)
+	}
+	credential := &Credential{
+		Username: secret.Username,
+		Password: secret.Value,
+		stop:     make(chan struct{}),
+	}
+	if secret.LeaseID == "" {
+		return credential, nil
+	}
+	credential.revoke = func() {
+		ctx, cancel := context.WithTimeout(context.Background(), leaseRevokeTimeout)
+		defer cancel()
+		if err := provider.RevokeLease(ctx, secret.LeaseID); err != nil {
+			slog.Warn("failed to revoke secret lease", slog.String("lease", secret.LeaseID), log.BBError(err))
+		}
+	}
+	if secret.Renewable && secret.LeaseDuration > 0 {
+		go renewLease(provider, secret.LeaseID, secret.LeaseDuration, credential.stop)
+	}
+	return credential, nil
+}
+
+// renewLease renews the lease when two thirds of the lease duration have passed, until stopped or the renewal fails.
+func renewLease(provider LeaseProvider, leaseID string, duration time.Duration, stop <-chan struct{}) {
+	increment := duration
+	for {
+		timer := time.NewTimer(duration * 2 / 3)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), leaseRevokeTimeout)
+		newDuration, err := provider.RenewLease(ctx, leaseID, increment)
+		cancel()
+		if err != nil {
+			slog.Warn("failed to renew secret lease", slog.String("lease", leaseID), log.BBError(err))
+			return
+		}
+		if newDuration <= 0 {
+			// The lease reached its max TTL and cannot be extended any more.
+			return
+		}
+		duration = newDuration
 	}
-	return string(secret), nil
 }
@@ -0,0 +1,322 @@
+package secret
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		secret    string
+		scheme    string
+		reference string
+		ok        bool
+	}{
+		{
+			secret: "plain-password",
+		},
+		{
+			secret:    "{{https://secretmanager.googleapis.com/v1/projects/p/secrets/s/versions/1:access}}",
+			scheme:    "https",
+			reference: "https://secretmanager.googleapis.com/v1/projects/p/secrets/s/versions/1:access",
+			ok:        true,
+		},
+		{
+			secret:    "{{vault:secret/bytebase/prod#password}}",
+			scheme:    "vault",
+			reference: "secret/bytebase/prod#password",
+			ok:        true,
+		},
+		{
+			secret:    "{{aws-secretsmanager:arn:aws:secretsmanager:us-east-1:123456789012:secret:prod#password}}",
+			scheme:    "aws-secretsmanager",
+			reference: "arn:aws:secretsmanager:us-east-1:123456789012:secret:prod#password",
+			ok:        true,
+		},
+		{
+			secret: "{{no-scheme}}",
+		},
+		{
+			secret: "{{vault:secret/x",
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		scheme, reference, ok := parseReference(test.secret)
+		a.Equal(test.ok, ok, test.secret)
+		a.Equal(test.scheme, scheme, test.secret)
+		a.Equal(test.reference, reference, test.secret)
+	}
+}
+
+func TestURLProvider(t *testing.T) {
+	a := require.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data":
+			_ = json.NewEncoder(w).Encode(map[string]any{"payload": map[string]any{"data": base64.StdEncoding.EncodeToString([]byte("ktPYr0bQixOHzCux"))}})
+		case "/bad":
+			_ = json.NewEncoder(w).Encode(map[string]any{"payload": map[string]any{"data": "not base64!"}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	m := NewManager(map[string]Provider{"http": NewURLProvider()})
+	credential, err := m.Resolve(context.Background(), "{{"+server.URL+"/data}}")
+	a.NoError(err)
+	a.Equal("ktPYr0bQixOHzCux", credential.Password)
+	a.False(credential.HasLease())
+
+	_, err = m.Resolve(context.Background(), "{{"+server.URL+"/bad}}")
+	a.Error(err)
+	_, err = m.Resolve(context.Background(), "{{"+server.URL+"/missing}}")
+	a.Error(err)
+
+	credential, err = m.Resolve(context.Background(), "plain")
+	a.NoError(err)
+	a.Equal("plain", credential.Password)
+}
+
+// fakeProvider returns the current value and counts the calls.
+type fakeProvider struct {
+	mu    sync.Mutex
+	value string
+	err   error
+	calls int
+}
+
+func (p *fakeProvider) GetSecret(context.Context, string) (*Secret, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &Secret{Value: p.value}, nil
+}
+
+func TestManagerCacheTTL(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	provider := &fakeProvider{value: "v1"}
+	m := NewManager(map[string]Provider{"fake": provider})
+	now := time.Unix(1700000000, 0)
+	m.now = func() time.Time { return now }
+
+	credential, err := m.Resolve(ctx, "{{fake:db}}")
+	a.NoError(err)
+	a.Equal("v1", credential.Password)
+
+	// The secret is rotated, but the cached one is used until it expires.
+	provider.value = "v2"
+	now = now.Add(defaultCacheTTL - time.Second)
+	credential, err = m.Resolve(ctx, "{{fake:db}}")
+	a.NoError(err)
+	a.Equal("v1", credential.Password)
+	a.Equal(1, provider.calls)
+
+	now = now.Add(2 * time.Second)
+	credential, err = m.Resolve(ctx, "{{fake:db}}")
+	a.NoError(err)
+	a.Equal("v2", credential.Password)
+	a.Equal(2, provider.calls)
+
+	// The stale secret is used if the provider is unavailable.
+	provider.err = context.DeadlineExceeded
+	now = now.Add(defaultCacheTTL + time.Second)
+	credential, err = m.Resolve(ctx, "{{fake:db}}")
+	a.NoError(err)
+	a.Equal("v2", credential.Password)
+
+	_, err = m.Resolve(ctx, "{{fake:other}}")
+	a.Error(err)
+	_, err = m.Resolve(ctx, "{{unknown:db}}")
+	a.Error(err)
+}
+
+// vaultServer is the stand-in Vault server with the AppRole auth, the KV v2 and the database secrets engines.
+type vaultServer struct {
+	mu       sync.Mutex
+	tokens   map[string]bool
+	logins   int
+	renewals int
+	revoked  []string
+}
+
+func (v *vaultServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	writeJSON := func(status int, body any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	}
+	if r.URL.Path == "/v1/auth/approle/login" {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["role_id"] != "role" || body["secret_id"] != "secret" {
+			writeJSON(http.StatusBadRequest, map[string]any{"errors": []string{"invalid role or secret ID"}})
+			return
+		}
+		v.logins++
+		token := "token-" + strings.Repeat("x", v.logins)
+		v.tokens[token] = true
+		writeJSON(http.StatusOK, map[string]any{"auth": map[string]any{"client_token": token, "lease_duration": 3600}})
+		return
+	}
+	if !v.tokens[r.Header.Get("X-Vault-Token")] {
+		writeJSON(http.StatusForbidden, map[string]any{"errors": []string{"permission denied"}})
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/secret/data/bytebase/prod":
+		writeJSON(http.StatusOK, map[string]any{"data": map[string]any{"data": map[string]any{"password": "kv-password", "port": 5432}}})
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/database/creds/readonly":
+		writeJSON(http.StatusOK, map[string]any{
+			"lease_id":       "database/creds/readonly/abc",
+			"lease_duration": 1,
+			"renewable":      true,
+			"data":           map[string]any{"username": "v-readonly-abc", "password": "db-password"},
+		})
+	case r.Method == http.MethodPut && r.URL.Path == "/v1/sys/leases/renew":
+		v.renewals++
+		writeJSON(http.StatusOK, map[string]any{"lease_id": "database/creds/readonly/abc", "lease_duration": 1, "renewable": true})
+	case r.Method == http.MethodPut && r.URL.Path == "/v1/sys/leases/revoke":
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		v.revoked = append(v.revoked, body["lease_id"])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeJSON(http.StatusNotFound, map[string]any{"errors": []string{}})
+	}
+}
+
+func TestVaultKVProvider(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	vault := &vaultServer{tokens: map[string]bool{"static": true}}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	// Token authentication.
+	provider := NewVaultKVProvider(NewVaultClient(VaultConfig{Address: server.URL, Token: "static"}))
+	secret, err := provider.GetSecret(ctx, "secret/bytebase/prod")
+	a.NoError(err)
+	a.Equal("kv-password", secret.Value)
+	_, err = provider.GetSecret(ctx, "secret/bytebase/prod#port")
+	a.Error(err)
+	_, err = provider.GetSecret(ctx, "secret/bytebase/prod#missing")
+	a.Error(err)
+	_, err = provider.GetSecret(ctx, "secret")
+	a.Error(err)
+
+	// AppRole authentication logs in again when the token is revoked.
+	client := NewVaultClient(VaultConfig{Address: server.URL, RoleID: "role", SecretID: "secret"})
+	provider = NewVaultKVProvider(client)
+	secret, err = provider.GetSecret(ctx, "secret/bytebase/prod#password")
+	a.NoError(err)
+	a.Equal("kv-password", secret.Value)
+	a.Equal(1, vault.logins)
+	vault.tokens = map[string]bool{}
+	secret, err = provider.GetSecret(ctx, "secret/bytebase/prod#password")
+	a.NoError(err)
+	a.Equal("kv-password", secret.Value)
+	a.Equal(2, vault.logins)
+
+	// The token is refreshed before it expires.
+	client.now = func() time.Time { return time.Now().Add(time.Hour) }
+	_, err = provider.GetSecret(ctx, "secret/bytebase/prod#password")
+	a.NoError(err)
+	a.Equal(3, vault.logins)
+
+	_, err = NewVaultKVProvider(NewVaultClient(VaultConfig{Address: server.URL, RoleID: "role", SecretID: "wrong"})).GetSecret(ctx, "secret/bytebase/prod")
+	a.Error(err)
+}
+
+func TestVaultDatabaseProvider(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	vault := &vaultServer{tokens: map[string]bool{"static": true}}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	client := NewVaultClient(VaultConfig{Address: server.URL, Token: "static"})
+	m := NewManager(map[string]Provider{"vault-database": NewVaultDatabaseProvider(client)})
+	credential, err := m.Resolve(ctx, "{{vault-database:database/readonly}}")
+	a.NoError(err)
+	a.Equal("v-readonly-abc", credential.Username)
+	a.Equal("db-password", credential.Password)
+	a.True(credential.HasLease())
+
+	// The one second lease is renewed in the background.
+	a.Eventually(func() bool {
+		vault.mu.Lock()
+		defer vault.mu.Unlock()
+		return vault.renewals >= 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	credential.Release()
+	credential.Release()
+	vault.mu.Lock()
+	a.Equal([]string{"database/creds/readonly/abc"}, vault.revoked)
+	vault.mu.Unlock()
+
+	_, err = m.Resolve(ctx, "{{vault-database:database}}")
+	a.Error(err)
+}
+
+func TestAWSSecretsManagerProvider(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" ||
+			!strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/us-east-1/secretsmanager/aws4_request") {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"__type": "InvalidSignatureException", "message": "bad request"})
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch body["SecretId"] {
+		case "prod/json":
+			_ = json.NewEncoder(w).Encode(map[string]string{"SecretString": `{"username": "admin", "password": "aws-password"}`})
+		case "prod/plain":
+			_ = json.NewEncoder(w).Encode(map[string]string{"SecretString": "plain-password"})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"__type": "ResourceNotFoundException", "message": "Secrets Manager can't find the specified secret."})
+		}
+	}))
+	defer server.Close()
+
+	provider := NewAWSSecretsManagerProviderWithConfig(server.URL, aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	})
+	secret, err := provider.GetSecret(ctx, "prod/json#password")
+	a.NoError(err)
+	a.Equal("aws-password", secret.Value)
+	secret, err = provider.GetSecret(ctx, "prod/plain")
+	a.NoError(err)
+	a.Equal("plain-password", secret.Value)
+	_, err = provider.GetSecret(ctx, "prod/plain#password")
+	a.Error(err)
+	_, err = provider.GetSecret(ctx, "prod/missing")
+	a.ErrorContains(err, "ResourceNotFoundException")
+}
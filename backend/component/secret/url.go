@@ -0,0 +1,59 @@
+package secret
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const httpTimeout = 30 * time.Second
+
+// URLProvider gets the secret from the URL, whose response is in the GCP Secret Manager format.
+// See https://cloud.google.com/secret-manager/docs/reference/rest/v1/projects.secrets.versions/access.
+type URLProvider struct {
+	client *http.Client
+}
+
+// NewURLProvider creates a URL secret provider.
+func NewURLProvider() *URLProvider {
+	return &URLProvider{client: &http.Client{Timeout: httpTimeout}}
+}
+
+type payload struct {
+	Data string `json:"data"`
+}
+
+type accessResponse struct {
+	Payload payload `json:"payload"`
+}
+
+// GetSecret gets the secret from the URL.
+func (p *URLProvider) GetSecret(ctx context.Context, secretURL string) (*Secret, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, secretURL, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid secret URL %q", secretURL)
+	}
+	response, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get secret from %q", secretURL)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to get secret from %q status %v", secretURL, response.StatusCode)
+	}
+
+	var r accessResponse
+	if err := json.NewDecoder(response.Body).Decode(&r); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode JSON response")
+	}
+	secret, err := base64.StdEncoding.DecodeString(r.Payload.Data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode the base64 payload data from %q", secretURL)
+	}
+	return &Secret{Value: string(secret)}, nil
+}
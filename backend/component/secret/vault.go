@@ -0,0 +1,288 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// vaultTokenRenewWindow is how long before the AppRole token expires that we log in again.
+	vaultTokenRenewWindow = 30 * time.Second
+	defaultAppRoleMount   = "approle"
+)
+
+// VaultConfig is the config of the HashiCorp Vault client.
+type VaultConfig struct {
+	// Address is the Vault server address, e.g. https://vault.example.com:8200.
+	Address   string
+	Namespace string
+	// Token is used if set, otherwise the client logs in with the AppRole.
+	Token string
+	// AppRoleMount is the mount path of the AppRole auth method, "approle" by default.
+	AppRoleMount string
+	RoleID       string
+	SecretID     string
+}
+
+// VaultClient is the minimal HashiCorp Vault HTTP API client.
+type VaultClient struct {
+	config VaultConfig
+	client *http.Client
+	now    func() time.Time
+
+	mu sync.Mutex
+	// token is the static token or the one logged in with the AppRole.
+	token           string
+	tokenExpireTime time.Time
+}
+
+// NewVaultClient creates a Vault client.
+func NewVaultClient(config VaultConfig) *VaultClient {
+	if config.AppRoleMount == "" {
+		config.AppRoleMount = defaultAppRoleMount
+	}
+	config.Address = strings.TrimSuffix(config.Address, "/")
+	return &VaultClient{
+		config: config,
+		client: &http.Client{Timeout: httpTimeout},
+		now:    time.Now,
+		token:  config.Token,
+	}
+}
+
+// NewVaultClientFromEnv creates a Vault client with the standard Vault environment variables.
+// It returns false if VAULT_ADDR is not set.
+func NewVaultClientFromEnv() (*VaultClient, bool) {
+	address := os.Getenv("VAULT_ADDR")
+	if address == "" {
+		return nil, false
+	}
+	return NewVaultClient(VaultConfig{
+		Address:      address,
+		Namespace:    os.Getenv("VAULT_NAMESPACE"),
+		Token:        os.Getenv("VAULT_TOKEN"),
+		AppRoleMount: os.Getenv("VAULT_APPROLE_MOUNT"),
+		RoleID:       os.Getenv("VAULT_ROLE_ID"),
+		SecretID:     os.Getenv("VAULT_SECRET_ID"),
+	}), true
+}
+
+type vaultResponse struct {
+	LeaseID       string          `json:"lease_id"`
+	LeaseDuration int64           `json:"lease_duration"`
+	Renewable     bool            `json:"renewable"`
+	Data          json.RawMessage `json:"data"`
+	Auth          *struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int64  `json:"lease_duration"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+// do sends the request to Vault with the token. It logs in again and retries once if the token is rejected.
+func (c *VaultClient) do(ctx context.Context, method, path string, body any) (*vaultResponse, error) {
+	token, err := c.getToken(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	resp, status, err := c.send(ctx, method, path, token, body)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusForbidden && c.usesAppRole() {
+		if token, err = c.getToken(ctx, true); err != nil {
+			return nil, err
+		}
+		if resp, status, err = c.send(ctx, method, path, token, body); err != nil {
+			return nil, err
+		}
+	}
+	if status < 200 || status >= 300 {
+		return nil, errors.Errorf("vault %s %s returned status %d: %s", method, path, status, strings.Join(resp.Errors, "; "))
+	}
+	return resp, nil
+}
+
+func (c *VaultClient) send(ctx context.Context, method, path, token string, body any) (*vaultResponse, int, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to marshal vault request")
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/v1/%s", c.config.Address, strings.TrimPrefix(path, "/")), reader)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to create vault request")
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if c.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.config.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	response, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to request vault %s %s", method, path)
+	}
+	defer response.Body.Close()
+
+	resp := &vaultResponse{}
+	if response.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(response.Body).Decode(resp); err != nil && err != io.EOF {
+			return nil, 0, errors.Wrapf(err, "failed to decode vault response of %s %s", method, path)
+		}
+	}
+	return resp, response.StatusCode, nil
+}
+
+func (c *VaultClient) usesAppRole() bool {
+	return c.config.Token == "" && c.config.RoleID != ""
+}
+
+// getToken returns the token, logging in with the AppRole if there is no token or it's about to expire.
+func (c *VaultClient) getToken(ctx context.Context, forceLogin bool) (string, error) {
+	if !c.usesAppRole() {
+		if c.config.Token == "" {
+			return "", errors.New("vault token or AppRole is not configured")
+		}
+		return c.config.Token, nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !forceLogin && c.token != "" && (c.tokenExpireTime.IsZero() || c.now().Add(vaultTokenRenewWindow).Before(c.tokenExpireTime)) {
+		return c.token, nil
+	}
+	resp, status, err := c.send(ctx, http.MethodPost, fmt.Sprintf("auth/%s/login", c.config.AppRoleMount), "", map[string]string{
+		"role_id":   c.config.RoleID,
+		"secret_id": c.config.SecretID,
+	})
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK || resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return "", errors.Errorf("failed to log in vault with AppRole, status %d: %s", status, strings.Join(resp.Errors, "; "))
+	}
+	c.token = resp.Auth.ClientToken
+	c.tokenExpireTime = time.Time{}
+	if resp.Auth.LeaseDuration > 0 {
+		c.tokenExpireTime = c.now().Add(time.Duration(resp.Auth.LeaseDuration) * time.Second)
+	}
+	return c.token, nil
+}
+
+// VaultKVProvider gets the secrets from the Vault KV secrets engine version 2.
+// The reference is <mount>/<path>#<key>, where the key is "password" if omitted.
+type VaultKVProvider struct {
+	client *VaultClient
+}
+
+// NewVaultKVProvider creates a Vault KV v2 secret provider.
+func NewVaultKVProvider(client *VaultClient) *VaultKVProvider {
+	return &VaultKVProvider{client: client}
+}
+
+// GetSecret gets the key of the latest version of the KV secret.
+func (p *VaultKVProvider) GetSecret(ctx context.Context, reference string) (*Secret, error) {
+	path, key, _ := strings.Cut(reference, "#")
+	if key == "" {
+		key = "password"
+	}
+	mount, secretPath, ok := strings.Cut(strings.Trim(path, "/"), "/")
+	if !ok || secretPath == "" {
+		return nil, errors.Errorf("invalid vault secret reference %q, expect <mount>/<path>#<key>", reference)
+	}
+	resp, err := p.client.do(ctx, http.MethodGet, fmt.Sprintf("%s/data/%s", mount, secretPath), nil)
+	if err != nil {
+		return nil, err
+	}
+	var data struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode vault KV secret %q", path)
+	}
+	value, ok := data.Data[key]
+	if !ok {
+		return nil, errors.Errorf("key %q not found in vault secret %q", key, path)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.Errorf("key %q of vault secret %q is not a string", key, path)
+	}
+	return &Secret{Value: s}, nil
+}
+
+// VaultDatabaseProvider leases the dynamic credentials from the Vault database secrets engine.
+// The reference is <mount>/<role>.
+type VaultDatabaseProvider struct {
+	client *VaultClient
+}
+
+// NewVaultDatabaseProvider creates a Vault database secret provider.
+func NewVaultDatabaseProvider(client *VaultClient) *VaultDatabaseProvider {
+	return &VaultDatabaseProvider{client: client}
+}
+
+// GetSecret leases a new database credential of the role.
+func (p *VaultDatabaseProvider) GetSecret(ctx context.Context, reference string) (*Secret, error) {
+	mount, role, ok := strings.Cut(strings.Trim(reference, "/"), "/")
+	if !ok || role == "" {
+		return nil, errors.Errorf("invalid vault database reference %q, expect <mount>/<role>", reference)
+	}
+	resp, err := p.client.do(ctx, http.MethodGet, fmt.Sprintf("%s/creds/%s", mount, role), nil)
+	if err != nil {
+		return nil, err
+	}
+	var data struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode vault database credential of %q", reference)
+	}
+	if data.Username == "" {
+		return nil, errors.Errorf("vault database credential of %q has no username", reference)
+	}
+	return &Secret{
+		Username:      data.Username,
+		Value:         data.Password,
+		LeaseID:       resp.LeaseID,
+		LeaseDuration: time.Duration(resp.LeaseDuration) * time.Second,
+		Renewable:     resp.Renewable,
+	}, nil
+}
+
+// RenewLease renews the lease of the database credential.
+func (p *VaultDatabaseProvider) RenewLease(ctx context.Context, leaseID string, increment time.Duration) (time.Duration, error) {
+	resp, err := p.client.do(ctx, http.MethodPut, "sys/leases/renew", map[string]any{
+		"lease_id":  leaseID,
+		"increment": int64(increment / time.Second),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(resp.LeaseDuration) * time.Second, nil
+}
+
+// RevokeLease revokes the lease of the database credential, which drops the database user.
+func (p *VaultDatabaseProvider) RevokeLease(ctx context.Context, leaseID string) error {
+	_, err := p.client.do(ctx, http.MethodPut, "sys/leases/revoke", map[string]any{
+		"lease_id": leaseID,
+	})
+	return err
+}
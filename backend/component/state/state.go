@@ -1,11 +1,19 @@
 // Package state contains the synchronization state shared within the server.
+//
+// The state is in memory and local to the replica, and it's not coordinated across the replicas sharing the metadata
+// database. Only the leader runs the runners, so the state of the runners, e.g. RunningTaskRuns and
+// InstanceOutstandingConnections, is only populated on the leader, and it's lost when the leadership changes:
+//   - The task runs executing on the old leader are canceled before it releases the lease, and the new leader marks the
+//     leftover RUNNING task runs as canceled on start.
+//   - GhostTaskState, the gh-ost migrations synced and waiting for the cutover, is aborted on the old leader, and the
+//     sync task needs to rerun before the cutover.
+//
+// The runner queues filled by the API, e.g. RollbackGenerate and ApprovalFinding, are a fast path on the leader,
+// and the runners pick up the work from the database periodically, which is the source of truth across replicas.
 package state
 
 import (
 	"sync"
-	"time"
-
-	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 
 	"github.com/dgraph-io/ristretto"
 	"github.com/pkg/errors"
@@ -36,9 +44,6 @@ type State struct {
 	// GhostTaskState is the map from task ID to gh-ost state.
 	GhostTaskState sync.Map // map[taskID]sharedGhostState
 
-	// TaskRunExecutionStatuses is the map from task run ID to task run execution status.
-	TaskRunExecutionStatuses sync.Map // map[taskRunID]TaskRunExecutionStatus
-
 	// RunningTaskRuns is the set of running taskruns.
 	RunningTaskRuns sync.Map // map[taskRunID]bool
 	// RunningTaskRunsCancelFunc is the cancelFunc of running taskruns.
@@ -62,11 +67,15 @@ type State struct {
 
 	// TaskSkippedOrDoneChan is the channel for notifying the task is skipped or done.
 	TaskSkippedOrDoneChan chan int
+	// TaskCleanupChan is the channel for cleaning up the task which is skipped or whose issue is canceled.
+	TaskCleanupChan chan int
 
 	// PlanCheckTickleChan is the tickler for plan check scheduler.
 	PlanCheckTickleChan chan int
 	// TaskRunTickleChan is the tickler for task run scheduler.
 	TaskRunTickleChan chan int
+	// WebhookDeliveryTickleChan is the tickler for webhook delivery runner.
+	WebhookDeliveryTickleChan chan int
 
 	ExpireCache *ristretto.Cache
 
@@ -88,12 +97,49 @@ func New() (*State, error) {
 		InstanceOutstandingConnections:       make(map[int]int),
 		IssueExternalApprovalRelayCancelChan: make(chan int, 1),
 		TaskSkippedOrDoneChan:                make(chan int, 1000),
+		TaskCleanupChan:                      make(chan int, 1000),
 		PlanCheckTickleChan:                  make(chan int, 1000),
 		TaskRunTickleChan:                    make(chan int, 1000),
+		WebhookDeliveryTickleChan:            make(chan int, 1000),
 		ExpireCache:                          expireCache,
 	}, nil
 }
 
+// ClearRunnerQueues clears the runner queues filled by the API.
+// It's called on the replicas which are not the leader, where the queues are never consumed.
+func (s *State) ClearRunnerQueues() {
+	for _, m := range []*sync.Map{&s.RollbackGenerate, &s.ApprovalFinding} {
+		m.Range(func(key, _ any) bool {
+			m.Delete(key)
+			return true
+		})
+	}
+}
+
+// TicklePlanCheck tickles the plan check scheduler to pick up the new plan check runs.
+func (s *State) TicklePlanCheck() {
+	tickle(s.PlanCheckTickleChan)
+}
+
+// TickleTaskRun tickles the task run scheduler to pick up the new task runs.
+func (s *State) TickleTaskRun() {
+	tickle(s.TaskRunTickleChan)
+}
+
+// TickleWebhookDelivery tickles the webhook delivery runner to pick up the due deliveries.
+func (s *State) TickleWebhookDelivery() {
+	tickle(s.WebhookDeliveryTickleChan)
+}
+
+// tickle tickles the runner without blocking. The runner may run on another replica, i.e. the leader, where
+// the tickle is not received, and it picks up the work from the database on its next tick anyway.
+func tickle(c chan int) {
+	select {
+	case c <- 0:
+	default:
+	}
+}
+
 // InstanceSlowQuerySyncMessage is the message for synchronizing slow query logs for instances.
 type InstanceSlowQuerySyncMessage struct {
 	InstanceID string
@@ -103,8 +149,3 @@ type InstanceSlowQuerySyncMessage struct {
 	// If ProjectID is not empty, then only databases belong to the project will be synced.
 	ProjectID string
 }
-
-type TaskRunExecutionStatus struct {
-	ExecutionStatus v1pb.TaskRun_ExecutionStatus
-	UpdateTime      time.Time
-}
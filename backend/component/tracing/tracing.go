@@ -0,0 +1,65 @@
+// Package tracing sets up the OpenTelemetry tracing exported over OTLP.
+package tracing
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const serviceName = "bytebase"
+
+// Provider exports the spans over OTLP.
+type Provider struct {
+	tracerProvider *sdktrace.TracerProvider
+}
+
+// NewProvider creates a provider exporting the spans to the OTLP gRPC endpoint, and sets it as the global tracer provider.
+// The spans are created with the global tracer provider, and they're not recorded if the provider is not set.
+func NewProvider(ctx context.Context, endpoint string, insecure bool, version string) (*Provider, error) {
+	options := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(endpoint),
+	}
+	if insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	// The exporter connects to the endpoint lazily, so that the server can start before the collector.
+	exporter, err := otlptracegrpc.New(ctx, options...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create OTLP trace exporter for %q", endpoint)
+	}
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(version),
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create tracing resource")
+	}
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		// Respect the sampling decision of the caller, and sample all the root spans.
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return &Provider{tracerProvider: tracerProvider}, nil
+}
+
+// Shutdown flushes the pending spans and stops the exporter.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if err := p.tracerProvider.Shutdown(ctx); err != nil {
+		return errors.Wrap(err, "failed to shutdown tracer provider")
+	}
+	return nil
+}
@@ -71,6 +71,33 @@ const (
 
 	// ActivityDatabaseRecoveryPITRDone is the type for performing PITR on the database successfully.
 	ActivityDatabaseRecoveryPITRDone ActivityType = "bb.database.recovery.pitr.done"
+	// ActivityDatabaseCreate is the type for creating databases, or finding new databases in the instance.
+	ActivityDatabaseCreate ActivityType = "bb.database.create"
+	// ActivityDatabaseDelete is the type for databases no longer found in the instance.
+	ActivityDatabaseDelete ActivityType = "bb.database.delete"
+
+	// Instance related.
+
+	// ActivityInstanceCreate is the type for creating instances.
+	ActivityInstanceCreate ActivityType = "bb.instance.create"
+	// ActivityInstanceDelete is the type for deleting instances.
+	ActivityInstanceDelete ActivityType = "bb.instance.delete"
+	// ActivityInstanceUndelete is the type for undeleting instances.
+	ActivityInstanceUndelete ActivityType = "bb.instance.undelete"
+
+	// Anomaly related.
+
+	// ActivityAnomalyCreate is the type for opening anomalies, e.g. schema drift and backup anomalies.
+	ActivityAnomalyCreate ActivityType = "bb.anomaly.create"
+
+	// Policy related.
+
+	// ActivityPolicyCreate is the type for creating policies.
+	ActivityPolicyCreate ActivityType = "bb.policy.create"
+	// ActivityPolicyUpdate is the type for updating policies.
+	ActivityPolicyUpdate ActivityType = "bb.policy.update"
+	// ActivityPolicyDelete is the type for deleting policies.
+	ActivityPolicyDelete ActivityType = "bb.policy.delete"
 )
 
 // ActivityLevel is the level of activities.
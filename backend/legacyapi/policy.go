@@ -51,6 +51,10 @@ const (
 	PolicyTypeDisableCopyData PolicyType = "bb.policy.disable-copy-data"
 	// PolicyTypeMaskingRule is the masking rule policy type.
 	PolicyTypeMaskingRule PolicyType = "bb.policy.masking-rule"
+	// PolicyTypeMigrationTimeout is the migration timeout policy type.
+	PolicyTypeMigrationTimeout PolicyType = "bb.policy.migration-timeout"
+	// PolicyTypeConcurrency is the task run concurrency policy type.
+	PolicyTypeConcurrency PolicyType = "bb.policy.concurrency"
 
 	// PipelineApprovalValueManualNever means the pipeline will automatically be approved without user intervention.
 	PipelineApprovalValueManualNever PipelineApprovalValue = "MANUAL_APPROVAL_NEVER"
@@ -102,6 +106,8 @@ var (
 		PolicyTypeDisableCopyData:  {PolicyResourceTypeEnvironment},
 		PolicyTypeMaskingRule:      {PolicyResourceTypeWorkspace},
 		PolicyTypeMaskingException: {PolicyResourceTypeProject},
+		PolicyTypeMigrationTimeout: {PolicyResourceTypeEnvironment, PolicyResourceTypeProject},
+		PolicyTypeConcurrency:      {PolicyResourceTypeWorkspace, PolicyResourceTypeEnvironment, PolicyResourceTypeInstance, PolicyResourceTypeProject},
 	}
 )
 
@@ -46,6 +46,9 @@ const (
 	SettingSemanticTypes SettingName = "bb.workspace.semantic-types"
 	// SettingMaskingAlgorithms is the setting name for masking algorithms.
 	SettingMaskingAlgorithm SettingName = "bb.workspace.masking-algorithm"
+	// SettingSCIMToken is the setting name for the SHA-256 hash of the SCIM provisioning token.
+	// The token itself is never stored.
+	SettingSCIMToken SettingName = "bb.workspace.scim-token"
 )
 
 // IMType is the type of IM.
@@ -219,6 +219,18 @@ type TaskDatabaseDataUpdatePayload struct {
 	RollbackFromTaskID int `json:"rollbackFromTaskId,omitempty"`
 
 	SchemaGroupName string `json:"schemaGroupName,omitempty"`
+
+	// BatchConfig is set to execute the statement in batches.
+	BatchConfig *TaskBatchConfig `json:"batchConfig,omitempty"`
+}
+
+// TaskBatchConfig is the config of the batched execution of the data update task.
+type TaskBatchConfig struct {
+	BatchSize int `json:"batchSize,omitempty"`
+	// SleepMs is the pause between batches in milliseconds.
+	SleepMs int64 `json:"sleepMs,omitempty"`
+	// MaxReplicaLagMs pauses the execution while the replica lag exceeds it in milliseconds. Zero disables the check.
+	MaxReplicaLagMs int64 `json:"maxReplicaLagMs,omitempty"`
 }
 
 // TaskDatabaseBackupPayload is the task payload for database backup.
@@ -0,0 +1,119 @@
+package metric
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// The Prometheus metrics are registered to the default registry, and served at /metrics.
+const prometheusNamespace = "bytebase"
+
+// The kinds of the SQL Editor query volume.
+const (
+	// SQLQueryKindQuery is the query in SQL Editor.
+	SQLQueryKindQuery = "query"
+	// SQLQueryKindExport is the export in SQL Editor.
+	SQLQueryKindExport = "export"
+)
+
+var (
+	taskRunDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: prometheusNamespace,
+		Name:      "task_run_duration_seconds",
+		Help:      "Duration of the task run executions by engine, task type and outcome status.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 4, 10),
+	}, []string{"engine", "type", "status"})
+
+	planCheckDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: prometheusNamespace,
+		Name:      "plan_check_duration_seconds",
+		Help:      "Latency of the plan check runs by check type and outcome status.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+	}, []string{"type", "status"})
+
+	schemaSyncLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: prometheusNamespace,
+		Name:      "schema_sync_lag_seconds",
+		Help:      "Time since the last successful schema sync of the instance.",
+	}, []string{"instance", "engine"})
+
+	connectionFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: prometheusNamespace,
+		Name:      "connection_failures_total",
+		Help:      "Number of the failures to connect to the database instances.",
+	}, []string{"instance", "engine"})
+
+	approvalWaitDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: prometheusNamespace,
+		Name:      "approval_wait_duration_seconds",
+		Help:      "Time from the issue creation to the issue being fully approved.",
+		// From 1 minute to about half a year.
+		Buckets: prometheus.ExponentialBuckets(60, 4, 10),
+	}, []string{"type"})
+
+	sqlQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: prometheusNamespace,
+		Name:      "sql_queries_total",
+		Help:      "Number of the queries and exports in SQL Editor.",
+	}, []string{"engine", "kind"})
+
+	sqlQueryRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: prometheusNamespace,
+		Name:      "sql_query_rows_total",
+		Help:      "Number of the rows returned by the queries and exports in SQL Editor.",
+	}, []string{"engine", "kind"})
+
+	sqlExportBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: prometheusNamespace,
+		Name:      "sql_export_bytes_total",
+		Help:      "Number of the bytes exported in SQL Editor by format.",
+	}, []string{"engine", "format"})
+)
+
+// ObserveTaskRun records the duration and the outcome status of a finished task run.
+func ObserveTaskRun(engine, taskType, status string, duration time.Duration) {
+	taskRunDuration.WithLabelValues(engine, taskType, status).Observe(duration.Seconds())
+}
+
+// ObservePlanCheck records the latency and the outcome status of a plan check run.
+func ObservePlanCheck(checkType, status string, duration time.Duration) {
+	planCheckDuration.WithLabelValues(checkType, status).Observe(duration.Seconds())
+}
+
+// SetSchemaSyncLag sets the time since the last successful schema sync of the instance.
+func SetSchemaSyncLag(instance, engine string, lag time.Duration) {
+	schemaSyncLag.WithLabelValues(instance, engine).Set(lag.Seconds())
+}
+
+// ResetSchemaSyncLag deletes the schema sync lags of all the instances.
+func ResetSchemaSyncLag() {
+	schemaSyncLag.Reset()
+}
+
+// IncConnectionFailure counts a failure to connect to the instance.
+func IncConnectionFailure(instance, engine string) {
+	connectionFailures.WithLabelValues(instance, engine).Inc()
+}
+
+// ObserveApprovalWait records the time from the issue creation to the issue being fully approved.
+func ObserveApprovalWait(issueType string, duration time.Duration) {
+	approvalWaitDuration.WithLabelValues(issueType).Observe(duration.Seconds())
+}
+
+// AddSQLQuery counts a query or an export in SQL Editor and the returned rows.
+func AddSQLQuery(engine, kind string, rows int64) {
+	sqlQueries.WithLabelValues(engine, kind).Inc()
+	AddSQLQueryRows(engine, kind, rows)
+}
+
+// AddSQLQueryRows counts the rows returned by a query in SQL Editor, e.g. the rows of the next page of a paginated query.
+func AddSQLQueryRows(engine, kind string, rows int64) {
+	sqlQueryRows.WithLabelValues(engine, kind).Add(float64(rows))
+}
+
+// AddSQLExportBytes counts the bytes exported in SQL Editor.
+func AddSQLExportBytes(engine, format string, bytes int64) {
+	sqlExportBytes.WithLabelValues(engine, format).Add(float64(bytes))
+}
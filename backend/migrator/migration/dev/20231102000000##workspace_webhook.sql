@@ -0,0 +1,5 @@
+-- Workspace webhooks are the project webhooks without project.
+ALTER TABLE project_webhook ALTER COLUMN project_id DROP NOT NULL;
+
+-- The unique index on (project_id, url) does not cover the workspace webhooks because NULLs are distinct.
+CREATE UNIQUE INDEX idx_project_webhook_unique_workspace_url ON project_webhook(url) WHERE project_id IS NULL;
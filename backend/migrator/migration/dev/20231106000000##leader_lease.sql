@@ -0,0 +1,5 @@
+CREATE TABLE leader_lease (
+    name TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    expire_ts BIGINT NOT NULL
+);
@@ -0,0 +1,6 @@
+CREATE TABLE saml_consumed_id (
+    id TEXT PRIMARY KEY,
+    expire_ts BIGINT NOT NULL
+);
+
+CREATE INDEX idx_saml_consumed_id_expire_ts ON saml_consumed_id(expire_ts);
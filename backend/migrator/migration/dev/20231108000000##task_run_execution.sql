@@ -0,0 +1,6 @@
+CREATE TABLE task_run_execution (
+    task_run_id INTEGER PRIMARY KEY REFERENCES task_run (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    execution_status TEXT NOT NULL,
+    execution_detail JSONB NOT NULL DEFAULT '{}'
+);
@@ -19,7 +19,7 @@ CREATE TABLE idp (
   resource_id TEXT NOT NULL,
   name TEXT NOT NULL,
   domain TEXT NOT NULL,
-  type TEXT NOT NULL CONSTRAINT idp_type_check CHECK (type IN ('OAUTH2', 'OIDC', 'LDAP')),
+  type TEXT NOT NULL CONSTRAINT idp_type_check CHECK (type IN ('OAUTH2', 'OIDC', 'LDAP', 'SAML')),
   -- config stores the corresponding configuration of the IdP, which may vary depending on the type of the IdP.
   config JSONB NOT NULL DEFAULT '{}'
 );
@@ -300,17 +300,21 @@ CREATE TABLE project_webhook (
     created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
     updater_id INTEGER NOT NULL REFERENCES principal (id),
     updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
-    project_id INTEGER NOT NULL REFERENCES project (id),
+    -- project_id is NULL for the workspace webhooks, which receive the events of the whole workspace.
+    project_id INTEGER REFERENCES project (id),
     type TEXT NOT NULL CHECK (type LIKE 'bb.plugin.webhook.%'),
     name TEXT NOT NULL,
     url TEXT NOT NULL,
-    activity_list TEXT ARRAY NOT NULL
+    activity_list TEXT ARRAY NOT NULL,
+    signing_secret TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX idx_project_webhook_project_id ON project_webhook(project_id);
 
 CREATE UNIQUE INDEX idx_project_webhook_unique_project_id_url ON project_webhook(project_id, url);
 
+CREATE UNIQUE INDEX idx_project_webhook_unique_workspace_url ON project_webhook(url) WHERE project_id IS NULL;
+
 ALTER SEQUENCE project_webhook_id_seq RESTART WITH 101;
 
 CREATE TRIGGER update_project_webhook_updated_ts
@@ -319,6 +323,32 @@ UPDATE
     ON project_webhook FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- webhook_delivery is the outbox of the project webhook deliveries.
+CREATE TABLE webhook_delivery (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    webhook_id INTEGER NOT NULL REFERENCES project_webhook (id) ON DELETE CASCADE,
+    status TEXT NOT NULL CHECK (status IN ('PENDING', 'DELIVERED', 'DEAD')),
+    payload JSONB NOT NULL DEFAULT '{}',
+    attempt INTEGER NOT NULL DEFAULT 0,
+    next_attempt_ts BIGINT NOT NULL DEFAULT 0,
+    response_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_webhook_delivery_webhook_id ON webhook_delivery(webhook_id);
+
+CREATE INDEX idx_webhook_delivery_status_next_attempt_ts ON webhook_delivery(status, next_attempt_ts);
+
+ALTER SEQUENCE webhook_delivery_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_webhook_delivery_updated_ts
+BEFORE
+UPDATE
+    ON webhook_delivery FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
 -- Instance
 CREATE TABLE instance (
     id SERIAL PRIMARY KEY,
@@ -660,6 +690,27 @@ UPDATE
     ON task_run FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- task_run_log records the events of a task run, e.g. each attempt to execute the migration.
+CREATE TABLE task_run_log (
+    id BIGSERIAL PRIMARY KEY,
+    task_run_id INTEGER NOT NULL REFERENCES task_run (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_task_run_log_task_run_id ON task_run_log(task_run_id);
+
+ALTER SEQUENCE task_run_log_id_seq RESTART WITH 101;
+
+-- task_run_execution is the execution status of the running task run, e.g. the progress of the online schema migration,
+-- which is recorded by the leader executing the task run and read by any replica.
+CREATE TABLE task_run_execution (
+    task_run_id INTEGER PRIMARY KEY REFERENCES task_run (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    execution_status TEXT NOT NULL,
+    execution_detail JSONB NOT NULL DEFAULT '{}'
+);
+
 -- Pipeline related END
 -----------------------
 -- Plan related BEGIN
@@ -1232,4 +1283,44 @@ CREATE TRIGGER update_changelist_updated_ts
 BEFORE
 UPDATE
     ON changelist FOR EACH ROW
-EXECUTE FUNCTION trigger_update_updated_ts();
\ No newline at end of file
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- scim_group is the group provisioned by the identity provider through SCIM.
+-- A group named "projects/{project}/roles/{role}" grants the role of the project to its members.
+CREATE TABLE scim_group (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    display_name TEXT NOT NULL,
+    external_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX idx_scim_group_unique_display_name ON scim_group(display_name);
+
+ALTER SEQUENCE scim_group_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_scim_group_updated_ts
+BEFORE
+UPDATE
+    ON scim_group FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+CREATE TABLE scim_group_member (
+    group_id INTEGER NOT NULL REFERENCES scim_group (id) ON DELETE CASCADE,
+    principal_id INTEGER NOT NULL REFERENCES principal (id),
+    PRIMARY KEY (group_id, principal_id)
+);
+-- leader_lease is the lease held by the leader among the replicas sharing the metadata database.
+CREATE TABLE leader_lease (
+    name TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    expire_ts BIGINT NOT NULL
+);
+
+-- saml_consumed_id is the SAML request IDs and login codes consumed by any replica, which are rejected if replayed before they expire.
+CREATE TABLE saml_consumed_id (
+    id TEXT PRIMARY KEY,
+    expire_ts BIGINT NOT NULL
+);
+
+CREATE INDEX idx_saml_consumed_id_expire_ts ON saml_consumed_id(expire_ts);
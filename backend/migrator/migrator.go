@@ -18,6 +18,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	dbdriver "github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/store"
@@ -55,6 +56,13 @@ func MigrateSchema(ctx context.Context, storeDB *store.DB, strictUseDb bool, pgB
 	}
 	defer metadataDriver.Close(ctx)
 
+	// Serialize the migrations of the replicas starting at the same time against the metadata database.
+	unlock, err := lockMigration(ctx, metadataDriver.GetDB())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	storeInstance := store.New(storeDB)
 	// Calculate prod cutoffSchemaVersion.
 	cutoffSchemaVersion, err := getProdCutoffVersion()
@@ -577,3 +585,26 @@ func getMinorVersions(names []string) ([]semver.Version, error) {
 	})
 	return versions, nil
 }
+
+// migrationLockID is the key of the PostgreSQL advisory lock serializing the migrations.
+const migrationLockID = 6_000_000_001
+
+// lockMigration acquires the session-level advisory lock for the migration on a dedicated connection,
+// which is released when the returned function is called or the connection is closed.
+func lockMigration(ctx context.Context, db *sql.DB) (func(), error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get connection for the migration lock")
+	}
+	slog.Info("Acquiring the migration lock...")
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to acquire the migration lock")
+	}
+	return func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID); err != nil {
+			slog.Warn("failed to release the migration lock", log.BBError(err))
+		}
+		conn.Close()
+	}, nil
+}
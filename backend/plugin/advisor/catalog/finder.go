@@ -61,3 +61,10 @@ func NewEmptyFinder(ctx *FinderContext) *Finder {
 func (f *Finder) WalkThrough(statements string) error {
 	return f.Final.WalkThrough(statements)
 }
+
+// WalkThroughWithCurrentSchema does the walk through, and resolves the unqualified object names in the current schema.
+// It's used for the engines whose default schema depends on the session, such as Oracle.
+func (f *Finder) WalkThroughWithCurrentSchema(statements string, currentSchema string) error {
+	f.Final.currentSchema = currentSchema
+	return f.Final.WalkThrough(statements)
+}
@@ -156,6 +156,9 @@ type DatabaseState struct {
 	schemaSet    schemaStateMap
 	deleted      bool
 	usable       bool
+
+	// currentSchema is the schema of the unqualified object names, only used for Oracle.
+	currentSchema string
 }
 
 type TableIndexFind struct {
@@ -222,6 +222,24 @@ func (d *DatabaseState) WalkThrough(stmt string) error {
 			d.usable = false
 		}
 		return nil
+	case storepb.Engine_ORACLE, storepb.Engine_MSSQL, storepb.Engine_SNOWFLAKE:
+		var err error
+		switch d.dbType {
+		case storepb.Engine_ORACLE:
+			err = d.oracleWalkThrough(stmt)
+		case storepb.Engine_MSSQL:
+			err = d.mssqlWalkThrough(stmt)
+		default:
+			err = d.snowflakeWalkThrough(stmt)
+		}
+		if err != nil {
+			if d.ctx.CheckIntegrity {
+				return err
+			}
+			// Same as PostgreSQL, we use `usable` to mark the state is not reliable if walk-through fails without the synced metadata.
+			d.usable = false
+		}
+		return nil
 	default:
 		return &WalkThroughError{
 			Type:    ErrorTypeUnsupported,
@@ -0,0 +1,272 @@
+package catalog
+
+// This file defines the state changes shared by the walk-through based on ANTLR parse trees, such as Oracle, MSSQL and Snowflake.
+// The callers normalize the identifiers in their own ways before calling these functions.
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+)
+
+// antlrGetSchema returns the schema state, the empty schema name means the default schema.
+// The default schema is created if it doesn't exist, because the synced metadata may not contain the empty schema.
+func (d *DatabaseState) antlrGetSchema(schemaName string, defaultSchemaName string) (*SchemaState, *WalkThroughError) {
+	if schemaName == "" {
+		schemaName = defaultSchemaName
+	}
+	for name, schema := range d.schemaSet {
+		if compareIdentifier(name, schemaName, d.ctx.IgnoreCaseSensitive) {
+			return schema, nil
+		}
+	}
+	if d.ctx.CheckIntegrity && !compareIdentifier(schemaName, defaultSchemaName, d.ctx.IgnoreCaseSensitive) {
+		return nil, &WalkThroughError{
+			Type:    ErrorTypeSchemaNotExists,
+			Content: fmt.Sprintf("The schema %q doesn't exist", schemaName),
+		}
+	}
+	return d.createSchema(schemaName), nil
+}
+
+// antlrCreateSchema creates the schema.
+func (d *DatabaseState) antlrCreateSchema(schemaName string, ifNotExists bool) *WalkThroughError {
+	for name := range d.schemaSet {
+		if compareIdentifier(name, schemaName, d.ctx.IgnoreCaseSensitive) {
+			if ifNotExists {
+				return nil
+			}
+			return &WalkThroughError{
+				Type:    ErrorTypeSchemaExists,
+				Content: fmt.Sprintf("The schema %q already exists", schemaName),
+			}
+		}
+	}
+	d.createSchema(schemaName)
+	return nil
+}
+
+// antlrDropSchema drops the schema.
+func (d *DatabaseState) antlrDropSchema(schemaName string, ifExists bool) *WalkThroughError {
+	for name := range d.schemaSet {
+		if compareIdentifier(name, schemaName, d.ctx.IgnoreCaseSensitive) {
+			delete(d.schemaSet, name)
+			return nil
+		}
+	}
+	if ifExists || !d.ctx.CheckIntegrity {
+		return nil
+	}
+	return &WalkThroughError{
+		Type:    ErrorTypeSchemaNotExists,
+		Content: fmt.Sprintf("The schema %q doesn't exist", schemaName),
+	}
+}
+
+// antlrGetTable returns the table state, the incomplete table is created if CheckIntegrity is false.
+func (s *SchemaState) antlrGetTable(tableName string) (*TableState, *WalkThroughError) {
+	table, exists := s.getTable(tableName)
+	if !exists {
+		if s.ctx.CheckIntegrity {
+			return nil, NewTableNotExistsError(tableName)
+		}
+		table = s.createIncompleteTable(tableName)
+	}
+	return table, nil
+}
+
+// antlrCreateTable creates an empty table, the caller should create the columns and constraints later.
+func (s *SchemaState) antlrCreateTable(tableName string) (*TableState, *WalkThroughError) {
+	if _, exists := s.getTable(tableName); exists {
+		return nil, NewTableExistsError(tableName)
+	}
+	table := &TableState{
+		name:          tableName,
+		engine:        newEmptyStringPointer(),
+		collation:     newEmptyStringPointer(),
+		comment:       newEmptyStringPointer(),
+		columnSet:     make(columnStateMap),
+		indexSet:      make(IndexStateMap),
+		dependentView: make(map[string]bool),
+	}
+	s.tableSet[tableName] = table
+	return table, nil
+}
+
+// antlrDropTable drops the table.
+func (s *SchemaState) antlrDropTable(tableName string, ifExists bool) *WalkThroughError {
+	table, exists := s.getTable(tableName)
+	if !exists {
+		if ifExists || !s.ctx.CheckIntegrity {
+			return nil
+		}
+		return NewTableNotExistsError(tableName)
+	}
+	delete(s.tableSet, table.name)
+	return nil
+}
+
+// antlrRenameTable renames the table, the new name is unqualified.
+func (s *SchemaState) antlrRenameTable(oldName string, newName string) *WalkThroughError {
+	table, err := s.antlrGetTable(oldName)
+	if err != nil {
+		return err
+	}
+	return s.renameTable(s.ctx, table.name, newName)
+}
+
+// antlrGetColumn returns the column state, the incomplete column is created if CheckIntegrity is false.
+func (t *TableState) antlrGetColumn(ctx *FinderContext, columnName string) (*ColumnState, *WalkThroughError) {
+	for name, column := range t.columnSet {
+		if compareIdentifier(name, columnName, ctx.IgnoreCaseSensitive) {
+			return column, nil
+		}
+	}
+	if ctx.CheckIntegrity {
+		return nil, NewColumnNotExistsError(t.name, columnName)
+	}
+	return t.createIncompleteColumn(columnName), nil
+}
+
+// antlrCreateColumn appends the column to the table.
+func (t *TableState) antlrCreateColumn(ctx *FinderContext, column *ColumnState) *WalkThroughError {
+	for name := range t.columnSet {
+		if compareIdentifier(name, column.name, ctx.IgnoreCaseSensitive) {
+			return &WalkThroughError{
+				Type:    ErrorTypeColumnExists,
+				Content: fmt.Sprintf("Column `%s` already exists in table `%s`", column.name, t.name),
+			}
+		}
+	}
+	column.position = newIntPointer(len(t.columnSet) + 1)
+	if column.dependentView == nil {
+		column.dependentView = make(map[string]bool)
+	}
+	t.columnSet[column.name] = column
+	return nil
+}
+
+// antlrDropColumn drops the column, and removes it from the index keys.
+func (t *TableState) antlrDropColumn(ctx *FinderContext, columnName string) *WalkThroughError {
+	column, err := t.antlrGetColumn(ctx, columnName)
+	if err != nil {
+		return err
+	}
+	return t.dropColumn(ctx, column.name)
+}
+
+// antlrRenameColumn renames the column, and renames it in the index keys.
+func (t *TableState) antlrRenameColumn(ctx *FinderContext, oldName string, newName string) *WalkThroughError {
+	column, err := t.antlrGetColumn(ctx, oldName)
+	if err != nil {
+		return err
+	}
+	return t.renameColumn(ctx, column.name, newName)
+}
+
+// antlrCheckIndexKeys checks that the column keys exist in the table, and returns the column names in the table.
+func (t *TableState) antlrCheckIndexKeys(ctx *FinderContext, indexName string, keyList []string) ([]string, *WalkThroughError) {
+	if len(keyList) == 0 {
+		return nil, &WalkThroughError{
+			Type:    ErrorTypeIndexEmptyKeys,
+			Content: fmt.Sprintf("Index `%s` in table `%s` has empty key", indexName, t.name),
+		}
+	}
+	var result []string
+	for _, key := range keyList {
+		column, err := t.antlrGetColumn(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, column.name)
+	}
+	return result, nil
+}
+
+// antlrCreateIndex creates the index, or the constraint which is backed by an index, such as PRIMARY KEY and UNIQUE.
+func (t *TableState) antlrCreateIndex(index *IndexState) *WalkThroughError {
+	if index.Primary() {
+		for _, existing := range t.indexSet {
+			if existing.Primary() {
+				return &WalkThroughError{
+					Type:    ErrorTypePrimaryKeyExists,
+					Content: fmt.Sprintf("Primary key exists in table `%s`", t.name),
+				}
+			}
+		}
+	}
+	if _, exists := t.indexSet[index.name]; exists {
+		return NewIndexExistsError(t.name, index.name)
+	}
+	if index.visible == nil {
+		index.visible = newTruePointer()
+	}
+	if index.comment == nil {
+		index.comment = newEmptyStringPointer()
+	}
+	t.indexSet[index.name] = index
+	return nil
+}
+
+// antlrDropPrimaryKey drops the primary key of the table.
+func (t *TableState) antlrDropPrimaryKey(ctx *FinderContext) *WalkThroughError {
+	for name, index := range t.indexSet {
+		if index.Primary() {
+			delete(t.indexSet, name)
+			return nil
+		}
+	}
+	if ctx.CheckIntegrity {
+		return &WalkThroughError{
+			Type:    ErrorTypePrimaryKeyNotExists,
+			Content: fmt.Sprintf("Primary key does not exist in table `%s`", t.name),
+		}
+	}
+	return nil
+}
+
+// antlrDropConstraint drops the constraint by name.
+// We only record the PRIMARY KEY and UNIQUE constraints, so the other constraints are ignored silently.
+func (t *TableState) antlrDropConstraint(ctx *FinderContext, constraintName string) {
+	for name := range t.indexSet {
+		if compareIdentifier(name, constraintName, ctx.IgnoreCaseSensitive) {
+			delete(t.indexSet, name)
+			return
+		}
+	}
+}
+
+// antlrGenerateIndexName generates the name for the anonymous constraint, such as PK_TBL, PK_TBL_2.
+func (t *TableState) antlrGenerateIndexName(prefix string) string {
+	name := fmt.Sprintf("%s_%s", prefix, t.name)
+	for suffix := 2; ; suffix++ {
+		if _, exists := t.indexSet[name]; !exists {
+			return name
+		}
+		name = fmt.Sprintf("%s_%s_%d", prefix, t.name, suffix)
+	}
+}
+
+// antlrRuleContext is the rule context generated by ANTLR, which can access its parser.
+type antlrRuleContext interface {
+	antlr.ParserRuleContext
+	GetParser() antlr.Parser
+}
+
+// antlrGetText returns the original text of the rule context.
+func antlrGetText(ctx antlrRuleContext) string {
+	return ctx.GetParser().GetTokenStream().GetTextFromRuleContext(ctx)
+}
+
+// equalKeyList returns true if the two key lists are the same in order.
+func equalKeyList(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,473 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+)
+
+const (
+	mssqlDefaultSchemaName = "dbo"
+)
+
+func (d *DatabaseState) mssqlWalkThrough(stmt string) error {
+	result, err := tsqlparser.ParseTSQL(stmt)
+	if err != nil {
+		return NewParseError(err.Error())
+	}
+
+	listener := &mssqlWalkThroughListener{
+		databaseState: d,
+	}
+	antlr.ParseTreeWalkerDefault.Walk(listener, result.Tree)
+	if listener.err != nil {
+		return listener.err
+	}
+
+	return nil
+}
+
+// mssqlWalkThroughListener changes the database state by the DDL statements.
+type mssqlWalkThroughListener struct {
+	*parser.BaseTSqlParserListener
+
+	databaseState *DatabaseState
+	err           *WalkThroughError
+}
+
+func (l *mssqlWalkThroughListener) setError(ctx antlr.ParserRuleContext, err *WalkThroughError) {
+	if err == nil {
+		return
+	}
+	if err.Line == 0 {
+		err.Line = ctx.GetStart().GetLine()
+	}
+	l.err = err
+}
+
+// shouldChangeState returns true if no error occurs before.
+func (l *mssqlWalkThroughListener) shouldChangeState(ctx antlr.ParserRuleContext) bool {
+	if l.err != nil {
+		return false
+	}
+	if l.databaseState.deleted {
+		l.setError(ctx, &WalkThroughError{
+			Type:    ErrorTypeDatabaseIsDeleted,
+			Content: fmt.Sprintf(`Database %q is deleted`, l.databaseState.name),
+		})
+		return false
+	}
+	return true
+}
+
+// EnterCreate_schema is called when production create_schema is entered.
+func (l *mssqlWalkThroughListener) EnterCreate_schema(ctx *parser.Create_schemaContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	schemaName := mssqlNormalizeIdentifier(ctx.GetSchema_name())
+	if schemaName == "" {
+		// CREATE SCHEMA AUTHORIZATION owner creates the schema with the same name as the owner.
+		schemaName = mssqlNormalizeIdentifier(ctx.GetOwner_name())
+	}
+	l.setError(ctx, l.databaseState.antlrCreateSchema(schemaName, false /* ifNotExists */))
+}
+
+// EnterDrop_schema is called when production drop_schema is entered.
+func (l *mssqlWalkThroughListener) EnterDrop_schema(ctx *parser.Drop_schemaContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	l.setError(ctx, l.databaseState.antlrDropSchema(mssqlNormalizeIdentifier(ctx.GetSchema_name()), ctx.EXISTS() != nil))
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *mssqlWalkThroughListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	l.setError(ctx, l.databaseState.mssqlCreateTable(ctx))
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *mssqlWalkThroughListener) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	l.setError(ctx, l.databaseState.mssqlAlterTable(ctx))
+}
+
+// EnterDrop_table is called when production drop_table is entered.
+func (l *mssqlWalkThroughListener) EnterDrop_table(ctx *parser.Drop_tableContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	for _, tableName := range ctx.AllTable_name() {
+		schema, err := l.databaseState.mssqlGetSchema(tableName)
+		if err != nil {
+			if ctx.EXISTS() != nil {
+				continue
+			}
+			l.setError(ctx, err)
+			return
+		}
+		if err := schema.antlrDropTable(mssqlNormalizeIdentifier(tableName.GetTable()), ctx.EXISTS() != nil); err != nil {
+			l.setError(ctx, err)
+			return
+		}
+	}
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *mssqlWalkThroughListener) EnterCreate_index(ctx *parser.Create_indexContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	l.setError(ctx, l.databaseState.mssqlCreateIndex(ctx))
+}
+
+// EnterDrop_index is called when production drop_index is entered.
+func (l *mssqlWalkThroughListener) EnterDrop_index(ctx *parser.Drop_indexContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	ifExists := ctx.EXISTS() != nil
+	for _, index := range ctx.AllDrop_relational_or_xml_or_spatial_index() {
+		tableName := index.Full_table_name()
+		err := l.databaseState.mssqlDropIndex(
+			mssqlNormalizeIdentifier(tableName.GetSchema()),
+			mssqlNormalizeIdentifier(tableName.GetTable()),
+			mssqlNormalizeIdentifier(index.GetIndex_name()),
+			ifExists,
+		)
+		if err != nil {
+			l.setError(ctx, err)
+			return
+		}
+	}
+	for _, index := range ctx.AllDrop_backward_compatible_index() {
+		err := l.databaseState.mssqlDropIndex(
+			mssqlNormalizeIdentifier(index.GetOwner_name()),
+			mssqlNormalizeIdentifier(index.GetTable_or_view_name()),
+			mssqlNormalizeIdentifier(index.GetIndex_name()),
+			ifExists,
+		)
+		if err != nil {
+			l.setError(ctx, err)
+			return
+		}
+	}
+}
+
+func (d *DatabaseState) mssqlGetSchema(tableName parser.ITable_nameContext) (*SchemaState, *WalkThroughError) {
+	if database := mssqlNormalizeIdentifier(tableName.GetDatabase()); database != "" && !d.isCurrentDatabase(database) {
+		return nil, NewAccessOtherDatabaseError(d.name, database)
+	}
+	return d.antlrGetSchema(mssqlNormalizeIdentifier(tableName.GetSchema()), mssqlDefaultSchemaName)
+}
+
+func (d *DatabaseState) mssqlCreateTable(ctx *parser.Create_tableContext) *WalkThroughError {
+	schema, err := d.mssqlGetSchema(ctx.Table_name())
+	if err != nil {
+		return err
+	}
+	table, err := schema.antlrCreateTable(mssqlNormalizeIdentifier(ctx.Table_name().GetTable()))
+	if err != nil {
+		return err
+	}
+	if err := schema.mssqlAddColumnsAndConstraints(table, ctx.Column_def_table_constraints()); err != nil {
+		return err
+	}
+	for _, index := range ctx.AllTable_indices() {
+		if index.Column_name_list_with_order() == nil {
+			// We don't record the columnstore index without column list.
+			continue
+		}
+		indexName := mssqlNormalizeIdentifier(index.Id_(0))
+		keyList, err := table.antlrCheckIndexKeys(schema.ctx, indexName, mssqlColumnNameListWithOrder(index.Column_name_list_with_order()))
+		if err != nil {
+			return err
+		}
+		if err := table.antlrCreateIndex(&IndexState{
+			name:           indexName,
+			expressionList: keyList,
+			indexType:      newStringPointer(mssqlIndexType(index.Clustered())),
+			unique:         newBoolPointer(index.UNIQUE() != nil),
+			primary:        newFalsePointer(),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mssqlAddColumnsAndConstraints adds the columns first, and then the table constraints which may refer to the columns.
+func (s *SchemaState) mssqlAddColumnsAndConstraints(table *TableState, ctx parser.IColumn_def_table_constraintsContext) *WalkThroughError {
+	if ctx == nil {
+		return nil
+	}
+	for _, item := range ctx.AllColumn_def_table_constraint() {
+		if item.Column_definition() == nil {
+			continue
+		}
+		if err := s.mssqlCreateColumn(table, item.Column_definition()); err != nil {
+			return err
+		}
+	}
+	for _, item := range ctx.AllColumn_def_table_constraint() {
+		constraint := item.Table_constraint()
+		if constraint == nil || (constraint.PRIMARY() == nil && constraint.UNIQUE() == nil) {
+			// We only record the PRIMARY KEY and UNIQUE constraints.
+			continue
+		}
+		if err := s.mssqlCreateIndexConstraint(
+			table,
+			mssqlNormalizeIdentifier(constraint.GetConstraint()),
+			constraint.PRIMARY() != nil,
+			constraint.Clustered(),
+			mssqlColumnNameListWithOrder(constraint.Column_name_list_with_order()),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SchemaState) mssqlCreateColumn(table *TableState, ctx parser.IColumn_definitionContext) *WalkThroughError {
+	column := &ColumnState{
+		name:         mssqlNormalizeIdentifier(ctx.Id_()),
+		nullable:     newTruePointer(),
+		columnType:   newEmptyStringPointer(),
+		characterSet: newEmptyStringPointer(),
+		collation:    newEmptyStringPointer(),
+		comment:      newEmptyStringPointer(),
+	}
+	if ctx.Data_type() != nil {
+		column.columnType = newStringPointer(antlrGetText(ctx.Data_type()))
+	}
+	s.mssqlApplyColumnDefinitionElements(column, ctx.AllColumn_definition_element())
+	if err := table.antlrCreateColumn(s.ctx, column); err != nil {
+		return err
+	}
+	return s.mssqlCreateColumnConstraints(table, column.name, ctx.AllColumn_definition_element())
+}
+
+// mssqlApplyColumnDefinitionElements applies the nullability, default value and collation to the column.
+func (*SchemaState) mssqlApplyColumnDefinitionElements(column *ColumnState, elements []parser.IColumn_definition_elementContext) {
+	for _, element := range elements {
+		switch {
+		case element.DEFAULT() != nil && element.GetConstant_expr() != nil:
+			column.defaultValue = newStringPointer(antlrGetText(element.GetConstant_expr()))
+		case element.COLLATE() != nil:
+			column.collation = newStringPointer(mssqlNormalizeIdentifier(element.GetCollation_name()))
+		case element.Column_constraint() != nil:
+			constraint := element.Column_constraint()
+			if constraint.Null_notnull() != nil {
+				column.nullable = newBoolPointer(constraint.Null_notnull().NOT() == nil)
+			}
+			if constraint.PRIMARY() != nil {
+				column.nullable = newFalsePointer()
+			}
+		}
+	}
+}
+
+func (s *SchemaState) mssqlCreateColumnConstraints(table *TableState, columnName string, elements []parser.IColumn_definition_elementContext) *WalkThroughError {
+	for _, element := range elements {
+		constraint := element.Column_constraint()
+		if constraint == nil || (constraint.PRIMARY() == nil && constraint.UNIQUE() == nil) {
+			continue
+		}
+		if err := s.mssqlCreateIndexConstraint(
+			table,
+			mssqlNormalizeIdentifier(constraint.GetConstraint()),
+			constraint.PRIMARY() != nil,
+			constraint.Clustered(),
+			[]string{columnName},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SchemaState) mssqlCreateIndexConstraint(table *TableState, name string, primary bool, clustered parser.IClusteredContext, keyList []string) *WalkThroughError {
+	if name == "" {
+		// SQL Server generates the name like PK__tbl__3213E83F for the anonymous constraint, we use a readable one instead.
+		prefix := "UQ"
+		if primary {
+			prefix = "PK"
+		}
+		name = table.antlrGenerateIndexName(prefix)
+	} else if s.mssqlConstraintExists(name) {
+		return NewRelationExistsError(name, s.name)
+	}
+	keyList, err := table.antlrCheckIndexKeys(s.ctx, name, keyList)
+	if err != nil {
+		return err
+	}
+	if primary {
+		for _, key := range keyList {
+			table.columnSet[key].nullable = newFalsePointer()
+		}
+	}
+	indexType := mssqlIndexType(clustered)
+	if clustered == nil && primary {
+		// The PRIMARY KEY is clustered by default.
+		indexType = "CLUSTERED"
+	}
+	return table.antlrCreateIndex(&IndexState{
+		name:           name,
+		expressionList: keyList,
+		indexType:      newStringPointer(indexType),
+		unique:         newTruePointer(),
+		primary:        newBoolPointer(primary),
+		isConstraint:   true,
+	})
+}
+
+func (d *DatabaseState) mssqlAlterTable(ctx *parser.Alter_tableContext) *WalkThroughError {
+	schema, err := d.mssqlGetSchema(ctx.Table_name(0))
+	if err != nil {
+		return err
+	}
+	table, err := schema.antlrGetTable(mssqlNormalizeIdentifier(ctx.Table_name(0).GetTable()))
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case ctx.ADD() != nil && ctx.Column_def_table_constraints() != nil:
+		return schema.mssqlAddColumnsAndConstraints(table, ctx.Column_def_table_constraints())
+	case ctx.ALTER(1) != nil && ctx.Column_definition() != nil:
+		return schema.mssqlAlterColumn(table, ctx.Column_definition())
+	case ctx.DROP() != nil && ctx.COLUMN() != nil:
+		for _, column := range ctx.AllId_() {
+			if err := table.antlrDropColumn(schema.ctx, mssqlNormalizeIdentifier(column)); err != nil {
+				return err
+			}
+		}
+	case ctx.DROP() != nil && ctx.CONSTRAINT() != nil:
+		table.antlrDropConstraint(schema.ctx, mssqlNormalizeIdentifier(ctx.GetConstraint()))
+	}
+	return nil
+}
+
+// mssqlAlterColumn changes the data type and the nullability of the column, the constraints cannot be added by ALTER COLUMN.
+func (s *SchemaState) mssqlAlterColumn(table *TableState, ctx parser.IColumn_definitionContext) *WalkThroughError {
+	column, err := table.antlrGetColumn(s.ctx, mssqlNormalizeIdentifier(ctx.Id_()))
+	if err != nil {
+		return err
+	}
+	if ctx.Data_type() != nil {
+		column.columnType = newStringPointer(antlrGetText(ctx.Data_type()))
+	}
+	// The column is nullable if NOT NULL is not specified.
+	column.nullable = newTruePointer()
+	s.mssqlApplyColumnDefinitionElements(column, ctx.AllColumn_definition_element())
+	return nil
+}
+
+func (d *DatabaseState) mssqlCreateIndex(ctx *parser.Create_indexContext) *WalkThroughError {
+	schema, err := d.mssqlGetSchema(ctx.Table_name())
+	if err != nil {
+		return err
+	}
+	table, err := schema.antlrGetTable(mssqlNormalizeIdentifier(ctx.Table_name().GetTable()))
+	if err != nil {
+		return err
+	}
+	indexName := mssqlNormalizeIdentifier(ctx.Id_(0))
+	keyList, err := table.antlrCheckIndexKeys(schema.ctx, indexName, mssqlColumnNameListWithOrder(ctx.Column_name_list_with_order()))
+	if err != nil {
+		return err
+	}
+	return table.antlrCreateIndex(&IndexState{
+		name:           indexName,
+		expressionList: keyList,
+		indexType:      newStringPointer(mssqlIndexType(ctx.Clustered())),
+		unique:         newBoolPointer(ctx.UNIQUE() != nil),
+		primary:        newFalsePointer(),
+	})
+}
+
+func (d *DatabaseState) mssqlDropIndex(schemaName string, tableName string, indexName string, ifExists bool) *WalkThroughError {
+	schema, err := d.antlrGetSchema(schemaName, mssqlDefaultSchemaName)
+	if err != nil {
+		if ifExists {
+			return nil
+		}
+		return err
+	}
+	table, err := schema.antlrGetTable(tableName)
+	if err != nil {
+		if ifExists {
+			return nil
+		}
+		return err
+	}
+	for name, index := range table.indexSet {
+		if compareIdentifier(name, indexName, schema.ctx.IgnoreCaseSensitive) {
+			if index.isConstraint {
+				return &WalkThroughError{
+					Type:    ErrorTypeInvalidStatement,
+					Content: fmt.Sprintf("An explicit DROP INDEX is not allowed on index `%s`, it is being used for constraint enforcement", name),
+				}
+			}
+			delete(table.indexSet, name)
+			return nil
+		}
+	}
+	if ifExists || !schema.ctx.CheckIntegrity {
+		return nil
+	}
+	return NewIndexNotExistsError(table.name, indexName)
+}
+
+// mssqlConstraintExists returns true if the PRIMARY KEY or UNIQUE constraint exists in the schema.
+// In SQL Server, the constraint names are unique in a schema.
+func (s *SchemaState) mssqlConstraintExists(name string) bool {
+	for _, table := range s.tableSet {
+		for indexName, index := range table.indexSet {
+			if index.isConstraint && compareIdentifier(indexName, name, s.ctx.IgnoreCaseSensitive) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func mssqlColumnNameListWithOrder(ctx parser.IColumn_name_list_with_orderContext) []string {
+	if ctx == nil {
+		return nil
+	}
+	var result []string
+	for _, id := range ctx.AllId_() {
+		result = append(result, mssqlNormalizeIdentifier(id))
+	}
+	return result
+}
+
+func mssqlIndexType(ctx parser.IClusteredContext) string {
+	if ctx == nil {
+		return "NONCLUSTERED"
+	}
+	return strings.ToUpper(ctx.GetText())
+}
+
+// mssqlNormalizeIdentifier removes the brackets or double quotes of the delimited identifier.
+// Unlike tsqlparser.NormalizeTSQLIdentifier, it keeps the case, and the comparison follows IgnoreCaseSensitive.
+func mssqlNormalizeIdentifier(ctx parser.IId_Context) string {
+	if ctx == nil {
+		return ""
+	}
+	text := ctx.GetText()
+	if len(text) >= 2 && ((text[0] == '[' && text[len(text)-1] == ']') || (text[0] == '"' && text[len(text)-1] == '"')) {
+		text = text[1 : len(text)-1]
+	}
+	return text
+}
@@ -0,0 +1,484 @@
+package catalog
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/plsql-parser"
+
+	plsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/plsql"
+)
+
+func (d *DatabaseState) oracleWalkThrough(stmt string) error {
+	tree, _, err := plsqlparser.ParsePLSQL(stmt)
+	if err != nil {
+		return NewParseError(err.Error())
+	}
+
+	listener := &oracleWalkThroughListener{
+		databaseState: d,
+	}
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+	if listener.err != nil {
+		return listener.err
+	}
+
+	return nil
+}
+
+// oracleWalkThroughListener changes the database state by the top-level statements.
+type oracleWalkThroughListener struct {
+	*parser.BasePlSqlParserListener
+
+	databaseState *DatabaseState
+	err           *WalkThroughError
+}
+
+func (l *oracleWalkThroughListener) setError(ctx antlr.ParserRuleContext, err *WalkThroughError) {
+	if err == nil {
+		return
+	}
+	if err.Line == 0 {
+		err.Line = ctx.GetStop().GetLine()
+	}
+	l.err = err
+}
+
+// shouldChangeState returns true if the statement is a top-level statement and no error occurs before.
+func (l *oracleWalkThroughListener) shouldChangeState(ctx antlr.ParserRuleContext) bool {
+	if l.err != nil {
+		return false
+	}
+	if l.databaseState.deleted {
+		l.setError(ctx, &WalkThroughError{
+			Type:    ErrorTypeDatabaseIsDeleted,
+			Content: fmt.Sprintf(`Database %q is deleted`, l.databaseState.name),
+		})
+		return false
+	}
+	_, ok := ctx.GetParent().(*parser.Unit_statementContext)
+	return ok
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *oracleWalkThroughListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	l.setError(ctx, l.databaseState.oracleCreateTable(ctx))
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *oracleWalkThroughListener) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	l.setError(ctx, l.databaseState.oracleAlterTable(ctx))
+}
+
+// EnterDrop_table is called when production drop_table is entered.
+func (l *oracleWalkThroughListener) EnterDrop_table(ctx *parser.Drop_tableContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	l.setError(ctx, l.databaseState.oracleDropTable(ctx))
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *oracleWalkThroughListener) EnterCreate_index(ctx *parser.Create_indexContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	l.setError(ctx, l.databaseState.oracleCreateIndex(ctx))
+}
+
+// EnterDrop_index is called when production drop_index is entered.
+func (l *oracleWalkThroughListener) EnterDrop_index(ctx *parser.Drop_indexContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	l.setError(ctx, l.databaseState.oracleDropIndex(ctx))
+}
+
+// oracleCurrentSchema returns the schema of the unqualified object names.
+// We use the database name if the current schema is not set, it's right for the schema tenant mode.
+func (d *DatabaseState) oracleCurrentSchema() string {
+	if d.currentSchema != "" {
+		return d.currentSchema
+	}
+	return d.name
+}
+
+func (d *DatabaseState) oracleGetSchema(schemaName string) (*SchemaState, *WalkThroughError) {
+	return d.antlrGetSchema(schemaName, d.oracleCurrentSchema())
+}
+
+func (d *DatabaseState) oracleCreateTable(ctx *parser.Create_tableContext) *WalkThroughError {
+	schemaName := ""
+	if ctx.Schema_name() != nil {
+		schemaName = plsqlparser.NormalizeIdentifierContext(ctx.Schema_name().Identifier())
+	}
+	schema, err := d.oracleGetSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	tableName := plsqlparser.NormalizeIdentifierContext(ctx.Table_name().Identifier())
+	if schema.oracleRelationExists(tableName) {
+		return NewRelationExistsError(tableName, schema.name)
+	}
+	table, err := schema.antlrCreateTable(tableName)
+	if err != nil {
+		return err
+	}
+
+	relationalTable := ctx.Relational_table()
+	if relationalTable == nil {
+		return nil
+	}
+	// Oracle allows the out-of-line constraints to appear before the columns, so we create the columns first.
+	for _, property := range relationalTable.AllRelational_property() {
+		if property.Column_definition() == nil {
+			continue
+		}
+		if err := schema.oracleCreateColumn(table, property.Column_definition()); err != nil {
+			return err
+		}
+	}
+	for _, property := range relationalTable.AllRelational_property() {
+		if property.Out_of_line_constraint() == nil {
+			continue
+		}
+		if err := schema.oracleCreateConstraint(table, property.Out_of_line_constraint()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SchemaState) oracleCreateColumn(table *TableState, ctx parser.IColumn_definitionContext) *WalkThroughError {
+	columnName := oracleNormalizeColumnName(ctx.Column_name())
+	column := &ColumnState{
+		name:         columnName,
+		nullable:     newTruePointer(),
+		columnType:   newEmptyStringPointer(),
+		characterSet: newEmptyStringPointer(),
+		collation:    newEmptyStringPointer(),
+		comment:      newEmptyStringPointer(),
+	}
+	if ctx.Datatype() != nil {
+		column.columnType = newStringPointer(antlrGetText(ctx.Datatype()))
+	} else if ctx.Regular_id() != nil {
+		column.columnType = newStringPointer(antlrGetText(ctx.Regular_id()))
+	}
+	if ctx.DEFAULT() != nil && ctx.Expression() != nil {
+		column.defaultValue = newStringPointer(antlrGetText(ctx.Expression()))
+	}
+	for _, constraint := range ctx.AllInline_constraint() {
+		switch {
+		case constraint.NULL_() != nil:
+			column.nullable = newBoolPointer(constraint.NOT() == nil)
+		case constraint.PRIMARY() != nil:
+			column.nullable = newFalsePointer()
+		}
+	}
+	if err := table.antlrCreateColumn(s.ctx, column); err != nil {
+		return err
+	}
+	return s.oracleCreateInlineConstraints(table, columnName, ctx.AllInline_constraint())
+}
+
+func (s *SchemaState) oracleCreateInlineConstraints(table *TableState, columnName string, constraints []parser.IInline_constraintContext) *WalkThroughError {
+	for _, constraint := range constraints {
+		if constraint.PRIMARY() == nil && constraint.UNIQUE() == nil {
+			continue
+		}
+		if err := s.oracleCreateIndexConstraint(table, constraint.Constraint_name(), constraint.PRIMARY() != nil, []string{columnName}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SchemaState) oracleCreateConstraint(table *TableState, ctx parser.IOut_of_line_constraintContext) *WalkThroughError {
+	if ctx.PRIMARY() == nil && ctx.UNIQUE() == nil {
+		// We only record the PRIMARY KEY and UNIQUE constraints.
+		return nil
+	}
+	var keyList []string
+	for _, column := range ctx.AllColumn_name() {
+		keyList = append(keyList, oracleNormalizeColumnName(column))
+	}
+	return s.oracleCreateIndexConstraint(table, ctx.Constraint_name(), ctx.PRIMARY() != nil, keyList)
+}
+
+func (s *SchemaState) oracleCreateIndexConstraint(table *TableState, constraintName parser.IConstraint_nameContext, primary bool, keyList []string) *WalkThroughError {
+	_, name := plsqlparser.NormalizeConstraintName(constraintName)
+	if name == "" {
+		// Oracle generates the name like SYS_C0012345 for the anonymous constraint, we use a readable one instead.
+		prefix := "UK"
+		if primary {
+			prefix = "PK"
+		}
+		name = table.antlrGenerateIndexName(prefix)
+	} else if s.oracleRelationExists(name) {
+		return NewRelationExistsError(name, s.name)
+	}
+	keyList, err := table.antlrCheckIndexKeys(s.ctx, name, keyList)
+	if err != nil {
+		return err
+	}
+	if primary {
+		for _, key := range keyList {
+			table.columnSet[key].nullable = newFalsePointer()
+		}
+	}
+	return table.antlrCreateIndex(&IndexState{
+		name:           name,
+		expressionList: keyList,
+		indexType:      newStringPointer("NORMAL"),
+		unique:         newTruePointer(),
+		primary:        newBoolPointer(primary),
+		isConstraint:   true,
+	})
+}
+
+func (d *DatabaseState) oracleAlterTable(ctx *parser.Alter_tableContext) *WalkThroughError {
+	schemaName, tableName := oracleNormalizeTableviewName(ctx.Tableview_name())
+	schema, err := d.oracleGetSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	table, err := schema.antlrGetTable(tableName)
+	if err != nil {
+		return err
+	}
+
+	if properties := ctx.Alter_table_properties(); properties != nil {
+		if properties.RENAME() != nil && properties.Tableview_name() != nil {
+			_, newTableName := oracleNormalizeTableviewName(properties.Tableview_name())
+			if schema.oracleRelationExists(newTableName) {
+				return NewRelationExistsError(newTableName, schema.name)
+			}
+			return schema.antlrRenameTable(table.name, newTableName)
+		}
+		return nil
+	}
+	if constraintClauses := ctx.Constraint_clauses(); constraintClauses != nil {
+		return schema.oracleAlterConstraint(table, constraintClauses)
+	}
+	if columnClauses := ctx.Column_clauses(); columnClauses != nil {
+		if rename := columnClauses.Rename_column_clause(); rename != nil {
+			return table.antlrRenameColumn(
+				schema.ctx,
+				oracleNormalizeColumnName(rename.Old_column_name().Column_name()),
+				oracleNormalizeColumnName(rename.New_column_name().Column_name()),
+			)
+		}
+		if clauses := columnClauses.Add_modify_drop_column_clauses(); clauses != nil {
+			for _, child := range clauses.GetChildren() {
+				var err *WalkThroughError
+				switch child := child.(type) {
+				case *parser.Constraint_clausesContext:
+					err = schema.oracleAlterConstraint(table, child)
+				case *parser.Add_column_clauseContext:
+					for _, column := range child.AllColumn_definition() {
+						if err = schema.oracleCreateColumn(table, column); err != nil {
+							break
+						}
+					}
+				case *parser.Modify_column_clausesContext:
+					for _, column := range child.AllModify_col_properties() {
+						if err = schema.oracleModifyColumn(table, column); err != nil {
+							break
+						}
+					}
+				case *parser.Drop_column_clauseContext:
+					for _, column := range child.AllColumn_name() {
+						if err = table.antlrDropColumn(schema.ctx, oracleNormalizeColumnName(column)); err != nil {
+							break
+						}
+					}
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *SchemaState) oracleModifyColumn(table *TableState, ctx parser.IModify_col_propertiesContext) *WalkThroughError {
+	column, err := table.antlrGetColumn(s.ctx, oracleNormalizeColumnName(ctx.Column_name()))
+	if err != nil {
+		return err
+	}
+	if ctx.Datatype() != nil {
+		column.columnType = newStringPointer(antlrGetText(ctx.Datatype()))
+	}
+	if ctx.DEFAULT() != nil && ctx.Expression() != nil {
+		column.defaultValue = newStringPointer(antlrGetText(ctx.Expression()))
+	}
+	for _, constraint := range ctx.AllInline_constraint() {
+		if constraint.NULL_() != nil {
+			column.nullable = newBoolPointer(constraint.NOT() == nil)
+		}
+	}
+	return s.oracleCreateInlineConstraints(table, column.name, ctx.AllInline_constraint())
+}
+
+func (s *SchemaState) oracleAlterConstraint(table *TableState, ctx parser.IConstraint_clausesContext) *WalkThroughError {
+	switch {
+	case ctx.ADD() != nil:
+		for _, constraint := range ctx.AllOut_of_line_constraint() {
+			if err := s.oracleCreateConstraint(table, constraint); err != nil {
+				return err
+			}
+		}
+	case ctx.RENAME() != nil:
+		_, oldName := plsqlparser.NormalizeConstraintName(ctx.Old_constraint_name().Constraint_name())
+		_, newName := plsqlparser.NormalizeConstraintName(ctx.New_constraint_name().Constraint_name())
+		if index, exists := table.indexSet[oldName]; exists {
+			if s.oracleRelationExists(newName) {
+				return NewRelationExistsError(newName, s.name)
+			}
+			delete(table.indexSet, oldName)
+			index.name = newName
+			table.indexSet[newName] = index
+		}
+	default:
+		for _, dropConstraint := range ctx.AllDrop_constraint_clause() {
+			drop := dropConstraint.Drop_primary_key_or_unique_or_generic_clause()
+			switch {
+			case drop.PRIMARY() != nil:
+				if err := table.antlrDropPrimaryKey(s.ctx); err != nil {
+					return err
+				}
+			case drop.UNIQUE() != nil:
+				var keyList []string
+				for _, column := range drop.AllColumn_name() {
+					keyList = append(keyList, oracleNormalizeColumnName(column))
+				}
+				for name, index := range table.indexSet {
+					if index.isConstraint && index.Unique() && !index.Primary() && equalKeyList(index.expressionList, keyList) {
+						delete(table.indexSet, name)
+						break
+					}
+				}
+			default:
+				_, name := plsqlparser.NormalizeConstraintName(drop.Constraint_name())
+				table.antlrDropConstraint(s.ctx, name)
+			}
+		}
+	}
+	return nil
+}
+
+func (d *DatabaseState) oracleDropTable(ctx *parser.Drop_tableContext) *WalkThroughError {
+	schemaName, tableName := oracleNormalizeTableviewName(ctx.Tableview_name())
+	schema, err := d.oracleGetSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	return schema.antlrDropTable(tableName, false /* ifExists */)
+}
+
+func (d *DatabaseState) oracleCreateIndex(ctx *parser.Create_indexContext) *WalkThroughError {
+	tableIndexClause := ctx.Table_index_clause()
+	if tableIndexClause == nil {
+		// We don't record the cluster index and bitmap join index.
+		return nil
+	}
+	indexSchemaName, indexName := plsqlparser.NormalizeIndexName(ctx.Index_name())
+	schemaName, tableName := oracleNormalizeTableviewName(tableIndexClause.Tableview_name())
+	if indexSchemaName == "" {
+		indexSchemaName = schemaName
+	}
+	schema, err := d.oracleGetSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	table, err := schema.antlrGetTable(tableName)
+	if err != nil {
+		return err
+	}
+	indexSchema, err := d.oracleGetSchema(indexSchemaName)
+	if err != nil {
+		return err
+	}
+	if indexSchema.oracleRelationExists(indexName) {
+		return NewRelationExistsError(indexName, indexSchema.name)
+	}
+
+	var keyList []string
+	for _, expression := range tableIndexClause.AllIndex_expr() {
+		if expression.Column_name() != nil {
+			column, err := table.antlrGetColumn(schema.ctx, oracleNormalizeColumnName(expression.Column_name()))
+			if err != nil {
+				return err
+			}
+			keyList = append(keyList, column.name)
+			continue
+		}
+		keyList = append(keyList, antlrGetText(expression))
+	}
+	indexType := "NORMAL"
+	if ctx.BITMAP() != nil {
+		indexType = "BITMAP"
+	}
+	return table.antlrCreateIndex(&IndexState{
+		name:           indexName,
+		expressionList: keyList,
+		indexType:      newStringPointer(indexType),
+		unique:         newBoolPointer(ctx.UNIQUE() != nil),
+		primary:        newFalsePointer(),
+	})
+}
+
+func (d *DatabaseState) oracleDropIndex(ctx *parser.Drop_indexContext) *WalkThroughError {
+	schemaName, indexName := plsqlparser.NormalizeIndexName(ctx.Index_name())
+	schema, err := d.oracleGetSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	table, index, err := schema.getIndex(indexName)
+	if err != nil {
+		if schema.ctx.CheckIntegrity {
+			return err
+		}
+		return nil
+	}
+	delete(table.indexSet, index.name)
+	return nil
+}
+
+// oracleRelationExists returns true if the table or the index with the given name exists in the schema.
+// In Oracle, the tables and the indexes share the same namespace in a schema.
+func (s *SchemaState) oracleRelationExists(name string) bool {
+	if _, exists := s.getTable(name); exists {
+		return true
+	}
+	_, _, err := s.getIndex(name)
+	return err == nil
+}
+
+func oracleNormalizeTableviewName(ctx parser.ITableview_nameContext) (string, string) {
+	if ctx == nil || ctx.Identifier() == nil {
+		return "", ""
+	}
+	if ctx.Id_expression() != nil {
+		return plsqlparser.NormalizeIdentifierContext(ctx.Identifier()), plsqlparser.NormalizeIDExpression(ctx.Id_expression())
+	}
+	return "", plsqlparser.NormalizeIdentifierContext(ctx.Identifier())
+}
+
+// oracleNormalizeColumnName returns the last part of the column name.
+func oracleNormalizeColumnName(ctx parser.IColumn_nameContext) string {
+	if ctx == nil {
+		return ""
+	}
+	if list := ctx.AllId_expression(); len(list) > 0 {
+		return plsqlparser.NormalizeIDExpression(list[len(list)-1])
+	}
+	return plsqlparser.NormalizeIdentifierContext(ctx.Identifier())
+}
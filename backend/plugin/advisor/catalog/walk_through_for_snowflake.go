@@ -0,0 +1,404 @@
+package catalog
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+
+	snowparser "github.com/bytebase/bytebase/backend/plugin/parser/snowflake"
+)
+
+const (
+	snowflakeDefaultSchemaName = "PUBLIC"
+)
+
+func (d *DatabaseState) snowflakeWalkThrough(stmt string) error {
+	result, err := snowparser.ParseSnowSQL(stmt)
+	if err != nil {
+		return NewParseError(err.Error())
+	}
+
+	listener := &snowflakeWalkThroughListener{
+		databaseState: d,
+	}
+	antlr.ParseTreeWalkerDefault.Walk(listener, result.Tree)
+	if listener.err != nil {
+		return listener.err
+	}
+
+	return nil
+}
+
+// snowflakeWalkThroughListener changes the database state by the DDL statements.
+type snowflakeWalkThroughListener struct {
+	*parser.BaseSnowflakeParserListener
+
+	databaseState *DatabaseState
+	err           *WalkThroughError
+}
+
+func (l *snowflakeWalkThroughListener) setError(ctx antlr.ParserRuleContext, err *WalkThroughError) {
+	if err == nil {
+		return
+	}
+	if err.Line == 0 {
+		err.Line = ctx.GetStart().GetLine()
+	}
+	l.err = err
+}
+
+// shouldChangeState returns true if no error occurs before.
+func (l *snowflakeWalkThroughListener) shouldChangeState(ctx antlr.ParserRuleContext) bool {
+	if l.err != nil {
+		return false
+	}
+	if l.databaseState.deleted {
+		l.setError(ctx, &WalkThroughError{
+			Type:    ErrorTypeDatabaseIsDeleted,
+			Content: fmt.Sprintf(`Database %q is deleted`, l.databaseState.name),
+		})
+		return false
+	}
+	return true
+}
+
+// EnterCreate_schema is called when production create_schema is entered.
+func (l *snowflakeWalkThroughListener) EnterCreate_schema(ctx *parser.Create_schemaContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	schemaName, err := l.databaseState.snowflakeNormalizeSchemaName(ctx.Schema_name())
+	if err != nil {
+		l.setError(ctx, err)
+		return
+	}
+	if ctx.Or_replace() != nil {
+		if err := l.databaseState.antlrDropSchema(schemaName, true /* ifExists */); err != nil {
+			l.setError(ctx, err)
+			return
+		}
+	}
+	l.setError(ctx, l.databaseState.antlrCreateSchema(schemaName, ctx.If_not_exists() != nil))
+}
+
+// EnterDrop_schema is called when production drop_schema is entered.
+func (l *snowflakeWalkThroughListener) EnterDrop_schema(ctx *parser.Drop_schemaContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	schemaName, err := l.databaseState.snowflakeNormalizeSchemaName(ctx.Schema_name())
+	if err != nil {
+		l.setError(ctx, err)
+		return
+	}
+	l.setError(ctx, l.databaseState.antlrDropSchema(schemaName, ctx.If_exists() != nil))
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *snowflakeWalkThroughListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	l.setError(ctx, l.databaseState.snowflakeCreateTable(ctx))
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *snowflakeWalkThroughListener) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	l.setError(ctx, l.databaseState.snowflakeAlterTable(ctx))
+}
+
+// EnterDrop_table is called when production drop_table is entered.
+func (l *snowflakeWalkThroughListener) EnterDrop_table(ctx *parser.Drop_tableContext) {
+	if !l.shouldChangeState(ctx) {
+		return
+	}
+	ifExists := ctx.If_exists() != nil
+	schema, tableName, err := l.databaseState.snowflakeGetSchema(ctx.Object_name())
+	if err != nil {
+		if !ifExists {
+			l.setError(ctx, err)
+		}
+		return
+	}
+	l.setError(ctx, schema.antlrDropTable(tableName, ifExists))
+}
+
+// snowflakeGetSchema returns the schema state and the normalized object name.
+func (d *DatabaseState) snowflakeGetSchema(objectName parser.IObject_nameContext) (*SchemaState, string, *WalkThroughError) {
+	if database := snowparser.NormalizeSnowSQLObjectNamePart(objectName.GetD()); database != "" && !d.isCurrentDatabase(database) {
+		return nil, "", NewAccessOtherDatabaseError(d.name, database)
+	}
+	schema, err := d.antlrGetSchema(snowparser.NormalizeSnowSQLObjectNamePart(objectName.GetS()), snowflakeDefaultSchemaName)
+	if err != nil {
+		return nil, "", err
+	}
+	return schema, snowparser.NormalizeSnowSQLObjectNamePart(objectName.GetO()), nil
+}
+
+func (d *DatabaseState) snowflakeNormalizeSchemaName(schemaName parser.ISchema_nameContext) (string, *WalkThroughError) {
+	ids := schemaName.AllId_()
+	if len(ids) == 2 {
+		if database := snowparser.NormalizeSnowSQLObjectNamePart(ids[0]); !d.isCurrentDatabase(database) {
+			return "", NewAccessOtherDatabaseError(d.name, database)
+		}
+	}
+	return snowparser.NormalizeSnowSQLObjectNamePart(ids[len(ids)-1]), nil
+}
+
+func (d *DatabaseState) snowflakeCreateTable(ctx *parser.Create_tableContext) *WalkThroughError {
+	schema, tableName, err := d.snowflakeGetSchema(ctx.Object_name())
+	if err != nil {
+		return err
+	}
+	if _, exists := schema.getTable(tableName); exists {
+		if ctx.If_not_exists() != nil {
+			return nil
+		}
+		if ctx.Or_replace() != nil {
+			if err := schema.antlrDropTable(tableName, true /* ifExists */); err != nil {
+				return err
+			}
+		}
+	}
+	table, err := schema.antlrCreateTable(tableName)
+	if err != nil {
+		return err
+	}
+
+	// Snowflake allows the out-of-line constraints to appear before the columns, so we create the columns first.
+	items := ctx.Column_decl_item_list().AllColumn_decl_item()
+	for _, item := range items {
+		if item.Full_col_decl() == nil {
+			continue
+		}
+		if err := schema.snowflakeCreateColumn(table, item.Full_col_decl()); err != nil {
+			return err
+		}
+	}
+	for _, item := range items {
+		if item.Out_of_line_constraint() == nil {
+			continue
+		}
+		if err := schema.snowflakeCreateConstraint(table, item.Out_of_line_constraint()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SchemaState) snowflakeCreateColumn(table *TableState, ctx parser.IFull_col_declContext) *WalkThroughError {
+	columnName := snowparser.NormalizeSnowSQLObjectNamePart(ctx.Col_decl().Column_name().Id_())
+	column := &ColumnState{
+		name:         columnName,
+		nullable:     newTruePointer(),
+		columnType:   newStringPointer(antlrGetText(ctx.Col_decl().Data_type())),
+		characterSet: newEmptyStringPointer(),
+		collation:    newEmptyStringPointer(),
+		comment:      newEmptyStringPointer(),
+	}
+	for _, defaultValue := range ctx.AllDefault_value() {
+		if defaultValue.DEFAULT() != nil && defaultValue.Expr() != nil {
+			column.defaultValue = newStringPointer(antlrGetText(defaultValue.Expr()))
+		}
+	}
+	for _, collate := range ctx.AllCollate() {
+		column.collation = newStringPointer(antlrGetText(collate.String_()))
+	}
+	for _, nullNotNull := range ctx.AllNull_not_null() {
+		column.nullable = newBoolPointer(nullNotNull.NOT() == nil)
+	}
+	for _, constraint := range ctx.AllInline_constraint() {
+		if constraint.Null_not_null() != nil {
+			column.nullable = newBoolPointer(constraint.Null_not_null().NOT() == nil)
+		}
+	}
+	if err := table.antlrCreateColumn(s.ctx, column); err != nil {
+		return err
+	}
+	return s.snowflakeCreateInlineConstraints(table, columnName, ctx.AllInline_constraint())
+}
+
+func (s *SchemaState) snowflakeCreateInlineConstraints(table *TableState, columnName string, constraints []parser.IInline_constraintContext) *WalkThroughError {
+	for _, constraint := range constraints {
+		if constraint == nil || (constraint.PRIMARY() == nil && constraint.UNIQUE() == nil) {
+			continue
+		}
+		if err := s.snowflakeCreateIndexConstraint(
+			table,
+			snowparser.NormalizeSnowSQLObjectNamePart(constraint.Id_()),
+			constraint.PRIMARY() != nil,
+			[]string{columnName},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SchemaState) snowflakeCreateConstraint(table *TableState, ctx parser.IOut_of_line_constraintContext) *WalkThroughError {
+	if ctx.PRIMARY() == nil && ctx.UNIQUE() == nil {
+		// We only record the PRIMARY KEY and UNIQUE constraints.
+		return nil
+	}
+	var keyList []string
+	if columnList := ctx.Column_list_in_parentheses(0); columnList != nil {
+		keyList = snowflakeColumnList(columnList.Column_list())
+	}
+	return s.snowflakeCreateIndexConstraint(table, snowparser.NormalizeSnowSQLObjectNamePart(ctx.Id_()), ctx.PRIMARY() != nil, keyList)
+}
+
+func (s *SchemaState) snowflakeCreateIndexConstraint(table *TableState, name string, primary bool, keyList []string) *WalkThroughError {
+	if name == "" {
+		// Snowflake generates the name like SYS_CONSTRAINT_<uuid> for the anonymous constraint, we use a readable one instead.
+		prefix := "UK"
+		if primary {
+			prefix = "PK"
+		}
+		name = table.antlrGenerateIndexName(prefix)
+	}
+	keyList, err := table.antlrCheckIndexKeys(s.ctx, name, keyList)
+	if err != nil {
+		return err
+	}
+	if primary {
+		for _, key := range keyList {
+			table.columnSet[key].nullable = newFalsePointer()
+		}
+	}
+	return table.antlrCreateIndex(&IndexState{
+		name:           name,
+		expressionList: keyList,
+		indexType:      newEmptyStringPointer(),
+		unique:         newTruePointer(),
+		primary:        newBoolPointer(primary),
+		isConstraint:   true,
+	})
+}
+
+func (d *DatabaseState) snowflakeAlterTable(ctx *parser.Alter_tableContext) *WalkThroughError {
+	ifExists := ctx.If_exists() != nil
+	schema, tableName, err := d.snowflakeGetSchema(ctx.Object_name(0))
+	if err != nil {
+		if ifExists {
+			return nil
+		}
+		return err
+	}
+	if _, exists := schema.getTable(tableName); !exists && ifExists {
+		return nil
+	}
+	table, err := schema.antlrGetTable(tableName)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case ctx.RENAME() != nil && ctx.Object_name(1) != nil:
+		newSchema, newTableName, err := d.snowflakeGetSchema(ctx.Object_name(1))
+		if err != nil {
+			return err
+		}
+		if newSchema != schema {
+			if _, exists := newSchema.getTable(newTableName); exists {
+				return NewTableExistsError(newTableName)
+			}
+			delete(schema.tableSet, table.name)
+			table.name = newTableName
+			newSchema.tableSet[newTableName] = table
+			return nil
+		}
+		return schema.antlrRenameTable(table.name, newTableName)
+	case ctx.Table_column_action() != nil:
+		return schema.snowflakeAlterColumn(table, ctx.Table_column_action())
+	case ctx.Constraint_action() != nil:
+		return schema.snowflakeAlterConstraint(table, ctx.Constraint_action())
+	}
+	return nil
+}
+
+func (s *SchemaState) snowflakeAlterColumn(table *TableState, ctx parser.ITable_column_actionContext) *WalkThroughError {
+	switch {
+	case ctx.ADD() != nil:
+		columnName := snowparser.NormalizeSnowSQLObjectNamePart(ctx.Column_name(0).Id_())
+		column := &ColumnState{
+			name:         columnName,
+			nullable:     newTruePointer(),
+			columnType:   newStringPointer(antlrGetText(ctx.Data_type())),
+			characterSet: newEmptyStringPointer(),
+			collation:    newEmptyStringPointer(),
+			comment:      newEmptyStringPointer(),
+		}
+		if ctx.DEFAULT(0) != nil && ctx.Expr() != nil {
+			column.defaultValue = newStringPointer(antlrGetText(ctx.Expr()))
+		}
+		if ctx.Null_not_null() != nil {
+			column.nullable = newBoolPointer(ctx.Null_not_null().NOT() == nil)
+		}
+		if err := table.antlrCreateColumn(s.ctx, column); err != nil {
+			return err
+		}
+		return s.snowflakeCreateInlineConstraints(table, columnName, []parser.IInline_constraintContext{ctx.Inline_constraint()})
+	case ctx.RENAME() != nil:
+		return table.antlrRenameColumn(
+			s.ctx,
+			snowparser.NormalizeSnowSQLObjectNamePart(ctx.Column_name(0).Id_()),
+			snowparser.NormalizeSnowSQLObjectNamePart(ctx.Column_name(1).Id_()),
+		)
+	case ctx.Alter_modify() == nil && ctx.DROP(0) != nil && ctx.Column_list() != nil:
+		for _, columnName := range snowflakeColumnList(ctx.Column_list()) {
+			if err := table.antlrDropColumn(s.ctx, columnName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *SchemaState) snowflakeAlterConstraint(table *TableState, ctx parser.IConstraint_actionContext) *WalkThroughError {
+	switch {
+	case ctx.ADD() != nil:
+		return s.snowflakeCreateConstraint(table, ctx.Out_of_line_constraint())
+	case ctx.RENAME() != nil:
+		oldName := snowparser.NormalizeSnowSQLObjectNamePart(ctx.Id_(0))
+		newName := snowparser.NormalizeSnowSQLObjectNamePart(ctx.Id_(1))
+		if index, exists := table.indexSet[oldName]; exists {
+			if _, exists := table.indexSet[newName]; exists {
+				return NewIndexExistsError(table.name, newName)
+			}
+			delete(table.indexSet, oldName)
+			index.name = newName
+			table.indexSet[newName] = index
+		}
+	case ctx.DROP() != nil:
+		switch {
+		case ctx.PRIMARY() != nil:
+			return table.antlrDropPrimaryKey(s.ctx)
+		case ctx.CONSTRAINT() != nil:
+			table.antlrDropConstraint(s.ctx, snowparser.NormalizeSnowSQLObjectNamePart(ctx.Id_(0)))
+		case ctx.UNIQUE() != nil && ctx.Column_list_in_parentheses() != nil:
+			keyList := snowflakeColumnList(ctx.Column_list_in_parentheses().Column_list())
+			for name, index := range table.indexSet {
+				if index.isConstraint && index.Unique() && !index.Primary() && equalKeyList(index.expressionList, keyList) {
+					delete(table.indexSet, name)
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func snowflakeColumnList(ctx parser.IColumn_listContext) []string {
+	if ctx == nil {
+		return nil
+	}
+	var result []string
+	for _, column := range ctx.AllColumn_name() {
+		result = append(result, snowparser.NormalizeSnowSQLObjectNamePart(column.Id_()))
+	}
+	return result
+}
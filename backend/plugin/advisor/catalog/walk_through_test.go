@@ -106,6 +106,139 @@ func TestPostgreSQLWalkThrough(t *testing.T) {
 	}
 }
 
+func TestOracleWalkThrough(t *testing.T) {
+	originDatabase := &storepb.DatabaseSchemaMetadata{
+		Name: "TEST_DB",
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "TEST_DB",
+				Tables: []*storepb.TableMetadata{
+					{
+						Name: "T1",
+						Columns: []*storepb.ColumnMetadata{
+							{
+								Name:     "ID",
+								Type:     "NUMBER",
+								Nullable: false,
+							},
+							{
+								Name:     "NAME",
+								Type:     "VARCHAR2(20)",
+								Nullable: true,
+							},
+						},
+						Indexes: []*storepb.IndexMetadata{
+							{
+								Name:        "PK_T1",
+								Expressions: []string{"ID"},
+								Unique:      true,
+								Primary:     true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []string{
+		"oracle_walk_through",
+	}
+
+	for _, test := range tests {
+		runWalkThroughTest(t, test, storepb.Engine_ORACLE, originDatabase, false /* record */)
+	}
+}
+
+func TestMSSQLWalkThrough(t *testing.T) {
+	originDatabase := &storepb.DatabaseSchemaMetadata{
+		Name: "test_db",
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "dbo",
+				Tables: []*storepb.TableMetadata{
+					{
+						Name: "t1",
+						Columns: []*storepb.ColumnMetadata{
+							{
+								Name:     "id",
+								Type:     "int",
+								Nullable: false,
+							},
+							{
+								Name:     "name",
+								Type:     "varchar(20)",
+								Nullable: true,
+							},
+						},
+						Indexes: []*storepb.IndexMetadata{
+							{
+								Name:        "pk_t1",
+								Expressions: []string{"id"},
+								Type:        "CLUSTERED",
+								Unique:      true,
+								Primary:     true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []string{
+		"mssql_walk_through",
+	}
+
+	for _, test := range tests {
+		runWalkThroughTest(t, test, storepb.Engine_MSSQL, originDatabase, false /* record */)
+	}
+}
+
+func TestSnowflakeWalkThrough(t *testing.T) {
+	originDatabase := &storepb.DatabaseSchemaMetadata{
+		Name: "TEST_DB",
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "PUBLIC",
+				Tables: []*storepb.TableMetadata{
+					{
+						Name: "T1",
+						Columns: []*storepb.ColumnMetadata{
+							{
+								Name:     "ID",
+								Type:     "NUMBER(38,0)",
+								Nullable: false,
+							},
+							{
+								Name:     "NAME",
+								Type:     "VARCHAR(20)",
+								Nullable: true,
+							},
+						},
+						Indexes: []*storepb.IndexMetadata{
+							{
+								Name:        "PK_T1",
+								Expressions: []string{"ID"},
+								Unique:      true,
+								Primary:     true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []string{
+		"snowflake_walk_through",
+	}
+
+	for _, test := range tests {
+		runWalkThroughTest(t, test, storepb.Engine_SNOWFLAKE, originDatabase, false /* record */)
+	}
+}
+
 func convertInterfaceSliceToStringSlice(slice []any) []string {
 	var res []string
 	for _, item := range slice {
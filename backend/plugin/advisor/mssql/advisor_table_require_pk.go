@@ -3,12 +3,14 @@ package mssql
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/antlr4-go/antlr/v4"
 	parser "github.com/bytebase/tsql-parser"
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
 	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -45,6 +47,8 @@ func (*TableRequirePkAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Ad
 		tableHasPrimaryKey:         make(map[string]bool),
 		tableOriginalName:          make(map[string]string),
 		tableLine:                  make(map[string]int),
+		tableCatalog:               make(map[string]catalog.TableFind),
+		catalog:                    ctx.Catalog,
 	}
 
 	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
@@ -68,6 +72,10 @@ type tableRequirePkChecker struct {
 	tableOriginalName map[string]string
 	// tableLine is a map from normalized table name to the line number of the table.
 	tableLine map[string]int
+	// tableCatalog is a map from normalized table name to the schema and table name in the catalog.
+	tableCatalog map[string]catalog.TableFind
+
+	catalog *catalog.Finder
 
 	adviceList []advisor.Advice
 }
@@ -75,6 +83,14 @@ type tableRequirePkChecker struct {
 // generateAdvice returns the advices generated by the listener, the advices must not be empty.
 func (l *tableRequirePkChecker) generateAdvice() ([]advisor.Advice, error) {
 	for tableName, hasPK := range l.tableHasPrimaryKey {
+		if l.catalog != nil && l.catalog.Final.Usable() {
+			// The catalog walks through the whole sheet, so it knows the PRIMARY KEY changed by the other statements.
+			// The renamed table is not in the catalog with the original name, we keep the result of the listener for it.
+			find := l.tableCatalog[tableName]
+			if l.catalog.Final.FindTable(&find) != nil {
+				hasPK = l.catalog.Final.FindPrimaryKey(&catalog.PrimaryKeyFind{SchemaName: find.SchemaName, TableName: find.TableName}) != nil
+			}
+		}
 		if !hasPK {
 			l.adviceList = append(l.adviceList, advisor.Advice{
 				Status:  l.level,
@@ -108,6 +124,7 @@ func (l *tableRequirePkChecker) EnterCreate_table(ctx *parser.Create_tableContex
 	l.tableHasPrimaryKey[normalizedTableName] = false
 	l.tableOriginalName[normalizedTableName] = tableName.GetText()
 	l.tableLine[normalizedTableName] = tableName.GetStart().GetLine()
+	l.tableCatalog[normalizedTableName] = getCatalogTableFind(tableName)
 
 	l.currentNormalizedTableName = normalizedTableName
 	l.currentConstraintAction = currentConstraintActionAdd
@@ -155,6 +172,16 @@ func (l *tableRequirePkChecker) EnterAlter_table(ctx *parser.Alter_tableContext)
 		return
 	}
 	normalizedTableName := tsqlparser.NormalizeTSQLTableName(tableName, "" /* fallbackDatabase */, "dbo" /* fallbackSchema */, false /* caseSensitive */)
+	if _, exists := l.tableHasPrimaryKey[normalizedTableName]; !exists && l.catalog != nil {
+		// The existing table with PRIMARY KEY may lose it by altering.
+		find := getCatalogTableFind(tableName)
+		if l.catalog.Origin.FindPrimaryKey(&catalog.PrimaryKeyFind{SchemaName: find.SchemaName, TableName: find.TableName}) != nil {
+			l.tableHasPrimaryKey[normalizedTableName] = true
+			l.tableOriginalName[normalizedTableName] = tableName.GetText()
+			l.tableLine[normalizedTableName] = tableName.GetStart().GetLine()
+			l.tableCatalog[normalizedTableName] = find
+		}
+	}
 	if ctx.ADD() != nil && ctx.Column_def_table_constraints() != nil {
 		l.currentNormalizedTableName = normalizedTableName
 		l.currentConstraintAction = currentConstraintActionAdd
@@ -168,3 +195,27 @@ func (l *tableRequirePkChecker) ExitAlter_table(*parser.Alter_tableContext) {
 	l.currentNormalizedTableName = ""
 	l.currentConstraintAction = currentConstraintActionNone
 }
+
+// getCatalogTableFind returns the schema and table name in the same way as the catalog walk-through.
+func getCatalogTableFind(tableName parser.ITable_nameContext) catalog.TableFind {
+	find := catalog.TableFind{
+		SchemaName: "dbo",
+		TableName:  trimTSQLIdentifierQuotes(tableName.GetTable()),
+	}
+	if schema := trimTSQLIdentifierQuotes(tableName.GetSchema()); schema != "" {
+		find.SchemaName = schema
+	}
+	return find
+}
+
+// trimTSQLIdentifierQuotes removes the brackets or double quotes of the identifier, and keeps the case.
+func trimTSQLIdentifierQuotes(id parser.IId_Context) string {
+	if id == nil {
+		return ""
+	}
+	text := id.GetText()
+	if len(text) >= 2 && ((strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]")) || (strings.HasPrefix(text, `"`) && strings.HasSuffix(text, `"`))) {
+		return text[1 : len(text)-1]
+	}
+	return text
+}
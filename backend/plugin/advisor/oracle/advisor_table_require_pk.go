@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
@@ -36,12 +37,19 @@ func (*TableRequirePKAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Ad
 		return nil, err
 	}
 
+	currentSchema := ctx.CurrentSchema
+	if currentSchema == "" && ctx.Catalog != nil {
+		// Keep the same default schema as the catalog walk-through.
+		currentSchema = ctx.Catalog.Final.DatabaseName()
+	}
 	listener := &TableRequirePKListener{
 		level:         level,
 		title:         string(ctx.Rule.Type),
-		currentSchema: ctx.CurrentSchema,
+		currentSchema: currentSchema,
+		catalog:       ctx.Catalog,
 		tableWitPK:    make(map[string]bool),
 		tableLine:     make(map[string]int),
+		tableCatalog:  make(map[string]catalog.TableFind),
 	}
 
 	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
@@ -57,13 +65,24 @@ type TableRequirePKListener struct {
 	title         string
 	currentSchema string
 	tableName     string
+	catalog       *catalog.Finder
 	tableWitPK    map[string]bool
 	tableLine     map[string]int
+	// tableCatalog is a map from the table name to the schema and table name in the catalog.
+	tableCatalog map[string]catalog.TableFind
 }
 
 func (l *TableRequirePKListener) generateAdvice() ([]advisor.Advice, error) {
 	advice := []advisor.Advice{}
 	for tableName, hasPK := range l.tableWitPK {
+		if l.catalog != nil && l.catalog.Final.Usable() {
+			// The catalog walks through the whole sheet, so it knows the PRIMARY KEY changed by the other statements.
+			// The renamed table is not in the catalog with the original name, we keep the result of the listener for it.
+			find := l.tableCatalog[tableName]
+			if l.catalog.Final.FindTable(&find) != nil {
+				hasPK = l.catalog.Final.FindPrimaryKey(&catalog.PrimaryKeyFind{SchemaName: find.SchemaName, TableName: find.TableName}) != nil
+			}
+		}
 		if !hasPK {
 			advice = append(advice, advisor.Advice{
 				Status:  l.level,
@@ -93,9 +112,11 @@ func (l *TableRequirePKListener) EnterCreate_table(ctx *parser.Create_tableConte
 		schemaName = normalizeIdentifier(ctx.Schema_name(), l.currentSchema)
 	}
 
-	l.tableName = fmt.Sprintf("%s.%s", schemaName, normalizeIdentifier(ctx.Table_name(), l.currentSchema))
+	tableName := normalizeIdentifier(ctx.Table_name(), l.currentSchema)
+	l.tableName = fmt.Sprintf("%s.%s", schemaName, tableName)
 	l.tableWitPK[l.tableName] = false
 	l.tableLine[l.tableName] = ctx.GetStop().GetLine()
+	l.tableCatalog[l.tableName] = catalog.TableFind{SchemaName: schemaName, TableName: tableName}
 }
 
 // ExitCreate_table is called when production create_table is exited.
@@ -133,6 +154,20 @@ func (l *TableRequirePKListener) EnterOut_of_line_constraint(ctx *parser.Out_of_
 // EnterAlter_table is called when production alter_table is entered.
 func (l *TableRequirePKListener) EnterAlter_table(ctx *parser.Alter_tableContext) {
 	l.tableName = normalizeIdentifier(ctx.Tableview_name(), l.currentSchema)
+	if _, exists := l.tableWitPK[l.tableName]; exists || l.catalog == nil {
+		return
+	}
+	// The existing table with PRIMARY KEY may lose it by altering.
+	schemaName, tableName := l.currentSchema, normalizeIdentifier(ctx.Tableview_name().Identifier(), l.currentSchema)
+	if ctx.Tableview_name().Id_expression() != nil {
+		schemaName, tableName = tableName, normalizeIDExpression(ctx.Tableview_name().Id_expression())
+	}
+	if l.catalog.Origin.FindPrimaryKey(&catalog.PrimaryKeyFind{SchemaName: schemaName, TableName: tableName}) == nil {
+		return
+	}
+	l.tableWitPK[l.tableName] = true
+	l.tableLine[l.tableName] = ctx.GetStop().GetLine()
+	l.tableCatalog[l.tableName] = catalog.TableFind{SchemaName: schemaName, TableName: tableName}
 }
 
 // ExitAlter_table is called when production alter_table is exited.
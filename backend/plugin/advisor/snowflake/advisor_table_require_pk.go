@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
 	snowsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/snowflake"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -45,6 +46,8 @@ func (*TableRequirePkAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Ad
 		tableHasPrimaryKey:         make(map[string]bool),
 		tableOriginalName:          make(map[string]string),
 		tableLine:                  make(map[string]int),
+		tableCatalog:               make(map[string]catalog.TableFind),
+		catalog:                    ctx.Catalog,
 	}
 
 	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
@@ -75,11 +78,23 @@ type tableRequirePkChecker struct {
 	// tableLine is a map of normalized table name to the line number of the table.
 	// The key of the tableLine is the superset of the key of the tableHasPrimaryKey.
 	tableLine map[string]int
+	// tableCatalog is a map of normalized table name to the schema and table name in the catalog.
+	tableCatalog map[string]catalog.TableFind
+
+	catalog *catalog.Finder
 }
 
 // generateAdvice returns the advices generated by the listener, the advices must not be empty.
 func (l *tableRequirePkChecker) generateAdvice() ([]advisor.Advice, error) {
 	for tableName, has := range l.tableHasPrimaryKey {
+		if l.catalog != nil && l.catalog.Final.Usable() {
+			// The catalog walks through the whole sheet, so it knows the PRIMARY KEY changed by the other statements.
+			// The renamed table is not in the catalog with the original name, we keep the result of the listener for it.
+			find := l.tableCatalog[tableName]
+			if l.catalog.Final.FindTable(&find) != nil {
+				has = l.catalog.Final.FindPrimaryKey(&catalog.PrimaryKeyFind{SchemaName: find.SchemaName, TableName: find.TableName}) != nil
+			}
+		}
 		if !has {
 			l.adviceList = append(l.adviceList, advisor.Advice{
 				Status:  l.level,
@@ -111,6 +126,7 @@ func (l *tableRequirePkChecker) EnterCreate_table(ctx *parser.Create_tableContex
 	l.tableHasPrimaryKey[normalizedTableName] = false
 	l.tableOriginalName[normalizedTableName] = originalTableName.GetText()
 	l.tableLine[normalizedTableName] = ctx.GetStart().GetLine()
+	l.tableCatalog[normalizedTableName] = getCatalogTableFind(originalTableName)
 	l.currentNormalizedTableName = normalizedTableName
 	l.currentConstraintAction = currentConstraintActionAdd
 }
@@ -172,11 +188,21 @@ func (l *tableRequirePkChecker) EnterConstraint_action(ctx *parser.Constraint_ac
 
 // EnterAlter_table is called when production alter_table is entered.
 func (l *tableRequirePkChecker) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	originalTableName := ctx.Object_name(0)
+	normalizedTableName := snowsqlparser.NormalizeSnowSQLObjectName(originalTableName, "", "PUBLIC")
+	if _, exists := l.tableHasPrimaryKey[normalizedTableName]; !exists && l.catalog != nil {
+		// The existing table with PRIMARY KEY may lose it by altering.
+		find := getCatalogTableFind(originalTableName)
+		if l.catalog.Origin.FindPrimaryKey(&catalog.PrimaryKeyFind{SchemaName: find.SchemaName, TableName: find.TableName}) != nil {
+			l.tableHasPrimaryKey[normalizedTableName] = true
+			l.tableOriginalName[normalizedTableName] = originalTableName.GetText()
+			l.tableLine[normalizedTableName] = ctx.GetStart().GetLine()
+			l.tableCatalog[normalizedTableName] = find
+		}
+	}
 	if ctx.Constraint_action() == nil {
 		return
 	}
-	originalTableName := ctx.Object_name(0)
-	normalizedTableName := snowsqlparser.NormalizeSnowSQLObjectName(originalTableName, "", "PUBLIC")
 
 	l.currentNormalizedTableName = normalizedTableName
 	l.tableOriginalName[normalizedTableName] = originalTableName.GetText()
@@ -187,3 +213,15 @@ func (l *tableRequirePkChecker) ExitAlter_table(*parser.Alter_tableContext) {
 	l.currentNormalizedTableName = ""
 	l.currentConstraintAction = currentConstraintActionNone
 }
+
+// getCatalogTableFind returns the schema and table name in the same way as the catalog walk-through.
+func getCatalogTableFind(objectName parser.IObject_nameContext) catalog.TableFind {
+	find := catalog.TableFind{
+		SchemaName: "PUBLIC",
+		TableName:  snowsqlparser.NormalizeSnowSQLObjectNamePart(objectName.GetO()),
+	}
+	if schema := snowsqlparser.NormalizeSnowSQLObjectNamePart(objectName.GetS()); schema != "" {
+		find.SchemaName = schema
+	}
+	return find
+}
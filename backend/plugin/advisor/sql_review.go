@@ -660,10 +660,15 @@ func SQLReviewCheck(statements string, ruleList []*storepb.SQLReviewRule, checkC
 
 	finder := checkContext.Catalog.GetFinder()
 	switch checkContext.DbType {
-	case storepb.Engine_TIDB, storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_POSTGRES, storepb.Engine_OCEANBASE:
+	case storepb.Engine_TIDB, storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_POSTGRES, storepb.Engine_OCEANBASE, storepb.Engine_MSSQL, storepb.Engine_SNOWFLAKE:
 		if err := finder.WalkThrough(statements); err != nil {
 			return convertWalkThroughErrorToAdvice(checkContext, err)
 		}
+	case storepb.Engine_ORACLE:
+		// The unqualified object names in Oracle belong to the schema of the current user.
+		if err := finder.WalkThroughWithCurrentSchema(statements, checkContext.CurrentSchema); err != nil {
+			return convertWalkThroughErrorToAdvice(checkContext, err)
+		}
 	}
 
 	for _, rule := range ruleList {
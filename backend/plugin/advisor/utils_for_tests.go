@@ -156,11 +156,18 @@ func RunSQLReviewRuleTest(t *testing.T, rule SQLReviewRuleType, dbType storepb.E
 	require.NoError(t, err, rule)
 
 	for i, tc := range tests {
-		database := MockMySQLDatabase
-		if dbType == storepb.Engine_POSTGRES {
-			database = MockPostgreSQLDatabase
+		var finder *catalog.Finder
+		switch dbType {
+		case storepb.Engine_ORACLE, storepb.Engine_MSSQL, storepb.Engine_SNOWFLAKE:
+			// The test cases for these engines don't rely on the mock database, so we walk through without checking integrity.
+			finder = catalog.NewEmptyFinder(&catalog.FinderContext{CheckIntegrity: false, EngineType: dbType})
+		default:
+			database := MockMySQLDatabase
+			if dbType == storepb.Engine_POSTGRES {
+				database = MockPostgreSQLDatabase
+			}
+			finder = catalog.NewFinder(database, &catalog.FinderContext{CheckIntegrity: true, EngineType: dbType})
 		}
-		finder := catalog.NewFinder(database, &catalog.FinderContext{CheckIntegrity: true, EngineType: dbType})
 
 		payload, err := SetDefaultSQLReviewRulePayload(rule, dbType)
 		require.NoError(t, err)
@@ -7,6 +7,8 @@ import (
 	"io"
 	"strings"
 
+	"github.com/pkg/errors"
+
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
 )
 
@@ -84,7 +86,6 @@ func dumpTxn(ctx context.Context, txn *sql.Tx, schemas []string, out io.Writer)
 }
 
 // Restore restores a database.
-func (*Driver) Restore(_ context.Context, _ io.Reader) (err error) {
-	// TODO(d): implement it.
-	return nil
+func (*Driver) Restore(_ context.Context, _ io.Reader) error {
+	return errors.New("restore is not supported for DM")
 }
@@ -14,6 +14,8 @@ import (
 	"unicode"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/bytebase/bytebase/backend/plugin/parser/base"
 	"github.com/bytebase/bytebase/backend/store/model"
@@ -340,6 +342,10 @@ type ConnectionConfig struct {
 	// SchemaTenantMode is the Oracle specific mode.
 	// If true, bytebase will treat the schema as a database.
 	SchemaTenantMode bool
+	// AuthTokenProvider mints a short-lived token as the password of each new connection, which is used by the
+	// cloud IAM database authentication. The Password is the token minted when the driver is opened.
+	// It's only supported for MySQL, PostgreSQL and SQL Server now.
+	AuthTokenProvider func(ctx context.Context) (string, error)
 }
 
 // SSHConfig is the configuration for connection over SSH.
@@ -373,6 +379,13 @@ type QueryContext struct {
 	ShareDB bool
 	// EngineVersion is only for oracle.
 	EngineVersion string
+
+	// RowBatchHandler receives the rows in batches of RowBatchSize while they are read, instead of collecting them in the returned result.
+	// The result passed to the handler has the columns but no rows. The rows are masked in the same way as the returned rows.
+	// It's only honored by the drivers querying through the database/sql connection, and the other drivers return all the rows as usual.
+	RowBatchHandler func(result *v1pb.QueryResult, rows []*v1pb.QueryRow) error
+	// RowBatchSize is the number of rows passed to RowBatchHandler at a time.
+	RowBatchSize int
 }
 
 // DatabaseRoleMessage is the API message for database role.
@@ -469,7 +482,20 @@ func Register(dbType storepb.Engine, f driverFunc) {
 	drivers[dbType] = f
 }
 
+// UnwrapDriver returns the driver of the engine from the driver wrapped by Open or the callers, e.g. to assert its type.
+// The wrappers implement the Unwrap method returning the wrapped driver.
+func UnwrapDriver(driver Driver) Driver {
+	for {
+		wrapper, ok := driver.(interface{ Unwrap() Driver })
+		if !ok {
+			return driver
+		}
+		driver = wrapper.Unwrap()
+	}
+}
+
 // Open opens a database specified by its database driver type and connection config without verifying the connection.
+// The returned driver traces the database operations, and UnwrapDriver returns the driver of the engine.
 func Open(ctx context.Context, dbType storepb.Engine, driverConfig DriverConfig, connectionConfig ConnectionConfig, connCtx ConnectionContext) (Driver, error) {
 	driversMu.RLock()
 	f, ok := drivers[dbType]
@@ -478,16 +504,41 @@ func Open(ctx context.Context, dbType storepb.Engine, driverConfig DriverConfig,
 		return nil, errors.Errorf("db: unknown driver %v", dbType)
 	}
 
+	ctx, span := tracer.Start(ctx, "db.Open", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attribute.String("db.system", strings.ToLower(dbType.String()))))
 	driver, err := f(driverConfig).Open(ctx, dbType, connectionConfig, connCtx)
+	endSpan(span, err)
 	if err != nil {
 		return nil, err
 	}
 
-	return driver, nil
+	return newTracingDriver(driver, dbType, connectionConfig, connCtx), nil
 }
 
 // ExecuteOptions is the options for execute.
 type ExecuteOptions struct {
-	BeginFunc          func(ctx context.Context, conn *sql.Conn) error
-	EndTransactionFunc func(tx *sql.Tx) error
+	BeginFunc func(ctx context.Context, conn *sql.Conn) error
+	// BeginTransactionFunc is called after the transaction begins and before the statements are executed in the transaction.
+	BeginTransactionFunc func(tx *sql.Tx) error
+	EndTransactionFunc   func(tx *sql.Tx) error
+	// LockTimeout is the maximum time a statement waits for a lock. Zero means the database default.
+	LockTimeout time.Duration
+	// StatementTimeout is the maximum execution time of a statement. Zero means the database default.
+	StatementTimeout time.Duration
+	// LockTimeoutRetries is the max number of retries if the execution fails with LockTimeoutError.
+	// The drivers don't retry, and it's up to the caller.
+	LockTimeoutRetries int
+}
+
+// LockTimeoutError is returned by Execute if a statement fails to acquire a lock in ExecuteOptions.LockTimeout
+// and nothing has been committed, so the execution can be retried.
+type LockTimeoutError struct {
+	Err error
+}
+
+func (e *LockTimeoutError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LockTimeoutError) Unwrap() error {
+	return e.Err
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/bytebase/bytebase/backend/store/model"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
 func TestParseMigrationInfo(t *testing.T) {
@@ -320,3 +321,22 @@ func TestParseSchemaFileInfo(t *testing.T) {
 		})
 	}
 }
+
+type fakeWrappingDriver struct {
+	Driver
+}
+
+func (d *fakeWrappingDriver) Unwrap() Driver {
+	return d.Driver
+}
+
+func TestUnwrapDriver(t *testing.T) {
+	a := require.New(t)
+	fake := &fakePingDriver{}
+	driver := newTracingDriver(fake, storepb.Engine_MYSQL, ConnectionConfig{}, ConnectionContext{})
+
+	// The driver of the engine is unwrapped from all the wrappers.
+	a.Same(fake, UnwrapDriver(driver))
+	a.Same(fake, UnwrapDriver(&fakeWrappingDriver{Driver: driver}))
+	a.Same(fake, UnwrapDriver(fake))
+}
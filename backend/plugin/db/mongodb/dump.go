@@ -0,0 +1,472 @@
+package mongodb
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Dump writes two formats:
+//   - The schema-only dump is a mongosh script creating the collections, indexes, validators and views with the commands
+//     in canonical extended JSON. It's plain text because it is stored in the TEXT columns and parsed as the database schema.
+//   - The backup archive is a stream of BSON documents, each of them is a dumpRecord. It's only written to the backup files
+//     and must never be stored in the TEXT columns.
+//
+// The archive records are written in the order of header, collections, documents, indexes and views,
+// so that the restore can replay them one by one:
+//  1. create the collections with their options such as validator and collation,
+//  2. insert the documents in batches,
+//  3. build the indexes after the data is loaded,
+//  4. create the views which may depend on the collections.
+const (
+	dumpFormatVersion = 1
+
+	dumpRecordTypeHeader     = "header"
+	dumpRecordTypeCollection = "collection"
+	dumpRecordTypeDocument   = "document"
+	dumpRecordTypeIndex      = "index"
+	dumpRecordTypeView       = "view"
+
+	// restoreBatchSize is the maximum number of documents in one insert batch.
+	restoreBatchSize = 1000
+	// restoreBatchBytes is the maximum total size of documents in one insert batch,
+	// it's lower than the 48MB message size limit of MongoDB.
+	restoreBatchBytes = 16 * 1024 * 1024
+)
+
+type dumpRecord struct {
+	Type       string   `bson:"type"`
+	Version    int      `bson:"version,omitempty"`
+	Database   string   `bson:"database,omitempty"`
+	Collection string   `bson:"collection,omitempty"`
+	Options    bson.Raw `bson:"options,omitempty"`
+	Index      bson.Raw `bson:"index,omitempty"`
+	Document   bson.Raw `bson:"document,omitempty"`
+}
+
+type collectionSpecification struct {
+	Name    string   `bson:"name"`
+	Type    string   `bson:"type"`
+	Options bson.Raw `bson:"options"`
+}
+
+// archiveDatabase is the database read by the dump and written by the restore.
+// It's implemented by mongoDatabase, and by the in-memory database in the tests.
+type archiveDatabase interface {
+	// listCollections returns the user collections and views sorted by name.
+	listCollections(ctx context.Context) ([]*collectionSpecification, error)
+	// listIndexes returns the index specifications of the collection except the _id index.
+	listIndexes(ctx context.Context, collection string) ([]bson.Raw, error)
+	findDocuments(ctx context.Context, collection string, fn func(document bson.Raw) error) error
+	countDocuments(ctx context.Context, collection string) (int64, error)
+	dropCollection(ctx context.Context, collection string) error
+	runCommand(ctx context.Context, command bson.D) error
+	insertDocuments(ctx context.Context, collection string, documents []any) error
+}
+
+// Dump dumps the database.
+// The schema-only dump is a mongosh script of the collections, indexes, validators and views,
+// otherwise it's a BSON archive including the documents, which is only used by the backup.
+func (driver *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool) (string, error) {
+	database := &mongoDatabase{database: driver.client.Database(driver.databaseName)}
+	if schemaOnly {
+		return "", dumpSchema(ctx, database, out)
+	}
+	return "", dumpArchive(ctx, database, driver.databaseName, out)
+}
+
+// dumpSchema writes the mongosh script creating the collections, indexes, validators and views.
+// The commands are written in canonical extended JSON and deserialized by EJSON, so the BSON types are kept.
+func dumpSchema(ctx context.Context, database archiveDatabase, out io.Writer) error {
+	specs, err := database.listCollections(ctx)
+	if err != nil {
+		return err
+	}
+	collections, views := splitViews(specs)
+	for _, collection := range collections {
+		if err := writeSchemaCommand(out, buildCreateCommand(collection.Name, collection.Options)); err != nil {
+			return err
+		}
+	}
+	for _, collection := range collections {
+		indexes, err := database.listIndexes(ctx, collection.Name)
+		if err != nil {
+			return err
+		}
+		if len(indexes) == 0 {
+			continue
+		}
+		var specs bson.A
+		for _, index := range indexes {
+			spec, err := buildIndexSpec(index)
+			if err != nil {
+				return err
+			}
+			specs = append(specs, spec)
+		}
+		if err := writeSchemaCommand(out, bson.D{{Key: "createIndexes", Value: collection.Name}, {Key: "indexes", Value: specs}}); err != nil {
+			return err
+		}
+	}
+	for _, view := range views {
+		if err := writeSchemaCommand(out, buildCreateCommand(view.Name, view.Options)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeSchemaCommand(out io.Writer, command bson.D) error {
+	data, err := bson.MarshalExtJSON(command, true /* canonical */, false /* escapeHTML */)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal command to extended JSON")
+	}
+	if _, err := fmt.Fprintf(out, "db.runCommand(EJSON.deserialize(%s));\n", data); err != nil {
+		return errors.Wrap(err, "failed to write command")
+	}
+	return nil
+}
+
+// dumpArchive writes the BSON archive of the collections, documents, indexes, validators and views.
+func dumpArchive(ctx context.Context, database archiveDatabase, databaseName string, out io.Writer) error {
+	specs, err := database.listCollections(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := writeDumpRecord(out, &dumpRecord{Type: dumpRecordTypeHeader, Version: dumpFormatVersion, Database: databaseName}); err != nil {
+		return err
+	}
+
+	collections, views := splitViews(specs)
+	for _, collection := range collections {
+		if err := writeDumpRecord(out, &dumpRecord{Type: dumpRecordTypeCollection, Collection: collection.Name, Options: collection.Options}); err != nil {
+			return err
+		}
+	}
+	for _, collection := range collections {
+		if err := dumpDocuments(ctx, database, collection.Name, out); err != nil {
+			return err
+		}
+	}
+	for _, collection := range collections {
+		indexes, err := database.listIndexes(ctx, collection.Name)
+		if err != nil {
+			return err
+		}
+		for _, index := range indexes {
+			if err := writeDumpRecord(out, &dumpRecord{Type: dumpRecordTypeIndex, Collection: collection.Name, Index: index}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, view := range views {
+		if err := writeDumpRecord(out, &dumpRecord{Type: dumpRecordTypeView, Collection: view.Name, Options: view.Options}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitViews(specs []*collectionSpecification) ([]*collectionSpecification, []*collectionSpecification) {
+	var collections, views []*collectionSpecification
+	for _, spec := range specs {
+		if spec.Type == "view" {
+			views = append(views, spec)
+		} else {
+			collections = append(collections, spec)
+		}
+	}
+	return collections, views
+}
+
+// mongoDatabase implements archiveDatabase with the MongoDB driver.
+type mongoDatabase struct {
+	database *mongo.Database
+}
+
+func (d *mongoDatabase) listCollections(ctx context.Context) ([]*collectionSpecification, error) {
+	cursor, err := d.database.ListCollections(ctx, bson.D{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list collections")
+	}
+	defer cursor.Close(ctx)
+
+	var specs []*collectionSpecification
+	for cursor.Next(ctx) {
+		var spec collectionSpecification
+		if err := cursor.Decode(&spec); err != nil {
+			return nil, errors.Wrap(err, "failed to decode collection specification")
+		}
+		// The system collections such as system.views and system.buckets.* are maintained by MongoDB.
+		if strings.HasPrefix(spec.Name, "system.") {
+			continue
+		}
+		specs = append(specs, &spec)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to list collections")
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+	return specs, nil
+}
+
+func (d *mongoDatabase) listIndexes(ctx context.Context, collection string) ([]bson.Raw, error) {
+	cursor, err := d.database.Collection(collection).Indexes().List(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list indexes of collection %q", collection)
+	}
+	defer cursor.Close(ctx)
+
+	var indexes []bson.Raw
+	for cursor.Next(ctx) {
+		// The _id index is created along with the collection.
+		if name, ok := cursor.Current.Lookup("name").StringValueOK(); ok && name == "_id_" {
+			continue
+		}
+		indexes = append(indexes, append(bson.Raw(nil), cursor.Current...))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to list indexes of collection %q", collection)
+	}
+	return indexes, nil
+}
+
+func (d *mongoDatabase) findDocuments(ctx context.Context, collection string, fn func(document bson.Raw) error) error {
+	cursor, err := d.database.Collection(collection).Find(ctx, bson.D{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to find documents in collection %q", collection)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		if err := fn(cursor.Current); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return errors.Wrapf(err, "failed to find documents in collection %q", collection)
+	}
+	return nil
+}
+
+func (d *mongoDatabase) countDocuments(ctx context.Context, collection string) (int64, error) {
+	count, err := d.database.Collection(collection).CountDocuments(ctx, bson.D{}, options.Count().SetLimit(1))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to count documents in collection %q", collection)
+	}
+	return count, nil
+}
+
+func (d *mongoDatabase) dropCollection(ctx context.Context, collection string) error {
+	if err := d.database.Collection(collection).Drop(ctx); err != nil {
+		return errors.Wrapf(err, "failed to drop collection %q", collection)
+	}
+	return nil
+}
+
+func (d *mongoDatabase) runCommand(ctx context.Context, command bson.D) error {
+	return d.database.RunCommand(ctx, command).Err()
+}
+
+func (d *mongoDatabase) insertDocuments(ctx context.Context, collection string, documents []any) error {
+	// The documents were accepted by the source database, but the validator may be changed after they were inserted,
+	// so we bypass the document validation to restore them as they are.
+	if _, err := d.database.Collection(collection).InsertMany(ctx, documents, options.InsertMany().SetBypassDocumentValidation(true)); err != nil {
+		return errors.Wrapf(err, "failed to insert documents into collection %q", collection)
+	}
+	return nil
+}
+
+func dumpDocuments(ctx context.Context, database archiveDatabase, collection string, out io.Writer) error {
+	count := 0
+	if err := database.findDocuments(ctx, collection, func(document bson.Raw) error {
+		count++
+		return writeDumpRecord(out, &dumpRecord{Type: dumpRecordTypeDocument, Collection: collection, Document: document})
+	}); err != nil {
+		return err
+	}
+	slog.Debug("dumped collection documents", slog.String("collection", collection), slog.Int("count", count))
+	return nil
+}
+
+func writeDumpRecord(out io.Writer, record *dumpRecord) error {
+	data, err := bson.Marshal(record)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s record", record.Type)
+	}
+	if _, err := out.Write(data); err != nil {
+		return errors.Wrapf(err, "failed to write %s record", record.Type)
+	}
+	return nil
+}
+
+// readDumpRecord reads the next record from the archive, it returns io.EOF at the end of the archive.
+func readDumpRecord(src io.Reader) (*dumpRecord, error) {
+	raw, err := bson.NewFromIOReader(src)
+	if err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, errors.Wrap(err, "failed to read record from the archive")
+	}
+	var record dumpRecord
+	if err := bson.Unmarshal(raw, &record); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal record")
+	}
+	return &record, nil
+}
+
+// Restore restores the database from the backup archive generated by Dump.
+func (driver *Driver) Restore(ctx context.Context, src io.Reader) error {
+	return restoreArchive(ctx, &mongoDatabase{database: driver.client.Database(driver.databaseName)}, src)
+}
+
+func restoreArchive(ctx context.Context, database archiveDatabase, src io.Reader) error {
+	specs, err := database.listCollections(ctx)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, spec := range specs {
+		existing[spec.Name] = true
+	}
+
+	reader := bufio.NewReader(src)
+	batch := &insertBatch{}
+	for {
+		record, err := readDumpRecord(reader)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		if record.Type != dumpRecordTypeDocument {
+			if err := batch.flush(ctx, database); err != nil {
+				return err
+			}
+		}
+
+		switch record.Type {
+		case dumpRecordTypeHeader:
+			if record.Version > dumpFormatVersion {
+				return errors.Errorf("unsupported dump format version %d", record.Version)
+			}
+		case dumpRecordTypeCollection, dumpRecordTypeView:
+			if existing[record.Collection] {
+				if err := dropEmptyCollection(ctx, database, record.Collection); err != nil {
+					return err
+				}
+			}
+			if err := database.runCommand(ctx, buildCreateCommand(record.Collection, record.Options)); err != nil {
+				return errors.Wrapf(err, "failed to create %s %q", record.Type, record.Collection)
+			}
+		case dumpRecordTypeDocument:
+			if err := batch.add(ctx, database, record.Collection, record.Document); err != nil {
+				return err
+			}
+		case dumpRecordTypeIndex:
+			spec, err := buildIndexSpec(record.Index)
+			if err != nil {
+				return err
+			}
+			if err := database.runCommand(ctx, bson.D{
+				{Key: "createIndexes", Value: record.Collection},
+				{Key: "indexes", Value: bson.A{spec}},
+			}); err != nil {
+				return errors.Wrapf(err, "failed to create index on collection %q", record.Collection)
+			}
+		default:
+			return errors.Errorf("unknown record type %q", record.Type)
+		}
+	}
+	return batch.flush(ctx, database)
+}
+
+// dropEmptyCollection drops the existing collection before the restore creates it again.
+// The database creation creates an empty collection for MongoDB, we refuse to touch the collection having data.
+func dropEmptyCollection(ctx context.Context, database archiveDatabase, collection string) error {
+	count, err := database.countDocuments(ctx, collection)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.Errorf("collection %q already exists and is not empty", collection)
+	}
+	return database.dropCollection(ctx, collection)
+}
+
+// buildCreateCommand builds the create command of the collection or view with the options from listCollections.
+func buildCreateCommand(name string, opts bson.Raw) bson.D {
+	command := bson.D{{Key: "create", Value: name}}
+	elements, _ := opts.Elements()
+	for _, element := range elements {
+		command = append(command, bson.E{Key: element.Key(), Value: element.Value()})
+	}
+	return command
+}
+
+// buildIndexSpec builds the index specification for createIndexes from the output of listIndexes.
+func buildIndexSpec(index bson.Raw) (bson.D, error) {
+	elements, err := index.Elements()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse index specification")
+	}
+	var spec bson.D
+	for _, element := range elements {
+		// The namespace field is returned by the old MongoDB versions and rejected by createIndexes.
+		if element.Key() == "ns" {
+			continue
+		}
+		spec = append(spec, bson.E{Key: element.Key(), Value: element.Value()})
+	}
+	return spec, nil
+}
+
+type insertBatch struct {
+	collection string
+	documents  []any
+	size       int
+	total      int
+}
+
+func (b *insertBatch) add(ctx context.Context, database archiveDatabase, collection string, document bson.Raw) error {
+	if collection != b.collection || len(b.documents) >= restoreBatchSize || b.size+len(document) > restoreBatchBytes {
+		if err := b.flush(ctx, database); err != nil {
+			return err
+		}
+	}
+	if collection != b.collection {
+		b.collection = collection
+		b.total = 0
+	}
+	b.documents = append(b.documents, document)
+	b.size += len(document)
+	return nil
+}
+
+func (b *insertBatch) flush(ctx context.Context, database archiveDatabase) error {
+	if len(b.documents) == 0 {
+		return nil
+	}
+	if err := database.insertDocuments(ctx, b.collection, b.documents); err != nil {
+		return err
+	}
+	b.total += len(b.documents)
+	slog.Debug("restored collection documents", slog.String("collection", b.collection), slog.Int("total", b.total))
+	b.documents = nil
+	b.size = 0
+	return nil
+}
@@ -0,0 +1,238 @@
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestDumpRecordRoundTrip(t *testing.T) {
+	a := require.New(t)
+	document, err := bson.Marshal(bson.D{{Key: "_id", Value: 1}, {Key: "name", Value: "bytebase"}})
+	a.NoError(err)
+	records := []*dumpRecord{
+		{Type: dumpRecordTypeHeader, Version: dumpFormatVersion, Database: "db"},
+		{Type: dumpRecordTypeCollection, Collection: "users"},
+		{Type: dumpRecordTypeDocument, Collection: "users", Document: document},
+	}
+
+	var buf bytes.Buffer
+	for _, record := range records {
+		a.NoError(writeDumpRecord(&buf, record))
+	}
+	for _, want := range records {
+		got, err := readDumpRecord(&buf)
+		a.NoError(err)
+		a.Equal(want.Type, got.Type)
+		a.Equal(want.Collection, got.Collection)
+		a.Equal(want.Database, got.Database)
+		a.Equal([]byte(want.Document), []byte(got.Document))
+	}
+	_, err = readDumpRecord(&buf)
+	a.Equal(io.EOF, err)
+}
+
+func TestBuildCreateCommand(t *testing.T) {
+	a := require.New(t)
+	opts, err := bson.Marshal(bson.D{
+		{Key: "viewOn", Value: "users"},
+		{Key: "pipeline", Value: bson.A{bson.D{{Key: "$match", Value: bson.D{{Key: "active", Value: true}}}}}},
+	})
+	a.NoError(err)
+
+	command := buildCreateCommand("active_users", opts)
+	got, err := bson.MarshalExtJSON(command, true, false)
+	a.NoError(err)
+	a.Equal(`{"create":"active_users","viewOn":"users","pipeline":[{"$match":{"active":true}}]}`, string(got))
+
+	command = buildCreateCommand("users", nil)
+	got, err = bson.MarshalExtJSON(command, true, false)
+	a.NoError(err)
+	a.Equal(`{"create":"users"}`, string(got))
+}
+
+func TestBuildIndexSpec(t *testing.T) {
+	a := require.New(t)
+	index, err := bson.Marshal(bson.D{
+		{Key: "v", Value: 2},
+		{Key: "key", Value: bson.D{{Key: "email", Value: 1}}},
+		{Key: "name", Value: "email_1"},
+		{Key: "ns", Value: "db.users"},
+		{Key: "unique", Value: true},
+	})
+	a.NoError(err)
+
+	spec, err := buildIndexSpec(index)
+	a.NoError(err)
+	got, err := bson.MarshalExtJSON(spec, true, false)
+	a.NoError(err)
+	a.Equal(`{"v":{"$numberInt":"2"},"key":{"email":{"$numberInt":"1"}},"name":"email_1","unique":true}`, string(got))
+}
+
+func TestDumpRestoreRoundTrip(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	source := newMemoryDatabase()
+	validator := bson.D{{Key: "$jsonSchema", Value: bson.D{{Key: "required", Value: bson.A{"email"}}}}}
+	a.NoError(source.runCommand(ctx, bson.D{{Key: "create", Value: "users"}, {Key: "validator", Value: validator}, {Key: "validationLevel", Value: "strict"}}))
+	a.NoError(source.runCommand(ctx, bson.D{{Key: "create", Value: "orders"}}))
+	a.NoError(source.runCommand(ctx, bson.D{
+		{Key: "createIndexes", Value: "users"},
+		{Key: "indexes", Value: bson.A{bson.D{{Key: "v", Value: int32(2)}, {Key: "key", Value: bson.D{{Key: "email", Value: int32(1)}}}, {Key: "name", Value: "email_1"}, {Key: "unique", Value: true}}}},
+	}))
+	a.NoError(source.runCommand(ctx, bson.D{
+		{Key: "create", Value: "active_users"},
+		{Key: "viewOn", Value: "users"},
+		{Key: "pipeline", Value: bson.A{bson.D{{Key: "$match", Value: bson.D{{Key: "active", Value: true}}}}}},
+	}))
+	var documents []any
+	for i := 0; i < restoreBatchSize+1; i++ {
+		documents = append(documents, bson.D{{Key: "_id", Value: int32(i)}, {Key: "email", Value: "a\x00b"}})
+	}
+	a.NoError(source.insertDocuments(ctx, "users", documents))
+	a.NoError(source.insertDocuments(ctx, "orders", []any{bson.D{{Key: "_id", Value: "o1"}, {Key: "total", Value: 1.5}}}))
+
+	var archive bytes.Buffer
+	a.NoError(dumpArchive(ctx, source, "db", &archive))
+
+	// The database creation leaves an empty collection, which is replaced by the restore.
+	target := newMemoryDatabase()
+	a.NoError(target.runCommand(ctx, bson.D{{Key: "create", Value: "users"}}))
+	a.NoError(restoreArchive(ctx, target, &archive))
+
+	sourceSpecs, err := source.listCollections(ctx)
+	a.NoError(err)
+	targetSpecs, err := target.listCollections(ctx)
+	a.NoError(err)
+	a.Equal(sourceSpecs, targetSpecs)
+	for _, spec := range sourceSpecs {
+		a.Equal(source.collections[spec.Name].indexes, target.collections[spec.Name].indexes, spec.Name)
+		a.Equal(source.collections[spec.Name].documents, target.collections[spec.Name].documents, spec.Name)
+	}
+
+	// The restore refuses to replace the existing collection having data.
+	archive.Reset()
+	a.NoError(dumpArchive(ctx, source, "db", &archive))
+	a.ErrorContains(restoreArchive(ctx, target, &archive), "already exists and is not empty")
+}
+
+func TestDumpSchemaIsText(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	database := newMemoryDatabase()
+	a.NoError(database.runCommand(ctx, bson.D{{Key: "create", Value: "users"}, {Key: "validator", Value: bson.D{{Key: "age", Value: bson.D{{Key: "$gte", Value: int32(18)}}}}}}))
+	a.NoError(database.runCommand(ctx, bson.D{
+		{Key: "createIndexes", Value: "users"},
+		{Key: "indexes", Value: bson.A{bson.D{{Key: "key", Value: bson.D{{Key: "name", Value: int32(1)}}}, {Key: "name", Value: "name_1"}}}},
+	}))
+	a.NoError(database.runCommand(ctx, bson.D{{Key: "create", Value: "adults"}, {Key: "viewOn", Value: "users"}, {Key: "pipeline", Value: bson.A{}}}))
+	a.NoError(database.insertDocuments(ctx, "users", []any{bson.D{{Key: "_id", Value: int32(1)}}}))
+
+	var buf bytes.Buffer
+	a.NoError(dumpSchema(ctx, database, &buf))
+	got := buf.String()
+	a.True(utf8.ValidString(got))
+	a.NotContains(got, "\x00")
+	a.Equal(`db.runCommand(EJSON.deserialize({"create":"users","validator":{"age":{"$gte":{"$numberInt":"18"}}}}));
+db.runCommand(EJSON.deserialize({"createIndexes":"users","indexes":[{"key":{"name":{"$numberInt":"1"}},"name":"name_1"}]}));
+db.runCommand(EJSON.deserialize({"create":"adults","viewOn":"users","pipeline":[]}));
+`, got)
+}
+
+// memoryDatabase is the in-memory archiveDatabase interpreting the create and createIndexes commands.
+type memoryDatabase struct {
+	collections map[string]*memoryCollection
+}
+
+type memoryCollection struct {
+	spec      *collectionSpecification
+	indexes   []bson.Raw
+	documents []bson.Raw
+}
+
+func newMemoryDatabase() *memoryDatabase {
+	return &memoryDatabase{collections: make(map[string]*memoryCollection)}
+}
+
+func (d *memoryDatabase) listCollections(context.Context) ([]*collectionSpecification, error) {
+	var specs []*collectionSpecification
+	for _, collection := range d.collections {
+		specs = append(specs, collection.spec)
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+	return specs, nil
+}
+
+func (d *memoryDatabase) listIndexes(_ context.Context, collection string) ([]bson.Raw, error) {
+	return d.collections[collection].indexes, nil
+}
+
+func (d *memoryDatabase) findDocuments(_ context.Context, collection string, fn func(document bson.Raw) error) error {
+	for _, document := range d.collections[collection].documents {
+		if err := fn(document); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *memoryDatabase) countDocuments(_ context.Context, collection string) (int64, error) {
+	return int64(len(d.collections[collection].documents)), nil
+}
+
+func (d *memoryDatabase) dropCollection(_ context.Context, collection string) error {
+	delete(d.collections, collection)
+	return nil
+}
+
+func (d *memoryDatabase) runCommand(_ context.Context, command bson.D) error {
+	switch command[0].Key {
+	case "create":
+		name := command[0].Value.(string)
+		if _, ok := d.collections[name]; ok {
+			return errors.Errorf("collection %q already exists", name)
+		}
+		options, err := bson.Marshal(command[1:])
+		if err != nil {
+			return err
+		}
+		spec := &collectionSpecification{Name: name, Type: "collection", Options: options}
+		if strings.Contains(string(options), "viewOn") {
+			spec.Type = "view"
+		}
+		d.collections[name] = &memoryCollection{spec: spec}
+	case "createIndexes":
+		collection := d.collections[command[0].Value.(string)]
+		for _, index := range command[1].Value.(bson.A) {
+			spec, err := bson.Marshal(index)
+			if err != nil {
+				return err
+			}
+			collection.indexes = append(collection.indexes, spec)
+		}
+	default:
+		return errors.Errorf("unsupported command %q", command[0].Key)
+	}
+	return nil
+}
+
+func (d *memoryDatabase) insertDocuments(_ context.Context, collection string, documents []any) error {
+	for _, document := range documents {
+		data, err := bson.Marshal(document)
+		if err != nil {
+			return err
+		}
+		d.collections[collection].documents = append(d.collections[collection].documents, data)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package mongodb
+
+import (
+	"regexp"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+var (
+	// collectionRegexp matches the collection of the statements like db.collection.find() and db.getCollection("collection").find().
+	collectionRegexp = regexp.MustCompile(`^\s*db\s*\.\s*(?:getCollection\s*\(\s*["']([^"']+)["']\s*\)|([A-Za-z_$][\w$]*))`)
+)
+
+// getCollectionName returns the collection name the simple statement queries, it returns an empty string if not found.
+func getCollectionName(statement string) string {
+	matches := collectionRegexp.FindStringSubmatch(statement)
+	if len(matches) == 0 {
+		return ""
+	}
+	if matches[1] != "" {
+		return matches[1]
+	}
+	return matches[2]
+}
+
+// maskSimpleStatementResult masks the top-level fields of the collection with the maskers in the sensitive schema info.
+func maskSimpleStatementResult(result *v1pb.QueryResult, databaseName, statement string, queryContext *db.QueryContext) {
+	if queryContext == nil || !queryContext.EnableSensitive {
+		return
+	}
+	collection := getCollectionName(statement)
+	if collection == "" {
+		return
+	}
+	// MongoDB has no schema, so the collections are in the schema with empty name.
+	table := queryContext.SensitiveSchemaInfo.FindTable(databaseName, "", collection)
+	if table == nil {
+		return
+	}
+	for _, column := range table.ColumnList {
+		m := column.MaskingAttributes.GetMasker()
+		for i, columnName := range result.ColumnNames {
+			if columnName != column.Name {
+				continue
+			}
+			for _, row := range result.Rows {
+				if i < len(row.Values) {
+					row.Values[i] = m.Mask(row.Values[i])
+				}
+			}
+		}
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/url"
 	"os"
@@ -129,16 +128,6 @@ func (driver *Driver) Execute(ctx context.Context, statement string, _ bool, _ d
 	return 0, nil
 }
 
-// Dump dumps the database.
-func (*Driver) Dump(_ context.Context, _ io.Writer, _ bool) (string, error) {
-	return "", nil
-}
-
-// Restore restores the backup read from src.
-func (*Driver) Restore(_ context.Context, _ io.Reader) error {
-	panic("not implemented")
-}
-
 // getMongoDBConnectionURI returns the MongoDB connection URI.
 // https://www.mongodb.com/docs/manual/reference/connection-string/
 func getMongoDBConnectionURI(connConfig db.ConnectionConfig) string {
@@ -209,6 +198,7 @@ func (driver *Driver) QueryConn(ctx context.Context, _ *sql.Conn, statement stri
 		if err != nil {
 			slog.Error("failed to get simple statement result", slog.String("content", outContent.String()), log.BBError(err))
 		} else {
+			maskSimpleStatementResult(result, driver.databaseName, statement, queryContext)
 			result.Latency = durationpb.New(time.Since(startTime))
 			result.Statement = statement
 			return []*v1pb.QueryResult{result}, nil
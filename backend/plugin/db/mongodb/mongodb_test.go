@@ -105,6 +105,32 @@ func TestIsMongoStatement(t *testing.T) {
 	}
 }
 
+func TestGetCollectionName(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      string
+	}{
+		{
+			statement: `show collections`,
+			want:      "",
+		},
+		{
+			statement: `db.cpl_station_info.find().limit(100)`,
+			want:      "cpl_station_info",
+		},
+		{
+			statement: ` db.getCollection("user-info").find({})`,
+			want:      "user-info",
+		},
+	}
+
+	a := require.New(t)
+	for _, tt := range tests {
+		got := getCollectionName(tt.statement)
+		a.Equal(tt.want, got, tt.statement)
+	}
+}
+
 func TestGetSimpleStatementResult(t *testing.T) {
 	groupsValue := `[
 	"basketball",
@@ -1,18 +1,859 @@
 package mssql
 
 import (
+	"bufio"
 	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
 	"io"
+	"log/slog"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// batchSeparator separates the batches in the dump, the same as sqlcmd and SSMS.
+	// CREATE VIEW, PROCEDURE, FUNCTION and TRIGGER must be the only statement in a batch.
+	batchSeparator = "GO"
+	// dumpInsertBatchSize is the number of rows in one INSERT statement, T-SQL allows at most 1000 rows in a VALUES clause.
+	dumpInsertBatchSize = 100
+	// restoreTxnBatchSize is the number of INSERT batches committed in one transaction when restoring.
+	restoreTxnBatchSize = 100
 )
 
-// Dump dumps the database.
-func (*Driver) Dump(_ context.Context, _ io.Writer, _ bool) (string, error) {
-	// TODO(d): implement it.
+type dumpColumn struct {
+	name               string
+	typeName           string
+	typeSchema         string
+	baseTypeName       string
+	maxLength          int
+	precision          int
+	scale              int
+	nullable           bool
+	userDefined        bool
+	assemblyType       bool
+	collation          sql.NullString
+	identitySeed       sql.NullString
+	identityIncrement  sql.NullString
+	computedDefinition sql.NullString
+	persisted          bool
+	defaultName        sql.NullString
+	defaultDefinition  sql.NullString
+}
+
+type dumpIndex struct {
+	name             string
+	typeDesc         string
+	primary          bool
+	unique           bool
+	uniqueConstraint bool
+	filter           sql.NullString
+	columns          []string
+	includedColumns  []string
+}
+
+type dumpTable struct {
+	schema     string
+	name       string
+	columns    []*dumpColumn
+	primaryKey *dumpIndex
+	indexes    []*dumpIndex
+}
+
+// Dump dumps the schema objects of the database, and the data as batched INSERT statements unless schemaOnly is set.
+// The batches in the dump are separated by GO, so the dump can also be replayed by sqlcmd.
+func (driver *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool) (string, error) {
+	txn, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer txn.Rollback()
+
+	if err := dumpTxn(ctx, txn, out, schemaOnly); err != nil {
+		return "", errors.Wrapf(err, "failed to dump database %q", driver.databaseName)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return "", err
+	}
 	return "", nil
 }
 
-// Restore restores a database.
-func (*Driver) Restore(_ context.Context, _ io.Reader) (err error) {
-	// TODO(d): implement it.
+func dumpTxn(ctx context.Context, txn *sql.Tx, out io.Writer, schemaOnly bool) error {
+	schemas, err := getSchemas(txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get schemas")
+	}
+	for _, schema := range schemas {
+		if schema == "dbo" {
+			continue
+		}
+		if err := writeBatch(out, fmt.Sprintf("CREATE SCHEMA %s;", quoteIdentifier(schema))); err != nil {
+			return err
+		}
+	}
+	if err := dumpAliasTypes(ctx, txn, out); err != nil {
+		return errors.Wrap(err, "failed to dump types")
+	}
+	if err := dumpSequences(ctx, txn, out, schemaOnly); err != nil {
+		return errors.Wrap(err, "failed to dump sequences")
+	}
+	tables, err := getDumpTables(ctx, txn)
+	if err != nil {
+		return errors.Wrap(err, "failed to get tables")
+	}
+	for _, table := range tables {
+		if err := writeBatch(out, table.createStatement()); err != nil {
+			return err
+		}
+	}
+	if !schemaOnly {
+		for _, table := range tables {
+			if err := dumpTableData(ctx, txn, table, out); err != nil {
+				return errors.Wrapf(err, "failed to dump data of table %s.%s", table.schema, table.name)
+			}
+		}
+	}
+	// Create the indexes and constraints after loading the data, so that the restore is faster and
+	// doesn't depend on the order of the tables.
+	for _, table := range tables {
+		for _, index := range table.indexes {
+			if err := writeBatch(out, index.createStatement(table)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := dumpCheckConstraints(ctx, txn, out); err != nil {
+		return errors.Wrap(err, "failed to dump check constraints")
+	}
+	if err := dumpForeignKeys(ctx, txn, out); err != nil {
+		return errors.Wrap(err, "failed to dump foreign keys")
+	}
+	// The triggers are created after loading the data, otherwise they would fire on the restored rows.
+	if err := dumpModules(ctx, txn, out); err != nil {
+		return errors.Wrap(err, "failed to dump views, functions, procedures and triggers")
+	}
+	return nil
+}
+
+func dumpAliasTypes(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	query := `
+		SELECT
+			SCHEMA_NAME(t.schema_id),
+			t.name,
+			bt.name,
+			t.max_length,
+			t.precision,
+			t.scale,
+			t.is_nullable
+		FROM sys.types t
+		INNER JOIN sys.types bt ON t.system_type_id = bt.user_type_id
+		WHERE t.is_user_defined = 1 AND t.is_table_type = 0 AND t.is_assembly_type = 0
+		ORDER BY 1, 2;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var statements []string
+	for rows.Next() {
+		var schema, name string
+		column := &dumpColumn{}
+		if err := rows.Scan(&schema, &name, &column.typeName, &column.maxLength, &column.precision, &column.scale, &column.nullable); err != nil {
+			return err
+		}
+		nullable := "NOT NULL"
+		if column.nullable {
+			nullable = "NULL"
+		}
+		statements = append(statements, fmt.Sprintf("CREATE TYPE %s.%s FROM %s %s;", quoteIdentifier(schema), quoteIdentifier(name), column.formatType(), nullable))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if err := writeBatch(out, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dumpSequences(ctx context.Context, txn *sql.Tx, out io.Writer, schemaOnly bool) error {
+	query := `
+		SELECT
+			SCHEMA_NAME(s.schema_id),
+			s.name,
+			TYPE_NAME(s.user_type_id),
+			CAST(s.start_value AS NVARCHAR(40)),
+			CAST(s.increment AS NVARCHAR(40)),
+			CAST(s.minimum_value AS NVARCHAR(40)),
+			CAST(s.maximum_value AS NVARCHAR(40)),
+			s.is_cycling,
+			CAST(s.current_value AS NVARCHAR(40))
+		FROM sys.sequences s
+		ORDER BY 1, 2;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var statements []string
+	for rows.Next() {
+		var schema, name, typeName, start, increment, minimum, maximum, current string
+		var cycling bool
+		if err := rows.Scan(&schema, &name, &typeName, &start, &increment, &minimum, &maximum, &cycling, &current); err != nil {
+			return err
+		}
+		cycle := "NO CYCLE"
+		if cycling {
+			cycle = "CYCLE"
+		}
+		fullName := fmt.Sprintf("%s.%s", quoteIdentifier(schema), quoteIdentifier(name))
+		statements = append(statements, fmt.Sprintf("CREATE SEQUENCE %s AS %s START WITH %s INCREMENT BY %s MINVALUE %s MAXVALUE %s %s;", fullName, typeName, start, increment, minimum, maximum, cycle))
+		// current_value equals to start_value before the sequence is used, otherwise it's the last generated value.
+		if !schemaOnly && current != start {
+			restart, err := addIntegerString(current, increment)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get the next value of sequence %s.%s", schema, name)
+			}
+			statements = append(statements, fmt.Sprintf("ALTER SEQUENCE %s RESTART WITH %s;", fullName, restart))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if err := writeBatch(out, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addIntegerString adds two integers in string, the sequence values may exceed the range of int64.
+func addIntegerString(a, b string) (string, error) {
+	x, ok := new(big.Int).SetString(a, 10)
+	if !ok {
+		return "", errors.Errorf("invalid integer %q", a)
+	}
+	y, ok := new(big.Int).SetString(b, 10)
+	if !ok {
+		return "", errors.Errorf("invalid integer %q", b)
+	}
+	return x.Add(x, y).String(), nil
+}
+
+func getDumpTables(ctx context.Context, txn *sql.Tx) ([]*dumpTable, error) {
+	query := `
+		SELECT
+			s.name,
+			t.name,
+			c.name,
+			ty.name,
+			SCHEMA_NAME(ty.schema_id),
+			TYPE_NAME(c.system_type_id),
+			c.max_length,
+			c.precision,
+			c.scale,
+			c.is_nullable,
+			ty.is_user_defined,
+			ty.is_assembly_type,
+			c.collation_name,
+			CAST(ic.seed_value AS NVARCHAR(40)),
+			CAST(ic.increment_value AS NVARCHAR(40)),
+			cc.definition,
+			ISNULL(cc.is_persisted, 0),
+			dc.name,
+			dc.definition
+		FROM sys.tables t
+		INNER JOIN sys.schemas s ON t.schema_id = s.schema_id
+		INNER JOIN sys.columns c ON c.object_id = t.object_id
+		INNER JOIN sys.types ty ON c.user_type_id = ty.user_type_id
+		LEFT JOIN sys.identity_columns ic ON ic.object_id = c.object_id AND ic.column_id = c.column_id
+		LEFT JOIN sys.computed_columns cc ON cc.object_id = c.object_id AND cc.column_id = c.column_id
+		LEFT JOIN sys.default_constraints dc ON dc.parent_object_id = c.object_id AND dc.parent_column_id = c.column_id
+		WHERE t.is_ms_shipped = 0
+		ORDER BY s.name, t.name, c.column_id;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []*dumpTable
+	tableMap := make(map[string]*dumpTable)
+	for rows.Next() {
+		var schema, name string
+		column := &dumpColumn{}
+		if err := rows.Scan(
+			&schema,
+			&name,
+			&column.name,
+			&column.typeName,
+			&column.typeSchema,
+			&column.baseTypeName,
+			&column.maxLength,
+			&column.precision,
+			&column.scale,
+			&column.nullable,
+			&column.userDefined,
+			&column.assemblyType,
+			&column.collation,
+			&column.identitySeed,
+			&column.identityIncrement,
+			&column.computedDefinition,
+			&column.persisted,
+			&column.defaultName,
+			&column.defaultDefinition,
+		); err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("%s.%s", schema, name)
+		table, ok := tableMap[key]
+		if !ok {
+			table = &dumpTable{schema: schema, name: name}
+			tableMap[key] = table
+			tables = append(tables, table)
+		}
+		table.columns = append(table.columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := getDumpIndexes(ctx, txn, tableMap); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+func getDumpIndexes(ctx context.Context, txn *sql.Tx, tableMap map[string]*dumpTable) error {
+	// Only the clustered and nonclustered rowstore indexes are dumped.
+	query := `
+		SELECT
+			s.name,
+			t.name,
+			i.name,
+			i.type_desc,
+			i.is_primary_key,
+			i.is_unique,
+			i.is_unique_constraint,
+			i.filter_definition,
+			c.name,
+			ic.is_descending_key,
+			ic.is_included_column
+		FROM sys.indexes i
+		INNER JOIN sys.tables t ON i.object_id = t.object_id
+		INNER JOIN sys.schemas s ON t.schema_id = s.schema_id
+		INNER JOIN sys.index_columns ic ON i.object_id = ic.object_id AND i.index_id = ic.index_id
+		INNER JOIN sys.columns c ON ic.object_id = c.object_id AND ic.column_id = c.column_id
+		WHERE t.is_ms_shipped = 0 AND i.type IN (1, 2)
+		ORDER BY s.name, t.name, i.index_id, ic.key_ordinal, ic.index_column_id;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	indexMap := make(map[string]*dumpIndex)
+	for rows.Next() {
+		var schema, tableName, columnName string
+		var descending, included bool
+		index := &dumpIndex{}
+		if err := rows.Scan(&schema, &tableName, &index.name, &index.typeDesc, &index.primary, &index.unique, &index.uniqueConstraint, &index.filter, &columnName, &descending, &included); err != nil {
+			return err
+		}
+		table, ok := tableMap[fmt.Sprintf("%s.%s", schema, tableName)]
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%s.%s.%s", schema, tableName, index.name)
+		if existing, ok := indexMap[key]; ok {
+			index = existing
+		} else {
+			indexMap[key] = index
+			if index.primary {
+				table.primaryKey = index
+			} else {
+				table.indexes = append(table.indexes, index)
+			}
+		}
+		if included {
+			index.includedColumns = append(index.includedColumns, quoteIdentifier(columnName))
+			continue
+		}
+		order := "ASC"
+		if descending {
+			order = "DESC"
+		}
+		index.columns = append(index.columns, fmt.Sprintf("%s %s", quoteIdentifier(columnName), order))
+	}
+	return rows.Err()
+}
+
+func dumpCheckConstraints(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	query := `
+		SELECT
+			SCHEMA_NAME(t.schema_id),
+			t.name,
+			cc.name,
+			cc.definition
+		FROM sys.check_constraints cc
+		INNER JOIN sys.tables t ON cc.parent_object_id = t.object_id
+		WHERE t.is_ms_shipped = 0
+		ORDER BY 1, 2, 3;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var statements []string
+	for rows.Next() {
+		var schema, table, name, definition string
+		if err := rows.Scan(&schema, &table, &name, &definition); err != nil {
+			return err
+		}
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s.%s ADD CONSTRAINT %s CHECK %s;", quoteIdentifier(schema), quoteIdentifier(table), quoteIdentifier(name), definition))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if err := writeBatch(out, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dumpForeignKeys(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	query := `
+		SELECT
+			SCHEMA_NAME(pt.schema_id),
+			pt.name,
+			fk.name,
+			pc.name,
+			SCHEMA_NAME(rt.schema_id),
+			rt.name,
+			rc.name,
+			fk.delete_referential_action_desc,
+			fk.update_referential_action_desc
+		FROM sys.foreign_keys fk
+		INNER JOIN sys.foreign_key_columns fkc ON fk.object_id = fkc.constraint_object_id
+		INNER JOIN sys.tables pt ON fkc.parent_object_id = pt.object_id
+		INNER JOIN sys.columns pc ON fkc.parent_object_id = pc.object_id AND fkc.parent_column_id = pc.column_id
+		INNER JOIN sys.tables rt ON fkc.referenced_object_id = rt.object_id
+		INNER JOIN sys.columns rc ON fkc.referenced_object_id = rc.object_id AND fkc.referenced_column_id = rc.column_id
+		WHERE fk.is_ms_shipped = 0
+		ORDER BY 1, 2, 3, fkc.constraint_column_id;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type foreignKey struct {
+		table             string
+		name              string
+		columns           []string
+		referencedTable   string
+		referencedColumns []string
+		onDelete          string
+		onUpdate          string
+	}
+	var foreignKeys []*foreignKey
+	foreignKeyMap := make(map[string]*foreignKey)
+	for rows.Next() {
+		var schema, table, name, column, referencedSchema, referencedTable, referencedColumn, onDelete, onUpdate string
+		if err := rows.Scan(&schema, &table, &name, &column, &referencedSchema, &referencedTable, &referencedColumn, &onDelete, &onUpdate); err != nil {
+			return err
+		}
+		key := fmt.Sprintf("%s.%s", schema, name)
+		fk, ok := foreignKeyMap[key]
+		if !ok {
+			fk = &foreignKey{
+				table:           fmt.Sprintf("%s.%s", quoteIdentifier(schema), quoteIdentifier(table)),
+				name:            quoteIdentifier(name),
+				referencedTable: fmt.Sprintf("%s.%s", quoteIdentifier(referencedSchema), quoteIdentifier(referencedTable)),
+				onDelete:        strings.ReplaceAll(onDelete, "_", " "),
+				onUpdate:        strings.ReplaceAll(onUpdate, "_", " "),
+			}
+			foreignKeyMap[key] = fk
+			foreignKeys = append(foreignKeys, fk)
+		}
+		fk.columns = append(fk.columns, quoteIdentifier(column))
+		fk.referencedColumns = append(fk.referencedColumns, quoteIdentifier(referencedColumn))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, fk := range foreignKeys {
+		statement := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s) ON DELETE %s ON UPDATE %s;",
+			fk.table, fk.name, strings.Join(fk.columns, ", "), fk.referencedTable, strings.Join(fk.referencedColumns, ", "), fk.onDelete, fk.onUpdate)
+		if err := writeBatch(out, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dumpModules(ctx context.Context, txn *sql.Tx, out io.Writer) error {
+	// The modules are ordered by the creation time, which is a good approximation of the dependency order.
+	query := `
+		SELECT m.definition
+		FROM sys.sql_modules m
+		INNER JOIN sys.objects o ON m.object_id = o.object_id
+		WHERE o.is_ms_shipped = 0 AND o.type IN ('V', 'P', 'FN', 'IF', 'TF', 'TR')
+		ORDER BY o.create_date, o.object_id;`
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var definitions []string
+	for rows.Next() {
+		var definition sql.NullString
+		if err := rows.Scan(&definition); err != nil {
+			return err
+		}
+		// The definition is NULL for the encrypted modules.
+		if !definition.Valid {
+			continue
+		}
+		definitions = append(definitions, strings.TrimSpace(definition.String))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, definition := range definitions {
+		if err := writeBatch(out, definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dumpTableData(ctx context.Context, txn *sql.Tx, table *dumpTable, out io.Writer) error {
+	var columnNames, selectList []string
+	hasIdentity := false
+	for _, column := range table.columns {
+		// The computed and rowversion columns are generated by the server.
+		if column.computedDefinition.Valid || column.baseTypeName == "timestamp" {
+			continue
+		}
+		if column.identitySeed.Valid {
+			hasIdentity = true
+		}
+		columnNames = append(columnNames, quoteIdentifier(column.name))
+		selectList = append(selectList, column.selectExpression())
+	}
+	if len(columnNames) == 0 {
+		return nil
+	}
+
+	tableName := fmt.Sprintf("%s.%s", quoteIdentifier(table.schema), quoteIdentifier(table.name))
+	query := fmt.Sprintf("SELECT %s FROM %s;", strings.Join(selectList, ", "), tableName)
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	insertPrefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES\n", tableName, strings.Join(columnNames, ", "))
+	values := make([]any, len(columnNames))
+	pointers := make([]any, len(columnNames))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	var batch []string
+	started := false
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if !started && hasIdentity {
+			if err := writeBatch(out, fmt.Sprintf("SET IDENTITY_INSERT %s ON;", tableName)); err != nil {
+				return err
+			}
+		}
+		started = true
+		if err := writeBatch(out, insertPrefix+strings.Join(batch, ",\n")+";"); err != nil {
+			return err
+		}
+		batch = nil
+		return nil
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+		var list []string
+		for _, value := range values {
+			list = append(list, formatValue(value))
+		}
+		batch = append(batch, fmt.Sprintf("(%s)", strings.Join(list, ", ")))
+		if len(batch) >= dumpInsertBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	if started && hasIdentity {
+		return writeBatch(out, fmt.Sprintf("SET IDENTITY_INSERT %s OFF;", tableName))
+	}
+	return nil
+}
+
+func (t *dumpTable) createStatement() string {
+	var buf strings.Builder
+	_, _ = fmt.Fprintf(&buf, "CREATE TABLE %s.%s (\n", quoteIdentifier(t.schema), quoteIdentifier(t.name))
+	for i, column := range t.columns {
+		if i > 0 {
+			_, _ = buf.WriteString(",\n")
+		}
+		_, _ = fmt.Fprintf(&buf, "  %s", column.definition())
+	}
+	if t.primaryKey != nil {
+		_, _ = fmt.Fprintf(&buf, ",\n  CONSTRAINT %s PRIMARY KEY %s (%s)", quoteIdentifier(t.primaryKey.name), t.primaryKey.typeDesc, strings.Join(t.primaryKey.columns, ", "))
+	}
+	_, _ = buf.WriteString("\n);")
+	return buf.String()
+}
+
+func (c *dumpColumn) definition() string {
+	if c.computedDefinition.Valid {
+		definition := fmt.Sprintf("%s AS %s", quoteIdentifier(c.name), c.computedDefinition.String)
+		if c.persisted {
+			definition += " PERSISTED"
+		}
+		return definition
+	}
+	var parts []string
+	parts = append(parts, quoteIdentifier(c.name), c.formatType())
+	if c.collation.Valid && !c.userDefined {
+		parts = append(parts, "COLLATE", c.collation.String)
+	}
+	if c.identitySeed.Valid {
+		parts = append(parts, fmt.Sprintf("IDENTITY(%s, %s)", c.identitySeed.String, c.identityIncrement.String))
+	}
+	if c.nullable {
+		parts = append(parts, "NULL")
+	} else {
+		parts = append(parts, "NOT NULL")
+	}
+	if c.defaultDefinition.Valid {
+		parts = append(parts, "CONSTRAINT", quoteIdentifier(c.defaultName.String), "DEFAULT", c.defaultDefinition.String)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (c *dumpColumn) formatType() string {
+	if c.userDefined {
+		return fmt.Sprintf("%s.%s", quoteIdentifier(c.typeSchema), quoteIdentifier(c.typeName))
+	}
+	switch strings.ToLower(c.typeName) {
+	case "varchar", "char", "varbinary", "binary":
+		if c.maxLength == -1 {
+			return fmt.Sprintf("%s(max)", c.typeName)
+		}
+		return fmt.Sprintf("%s(%d)", c.typeName, c.maxLength)
+	case "nvarchar", "nchar":
+		if c.maxLength == -1 {
+			return fmt.Sprintf("%s(max)", c.typeName)
+		}
+		// The max_length is in bytes, and each character takes 2 bytes.
+		return fmt.Sprintf("%s(%d)", c.typeName, c.maxLength/2)
+	case "decimal", "numeric":
+		return fmt.Sprintf("%s(%d, %d)", c.typeName, c.precision, c.scale)
+	case "datetime2", "datetimeoffset", "time":
+		return fmt.Sprintf("%s(%d)", c.typeName, c.scale)
+	default:
+		return c.typeName
+	}
+}
+
+// selectExpression returns the expression to select the column for the INSERT statements.
+// The values are converted to the types which can be formatted as T-SQL literals without losing precision.
+func (c *dumpColumn) selectExpression() string {
+	name := quoteIdentifier(c.name)
+	if c.assemblyType {
+		return fmt.Sprintf("CAST(%s AS VARBINARY(MAX))", name)
+	}
+	switch strings.ToLower(c.baseTypeName) {
+	case "tinyint", "smallint", "int", "bigint", "bit", "float", "real":
+		return name
+	case "binary", "varbinary", "image":
+		return fmt.Sprintf("CAST(%s AS VARBINARY(MAX))", name)
+	case "date", "datetime", "datetime2", "smalldatetime", "time", "datetimeoffset":
+		return fmt.Sprintf("CONVERT(NVARCHAR(40), %s, 126)", name)
+	default:
+		return fmt.Sprintf("CONVERT(NVARCHAR(MAX), %s)", name)
+	}
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case string:
+		return fmt.Sprintf("N'%s'", strings.ReplaceAll(v, "'", "''"))
+	default:
+		return fmt.Sprintf("N'%s'", strings.ReplaceAll(fmt.Sprint(v), "'", "''"))
+	}
+}
+
+func (i *dumpIndex) createStatement(table *dumpTable) string {
+	tableName := fmt.Sprintf("%s.%s", quoteIdentifier(table.schema), quoteIdentifier(table.name))
+	if i.uniqueConstraint {
+		return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s UNIQUE %s (%s);", tableName, quoteIdentifier(i.name), i.typeDesc, strings.Join(i.columns, ", "))
+	}
+	var buf strings.Builder
+	_, _ = buf.WriteString("CREATE ")
+	if i.unique {
+		_, _ = buf.WriteString("UNIQUE ")
+	}
+	_, _ = fmt.Fprintf(&buf, "%s INDEX %s ON %s (%s)", i.typeDesc, quoteIdentifier(i.name), tableName, strings.Join(i.columns, ", "))
+	if len(i.includedColumns) > 0 {
+		_, _ = fmt.Fprintf(&buf, " INCLUDE (%s)", strings.Join(i.includedColumns, ", "))
+	}
+	if i.filter.Valid {
+		_, _ = fmt.Fprintf(&buf, " WHERE %s", i.filter.String)
+	}
+	_, _ = buf.WriteString(";")
+	return buf.String()
+}
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf("[%s]", strings.ReplaceAll(name, "]", "]]"))
+}
+
+func writeBatch(out io.Writer, batch string) error {
+	if _, err := io.WriteString(out, batch); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "\n"+batchSeparator+"\n")
+	return err
+}
+
+// splitBatches reads the batches separated by GO lines and calls f with each of them.
+func splitBatches(src io.Reader, f func(string) error) error {
+	reader := bufio.NewReader(src)
+	var buf strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if strings.EqualFold(strings.TrimSpace(line), batchSeparator) {
+			if batch := strings.TrimSpace(buf.String()); batch != "" {
+				if err := f(batch); err != nil {
+					return err
+				}
+			}
+			buf.Reset()
+		} else {
+			_, _ = buf.WriteString(line)
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	if batch := strings.TrimSpace(buf.String()); batch != "" {
+		return f(batch)
+	}
+	return nil
+}
+
+// Restore restores the database from the dump generated by Dump.
+// The schema batches are executed one by one, and the INSERT batches are committed in transactions of restoreTxnBatchSize batches.
+func (driver *Driver) Restore(ctx context.Context, src io.Reader) error {
+	// SET IDENTITY_INSERT is session scoped, so all the batches are executed in the same connection.
+	conn, err := driver.db.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get connection")
+	}
+	defer conn.Close()
+
+	var txn *sql.Tx
+	defer func() {
+		if txn != nil {
+			_ = txn.Rollback()
+		}
+	}()
+	commit := func() error {
+		if txn == nil {
+			return nil
+		}
+		err := txn.Commit()
+		txn = nil
+		return err
+	}
+
+	batchCount, dataBatchCount := 0, 0
+	if err := splitBatches(src, func(batch string) error {
+		batchCount++
+		if !strings.HasPrefix(batch, "INSERT INTO ") {
+			if err := commit(); err != nil {
+				return errors.Wrap(err, "failed to commit transaction")
+			}
+			if _, err := conn.ExecContext(ctx, batch); err != nil {
+				return errors.Wrapf(err, "failed to execute batch %d", batchCount)
+			}
+			return nil
+		}
+
+		if txn == nil {
+			newTxn, err := conn.BeginTx(ctx, nil)
+			if err != nil {
+				return errors.Wrap(err, "failed to begin transaction")
+			}
+			txn = newTxn
+		}
+		if _, err := txn.ExecContext(ctx, batch); err != nil {
+			return errors.Wrapf(err, "failed to execute batch %d", batchCount)
+		}
+		dataBatchCount++
+		if dataBatchCount%restoreTxnBatchSize == 0 {
+			if err := commit(); err != nil {
+				return errors.Wrap(err, "failed to commit transaction")
+			}
+			slog.Info("restoring database", slog.String("database", driver.databaseName), slog.Int("batches", batchCount), slog.Int("insertBatches", dataBatchCount))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	slog.Info("restored database", slog.String("database", driver.databaseName), slog.Int("batches", batchCount), slog.Int("insertBatches", dataBatchCount))
 	return nil
 }
@@ -0,0 +1,84 @@
+package mssql
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTableStatement(t *testing.T) {
+	a := require.New(t)
+	table := &dumpTable{
+		schema: "dbo",
+		name:   "order]s",
+		columns: []*dumpColumn{
+			{name: "id", typeName: "bigint", baseTypeName: "bigint", identitySeed: sql.NullString{String: "1", Valid: true}, identityIncrement: sql.NullString{String: "1", Valid: true}},
+			{name: "name", typeName: "nvarchar", baseTypeName: "nvarchar", maxLength: 100, nullable: true, collation: sql.NullString{String: "SQL_Latin1_General_CP1_CI_AS", Valid: true}},
+			{name: "amount", typeName: "decimal", baseTypeName: "decimal", precision: 10, scale: 2, defaultName: sql.NullString{String: "DF_amount", Valid: true}, defaultDefinition: sql.NullString{String: "((0))", Valid: true}},
+			{name: "payload", typeName: "varbinary", baseTypeName: "varbinary", maxLength: -1, nullable: true},
+			{name: "total", computedDefinition: sql.NullString{String: "([amount]*(2))", Valid: true}, persisted: true},
+		},
+		primaryKey: &dumpIndex{name: "PK_orders", typeDesc: "CLUSTERED", primary: true, columns: []string{"[id] ASC"}},
+	}
+	want := `CREATE TABLE [dbo].[order]]s] (
+  [id] bigint IDENTITY(1, 1) NOT NULL,
+  [name] nvarchar(50) COLLATE SQL_Latin1_General_CP1_CI_AS NULL,
+  [amount] decimal(10, 2) NOT NULL CONSTRAINT [DF_amount] DEFAULT ((0)),
+  [payload] varbinary(max) NULL,
+  [total] AS ([amount]*(2)) PERSISTED,
+  CONSTRAINT [PK_orders] PRIMARY KEY CLUSTERED ([id] ASC)
+);`
+	a.Equal(want, table.createStatement())
+
+	index := &dumpIndex{name: "idx_name", typeDesc: "NONCLUSTERED", unique: true, columns: []string{"[name] DESC"}, includedColumns: []string{"[amount]"}, filter: sql.NullString{String: "([name] IS NOT NULL)", Valid: true}}
+	a.Equal("CREATE UNIQUE NONCLUSTERED INDEX [idx_name] ON [dbo].[order]]s] ([name] DESC) INCLUDE ([amount]) WHERE ([name] IS NOT NULL);", index.createStatement(table))
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{value: nil, want: "NULL"},
+		{value: true, want: "1"},
+		{value: int64(-42), want: "-42"},
+		{value: 1.5, want: "1.5"},
+		{value: []byte{0xde, 0xad}, want: "0xdead"},
+		{value: []byte{}, want: "0x"},
+		{value: "it's", want: "N'it''s'"},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, formatValue(test.value))
+	}
+}
+
+func TestSplitBatches(t *testing.T) {
+	a := require.New(t)
+	dump := "CREATE SCHEMA [s];\nGO\nCREATE PROCEDURE p AS\nBEGIN\n  SELECT 1;\n  SELECT 2;\nEND\n  go  \nINSERT INTO t VALUES (1);\n"
+	var batches []string
+	a.NoError(splitBatches(strings.NewReader(dump), func(batch string) error {
+		batches = append(batches, batch)
+		return nil
+	}))
+	a.Equal([]string{
+		"CREATE SCHEMA [s];",
+		"CREATE PROCEDURE p AS\nBEGIN\n  SELECT 1;\n  SELECT 2;\nEND",
+		"INSERT INTO t VALUES (1);",
+	}, batches)
+}
+
+func TestAddIntegerString(t *testing.T) {
+	a := require.New(t)
+	got, err := addIntegerString("9223372036854775807", "1")
+	a.NoError(err)
+	a.Equal("9223372036854775808", got)
+	got, err = addIntegerString("10", "-5")
+	a.NoError(err)
+	a.Equal("5", got)
+	_, err = addIntegerString("1.5", "1")
+	a.Error(err)
+}
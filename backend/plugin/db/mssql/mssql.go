@@ -10,8 +10,7 @@ import (
 	"strings"
 	"time"
 
-	// Import go-ora Oracle driver.
-	_ "github.com/microsoft/go-mssqldb"
+	mssql "github.com/microsoft/go-mssqldb"
 	_ "github.com/microsoft/go-mssqldb/integratedauth/krb5"
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -56,6 +55,17 @@ func (driver *Driver) Open(_ context.Context, _ storepb.Engine, config db.Connec
 		Host:     fmt.Sprintf("%s:%s", config.Host, config.Port),
 		RawQuery: query.Encode(),
 	}
+	if config.AuthTokenProvider != nil {
+		// The access token replaces the username and password.
+		u.User = nil
+		connector, err := mssql.NewConnectorWithAccessTokenProvider(u.String(), config.AuthTokenProvider)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create access token connector")
+		}
+		driver.db = sql.OpenDB(connector)
+		driver.databaseName = config.Database
+		return driver, nil
+	}
 	db, err := sql.Open("sqlserver", u.String())
 	if err != nil {
 		return nil, err
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
@@ -325,12 +327,98 @@ func getViews(txn *sql.Tx) (map[string][]*storepb.ViewMetadata, error) {
 	return viewMap, nil
 }
 
-// SyncSlowQuery syncs the slow query.
-func (*Driver) SyncSlowQuery(_ context.Context, _ time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
-	return nil, errors.Errorf("not implemented")
+// SyncSlowQuery syncs the slow query from the Query Store of the current database.
+// The runtime statistics are aggregated by query text over the Query Store intervals starting on the log date,
+// and only the queries whose maximum duration exceeds one second are collected.
+func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	query := `
+		WITH stats AS (
+			SELECT
+				q.query_text_id,
+				SUM(rs.count_executions) AS executions,
+				CAST(SUM(rs.avg_duration * rs.count_executions) AS BIGINT) AS total_duration,
+				MAX(rs.max_duration) AS max_duration,
+				CAST(SUM(rs.avg_rowcount * rs.count_executions) AS BIGINT) AS total_rowcount,
+				MAX(rs.max_rowcount) AS max_rowcount,
+				MAX(rs.last_execution_time) AS last_execution_time
+			FROM sys.query_store_runtime_stats rs
+			INNER JOIN sys.query_store_runtime_stats_interval rsi ON rs.runtime_stats_interval_id = rsi.runtime_stats_interval_id
+			INNER JOIN sys.query_store_plan p ON rs.plan_id = p.plan_id
+			INNER JOIN sys.query_store_query q ON p.query_id = q.query_id
+			WHERE rsi.start_time >= @p1 AND rsi.start_time < @p2
+			GROUP BY q.query_text_id
+			HAVING MAX(rs.max_duration) >= 1000000
+		)
+		SELECT
+			qt.query_sql_text,
+			stats.executions,
+			stats.total_duration,
+			stats.max_duration,
+			stats.total_rowcount,
+			stats.max_rowcount,
+			stats.last_execution_time
+		FROM stats
+		INNER JOIN sys.query_store_query_text qt ON stats.query_text_id = qt.query_text_id
+		ORDER BY stats.total_duration DESC`
+
+	rows, err := driver.db.QueryContext(ctx, query, logDateTs, logDateTs.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var items []*storepb.SlowQueryStatisticsItem
+	for rows.Next() {
+		var sqlText string
+		var executions, totalDuration, maxDuration, totalRowCount, maxRowCount int64
+		var lastExecutionTime time.Time
+		if err := rows.Scan(&sqlText, &executions, &totalDuration, &maxDuration, &totalRowCount, &maxRowCount, &lastExecutionTime); err != nil {
+			return nil, err
+		}
+		if len(items) >= db.SlowQueryMaxSamplePerDay {
+			continue
+		}
+		if len(sqlText) > db.SlowQueryMaxLen {
+			sqlText = sqlText[:db.SlowQueryMaxLen]
+		}
+		// The durations in Query Store are in microseconds.
+		maxQueryTime := durationpb.New(time.Duration(maxDuration) * time.Microsecond)
+		items = append(items, &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:   util.GetSlowQueryFingerprint(storepb.Engine_MSSQL, sqlText),
+			Count:            executions,
+			LatestLogTime:    timestamppb.New(lastExecutionTime),
+			TotalQueryTime:   durationpb.New(time.Duration(totalDuration) * time.Microsecond),
+			MaximumQueryTime: maxQueryTime,
+			TotalRowsSent:    totalRowCount,
+			MaximumRowsSent:  maxRowCount,
+			Samples: []*storepb.SlowQueryDetails{
+				{
+					StartTime: timestamppb.New(lastExecutionTime),
+					QueryTime: maxQueryTime,
+					RowsSent:  maxRowCount,
+					SqlText:   sqlText,
+				},
+			},
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+
+	return map[string]*storepb.SlowQueryStatistics{
+		driver.databaseName: util.MergeSlowQueryStatistics(items),
+	}, nil
 }
 
-// CheckSlowQueryLogEnabled checks if slow query log is enabled.
-func (*Driver) CheckSlowQueryLogEnabled(_ context.Context) error {
-	return errors.Errorf("not implemented")
+// CheckSlowQueryLogEnabled checks if the Query Store is enabled for the current database.
+func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
+	query := "SELECT actual_state_desc FROM sys.database_query_store_options"
+	var state string
+	if err := driver.db.QueryRowContext(ctx, query).Scan(&state); err != nil {
+		return util.FormatErrorWithQuery(err, query)
+	}
+	if state != "READ_WRITE" && state != "READ_ONLY" {
+		return errors.Errorf("query store is not enabled for database %q: actual_state_desc = %s", driver.databaseName, state)
+	}
+	return nil
 }
@@ -4,6 +4,7 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log/slog"
 	"net"
@@ -101,10 +102,24 @@ func (driver *Driver) Open(_ context.Context, dbType storepb.Engine, connCfg db.
 		params = append(params, fmt.Sprintf("tls=%s", tlsKey))
 	}
 
-	dsn := fmt.Sprintf("%s:%s@%s(%s:%s)/%s?%s", connCfg.Username, connCfg.Password, protocol, connCfg.Host, connCfg.Port, connCfg.Database, strings.Join(params, "&"))
-	db, err := sql.Open("mysql", dsn)
-	if err != nil {
-		return nil, err
+	var db *sql.DB
+	if connCfg.AuthTokenProvider != nil {
+		// The IAM authentication tokens are sent with the cleartext plugin, so the verified TLS is required.
+		if tlsConfig == nil {
+			return nil, errors.Errorf("TLS is required for the IAM authentication")
+		}
+		params = append(params, "allowCleartextPasswords=true")
+		cfg, err := mysql.ParseDSN(fmt.Sprintf("%s@%s(%s:%s)/%s?%s", connCfg.Username, protocol, connCfg.Host, connCfg.Port, connCfg.Database, strings.Join(params, "&")))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse mysql dsn")
+		}
+		db = sql.OpenDB(&tokenConnector{cfg: cfg, tokenProvider: connCfg.AuthTokenProvider})
+	} else {
+		dsn := fmt.Sprintf("%s:%s@%s(%s:%s)/%s?%s", connCfg.Username, connCfg.Password, protocol, connCfg.Host, connCfg.Port, connCfg.Database, strings.Join(params, "&"))
+		var err error
+		if db, err = sql.Open("mysql", dsn); err != nil {
+			return nil, err
+		}
 	}
 	driver.dbType = dbType
 	driver.db = db
@@ -119,6 +134,32 @@ func (driver *Driver) Open(_ context.Context, dbType storepb.Engine, connCfg db.
 	return driver, nil
 }
 
+// tokenConnector connects with a fresh authentication token as the password for each connection.
+type tokenConnector struct {
+	cfg           *mysql.Config
+	tokenProvider func(ctx context.Context) (string, error)
+}
+
+// Connect implements driver.Connector.
+func (c *tokenConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	token, err := c.tokenProvider(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get authentication token")
+	}
+	cfg := c.cfg.Clone()
+	cfg.Passwd = token
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+// Driver implements driver.Connector.
+func (*tokenConnector) Driver() driver.Driver {
+	return &mysql.MySQLDriver{}
+}
+
 // Close closes the driver.
 func (driver *Driver) Close(context.Context) error {
 	var err error
@@ -173,6 +214,21 @@ func (driver *Driver) Execute(ctx context.Context, statement string, _ bool, opt
 	}
 	defer conn.Close()
 
+	timeoutStmts := driver.getTimeoutStatements(opts)
+	for _, stmt := range timeoutStmts {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return 0, errors.Wrapf(err, "failed to set timeout %q", stmt)
+		}
+	}
+	if len(timeoutStmts) > 0 {
+		defer func() {
+			// Reset the session variables before returning the connection to the pool.
+			if _, err := conn.ExecContext(context.Background(), driver.getResetTimeoutStatement()); err != nil {
+				slog.Warn("failed to reset the session timeouts", log.BBError(err))
+			}
+		}()
+	}
+
 	if opts.BeginFunc != nil {
 		if err := opts.BeginFunc(ctx, conn); err != nil {
 			return 0, err
@@ -187,7 +243,12 @@ func (driver *Driver) Execute(ctx context.Context, statement string, _ bool, opt
 	var totalRowsAffected int64
 	sqlResult, err := tx.ExecContext(ctx, statement)
 	if err != nil {
-		return 0, errors.Wrapf(err, "failed to execute context in a transaction")
+		err = errors.Wrapf(err, "failed to execute context in a transaction")
+		// DDL statements are committed implicitly, so it's only safe to retry a single statement.
+		if isLockTimeoutError(err) && driver.isSingleStatement(statement) {
+			return 0, &db.LockTimeoutError{Err: err}
+		}
+		return 0, err
 	}
 	rowsAffected, err := sqlResult.RowsAffected()
 	if err != nil {
@@ -203,6 +264,61 @@ func (driver *Driver) Execute(ctx context.Context, statement string, _ bool, opt
 	return totalRowsAffected, nil
 }
 
+// getTimeoutStatements returns the statements to set the session lock wait timeout and execution timeout.
+// MySQL only applies max_execution_time to SELECT statements, and MariaDB uses max_statement_time instead.
+func (driver *Driver) getTimeoutStatements(opts db.ExecuteOptions) []string {
+	var stmts []string
+	if opts.LockTimeout > 0 {
+		// lock_wait_timeout is in seconds and the minimum is 1.
+		seconds := int64(opts.LockTimeout.Seconds())
+		if seconds < 1 {
+			seconds = 1
+		}
+		stmts = append(stmts, fmt.Sprintf("SET SESSION lock_wait_timeout = %d", seconds))
+	}
+	if opts.StatementTimeout > 0 {
+		switch driver.dbType {
+		case storepb.Engine_MARIADB:
+			stmts = append(stmts, fmt.Sprintf("SET SESSION max_statement_time = %.3f", opts.StatementTimeout.Seconds()))
+		case storepb.Engine_MYSQL, storepb.Engine_TIDB:
+			stmts = append(stmts, fmt.Sprintf("SET SESSION max_execution_time = %d", opts.StatementTimeout.Milliseconds()))
+		}
+	}
+	return stmts
+}
+
+func (driver *Driver) getResetTimeoutStatement() string {
+	switch driver.dbType {
+	case storepb.Engine_MARIADB:
+		return "SET SESSION lock_wait_timeout = DEFAULT, max_statement_time = DEFAULT"
+	case storepb.Engine_MYSQL, storepb.Engine_TIDB:
+		return "SET SESSION lock_wait_timeout = DEFAULT, max_execution_time = DEFAULT"
+	default:
+		return "SET SESSION lock_wait_timeout = DEFAULT"
+	}
+}
+
+func (driver *Driver) isSingleStatement(statement string) bool {
+	list, err := base.SplitMultiSQL(storepb.Engine_MYSQL, statement)
+	if err != nil {
+		return false
+	}
+	count := 0
+	for _, stmt := range list {
+		if !stmt.Empty {
+			count++
+		}
+	}
+	return count == 1
+}
+
+// isLockTimeoutError returns true if the error is ER_LOCK_WAIT_TIMEOUT, which is raised when lock_wait_timeout
+// or innodb_lock_wait_timeout is exceeded.
+func isLockTimeoutError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1205
+}
+
 // QueryConn queries a SQL statement in a given connection.
 func (driver *Driver) QueryConn(ctx context.Context, conn *sql.Conn, statement string, queryContext *db.QueryContext) ([]*v1pb.QueryResult, error) {
 	singleSQLs, err := base.SplitMultiSQL(storepb.Engine_MYSQL, statement)
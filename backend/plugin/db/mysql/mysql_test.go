@@ -1,10 +1,14 @@
 package mysql
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
@@ -46,3 +50,35 @@ func convertExplainRow(values ...string) *v1pb.QueryRow {
 	}
 	return row
 }
+
+func TestParseTiDBTimeZone(t *testing.T) {
+	tests := []struct {
+		timeZone string
+		offset   int
+	}{
+		{timeZone: "UTC", offset: 0},
+		{timeZone: "+08:00", offset: 8 * 3600},
+		{timeZone: "-05:30", offset: -(5*3600 + 30*60)},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		location := parseTiDBTimeZone(test.timeZone)
+		_, offset := time.Date(2023, 1, 1, 0, 0, 0, 0, location).Zone()
+		a.Equal(test.offset, offset, test.timeZone)
+	}
+}
+
+func TestOpenIAMRequiresTLS(t *testing.T) {
+	a := require.New(t)
+	driver := &Driver{}
+	_, err := driver.Open(context.Background(), storepb.Engine_MYSQL, db.ConnectionConfig{
+		Host:     "localhost",
+		Port:     "3306",
+		Username: "bytebase",
+		AuthTokenProvider: func(context.Context) (string, error) {
+			return "token", nil
+		},
+	}, db.ConnectionContext{})
+	a.ErrorContains(err, "TLS is required")
+}
@@ -504,6 +504,9 @@ type slowLog struct {
 
 // SyncSlowQuery syncs slow query from mysql.slow_log.
 func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	if driver.dbType == storepb.Engine_TIDB {
+		return driver.syncTiDBSlowQuery(ctx, logDateTs)
+	}
 	var timeZone string
 	// The MySQL function convert_tz requires loading the time zone table into MySQL.
 	// So we convert time zone in backend instead of MySQL server
@@ -613,6 +616,98 @@ func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (m
 	return analyzeSlowLog(driver.dbType, logs)
 }
 
+// syncTiDBSlowQuery syncs slow query from information_schema.cluster_slow_query.
+// TiDB doesn't support mysql.slow_log, and the cluster_slow_query table collects the slow query log files of all the TiDB servers.
+func (driver *Driver) syncTiDBSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	// The Time column is a TIMESTAMP, which is compared and displayed in the session time zone.
+	var timeZone, systemTimeZone string
+	timeZoneQuery := `SELECT @@time_zone, @@system_time_zone;`
+	if err := driver.db.QueryRowContext(ctx, timeZoneQuery).Scan(&timeZone, &systemTimeZone); err != nil {
+		return nil, util.FormatErrorWithQuery(err, timeZoneQuery)
+	}
+	if strings.ToLower(timeZone) == "system" {
+		timeZone = systemTimeZone
+	}
+	location := parseTiDBTimeZone(timeZone)
+
+	logs := make([]*slowLog, 0, db.SlowQueryMaxSamplePerDay)
+	query := `
+		SELECT
+			CAST(Time AS CHAR) AS start_time,
+			Query_time,
+			IFNULL(LockKeys_time, 0),
+			IFNULL(Result_rows, 0),
+			IFNULL(Total_keys, 0),
+			IFNULL(DB, ''),
+			Query
+		FROM
+			information_schema.cluster_slow_query
+		WHERE
+			Is_internal = false
+			AND Time >= ?
+			AND Time < ?
+	`
+
+	slowLogRows, err := driver.db.QueryContext(ctx, query, logDateTs.Format("2006-01-02"), logDateTs.AddDate(0, 0, 1).Format("2006-01-02"))
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer slowLogRows.Close()
+	for slowLogRows.Next() {
+		log := slowLog{
+			details: &storepb.SlowQueryDetails{},
+		}
+		var startTime string
+		var queryTime, lockTime float64
+		if err := slowLogRows.Scan(
+			&startTime,
+			&queryTime,
+			&lockTime,
+			&log.details.RowsSent,
+			&log.details.RowsExamined,
+			&log.database,
+			&log.details.SqlText,
+		); err != nil {
+			return nil, err
+		}
+
+		startTimeTs, err := time.ParseInLocation("2006-01-02 15:04:05.999999", startTime, location)
+		if err != nil {
+			return nil, err
+		}
+		log.details.StartTime = timestamppb.New(startTimeTs)
+		log.details.QueryTime = durationpb.New(time.Duration(queryTime * float64(time.Second)))
+		log.details.LockTime = durationpb.New(time.Duration(lockTime * float64(time.Second)))
+
+		// Use Reservoir Sampling to sample slow logs.
+		if len(logs) < db.SlowQueryMaxSamplePerDay {
+			logs = append(logs, &log)
+		} else {
+			pos := rand.Intn(len(logs))
+			logs[pos] = &log
+		}
+	}
+
+	if err := slowLogRows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+
+	return analyzeSlowLog(driver.dbType, logs)
+}
+
+// parseTiDBTimeZone parses the TiDB time zone, which is either a location name such as "Asia/Shanghai" or an offset such as "+08:00".
+func parseTiDBTimeZone(timeZone string) *time.Location {
+	if location, err := time.LoadLocation(timeZone); err == nil {
+		return location
+	}
+	if offset, err := time.Parse("-07:00", timeZone); err == nil {
+		_, seconds := offset.Zone()
+		return time.FixedZone(timeZone, seconds)
+	}
+	slog.Debug("failed to load time zone", slog.String("timeZone", timeZone))
+	return time.Local
+}
+
 func parseDuration(s string) (time.Duration, error) {
 	if s == "" {
 		return 0, nil
@@ -729,6 +824,9 @@ func extractDatabase(engne storepb.Engine, defaultDB string, sql string) []strin
 
 // CheckSlowQueryLogEnabled checks whether the slow query log is enabled.
 func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
+	if driver.dbType == storepb.Engine_TIDB {
+		return driver.checkTiDBSlowQueryLogEnabled(ctx)
+	}
 	showSlowQueryLog := "SHOW GLOBAL VARIABLES LIKE 'slow_query_log'"
 
 	slowQueryLogRows, err := driver.db.QueryContext(ctx, showSlowQueryLog)
@@ -770,3 +868,15 @@ func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
 
 	return nil
 }
+
+func (driver *Driver) checkTiDBSlowQueryLogEnabled(ctx context.Context) error {
+	query := "SELECT @@tidb_enable_slow_log"
+	var value string
+	if err := driver.db.QueryRowContext(ctx, query).Scan(&value); err != nil {
+		return util.FormatErrorWithQuery(err, query)
+	}
+	if value != "1" && strings.ToUpper(value) != "ON" {
+		return errors.New("slow query log is not enabled: tidb_enable_slow_log = " + value)
+	}
+	return nil
+}
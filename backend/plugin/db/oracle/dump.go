@@ -8,10 +8,16 @@ import (
 	"log/slog"
 	"sort"
 	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/plsql-parser"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
 )
 
-// Dump dumps the database.
-func (driver *Driver) Dump(ctx context.Context, out io.Writer, _ bool) (string, error) {
+// Dump dumps the schema objects of the database, and the data as INSERT statements unless schemaOnly is set.
+func (driver *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool) (string, error) {
 	txn, err := driver.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
 		return "", err
@@ -33,7 +39,7 @@ func (driver *Driver) Dump(ctx context.Context, out io.Writer, _ bool) (string,
 	} else {
 		list = append(list, schemas...)
 	}
-	if err := driver.dumpTxn(ctx, txn, list, out); err != nil {
+	if err := driver.dumpTxn(ctx, txn, list, out, schemaOnly); err != nil {
 		return "", err
 	}
 
@@ -43,17 +49,18 @@ func (driver *Driver) Dump(ctx context.Context, out io.Writer, _ bool) (string,
 	return "", nil
 }
 
-func (driver *Driver) dumpTxn(ctx context.Context, txn *sql.Tx, schemas []string, out io.Writer) error {
+func (driver *Driver) dumpTxn(ctx context.Context, txn *sql.Tx, schemas []string, out io.Writer, schemaOnly bool) error {
 	for _, schema := range schemas {
-		if err := driver.dumpSchemaTxn(ctx, txn, schema, out); err != nil {
+		if err := driver.dumpSchemaTxn(ctx, txn, schema, out, schemaOnly); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (driver *Driver) dumpSchemaTxn(ctx context.Context, txn *sql.Tx, schema string, out io.Writer) error {
-	if err := driver.dumpTableTxn(ctx, txn, schema, out); err != nil {
+func (driver *Driver) dumpSchemaTxn(ctx context.Context, txn *sql.Tx, schema string, out io.Writer, schemaOnly bool) error {
+	tableMap, err := driver.dumpTableTxn(ctx, txn, schema, out)
+	if err != nil {
 		return err
 	}
 	if err := dumpViewTxn(ctx, txn, schema, out); err != nil {
@@ -68,7 +75,13 @@ func (driver *Driver) dumpSchemaTxn(ctx context.Context, txn *sql.Tx, schema str
 	if err := driver.dumpSequenceTxn(ctx, txn, schema, out); err != nil {
 		return err
 	}
-	return dumpTriggerOrderingTxn(ctx, txn, schema, out)
+	if err := dumpTriggerOrderingTxn(ctx, txn, schema, out); err != nil {
+		return err
+	}
+	if schemaOnly {
+		return nil
+	}
+	return dumpTableData(ctx, txn, tableMap, out)
 }
 
 func assembleTableStatement(tableMap map[string]*tableSchema, out io.Writer) error {
@@ -1690,12 +1703,12 @@ ORDER BY AT.TABLE_OWNER, AT.TABLE_NAME, AT.TRIGGER_NAME, ATC.COLUMN_NAME ASC
 `
 )
 
-func (driver *Driver) dumpTableTxn(ctx context.Context, txn *sql.Tx, schema string, out io.Writer) error {
+func (driver *Driver) dumpTableTxn(ctx context.Context, txn *sql.Tx, schema string, out io.Writer) (map[string]*tableSchema, error) {
 	tableMap := make(map[string]*tableSchema)
 	tableRows, err := txn.QueryContext(ctx, fmt.Sprintf(dumpTableSQL, schema))
 	var constraintList []*constraintMeta
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer tableRows.Close()
 
@@ -1761,7 +1774,7 @@ func (driver *Driver) dumpTableTxn(ctx context.Context, txn *sql.Tx, schema stri
 			&meta.Status,
 			&meta.Generated,
 		); err != nil {
-			return err
+			return nil, err
 		}
 		if !meta.TableName.Valid {
 			continue
@@ -1771,13 +1784,13 @@ func (driver *Driver) dumpTableTxn(ctx context.Context, txn *sql.Tx, schema stri
 		}
 	}
 	if err := tableRows.Err(); err != nil {
-		return err
+		return nil, err
 	}
 
 	var fieldRows *sql.Rows
 	majorVersion, err := driver.getMajorVersion(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if majorVersion >= 12 {
 		fieldRows, err = txn.QueryContext(ctx, fmt.Sprintf(dumpFieldSQL, schema))
@@ -1785,7 +1798,7 @@ func (driver *Driver) dumpTableTxn(ctx context.Context, txn *sql.Tx, schema stri
 		fieldRows, err = txn.QueryContext(ctx, fmt.Sprintf(dumpFieldSQL11g, schema))
 	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer fieldRows.Close()
 	for fieldRows.Next() {
@@ -1811,7 +1824,7 @@ func (driver *Driver) dumpTableTxn(ctx context.Context, txn *sql.Tx, schema stri
 			&field.IsInvisible,
 			&field.Comments,
 		); err != nil {
-			return err
+			return nil, err
 		}
 		if !field.TableName.Valid {
 			slog.Warn("column table name null", slog.String("schema", schema))
@@ -1828,12 +1841,12 @@ func (driver *Driver) dumpTableTxn(ctx context.Context, txn *sql.Tx, schema stri
 		tableMap[field.TableName.String].fields = append(tableMap[field.TableName.String].fields, &field)
 	}
 	if err := fieldRows.Err(); err != nil {
-		return err
+		return nil, err
 	}
 
 	constraintRows, err := txn.QueryContext(ctx, fmt.Sprintf(dumpConstraintSQL, schema))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer constraintRows.Close()
 	for constraintRows.Next() {
@@ -1858,7 +1871,7 @@ func (driver *Driver) dumpTableTxn(ctx context.Context, txn *sql.Tx, schema stri
 			&constraint.RConstraintName,
 			&constraint.RColumnName,
 		); err != nil {
-			return err
+			return nil, err
 		}
 		if !constraint.TableName.Valid {
 			slog.Warn("constraint table name null", slog.String("schema", schema))
@@ -1871,7 +1884,7 @@ func (driver *Driver) dumpTableTxn(ctx context.Context, txn *sql.Tx, schema stri
 		constraintList = append(constraintList, &constraint)
 	}
 	if err := constraintRows.Err(); err != nil {
-		return err
+		return nil, err
 	}
 
 	var mergedConstraintList []*mergedConstraintMeta
@@ -1910,7 +1923,10 @@ func (driver *Driver) dumpTableTxn(ctx context.Context, txn *sql.Tx, schema stri
 		tableMap[constraint.TableName.String].constraints = append(tableMap[constraint.TableName.String].constraints, constraint)
 	}
 
-	return assembleTableStatement(tableMap, out)
+	if err := assembleTableStatement(tableMap, out); err != nil {
+		return nil, err
+	}
+	return tableMap, nil
 }
 
 func dumpViewTxn(ctx context.Context, txn *sql.Tx, schema string, _ io.Writer) error {
@@ -2291,8 +2307,169 @@ func dumpTriggerOrderingTxn(ctx context.Context, txn *sql.Tx, schema string, _ i
 	return nil
 }
 
-// Restore restores a database.
-func (*Driver) Restore(_ context.Context, _ io.Reader) (err error) {
-	// TODO(d): implement it.
+// restoreTxnBatchSize is the number of DML statements committed in one transaction when restoring.
+const restoreTxnBatchSize = 1000
+
+// Restore restores the database from the dump generated by Dump.
+// The DDL statements and PL/SQL blocks are executed one by one since Oracle commits the DDL statements implicitly,
+// and the DML statements are committed in transactions of restoreTxnBatchSize statements.
+func (driver *Driver) Restore(ctx context.Context, src io.Reader) error {
+	conn, err := driver.db.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get connection")
+	}
+	defer conn.Close()
+
+	var txn *sql.Tx
+	defer func() {
+		if txn != nil {
+			_ = txn.Rollback()
+		}
+	}()
+	commit := func() error {
+		if txn == nil {
+			return nil
+		}
+		err := txn.Commit()
+		txn = nil
+		return err
+	}
+
+	dmlCount := 0
+	statementCount, err := restoreStatements(src, func(stmt string, dml bool) error {
+		if !dml {
+			if err := commit(); err != nil {
+				return errors.Wrap(err, "failed to commit transaction")
+			}
+			_, err := conn.ExecContext(ctx, stmt)
+			return err
+		}
+
+		if txn == nil {
+			newTxn, err := conn.BeginTx(ctx, nil)
+			if err != nil {
+				return errors.Wrap(err, "failed to begin transaction")
+			}
+			txn = newTxn
+		}
+		if _, err := txn.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+		dmlCount++
+		if dmlCount%restoreTxnBatchSize == 0 {
+			if err := commit(); err != nil {
+				return errors.Wrap(err, "failed to commit transaction")
+			}
+			slog.Info("restoring database", slog.String("database", driver.databaseName), slog.Int("dmlStatements", dmlCount))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	slog.Info("restored database", slog.String("database", driver.databaseName), slog.Int("statements", statementCount))
+	return nil
+}
+
+// restoreStatements splits the dump into statements and calls exec with each of them, it returns the number of statements.
+func restoreStatements(src io.Reader, exec func(stmt string, dml bool) error) (int, error) {
+	statementCount := 0
+	f := func(stmt string) error {
+		// The underlying oracle golang driver go-ora does not support semicolon, so we should trim the suffix semicolon.
+		stmt = strings.TrimSuffix(strings.TrimSpace(stmt), ";")
+		if stmt == "" {
+			return nil
+		}
+		// The PL/SQL blocks must end with the semicolon, which is removed by the splitter.
+		if isPLSQLBlock(stmt) {
+			stmt += ";"
+		}
+		statementCount++
+		if err := exec(stmt, isDMLStatement(stmt)); err != nil {
+			return errors.Wrapf(err, "failed to execute statement %d", statementCount)
+		}
+		return nil
+	}
+	if err := splitDumpStatements(src, f); err != nil {
+		return 0, err
+	}
+	return statementCount, nil
+}
+
+// splitDumpStatements splits the dump into statements by the semicolons with the PL/SQL lexer.
+// The dump only contains the statements generated by Dump, so we don't need to parse them, which is too slow for the data section.
+// The PL/SQL blocks end with the semicolon following the END keyword, and the semicolons are removed from the statements.
+func splitDumpStatements(src io.Reader, f func(string) error) error {
+	lexer := parser.NewPlSqlLexer(antlr.NewIoStream(src))
+	lexerErrorListener := &base.ParseErrorListener{}
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(lexerErrorListener)
+
+	var buf strings.Builder
+	var first, previous int
+	for {
+		token := lexer.NextToken()
+		if lexerErrorListener.Err != nil {
+			return lexerErrorListener.Err
+		}
+		if token.GetTokenType() == antlr.TokenEOF {
+			break
+		}
+		if token.GetChannel() != antlr.TokenDefaultChannel {
+			// Skip the whitespaces and comments between the statements.
+			if buf.Len() > 0 {
+				_, _ = buf.WriteString(token.GetText())
+			}
+			continue
+		}
+		if token.GetTokenType() == parser.PlSqlLexerSEMICOLON {
+			isBlock := first == parser.PlSqlLexerDECLARE || first == parser.PlSqlLexerBEGIN
+			if !isBlock || previous == parser.PlSqlLexerEND {
+				if err := f(buf.String()); err != nil {
+					return err
+				}
+				buf.Reset()
+				first, previous = 0, 0
+				continue
+			}
+		}
+		if buf.Len() == 0 {
+			first = token.GetTokenType()
+		}
+		previous = token.GetTokenType()
+		_, _ = buf.WriteString(token.GetText())
+	}
+	if strings.TrimSpace(buf.String()) != "" {
+		return f(buf.String())
+	}
 	return nil
 }
+
+func isDMLStatement(stmt string) bool {
+	fields := strings.Fields(stmt)
+	if len(fields) == 0 {
+		return false
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "INSERT", "UPDATE", "DELETE", "MERGE":
+		return true
+	default:
+		return false
+	}
+}
+
+func isPLSQLBlock(stmt string) bool {
+	fields := strings.Fields(stmt)
+	if len(fields) == 0 {
+		return false
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "DECLARE", "BEGIN":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,380 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// dumpInsertBatchSize is the maximum number of rows in one INSERT ALL statement.
+	dumpInsertBatchSize = 100
+	// dumpInsertMaxColumns is the maximum number of target columns in one INSERT ALL statement.
+	dumpInsertMaxColumns = 999
+	// dumpLOBChunkSize is the number of characters or bytes written to the temporary LOB at once,
+	// it keeps the literals below the 32767 bytes limit of PL/SQL.
+	dumpLOBChunkSize = 4000
+
+	oracleNumericCharacters = `'NLS_NUMERIC_CHARACTERS=''.,'''`
+	oracleDateFormat        = "SYYYY-MM-DD HH24:MI:SS"
+	oracleTimestampFormat   = "SYYYY-MM-DD HH24:MI:SS.FF9"
+	oracleTimestampTZFormat = "SYYYY-MM-DD HH24:MI:SS.FF9 TZR"
+)
+
+// dumpDataColumn is the column written in the INSERT statements of the data section.
+type dumpDataColumn struct {
+	name string
+	// selectExpression converts the column value to the text or bytes formatted by literal.
+	selectExpression string
+	// literal formats the selected value as the literal of the column type.
+	literal func(string) string
+	// lobType is the PL/SQL type of the temporary LOB for the LOB columns.
+	// The LOB values may exceed the size limit of the literals, so they are written by DBMS_LOB in PL/SQL blocks.
+	lobType string
+	// lobWrapper wraps the temporary LOB to the column type, such as XMLTYPE.
+	lobWrapper string
+}
+
+// dumpTableData dumps the rows of the tables as INSERT statements, the tables are ordered by the foreign keys
+// so that the referenced rows are restored first.
+func dumpTableData(ctx context.Context, txn *sql.Tx, tableMap map[string]*tableSchema, out io.Writer) error {
+	for _, table := range sortTablesByForeignKey(tableMap) {
+		if table.meta.Temporary.Valid && table.meta.Temporary.String == "Y" {
+			continue
+		}
+		if len(table.fields) > 0 && table.fields[0].ExtTableName.Valid {
+			continue
+		}
+		if err := dumpSingleTableData(ctx, txn, table, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dumpSingleTableData(ctx context.Context, txn *sql.Tx, table *tableSchema, out io.Writer) error {
+	tableName := fmt.Sprintf(`"%s"."%s"`, table.meta.Owner.String, table.meta.TableName.String)
+	var columns []*dumpDataColumn
+	for _, field := range table.fields {
+		// The hidden columns are maintained by Oracle.
+		if !field.ColumnID.Valid {
+			continue
+		}
+		column, err := newDumpDataColumn(field)
+		if err != nil {
+			return errors.Wrapf(err, "failed to dump data of table %s", tableName)
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	var selectList []string
+	for _, column := range columns {
+		selectList = append(selectList, column.selectExpression)
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(selectList, ", "), tableName)
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to query data of table %s", tableName)
+	}
+	defer rows.Close()
+
+	writer := newTableDataWriter(tableName, columns, out)
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for rows.Next() {
+		for i, column := range columns {
+			if column.lobType == "BLOB" {
+				values[i] = new([]byte)
+			} else {
+				values[i] = new(sql.NullString)
+			}
+			pointers[i] = values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+		row := make([]any, len(columns))
+		for i, value := range values {
+			switch v := value.(type) {
+			case *[]byte:
+				if *v != nil {
+					row[i] = *v
+				}
+			case *sql.NullString:
+				if v.Valid {
+					row[i] = v.String
+				}
+			}
+		}
+		if err := writer.writeRow(row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return writer.flush()
+}
+
+// newDumpDataColumn returns the dump data column of the field, the values are selected as texts converted
+// with explicit formats, so they don't depend on the NLS settings of the session.
+func newDumpDataColumn(field *fieldMeta) (*dumpDataColumn, error) {
+	name := fmt.Sprintf(`"%s"`, field.ColumnName.String)
+	dataType := strings.ToUpper(field.DataType.String)
+	column := &dumpDataColumn{name: name, selectExpression: name, literal: quoteStringLiteral}
+	switch {
+	case field.DataTypeOwner.Valid && dataType != "XMLTYPE":
+		return nil, errors.Errorf("column %s of user-defined type %s.%s is not supported", name, field.DataTypeOwner.String, dataType)
+	case dataType == "NUMBER" || dataType == "FLOAT" || dataType == "INTEGER":
+		column.selectExpression = fmt.Sprintf("TO_CHAR(%s, 'TM9', %s)", name, oracleNumericCharacters)
+		column.literal = func(s string) string { return s }
+	case dataType == "BINARY_FLOAT" || dataType == "BINARY_DOUBLE":
+		column.selectExpression = fmt.Sprintf("TO_CHAR(%s, 'TM9', %s)", name, oracleNumericCharacters)
+		column.literal = func(s string) string { return formatBinaryFloatLiteral(dataType, s) }
+	case dataType == "DATE":
+		column.selectExpression = fmt.Sprintf("TO_CHAR(%s, '%s')", name, oracleDateFormat)
+		column.literal = func(s string) string {
+			return fmt.Sprintf("TO_DATE(%s, '%s')", quoteStringLiteral(s), oracleDateFormat)
+		}
+	case strings.HasPrefix(dataType, "TIMESTAMP") && strings.HasSuffix(dataType, "WITH LOCAL TIME ZONE"):
+		column.selectExpression = fmt.Sprintf("TO_CHAR(CAST(%s AS TIMESTAMP WITH TIME ZONE), '%s')", name, oracleTimestampTZFormat)
+		column.literal = func(s string) string {
+			return fmt.Sprintf("TO_TIMESTAMP_TZ(%s, '%s')", quoteStringLiteral(s), oracleTimestampTZFormat)
+		}
+	case strings.HasPrefix(dataType, "TIMESTAMP") && strings.HasSuffix(dataType, "WITH TIME ZONE"):
+		column.selectExpression = fmt.Sprintf("TO_CHAR(%s, '%s')", name, oracleTimestampTZFormat)
+		column.literal = func(s string) string {
+			return fmt.Sprintf("TO_TIMESTAMP_TZ(%s, '%s')", quoteStringLiteral(s), oracleTimestampTZFormat)
+		}
+	case strings.HasPrefix(dataType, "TIMESTAMP"):
+		column.selectExpression = fmt.Sprintf("TO_CHAR(%s, '%s')", name, oracleTimestampFormat)
+		column.literal = func(s string) string {
+			return fmt.Sprintf("TO_TIMESTAMP(%s, '%s')", quoteStringLiteral(s), oracleTimestampFormat)
+		}
+	case strings.HasPrefix(dataType, "INTERVAL YEAR"):
+		column.selectExpression = fmt.Sprintf("TO_CHAR(%s)", name)
+		column.literal = func(s string) string { return fmt.Sprintf("TO_YMINTERVAL(%s)", quoteStringLiteral(s)) }
+	case strings.HasPrefix(dataType, "INTERVAL DAY"):
+		column.selectExpression = fmt.Sprintf("TO_CHAR(%s)", name)
+		column.literal = func(s string) string { return fmt.Sprintf("TO_DSINTERVAL(%s)", quoteStringLiteral(s)) }
+	case dataType == "CHAR" || dataType == "VARCHAR2":
+	case dataType == "NCHAR" || dataType == "NVARCHAR2":
+		column.literal = func(s string) string { return "N" + quoteStringLiteral(s) }
+	case dataType == "RAW":
+		column.selectExpression = fmt.Sprintf("RAWTOHEX(%s)", name)
+		column.literal = func(s string) string { return fmt.Sprintf("HEXTORAW('%s')", s) }
+	case dataType == "ROWID" || dataType == "UROWID":
+		column.selectExpression = fmt.Sprintf("ROWIDTOCHAR(%s)", name)
+		column.literal = func(s string) string { return fmt.Sprintf("CHARTOROWID(%s)", quoteStringLiteral(s)) }
+	case dataType == "CLOB" || dataType == "NCLOB" || dataType == "BLOB":
+		column.lobType = dataType
+	case dataType == "XMLTYPE":
+		column.selectExpression = fmt.Sprintf("XMLSERIALIZE(CONTENT %s AS CLOB)", name)
+		column.lobType = "CLOB"
+		column.lobWrapper = "XMLTYPE"
+	default:
+		return nil, errors.Errorf("column %s of type %s is not supported", name, dataType)
+	}
+	return column, nil
+}
+
+// formatBinaryFloatLiteral formats the output of TO_CHAR(value, 'TM9') as the BINARY_FLOAT or BINARY_DOUBLE literal.
+func formatBinaryFloatLiteral(dataType, s string) string {
+	switch s {
+	case "Inf":
+		return dataType + "_INFINITY"
+	case "-Inf":
+		return "-" + dataType + "_INFINITY"
+	case "Nan":
+		return dataType + "_NAN"
+	}
+	if dataType == "BINARY_FLOAT" {
+		return s + "f"
+	}
+	return s + "d"
+}
+
+func quoteStringLiteral(s string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", "''"))
+}
+
+// tableDataWriter writes the rows of a table as INSERT ALL statements of at most dumpInsertBatchSize rows.
+// The rows with LOB values are written as PL/SQL blocks one by one.
+type tableDataWriter struct {
+	tableName  string
+	columns    []*dumpDataColumn
+	columnList string
+	batchSize  int
+	batch      []string
+	out        io.Writer
+}
+
+func newTableDataWriter(tableName string, columns []*dumpDataColumn, out io.Writer) *tableDataWriter {
+	var names []string
+	for _, column := range columns {
+		names = append(names, column.name)
+	}
+	return &tableDataWriter{
+		tableName:  tableName,
+		columns:    columns,
+		columnList: strings.Join(names, ", "),
+		batchSize:  max(1, min(dumpInsertBatchSize, dumpInsertMaxColumns/len(columns))),
+		out:        out,
+	}
+}
+
+// writeRow writes the row, the values are nil for NULL, []byte for BLOB and string for the others.
+func (w *tableDataWriter) writeRow(row []any) error {
+	for i, value := range row {
+		if value != nil && w.columns[i].lobType != "" {
+			if err := w.flush(); err != nil {
+				return err
+			}
+			return w.writeLOBRow(row)
+		}
+	}
+	var list []string
+	for i, value := range row {
+		if value == nil {
+			list = append(list, "NULL")
+			continue
+		}
+		list = append(list, w.columns[i].literal(value.(string)))
+	}
+	w.batch = append(w.batch, strings.Join(list, ", "))
+	if len(w.batch) >= w.batchSize {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *tableDataWriter) flush() error {
+	if len(w.batch) == 0 {
+		return nil
+	}
+	var buf strings.Builder
+	if len(w.batch) == 1 {
+		_, _ = fmt.Fprintf(&buf, "INSERT INTO %s (%s) VALUES (%s);\n", w.tableName, w.columnList, w.batch[0])
+	} else {
+		_, _ = buf.WriteString("INSERT ALL\n")
+		for _, values := range w.batch {
+			_, _ = fmt.Fprintf(&buf, "  INTO %s (%s) VALUES (%s)\n", w.tableName, w.columnList, values)
+		}
+		_, _ = buf.WriteString("SELECT 1 FROM DUAL;\n")
+	}
+	w.batch = nil
+	if _, err := io.WriteString(w.out, buf.String()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// writeLOBRow writes the row with LOB values as a PL/SQL block, which appends the LOB values to the temporary LOBs in chunks.
+func (w *tableDataWriter) writeLOBRow(row []any) error {
+	var declare, body strings.Builder
+	var list []string
+	for i, value := range row {
+		column := w.columns[i]
+		if value == nil {
+			list = append(list, "NULL")
+			continue
+		}
+		if column.lobType == "" {
+			list = append(list, column.literal(value.(string)))
+			continue
+		}
+		variable := fmt.Sprintf("v%d", i)
+		_, _ = fmt.Fprintf(&declare, "  %s %s;\n", variable, column.lobType)
+		_, _ = fmt.Fprintf(&body, "  DBMS_LOB.CREATETEMPORARY(%s, TRUE);\n", variable)
+		switch v := value.(type) {
+		case []byte:
+			for start := 0; start < len(v); start += dumpLOBChunkSize {
+				chunk := v[start:min(start+dumpLOBChunkSize, len(v))]
+				_, _ = fmt.Fprintf(&body, "  DBMS_LOB.WRITEAPPEND(%s, %d, HEXTORAW('%s'));\n", variable, len(chunk), strings.ToUpper(hex.EncodeToString(chunk)))
+			}
+		case string:
+			prefix := ""
+			if column.lobType == "NCLOB" {
+				prefix = "N"
+			}
+			for _, chunk := range splitByCharacters(v, dumpLOBChunkSize) {
+				_, _ = fmt.Fprintf(&body, "  DBMS_LOB.WRITEAPPEND(%s, %d, %s%s);\n", variable, utf8.RuneCountInString(chunk), prefix, quoteStringLiteral(chunk))
+			}
+		}
+		if column.lobWrapper != "" {
+			variable = fmt.Sprintf("%s(%s)", column.lobWrapper, variable)
+		}
+		list = append(list, variable)
+	}
+	block := fmt.Sprintf("DECLARE\n%sBEGIN\n%s  INSERT INTO %s (%s) VALUES (%s);\nEND;\n", declare.String(), body.String(), w.tableName, w.columnList, strings.Join(list, ", "))
+	if _, err := io.WriteString(w.out, block); err != nil {
+		return err
+	}
+	return nil
+}
+
+// splitByCharacters splits s into the chunks of at most size characters.
+func splitByCharacters(s string, size int) []string {
+	var chunks []string
+	for len(s) > 0 {
+		end, count := 0, 0
+		for end < len(s) && count < size {
+			_, width := utf8.DecodeRuneInString(s[end:])
+			end += width
+			count++
+		}
+		chunks = append(chunks, s[:end])
+		s = s[end:]
+	}
+	return chunks
+}
+
+// sortTablesByForeignKey sorts the tables so that the referenced tables come before the referencing tables.
+// The tables are sorted by name if there is no dependency between them, and the cycles are broken by name.
+func sortTablesByForeignKey(tableMap map[string]*tableSchema) []*tableSchema {
+	var names []string
+	for name := range tableMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []*tableSchema
+	visited := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		table, ok := tableMap[name]
+		if !ok || visited[name] {
+			return
+		}
+		visited[name] = true
+		var referenced []string
+		for _, constraint := range table.constraints {
+			if constraint.ConstraintType.String != "R" || !constraint.RTableName.Valid {
+				continue
+			}
+			if constraint.ROwner.Valid && constraint.ROwner.String != table.meta.Owner.String {
+				continue
+			}
+			referenced = append(referenced, constraint.RTableName.String)
+		}
+		sort.Strings(referenced)
+		for _, name := range referenced {
+			visit(name)
+		}
+		result = append(result, table)
+	}
+	for _, name := range names {
+		visit(name)
+	}
+	return result
+}
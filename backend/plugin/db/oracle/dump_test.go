@@ -0,0 +1,155 @@
+package oracle
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDMLStatement(t *testing.T) {
+	tests := []struct {
+		stmt string
+		want bool
+	}{
+		{stmt: `INSERT INTO "T" VALUES (1)`, want: true},
+		{stmt: "insert\ninto t values (1)", want: true},
+		{stmt: "MERGE INTO t USING s ON (t.id = s.id) WHEN MATCHED THEN UPDATE SET t.a = s.a", want: true},
+		{stmt: `CREATE TABLE "T" ("ID" NUMBER)`, want: false},
+		{stmt: "", want: false},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, isDMLStatement(test.stmt), test.stmt)
+	}
+}
+
+func TestDumpDataRestoreRowCount(t *testing.T) {
+	a := require.New(t)
+	field := func(name, dataType string) *fieldMeta {
+		return &fieldMeta{
+			ColumnName: sql.NullString{String: name, Valid: true},
+			DataType:   sql.NullString{String: dataType, Valid: true},
+			ColumnID:   sql.NullInt64{Int64: 1, Valid: true},
+		}
+	}
+	tables := map[string][]*fieldMeta{
+		"PARENT": {field("ID", "NUMBER"), field("NAME", "VARCHAR2"), field("CREATED", "DATE")},
+		"CHILD":  {field("ID", "NUMBER"), field("DOC", "CLOB"), field("DATA", "BLOB")},
+	}
+	rowCounts := map[string]int{
+		`"S"."PARENT"`: 2*dumpInsertBatchSize + 3,
+		`"S"."CHILD"`:  5,
+	}
+
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("CREATE TABLE \"S\".\"PARENT\" (\"ID\" NUMBER DEFAULT 1, \"NAME\" VARCHAR2(10) DEFAULT ';');\n")
+	for name, fields := range tables {
+		tableName := fmt.Sprintf(`"S"."%s"`, name)
+		var columns []*dumpDataColumn
+		for _, field := range fields {
+			column, err := newDumpDataColumn(field)
+			a.NoError(err)
+			columns = append(columns, column)
+		}
+		writer := newTableDataWriter(tableName, columns, &buf)
+		for i := 0; i < rowCounts[tableName]; i++ {
+			row := []any{fmt.Sprint(i), "it's; a value", "2023-01-02 03:04:05"}
+			if name == "CHILD" {
+				row = []any{fmt.Sprint(i), nil, nil}
+				if i%2 == 0 {
+					// The LOB values longer than the chunk size are appended in multiple chunks.
+					row = []any{fmt.Sprint(i), strings.Repeat("中;'", dumpLOBChunkSize/2), bytes.Repeat([]byte{0, 0xff}, dumpLOBChunkSize/2+1)}
+				}
+			}
+			a.NoError(writer.writeRow(row))
+		}
+		a.NoError(writer.flush())
+	}
+
+	restored := make(map[string]int)
+	intoRegexp := regexp.MustCompile(`INTO ("S"\."\w+") \(`)
+	ddlCount := 0
+	_, err := restoreStatements(&buf, func(stmt string, dml bool) error {
+		if !dml && !isPLSQLBlock(stmt) {
+			ddlCount++
+			a.Equal(`CREATE TABLE "S"."PARENT" ("ID" NUMBER DEFAULT 1, "NAME" VARCHAR2(10) DEFAULT ';')`, stmt)
+			return nil
+		}
+		if !dml {
+			a.True(strings.HasSuffix(stmt, "END;"), stmt)
+		}
+		for _, match := range intoRegexp.FindAllStringSubmatch(stmt, -1) {
+			restored[match[1]]++
+		}
+		return nil
+	})
+	a.NoError(err)
+	a.Equal(1, ddlCount)
+	a.Equal(rowCounts, restored)
+}
+
+func TestNewDumpDataColumn(t *testing.T) {
+	tests := []struct {
+		dataType string
+		value    string
+		want     string
+	}{
+		{dataType: "NUMBER", value: "-1.5", want: "-1.5"},
+		{dataType: "BINARY_DOUBLE", value: "-Inf", want: "-BINARY_DOUBLE_INFINITY"},
+		{dataType: "BINARY_FLOAT", value: "1.5", want: "1.5f"},
+		{dataType: "VARCHAR2", value: "it's", want: "'it''s'"},
+		{dataType: "NVARCHAR2", value: "a", want: "N'a'"},
+		{dataType: "DATE", value: " 2023-01-02 03:04:05", want: "TO_DATE(' 2023-01-02 03:04:05', 'SYYYY-MM-DD HH24:MI:SS')"},
+		{dataType: "TIMESTAMP(6) WITH TIME ZONE", value: "x", want: "TO_TIMESTAMP_TZ('x', 'SYYYY-MM-DD HH24:MI:SS.FF9 TZR')"},
+		{dataType: "INTERVAL DAY(2) TO SECOND(6)", value: "+01 00:00:00.000000", want: "TO_DSINTERVAL('+01 00:00:00.000000')"},
+		{dataType: "RAW", value: "DEAD", want: "HEXTORAW('DEAD')"},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		column, err := newDumpDataColumn(&fieldMeta{
+			ColumnName: sql.NullString{String: "C", Valid: true},
+			DataType:   sql.NullString{String: test.dataType, Valid: true},
+		})
+		a.NoError(err, test.dataType)
+		a.Equal(test.want, column.literal(test.value), test.dataType)
+	}
+
+	_, err := newDumpDataColumn(&fieldMeta{
+		ColumnName: sql.NullString{String: "C", Valid: true},
+		DataType:   sql.NullString{String: "LONG", Valid: true},
+	})
+	a.Error(err)
+}
+
+func TestSortTablesByForeignKey(t *testing.T) {
+	a := require.New(t)
+	table := func(name string, references ...string) *tableSchema {
+		t := &tableSchema{meta: &tableMeta{TableName: sql.NullString{String: name, Valid: true}, Owner: sql.NullString{String: "S", Valid: true}}}
+		for _, reference := range references {
+			t.constraints = append(t.constraints, &mergedConstraintMeta{
+				ConstraintType: sql.NullString{String: "R", Valid: true},
+				ROwner:         sql.NullString{String: "S", Valid: true},
+				RTableName:     sql.NullString{String: reference, Valid: true},
+			})
+		}
+		return t
+	}
+	tableMap := map[string]*tableSchema{
+		"A": table("A", "C"),
+		"B": table("B"),
+		"C": table("C", "B"),
+		"D": table("D", "D"),
+	}
+	var names []string
+	for _, table := range sortTablesByForeignKey(tableMap) {
+		names = append(names, table.meta.TableName.String)
+	}
+	a.Equal([]string{"B", "C", "A", "D"}, names)
+}
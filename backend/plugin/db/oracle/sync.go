@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
@@ -510,12 +512,84 @@ func getViews(txn *sql.Tx, schemaName string) (map[string][]*storepb.ViewMetadat
 	return viewMap, nil
 }
 
-// SyncSlowQuery syncs the slow query.
-func (*Driver) SyncSlowQuery(_ context.Context, _ time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
-	return nil, errors.Errorf("not implemented")
+// SyncSlowQuery syncs the slow query from the AWR snapshots in DBA_HIST_SQLSTAT.
+// The *_DELTA columns hold the statistics of each snapshot interval, so we sum the intervals ending on the log date
+// instead of assigning the cumulative totals of the shared pool to a single day.
+// AWR doesn't record the statistics of the individual executions, so the maximum query time and rows sent are left unset.
+func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	args := []any{logDateTs.Format("2006-01-02"), logDateTs.AddDate(0, 0, 1).Format("2006-01-02")}
+	schemaFilter := fmt.Sprintf("s.PARSING_SCHEMA_NAME NOT IN (%s)", systemSchema)
+	if driver.schemaTenantMode {
+		schemaFilter = "s.PARSING_SCHEMA_NAME = :3"
+		args = append(args, driver.databaseName)
+	}
+	query := fmt.Sprintf(`
+		SELECT
+			DBMS_LOB.SUBSTR(t.SQL_TEXT, %d, 1),
+			SUM(s.EXECUTIONS_DELTA),
+			SUM(s.ELAPSED_TIME_DELTA),
+			SUM(s.ROWS_PROCESSED_DELTA),
+			CAST(MAX(sn.END_INTERVAL_TIME) AS DATE)
+		FROM DBA_HIST_SQLSTAT s
+			JOIN DBA_HIST_SNAPSHOT sn ON sn.SNAP_ID = s.SNAP_ID AND sn.DBID = s.DBID AND sn.INSTANCE_NUMBER = s.INSTANCE_NUMBER
+			JOIN DBA_HIST_SQLTEXT t ON t.SQL_ID = s.SQL_ID AND t.DBID = s.DBID
+		WHERE sn.END_INTERVAL_TIME >= TO_DATE(:1, 'YYYY-MM-DD')
+			AND sn.END_INTERVAL_TIME < TO_DATE(:2, 'YYYY-MM-DD')
+			AND %s
+		GROUP BY s.SQL_ID, DBMS_LOB.SUBSTR(t.SQL_TEXT, %d, 1)
+		HAVING SUM(s.EXECUTIONS_DELTA) > 0 AND SUM(s.ELAPSED_TIME_DELTA) / SUM(s.EXECUTIONS_DELTA) >= 1000000
+		ORDER BY SUM(s.ELAPSED_TIME_DELTA) DESC`, db.SlowQueryMaxLen, schemaFilter, db.SlowQueryMaxLen)
+
+	rows, err := driver.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var items []*storepb.SlowQueryStatisticsItem
+	for rows.Next() {
+		var sqlText string
+		var executions, elapsedTime, rowsProcessed int64
+		var lastActiveTime time.Time
+		if err := rows.Scan(&sqlText, &executions, &elapsedTime, &rowsProcessed, &lastActiveTime); err != nil {
+			return nil, err
+		}
+		if len(items) >= db.SlowQueryMaxSamplePerDay {
+			continue
+		}
+		if len(sqlText) > db.SlowQueryMaxLen {
+			sqlText = sqlText[:db.SlowQueryMaxLen]
+		}
+		items = append(items, &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint: util.GetSlowQueryFingerprint(storepb.Engine_ORACLE, sqlText),
+			Count:          executions,
+			LatestLogTime:  timestamppb.New(lastActiveTime),
+			// ELAPSED_TIME_DELTA is in microseconds.
+			TotalQueryTime: durationpb.New(time.Duration(elapsedTime) * time.Microsecond),
+			TotalRowsSent:  rowsProcessed,
+			Samples: []*storepb.SlowQueryDetails{
+				{
+					StartTime: timestamppb.New(lastActiveTime),
+					SqlText:   sqlText,
+				},
+			},
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+
+	return map[string]*storepb.SlowQueryStatistics{
+		driver.databaseName: util.MergeSlowQueryStatistics(items),
+	}, nil
 }
 
-// CheckSlowQueryLogEnabled checks if slow query log is enabled.
-func (*Driver) CheckSlowQueryLogEnabled(_ context.Context) error {
-	return errors.Errorf("not implemented")
+// CheckSlowQueryLogEnabled checks if the user has the privilege to read the AWR snapshots.
+func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
+	query := "SELECT COUNT(*) FROM DBA_HIST_SQLSTAT s JOIN DBA_HIST_SNAPSHOT sn ON sn.SNAP_ID = s.SNAP_ID AND sn.DBID = s.DBID AND sn.INSTANCE_NUMBER = s.INSTANCE_NUMBER WHERE ROWNUM = 1"
+	var count int64
+	if err := driver.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return errors.Wrapf(util.FormatErrorWithQuery(err, query), "failed to read the AWR snapshots, please grant SELECT ON DBA_HIST_SQLSTAT, DBA_HIST_SNAPSHOT and DBA_HIST_SQLTEXT to the user")
+	}
+	return nil
 }
@@ -133,6 +133,18 @@ func (driver *Driver) Open(_ context.Context, _ storepb.Engine, config db.Connec
 	}
 	driver.config = config
 
+	if config.AuthTokenProvider != nil {
+		// Use a fresh authentication token as the password for each connection.
+		driver.db = stdlib.OpenDB(*connConfig, stdlib.OptionBeforeConnect(func(ctx context.Context, cfg *pgx.ConnConfig) error {
+			token, err := config.AuthTokenProvider(ctx)
+			if err != nil {
+				return errors.Wrap(err, "failed to get authentication token")
+			}
+			cfg.Password = token
+			return nil
+		}))
+		return driver, nil
+	}
 	driver.connectionString = stdlib.RegisterConnConfig(connConfig)
 	db, err := sql.Open(driverName, driver.connectionString)
 	if err != nil {
@@ -261,7 +273,7 @@ func (driver *Driver) getPGStatStatementsVersion(ctx context.Context) (string, e
 
 // Execute will execute the statement. For CREATE DATABASE statement, some types of databases such as Postgres
 // will not use transactions to execute the statement but will still use transactions to execute the rest of statements.
-func (driver *Driver) Execute(ctx context.Context, statement string, createDatabase bool, _ db.ExecuteOptions) (int64, error) {
+func (driver *Driver) Execute(ctx context.Context, statement string, createDatabase bool, opts db.ExecuteOptions) (int64, error) {
 	if createDatabase {
 		databases, err := driver.getDatabases(ctx)
 		if err != nil {
@@ -340,11 +352,33 @@ func (driver *Driver) Execute(ctx context.Context, statement string, createDatab
 		if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL ROLE '%s'", owner)); err != nil {
 			return 0, err
 		}
+		for _, stmt := range getTimeoutStatements("SET LOCAL", opts) {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return 0, err
+			}
+		}
+
+		if opts.BeginTransactionFunc != nil {
+			if err := opts.BeginTransactionFunc(tx); err != nil {
+				return 0, errors.Wrapf(err, "failed to execute beginTx")
+			}
+		}
 
 		sqlResult, err := tx.ExecContext(ctx, strings.Join(remainingStmts, "\n"))
 		if err != nil {
+			if isLockTimeoutError(err) {
+				// The transaction is rolled back, so it's safe to retry.
+				return 0, &db.LockTimeoutError{Err: err}
+			}
 			return 0, err
 		}
+
+		if opts.EndTransactionFunc != nil {
+			if err := opts.EndTransactionFunc(tx); err != nil {
+				return 0, errors.Wrapf(err, "failed to execute beforeCommitTx")
+			}
+		}
+
 		if err := tx.Commit(); err != nil {
 			return 0, err
 		}
@@ -358,14 +392,58 @@ func (driver *Driver) Execute(ctx context.Context, statement string, createDatab
 	}
 
 	// Run non-transaction statements at the end.
-	for _, stmt := range nonTransactionStmts {
-		if _, err := driver.db.ExecContext(ctx, stmt); err != nil {
+	if len(nonTransactionStmts) == 0 {
+		return totalRowsAffected, nil
+	}
+	conn, err := driver.db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	timeoutStmts := getTimeoutStatements("SET", opts)
+	for _, stmt := range timeoutStmts {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return 0, err
+		}
+	}
+	if len(timeoutStmts) > 0 {
+		defer func() {
+			// Reset the session settings before returning the connection to the pool.
+			if _, err := conn.ExecContext(context.Background(), "RESET lock_timeout; RESET statement_timeout;"); err != nil {
+				slog.Warn("failed to reset the session timeouts", log.BBError(err))
+			}
+		}()
+	}
+	for i, stmt := range nonTransactionStmts {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			if i == 0 && len(remainingStmts) == 0 && isLockTimeoutError(err) {
+				// Nothing has been applied yet, so it's safe to retry.
+				return 0, &db.LockTimeoutError{Err: err}
+			}
 			return 0, err
 		}
 	}
 	return totalRowsAffected, nil
 }
 
+// getTimeoutStatements returns the statements to set the lock_timeout and statement_timeout, e.g. "SET LOCAL" for the transaction
+// and "SET" for the session.
+func getTimeoutStatements(set string, opts db.ExecuteOptions) []string {
+	var stmts []string
+	if opts.LockTimeout > 0 {
+		stmts = append(stmts, fmt.Sprintf("%s lock_timeout = '%dms'", set, opts.LockTimeout.Milliseconds()))
+	}
+	if opts.StatementTimeout > 0 {
+		stmts = append(stmts, fmt.Sprintf("%s statement_timeout = '%dms'", set, opts.StatementTimeout.Milliseconds()))
+	}
+	return stmts
+}
+
+// isLockTimeoutError returns true if the error is lock_not_available, which is raised when lock_timeout is exceeded.
+func isLockTimeoutError(err error) bool {
+	return strings.Contains(err.Error(), "SQLSTATE 55P03")
+}
+
 func isSuperuserStatement(stmt string) bool {
 	upperCaseStmt := strings.ToUpper(stmt)
 	if strings.HasPrefix(upperCaseStmt, "GRANT") || strings.HasPrefix(upperCaseStmt, "CREATE EXTENSION") || strings.HasPrefix(upperCaseStmt, "CREATE EVENT TRIGGER") || strings.HasPrefix(upperCaseStmt, "COMMENT ON EVENT TRIGGER") {
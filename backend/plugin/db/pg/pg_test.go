@@ -2,8 +2,11 @@ package pg
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
 )
 
 func TestGetDatabaseInCreateDatabaseStatement(t *testing.T) {
@@ -49,3 +52,32 @@ func TestGetDatabaseInCreateDatabaseStatement(t *testing.T) {
 		require.Equal(t, test.want, got)
 	}
 }
+
+func TestGetTimeoutStatements(t *testing.T) {
+	tests := []struct {
+		set  string
+		opts db.ExecuteOptions
+		want []string
+	}{
+		{
+			set:  "SET LOCAL",
+			opts: db.ExecuteOptions{},
+			want: nil,
+		},
+		{
+			set:  "SET LOCAL",
+			opts: db.ExecuteOptions{LockTimeout: 3 * time.Second},
+			want: []string{"SET LOCAL lock_timeout = '3000ms'"},
+		},
+		{
+			set:  "SET",
+			opts: db.ExecuteOptions{LockTimeout: 500 * time.Millisecond, StatementTimeout: time.Minute},
+			want: []string{"SET lock_timeout = '500ms'", "SET statement_timeout = '60000ms'"},
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, getTimeoutStatements(test.set, test.opts))
+	}
+}
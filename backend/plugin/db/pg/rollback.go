@@ -0,0 +1,399 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+	pgrawparser "github.com/bytebase/bytebase/backend/plugin/parser/sql/engine/pg"
+)
+
+const (
+	// rollbackCaptureName is the name of the savepoint, the trigger and the trigger function used to capture the before-images.
+	rollbackCaptureName = "bytebase_rollback_capture"
+	// rollbackLogTableName is the name of the temporary table storing the before-images.
+	rollbackLogTableName = "bytebase_rollback_log"
+
+	rollbackOperationInsert = "INSERT"
+	rollbackOperationUpdate = "UPDATE"
+	rollbackOperationDelete = "DELETE"
+)
+
+// RollbackCapture captures the before-images of the rows changed by a data change transaction,
+// and generates the rollback statements from them before the transaction commits.
+//
+// The before-images are captured by the row-level triggers on the changed tables, which write the OLD and NEW rows
+// into a temporary table. The triggers, the trigger function and the temporary table are created and dropped in the
+// same transaction, so nothing is left in the database after the transaction ends.
+// Note that creating triggers blocks the concurrent writes on the changed tables until the transaction ends.
+type RollbackCapture struct {
+	tables []*rollbackTable
+	// begun is true if the triggers are created in the transaction.
+	begun bool
+}
+
+// rollbackTable is the table captured by the RollbackCapture.
+type rollbackTable struct {
+	// schema and name are the schema and table name in the statement, the schema may be empty.
+	schema string
+	name   string
+	// resolvedSchema and resolvedName are the schema and table name resolved in the database.
+	resolvedSchema string
+	resolvedName   string
+	primaryKey     []string
+	// insertableColumns are the columns in the order of the table definition, excluding the generated columns.
+	insertableColumns []string
+	// generatedColumns are the generated columns, which cannot be inserted or updated.
+	generatedColumns map[string]bool
+	// hasIdentityAlways is true if the table has GENERATED ALWAYS identity columns, which require OVERRIDING SYSTEM VALUE to insert.
+	hasIdentityAlways bool
+}
+
+// NewRollbackCapture creates a RollbackCapture for the data change statement.
+// It only supports the statement consisting of INSERT, UPDATE, DELETE and SELECT statements.
+func NewRollbackCapture(statement string) (*RollbackCapture, error) {
+	nodes, err := pgrawparser.Parse(pgrawparser.ParseContext{}, statement)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse statement")
+	}
+	capture := &RollbackCapture{}
+	tableSet := make(map[string]bool)
+	for _, node := range nodes {
+		var table *ast.TableDef
+		switch node := node.(type) {
+		case *ast.InsertStmt:
+			table = node.Table
+		case *ast.UpdateStmt:
+			table = node.Table
+		case *ast.DeleteStmt:
+			table = node.Table
+		case *ast.SelectStmt:
+			continue
+		default:
+			text := ""
+			if node != nil {
+				text = node.Text()
+			}
+			return nil, errors.Errorf("rollback SQL generation only supports INSERT, UPDATE and DELETE statements, but found %q", text)
+		}
+		if table == nil {
+			continue
+		}
+		key := fmt.Sprintf("%s.%s", table.Schema, table.Name)
+		if tableSet[key] {
+			continue
+		}
+		tableSet[key] = true
+		capture.tables = append(capture.tables, &rollbackTable{schema: table.Schema, name: table.Name})
+	}
+	return capture, nil
+}
+
+// Begin creates the triggers capturing the before-images in the transaction.
+// It rolls back to the savepoint on error, so the transaction can continue without the capture.
+func (c *RollbackCapture) Begin(ctx context.Context, tx *sql.Tx) error {
+	if len(c.tables) == 0 {
+		return nil
+	}
+	return runInSavepoint(ctx, tx, func() error {
+		for _, table := range c.tables {
+			if err := table.resolve(ctx, tx); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+			CREATE TEMPORARY TABLE %s (
+				id BIGSERIAL PRIMARY KEY,
+				schema_name TEXT NOT NULL,
+				table_name TEXT NOT NULL,
+				operation TEXT NOT NULL,
+				old_row JSONB,
+				new_row JSONB
+			) ON COMMIT DROP`, rollbackLogTableName)); err != nil {
+			return errors.Wrapf(err, "failed to create the temporary table for rollback")
+		}
+		// The OLD and NEW records are only referenced in the branches they are assigned for the compatibility with the old versions.
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+			CREATE OR REPLACE FUNCTION pg_temp.%[1]s() RETURNS TRIGGER AS $bytebase$
+			BEGIN
+				IF TG_OP = 'INSERT' THEN
+					INSERT INTO pg_temp.%[2]s (schema_name, table_name, operation, new_row) VALUES (TG_TABLE_SCHEMA, TG_TABLE_NAME, TG_OP, to_jsonb(NEW));
+				ELSIF TG_OP = 'UPDATE' THEN
+					INSERT INTO pg_temp.%[2]s (schema_name, table_name, operation, old_row, new_row) VALUES (TG_TABLE_SCHEMA, TG_TABLE_NAME, TG_OP, to_jsonb(OLD), to_jsonb(NEW));
+				ELSIF TG_OP = 'DELETE' THEN
+					INSERT INTO pg_temp.%[2]s (schema_name, table_name, operation, old_row) VALUES (TG_TABLE_SCHEMA, TG_TABLE_NAME, TG_OP, to_jsonb(OLD));
+				END IF;
+				RETURN NULL;
+			END;
+			$bytebase$ LANGUAGE plpgsql`, rollbackCaptureName, rollbackLogTableName)); err != nil {
+			return errors.Wrapf(err, "failed to create the trigger function for rollback")
+		}
+		for _, table := range c.tables {
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE TRIGGER %s AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE PROCEDURE pg_temp.%s()", rollbackCaptureName, table.identifier(), rollbackCaptureName)); err != nil {
+				return errors.Wrapf(err, "failed to create the trigger for rollback on table %s", table.identifier())
+			}
+		}
+		c.begun = true
+		return nil
+	})
+}
+
+// Generate generates the rollback statements from the before-images captured in the transaction.
+// It must be called before the transaction commits, because the temporary table is dropped on commit.
+func (c *RollbackCapture) Generate(ctx context.Context, tx *sql.Tx) (string, error) {
+	if !c.begun {
+		return "", nil
+	}
+	tableMap := make(map[string]*rollbackTable)
+	for _, table := range c.tables {
+		tableMap[fmt.Sprintf("%s.%s", table.resolvedSchema, table.resolvedName)] = table
+	}
+
+	var buf strings.Builder
+	if err := runInSavepoint(ctx, tx, func() error {
+		// Revert the changes in the reverse order.
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT schema_name, table_name, operation, old_row::TEXT, new_row::TEXT FROM pg_temp.%s ORDER BY id DESC", rollbackLogTableName))
+		if err != nil {
+			return errors.Wrapf(err, "failed to query the before-images")
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var schemaName, tableName, operation string
+			var oldRow, newRow sql.NullString
+			if err := rows.Scan(&schemaName, &tableName, &operation, &oldRow, &newRow); err != nil {
+				return errors.Wrapf(err, "failed to scan the before-image")
+			}
+			table, ok := tableMap[fmt.Sprintf("%s.%s", schemaName, tableName)]
+			if !ok {
+				return errors.Errorf("unexpected before-image of table %s", pgx.Identifier{schemaName, tableName}.Sanitize())
+			}
+			statement, err := table.generateRollbackStatement(operation, oldRow.String, newRow.String)
+			if err != nil {
+				return err
+			}
+			if _, err := buf.WriteString(statement); err != nil {
+				return err
+			}
+			if _, err := buf.WriteString("\n"); err != nil {
+				return err
+			}
+			if buf.Len() > common.MaxSheetSize {
+				return errors.Errorf("rollback SQL statement exceeds the size limit %d", common.MaxSheetSize)
+			}
+		}
+		return rows.Err()
+	}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Cleanup drops the triggers, the trigger function and the temporary table created by Begin.
+// The transaction must not be committed if it returns error, otherwise the triggers are left in the database.
+func (c *RollbackCapture) Cleanup(ctx context.Context, tx *sql.Tx) error {
+	if !c.begun {
+		return nil
+	}
+	for _, table := range c.tables {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s", rollbackCaptureName, table.identifier())); err != nil {
+			return errors.Wrapf(err, "failed to drop the trigger for rollback on table %s", table.identifier())
+		}
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP FUNCTION IF EXISTS pg_temp.%s()", rollbackCaptureName)); err != nil {
+		return errors.Wrapf(err, "failed to drop the trigger function for rollback")
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS pg_temp.%s", rollbackLogTableName)); err != nil {
+		return errors.Wrapf(err, "failed to drop the temporary table for rollback")
+	}
+	c.begun = false
+	return nil
+}
+
+// runInSavepoint runs f in a savepoint, and rolls back to the savepoint if f fails,
+// because any error aborts the whole transaction in PostgreSQL.
+func runInSavepoint(ctx context.Context, tx *sql.Tx, f func() error) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+rollbackCaptureName); err != nil {
+		return errors.Wrapf(err, "failed to create savepoint")
+	}
+	if err := f(); err != nil {
+		if _, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+rollbackCaptureName); rollbackErr != nil {
+			return errors.Wrapf(rollbackErr, "failed to rollback to savepoint after error %v", err)
+		}
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+rollbackCaptureName); err != nil {
+		return errors.Wrapf(err, "failed to release savepoint")
+	}
+	return nil
+}
+
+// resolve resolves the schema, name and primary key of the table in the database.
+func (t *rollbackTable) resolve(ctx context.Context, tx *sql.Tx) error {
+	// The table without schema is resolved by the search path.
+	name := pgx.Identifier{t.name}.Sanitize()
+	if t.schema != "" {
+		name = pgx.Identifier{t.schema, t.name}.Sanitize()
+	}
+	if err := tx.QueryRowContext(ctx, `
+		SELECT n.nspname, c.relname
+		FROM pg_catalog.pg_class c JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.oid = to_regclass($1)`, name).Scan(&t.resolvedSchema, &t.resolvedName); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.Errorf("table %s does not exist before the data change", name)
+		}
+		return errors.Wrapf(err, "failed to find table %s", name)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT a.attname
+		FROM pg_catalog.pg_index i JOIN pg_catalog.pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = to_regclass($1) AND i.indisprimary
+		ORDER BY a.attnum`, t.identifier())
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the primary key of table %s", t.identifier())
+	}
+	defer rows.Close()
+	t.primaryKey = nil
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return err
+		}
+		t.primaryKey = append(t.primaryKey, column)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return t.resolveColumns(ctx, tx)
+}
+
+// resolveColumns resolves the insertable columns, the generated columns and the identity columns of the table.
+// The attgenerated column is read through to_jsonb because it doesn't exist before PostgreSQL 12.
+func (t *rollbackTable) resolveColumns(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT a.attname, COALESCE(to_jsonb(a) ->> 'attidentity', ''), COALESCE(to_jsonb(a) ->> 'attgenerated', '')
+		FROM pg_catalog.pg_attribute a
+		WHERE a.attrelid = to_regclass($1) AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`, t.identifier())
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the columns of table %s", t.identifier())
+	}
+	defer rows.Close()
+	t.insertableColumns = nil
+	t.generatedColumns = make(map[string]bool)
+	t.hasIdentityAlways = false
+	for rows.Next() {
+		var column, identity, generated string
+		if err := rows.Scan(&column, &identity, &generated); err != nil {
+			return err
+		}
+		if generated != "" {
+			t.generatedColumns[column] = true
+			continue
+		}
+		if identity == "a" {
+			t.hasIdentityAlways = true
+		}
+		t.insertableColumns = append(t.insertableColumns, column)
+	}
+	return rows.Err()
+}
+
+// identifier returns the quoted identifier of the resolved table.
+func (t *rollbackTable) identifier() string {
+	return pgx.Identifier{t.resolvedSchema, t.resolvedName}.Sanitize()
+}
+
+// generateRollbackStatement generates the statement reverting the change of a row.
+// The rows are the JSON text converted from the table row type, and jsonb_populate_record converts them back,
+// so that we don't need to format the values of each data type.
+func (t *rollbackTable) generateRollbackStatement(operation, oldRow, newRow string) (string, error) {
+	switch operation {
+	case rollbackOperationDelete:
+		if len(t.insertableColumns) == 0 {
+			return "", errors.Errorf("table %s has no insertable column to generate rollback SQL", t.identifier())
+		}
+		columnList := quoteColumns(t.insertableColumns)
+		overriding := ""
+		if t.hasIdentityAlways {
+			overriding = " OVERRIDING SYSTEM VALUE"
+		}
+		return fmt.Sprintf("INSERT INTO %s (%s)%s SELECT %s FROM %s;", t.identifier(), columnList, overriding, columnList, t.populateRecord(oldRow)), nil
+	case rollbackOperationInsert:
+		condition, err := t.primaryKeyCondition(newRow)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("DELETE FROM %s WHERE %s;", t.identifier(), condition), nil
+	case rollbackOperationUpdate:
+		condition, err := t.primaryKeyCondition(newRow)
+		if err != nil {
+			return "", err
+		}
+		columns, err := getChangedColumns(oldRow, newRow, t.generatedColumns)
+		if err != nil {
+			return "", err
+		}
+		if len(columns) == 0 {
+			return fmt.Sprintf("-- No column of the row in %s is changed.", t.identifier()), nil
+		}
+		columnList := quoteColumns(columns)
+		return fmt.Sprintf("UPDATE %s SET (%s) = (SELECT %s FROM %s) WHERE %s;", t.identifier(), columnList, columnList, t.populateRecord(oldRow), condition), nil
+	default:
+		return "", errors.Errorf("unsupported operation %q", operation)
+	}
+}
+
+// primaryKeyCondition returns the condition matching the row by the primary key.
+func (t *rollbackTable) primaryKeyCondition(row string) (string, error) {
+	if len(t.primaryKey) == 0 {
+		return "", errors.Errorf("table %s requires PRIMARY KEY to generate rollback SQL", t.identifier())
+	}
+	columnList := quoteColumns(t.primaryKey)
+	return fmt.Sprintf("(%s) = (SELECT %s FROM %s)", columnList, columnList, t.populateRecord(row)), nil
+}
+
+func (t *rollbackTable) populateRecord(row string) string {
+	return fmt.Sprintf("jsonb_populate_record(NULL::%s, '%s')", t.identifier(), strings.ReplaceAll(row, "'", "''"))
+}
+
+// getChangedColumns returns the sorted columns with different values in the old and new rows.
+// The generated columns are skipped because they are recomputed from the other columns.
+func getChangedColumns(oldRow, newRow string, generatedColumns map[string]bool) ([]string, error) {
+	oldValues := make(map[string]json.RawMessage)
+	if err := json.Unmarshal([]byte(oldRow), &oldValues); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the old row")
+	}
+	newValues := make(map[string]json.RawMessage)
+	if err := json.Unmarshal([]byte(newRow), &newValues); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the new row")
+	}
+	var columns []string
+	for column, oldValue := range oldValues {
+		if generatedColumns[column] {
+			continue
+		}
+		if newValue, ok := newValues[column]; ok && string(newValue) == string(oldValue) {
+			continue
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns, nil
+}
+
+func quoteColumns(columns []string) string {
+	var quoted []string
+	for _, column := range columns {
+		quoted = append(quoted, pgx.Identifier{column}.Sanitize())
+	}
+	return strings.Join(quoted, ", ")
+}
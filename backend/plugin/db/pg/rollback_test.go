@@ -0,0 +1,101 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRollbackCapture(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      []*rollbackTable
+		wantErr   bool
+	}{
+		{
+			statement: `INSERT INTO t VALUES (1); UPDATE public."T" SET a = 1; DELETE FROM t WHERE id = 1; SELECT * FROM t2;`,
+			want: []*rollbackTable{
+				{name: "t"},
+				{schema: "public", name: "T"},
+			},
+		},
+		{
+			statement: `TRUNCATE t;`,
+			wantErr:   true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		capture, err := NewRollbackCapture(test.statement)
+		if test.wantErr {
+			a.Error(err, test.statement)
+			continue
+		}
+		a.NoError(err, test.statement)
+		a.Equal(test.want, capture.tables, test.statement)
+	}
+}
+
+func TestGenerateRollbackStatement(t *testing.T) {
+	tests := []struct {
+		operation string
+		oldRow    string
+		newRow    string
+		want      string
+		wantErr   bool
+	}{
+		{
+			operation: rollbackOperationInsert,
+			newRow:    `{"id": 1, "name": "a"}`,
+			want:      `DELETE FROM "public"."t" WHERE ("id") = (SELECT "id" FROM jsonb_populate_record(NULL::"public"."t", '{"id": 1, "name": "a"}'));`,
+		},
+		{
+			operation: rollbackOperationDelete,
+			oldRow:    `{"id": 1, "name": "a'b"}`,
+			want:      `INSERT INTO "public"."t" ("id", "name") SELECT "id", "name" FROM jsonb_populate_record(NULL::"public"."t", '{"id": 1, "name": "a''b"}');`,
+		},
+		{
+			operation: rollbackOperationUpdate,
+			oldRow:    `{"id": 1, "age": 2, "name": "a"}`,
+			newRow:    `{"id": 1, "age": 2, "name": "b"}`,
+			want:      `UPDATE "public"."t" SET ("name") = (SELECT "name" FROM jsonb_populate_record(NULL::"public"."t", '{"id": 1, "age": 2, "name": "a"}')) WHERE ("id") = (SELECT "id" FROM jsonb_populate_record(NULL::"public"."t", '{"id": 1, "age": 2, "name": "b"}'));`,
+		},
+		{
+			operation: "TRUNCATE",
+			wantErr:   true,
+		},
+	}
+
+	a := require.New(t)
+	table := &rollbackTable{resolvedSchema: "public", resolvedName: "t", primaryKey: []string{"id"}, insertableColumns: []string{"id", "name"}}
+	for _, test := range tests {
+		got, err := table.generateRollbackStatement(test.operation, test.oldRow, test.newRow)
+		if test.wantErr {
+			a.Error(err)
+			continue
+		}
+		a.NoError(err)
+		a.Equal(test.want, got)
+	}
+
+	// The generated columns are neither inserted nor updated, and the GENERATED ALWAYS identity columns are inserted with OVERRIDING SYSTEM VALUE.
+	generatedTable := &rollbackTable{
+		resolvedSchema:    "public",
+		resolvedName:      "t",
+		primaryKey:        []string{"id"},
+		insertableColumns: []string{"id", "price"},
+		generatedColumns:  map[string]bool{"total": true},
+		hasIdentityAlways: true,
+	}
+	got, err := generatedTable.generateRollbackStatement(rollbackOperationDelete, `{"id": 1, "price": 2, "total": 4}`, "")
+	a.NoError(err)
+	a.Equal(`INSERT INTO "public"."t" ("id", "price") OVERRIDING SYSTEM VALUE SELECT "id", "price" FROM jsonb_populate_record(NULL::"public"."t", '{"id": 1, "price": 2, "total": 4}');`, got)
+	got, err = generatedTable.generateRollbackStatement(rollbackOperationUpdate, `{"id": 1, "price": 2, "total": 4}`, `{"id": 1, "price": 3, "total": 6}`)
+	a.NoError(err)
+	a.Equal(`UPDATE "public"."t" SET ("price") = (SELECT "price" FROM jsonb_populate_record(NULL::"public"."t", '{"id": 1, "price": 2, "total": 4}')) WHERE ("id") = (SELECT "id" FROM jsonb_populate_record(NULL::"public"."t", '{"id": 1, "price": 3, "total": 6}'));`, got)
+
+	noPKTable := &rollbackTable{resolvedSchema: "public", resolvedName: "t"}
+	_, err = noPKTable.generateRollbackStatement(rollbackOperationInsert, "", `{"id": 1}`)
+	a.Error(err)
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
@@ -188,7 +190,7 @@ func (*Driver) Restore(context.Context, io.Reader) error {
 }
 
 // QueryConn queries a SQL statement in a given connection.
-func (d *Driver) QueryConn(ctx context.Context, _ *sql.Conn, statement string, _ *db.QueryContext) ([]*v1pb.QueryResult, error) {
+func (d *Driver) QueryConn(ctx context.Context, _ *sql.Conn, statement string, queryContext *db.QueryContext) ([]*v1pb.QueryResult, error) {
 	startTime := time.Now()
 	lines := strings.Split(statement, "\n")
 	for i := range lines {
@@ -197,6 +199,7 @@ func (d *Driver) QueryConn(ctx context.Context, _ *sql.Conn, statement string, _
 
 	var data []*v1pb.QueryRow
 	var cmds []*redis.Cmd
+	var maskers []masker.Masker
 
 	if _, err := d.rdb.Pipelined(ctx, func(p redis.Pipeliner) error {
 		for _, line := range lines {
@@ -209,13 +212,14 @@ func (d *Driver) QueryConn(ctx context.Context, _ *sql.Conn, statement string, _
 			}
 			cmd := p.Do(ctx, input...)
 			cmds = append(cmds, cmd)
+			maskers = append(maskers, d.getKeyMasker(input, queryContext))
 		}
 		return nil
 	}); err != nil && err != redis.Nil {
 		return nil, err
 	}
 
-	for _, cmd := range cmds {
+	for i, cmd := range cmds {
 		if cmd.Err() == redis.Nil {
 			data = append(data, &v1pb.QueryRow{Values: []*v1pb.RowValue{{Kind: &v1pb.RowValue_StringValue{StringValue: "redis: nil"}}}})
 			continue
@@ -223,7 +227,7 @@ func (d *Driver) QueryConn(ctx context.Context, _ *sql.Conn, statement string, _
 
 		// RowValue cannot handle interface{} type
 		val := cmd.String()
-		data = append(data, &v1pb.QueryRow{Values: []*v1pb.RowValue{{Kind: &v1pb.RowValue_StringValue{StringValue: val}}}})
+		data = append(data, &v1pb.QueryRow{Values: []*v1pb.RowValue{maskers[i].Mask(&v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: val}})}})
 	}
 
 	return []*v1pb.QueryResult{{
@@ -235,6 +239,27 @@ func (d *Driver) QueryConn(ctx context.Context, _ *sql.Conn, statement string, _
 	}}, nil
 }
 
+// getKeyMasker returns the masker of the key the command accesses.
+// The sensitive data of Redis is configured with the key as the table name, and the most restrictive column wins.
+func (d *Driver) getKeyMasker(input []any, queryContext *db.QueryContext) masker.Masker {
+	if queryContext == nil || !queryContext.EnableSensitive || len(input) < 2 {
+		return masker.NewNoneMasker()
+	}
+	key, ok := input[1].(string)
+	if !ok {
+		return masker.NewNoneMasker()
+	}
+	table := queryContext.SensitiveSchemaInfo.FindTable(d.databaseName, "", key)
+	if table == nil {
+		return masker.NewNoneMasker()
+	}
+	maskingAttributes := base.NewEmptyMaskingAttributes()
+	for _, column := range table.ColumnList {
+		maskingAttributes.TransmittedBy(column.MaskingAttributes)
+	}
+	return maskingAttributes.GetMasker()
+}
+
 // RunStatement runs a SQL statement in a given connection.
 func (d *Driver) RunStatement(ctx context.Context, _ *sql.Conn, statement string) ([]*v1pb.QueryResult, error) {
 	return d.QueryConn(ctx, nil, statement, nil)
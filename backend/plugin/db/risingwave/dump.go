@@ -3,6 +3,8 @@ package risingwave
 import (
 	"context"
 	"io"
+
+	"github.com/pkg/errors"
 )
 
 // Dump dumps the database.
@@ -14,5 +16,5 @@ func (*Driver) Dump(_ context.Context, _ io.Writer, _ bool) (string, error) {
 // Restore restores a database.
 // TODO: RisingWave doesn't support pg_dump yet.
 func (*Driver) Restore(_ context.Context, _ io.Reader) error {
-	return nil
+	return errors.New("restore is not supported for RisingWave")
 }
@@ -55,5 +55,5 @@ func (d *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool) (stri
 
 // Restore restores a database.
 func (*Driver) Restore(_ context.Context, _ io.Reader) error {
-	panic("not implemented")
+	return errors.New("restore is not supported for Spanner")
 }
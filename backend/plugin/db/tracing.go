@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+var tracer = otel.Tracer("github.com/bytebase/bytebase/backend/plugin/db")
+
+// tracingDriver traces the database operations of the driver in spans.
+type tracingDriver struct {
+	Driver
+	attributes []attribute.KeyValue
+}
+
+func newTracingDriver(driver Driver, dbType storepb.Engine, config ConnectionConfig, connCtx ConnectionContext) *tracingDriver {
+	return &tracingDriver{
+		Driver: driver,
+		attributes: []attribute.KeyValue{
+			attribute.String("db.system", strings.ToLower(dbType.String())),
+			attribute.String("db.name", config.Database),
+			attribute.String("bytebase.instance", connCtx.InstanceID),
+		},
+	}
+}
+
+// Unwrap returns the wrapped driver.
+func (d *tracingDriver) Unwrap() Driver {
+	return d.Driver
+}
+
+func (d *tracingDriver) start(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "db."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(d.attributes...))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Ping traces the Ping of the driver.
+func (d *tracingDriver) Ping(ctx context.Context) (err error) {
+	ctx, span := d.start(ctx, "Ping")
+	defer func() { endSpan(span, err) }()
+	return d.Driver.Ping(ctx)
+}
+
+// Execute traces the Execute of the driver.
+func (d *tracingDriver) Execute(ctx context.Context, statement string, createDatabase bool, opts ExecuteOptions) (_ int64, err error) {
+	ctx, span := d.start(ctx, "Execute")
+	defer func() { endSpan(span, err) }()
+	return d.Driver.Execute(ctx, statement, createDatabase, opts)
+}
+
+// QueryConn traces the QueryConn of the driver.
+func (d *tracingDriver) QueryConn(ctx context.Context, conn *sql.Conn, statement string, queryContext *QueryContext) (_ []*v1pb.QueryResult, err error) {
+	ctx, span := d.start(ctx, "QueryConn")
+	defer func() { endSpan(span, err) }()
+	return d.Driver.QueryConn(ctx, conn, statement, queryContext)
+}
+
+// RunStatement traces the RunStatement of the driver.
+func (d *tracingDriver) RunStatement(ctx context.Context, conn *sql.Conn, statement string) (_ []*v1pb.QueryResult, err error) {
+	ctx, span := d.start(ctx, "RunStatement")
+	defer func() { endSpan(span, err) }()
+	return d.Driver.RunStatement(ctx, conn, statement)
+}
+
+// SyncInstance traces the SyncInstance of the driver.
+func (d *tracingDriver) SyncInstance(ctx context.Context) (_ *InstanceMetadata, err error) {
+	ctx, span := d.start(ctx, "SyncInstance")
+	defer func() { endSpan(span, err) }()
+	return d.Driver.SyncInstance(ctx)
+}
+
+// SyncDBSchema traces the SyncDBSchema of the driver.
+func (d *tracingDriver) SyncDBSchema(ctx context.Context) (_ *storepb.DatabaseSchemaMetadata, err error) {
+	ctx, span := d.start(ctx, "SyncDBSchema")
+	defer func() { endSpan(span, err) }()
+	return d.Driver.SyncDBSchema(ctx)
+}
+
+// SyncSlowQuery traces the SyncSlowQuery of the driver.
+func (d *tracingDriver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (_ map[string]*storepb.SlowQueryStatistics, err error) {
+	ctx, span := d.start(ctx, "SyncSlowQuery")
+	defer func() { endSpan(span, err) }()
+	return d.Driver.SyncSlowQuery(ctx, logDateTs)
+}
+
+// Dump traces the Dump of the driver.
+func (d *tracingDriver) Dump(ctx context.Context, out io.Writer, schemaOnly bool) (_ string, err error) {
+	ctx, span := d.start(ctx, "Dump")
+	defer func() { endSpan(span, err) }()
+	return d.Driver.Dump(ctx, out, schemaOnly)
+}
+
+// Restore traces the Restore of the driver.
+func (d *tracingDriver) Restore(ctx context.Context, src io.Reader) (err error) {
+	ctx, span := d.start(ctx, "Restore")
+	defer func() { endSpan(span, err) }()
+	return d.Driver.Restore(ctx, src)
+}
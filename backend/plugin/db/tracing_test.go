@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+type fakePingDriver struct {
+	Driver
+	err error
+}
+
+func (d *fakePingDriver) Ping(context.Context) error {
+	return d.err
+}
+
+func TestTracingDriver(t *testing.T) {
+	a := require.New(t)
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+
+	fake := &fakePingDriver{err: errors.New("connection refused")}
+	driver := newTracingDriver(fake, storepb.Engine_MYSQL, ConnectionConfig{Database: "db"}, ConnectionContext{InstanceID: "mysql-prod"})
+	a.Error(driver.Ping(context.Background()))
+	fake.err = nil
+	a.NoError(driver.Ping(context.Background()))
+
+	spans := recorder.Ended()
+	a.Len(spans, 2)
+	a.Equal("db.Ping", spans[0].Name())
+	a.Equal(otelcodes.Error, spans[0].Status().Code)
+	a.Equal(otelcodes.Unset, spans[1].Status().Code)
+	attributes := map[string]string{}
+	for _, attribute := range spans[0].Attributes() {
+		attributes[string(attribute.Key)] = attribute.Value.AsString()
+	}
+	a.Equal(map[string]string{"db.system": "mysql", "db.name": "db", "bytebase.instance": "mysql-prod"}, attributes)
+}
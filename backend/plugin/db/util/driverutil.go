@@ -9,7 +9,6 @@ import (
 	"io"
 	"log/slog"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -20,12 +19,16 @@ import (
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	"github.com/bytebase/bytebase/backend/plugin/parser/base"
 	mysqlparser "github.com/bytebase/bytebase/backend/plugin/parser/mysql"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
+// defaultRowBatchSize is the number of rows passed to the row batch handler at a time if the batch size is not set.
+const defaultRowBatchSize = 1000
+
 // FormatErrorWithQuery will format the error with failed query.
 func FormatErrorWithQuery(err error, query string) error {
 	if regexp.MustCompile("does not exist").MatchString(err.Error()) {
@@ -150,15 +153,17 @@ func Query(ctx context.Context, dbType storepb.Engine, conn *sql.Conn, statement
 		return nil, errors.Errorf("failed to extract sensitive fields: %q", statement)
 	}
 
-	var fieldMaskingLevels []storepb.MaskingLevel
+	var fieldMaskers []masker.Masker
 	var fieldMaskInfo []bool
 	var fieldSensitiveInfo []bool
 	for i := range columnNames {
 		maskingLevel := storepb.MaskingLevel_NONE
+		var fieldMasker masker.Masker = masker.NewNoneMasker()
 		if len(fieldList) > i && queryContext.EnableSensitive {
 			maskingLevel = fieldList[i].MaskingAttributes.MaskingLevel
+			fieldMasker = fieldList[i].MaskingAttributes.GetMasker()
 		}
-		fieldMaskingLevels = append(fieldMaskingLevels, maskingLevel)
+		fieldMaskers = append(fieldMaskers, fieldMasker)
 		sensitive := len(fieldList) > i && (maskingLevel == storepb.MaskingLevel_FULL || maskingLevel == storepb.MaskingLevel_PARTIAL)
 		fieldMaskInfo = append(fieldMaskInfo, sensitive && queryContext.EnableSensitive)
 		fieldSensitiveInfo = append(fieldSensitiveInfo, sensitive)
@@ -176,7 +181,25 @@ func Query(ctx context.Context, dbType storepb.Engine, conn *sql.Conn, statement
 		columnTypeNames = append(columnTypeNames, strings.ToUpper(v.DatabaseTypeName()))
 	}
 
-	data, err := readRows(rows, columnTypeNames, fieldMaskingLevels)
+	result := &v1pb.QueryResult{
+		ColumnNames:     columnNames,
+		ColumnTypeNames: columnTypeNames,
+		Masked:          fieldMaskInfo,
+		Sensitive:       fieldSensitiveInfo,
+	}
+	if queryContext.RowBatchHandler != nil {
+		if err := readRowBatches(rows, columnTypeNames, fieldMaskers, queryContext.RowBatchSize, func(batch []*v1pb.QueryRow) error {
+			return queryContext.RowBatchHandler(result, batch)
+		}); err != nil {
+			return nil, err
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	data, err := readRows(rows, columnTypeNames, fieldMaskers)
 	if err != nil {
 		return nil, err
 	}
@@ -185,13 +208,8 @@ func Query(ctx context.Context, dbType storepb.Engine, conn *sql.Conn, statement
 		return nil, err
 	}
 
-	return &v1pb.QueryResult{
-		ColumnNames:     columnNames,
-		ColumnTypeNames: columnTypeNames,
-		Rows:            data,
-		Masked:          fieldMaskInfo,
-		Sensitive:       fieldSensitiveInfo,
-	}, nil
+	result.Rows = data
+	return result, nil
 }
 
 // RunStatement runs a SQL statement in a given connection.
@@ -303,7 +321,7 @@ func rowsToQueryResult(rows *sql.Rows) (*v1pb.QueryResult, error) {
 	}, nil
 }
 
-func readRows(rows *sql.Rows, columnTypeNames []string, fieldMaskingLevels []storepb.MaskingLevel) ([]*v1pb.QueryRow, error) {
+func readRows(rows *sql.Rows, columnTypeNames []string, fieldMaskers []masker.Masker) ([]*v1pb.QueryRow, error) {
 	var data []*v1pb.QueryRow
 	if len(columnTypeNames) == 0 {
 		// No rows.
@@ -311,132 +329,114 @@ func readRows(rows *sql.Rows, columnTypeNames []string, fieldMaskingLevels []sto
 		return data, nil
 	}
 	for rows.Next() {
-		// wantBytesValue want to convert StringValue to BytesValue when columnTypeName is BIT or VARBIT
-		wantBytesValue := make([]bool, len(columnTypeNames))
-		scanArgs := make([]any, len(columnTypeNames))
-		for i, v := range columnTypeNames {
-			// TODO(steven need help): Consult a common list of data types from database driver documentation. e.g. MySQL,PostgreSQL.
-			switch v {
-			case "VARCHAR", "TEXT", "UUID", "TIMESTAMP":
-				scanArgs[i] = new(sql.NullString)
-			case "BOOL":
-				scanArgs[i] = new(sql.NullBool)
-			case "INT", "INTEGER":
-				scanArgs[i] = new(sql.NullInt64)
-			case "FLOAT":
-				scanArgs[i] = new(sql.NullFloat64)
-			case "BIT", "VARBIT":
-				wantBytesValue[i] = true
-				scanArgs[i] = new(sql.NullString)
-			default:
-				scanArgs[i] = new(sql.NullString)
-			}
-		}
-
-		if err := rows.Scan(scanArgs...); err != nil {
+		row, err := readRow(rows, columnTypeNames, fieldMaskers)
+		if err != nil {
 			return nil, err
 		}
+		data = append(data, row)
+	}
 
-		var rowData v1pb.QueryRow
-		for i := range columnTypeNames {
-			if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_FULL {
-				rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: "******"}})
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullBool); ok && v.Valid {
-				if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-					s := fmt.Sprintf("%t", v.Bool)
-					result := getMiddlePartOfString(s)
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: paddingAsterisk(result)}})
-				} else {
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_BoolValue{BoolValue: v.Bool}})
-				}
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullString); ok && v.Valid {
-				if wantBytesValue[i] {
-					if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-						result := getMiddlePartOfString(v.String)
-						rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: paddingAsterisk(result)}})
-					} else {
-						rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_BytesValue{BytesValue: []byte(v.String)}})
-					}
-				} else {
-					if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-						result := getMiddlePartOfString(v.String)
-						rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: paddingAsterisk(result)}})
-					} else {
-						rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: v.String}})
-					}
-				}
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullInt64); ok && v.Valid {
-				if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-					s := strconv.FormatInt(v.Int64, 10)
-					result := getMiddlePartOfString(s)
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: paddingAsterisk(result)}})
-				} else {
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_Int64Value{Int64Value: v.Int64}})
-				}
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullInt32); ok && v.Valid {
-				if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-					s := strconv.FormatInt(int64(v.Int32), 10)
-					result := getMiddlePartOfString(s)
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: paddingAsterisk(result)}})
-				} else {
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_Int32Value{Int32Value: v.Int32}})
-				}
-				continue
+	return data, nil
+}
+
+// readRowBatches reads the rows and passes them to the handler in batches of batchSize.
+// The handler is called at least once, so that it receives the result with no rows.
+func readRowBatches(rows *sql.Rows, columnTypeNames []string, fieldMaskers []masker.Masker, batchSize int, handler func([]*v1pb.QueryRow) error) error {
+	if batchSize <= 0 {
+		batchSize = defaultRowBatchSize
+	}
+	var batch []*v1pb.QueryRow
+	handled := false
+	// The oracle driver will panic if there is no rows such as EXPLAIN PLAN FOR statement.
+	if len(columnTypeNames) != 0 {
+		for rows.Next() {
+			row, err := readRow(rows, columnTypeNames, fieldMaskers)
+			if err != nil {
+				return err
 			}
-			if v, ok := (scanArgs[i]).(*sql.NullFloat64); ok && v.Valid {
-				if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-					s := strconv.FormatFloat(v.Float64, 'f', -1, 64)
-					result := getMiddlePartOfString(s)
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: paddingAsterisk(result)}})
-				} else {
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_DoubleValue{DoubleValue: v.Float64}})
-				}
+			batch = append(batch, row)
+			if len(batch) < batchSize {
 				continue
 			}
-			// If none of them match, set nil to its value.
-			if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-				rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: "**UL**"}})
-			} else {
-				rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{NullValue: structpb.NullValue_NULL_VALUE}})
+			if err := handler(batch); err != nil {
+				return err
 			}
+			batch, handled = nil, true
 		}
-
-		data = append(data, &rowData)
 	}
-
-	return data, nil
-}
-
-func paddingAsterisk(s string) string {
-	return fmt.Sprintf("**%s**", s)
+	if len(batch) == 0 && handled {
+		return nil
+	}
+	return handler(batch)
 }
 
-// getMiddlePartOfString will get the middle part of the string.
-func getMiddlePartOfString(stmt string) string {
-	if len(stmt) == 0 || len(stmt) == 1 {
-		return ""
+func readRow(rows *sql.Rows, columnTypeNames []string, fieldMaskers []masker.Masker) (*v1pb.QueryRow, error) {
+	// wantBytesValue want to convert StringValue to BytesValue when columnTypeName is BIT or VARBIT
+	wantBytesValue := make([]bool, len(columnTypeNames))
+	scanArgs := make([]any, len(columnTypeNames))
+	for i, v := range columnTypeNames {
+		// TODO(steven need help): Consult a common list of data types from database driver documentation. e.g. MySQL,PostgreSQL.
+		switch v {
+		case "VARCHAR", "TEXT", "UUID", "TIMESTAMP":
+			scanArgs[i] = new(sql.NullString)
+		case "BOOL":
+			scanArgs[i] = new(sql.NullBool)
+		case "INT", "INTEGER":
+			scanArgs[i] = new(sql.NullInt64)
+		case "FLOAT":
+			scanArgs[i] = new(sql.NullFloat64)
+		case "BIT", "VARBIT":
+			wantBytesValue[i] = true
+			scanArgs[i] = new(sql.NullString)
+		default:
+			scanArgs[i] = new(sql.NullString)
+		}
 	}
-	if len(stmt) == 2 || len(stmt) == 3 {
-		return string(stmt[1])
+
+	if err := rows.Scan(scanArgs...); err != nil {
+		return nil, err
 	}
 
-	s := []rune(stmt)
-	if len(s)%4 != 0 {
-		s = s[:len(s)/4*4]
+	var rowData v1pb.QueryRow
+	for i := range columnTypeNames {
+		value := convertScanArgToRowValue(scanArgs[i], wantBytesValue[i])
+		if len(fieldMaskers) > i && fieldMaskers[i] != nil {
+			value = fieldMaskers[i].Mask(value)
+		}
+		rowData.Values = append(rowData.Values, value)
 	}
+	return &rowData, nil
+}
 
-	var ret []rune
-	ret = append(ret, s[len(s)/4:len(s)/2]...)
-	ret = append(ret, s[len(s)/2:len(s)/4*3]...)
-	return string(ret)
+// convertScanArgToRowValue converts the scanned value to the row value.
+func convertScanArgToRowValue(scanArg any, wantBytesValue bool) *v1pb.RowValue {
+	switch v := scanArg.(type) {
+	case *sql.NullBool:
+		if v.Valid {
+			return &v1pb.RowValue{Kind: &v1pb.RowValue_BoolValue{BoolValue: v.Bool}}
+		}
+	case *sql.NullString:
+		if v.Valid {
+			if wantBytesValue {
+				return &v1pb.RowValue{Kind: &v1pb.RowValue_BytesValue{BytesValue: []byte(v.String)}}
+			}
+			return &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: v.String}}
+		}
+	case *sql.NullInt64:
+		if v.Valid {
+			return &v1pb.RowValue{Kind: &v1pb.RowValue_Int64Value{Int64Value: v.Int64}}
+		}
+	case *sql.NullInt32:
+		if v.Valid {
+			return &v1pb.RowValue{Kind: &v1pb.RowValue_Int32Value{Int32Value: v.Int32}}
+		}
+	case *sql.NullFloat64:
+		if v.Valid {
+			return &v1pb.RowValue{Kind: &v1pb.RowValue_DoubleValue{DoubleValue: v.Float64}}
+		}
+	}
+	// If none of them match, set nil to its value.
+	return &v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{NullValue: structpb.NullValue_NULL_VALUE}}
 }
 
 func getStatementWithResultLimit(stmt string, limit int) string {
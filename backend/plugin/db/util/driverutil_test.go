@@ -1,13 +1,20 @@
 package util
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"math/rand"
 	"strings"
 	"testing"
 	"time"
 
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
 func TestGetStatementWithResultLimit(t *testing.T) {
@@ -109,3 +116,58 @@ func TestApplyMultiStatements(t *testing.T) {
 		require.Equal(t, test.total, total)
 	}
 }
+
+func TestQueryRowBatches(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	a.NoError(err)
+	defer sqlDB.Close()
+	conn, err := sqlDB.Conn(ctx)
+	a.NoError(err)
+	defer conn.Close()
+	_, err = conn.ExecContext(ctx, "CREATE TABLE t(id INTEGER, name TEXT); INSERT INTO t VALUES (1, 'a'), (2, 'b'), (3, 'c'), (4, 'd'), (5, NULL);")
+	a.NoError(err)
+
+	tests := []struct {
+		statement  string
+		batchSizes []int
+	}{
+		{
+			statement:  "SELECT * FROM t",
+			batchSizes: []int{2, 2, 1},
+		},
+		{
+			statement:  "SELECT * FROM t WHERE id <= 4",
+			batchSizes: []int{2, 2},
+		},
+		{
+			// The handler receives the columns even if there are no rows.
+			statement:  "SELECT * FROM t WHERE id > 5",
+			batchSizes: []int{0},
+		},
+	}
+	for _, test := range tests {
+		want, err := Query(ctx, storepb.Engine_SQLITE, conn, test.statement, &db.QueryContext{})
+		a.NoError(err)
+
+		var batchSizes []int
+		var rows []*v1pb.QueryRow
+		got, err := Query(ctx, storepb.Engine_SQLITE, conn, test.statement, &db.QueryContext{
+			RowBatchHandler: func(result *v1pb.QueryResult, batch []*v1pb.QueryRow) error {
+				a.Equal(want.ColumnNames, result.ColumnNames)
+				batchSizes = append(batchSizes, len(batch))
+				rows = append(rows, batch...)
+				return nil
+			},
+			RowBatchSize: 2,
+		})
+		a.NoError(err)
+		a.Empty(got.Rows)
+		a.Equal(test.batchSizes, batchSizes, test.statement)
+		a.Equal(len(want.Rows), len(rows))
+		for i := range rows {
+			a.Equal(want.Rows[i].String(), rows[i].String())
+		}
+	}
+}
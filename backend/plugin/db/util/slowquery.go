@@ -0,0 +1,76 @@
+package util
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	mysqlparser "github.com/bytebase/bytebase/backend/plugin/parser/mysql"
+	plsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/plsql"
+	tsqlparser "github.com/bytebase/bytebase/backend/plugin/parser/tsql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// GetSlowQueryFingerprint returns the fingerprint of the slow query, truncated to db.SlowQueryMaxLen.
+// The fingerprint is computed by the lexer of the engine, and it falls back to the query text itself if the fingerprint cannot be computed.
+func GetSlowQueryFingerprint(engine storepb.Engine, statement string) string {
+	var fingerprint string
+	var err error
+	switch engine {
+	case storepb.Engine_MSSQL:
+		fingerprint, err = tsqlparser.GetFingerprint(statement)
+	case storepb.Engine_ORACLE, storepb.Engine_DM, storepb.Engine_OCEANBASE_ORACLE:
+		fingerprint, err = plsqlparser.GetFingerprint(statement)
+	default:
+		fingerprint, err = mysqlparser.GetFingerprint(statement)
+	}
+	if err != nil || fingerprint == "" {
+		fingerprint = statement
+	}
+	if len(fingerprint) > db.SlowQueryMaxLen {
+		fingerprint = fingerprint[:db.SlowQueryMaxLen]
+	}
+	return fingerprint
+}
+
+// MergeSlowQueryStatistics merges the slow query statistics items with the same fingerprint.
+// It is used by the engines which report the pre-aggregated statistics per statement instead of the slow query log,
+// so different statements sharing the same fingerprint are folded into one item.
+func MergeSlowQueryStatistics(items []*storepb.SlowQueryStatisticsItem) *storepb.SlowQueryStatistics {
+	itemMap := make(map[string]*storepb.SlowQueryStatisticsItem)
+	for _, item := range items {
+		existing, ok := itemMap[item.SqlFingerprint]
+		if !ok {
+			itemMap[item.SqlFingerprint] = item
+			continue
+		}
+		existing.Count += item.Count
+		if existing.LatestLogTime.AsTime().Before(item.LatestLogTime.AsTime()) {
+			existing.LatestLogTime = item.LatestLogTime
+		}
+		existing.TotalQueryTime = durationpb.New(existing.TotalQueryTime.AsDuration() + item.TotalQueryTime.AsDuration())
+		if existing.MaximumQueryTime.AsDuration() < item.MaximumQueryTime.AsDuration() {
+			existing.MaximumQueryTime = item.MaximumQueryTime
+		}
+		existing.TotalRowsSent += item.TotalRowsSent
+		existing.MaximumRowsSent = max(existing.MaximumRowsSent, item.MaximumRowsSent)
+		existing.TotalRowsExamined += item.TotalRowsExamined
+		existing.MaximumRowsExamined = max(existing.MaximumRowsExamined, item.MaximumRowsExamined)
+		for _, sample := range item.Samples {
+			if len(existing.Samples) >= db.SlowQueryMaxSamplePerFingerprint {
+				break
+			}
+			existing.Samples = append(existing.Samples, sample)
+		}
+	}
+
+	result := &storepb.SlowQueryStatistics{}
+	for _, item := range itemMap {
+		result.Items = append(result.Items, item)
+	}
+	sort.Slice(result.Items, func(i, j int) bool {
+		return result.Items[i].SqlFingerprint < result.Items[j].SqlFingerprint
+	})
+	return result
+}
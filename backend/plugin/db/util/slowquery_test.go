@@ -0,0 +1,81 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestMergeSlowQueryStatistics(t *testing.T) {
+	a := require.New(t)
+	early := time.Date(2023, 10, 1, 1, 0, 0, 0, time.UTC)
+	late := time.Date(2023, 10, 1, 2, 0, 0, 0, time.UTC)
+	fingerprint := GetSlowQueryFingerprint(storepb.Engine_MYSQL, "SELECT * FROM t WHERE id = 1")
+	a.Equal(fingerprint, GetSlowQueryFingerprint(storepb.Engine_MYSQL, "SELECT * FROM t WHERE id = 2"))
+	// The fingerprints are computed by the lexer of the engine.
+	a.Equal(`select * from "T" where name = ?`, GetSlowQueryFingerprint(storepb.Engine_ORACLE, `SELECT * FROM "T" WHERE name = 'a'`))
+	a.Equal("select * from [t] where name = ?", GetSlowQueryFingerprint(storepb.Engine_MSSQL, "SELECT * FROM [t] WHERE name = N'a'"))
+
+	items := []*storepb.SlowQueryStatisticsItem{
+		{
+			SqlFingerprint:      fingerprint,
+			Count:               2,
+			LatestLogTime:       timestamppb.New(early),
+			TotalQueryTime:      durationpb.New(4 * time.Second),
+			MaximumQueryTime:    durationpb.New(3 * time.Second),
+			TotalRowsSent:       10,
+			MaximumRowsSent:     8,
+			TotalRowsExamined:   100,
+			MaximumRowsExamined: 60,
+			Samples:             []*storepb.SlowQueryDetails{{SqlText: "SELECT * FROM t WHERE id = 1"}},
+		},
+		{
+			SqlFingerprint:      GetSlowQueryFingerprint(storepb.Engine_MYSQL, "SELECT * FROM t2"),
+			Count:               1,
+			LatestLogTime:       timestamppb.New(early),
+			TotalQueryTime:      durationpb.New(time.Second),
+			MaximumQueryTime:    durationpb.New(time.Second),
+			TotalRowsSent:       1,
+			MaximumRowsSent:     1,
+			TotalRowsExamined:   1,
+			MaximumRowsExamined: 1,
+			Samples:             []*storepb.SlowQueryDetails{{SqlText: "SELECT * FROM t2"}},
+		},
+		{
+			SqlFingerprint:      fingerprint,
+			Count:               1,
+			LatestLogTime:       timestamppb.New(late),
+			TotalQueryTime:      durationpb.New(5 * time.Second),
+			MaximumQueryTime:    durationpb.New(5 * time.Second),
+			TotalRowsSent:       1,
+			MaximumRowsSent:     1,
+			TotalRowsExamined:   200,
+			MaximumRowsExamined: 200,
+			Samples:             []*storepb.SlowQueryDetails{{SqlText: "SELECT * FROM t WHERE id = 2"}},
+		},
+	}
+
+	got := MergeSlowQueryStatistics(items)
+	a.Len(got.Items, 2)
+	var merged *storepb.SlowQueryStatisticsItem
+	for _, item := range got.Items {
+		if item.SqlFingerprint == fingerprint {
+			merged = item
+		}
+	}
+	a.NotNil(merged)
+	a.Equal(int64(3), merged.Count)
+	a.Equal(late, merged.LatestLogTime.AsTime())
+	a.Equal(9*time.Second, merged.TotalQueryTime.AsDuration())
+	a.Equal(5*time.Second, merged.MaximumQueryTime.AsDuration())
+	a.Equal(int64(11), merged.TotalRowsSent)
+	a.Equal(int64(8), merged.MaximumRowsSent)
+	a.Equal(int64(300), merged.TotalRowsExamined)
+	a.Equal(int64(200), merged.MaximumRowsExamined)
+	a.Len(merged.Samples, 2)
+}
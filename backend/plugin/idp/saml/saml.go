@@ -0,0 +1,557 @@
+// Package saml is the plugin for SAML 2.0 Identity Provider.
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/idp"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	protocolNS  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	assertionNS = "urn:oasis:names:tc:SAML:2.0:assertion"
+	metadataNS  = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	httpPostBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	successStatus   = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	bearerMethod    = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+
+	// NameIDKey is the key of the assertion subject name ID in the field mapping.
+	NameIDKey = "NameID"
+)
+
+// maxClockSkew is the tolerated clock skew between Bytebase and the identity provider
+// when validating the assertion time conditions.
+var maxClockSkew = 3 * time.Minute
+
+// IdentityProvider represents a SAML 2.0 Identity Provider, with Bytebase as the service provider.
+type IdentityProvider struct {
+	config         IdentityProviderConfig
+	idpCertificate *x509.Certificate
+	spCertificate  *x509.Certificate
+	spPrivateKey   *rsa.PrivateKey
+}
+
+// IdentityProviderConfig is the configuration to be consumed by the SAML
+// Identity Provider.
+type IdentityProviderConfig struct {
+	// EntityID is the entity ID of Bytebase as the service provider.
+	EntityID string `json:"entityId"`
+	// ACSURL is the assertion consumer service URL of Bytebase, which receives
+	// the HTTP-POST binding responses.
+	ACSURL string `json:"acsUrl"`
+	// IdPEntityID is the entity ID of the identity provider, i.e. the issuer of the assertions.
+	IdPEntityID string `json:"idpEntityId"`
+	// SSOURL is the single sign-on URL of the identity provider.
+	SSOURL string `json:"ssoUrl"`
+	// IdPCertificate is the PEM encoded certificate of the identity provider.
+	IdPCertificate string `json:"idpCertificate"`
+	// SPCertificate is the PEM encoded certificate of Bytebase as the service provider.
+	SPCertificate string `json:"spCertificate"`
+	// SPPrivateKey is the PEM encoded RSA private key of Bytebase as the service provider.
+	SPPrivateKey string `json:"spPrivateKey"`
+	// NameIDFormat is the requested name ID format, which is optional.
+	NameIDFormat string `json:"nameIdFormat"`
+	// FieldMapping is the mapping of the assertion attributes, where "NameID"
+	// refers to the name ID of the assertion subject.
+	FieldMapping *storepb.FieldMapping `json:"fieldMapping"`
+}
+
+// GetServiceProviderEntityID returns the entity ID of Bytebase as the service provider of the identity provider,
+// which is also the URL of the service provider metadata.
+func GetServiceProviderEntityID(externalURL, identityProviderID string) string {
+	return fmt.Sprintf("%s/saml/metadata/%s", strings.TrimSuffix(externalURL, "/"), identityProviderID)
+}
+
+// GetServiceProviderACSURL returns the assertion consumer service URL of Bytebase for the identity provider.
+func GetServiceProviderACSURL(externalURL, identityProviderID string) string {
+	return fmt.Sprintf("%s/saml/acs/%s", strings.TrimSuffix(externalURL, "/"), identityProviderID)
+}
+
+// NewIdentityProvider initializes a new SAML Identity Provider with the given
+// configuration.
+func NewIdentityProvider(config IdentityProviderConfig) (*IdentityProvider, error) {
+	if config.FieldMapping == nil {
+		return nil, errors.New(`the field "fieldMapping" is empty but required`)
+	}
+	for v, field := range map[string]string{
+		config.EntityID:                "entityId",
+		config.ACSURL:                  "acsUrl",
+		config.IdPEntityID:             "idpEntityId",
+		config.SSOURL:                  "ssoUrl",
+		config.IdPCertificate:          "idpCertificate",
+		config.SPCertificate:           "spCertificate",
+		config.SPPrivateKey:            "spPrivateKey",
+		config.FieldMapping.Identifier: "fieldMapping.identifier",
+	} {
+		if v == "" {
+			return nil, errors.Errorf("the field %q is empty but required", field)
+		}
+	}
+	if _, err := url.ParseRequestURI(config.SSOURL); err != nil {
+		return nil, errors.Wrapf(err, "invalid SSO URL %q", config.SSOURL)
+	}
+
+	idpCertificate, err := parseCertificate(config.IdPCertificate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse IdP certificate")
+	}
+	if _, ok := idpCertificate.PublicKey.(*rsa.PublicKey); !ok {
+		return nil, errors.New("IdP certificate must have an RSA public key")
+	}
+	spCertificate, err := parseCertificate(config.SPCertificate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse SP certificate")
+	}
+	spPrivateKey, err := parsePrivateKey(config.SPPrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse SP private key")
+	}
+	if publicKey, ok := spCertificate.PublicKey.(*rsa.PublicKey); !ok || !publicKey.Equal(&spPrivateKey.PublicKey) {
+		return nil, errors.New("SP certificate does not match the SP private key")
+	}
+	return &IdentityProvider{
+		config:         config,
+		idpCertificate: idpCertificate,
+		spCertificate:  spCertificate,
+		spPrivateKey:   spPrivateKey,
+	}, nil
+}
+
+// parseCertificate parses the PEM encoded certificate. The bare base64 encoded DER
+// certificate copied from the IdP metadata is also accepted.
+func parseCertificate(s string) (*x509.Certificate, error) {
+	if block, _ := pem.Decode([]byte(s)); block != nil {
+		return x509.ParseCertificate(block.Bytes)
+	}
+	der, err := decodeBase64(s)
+	if err != nil {
+		return nil, errors.New("certificate is neither PEM nor base64 encoded")
+	}
+	return x509.ParseCertificate(der)
+}
+
+// parsePrivateKey parses the PEM encoded PKCS #1 or PKCS #8 RSA private key.
+func parsePrivateKey(s string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("only RSA private keys are supported")
+	}
+	return rsaKey, nil
+}
+
+type entityDescriptor struct {
+	XMLName         xml.Name        `xml:"md:EntityDescriptor"`
+	XMLNSMD         string          `xml:"xmlns:md,attr"`
+	XMLNSDS         string          `xml:"xmlns:ds,attr"`
+	EntityID        string          `xml:"entityID,attr"`
+	SPSSODescriptor spSSODescriptor `xml:"md:SPSSODescriptor"`
+}
+
+type spSSODescriptor struct {
+	AuthnRequestsSigned        bool                     `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool                     `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string                   `xml:"protocolSupportEnumeration,attr"`
+	KeyDescriptor              keyDescriptor            `xml:"md:KeyDescriptor"`
+	NameIDFormat               string                   `xml:"md:NameIDFormat,omitempty"`
+	AssertionConsumerService   assertionConsumerService `xml:"md:AssertionConsumerService"`
+}
+
+type keyDescriptor struct {
+	Use             string `xml:"use,attr"`
+	X509Certificate string `xml:"ds:KeyInfo>ds:X509Data>ds:X509Certificate"`
+}
+
+type assertionConsumerService struct {
+	Binding   string `xml:"Binding,attr"`
+	Location  string `xml:"Location,attr"`
+	Index     int    `xml:"index,attr"`
+	IsDefault bool   `xml:"isDefault,attr"`
+}
+
+// Metadata returns the service provider metadata to be registered in the identity provider.
+func (p *IdentityProvider) Metadata() ([]byte, error) {
+	metadata := entityDescriptor{
+		XMLNSMD:  metadataNS,
+		XMLNSDS:  xmldsigNS,
+		EntityID: p.config.EntityID,
+		SPSSODescriptor: spSSODescriptor{
+			AuthnRequestsSigned:        true,
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: protocolNS,
+			KeyDescriptor: keyDescriptor{
+				Use:             "signing",
+				X509Certificate: base64.StdEncoding.EncodeToString(p.spCertificate.Raw),
+			},
+			NameIDFormat: p.config.NameIDFormat,
+			AssertionConsumerService: assertionConsumerService{
+				Binding:   httpPostBinding,
+				Location:  p.config.ACSURL,
+				Index:     0,
+				IsDefault: true,
+			},
+		},
+	}
+	b, err := xml.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal metadata")
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+type authnRequest struct {
+	XMLName                     xml.Name      `xml:"samlp:AuthnRequest"`
+	XMLNSSAMLP                  string        `xml:"xmlns:samlp,attr"`
+	XMLNSSAML                   string        `xml:"xmlns:saml,attr"`
+	ID                          string        `xml:"ID,attr"`
+	Version                     string        `xml:"Version,attr"`
+	IssueInstant                string        `xml:"IssueInstant,attr"`
+	Destination                 string        `xml:"Destination,attr"`
+	ProtocolBinding             string        `xml:"ProtocolBinding,attr"`
+	AssertionConsumerServiceURL string        `xml:"AssertionConsumerServiceURL,attr"`
+	Issuer                      string        `xml:"saml:Issuer"`
+	NameIDPolicy                *nameIDPolicy `xml:"samlp:NameIDPolicy,omitempty"`
+}
+
+type nameIDPolicy struct {
+	Format      string `xml:"Format,attr"`
+	AllowCreate bool   `xml:"AllowCreate,attr"`
+}
+
+// AuthnRequestURL returns the URL redirecting the user to the identity provider with a signed
+// AuthnRequest of the HTTP-Redirect binding, where the response should be in response to the request ID.
+func (p *IdentityProvider) AuthnRequestURL(requestID, relayState string, now time.Time) (string, error) {
+	request := authnRequest{
+		XMLNSSAMLP:                  protocolNS,
+		XMLNSSAML:                   assertionNS,
+		ID:                          requestID,
+		Version:                     "2.0",
+		IssueInstant:                now.UTC().Format(time.RFC3339),
+		Destination:                 p.config.SSOURL,
+		ProtocolBinding:             httpPostBinding,
+		AssertionConsumerServiceURL: p.config.ACSURL,
+		Issuer:                      p.config.EntityID,
+	}
+	if p.config.NameIDFormat != "" {
+		request.NameIDPolicy = &nameIDPolicy{Format: p.config.NameIDFormat, AllowCreate: true}
+	}
+	b, err := xml.Marshal(request)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal AuthnRequest")
+	}
+
+	// The HTTP-Redirect binding deflates the request, and signs the query string instead of the XML,
+	// see https://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf section 3.4.4.
+	var buf bytes.Buffer
+	writer, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(b); err != nil {
+		return "", errors.Wrap(err, "failed to deflate AuthnRequest")
+	}
+	if err := writer.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to deflate AuthnRequest")
+	}
+	query := "SAMLRequest=" + url.QueryEscape(base64.StdEncoding.EncodeToString(buf.Bytes()))
+	if relayState != "" {
+		query += "&RelayState=" + url.QueryEscape(relayState)
+	}
+	query += "&SigAlg=" + url.QueryEscape(rsaSHA256Alg)
+	digest := sha256.Sum256([]byte(query))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.spPrivateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign AuthnRequest")
+	}
+	query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signature))
+
+	separator := "?"
+	if strings.Contains(p.config.SSOURL, "?") {
+		separator = "&"
+	}
+	return p.config.SSOURL + separator + query, nil
+}
+
+// GenerateID generates a random SAML ID, which must not start with a digit.
+func GenerateID() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate ID")
+	}
+	return "id-" + hex.EncodeToString(b), nil
+}
+
+// UserInfo returns the parsed user information from the base64 encoded SAML response posted to
+// the assertion consumer service. The response or the assertion must be signed by the identity provider,
+// and isValidRequestID reports whether the request ID that the response is in response to was issued by us.
+// isValidRequestID is called once after the signature and the assertion are validated, so it should consume the
+// request ID, i.e. return false if the request ID was consumed already, to prevent replays.
+func (p *IdentityProvider) UserInfo(samlResponse string, isValidRequestID func(string) bool, now time.Time) (*storepb.IdentityProviderUserInfo, error) {
+	attributes, err := p.parseResponse(samlResponse, isValidRequestID, now)
+	if err != nil {
+		return nil, err
+	}
+	slog.Debug("User info", slog.Any("attributes", attributes))
+
+	userInfo := &storepb.IdentityProviderUserInfo{}
+	if v, ok := idp.GetValueWithKey(attributes, p.config.FieldMapping.Identifier).(string); ok {
+		userInfo.Identifier = v
+	}
+	if userInfo.Identifier == "" {
+		return nil, errors.Errorf("the field %q is not found in assertion or has empty value", p.config.FieldMapping.Identifier)
+	}
+
+	// Best effort to map optional fields
+	if p.config.FieldMapping.DisplayName != "" {
+		if v, ok := idp.GetValueWithKey(attributes, p.config.FieldMapping.DisplayName).(string); ok {
+			userInfo.DisplayName = v
+		}
+	}
+	if userInfo.DisplayName == "" {
+		userInfo.DisplayName = userInfo.Identifier
+	}
+	if p.config.FieldMapping.Email != "" {
+		if v, ok := idp.GetValueWithKey(attributes, p.config.FieldMapping.Email).(string); ok {
+			userInfo.Email = v
+		}
+	}
+	if p.config.FieldMapping.Phone != "" {
+		if v, ok := idp.GetValueWithKey(attributes, p.config.FieldMapping.Phone).(string); ok {
+			// Only set phone if it's valid.
+			if err := common.ValidatePhone(v); err == nil {
+				userInfo.Phone = v
+			}
+		}
+	}
+	return userInfo, nil
+}
+
+// parseResponse validates the SAML response and returns the name ID and the attributes of the assertion.
+func (p *IdentityProvider) parseResponse(samlResponse string, isValidRequestID func(string) bool, now time.Time) (map[string]any, error) {
+	b, err := decodeBase64(samlResponse)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode SAML response")
+	}
+	response, err := parseXML(b)
+	if err != nil {
+		return nil, err
+	}
+	if !response.is(protocolNS, "Response") {
+		return nil, errors.Errorf("expected SAML Response, but got %q", response.local)
+	}
+
+	if statusCode := getStatusCode(response); statusCode != successStatus {
+		return nil, errors.Errorf("SAML response status is %q", statusCode)
+	}
+	if destination := response.attr("Destination"); destination != "" && destination != p.config.ACSURL {
+		return nil, errors.Errorf("SAML response destination %q does not match the ACS URL %q", destination, p.config.ACSURL)
+	}
+	if issuer := response.childElement(assertionNS, "Issuer"); issuer != nil && issuer.text() != p.config.IdPEntityID {
+		return nil, errors.Errorf("SAML response issuer %q does not match the IdP entity ID %q", issuer.text(), p.config.IdPEntityID)
+	}
+	if len(response.childElements(assertionNS, "EncryptedAssertion")) > 0 {
+		return nil, errors.New("encrypted assertions are not supported")
+	}
+	assertions := response.childElements(assertionNS, "Assertion")
+	if len(assertions) != 1 {
+		return nil, errors.Errorf("expected one assertion, but found %d", len(assertions))
+	}
+	assertion := assertions[0]
+
+	// Only the signed elements are trusted. If the response is signed, the assertion as its direct
+	// child is covered by the signature, otherwise the assertion itself must be signed.
+	if len(response.childElements(xmldsigNS, "Signature")) > 0 {
+		if err := verifySignature(response, p.idpCertificate); err != nil {
+			return nil, errors.Wrap(err, "failed to verify SAML response signature")
+		}
+	}
+	if len(assertion.childElements(xmldsigNS, "Signature")) > 0 {
+		if err := verifySignature(assertion, p.idpCertificate); err != nil {
+			return nil, errors.Wrap(err, "failed to verify SAML assertion signature")
+		}
+	} else if len(response.childElements(xmldsigNS, "Signature")) == 0 {
+		return nil, errors.New("neither the SAML response nor the assertion is signed")
+	}
+
+	inResponseTo, err := p.validateAssertion(assertion, now)
+	if err != nil {
+		return nil, err
+	}
+	if v := response.attr("InResponseTo"); v != "" && v != inResponseTo {
+		return nil, errors.Errorf("SAML response is in response to %q, but the assertion is in response to %q", v, inResponseTo)
+	}
+	if !isValidRequestID(inResponseTo) {
+		return nil, errors.Errorf("SAML response is in response to an unknown or expired request %q", inResponseTo)
+	}
+
+	attributes := map[string]any{}
+	if subject := assertion.childElement(assertionNS, "Subject"); subject != nil {
+		if nameID := subject.childElement(assertionNS, "NameID"); nameID != nil {
+			attributes[NameIDKey] = nameID.text()
+		}
+	}
+	for _, statement := range assertion.childElements(assertionNS, "AttributeStatement") {
+		for _, attribute := range statement.childElements(assertionNS, "Attribute") {
+			values := attribute.childElements(assertionNS, "AttributeValue")
+			if len(values) == 0 {
+				continue
+			}
+			// Only the first value of the multi-valued attributes is used.
+			value := values[0].text()
+			for _, name := range []string{attribute.attr("Name"), attribute.attr("FriendlyName")} {
+				if name == "" {
+					continue
+				}
+				if _, ok := attributes[name]; !ok {
+					attributes[name] = value
+				}
+			}
+		}
+	}
+	return attributes, nil
+}
+
+func getStatusCode(response *element) string {
+	status := response.childElement(protocolNS, "Status")
+	if status == nil {
+		return ""
+	}
+	statusCode := status.childElement(protocolNS, "StatusCode")
+	if statusCode == nil {
+		return ""
+	}
+	return statusCode.attr("Value")
+}
+
+// validateAssertion validates the issuer, the subject confirmation and the conditions of the assertion,
+// and returns the request ID that the assertion is in response to.
+func (p *IdentityProvider) validateAssertion(assertion *element, now time.Time) (string, error) {
+	issuer := assertion.childElement(assertionNS, "Issuer")
+	if issuer == nil || issuer.text() != p.config.IdPEntityID {
+		return "", errors.Errorf("SAML assertion issuer does not match the IdP entity ID %q", p.config.IdPEntityID)
+	}
+
+	subject := assertion.childElement(assertionNS, "Subject")
+	if subject == nil {
+		return "", errors.New("SAML assertion has no subject")
+	}
+	var confirmErrs []string
+	inResponseTo := ""
+	for _, confirmation := range subject.childElements(assertionNS, "SubjectConfirmation") {
+		v, err := p.validateSubjectConfirmation(confirmation, now)
+		if err != nil {
+			confirmErrs = append(confirmErrs, err.Error())
+			continue
+		}
+		inResponseTo = v
+		break
+	}
+	if inResponseTo == "" {
+		return "", errors.Errorf("SAML assertion subject is not confirmed: %s", strings.Join(confirmErrs, "; "))
+	}
+
+	conditions := assertion.childElement(assertionNS, "Conditions")
+	if conditions == nil {
+		return "", errors.New("SAML assertion has no conditions")
+	}
+	if err := validateTimeRange(conditions, now); err != nil {
+		return "", errors.Wrap(err, "SAML assertion conditions are not satisfied")
+	}
+	audienceRestrictions := conditions.childElements(assertionNS, "AudienceRestriction")
+	if len(audienceRestrictions) == 0 {
+		return "", errors.New("SAML assertion has no audience restriction")
+	}
+	// The assertion is valid for us only if every audience restriction includes us.
+	for _, audienceRestriction := range audienceRestrictions {
+		found := false
+		for _, audience := range audienceRestriction.childElements(assertionNS, "Audience") {
+			if audience.text() == p.config.EntityID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", errors.Errorf("SAML assertion audience does not include the SP entity ID %q", p.config.EntityID)
+		}
+	}
+	return inResponseTo, nil
+}
+
+// validateSubjectConfirmation validates the bearer subject confirmation, and returns the request ID
+// that it is in response to.
+func (p *IdentityProvider) validateSubjectConfirmation(confirmation *element, now time.Time) (string, error) {
+	if method := confirmation.attr("Method"); method != bearerMethod {
+		return "", errors.Errorf("unsupported subject confirmation method %q", method)
+	}
+	data := confirmation.childElement(assertionNS, "SubjectConfirmationData")
+	if data == nil {
+		return "", errors.New("subject confirmation has no data")
+	}
+	if recipient := data.attr("Recipient"); recipient != p.config.ACSURL {
+		return "", errors.Errorf("subject confirmation recipient %q does not match the ACS URL %q", recipient, p.config.ACSURL)
+	}
+	if data.attr("NotOnOrAfter") == "" {
+		return "", errors.New("subject confirmation has no NotOnOrAfter")
+	}
+	if err := validateTimeRange(data, now); err != nil {
+		return "", err
+	}
+	inResponseTo := data.attr("InResponseTo")
+	if inResponseTo == "" {
+		return "", errors.New("subject confirmation has no InResponseTo, IdP-initiated login is not supported")
+	}
+	return inResponseTo, nil
+}
+
+// validateTimeRange validates the NotBefore and NotOnOrAfter attributes of the element with the clock skew.
+func validateTimeRange(e *element, now time.Time) error {
+	if v := e.attr("NotBefore"); v != "" {
+		notBefore, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return errors.Wrapf(err, "invalid NotBefore %q", v)
+		}
+		if now.Add(maxClockSkew).Before(notBefore) {
+			return errors.Errorf("not valid before %s", v)
+		}
+	}
+	if v := e.attr("NotOnOrAfter"); v != "" {
+		notOnOrAfter, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return errors.Wrapf(err, "invalid NotOnOrAfter %q", v)
+		}
+		if !now.Add(-maxClockSkew).Before(notOnOrAfter) {
+			return errors.Errorf("expired at %s", v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,653 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	testEntityID    = "https://bytebase.example.com/saml/metadata/okta"
+	testACSURL      = "https://bytebase.example.com/saml/acs/okta"
+	testIdPEntityID = "https://idp.example.com/metadata"
+	testRequestID   = "id-request"
+)
+
+// testIdP is a local stand-in identity provider signing the SAML responses.
+type testIdP struct {
+	key *rsa.PrivateKey
+}
+
+func newTestKeyPair(t *testing.T) (*rsa.PrivateKey, string, string) {
+	a := require.New(t)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	a.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	a.NoError(err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return key, string(certPEM), string(keyPEM)
+}
+
+type testAssertion struct {
+	issuer       string
+	audience     string
+	recipient    string
+	inResponseTo string
+	notOnOrAfter time.Time
+	email        string
+}
+
+func (a testAssertion) xml(signature string) string {
+	return fmt.Sprintf(`<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" xmlns:xs="http://www.w3.org/2001/XMLSchema" ID="id-assertion" Version="2.0" IssueInstant="%[1]s">`+
+		`<saml:Issuer>%[2]s</saml:Issuer>%[3]s`+
+		`<saml:Subject><saml:NameID Format="urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress">%[4]s</saml:NameID>`+
+		`<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer"><saml:SubjectConfirmationData InResponseTo="%[5]s" NotOnOrAfter="%[6]s" Recipient="%[7]s"/></saml:SubjectConfirmation></saml:Subject>`+
+		`<saml:Conditions NotBefore="%[1]s" NotOnOrAfter="%[6]s"><saml:AudienceRestriction><saml:Audience>%[8]s</saml:Audience></saml:AudienceRestriction></saml:Conditions>`+
+		`<saml:AttributeStatement>`+
+		`<saml:Attribute Name="urn:oid:2.16.840.1.113730.3.1.241" FriendlyName="displayName"><saml:AttributeValue xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="xs:string">Alice &amp; Bob</saml:AttributeValue></saml:Attribute>`+
+		`<saml:Attribute Name="phone"><saml:AttributeValue>+14155552671</saml:AttributeValue></saml:Attribute>`+
+		`</saml:AttributeStatement></saml:Assertion>`,
+		time.Now().UTC().Add(-time.Minute).Format(time.RFC3339), a.issuer, signature, a.email, a.inResponseTo,
+		a.notOnOrAfter.UTC().Format(time.RFC3339), a.recipient, a.audience)
+}
+
+func wrapResponse(assertion string) string {
+	return `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="id-response" Version="2.0" Destination="` + testACSURL + `">` +
+		`<saml:Issuer xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion">` + testIdPEntityID + `</saml:Issuer>` +
+		`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>` +
+		assertion + `</samlp:Response>`
+}
+
+// signedResponse returns the base64 encoded response with the assertion signed by the IdP.
+func (idp *testIdP) signedResponse(t *testing.T, assertion testAssertion) string {
+	a := require.New(t)
+	signature := `<ds:Signature xmlns:ds="http://www.w3.org/2000/09/xmldsig#"><ds:SignedInfo>` +
+		`<ds:CanonicalizationMethod Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"/>` +
+		`<ds:SignatureMethod Algorithm="http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"/>` +
+		`<ds:Reference URI="#id-assertion"><ds:Transforms>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2000/09/xmldsig#enveloped-signature"/>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"><ec:InclusiveNamespaces xmlns:ec="http://www.w3.org/2001/10/xml-exc-c14n#" PrefixList="xs"/></ds:Transform>` +
+		`</ds:Transforms><ds:DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"/>` +
+		`<ds:DigestValue>{{DIGEST}}</ds:DigestValue></ds:Reference></ds:SignedInfo>` +
+		`<ds:SignatureValue>{{SIGNATURE}}</ds:SignatureValue></ds:Signature>`
+
+	// The enveloped signature is excluded from the digest, so the placeholders do not matter.
+	root, err := parseXML([]byte(wrapResponse(assertion.xml(signature))))
+	a.NoError(err)
+	assertionElement := root.childElement(assertionNS, "Assertion")
+	digest := sha256Sum(canonicalize(assertionElement, assertionElement.childElement(xmldsigNS, "Signature"), []string{"xs"}))
+	signature = strings.Replace(signature, "{{DIGEST}}", base64.StdEncoding.EncodeToString(digest), 1)
+
+	root, err = parseXML([]byte(wrapResponse(assertion.xml(signature))))
+	a.NoError(err)
+	signedInfo := root.childElement(assertionNS, "Assertion").childElement(xmldsigNS, "Signature").childElement(xmldsigNS, "SignedInfo")
+	signatureValue, err := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, sha256Sum(canonicalize(signedInfo, nil, nil)))
+	a.NoError(err)
+	signature = strings.Replace(signature, "{{SIGNATURE}}", base64.StdEncoding.EncodeToString(signatureValue), 1)
+	return base64.StdEncoding.EncodeToString([]byte(wrapResponse(assertion.xml(signature))))
+}
+
+func sha256Sum(b []byte) []byte {
+	digest := sha256.Sum256(b)
+	return digest[:]
+}
+
+func newTestIdentityProvider(t *testing.T, idpCertPEM string) *IdentityProvider {
+	_, spCertPEM, spKeyPEM := newTestKeyPair(t)
+	p, err := NewIdentityProvider(IdentityProviderConfig{
+		EntityID:       testEntityID,
+		ACSURL:         testACSURL,
+		IdPEntityID:    testIdPEntityID,
+		SSOURL:         "https://idp.example.com/sso?tenant=1",
+		IdPCertificate: idpCertPEM,
+		SPCertificate:  spCertPEM,
+		SPPrivateKey:   spKeyPEM,
+		NameIDFormat:   "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+		FieldMapping: &storepb.FieldMapping{
+			Identifier:  NameIDKey,
+			DisplayName: "displayName",
+			Email:       NameIDKey,
+			Phone:       "phone",
+		},
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func TestUserInfo(t *testing.T) {
+	key, certPEM, _ := newTestKeyPair(t)
+	idp := &testIdP{key: key}
+	_, otherCertPEM, _ := newTestKeyPair(t)
+	valid := testAssertion{
+		issuer:       testIdPEntityID,
+		audience:     testEntityID,
+		recipient:    testACSURL,
+		inResponseTo: testRequestID,
+		notOnOrAfter: time.Now().Add(5 * time.Minute),
+		email:        "alice@example.com",
+	}
+
+	tests := []struct {
+		name      string
+		response  func() string
+		idpCert   string
+		wantError string
+	}{
+		{
+			name:     "valid",
+			response: func() string { return idp.signedResponse(t, valid) },
+			idpCert:  certPEM,
+		},
+		{
+			name: "wrong audience",
+			response: func() string {
+				assertion := valid
+				assertion.audience = "https://other.example.com"
+				return idp.signedResponse(t, assertion)
+			},
+			idpCert:   certPEM,
+			wantError: "audience",
+		},
+		{
+			name: "wrong issuer",
+			response: func() string {
+				assertion := valid
+				assertion.issuer = "https://evil.example.com"
+				return idp.signedResponse(t, assertion)
+			},
+			idpCert:   certPEM,
+			wantError: "issuer",
+		},
+		{
+			name: "expired",
+			response: func() string {
+				assertion := valid
+				assertion.notOnOrAfter = time.Now().Add(-10 * time.Minute)
+				return idp.signedResponse(t, assertion)
+			},
+			idpCert:   certPEM,
+			wantError: "expired",
+		},
+		{
+			name: "wrong recipient",
+			response: func() string {
+				assertion := valid
+				assertion.recipient = "https://other.example.com/acs"
+				return idp.signedResponse(t, assertion)
+			},
+			idpCert:   certPEM,
+			wantError: "recipient",
+		},
+		{
+			name: "unknown request",
+			response: func() string {
+				assertion := valid
+				assertion.inResponseTo = "id-unknown"
+				return idp.signedResponse(t, assertion)
+			},
+			idpCert:   certPEM,
+			wantError: "unknown or expired request",
+		},
+		{
+			name:      "signed by another IdP",
+			response:  func() string { return idp.signedResponse(t, valid) },
+			idpCert:   otherCertPEM,
+			wantError: "invalid signature",
+		},
+		{
+			name: "tampered",
+			response: func() string {
+				b, err := base64.StdEncoding.DecodeString(idp.signedResponse(t, valid))
+				require.NoError(t, err)
+				return base64.StdEncoding.EncodeToString(bytes.Replace(b, []byte("alice@example.com"), []byte("admin@example.com"), 1))
+			},
+			idpCert:   certPEM,
+			wantError: "digest mismatch",
+		},
+		{
+			name:      "unsigned",
+			response:  func() string { return base64.StdEncoding.EncodeToString([]byte(wrapResponse(valid.xml("")))) },
+			idpCert:   certPEM,
+			wantError: "is signed",
+		},
+		{
+			name: "DTD",
+			response: func() string {
+				return base64.StdEncoding.EncodeToString([]byte(`<!DOCTYPE foo [<!ENTITY x "x">]>` + wrapResponse(valid.xml(""))))
+			},
+			idpCert:   certPEM,
+			wantError: "directives are not allowed",
+		},
+	}
+
+	for _, test := range tests {
+		a := require.New(t)
+		p := newTestIdentityProvider(t, test.idpCert)
+		calls := 0
+		isValidRequestID := func(id string) bool {
+			calls++
+			return id == testRequestID
+		}
+		userInfo, err := p.UserInfo(test.response(), isValidRequestID, time.Now())
+		if test.wantError != "" {
+			a.ErrorContains(err, test.wantError, test.name)
+			continue
+		}
+		a.NoError(err, test.name)
+		a.Equal(1, calls, test.name)
+		a.Equal(&storepb.IdentityProviderUserInfo{
+			Identifier:  "alice@example.com",
+			DisplayName: "Alice & Bob",
+			Email:       "alice@example.com",
+			Phone:       "+14155552671",
+		}, userInfo, test.name)
+	}
+}
+
+// identityProviderFixture is a SAML response captured from a real identity provider, so that the signature
+// verification and the canonicalization are tested against an independent implementation.
+// The fixtures are from https://github.com/russellhaering/gosaml2/tree/main/providertests/testdata.
+type identityProviderFixture struct {
+	name        string
+	response    string
+	certificate string
+	entityID    string
+	acsURL      string
+	idpEntityID string
+	requestID   string
+	now         time.Time
+	// identifier is the name ID of the assertion subject.
+	identifier string
+}
+
+var identityProviderFixtures = []identityProviderFixture{
+	{
+		// Okta signs both the response and the assertion.
+		name:        "okta",
+		response:    "okta_response.xml",
+		certificate: "okta_cert.pem",
+		entityID:    `"123"`,
+		acsURL:      "http://localhost:8080/v1/_saml_callback",
+		idpEntityID: "http://www.okta.com/exk659aytfMeNI49v0h7",
+		requestID:   "_15f66d2d-628b-4d9b-a99e-089d8da862e1",
+		now:         time.Date(2016, 7, 25, 23, 16, 0, 0, time.UTC),
+		identifier:  "russellhaering",
+	},
+	{
+		// ADFS only signs the assertion, which uses the default namespace.
+		name:        "adfs",
+		response:    "adfs_response.xml",
+		certificate: "adfs_cert.pem",
+		entityID:    "https://saml.test.nope/session/sso/saml/spentityid/dknhyszjl7",
+		acsURL:      "https://saml.test.nope/session/sso/saml/acs/dknhyszjl7",
+		idpEntityID: "http://fs.spstest2.com/adfs/services/trust",
+		requestID:   "_5988bf45-1cc8-4228-b3e8-1aa8590e63d3",
+		now:         time.Date(2017, 9, 21, 23, 28, 0, 0, time.UTC),
+		identifier:  "paul@spstest2.com",
+	},
+}
+
+func (f identityProviderFixture) load(t *testing.T) (*IdentityProvider, string) {
+	a := require.New(t)
+	response, err := os.ReadFile(filepath.Join("testdata", f.response))
+	a.NoError(err)
+	certificate, err := os.ReadFile(filepath.Join("testdata", f.certificate))
+	a.NoError(err)
+	_, spCertPEM, spKeyPEM := newTestKeyPair(t)
+	p, err := NewIdentityProvider(IdentityProviderConfig{
+		EntityID:       f.entityID,
+		ACSURL:         f.acsURL,
+		IdPEntityID:    f.idpEntityID,
+		SSOURL:         "https://idp.example.com/sso",
+		IdPCertificate: string(certificate),
+		SPCertificate:  spCertPEM,
+		SPPrivateKey:   spKeyPEM,
+		FieldMapping: &storepb.FieldMapping{
+			Identifier: NameIDKey,
+		},
+	})
+	a.NoError(err)
+	return p, string(response)
+}
+
+func TestUserInfoIdentityProviderFixture(t *testing.T) {
+	a := require.New(t)
+	for _, f := range identityProviderFixtures {
+		p, response := f.load(t)
+		userInfo, err := p.UserInfo(base64.StdEncoding.EncodeToString([]byte(response)), func(id string) bool {
+			return id == f.requestID
+		}, f.now)
+		a.NoError(err, f.name)
+		a.Equal(f.identifier, userInfo.Identifier, f.name)
+
+		// The response can not be used for another request, or after it expires.
+		_, err = p.UserInfo(base64.StdEncoding.EncodeToString([]byte(response)), func(string) bool { return false }, f.now)
+		a.ErrorContains(err, "unknown or expired request", f.name)
+		_, err = p.UserInfo(base64.StdEncoding.EncodeToString([]byte(response)), func(id string) bool {
+			return id == f.requestID
+		}, f.now.Add(2*time.Hour))
+		a.ErrorContains(err, "expired", f.name)
+	}
+
+	// The certificate of another identity provider does not verify the signature.
+	okta, adfs := identityProviderFixtures[0], identityProviderFixtures[1]
+	p, _ := okta.load(t)
+	_, response := adfs.load(t)
+	p.config.ACSURL, p.config.EntityID, p.config.IdPEntityID = adfs.acsURL, adfs.entityID, adfs.idpEntityID
+	_, err := p.UserInfo(base64.StdEncoding.EncodeToString([]byte(response)), func(string) bool { return true }, adfs.now)
+	a.ErrorContains(err, "invalid signature")
+}
+
+func TestUserInfoSignatureWrapping(t *testing.T) {
+	okta, adfs := identityProviderFixtures[0], identityProviderFixtures[1]
+	const (
+		adfsResponseID  = "_b9d3ea70-2a0c-42b6-b8f7-657adeb2bb09"
+		adfsAssertionID = "_fd6108fd-d2bf-4327-a81f-c03b8fca770d"
+	)
+
+	// cut returns the element starting with the start tag and ending with the end tag in the response.
+	cut := func(t *testing.T, response, startTag, endTag string) string {
+		start := strings.Index(response, startTag)
+		require.GreaterOrEqual(t, start, 0, startTag)
+		end := strings.Index(response[start:], endTag)
+		require.GreaterOrEqual(t, end, 0, endTag)
+		return response[start : start+end+len(endTag)]
+	}
+	adfsAssertion := func(t *testing.T, response string) string {
+		return cut(t, response, "<Assertion ", "</Assertion>")
+	}
+	adfsSignature := func(t *testing.T, response string) string {
+		return cut(t, response, "<ds:Signature ", "</ds:Signature>")
+	}
+	// evil replaces the subject of the assertion.
+	evil := func(assertion string) string {
+		return strings.Replace(assertion, "paul@spstest2.com", "admin@spstest2.com", 1)
+	}
+
+	tests := []struct {
+		name    string
+		fixture identityProviderFixture
+		modify  func(t *testing.T, response string) string
+		// identifier is the expected identifier if the modified response is still valid.
+		identifier string
+		wantError  string
+	}{
+		{
+			name:    "modified subject",
+			fixture: adfs,
+			modify: func(_ *testing.T, response string) string {
+				return evil(response)
+			},
+			wantError: "digest mismatch",
+		},
+		{
+			// The comments are excluded from the canonicalization, so the signature is still valid,
+			// but the name ID must not be truncated at the comment.
+			name:    "comment in subject",
+			fixture: adfs,
+			modify: func(_ *testing.T, response string) string {
+				return strings.Replace(response, "paul@spstest2.com", "paul@spstest2<!-- evil -->.com", 1)
+			},
+			identifier: "paul@spstest2.com",
+		},
+		{
+			name:    "comment in subject of the signed response",
+			fixture: okta,
+			modify: func(_ *testing.T, response string) string {
+				return strings.Replace(response, "russellhaering", "russell<!---->haering", 1)
+			},
+			identifier: "russellhaering",
+		},
+		{
+			name:    "evil assertion before the signed assertion",
+			fixture: adfs,
+			modify: func(t *testing.T, response string) string {
+				assertion := adfsAssertion(t, response)
+				unsigned := strings.Replace(assertion, adfsSignature(t, response), "", 1)
+				return strings.Replace(response, assertion, evil(unsigned)+assertion, 1)
+			},
+			wantError: "duplicate XML ID",
+		},
+		{
+			name:    "evil assertion after the signed assertion",
+			fixture: adfs,
+			modify: func(t *testing.T, response string) string {
+				assertion := adfsAssertion(t, response)
+				unsigned := strings.Replace(assertion, adfsSignature(t, response), "", 1)
+				unsigned = strings.Replace(unsigned, `ID="`+adfsAssertionID+`"`, `ID="_evil"`, 1)
+				return strings.Replace(response, assertion, assertion+evil(unsigned), 1)
+			},
+			wantError: "expected one assertion",
+		},
+		{
+			name:    "duplicate ID attribute on the signed assertion",
+			fixture: adfs,
+			modify: func(_ *testing.T, response string) string {
+				return strings.Replace(response, `ID="`+adfsAssertionID+`"`, `ID="_evil" ID="`+adfsAssertionID+`"`, 1)
+			},
+			wantError: "duplicate XML attribute",
+		},
+		{
+			name:    "duplicate ID of the signed assertion in the response",
+			fixture: adfs,
+			modify: func(_ *testing.T, response string) string {
+				return strings.Replace(response, `ID="`+adfsResponseID+`"`, `ID="`+adfsAssertionID+`"`, 1)
+			},
+			wantError: "duplicate XML ID",
+		},
+		{
+			name:    "signature reference to the response instead of the signed assertion",
+			fixture: adfs,
+			modify: func(_ *testing.T, response string) string {
+				return strings.Replace(response, `URI="#`+adfsAssertionID+`"`, `URI="#`+adfsResponseID+`"`, 1)
+			},
+			wantError: "does not refer to the signed element",
+		},
+		{
+			name:    "signed assertion moved to extensions with the evil assertion keeping the ID",
+			fixture: adfs,
+			modify: func(t *testing.T, response string) string {
+				assertion := adfsAssertion(t, response)
+				extensions := `<samlp:Extensions>` + assertion + `</samlp:Extensions>`
+				return strings.Replace(response, assertion, extensions+evil(assertion), 1)
+			},
+			wantError: "duplicate XML ID",
+		},
+		{
+			name:    "signed assertion moved to extensions with the evil assertion of another ID",
+			fixture: adfs,
+			modify: func(t *testing.T, response string) string {
+				assertion := adfsAssertion(t, response)
+				extensions := `<samlp:Extensions>` + assertion + `</samlp:Extensions>`
+				return strings.Replace(response, assertion, extensions+strings.Replace(evil(assertion), `ID="`+adfsAssertionID+`"`, `ID="_evil"`, 1), 1)
+			},
+			wantError: "does not refer to the signed element",
+		},
+		{
+			name:    "signed assertion moved to the signature object of the evil assertion",
+			fixture: adfs,
+			modify: func(t *testing.T, response string) string {
+				assertion := adfsAssertion(t, response)
+				signature := adfsSignature(t, response)
+				wrapped := strings.Replace(signature, "</ds:Signature>", "<ds:Object>"+assertion+"</ds:Object></ds:Signature>", 1)
+				return strings.Replace(response, assertion, strings.Replace(evil(assertion), signature, wrapped, 1), 1)
+			},
+			wantError: "duplicate XML ID",
+		},
+		{
+			name:    "unsigned evil assertion with the signed assertion in extensions",
+			fixture: adfs,
+			modify: func(t *testing.T, response string) string {
+				assertion := adfsAssertion(t, response)
+				unsigned := strings.Replace(assertion, adfsSignature(t, response), "", 1)
+				unsigned = strings.Replace(unsigned, `ID="`+adfsAssertionID+`"`, `ID="_evil"`, 1)
+				extensions := `<samlp:Extensions>` + assertion + `</samlp:Extensions>`
+				return strings.Replace(response, assertion, extensions+evil(unsigned), 1)
+			},
+			wantError: "neither the SAML response nor the assertion is signed",
+		},
+		{
+			name:    "signed response wrapped by the evil response",
+			fixture: okta,
+			modify: func(t *testing.T, response string) string {
+				response = strings.TrimPrefix(response, `<?xml version="1.0" encoding="UTF-8"?>`)
+				outer := strings.Replace(response, "russellhaering", "admin", 1)
+				return strings.Replace(outer, "</saml2p:Response>", "<saml2p:Extensions>"+response+"</saml2p:Extensions></saml2p:Response>", 1)
+			},
+			wantError: "duplicate XML ID",
+		},
+		{
+			name:    "assertion signature removed from the signed response",
+			fixture: okta,
+			modify: func(t *testing.T, response string) string {
+				assertion := cut(t, response, "<saml2:Assertion ", "</saml2:Assertion>")
+				signature := cut(t, assertion, "<ds:Signature ", "</ds:Signature>")
+				return strings.Replace(response, signature, "", 1)
+			},
+			wantError: "failed to verify SAML response signature",
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		p, response := test.fixture.load(t)
+		modified := test.modify(t, response)
+		a.NotEqual(response, modified, test.name)
+		userInfo, err := p.UserInfo(base64.StdEncoding.EncodeToString([]byte(modified)), func(id string) bool {
+			return id == test.fixture.requestID
+		}, test.fixture.now)
+		if test.wantError != "" {
+			a.ErrorContains(err, test.wantError, test.name)
+			continue
+		}
+		a.NoError(err, test.name)
+		a.Equal(test.identifier, userInfo.Identifier, test.name)
+	}
+}
+
+func TestAuthnRequestURL(t *testing.T) {
+	a := require.New(t)
+	_, certPEM, _ := newTestKeyPair(t)
+	p := newTestIdentityProvider(t, certPEM)
+
+	requestID, err := GenerateID()
+	a.NoError(err)
+	redirectURL, err := p.AuthnRequestURL(requestID, "/sql-editor", time.Now())
+	a.NoError(err)
+	a.True(strings.HasPrefix(redirectURL, "https://idp.example.com/sso?tenant=1&SAMLRequest="))
+	u, err := url.Parse(redirectURL)
+	a.NoError(err)
+	query := u.Query()
+	a.Equal("/sql-editor", query.Get("RelayState"))
+	a.Equal(rsaSHA256Alg, query.Get("SigAlg"))
+
+	// The signature covers the query string without the signature itself.
+	signedQuery := u.RawQuery[strings.Index(u.RawQuery, "SAMLRequest="):strings.Index(u.RawQuery, "&Signature=")]
+	signature, err := base64.StdEncoding.DecodeString(query.Get("Signature"))
+	a.NoError(err)
+	a.NoError(rsa.VerifyPKCS1v15(&p.spPrivateKey.PublicKey, crypto.SHA256, sha256Sum([]byte(signedQuery)), signature))
+
+	deflated, err := base64.StdEncoding.DecodeString(query.Get("SAMLRequest"))
+	a.NoError(err)
+	request, err := io.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	a.NoError(err)
+	root, err := parseXML(request)
+	a.NoError(err)
+	a.True(root.is(protocolNS, "AuthnRequest"))
+	a.Equal(requestID, root.attr("ID"))
+	a.Equal(testACSURL, root.attr("AssertionConsumerServiceURL"))
+	a.Equal(testEntityID, root.childElement(assertionNS, "Issuer").text())
+}
+
+func TestMetadata(t *testing.T) {
+	a := require.New(t)
+	_, certPEM, _ := newTestKeyPair(t)
+	p := newTestIdentityProvider(t, certPEM)
+
+	metadata, err := p.Metadata()
+	a.NoError(err)
+	root, err := parseXML(metadata)
+	a.NoError(err)
+	a.True(root.is(metadataNS, "EntityDescriptor"))
+	a.Equal(testEntityID, root.attr("entityID"))
+	descriptor := root.childElement(metadataNS, "SPSSODescriptor")
+	a.NotNil(descriptor)
+	a.Equal("true", descriptor.attr("AuthnRequestsSigned"))
+	acs := descriptor.childElement(metadataNS, "AssertionConsumerService")
+	a.Equal(testACSURL, acs.attr("Location"))
+	a.Equal(httpPostBinding, acs.attr("Binding"))
+	certificate := descriptor.childElement(metadataNS, "KeyDescriptor").childElement(xmldsigNS, "KeyInfo").childElement(xmldsigNS, "X509Data").childElement(xmldsigNS, "X509Certificate")
+	a.Equal(base64.StdEncoding.EncodeToString(p.spCertificate.Raw), certificate.text())
+}
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		input             string
+		path              []string
+		inclusivePrefixes []string
+		want              string
+	}{
+		{
+			input: `<root xmlns="urn:a" xmlns:b="urn:b" xmlns:unused="urn:u"><b:child z="1" a="2" b:x="&amp;&quot;">t&gt;</b:child></root>`,
+			path:  []string{"child"},
+			want:  `<b:child xmlns:b="urn:b" a="2" z="1" b:x="&amp;&quot;">t&gt;</b:child>`,
+		},
+		{
+			input: `<root xmlns="urn:a"><c><d/></c></root>`,
+			path:  []string{"c"},
+			want:  `<c xmlns="urn:a"><d></d></c>`,
+		},
+		{
+			input:             `<root xmlns:xs="urn:xs" xmlns:y="urn:y"><c y:a="1"/></root>`,
+			path:              []string{"c"},
+			inclusivePrefixes: []string{"xs"},
+			want:              `<c xmlns:xs="urn:xs" xmlns:y="urn:y" y:a="1"></c>`,
+		},
+		{
+			input: `<?xml version="1.0"?><root><!-- comment --><a xmlns="urn:a"><b xmlns=""/></a></root>`,
+			want:  `<root><a xmlns="urn:a"><b xmlns=""></b></a></root>`,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		e, err := parseXML([]byte(test.input))
+		a.NoError(err)
+		for _, local := range test.path {
+			for _, child := range e.children {
+				if c, ok := child.(*element); ok && c.local == local {
+					e = c
+					break
+				}
+			}
+		}
+		a.Equal(test.want, string(canonicalize(e, nil, test.inclusivePrefixes)), test.input)
+	}
+}
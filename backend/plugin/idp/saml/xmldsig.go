@@ -0,0 +1,436 @@
+package saml
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"sort"
+	"strings"
+
+	// Register the hash functions used by the XML signatures.
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	xmlNamespace    = "http://www.w3.org/XML/1998/namespace"
+	xmldsigNS       = "http://www.w3.org/2000/09/xmldsig#"
+	excC14NAlg      = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	envelopedAlg    = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	rsaSHA1Alg      = "http://www.w3.org/2000/09/xmldsig#rsa-sha1"
+	rsaSHA256Alg    = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	rsaSHA512Alg    = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	sha1DigestAlg   = "http://www.w3.org/2000/09/xmldsig#sha1"
+	sha256DigestAlg = "http://www.w3.org/2001/04/xmlenc#sha256"
+	sha512DigestAlg = "http://www.w3.org/2001/04/xmlenc#sha512"
+)
+
+var signatureHashes = map[string]crypto.Hash{
+	rsaSHA1Alg:   crypto.SHA1,
+	rsaSHA256Alg: crypto.SHA256,
+	rsaSHA512Alg: crypto.SHA512,
+}
+
+var digestHashes = map[string]crypto.Hash{
+	sha1DigestAlg:   crypto.SHA1,
+	sha256DigestAlg: crypto.SHA256,
+	sha512DigestAlg: crypto.SHA512,
+}
+
+// element is a node of the minimal XML DOM, which keeps the namespace prefixes
+// and declarations as they are in the document for the canonicalization.
+type element struct {
+	prefix string
+	local  string
+	// attrs includes the namespace declarations.
+	attrs    []xml.Attr
+	children []any // *element or string for the character data.
+	parent   *element
+}
+
+// parseXML parses the XML document into the DOM and returns the root element.
+// DTDs are rejected to avoid entity expansion attacks.
+func parseXML(data []byte) (*element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var root, current *element
+	ids := map[string]bool{}
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse XML")
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			e := &element{
+				prefix: t.Name.Space,
+				local:  t.Name.Local,
+				attrs:  t.Copy().Attr,
+				parent: current,
+			}
+			if err := checkAttributes(e.attrs, ids); err != nil {
+				return nil, err
+			}
+			if current == nil {
+				if root != nil {
+					return nil, errors.New("XML document has multiple root elements")
+				}
+				root = e
+			} else {
+				current.children = append(current.children, e)
+			}
+			current = e
+		case xml.EndElement:
+			// RawToken does not verify that the start and end elements match.
+			if current == nil || current.prefix != t.Name.Space || current.local != t.Name.Local {
+				return nil, errors.Errorf("unexpected XML end element %q", qualifiedName(t.Name.Space, t.Name.Local))
+			}
+			current = current.parent
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, string(t))
+			}
+		case xml.Directive:
+			return nil, errors.New("XML directives are not allowed")
+		}
+	}
+	if root == nil {
+		return nil, errors.New("XML document is empty")
+	}
+	if current != nil {
+		return nil, errors.New("XML document is not closed")
+	}
+	return root, nil
+}
+
+// checkAttributes rejects the duplicate attributes of an element, which are not well-formed but accepted by
+// encoding/xml, and the duplicate IDs in the document, so that an ID refers to exactly one element.
+func checkAttributes(attrs []xml.Attr, ids map[string]bool) error {
+	names := map[xml.Name]bool{}
+	for _, attr := range attrs {
+		if names[attr.Name] {
+			return errors.Errorf("duplicate XML attribute %q", qualifiedName(attr.Name.Space, attr.Name.Local))
+		}
+		names[attr.Name] = true
+		if attr.Name.Space == "" && attr.Name.Local == "ID" {
+			if ids[attr.Value] {
+				return errors.Errorf("duplicate XML ID %q", attr.Value)
+			}
+			ids[attr.Value] = true
+		}
+	}
+	return nil
+}
+
+// lookupNamespace returns the namespace URI bound to the prefix in the scope of the element.
+func (e *element) lookupNamespace(prefix string) string {
+	if prefix == "xml" {
+		return xmlNamespace
+	}
+	for n := e; n != nil; n = n.parent {
+		for _, attr := range n.attrs {
+			if prefix == "" && attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+				return attr.Value
+			}
+			if prefix != "" && attr.Name.Space == "xmlns" && attr.Name.Local == prefix {
+				return attr.Value
+			}
+		}
+	}
+	return ""
+}
+
+// is reports whether the element has the namespace URI and local name.
+func (e *element) is(namespace, local string) bool {
+	return e.local == local && e.lookupNamespace(e.prefix) == namespace
+}
+
+// childElements returns the child elements with the namespace URI and local name.
+func (e *element) childElements(namespace, local string) []*element {
+	var elements []*element
+	for _, child := range e.children {
+		if c, ok := child.(*element); ok && c.is(namespace, local) {
+			elements = append(elements, c)
+		}
+	}
+	return elements
+}
+
+// childElement returns the first child element with the namespace URI and local name, or nil.
+func (e *element) childElement(namespace, local string) *element {
+	if elements := e.childElements(namespace, local); len(elements) > 0 {
+		return elements[0]
+	}
+	return nil
+}
+
+// attr returns the value of the unprefixed attribute.
+func (e *element) attr(name string) string {
+	for _, attr := range e.attrs {
+		if attr.Name.Space == "" && attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// text returns the concatenated character data of the element.
+func (e *element) text() string {
+	var b strings.Builder
+	for _, child := range e.children {
+		if s, ok := child.(string); ok {
+			_, _ = b.WriteString(s)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// isNamespaceDeclaration reports whether the attribute declares a namespace.
+func isNamespaceDeclaration(attr xml.Attr) bool {
+	return attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns")
+}
+
+// canonicalize serializes the element with the exclusive XML canonicalization without comments,
+// see https://www.w3.org/TR/xml-exc-c14n/. The skip element is omitted, which is used by the
+// enveloped signature transform. The inclusive prefixes are handled as the InclusiveNamespaces PrefixList.
+func canonicalize(e *element, skip *element, inclusivePrefixes []string) []byte {
+	var buf bytes.Buffer
+	writeCanonical(&buf, e, skip, map[string]string{}, inclusivePrefixes)
+	return buf.Bytes()
+}
+
+func writeCanonical(buf *bytes.Buffer, e *element, skip *element, rendered map[string]string, inclusivePrefixes []string) {
+	// Collect the visibly utilized prefixes and the inclusive prefixes in scope.
+	prefixes := map[string]bool{e.prefix: true}
+	var attrs []xml.Attr
+	for _, attr := range e.attrs {
+		if isNamespaceDeclaration(attr) {
+			continue
+		}
+		attrs = append(attrs, attr)
+		if attr.Name.Space != "" {
+			prefixes[attr.Name.Space] = true
+		}
+	}
+	for _, prefix := range inclusivePrefixes {
+		if prefix == "#default" {
+			prefix = ""
+		}
+		if prefix == "" || e.lookupNamespace(prefix) != "" {
+			prefixes[prefix] = true
+		}
+	}
+
+	scope := map[string]string{}
+	for k, v := range rendered {
+		scope[k] = v
+	}
+	var declarations []string
+	for prefix := range prefixes {
+		if prefix == "xml" {
+			continue
+		}
+		namespace := e.lookupNamespace(prefix)
+		if renderedNamespace, ok := rendered[prefix]; ok && renderedNamespace == namespace {
+			continue
+		}
+		if !hasKey(rendered, prefix) && prefix == "" && namespace == "" {
+			continue
+		}
+		scope[prefix] = namespace
+		declarations = append(declarations, prefix)
+	}
+	sort.Strings(declarations)
+
+	sort.Slice(attrs, func(i, j int) bool {
+		iNamespace, jNamespace := "", ""
+		if attrs[i].Name.Space != "" {
+			iNamespace = e.lookupNamespace(attrs[i].Name.Space)
+		}
+		if attrs[j].Name.Space != "" {
+			jNamespace = e.lookupNamespace(attrs[j].Name.Space)
+		}
+		if iNamespace != jNamespace {
+			return iNamespace < jNamespace
+		}
+		return attrs[i].Name.Local < attrs[j].Name.Local
+	})
+
+	_ = buf.WriteByte('<')
+	_, _ = buf.WriteString(qualifiedName(e.prefix, e.local))
+	for _, prefix := range declarations {
+		if prefix == "" {
+			_, _ = buf.WriteString(` xmlns="`)
+		} else {
+			_, _ = buf.WriteString(` xmlns:` + prefix + `="`)
+		}
+		_, _ = buf.WriteString(escapeAttr(scope[prefix]))
+		_ = buf.WriteByte('"')
+	}
+	for _, attr := range attrs {
+		_ = buf.WriteByte(' ')
+		_, _ = buf.WriteString(qualifiedName(attr.Name.Space, attr.Name.Local))
+		_, _ = buf.WriteString(`="`)
+		_, _ = buf.WriteString(escapeAttr(attr.Value))
+		_ = buf.WriteByte('"')
+	}
+	_ = buf.WriteByte('>')
+	for _, child := range e.children {
+		switch c := child.(type) {
+		case string:
+			_, _ = buf.WriteString(escapeText(c))
+		case *element:
+			if c == skip {
+				continue
+			}
+			writeCanonical(buf, c, skip, scope, inclusivePrefixes)
+		}
+	}
+	_, _ = buf.WriteString("</")
+	_, _ = buf.WriteString(qualifiedName(e.prefix, e.local))
+	_ = buf.WriteByte('>')
+}
+
+func hasKey(m map[string]string, key string) bool {
+	_, ok := m[key]
+	return ok
+}
+
+func qualifiedName(prefix, local string) string {
+	if prefix == "" {
+		return local
+	}
+	return prefix + ":" + local
+}
+
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+
+var attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func escapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
+
+// verifySignature verifies the enveloped signature of the element with the certificate.
+// The signature must be a direct child of the element and reference the element itself,
+// so that the caller can trust the element and its descendants once verified.
+func verifySignature(e *element, cert *x509.Certificate) error {
+	signatures := e.childElements(xmldsigNS, "Signature")
+	if len(signatures) != 1 {
+		return errors.Errorf("expected one signature, but found %d", len(signatures))
+	}
+	signature := signatures[0]
+	signedInfo := signature.childElement(xmldsigNS, "SignedInfo")
+	if signedInfo == nil {
+		return errors.New("signature has no SignedInfo")
+	}
+
+	c14nMethod := signedInfo.childElement(xmldsigNS, "CanonicalizationMethod")
+	if c14nMethod == nil || c14nMethod.attr("Algorithm") != excC14NAlg {
+		return errors.New("unsupported canonicalization method, only exclusive canonicalization without comments is supported")
+	}
+	signatureMethod := signedInfo.childElement(xmldsigNS, "SignatureMethod")
+	if signatureMethod == nil {
+		return errors.New("signature has no SignatureMethod")
+	}
+	signatureHash, ok := signatureHashes[signatureMethod.attr("Algorithm")]
+	if !ok {
+		return errors.Errorf("unsupported signature method %q", signatureMethod.attr("Algorithm"))
+	}
+
+	references := signedInfo.childElements(xmldsigNS, "Reference")
+	if len(references) != 1 {
+		return errors.Errorf("expected one signature reference, but found %d", len(references))
+	}
+	reference := references[0]
+	id := e.attr("ID")
+	if id == "" || reference.attr("URI") != "#"+id {
+		return errors.Errorf("signature reference %q does not refer to the signed element", reference.attr("URI"))
+	}
+
+	var inclusivePrefixes []string
+	enveloped := false
+	if transforms := reference.childElement(xmldsigNS, "Transforms"); transforms != nil {
+		for _, transform := range transforms.childElements(xmldsigNS, "Transform") {
+			switch algorithm := transform.attr("Algorithm"); algorithm {
+			case envelopedAlg:
+				enveloped = true
+			case excC14NAlg:
+				inclusivePrefixes = getInclusivePrefixes(transform)
+			default:
+				return errors.Errorf("unsupported signature transform %q", algorithm)
+			}
+		}
+	}
+	if !enveloped {
+		return errors.New("signature is not an enveloped signature")
+	}
+
+	digestMethod := reference.childElement(xmldsigNS, "DigestMethod")
+	if digestMethod == nil {
+		return errors.New("signature reference has no DigestMethod")
+	}
+	digestHash, ok := digestHashes[digestMethod.attr("Algorithm")]
+	if !ok {
+		return errors.Errorf("unsupported digest method %q", digestMethod.attr("Algorithm"))
+	}
+	digestValue := reference.childElement(xmldsigNS, "DigestValue")
+	if digestValue == nil {
+		return errors.New("signature reference has no DigestValue")
+	}
+	wantDigest, err := decodeBase64(digestValue.text())
+	if err != nil {
+		return errors.Wrap(err, "failed to decode digest value")
+	}
+	h := digestHash.New()
+	_, _ = h.Write(canonicalize(e, signature, inclusivePrefixes))
+	if !bytes.Equal(h.Sum(nil), wantDigest) {
+		return errors.New("digest mismatch, the signed element has been modified")
+	}
+
+	signatureValue := signature.childElement(xmldsigNS, "SignatureValue")
+	if signatureValue == nil {
+		return errors.New("signature has no SignatureValue")
+	}
+	signatureBytes, err := decodeBase64(signatureValue.text())
+	if err != nil {
+		return errors.Wrap(err, "failed to decode signature value")
+	}
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("only RSA certificates are supported")
+	}
+	h = signatureHash.New()
+	_, _ = h.Write(canonicalize(signedInfo, nil, getInclusivePrefixes(c14nMethod)))
+	if err := rsa.VerifyPKCS1v15(publicKey, signatureHash, h.Sum(nil), signatureBytes); err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	return nil
+}
+
+// getInclusivePrefixes returns the PrefixList of the InclusiveNamespaces of the canonicalization method.
+func getInclusivePrefixes(method *element) []string {
+	inclusiveNamespaces := method.childElement(excC14NAlg, "InclusiveNamespaces")
+	if inclusiveNamespaces == nil {
+		return nil
+	}
+	return strings.Fields(inclusiveNamespaces.attr("PrefixList"))
+}
+
+// decodeBase64 decodes the base64 value which may be wrapped with whitespaces.
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
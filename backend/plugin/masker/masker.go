@@ -0,0 +1,211 @@
+// Package masker includes the masking algorithms applied to the query results.
+package masker
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+const (
+	// defaultFullMaskSubstitution is the substitution used by the default full masker.
+	defaultFullMaskSubstitution = "******"
+	// nullValueText is the text of the NULL value used by the maskers working on strings.
+	nullValueText = "NULL"
+)
+
+// Masker is the interface that masks the value of a query result.
+type Masker interface {
+	// Mask returns the masked value, the original value must not be modified.
+	Mask(value *v1pb.RowValue) *v1pb.RowValue
+}
+
+// NewMasker creates the masker for the masking algorithm.
+func NewMasker(algorithm *storepb.MaskingAlgorithmSetting_Algorithm) Masker {
+	switch mask := algorithm.GetMask().(type) {
+	case *storepb.MaskingAlgorithmSetting_Algorithm_FullMask_:
+		return NewFullMasker(mask.FullMask.GetSubstitution())
+	case *storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_:
+		return NewRangeMasker(mask.RangeMask.GetSlices())
+	case *storepb.MaskingAlgorithmSetting_Algorithm_Md5Mask:
+		return NewMD5Masker(mask.Md5Mask.GetSalt())
+	default:
+		return NewNoneMasker()
+	}
+}
+
+// NewDefaultMasker returns the built-in masker for the masking level, it's used if there is no masking algorithm configured.
+func NewDefaultMasker(level storepb.MaskingLevel) Masker {
+	switch level {
+	case storepb.MaskingLevel_FULL:
+		return NewDefaultFullMasker()
+	case storepb.MaskingLevel_PARTIAL:
+		return NewDefaultRangeMasker()
+	default:
+		return NewNoneMasker()
+	}
+}
+
+// NoneMasker returns the original value.
+type NoneMasker struct{}
+
+// NewNoneMasker creates a NoneMasker.
+func NewNoneMasker() *NoneMasker {
+	return &NoneMasker{}
+}
+
+// Mask implements the Masker interface.
+func (*NoneMasker) Mask(value *v1pb.RowValue) *v1pb.RowValue {
+	return value
+}
+
+// FullMasker replaces the whole value with the substitution.
+type FullMasker struct {
+	substitution string
+}
+
+// NewFullMasker creates a FullMasker.
+func NewFullMasker(substitution string) *FullMasker {
+	return &FullMasker{substitution: substitution}
+}
+
+// NewDefaultFullMasker creates a FullMasker with the default substitution "******".
+func NewDefaultFullMasker() *FullMasker {
+	return NewFullMasker(defaultFullMaskSubstitution)
+}
+
+// Mask implements the Masker interface.
+func (m *FullMasker) Mask(*v1pb.RowValue) *v1pb.RowValue {
+	return newStringValue(m.substitution)
+}
+
+// RangeMasker replaces the slices of the value with their substitutions.
+type RangeMasker struct {
+	slices []*storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_Slice
+}
+
+// NewRangeMasker creates a RangeMasker, the slices are ordered by the start index.
+func NewRangeMasker(slices []*storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_Slice) *RangeMasker {
+	sorted := make([]*storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_Slice, len(slices))
+	copy(sorted, slices)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+	return &RangeMasker{slices: sorted}
+}
+
+// Mask implements the Masker interface.
+// The indexes of the slices are based on the characters of the original value, and the parts out of range are ignored.
+func (m *RangeMasker) Mask(value *v1pb.RowValue) *v1pb.RowValue {
+	s := []rune(stringOf(value))
+	var result []rune
+	// next is the index of the first character not written to the result yet.
+	next := 0
+	for _, slice := range m.slices {
+		start, end := max(int(slice.Start), next), min(int(slice.End), len(s))
+		if start >= end {
+			continue
+		}
+		result = append(result, s[next:start]...)
+		result = append(result, []rune(slice.Substitution)...)
+		next = end
+	}
+	result = append(result, s[next:]...)
+	return newStringValue(string(result))
+}
+
+// DefaultRangeMasker keeps the middle part of the value, such as "**ll**" for "hello!".
+type DefaultRangeMasker struct{}
+
+// NewDefaultRangeMasker creates a DefaultRangeMasker.
+func NewDefaultRangeMasker() *DefaultRangeMasker {
+	return &DefaultRangeMasker{}
+}
+
+// Mask implements the Masker interface.
+func (*DefaultRangeMasker) Mask(value *v1pb.RowValue) *v1pb.RowValue {
+	return newStringValue(fmt.Sprintf("**%s**", getMiddlePartOfString(stringOf(value))))
+}
+
+// MD5Masker replaces the value with the MD5 hash of the salted value.
+// The result is deterministic, so the masked values can still be joined and grouped.
+type MD5Masker struct {
+	salt string
+}
+
+// NewMD5Masker creates a MD5Masker.
+func NewMD5Masker(salt string) *MD5Masker {
+	return &MD5Masker{salt: salt}
+}
+
+// Mask implements the Masker interface.
+func (m *MD5Masker) Mask(value *v1pb.RowValue) *v1pb.RowValue {
+	if _, ok := value.GetKind().(*v1pb.RowValue_NullValue); ok || value.GetKind() == nil {
+		// Keep NULL as it is, so that the hash of the NULL value doesn't collide with the string "NULL".
+		return value
+	}
+	sum := md5.Sum([]byte(stringOf(value) + m.salt))
+	return newStringValue(hex.EncodeToString(sum[:]))
+}
+
+// getMiddlePartOfString returns the middle half of the string.
+func getMiddlePartOfString(stmt string) string {
+	if len(stmt) == 0 || len(stmt) == 1 {
+		return ""
+	}
+	if len(stmt) == 2 || len(stmt) == 3 {
+		return string(stmt[1])
+	}
+
+	s := []rune(stmt)
+	if len(s)%4 != 0 {
+		s = s[:len(s)/4*4]
+	}
+
+	var ret []rune
+	ret = append(ret, s[len(s)/4:len(s)/2]...)
+	ret = append(ret, s[len(s)/2:len(s)/4*3]...)
+	return string(ret)
+}
+
+// stringOf returns the text of the value, which the maskers working on strings use.
+func stringOf(value *v1pb.RowValue) string {
+	switch v := value.GetKind().(type) {
+	case *v1pb.RowValue_StringValue:
+		return v.StringValue
+	case *v1pb.RowValue_BytesValue:
+		return string(v.BytesValue)
+	case *v1pb.RowValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue)
+	case *v1pb.RowValue_Int32Value:
+		return strconv.FormatInt(int64(v.Int32Value), 10)
+	case *v1pb.RowValue_Int64Value:
+		return strconv.FormatInt(v.Int64Value, 10)
+	case *v1pb.RowValue_Uint32Value:
+		return strconv.FormatUint(uint64(v.Uint32Value), 10)
+	case *v1pb.RowValue_Uint64Value:
+		return strconv.FormatUint(v.Uint64Value, 10)
+	case *v1pb.RowValue_FloatValue:
+		return strconv.FormatFloat(float64(v.FloatValue), 'f', -1, 32)
+	case *v1pb.RowValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'f', -1, 64)
+	case *v1pb.RowValue_ValueValue:
+		if bytes, err := protojson.Marshal(v.ValueValue); err == nil {
+			return string(bytes)
+		}
+		return v.ValueValue.String()
+	default:
+		return nullValueText
+	}
+}
+
+func newStringValue(s string) *v1pb.RowValue {
+	return &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: s}}
+}
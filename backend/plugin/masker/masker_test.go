@@ -0,0 +1,129 @@
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestMasker(t *testing.T) {
+	nullValue := &v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{NullValue: structpb.NullValue_NULL_VALUE}}
+	tests := []struct {
+		description string
+		algorithm   *storepb.MaskingAlgorithmSetting_Algorithm
+		input       *v1pb.RowValue
+		want        *v1pb.RowValue
+	}{
+		{
+			description: "full mask",
+			algorithm: &storepb.MaskingAlgorithmSetting_Algorithm{
+				Mask: &storepb.MaskingAlgorithmSetting_Algorithm_FullMask_{FullMask: &storepb.MaskingAlgorithmSetting_Algorithm_FullMask{Substitution: "###"}},
+			},
+			input: newStringValue("bytebase"),
+			want:  newStringValue("###"),
+		},
+		{
+			description: "range mask",
+			algorithm: &storepb.MaskingAlgorithmSetting_Algorithm{
+				Mask: &storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_{RangeMask: &storepb.MaskingAlgorithmSetting_Algorithm_RangeMask{
+					Slices: []*storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_Slice{
+						{Start: 6, End: 100, Substitution: "#"},
+						{Start: 0, End: 2, Substitution: "*"},
+					},
+				}},
+			},
+			input: newStringValue("bytebase"),
+			want:  newStringValue("*teba#"),
+		},
+		{
+			description: "range mask on unicode and numbers",
+			algorithm: &storepb.MaskingAlgorithmSetting_Algorithm{
+				Mask: &storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_{RangeMask: &storepb.MaskingAlgorithmSetting_Algorithm_RangeMask{
+					Slices: []*storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_Slice{
+						{Start: 1, End: 3, Substitution: "**"},
+					},
+				}},
+			},
+			input: &v1pb.RowValue{Kind: &v1pb.RowValue_Int64Value{Int64Value: 12345}},
+			want:  newStringValue("1**45"),
+		},
+		{
+			description: "md5 mask",
+			algorithm: &storepb.MaskingAlgorithmSetting_Algorithm{
+				Mask: &storepb.MaskingAlgorithmSetting_Algorithm_Md5Mask{Md5Mask: &storepb.MaskingAlgorithmSetting_Algorithm_MD5Mask{Salt: "salt"}},
+			},
+			input: newStringValue("bytebase"),
+			// md5("bytebasesalt")
+			want: newStringValue("3f26a85514daebaf1518e1f42b4a20c4"),
+		},
+		{
+			description: "md5 mask keeps NULL",
+			algorithm: &storepb.MaskingAlgorithmSetting_Algorithm{
+				Mask: &storepb.MaskingAlgorithmSetting_Algorithm_Md5Mask{Md5Mask: &storepb.MaskingAlgorithmSetting_Algorithm_MD5Mask{Salt: "salt"}},
+			},
+			input: nullValue,
+			want:  nullValue,
+		},
+		{
+			description: "no mask",
+			algorithm:   &storepb.MaskingAlgorithmSetting_Algorithm{},
+			input:       newStringValue("bytebase"),
+			want:        newStringValue("bytebase"),
+		},
+	}
+
+	a := require.New(t)
+	for _, tc := range tests {
+		got := NewMasker(tc.algorithm).Mask(tc.input)
+		a.Equal(tc.want.String(), got.String(), tc.description)
+	}
+}
+
+func TestDefaultMasker(t *testing.T) {
+	tests := []struct {
+		level storepb.MaskingLevel
+		input *v1pb.RowValue
+		want  *v1pb.RowValue
+	}{
+		{
+			level: storepb.MaskingLevel_FULL,
+			input: newStringValue("bytebase"),
+			want:  newStringValue("******"),
+		},
+		{
+			level: storepb.MaskingLevel_PARTIAL,
+			input: newStringValue("bytebase"),
+			want:  newStringValue("**teba**"),
+		},
+		{
+			level: storepb.MaskingLevel_PARTIAL,
+			input: &v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{NullValue: structpb.NullValue_NULL_VALUE}},
+			want:  newStringValue("**UL**"),
+		},
+		{
+			level: storepb.MaskingLevel_NONE,
+			input: newStringValue("bytebase"),
+			want:  newStringValue("bytebase"),
+		},
+	}
+
+	a := require.New(t)
+	for _, tc := range tests {
+		got := NewDefaultMasker(tc.level).Mask(tc.input)
+		a.Equal(tc.want.String(), got.String(), tc.level.String())
+	}
+}
+
+func TestMD5MaskerDeterministic(t *testing.T) {
+	a := require.New(t)
+	m := NewMD5Masker("salt")
+	first := m.Mask(newStringValue("bytebase"))
+	second := m.Mask(newStringValue("bytebase"))
+	a.Equal(first.String(), second.String())
+	other := NewMD5Masker("pepper").Mask(newStringValue("bytebase"))
+	a.NotEqual(first.String(), other.String())
+}
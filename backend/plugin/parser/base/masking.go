@@ -3,6 +3,7 @@ package base
 import (
 	"cmp"
 
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
@@ -19,6 +20,31 @@ type SensitiveSchemaInfo struct {
 	DatabaseList        []DatabaseSchema
 }
 
+// FindTable finds the table schema by the database, schema and table name, it returns nil if not found.
+func (s *SensitiveSchemaInfo) FindTable(databaseName, schemaName, tableName string) *TableSchema {
+	if s == nil {
+		return nil
+	}
+	for i := range s.DatabaseList {
+		database := &s.DatabaseList[i]
+		if database.Name != databaseName {
+			continue
+		}
+		for j := range database.SchemaList {
+			schema := &database.SchemaList[j]
+			if schema.Name != schemaName {
+				continue
+			}
+			for k := range schema.TableList {
+				if schema.TableList[k].Name == tableName {
+					return &schema.TableList[k]
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // DatabaseSchema is the database schema using to extract sensitive fields.
 type DatabaseSchema struct {
 	Name       string
@@ -68,6 +94,8 @@ type FieldInfo struct {
 // MaskingAttributes contain the masking related attributes on the column, likes MaskingLevel.
 type MaskingAttributes struct {
 	MaskingLevel storepb.MaskingLevel
+	// Masker is the masker resolved from the semantic type of the column, nil means the default masker of the MaskingLevel.
+	Masker masker.Masker
 }
 
 // TransmittedBy transmits the masking attributes from other to self.
@@ -75,6 +103,7 @@ func (m *MaskingAttributes) TransmittedBy(other MaskingAttributes) (changed bool
 	changed = false
 	if cmp.Less(m.MaskingLevel, other.MaskingLevel) {
 		m.MaskingLevel = other.MaskingLevel
+		m.Masker = other.Masker
 		changed = true
 	}
 	return changed
@@ -89,7 +118,16 @@ func (m *MaskingAttributes) IsNeverChangeInTransmission() bool {
 func (m *MaskingAttributes) Clone() MaskingAttributes {
 	return MaskingAttributes{
 		MaskingLevel: m.MaskingLevel,
+		Masker:       m.Masker,
+	}
+}
+
+// GetMasker returns the masker to apply, it falls back to the default masker of the MaskingLevel.
+func (m *MaskingAttributes) GetMasker() masker.Masker {
+	if m.Masker != nil && (m.MaskingLevel == storepb.MaskingLevel_FULL || m.MaskingLevel == storepb.MaskingLevel_PARTIAL) {
+		return m.Masker
 	}
+	return masker.NewDefaultMasker(m.MaskingLevel)
 }
 
 // NewMaskingAttributes creates a new masking attributes.
@@ -99,6 +137,14 @@ func NewMaskingAttributes(lvl storepb.MaskingLevel) MaskingAttributes {
 	}
 }
 
+// NewMaskingAttributesWithMasker creates a new masking attributes with the masker resolved from the semantic type.
+func NewMaskingAttributesWithMasker(lvl storepb.MaskingLevel, m masker.Masker) MaskingAttributes {
+	return MaskingAttributes{
+		MaskingLevel: lvl,
+		Masker:       m,
+	}
+}
+
 // NewDefaultMaskingAttributes creates a new masking attributes with default masking level.
 func NewDefaultMaskingAttributes() MaskingAttributes {
 	return NewMaskingAttributes(defaultMaskingLevel)
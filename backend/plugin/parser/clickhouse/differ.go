@@ -0,0 +1,936 @@
+// Package clickhouse provides the ClickHouse parser plugin.
+package clickhouse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	// rebuildNewTablePrefix and rebuildOldTablePrefix are the name prefixes of the intermediate tables used to rebuild a table.
+	rebuildNewTablePrefix = "_bb_new_"
+	rebuildOldTablePrefix = "_bb_old_"
+)
+
+func init() {
+	base.RegisterSchemaDiffFunc(storepb.Engine_CLICKHOUSE, SchemaDiff)
+}
+
+// diffNode defines different modification types as the safe change order.
+// The safe change order means we can change them with no dependency conflicts as this order.
+type diffNode struct {
+	// Drop nodes
+	dropViewList       []string
+	dropDictionaryList []string
+	dropProjectionList []string
+	dropIndexList      []string
+	dropConstraintList []string
+	dropColumnList     []string
+	dropTableList      []string
+
+	// Create nodes
+	rebuildTableList           []string
+	createTableList            []string
+	addColumnList              []string
+	modifyColumnList           []string
+	alterTableList             []string
+	addConstraintList          []string
+	addIndexList               []string
+	addProjectionList          []string
+	createDictionaryList       []string
+	createMaterializedViewList []string
+	createViewList             []string
+}
+
+type objectKind int
+
+const (
+	objectKindTable objectKind = iota
+	objectKindView
+	objectKindMaterializedView
+	objectKindDictionary
+)
+
+// objectMap stores the objects by name, the tables, views and dictionaries share the same namespace in a ClickHouse database.
+type objectMap map[string]*objectInfo
+
+type objectInfo struct {
+	id   int
+	kind objectKind
+	name string
+	// definition is the text following the object name, it's only used for views and dictionaries.
+	definition string
+	// signature is the normalized text of the object definition, so that the whitespaces and comments don't affect the comparison.
+	signature string
+	// toTable is the target table of the materialized view. It's empty if the materialized view stores the data in its inner table.
+	toTable string
+	table   *tableInfo
+}
+
+type tableInfo struct {
+	columnList     []*columnInfo
+	columnMap      map[string]*columnInfo
+	indexList      []*elementInfo
+	constraintList []*elementInfo
+	projectionList []*elementInfo
+	// elementList is the raw text of the elements in the parentheses, and clauseList is the raw text of the clauses following the parentheses.
+	// We use them to print the CREATE TABLE statement.
+	elementList []string
+	clauseList  []string
+
+	engine      clause
+	engineName  string
+	partitionBy clause
+	primaryKey  clause
+	orderBy     clause
+	// orderByList is the signature list of the sorting key expressions.
+	orderByList []string
+	sampleBy    clause
+	ttl         clause
+	comment     string
+	settingList []string
+	settingMap  map[string]string
+	// keyColumnMap is the columns referenced by the partition key, primary key, sorting key and sampling key.
+	// ClickHouse cannot drop these columns or change their types without rebuilding the table.
+	keyColumnMap map[string]bool
+}
+
+// clause is a part of the definition with its raw text and the normalized signature.
+type clause struct {
+	text      string
+	signature string
+}
+
+type columnInfo struct {
+	name       string
+	definition string
+	dataType   clause
+	// defaultKind is one of DEFAULT, MATERIALIZED, ALIAS and EPHEMERAL.
+	defaultKind string
+	defaultExpr clause
+	codec       clause
+	ttl         clause
+	comment     string
+}
+
+// elementInfo is the index, constraint or projection of a table.
+type elementInfo struct {
+	name string
+	// definition is the text following the element name.
+	definition string
+	signature  string
+}
+
+// readOnlySettings are the MergeTree settings that cannot be changed after the table is created.
+var readOnlySettings = map[string]bool{
+	"index_granularity": true,
+}
+
+// defaultSettings are the MergeTree settings that ClickHouse adds to the table definition implicitly.
+var defaultSettings = map[string]string{
+	"index_granularity": "8192",
+}
+
+// SchemaDiff computes the migration DDL from the old schema to the new schema for ClickHouse.
+// The changes that cannot be done by ALTER, such as changing the engine or the sorting key of a table,
+// are done by rebuilding the table, and they are flagged by comments in the migration DDL.
+func SchemaDiff(oldStmt, newStmt string, _ bool) (string, error) {
+	oldObjectMap, err := buildObjectMap(oldStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema info for old statement")
+	}
+	newObjectMap, err := buildObjectMap(newStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema info for new statement")
+	}
+
+	diff := &diffNode{}
+	for _, newObject := range sortedObjects(newObjectMap) {
+		oldObject, exists := oldObjectMap[newObject.name]
+		if !exists || oldObject.kind != newObject.kind {
+			diff.createObject(newObject)
+			continue
+		}
+		delete(oldObjectMap, newObject.name)
+		if oldObject.signature == newObject.signature {
+			continue
+		}
+		diff.modifyObject(oldObject, newObject)
+	}
+
+	// Drop the remaining objects in the reverse order of the old schema.
+	oldObjects := sortedObjects(oldObjectMap)
+	for i := len(oldObjects) - 1; i >= 0; i-- {
+		diff.dropObject(oldObjects[i])
+	}
+
+	return diff.deparse()
+}
+
+func (diff *diffNode) createObject(object *objectInfo) {
+	switch object.kind {
+	case objectKindTable:
+		diff.createTableList = append(diff.createTableList, printCreateTable(quoteIdentifier(object.name), object.table))
+	case objectKindView:
+		diff.createViewList = append(diff.createViewList, fmt.Sprintf("CREATE VIEW %s %s;", quoteIdentifier(object.name), object.definition))
+	case objectKindMaterializedView:
+		diff.createMaterializedViewList = append(diff.createMaterializedViewList, fmt.Sprintf("CREATE MATERIALIZED VIEW %s %s;", quoteIdentifier(object.name), object.definition))
+	case objectKindDictionary:
+		diff.createDictionaryList = append(diff.createDictionaryList, fmt.Sprintf("CREATE DICTIONARY %s %s;", quoteIdentifier(object.name), object.definition))
+	}
+}
+
+func (diff *diffNode) dropObject(object *objectInfo) {
+	name := quoteIdentifier(object.name)
+	switch object.kind {
+	case objectKindTable:
+		diff.dropTableList = append(diff.dropTableList, fmt.Sprintf("DROP TABLE %s;", name))
+	case objectKindView, objectKindMaterializedView:
+		diff.dropViewList = append(diff.dropViewList, fmt.Sprintf("DROP VIEW %s;", name))
+	case objectKindDictionary:
+		diff.dropDictionaryList = append(diff.dropDictionaryList, fmt.Sprintf("DROP DICTIONARY %s;", name))
+	}
+}
+
+func (diff *diffNode) modifyObject(oldObject *objectInfo, newObject *objectInfo) {
+	name := quoteIdentifier(newObject.name)
+	switch newObject.kind {
+	case objectKindTable:
+		diff.modifyTable(newObject.name, oldObject.table, newObject.table)
+	case objectKindView:
+		diff.createViewList = append(diff.createViewList, fmt.Sprintf("CREATE OR REPLACE VIEW %s %s;", name, newObject.definition))
+	case objectKindMaterializedView:
+		// The materialized views cannot be replaced, so we drop and recreate them.
+		diff.dropObject(oldObject)
+		if oldObject.toTable == "" {
+			diff.createMaterializedViewList = append(diff.createMaterializedViewList, fmt.Sprintf("-- Rebuild materialized view %s, the data in its inner table is dropped and needs to be repopulated.", name))
+		}
+		diff.createObject(newObject)
+	case objectKindDictionary:
+		diff.createDictionaryList = append(diff.createDictionaryList, fmt.Sprintf("CREATE OR REPLACE DICTIONARY %s %s;", name, newObject.definition))
+	}
+}
+
+func (diff *diffNode) modifyTable(name string, oldTable *tableInfo, newTable *tableInfo) {
+	if reasons := rebuildReasons(oldTable, newTable); len(reasons) > 0 {
+		diff.rebuildTable(name, oldTable, newTable, reasons)
+		return
+	}
+
+	tableName := quoteIdentifier(name)
+
+	// Data skipping indexes, constraints and projections.
+	diff.dropIndexList = append(diff.dropIndexList, dropElements(tableName, "INDEX", oldTable.indexList, newTable.indexList)...)
+	diff.dropConstraintList = append(diff.dropConstraintList, dropElements(tableName, "CONSTRAINT", oldTable.constraintList, newTable.constraintList)...)
+	diff.dropProjectionList = append(diff.dropProjectionList, dropElements(tableName, "PROJECTION", oldTable.projectionList, newTable.projectionList)...)
+	mergeTree := isMergeTree(newTable.engineName)
+	for _, index := range addedElements(oldTable.indexList, newTable.indexList) {
+		diff.addIndexList = append(diff.addIndexList, fmt.Sprintf("ALTER TABLE %s ADD INDEX %s %s;", tableName, quoteIdentifier(index.name), index.definition))
+		if mergeTree {
+			// The new index is only built for the newly inserted data, so we materialize it for the existing data.
+			diff.addIndexList = append(diff.addIndexList, fmt.Sprintf("ALTER TABLE %s MATERIALIZE INDEX %s;", tableName, quoteIdentifier(index.name)))
+		}
+	}
+	for _, constraint := range addedElements(oldTable.constraintList, newTable.constraintList) {
+		diff.addConstraintList = append(diff.addConstraintList, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s;", tableName, quoteIdentifier(constraint.name), constraint.definition))
+	}
+	for _, projection := range addedElements(oldTable.projectionList, newTable.projectionList) {
+		diff.addProjectionList = append(diff.addProjectionList, fmt.Sprintf("ALTER TABLE %s ADD PROJECTION %s %s;", tableName, quoteIdentifier(projection.name), projection.definition))
+		if mergeTree {
+			diff.addProjectionList = append(diff.addProjectionList, fmt.Sprintf("ALTER TABLE %s MATERIALIZE PROJECTION %s;", tableName, quoteIdentifier(projection.name)))
+		}
+	}
+
+	// Columns.
+	for _, oldColumn := range oldTable.columnList {
+		if _, exists := newTable.columnMap[oldColumn.name]; !exists {
+			diff.dropColumnList = append(diff.dropColumnList, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", tableName, quoteIdentifier(oldColumn.name)))
+		}
+	}
+	// The columns appended to the sorting key must be added in the same ALTER statement as MODIFY ORDER BY.
+	var orderByActions []string
+	orderByColumns := appendedOrderByColumns(oldTable, newTable)
+	for i, newColumn := range newTable.columnList {
+		oldColumn, exists := oldTable.columnMap[newColumn.name]
+		if exists {
+			diff.modifyColumnList = append(diff.modifyColumnList, modifyColumn(tableName, oldColumn, newColumn)...)
+			continue
+		}
+		position := "FIRST"
+		if i > 0 {
+			position = fmt.Sprintf("AFTER %s", quoteIdentifier(newTable.columnList[i-1].name))
+		}
+		action := fmt.Sprintf("ADD COLUMN %s %s %s", quoteIdentifier(newColumn.name), newColumn.definition, position)
+		if orderByColumns[newColumn.name] {
+			orderByActions = append(orderByActions, action)
+			continue
+		}
+		diff.addColumnList = append(diff.addColumnList, fmt.Sprintf("ALTER TABLE %s %s;", tableName, action))
+	}
+	if oldTable.orderBy.signature != newTable.orderBy.signature {
+		orderByActions = append(orderByActions, fmt.Sprintf("MODIFY ORDER BY %s", newTable.orderBy.text))
+		diff.alterTableList = append(diff.alterTableList, fmt.Sprintf("ALTER TABLE %s %s;", tableName, strings.Join(orderByActions, ", ")))
+	}
+
+	// Table properties.
+	if oldTable.sampleBy.signature != newTable.sampleBy.signature {
+		if newTable.sampleBy.text == "" {
+			diff.alterTableList = append(diff.alterTableList, fmt.Sprintf("ALTER TABLE %s REMOVE SAMPLE BY;", tableName))
+		} else {
+			diff.alterTableList = append(diff.alterTableList, fmt.Sprintf("ALTER TABLE %s MODIFY SAMPLE BY %s;", tableName, newTable.sampleBy.text))
+		}
+	}
+	if oldTable.ttl.signature != newTable.ttl.signature {
+		if newTable.ttl.text == "" {
+			diff.alterTableList = append(diff.alterTableList, fmt.Sprintf("ALTER TABLE %s REMOVE TTL;", tableName))
+		} else {
+			diff.alterTableList = append(diff.alterTableList, fmt.Sprintf("ALTER TABLE %s MODIFY TTL %s;", tableName, newTable.ttl.text))
+		}
+	}
+	var modifySettings, resetSettings []string
+	for _, key := range newTable.settingList {
+		if oldTable.settingMap[key] != newTable.settingMap[key] {
+			modifySettings = append(modifySettings, fmt.Sprintf("%s = %s", key, newTable.settingMap[key]))
+		}
+	}
+	for _, key := range oldTable.settingList {
+		if _, exists := newTable.settingMap[key]; !exists && defaultSettings[key] != oldTable.settingMap[key] {
+			resetSettings = append(resetSettings, key)
+		}
+	}
+	if len(modifySettings) > 0 {
+		diff.alterTableList = append(diff.alterTableList, fmt.Sprintf("ALTER TABLE %s MODIFY SETTING %s;", tableName, strings.Join(modifySettings, ", ")))
+	}
+	if len(resetSettings) > 0 {
+		diff.alterTableList = append(diff.alterTableList, fmt.Sprintf("ALTER TABLE %s RESET SETTING %s;", tableName, strings.Join(resetSettings, ", ")))
+	}
+	if oldTable.comment != newTable.comment {
+		comment := newTable.comment
+		if comment == "" {
+			comment = "''"
+		}
+		diff.alterTableList = append(diff.alterTableList, fmt.Sprintf("ALTER TABLE %s MODIFY COMMENT %s;", tableName, comment))
+	}
+}
+
+func modifyColumn(tableName string, oldColumn *columnInfo, newColumn *columnInfo) []string {
+	columnName := quoteIdentifier(newColumn.name)
+	var stmts []string
+	if oldColumn.dataType.signature != newColumn.dataType.signature ||
+		(newColumn.defaultKind != "" && (oldColumn.defaultKind != newColumn.defaultKind || oldColumn.defaultExpr.signature != newColumn.defaultExpr.signature)) ||
+		(newColumn.codec.text != "" && oldColumn.codec.signature != newColumn.codec.signature) ||
+		(newColumn.ttl.text != "" && oldColumn.ttl.signature != newColumn.ttl.signature) {
+		parts := []string{columnName}
+		if newColumn.dataType.text != "" {
+			parts = append(parts, newColumn.dataType.text)
+		}
+		if newColumn.defaultKind != "" {
+			parts = append(parts, newColumn.defaultKind)
+			if newColumn.defaultExpr.text != "" {
+				parts = append(parts, newColumn.defaultExpr.text)
+			}
+		}
+		if newColumn.codec.text != "" {
+			parts = append(parts, "CODEC"+newColumn.codec.text)
+		}
+		if newColumn.ttl.text != "" {
+			parts = append(parts, "TTL", newColumn.ttl.text)
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", tableName, strings.Join(parts, " ")))
+	}
+	if oldColumn.defaultKind != "" && newColumn.defaultKind == "" {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s REMOVE %s;", tableName, columnName, oldColumn.defaultKind))
+	}
+	if oldColumn.codec.text != "" && newColumn.codec.text == "" {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s REMOVE CODEC;", tableName, columnName))
+	}
+	if oldColumn.ttl.text != "" && newColumn.ttl.text == "" {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s REMOVE TTL;", tableName, columnName))
+	}
+	if oldColumn.comment != newColumn.comment {
+		comment := newColumn.comment
+		if comment == "" {
+			comment = "''"
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s COMMENT COLUMN %s %s;", tableName, columnName, comment))
+	}
+	return stmts
+}
+
+// rebuildReasons returns the reasons why the table cannot be changed by ALTER and needs to be rebuilt.
+func rebuildReasons(oldTable *tableInfo, newTable *tableInfo) []string {
+	var reasons []string
+	if oldTable.engine.signature != newTable.engine.signature {
+		reasons = append(reasons, fmt.Sprintf("the engine is changed from %s to %s", oldTable.engine.text, newTable.engine.text))
+	}
+	if oldTable.partitionBy.signature != newTable.partitionBy.signature {
+		reasons = append(reasons, "the partition key is changed")
+	}
+	if effectivePrimaryKey(oldTable) != effectivePrimaryKey(newTable) {
+		reasons = append(reasons, "the primary key is changed")
+	}
+	if oldTable.orderBy.signature != newTable.orderBy.signature && appendedOrderByColumns(oldTable, newTable) == nil {
+		reasons = append(reasons, "the sorting key is changed")
+	}
+	for _, key := range sortedKeys(readOnlySettings) {
+		oldValue, newValue := settingValue(oldTable, key), settingValue(newTable, key)
+		if oldValue != newValue {
+			reasons = append(reasons, fmt.Sprintf("the setting %s is changed from %s to %s", key, oldValue, newValue))
+		}
+	}
+	for _, oldColumn := range oldTable.columnList {
+		if !oldTable.keyColumnMap[oldColumn.name] {
+			continue
+		}
+		newColumn, exists := newTable.columnMap[oldColumn.name]
+		if !exists {
+			reasons = append(reasons, fmt.Sprintf("the key column %s is dropped", quoteIdentifier(oldColumn.name)))
+		} else if oldColumn.dataType.signature != newColumn.dataType.signature {
+			reasons = append(reasons, fmt.Sprintf("the type of key column %s is changed", quoteIdentifier(oldColumn.name)))
+		}
+	}
+	if !supportsAlter(newTable.engineName) && strings.Join(elementSignatures(oldTable), ",") != strings.Join(elementSignatures(newTable), ",") {
+		reasons = append(reasons, fmt.Sprintf("the engine %s doesn't support ALTER", newTable.engineName))
+	}
+	return reasons
+}
+
+// rebuildTable recreates the table with the new definition. If the table stores data, the data is copied to the new table.
+func (diff *diffNode) rebuildTable(name string, oldTable *tableInfo, newTable *tableInfo, reasons []string) {
+	tableName := quoteIdentifier(name)
+	diff.rebuildTableList = append(diff.rebuildTableList, fmt.Sprintf("-- Rebuild table %s, because %s.", tableName, strings.Join(reasons, ", ")))
+	if !holdsData(oldTable.engineName) || !holdsData(newTable.engineName) {
+		diff.rebuildTableList = append(diff.rebuildTableList, fmt.Sprintf("DROP TABLE %s;", tableName), printCreateTable(tableName, newTable))
+		return
+	}
+
+	newTableName := quoteIdentifier(rebuildNewTablePrefix + name)
+	oldTableName := quoteIdentifier(rebuildOldTablePrefix + name)
+	diff.rebuildTableList = append(diff.rebuildTableList, printCreateTable(newTableName, newTable))
+	var columns []string
+	for _, newColumn := range newTable.columnList {
+		oldColumn, exists := oldTable.columnMap[newColumn.name]
+		if !exists || !isStoredColumn(oldColumn) || !isInsertableColumn(newColumn) {
+			continue
+		}
+		columns = append(columns, quoteIdentifier(newColumn.name))
+	}
+	if len(columns) > 0 {
+		columnList := strings.Join(columns, ", ")
+		diff.rebuildTableList = append(diff.rebuildTableList, fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s;", newTableName, columnList, columnList, tableName))
+	}
+	diff.rebuildTableList = append(diff.rebuildTableList,
+		fmt.Sprintf("RENAME TABLE %s TO %s, %s TO %s;", tableName, oldTableName, newTableName, tableName),
+		fmt.Sprintf("DROP TABLE %s;", oldTableName),
+	)
+}
+
+// appendedOrderByColumns returns the columns appended to the sorting key if the sorting key can be changed by MODIFY ORDER BY, otherwise returns nil.
+// ClickHouse only allows appending the columns that are added in the same ALTER statement without default value to the sorting key.
+func appendedOrderByColumns(oldTable *tableInfo, newTable *tableInfo) map[string]bool {
+	if oldTable.orderBy.signature == newTable.orderBy.signature {
+		return map[string]bool{}
+	}
+	if !isMergeTree(newTable.engineName) || len(newTable.orderByList) <= len(oldTable.orderByList) {
+		return nil
+	}
+	for i, expr := range oldTable.orderByList {
+		if newTable.orderByList[i] != expr {
+			return nil
+		}
+	}
+	columns := make(map[string]bool)
+	for _, expr := range newTable.orderByList[len(oldTable.orderByList):] {
+		column, exists := newTable.columnMap[unquoteSignature(expr)]
+		if !exists || column.defaultKind != "" {
+			return nil
+		}
+		if _, exists := oldTable.columnMap[column.name]; exists {
+			return nil
+		}
+		columns[column.name] = true
+	}
+	return columns
+}
+
+// effectivePrimaryKey returns the signature of the primary key, which is the sorting key if not specified.
+func effectivePrimaryKey(table *tableInfo) string {
+	if table.primaryKey.text != "" {
+		return normalizeTuple(table.primaryKey.signature)
+	}
+	return normalizeTuple(table.orderBy.signature)
+}
+
+// normalizeTuple removes the parentheses around the single expression, so that ORDER BY (a) equals ORDER BY a.
+func normalizeTuple(signature string) string {
+	signature = strings.TrimPrefix(signature, "`tuple` ")
+	if strings.HasPrefix(signature, "( ") && strings.HasSuffix(signature, " )") && !strings.Contains(signature, ",") {
+		return strings.TrimSuffix(strings.TrimPrefix(signature, "( "), " )")
+	}
+	return signature
+}
+
+func settingValue(table *tableInfo, key string) string {
+	if value, ok := table.settingMap[key]; ok {
+		return value
+	}
+	return defaultSettings[key]
+}
+
+func elementSignatures(table *tableInfo) []string {
+	var signatures []string
+	for _, column := range table.columnList {
+		signatures = append(signatures, column.name, column.dataType.signature, column.defaultKind, column.defaultExpr.signature, column.codec.signature, column.ttl.signature, column.comment)
+	}
+	for _, list := range [][]*elementInfo{table.indexList, table.constraintList, table.projectionList} {
+		for _, element := range list {
+			signatures = append(signatures, element.name, element.signature)
+		}
+	}
+	return signatures
+}
+
+func dropElements(tableName string, elementType string, oldList []*elementInfo, newList []*elementInfo) []string {
+	newMap := make(map[string]*elementInfo)
+	for _, element := range newList {
+		newMap[element.name] = element
+	}
+	var stmts []string
+	for _, oldElement := range oldList {
+		if newElement, exists := newMap[oldElement.name]; exists && newElement.signature == oldElement.signature {
+			continue
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s DROP %s %s;", tableName, elementType, quoteIdentifier(oldElement.name)))
+	}
+	return stmts
+}
+
+func addedElements(oldList []*elementInfo, newList []*elementInfo) []*elementInfo {
+	oldMap := make(map[string]*elementInfo)
+	for _, element := range oldList {
+		oldMap[element.name] = element
+	}
+	var elements []*elementInfo
+	for _, newElement := range newList {
+		if oldElement, exists := oldMap[newElement.name]; exists && newElement.signature == oldElement.signature {
+			continue
+		}
+		elements = append(elements, newElement)
+	}
+	return elements
+}
+
+func isMergeTree(engineName string) bool {
+	return strings.HasSuffix(engineName, "MergeTree")
+}
+
+// supportsAlter returns true if the engine supports ALTER TABLE for columns, indexes, constraints and projections.
+func supportsAlter(engineName string) bool {
+	if isMergeTree(engineName) {
+		return true
+	}
+	switch engineName {
+	case "Merge", "Distributed", "Memory", "Null", "Buffer":
+		return true
+	}
+	return false
+}
+
+// holdsData returns true if the table of the engine stores data in ClickHouse, so that the data should be copied on rebuilding.
+func holdsData(engineName string) bool {
+	if isMergeTree(engineName) {
+		return true
+	}
+	switch engineName {
+	case "Log", "TinyLog", "StripeLog", "Memory", "Set", "Join":
+		return true
+	}
+	return false
+}
+
+// isStoredColumn returns true if the column can be selected by SELECT with the column name.
+func isStoredColumn(column *columnInfo) bool {
+	return column.defaultKind != "EPHEMERAL"
+}
+
+// isInsertableColumn returns true if the column can be inserted by INSERT with the column name.
+func isInsertableColumn(column *columnInfo) bool {
+	return column.defaultKind != "MATERIALIZED" && column.defaultKind != "ALIAS"
+}
+
+func printCreateTable(tableName string, table *tableInfo) string {
+	var buf strings.Builder
+	_, _ = buf.WriteString(fmt.Sprintf("CREATE TABLE %s\n(\n", tableName))
+	for i, element := range table.elementList {
+		_, _ = buf.WriteString("    ")
+		_, _ = buf.WriteString(element)
+		if i < len(table.elementList)-1 {
+			_, _ = buf.WriteString(",")
+		}
+		_, _ = buf.WriteString("\n")
+	}
+	_, _ = buf.WriteString(")")
+	for _, clause := range table.clauseList {
+		_, _ = buf.WriteString("\n")
+		_, _ = buf.WriteString(clause)
+	}
+	_, _ = buf.WriteString(";")
+	return buf.String()
+}
+
+func (diff *diffNode) deparse() (string, error) {
+	var buf strings.Builder
+	for _, list := range [][]string{
+		diff.dropViewList,
+		diff.dropDictionaryList,
+		diff.dropProjectionList,
+		diff.dropIndexList,
+		diff.dropConstraintList,
+		diff.dropColumnList,
+		diff.dropTableList,
+		diff.rebuildTableList,
+		diff.createTableList,
+		diff.addColumnList,
+		diff.modifyColumnList,
+		diff.alterTableList,
+		diff.addConstraintList,
+		diff.addIndexList,
+		diff.addProjectionList,
+		diff.createDictionaryList,
+		diff.createMaterializedViewList,
+		diff.createViewList,
+	} {
+		for _, stmt := range list {
+			if _, err := buf.WriteString(stmt); err != nil {
+				return "", err
+			}
+			if _, err := buf.WriteString("\n"); err != nil {
+				return "", err
+			}
+		}
+	}
+	return buf.String(), nil
+}
+
+func buildObjectMap(sql string) (objectMap, error) {
+	m := make(objectMap)
+	statements, err := tokenize(sql)
+	if err != nil {
+		return nil, err
+	}
+	for _, stmt := range statements {
+		s := &tokenStream{stmt: stmt}
+		if s.isKeyword("USE") || s.isKeyword("SET") {
+			continue
+		}
+		if !s.acceptKeyword("CREATE") {
+			return nil, s.errorf("unsupported statement")
+		}
+		s.acceptKeyword("OR", "REPLACE")
+		object := &objectInfo{id: len(m)}
+		switch {
+		case s.acceptKeyword("TABLE"):
+			object.kind = objectKindTable
+		case s.acceptKeyword("VIEW"):
+			object.kind = objectKindView
+		case s.acceptKeyword("MATERIALIZED", "VIEW"):
+			object.kind = objectKindMaterializedView
+		case s.acceptKeyword("DICTIONARY"):
+			object.kind = objectKindDictionary
+		case s.acceptKeyword("DATABASE"):
+			// The schema is for a single database, so we ignore the database statements.
+			continue
+		default:
+			return nil, s.errorf("unsupported statement")
+		}
+		s.acceptKeyword("IF", "NOT", "EXISTS")
+		if object.name, err = s.identifier(); err != nil {
+			return nil, err
+		}
+		if s.acceptKeyword("UUID") {
+			s.next()
+		}
+		if s.acceptKeyword("ON", "CLUSTER") {
+			if _, err := s.identifier(); err != nil {
+				return nil, err
+			}
+		}
+		object.definition = s.text(s.pos, len(stmt.tokens))
+		object.signature = s.signature(s.pos, len(stmt.tokens))
+		switch object.kind {
+		case objectKindTable:
+			if object.table, err = parseTable(s); err != nil {
+				return nil, err
+			}
+		case objectKindMaterializedView:
+			if s.acceptKeyword("TO") {
+				if object.toTable, err = s.identifier(); err != nil {
+					return nil, err
+				}
+			}
+		default:
+		}
+		if _, exists := m[object.name]; exists {
+			return nil, errors.Errorf("duplicate object %q", object.name)
+		}
+		m[object.name] = object
+	}
+	return m, nil
+}
+
+func parseTable(s *tokenStream) (*tableInfo, error) {
+	table := &tableInfo{
+		columnMap:    make(map[string]*columnInfo),
+		settingMap:   make(map[string]string),
+		keyColumnMap: make(map[string]bool),
+	}
+	if !s.acceptOperator("(") {
+		return nil, s.errorf("unsupported table definition")
+	}
+	for {
+		start := s.pos
+		if err := parseTableElement(s, table); err != nil {
+			return nil, err
+		}
+		table.elementList = append(table.elementList, s.text(start, s.pos))
+		if !s.acceptOperator(",") {
+			break
+		}
+	}
+	if err := s.expectOperator(")"); err != nil {
+		return nil, err
+	}
+
+	for !s.eof() {
+		start := s.pos
+		switch {
+		case s.acceptKeyword("ENGINE"):
+			s.acceptOperator("=")
+			engineStart := s.pos
+			name, err := s.identifier()
+			if err != nil {
+				return nil, err
+			}
+			table.engineName = name
+			if s.acceptOperator("(") {
+				if _, _, err := s.skipUntil(func() bool { return false }); err != nil {
+					return nil, err
+				}
+				if err := s.expectOperator(")"); err != nil {
+					return nil, err
+				}
+			}
+			table.engine = clause{text: s.text(engineStart, s.pos), signature: strings.TrimSuffix(s.signature(engineStart, s.pos), " ( )")}
+		case s.acceptKeyword("PARTITION", "BY"):
+			table.partitionBy = parseClause(s, isTableClauseStart)
+		case s.acceptKeyword("PRIMARY", "KEY"):
+			table.primaryKey = parseClause(s, isTableClauseStart)
+		case s.acceptKeyword("ORDER", "BY"):
+			exprStart := s.pos
+			table.orderBy = parseClause(s, isTableClauseStart)
+			table.orderByList = tupleElements(s, exprStart, s.pos)
+		case s.acceptKeyword("SAMPLE", "BY"):
+			table.sampleBy = parseClause(s, isTableClauseStart)
+		case s.acceptKeyword("TTL"):
+			table.ttl = parseClause(s, isTableClauseStart)
+		case s.acceptKeyword("SETTINGS"):
+			for {
+				key, err := s.identifier()
+				if err != nil {
+					return nil, err
+				}
+				if err := s.expectOperator("="); err != nil {
+					return nil, err
+				}
+				value := parseClause(s, func(s *tokenStream) bool { return s.isOperator(",") || isTableClauseStart(s) })
+				table.settingList = append(table.settingList, key)
+				table.settingMap[key] = value.text
+				if !s.acceptOperator(",") {
+					break
+				}
+			}
+		case s.acceptKeyword("COMMENT"):
+			t := s.next()
+			if t == nil || t.kind != tokenString {
+				return nil, s.errorf("expect string")
+			}
+			table.comment = t.text
+		default:
+			return nil, s.errorf("unsupported table clause")
+		}
+		table.clauseList = append(table.clauseList, s.text(start, s.pos))
+	}
+
+	for _, key := range []clause{table.partitionBy, table.primaryKey, table.orderBy, table.sampleBy} {
+		for _, column := range table.columnList {
+			if strings.Contains(" "+key.signature+" ", " "+quoteIdentifier(column.name)+" ") {
+				table.keyColumnMap[column.name] = true
+			}
+		}
+	}
+	return table, nil
+}
+
+func parseTableElement(s *tokenStream, table *tableInfo) error {
+	isElementEnd := func(s *tokenStream) bool { return s.isOperator(",") }
+	var list *[]*elementInfo
+	switch {
+	case s.acceptKeyword("INDEX"):
+		list = &table.indexList
+	case s.acceptKeyword("CONSTRAINT"):
+		list = &table.constraintList
+	case s.acceptKeyword("PROJECTION"):
+		list = &table.projectionList
+	case s.acceptKeyword("PRIMARY", "KEY"):
+		table.primaryKey = parseClause(s, isElementEnd)
+		return nil
+	default:
+		return parseColumn(s, table)
+	}
+	name, err := s.identifier()
+	if err != nil {
+		return err
+	}
+	definition := parseClause(s, isElementEnd)
+	*list = append(*list, &elementInfo{name: name, definition: definition.text, signature: definition.signature})
+	return nil
+}
+
+func parseColumn(s *tokenStream, table *tableInfo) error {
+	name, err := s.identifier()
+	if err != nil {
+		return err
+	}
+	column := &columnInfo{name: name}
+	definitionStart := s.pos
+	column.dataType = parseClause(s, func(s *tokenStream) bool {
+		return s.isOperator(",") || s.isKeyword("NULL") || s.isKeyword("NOT") || isColumnClauseStart(s) ||
+			s.isKeyword("DEFAULT") || s.isKeyword("MATERIALIZED") || s.isKeyword("EPHEMERAL") || s.isKeyword("ALIAS")
+	})
+	if s.acceptKeyword("NOT", "NULL") || s.acceptKeyword("NULL") {
+		column.dataType = clause{text: s.text(definitionStart, s.pos), signature: s.signature(definitionStart, s.pos)}
+	}
+	isExpressionEnd := func(s *tokenStream) bool { return s.isOperator(",") || isColumnClauseStart(s) }
+	for !s.eof() && !s.isOperator(",") && !s.isOperator(")") {
+		switch {
+		case s.isKeyword("DEFAULT") || s.isKeyword("MATERIALIZED") || s.isKeyword("EPHEMERAL") || s.isKeyword("ALIAS"):
+			column.defaultKind = strings.ToUpper(s.next().text)
+			column.defaultExpr = parseClause(s, isExpressionEnd)
+		case s.acceptKeyword("COMMENT"):
+			t := s.next()
+			if t == nil || t.kind != tokenString {
+				return s.errorf("expect string")
+			}
+			column.comment = t.text
+		case s.acceptKeyword("CODEC"):
+			column.codec = parseClause(s, isExpressionEnd)
+		case s.acceptKeyword("TTL"):
+			column.ttl = parseClause(s, isExpressionEnd)
+		case s.acceptKeyword("PRIMARY", "KEY"):
+			table.primaryKey = clause{text: quoteIdentifier(name), signature: quoteIdentifier(name)}
+		case s.acceptKeyword("SETTINGS") || s.acceptKeyword("STATISTICS"):
+			// The column level settings and statistics are kept in the column definition only.
+			parseClause(s, isExpressionEnd)
+		default:
+			return s.errorf("unsupported column definition")
+		}
+	}
+	column.definition = s.text(definitionStart, s.pos)
+	table.columnList = append(table.columnList, column)
+	if _, exists := table.columnMap[name]; exists {
+		return errors.Errorf("duplicate column %q", name)
+	}
+	table.columnMap[name] = column
+	return nil
+}
+
+// parseClause consumes the tokens until the stop condition matches on the top level.
+func parseClause(s *tokenStream, stop func(*tokenStream) bool) clause {
+	start, end, err := s.skipUntil(func() bool { return stop(s) })
+	if err != nil {
+		return clause{}
+	}
+	return clause{text: s.text(start, end), signature: s.signature(start, end)}
+}
+
+func isTableClauseStart(s *tokenStream) bool {
+	return s.isKeyword("ENGINE") || s.isKeyword("PARTITION", "BY") || s.isKeyword("PRIMARY", "KEY") || s.isKeyword("ORDER", "BY") ||
+		s.isKeyword("SAMPLE", "BY") || s.isKeyword("TTL") || s.isKeyword("SETTINGS") || s.isKeyword("COMMENT")
+}
+
+func isColumnClauseStart(s *tokenStream) bool {
+	return s.isKeyword("COMMENT") || s.isKeyword("CODEC") || s.isKeyword("TTL") || s.isKeyword("PRIMARY", "KEY") ||
+		s.isKeyword("SETTINGS") || s.isKeyword("STATISTICS")
+}
+
+// tupleElements returns the signatures of the elements if the expression in [start, end) is a tuple, otherwise returns the signature of the expression.
+func tupleElements(s *tokenStream, start, end int) []string {
+	tokens := s.stmt.tokens
+	if start < end && tokens[start].kind == tokenWord && strings.EqualFold(tokens[start].text, "tuple") {
+		start++
+	}
+	if end-start < 2 || tokens[start].text != "(" || tokens[end-1].text != ")" {
+		if start >= end {
+			return nil
+		}
+		return []string{s.signature(start, end)}
+	}
+	var elements []string
+	depth := 0
+	elementStart := start + 1
+	for i := start + 1; i < end-1; i++ {
+		switch tokens[i].text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth < 0 {
+				// The parentheses don't wrap the whole expression, e.g. (a + b) * (c + d).
+				return []string{s.signature(start, end)}
+			}
+		case ",":
+			if depth == 0 && tokens[i].kind == tokenOperator {
+				elements = append(elements, s.signature(elementStart, i))
+				elementStart = i + 1
+			}
+		}
+	}
+	if elementStart < end-1 {
+		elements = append(elements, s.signature(elementStart, end-1))
+	}
+	return elements
+}
+
+// unquoteSignature returns the identifier name if the signature is a single identifier.
+func unquoteSignature(signature string) string {
+	if len(signature) >= 2 && strings.HasPrefix(signature, "`") && strings.HasSuffix(signature, "`") && !strings.Contains(signature, " ") {
+		return strings.ReplaceAll(signature[1:len(signature)-1], "``", "`")
+	}
+	return ""
+}
+
+func sortedObjects(m objectMap) []*objectInfo {
+	var objects []*objectInfo
+	for _, object := range m {
+		objects = append(objects, object)
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].id < objects[j].id
+	})
+	return objects
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
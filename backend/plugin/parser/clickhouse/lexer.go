@@ -0,0 +1,340 @@
+package clickhouse
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenQuotedIdentifier
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+// token is a lexical token of ClickHouse SQL, the comments and whitespaces are dropped.
+type token struct {
+	kind tokenKind
+	// text is the raw text of the token.
+	text string
+	// value is the unquoted text for quoted identifiers, and the raw text for the other tokens.
+	value string
+	start int
+	end   int
+}
+
+// statement is a single SQL statement with its tokens, the trailing semicolon is excluded.
+type statement struct {
+	text   string
+	tokens []token
+}
+
+// multiCharOperators are the operators consisting of more than one character.
+var multiCharOperators = []string{"::", "->", "<=", ">=", "!=", "<>", "==", "||"}
+
+// tokenize splits the ClickHouse SQL text into statements.
+func tokenize(sql string) ([]*statement, error) {
+	var statements []*statement
+	var tokens []token
+	flush := func() {
+		if len(tokens) > 0 {
+			statements = append(statements, &statement{
+				text:   sql[tokens[0].start:tokens[len(tokens)-1].end],
+				tokens: tokens,
+			})
+		}
+		tokens = nil
+	}
+
+	runes := []rune(sql)
+	// offsets maps the rune index to the byte offset.
+	offsets := make([]int, len(runes)+1)
+	offset := 0
+	for i, r := range runes {
+		offsets[i] = offset
+		offset += len(string(r))
+	}
+	offsets[len(runes)] = offset
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-', r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			end := strings.Index(string(runes[i+2:]), "*/")
+			if end < 0 {
+				return nil, errors.Errorf("unterminated comment at position %d", offsets[i])
+			}
+			i += 2 + len([]rune(string(runes[i+2:])[:end])) + 2
+		case r == ';':
+			flush()
+			i++
+		case r == '\'' || r == '`' || r == '"':
+			j := i + 1
+			for ; j < len(runes); j++ {
+				if runes[j] == '\\' {
+					j++
+					continue
+				}
+				if runes[j] == r {
+					// The doubled quote is an escaped quote.
+					if j+1 < len(runes) && runes[j+1] == r {
+						j++
+						continue
+					}
+					break
+				}
+			}
+			if j >= len(runes) {
+				return nil, errors.Errorf("unterminated quoted text at position %d", offsets[i])
+			}
+			text := sql[offsets[i]:offsets[j+1]]
+			t := token{kind: tokenString, text: text, value: text, start: offsets[i], end: offsets[j+1]}
+			if r != '\'' {
+				t.kind = tokenQuotedIdentifier
+				t.value = unquote(text)
+			}
+			tokens = append(tokens, t)
+			i = j + 1
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			j := i + 1
+			for ; j < len(runes); j++ {
+				c := runes[j]
+				if unicode.IsLetter(c) || unicode.IsDigit(c) || c == '.' || c == '_' {
+					continue
+				}
+				// The exponent sign, e.g. 1e-5.
+				if (c == '-' || c == '+') && (runes[j-1] == 'e' || runes[j-1] == 'E') {
+					continue
+				}
+				break
+			}
+			text := sql[offsets[i]:offsets[j]]
+			tokens = append(tokens, token{kind: tokenNumber, text: text, value: text, start: offsets[i], end: offsets[j]})
+			i = j
+		case unicode.IsLetter(r) || r == '_' || r == '$':
+			j := i + 1
+			for ; j < len(runes); j++ {
+				c := runes[j]
+				if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '$' {
+					break
+				}
+			}
+			text := sql[offsets[i]:offsets[j]]
+			tokens = append(tokens, token{kind: tokenWord, text: text, value: text, start: offsets[i], end: offsets[j]})
+			i = j
+		default:
+			length := 1
+			for _, op := range multiCharOperators {
+				if strings.HasPrefix(string(runes[i:min(i+2, len(runes))]), op) {
+					length = 2
+					break
+				}
+			}
+			text := sql[offsets[i]:offsets[i+length]]
+			tokens = append(tokens, token{kind: tokenOperator, text: text, value: text, start: offsets[i], end: offsets[i+length]})
+			i += length
+		}
+	}
+	flush()
+	return statements, nil
+}
+
+// unquote removes the quotes of the quoted identifier and unescapes it.
+func unquote(text string) string {
+	quote := text[0]
+	body := text[1 : len(text)-1]
+	var buf strings.Builder
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c == '\\' && i+1 < len(body) {
+			i++
+			_ = buf.WriteByte(body[i])
+			continue
+		}
+		if c == quote && i+1 < len(body) && body[i+1] == quote {
+			i++
+		}
+		_ = buf.WriteByte(c)
+	}
+	return buf.String()
+}
+
+// tokenStream is the cursor on the tokens of a statement.
+type tokenStream struct {
+	stmt *statement
+	pos  int
+}
+
+func (s *tokenStream) eof() bool {
+	return s.pos >= len(s.stmt.tokens)
+}
+
+func (s *tokenStream) peek(n int) *token {
+	if s.pos+n >= len(s.stmt.tokens) {
+		return nil
+	}
+	return &s.stmt.tokens[s.pos+n]
+}
+
+func (s *tokenStream) next() *token {
+	t := s.peek(0)
+	if t != nil {
+		s.pos++
+	}
+	return t
+}
+
+// isKeyword returns true if the following tokens are the given keywords.
+func (s *tokenStream) isKeyword(keywords ...string) bool {
+	for i, keyword := range keywords {
+		t := s.peek(i)
+		if t == nil || t.kind != tokenWord || !strings.EqualFold(t.text, keyword) {
+			return false
+		}
+	}
+	return true
+}
+
+// acceptKeyword consumes the given keywords if they are the following tokens.
+func (s *tokenStream) acceptKeyword(keywords ...string) bool {
+	if !s.isKeyword(keywords...) {
+		return false
+	}
+	s.pos += len(keywords)
+	return true
+}
+
+func (s *tokenStream) isOperator(op string) bool {
+	t := s.peek(0)
+	return t != nil && t.kind == tokenOperator && t.text == op
+}
+
+func (s *tokenStream) acceptOperator(op string) bool {
+	if !s.isOperator(op) {
+		return false
+	}
+	s.pos++
+	return true
+}
+
+func (s *tokenStream) expectOperator(op string) error {
+	if !s.acceptOperator(op) {
+		return s.errorf("expect %q", op)
+	}
+	return nil
+}
+
+// identifier consumes an identifier, and returns the last part of the qualified name.
+func (s *tokenStream) identifier() (string, error) {
+	t := s.next()
+	if t == nil || (t.kind != tokenWord && t.kind != tokenQuotedIdentifier) {
+		s.pos--
+		return "", s.errorf("expect identifier")
+	}
+	name := t.value
+	for s.isOperator(".") {
+		s.pos++
+		t = s.next()
+		if t == nil || (t.kind != tokenWord && t.kind != tokenQuotedIdentifier) {
+			return "", s.errorf("expect identifier")
+		}
+		name = t.value
+	}
+	return name, nil
+}
+
+// skipUntil skips the tokens until the stop condition matches on the top level, and returns the range of the skipped tokens.
+func (s *tokenStream) skipUntil(stop func() bool) (int, int, error) {
+	start := s.pos
+	depth := 0
+	for !s.eof() {
+		if depth == 0 && stop() {
+			break
+		}
+		t := s.next()
+		if t.kind != tokenOperator {
+			continue
+		}
+		switch t.text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth < 0 {
+				s.pos--
+				return start, s.pos, nil
+			}
+		}
+	}
+	if depth > 0 {
+		return 0, 0, s.errorf("unbalanced parentheses")
+	}
+	return start, s.pos, nil
+}
+
+// text returns the raw text of the tokens in [start, end).
+func (s *tokenStream) text(start, end int) string {
+	if start >= end {
+		return ""
+	}
+	tokens := s.stmt.tokens
+	base := tokens[0].start
+	return s.stmt.text[tokens[start].start-base : tokens[end-1].end-base]
+}
+
+// signature returns the normalized text of the tokens in [start, end), so that the whitespaces, comments,
+// identifier quotes and keyword cases don't affect the comparison.
+func (s *tokenStream) signature(start, end int) string {
+	var parts []string
+	for _, t := range s.stmt.tokens[start:end] {
+		switch {
+		case t.kind == tokenQuotedIdentifier:
+			parts = append(parts, quoteIdentifier(t.value))
+		case t.kind == tokenWord && keywords[strings.ToUpper(t.text)]:
+			parts = append(parts, strings.ToUpper(t.text))
+		case t.kind == tokenWord:
+			parts = append(parts, quoteIdentifier(t.value))
+		default:
+			parts = append(parts, t.text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func (s *tokenStream) errorf(format string, args ...any) error {
+	near := "end of statement"
+	if t := s.peek(0); t != nil {
+		near = t.text
+	}
+	return errors.Errorf("%s near %q in statement %q", errors.Errorf(format, args...).Error(), near, s.stmt.text)
+}
+
+// keywords are the ClickHouse keywords that are case-insensitive.
+var keywords = map[string]bool{
+	"ALL": true, "AND": true, "ANTI": true, "ANY": true, "ARRAY": true, "AS": true, "ASC": true, "ASOF": true,
+	"BETWEEN": true, "BY": true, "CASE": true, "CAST": true, "CROSS": true, "DAY": true, "DELETE": true,
+	"DESC": true, "DISK": true, "DISTINCT": true, "ELSE": true, "END": true, "EXCEPT": true, "FINAL": true,
+	"FROM": true, "FULL": true, "GLOBAL": true, "GROUP": true, "HAVING": true, "HOUR": true, "ILIKE": true,
+	"IN": true, "INNER": true, "INTERSECT": true, "INTERVAL": true, "IS": true, "JOIN": true, "LEFT": true,
+	"LIKE": true, "LIMIT": true, "MINUTE": true, "MONTH": true, "NOT": true, "NULL": true, "NULLS": true,
+	"OFFSET": true, "ON": true, "OR": true, "ORDER": true, "OUTER": true, "PREWHERE": true, "QUARTER": true,
+	"RECOMPRESS": true, "RIGHT": true, "SECOND": true, "SELECT": true, "SEMI": true, "SET": true,
+	"SETTINGS": true, "THEN": true, "TO": true, "UNION": true, "USING": true, "VOLUME": true, "WEEK": true,
+	"WHEN": true, "WHERE": true, "WITH": true, "YEAR": true,
+}
+
+// quoteIdentifier quotes the identifier with backticks.
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,142 @@
+package pg
+
+import (
+	"sort"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/postgresql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterExtractChangedResourcesFunc(storepb.Engine_POSTGRES, extractChangedResources)
+	base.RegisterExtractChangedResourcesFunc(storepb.Engine_REDSHIFT, extractChangedResources)
+	base.RegisterExtractChangedResourcesFunc(storepb.Engine_RISINGWAVE, extractChangedResources)
+}
+
+func extractChangedResources(currentDatabase string, currentSchema string, statement string) ([]base.SchemaResource, error) {
+	tree, err := ParsePostgreSQL(statement)
+	if err != nil {
+		return nil, err
+	}
+	if currentSchema == "" {
+		currentSchema = "public"
+	}
+
+	l := &resourceChangedListener{
+		currentDatabase: currentDatabase,
+		currentSchema:   currentSchema,
+		resourceMap:     make(map[string]base.SchemaResource),
+	}
+
+	var result []base.SchemaResource
+	antlr.ParseTreeWalkerDefault.Walk(l, tree.Tree)
+	for _, resource := range l.resourceMap {
+		result = append(result, resource)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result, nil
+}
+
+type resourceChangedListener struct {
+	*parser.BasePostgreSQLParserListener
+
+	currentDatabase string
+	currentSchema   string
+	resourceMap     map[string]base.SchemaResource
+}
+
+func (l *resourceChangedListener) addTable(list []string) {
+	resource := base.SchemaResource{
+		Database: l.currentDatabase,
+		Schema:   l.currentSchema,
+	}
+	switch len(list) {
+	case 3:
+		// PostgreSQL doesn't support the cross database reference, the database name must be the current one.
+		resource.Schema, resource.Table = list[1], list[2]
+	case 2:
+		resource.Schema, resource.Table = list[0], list[1]
+	case 1:
+		resource.Table = list[0]
+	default:
+		return
+	}
+	l.resourceMap[resource.String()] = resource
+}
+
+func (l *resourceChangedListener) addRelationExpr(ctx parser.IRelation_exprContext) {
+	if ctx == nil {
+		return
+	}
+	l.addTable(NormalizePostgreSQLQualifiedName(ctx.Qualified_name()))
+}
+
+// EnterCreatestmt is called when production createstmt is entered.
+func (l *resourceChangedListener) EnterCreatestmt(ctx *parser.CreatestmtContext) {
+	// The first qualified name is the table name, the others are the parent tables of the partition.
+	l.addTable(NormalizePostgreSQLQualifiedName(ctx.Qualified_name(0)))
+}
+
+// EnterCreateasstmt is called when production createasstmt is entered.
+func (l *resourceChangedListener) EnterCreateasstmt(ctx *parser.CreateasstmtContext) {
+	l.addTable(NormalizePostgreSQLQualifiedName(ctx.Create_as_target().Qualified_name()))
+}
+
+// EnterAltertablestmt is called when production altertablestmt is entered.
+func (l *resourceChangedListener) EnterAltertablestmt(ctx *parser.AltertablestmtContext) {
+	if ctx.TABLE() == nil {
+		return
+	}
+	l.addRelationExpr(ctx.Relation_expr())
+}
+
+// EnterRenamestmt is called when production renamestmt is entered.
+func (l *resourceChangedListener) EnterRenamestmt(ctx *parser.RenamestmtContext) {
+	if ctx.TABLE() == nil {
+		return
+	}
+	l.addRelationExpr(ctx.Relation_expr())
+}
+
+// EnterDropstmt is called when production dropstmt is entered.
+func (l *resourceChangedListener) EnterDropstmt(ctx *parser.DropstmtContext) {
+	if ctx.Object_type_any_name() == nil || ctx.Object_type_any_name().TABLE() == nil || ctx.Any_name_list() == nil {
+		return
+	}
+	for _, name := range ctx.Any_name_list().AllAny_name() {
+		l.addTable(NormalizePostgreSQLAnyName(name))
+	}
+}
+
+// EnterTruncatestmt is called when production truncatestmt is entered.
+func (l *resourceChangedListener) EnterTruncatestmt(ctx *parser.TruncatestmtContext) {
+	for _, relation := range ctx.Relation_expr_list().AllRelation_expr() {
+		l.addRelationExpr(relation)
+	}
+}
+
+// EnterIndexstmt is called when production indexstmt is entered.
+func (l *resourceChangedListener) EnterIndexstmt(ctx *parser.IndexstmtContext) {
+	l.addRelationExpr(ctx.Relation_expr())
+}
+
+// EnterInsertstmt is called when production insertstmt is entered.
+func (l *resourceChangedListener) EnterInsertstmt(ctx *parser.InsertstmtContext) {
+	l.addTable(NormalizePostgreSQLQualifiedName(ctx.Insert_target().Qualified_name()))
+}
+
+// EnterUpdatestmt is called when production updatestmt is entered.
+func (l *resourceChangedListener) EnterUpdatestmt(ctx *parser.UpdatestmtContext) {
+	l.addRelationExpr(ctx.Relation_expr_opt_alias().Relation_expr())
+}
+
+// EnterDeletestmt is called when production deletestmt is entered.
+func (l *resourceChangedListener) EnterDeletestmt(ctx *parser.DeletestmtContext) {
+	l.addRelationExpr(ctx.Relation_expr_opt_alias().Relation_expr())
+}
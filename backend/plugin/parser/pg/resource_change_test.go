@@ -0,0 +1,48 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+func TestExtractChangedResources(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      []base.SchemaResource
+	}{
+		{
+			statement: `CREATE TABLE t1 (id int); CREATE TABLE s1."T2" AS SELECT * FROM t3; ALTER TABLE t1 ADD COLUMN name text; ALTER TABLE s1."T2" RENAME TO t4;`,
+			want: []base.SchemaResource{
+				{Database: "db", Schema: "public", Table: "t1"},
+				{Database: "db", Schema: "s1", Table: "T2"},
+			},
+		},
+		{
+			statement: `DROP TABLE t1, s1.t2; DROP VIEW v1; TRUNCATE t3; CREATE INDEX idx ON t4 (id);`,
+			want: []base.SchemaResource{
+				{Database: "db", Schema: "public", Table: "t1"},
+				{Database: "db", Schema: "public", Table: "t3"},
+				{Database: "db", Schema: "public", Table: "t4"},
+				{Database: "db", Schema: "s1", Table: "t2"},
+			},
+		},
+		{
+			statement: `INSERT INTO t1 SELECT * FROM t2; UPDATE s1.t3 SET a = 1 FROM t4; DELETE FROM db.s1.t5 USING t6; SELECT * FROM t7;`,
+			want: []base.SchemaResource{
+				{Database: "db", Schema: "public", Table: "t1"},
+				{Database: "db", Schema: "s1", Table: "t3"},
+				{Database: "db", Schema: "s1", Table: "t5"},
+			},
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := extractChangedResources("db", "", test.statement)
+		a.NoError(err, test.statement)
+		a.Equal(test.want, got, test.statement)
+	}
+}
@@ -0,0 +1,51 @@
+package plsql
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/plsql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+var inListRegexp = regexp.MustCompile(`\b(in|values)(?:,? ?\(\?(?:, ?\?)*\))+`)
+
+// GetFingerprint gets the PL/SQL query fingerprint.
+// The literals and bind variables are replaced with question marks (?), the comments are removed, the whitespaces are collapsed
+// and the keywords and unquoted identifiers are lowercased, so the queries differing only in the literals share the fingerprint.
+func GetFingerprint(query string) (string, error) {
+	lexer := parser.NewPlSqlLexer(antlr.NewInputStream(query))
+	lexerErrorListener := &base.ParseErrorListener{}
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(lexerErrorListener)
+
+	var buf strings.Builder
+	space := false
+	for _, token := range lexer.GetAllTokens() {
+		switch token.GetTokenType() {
+		case parser.PlSqlLexerSINGLE_LINE_COMMENT, parser.PlSqlLexerMULTI_LINE_COMMENT, parser.PlSqlLexerREMARK_COMMENT, parser.PlSqlLexerSPACES:
+			space = buf.Len() > 0
+			continue
+		}
+		if space {
+			_, _ = buf.WriteString(" ")
+			space = false
+		}
+		switch token.GetTokenType() {
+		case parser.PlSqlLexerCHAR_STRING, parser.PlSqlLexerNATIONAL_CHAR_STRING_LIT, parser.PlSqlLexerBIT_STRING_LIT, parser.PlSqlLexerHEX_STRING_LIT,
+			parser.PlSqlLexerUNSIGNED_INTEGER, parser.PlSqlLexerAPPROXIMATE_NUM_LIT, parser.PlSqlLexerBINDVAR, parser.PlSqlLexerNULL_:
+			_, _ = buf.WriteString("?")
+		case parser.PlSqlLexerDELIMITED_ID:
+			_, _ = buf.WriteString(token.GetText())
+		default:
+			_, _ = buf.WriteString(strings.ToLower(token.GetText()))
+		}
+	}
+	if lexerErrorListener.Err != nil {
+		return "", lexerErrorListener.Err
+	}
+	fingerprint := strings.TrimRight(buf.String(), " ;")
+	return inListRegexp.ReplaceAllString(fingerprint, "$1(?+)"), nil
+}
@@ -0,0 +1,34 @@
+package plsql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPLSQLFingerprint(t *testing.T) {
+	tests := []struct {
+		stmt string
+		want string
+	}{
+		{
+			stmt: "-- comment\nSELECT * FROM \"Emp\" WHERE ename = 'O''Brien' AND sal > 1.5e3",
+			want: `select * from "Emp" where ename = ? and sal > ?`,
+		},
+		{
+			stmt: "select * from emp where empno in (:1, :b2, 3) and comm is null /* comment */",
+			want: "select * from emp where empno in(?+) and comm is ?",
+		},
+		{
+			stmt: "SELECT * FROM emp FETCH FIRST 10 ROWS ONLY",
+			want: "select * from emp fetch first ? rows only",
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := GetFingerprint(test.stmt)
+		a.NoError(err)
+		a.Equal(test.want, got, test.stmt)
+	}
+}
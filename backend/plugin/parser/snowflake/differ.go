@@ -0,0 +1,896 @@
+package snowflake
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/pkg/errors"
+
+	parser "github.com/bytebase/snowsql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const defaultSchemaName = "PUBLIC"
+
+func init() {
+	base.RegisterSchemaDiffFunc(storepb.Engine_SNOWFLAKE, SchemaDiff)
+}
+
+// diffNode defines different modification types as the safe change order.
+// The safe change order means we can change them with no dependency conflicts as this order.
+type diffNode struct {
+	// Drop nodes
+	dropTaskList               []string
+	dropStreamList             []string
+	dropViewList               []string
+	dropForeignKeyList         []string
+	dropConstraintExceptFkList []string
+	dropColumnList             []string
+	dropTableList              []string
+	dropSequenceList           []string
+	dropStageList              []string
+	dropSchemaList             []string
+
+	// Create nodes
+	createSchemaList          []string
+	createSequenceList        []string
+	alterSequenceList         []string
+	createStageList           []string
+	alterStageList            []string
+	createTableList           []string
+	addColumnList             []string
+	alterColumnList           []string
+	alterTableList            []string
+	addConstraintExceptFkList []string
+	addForeignKeyList         []string
+	createOrReplaceViewList   []string
+	createOrReplaceStreamList []string
+	createOrReplaceTaskList   []string
+}
+
+type objectKind int
+
+const (
+	objectKindTable objectKind = iota
+	objectKindView
+	objectKindSequence
+	objectKindStage
+	objectKindStream
+	objectKindTask
+)
+
+func (k objectKind) String() string {
+	switch k {
+	case objectKindTable:
+		return "TABLE"
+	case objectKindView:
+		return "VIEW"
+	case objectKindSequence:
+		return "SEQUENCE"
+	case objectKindStage:
+		return "STAGE"
+	case objectKindStream:
+		return "STREAM"
+	case objectKindTask:
+		return "TASK"
+	}
+	return ""
+}
+
+type schemaMap map[string]*schemaInfo
+type objectMap map[string]*objectInfo
+type columnMap map[string]*columnInfo
+type constraintMap map[string]*constraintInfo
+
+type schemaInfo struct {
+	id   int
+	name string
+	// createSchema is empty for the schemas that are only referenced by other objects, e.g. PUBLIC.
+	createSchema string
+	// objectMaps stores the objects by kind, because the objects of different kinds can share the same name.
+	objectMaps map[objectKind]objectMap
+}
+
+// objectInfo is the object in a schema, such as table, view, sequence, stage, stream and task.
+type objectInfo struct {
+	id         int
+	kind       objectKind
+	schemaName string
+	name       string
+	// body is the object definition following CREATE or CREATE OR REPLACE, with the fully qualified object name.
+	body string
+	// signature is the normalized text of the object definition, so that the whitespaces and comments don't affect the comparison.
+	signature string
+
+	// properties are the properties that can be changed by ALTER ... SET and ALTER ... UNSET.
+	properties map[string]string
+
+	// The following fields are only used for tables.
+	createTableAsSelect bool
+	tableType           string
+	clusterBy           string
+	columnList          []*columnInfo
+	columnMap           columnMap
+	constraintMap       constraintMap
+
+	// The following fields are only used for internal stages.
+	internalStage       bool
+	stageFixedSignature string
+	stageSetClause      string
+}
+
+type columnInfo struct {
+	name       string
+	definition string
+	dataType   string
+	notNull    bool
+	// defaultValue is the text of DEFAULT expression, AUTOINCREMENT or IDENTITY.
+	defaultValue string
+	comment      string
+	// otherSignature is the normalized text of the column properties that cannot be altered, such as COLLATE.
+	otherSignature string
+}
+
+type constraintInfo struct {
+	id         int
+	name       string
+	foreignKey bool
+	primaryKey bool
+	unique     bool
+	columns    string
+	definition string
+	signature  string
+}
+
+func newSchemaInfo(id int, name string) *schemaInfo {
+	return &schemaInfo{
+		id:         id,
+		name:       name,
+		objectMaps: make(map[objectKind]objectMap),
+	}
+}
+
+func (m schemaMap) getOrCreateSchema(name string) *schemaInfo {
+	if schema, ok := m[name]; ok {
+		return schema
+	}
+	schema := newSchemaInfo(len(m), name)
+	m[name] = schema
+	return schema
+}
+
+func (s *schemaInfo) getObjectMap(kind objectKind) objectMap {
+	if m, ok := s.objectMaps[kind]; ok {
+		return m
+	}
+	m := make(objectMap)
+	s.objectMaps[kind] = m
+	return m
+}
+
+// SchemaDiff computes the migration DDL from the old schema to the new schema for Snowflake.
+func SchemaDiff(oldStmt, newStmt string, _ bool) (string, error) {
+	oldSchemaMap, err := buildSchemaMap(oldStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema info for old statement")
+	}
+	newSchemaMap, err := buildSchemaMap(newStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema info for new statement")
+	}
+
+	diff := &diffNode{}
+	for _, newSchema := range sortedSchemas(newSchemaMap) {
+		oldSchema, exists := oldSchemaMap[newSchema.name]
+		if !exists {
+			oldSchema = newSchemaInfo(-1, newSchema.name)
+		}
+		if newSchema.createSchema != "" && oldSchema.createSchema == "" && newSchema.name != defaultSchemaName {
+			diff.createSchemaList = append(diff.createSchemaList, fmt.Sprintf("CREATE %s;", newSchema.createSchema))
+		}
+	}
+
+	for _, newObject := range sortedObjects(newSchemaMap) {
+		var oldObject *objectInfo
+		if oldSchema, exists := oldSchemaMap[newObject.schemaName]; exists {
+			oldObjectMap := oldSchema.getObjectMap(newObject.kind)
+			oldObject = oldObjectMap[newObject.name]
+			delete(oldObjectMap, newObject.name)
+		}
+		if oldObject == nil {
+			diff.createObject(newObject)
+			continue
+		}
+		if oldObject.signature == newObject.signature {
+			continue
+		}
+		if err := diff.modifyObject(oldObject, newObject); err != nil {
+			return "", err
+		}
+	}
+
+	// Drop the remaining objects in the reverse order of the old schema.
+	oldObjects := sortedObjects(oldSchemaMap)
+	for i := len(oldObjects) - 1; i >= 0; i-- {
+		diff.dropObject(oldObjects[i])
+	}
+	for _, oldSchema := range sortedSchemas(oldSchemaMap) {
+		if _, exists := newSchemaMap[oldSchema.name]; exists {
+			continue
+		}
+		if oldSchema.createSchema != "" && oldSchema.name != defaultSchemaName {
+			diff.dropSchemaList = append(diff.dropSchemaList, fmt.Sprintf("DROP SCHEMA %s;", quoteIdentifier(oldSchema.name)))
+		}
+	}
+
+	return diff.deparse()
+}
+
+func (diff *diffNode) createObject(object *objectInfo) {
+	stmt := fmt.Sprintf("CREATE %s;", object.body)
+	switch object.kind {
+	case objectKindTable:
+		diff.createTableList = append(diff.createTableList, stmt)
+	case objectKindView:
+		diff.createOrReplaceViewList = append(diff.createOrReplaceViewList, stmt)
+	case objectKindSequence:
+		diff.createSequenceList = append(diff.createSequenceList, stmt)
+	case objectKindStage:
+		diff.createStageList = append(diff.createStageList, stmt)
+	case objectKindStream:
+		diff.createOrReplaceStreamList = append(diff.createOrReplaceStreamList, stmt)
+	case objectKindTask:
+		diff.createOrReplaceTaskList = append(diff.createOrReplaceTaskList, stmt)
+	}
+}
+
+func (diff *diffNode) dropObject(object *objectInfo) {
+	stmt := fmt.Sprintf("DROP %s %s;", object.kind.String(), qualifiedName(object.schemaName, object.name))
+	switch object.kind {
+	case objectKindTable:
+		diff.dropTableList = append(diff.dropTableList, stmt)
+	case objectKindView:
+		diff.dropViewList = append(diff.dropViewList, stmt)
+	case objectKindSequence:
+		diff.dropSequenceList = append(diff.dropSequenceList, stmt)
+	case objectKindStage:
+		diff.dropStageList = append(diff.dropStageList, stmt)
+	case objectKindStream:
+		diff.dropStreamList = append(diff.dropStreamList, stmt)
+	case objectKindTask:
+		diff.dropTaskList = append(diff.dropTaskList, stmt)
+	}
+}
+
+func (diff *diffNode) modifyObject(oldObject *objectInfo, newObject *objectInfo) error {
+	name := qualifiedName(newObject.schemaName, newObject.name)
+	switch newObject.kind {
+	case objectKindTable:
+		return diff.modifyTable(oldObject, newObject)
+	case objectKindView:
+		diff.createOrReplaceViewList = append(diff.createOrReplaceViewList, fmt.Sprintf("CREATE OR REPLACE %s;", newObject.body))
+	case objectKindSequence:
+		// The START value of a sequence cannot be changed after the sequence is created.
+		diff.alterSequenceList = append(diff.alterSequenceList, alterProperties("SEQUENCE", name, oldObject.properties, newObject.properties)...)
+	case objectKindStage:
+		if !oldObject.internalStage || !newObject.internalStage {
+			// The external stages don't store any files, so it's safe to replace them.
+			diff.createStageList = append(diff.createStageList, fmt.Sprintf("CREATE OR REPLACE %s;", newObject.body))
+			return nil
+		}
+		// Replacing an internal stage drops all the files in it, so we alter it instead.
+		if oldObject.stageFixedSignature != newObject.stageFixedSignature {
+			return errors.Errorf("changing the encryption, directory table or tags of internal stage %s is not supported", name)
+		}
+		if newObject.stageSetClause != "" {
+			diff.alterStageList = append(diff.alterStageList, fmt.Sprintf("ALTER STAGE %s SET %s;", name, newObject.stageSetClause))
+		}
+	case objectKindStream:
+		// The streams cannot be altered except the comment, so we replace them.
+		diff.createOrReplaceStreamList = append(diff.createOrReplaceStreamList, fmt.Sprintf("CREATE OR REPLACE %s;", newObject.body))
+	case objectKindTask:
+		diff.createOrReplaceTaskList = append(diff.createOrReplaceTaskList, fmt.Sprintf("CREATE OR REPLACE %s;", newObject.body))
+	}
+	return nil
+}
+
+func (diff *diffNode) modifyTable(oldTable *objectInfo, newTable *objectInfo) error {
+	tableName := qualifiedName(newTable.schemaName, newTable.name)
+	if oldTable.createTableAsSelect || newTable.createTableAsSelect {
+		return errors.Errorf("changing table %s created by CREATE TABLE ... AS SELECT is not supported", tableName)
+	}
+	if oldTable.tableType != newTable.tableType {
+		return errors.Errorf("changing the table type of table %s from %q to %q is not supported", tableName, oldTable.tableType, newTable.tableType)
+	}
+
+	// Columns.
+	var droppedColumns []string
+	for _, oldColumn := range oldTable.columnList {
+		if _, exists := newTable.columnMap[oldColumn.name]; !exists {
+			droppedColumns = append(droppedColumns, quoteIdentifier(oldColumn.name))
+		}
+	}
+	for _, newColumn := range newTable.columnList {
+		oldColumn, exists := oldTable.columnMap[newColumn.name]
+		if !exists {
+			diff.addColumnList = append(diff.addColumnList, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", tableName, newColumn.definition))
+			continue
+		}
+		if err := diff.modifyColumn(tableName, oldColumn, newColumn); err != nil {
+			return err
+		}
+	}
+	if len(droppedColumns) > 0 {
+		diff.dropColumnList = append(diff.dropColumnList, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", tableName, strings.Join(droppedColumns, ", ")))
+	}
+
+	// Constraints.
+	for _, newConstraint := range sortedConstraints(newTable.constraintMap) {
+		key := constraintKey(newConstraint)
+		if oldConstraint, exists := oldTable.constraintMap[key]; exists {
+			delete(oldTable.constraintMap, key)
+			if oldConstraint.signature == newConstraint.signature {
+				continue
+			}
+			diff.appendDropConstraint(tableName, oldConstraint)
+		}
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD %s;", tableName, newConstraint.definition)
+		if newConstraint.foreignKey {
+			diff.addForeignKeyList = append(diff.addForeignKeyList, stmt)
+		} else {
+			diff.addConstraintExceptFkList = append(diff.addConstraintExceptFkList, stmt)
+		}
+	}
+	for _, oldConstraint := range sortedConstraints(oldTable.constraintMap) {
+		diff.appendDropConstraint(tableName, oldConstraint)
+	}
+
+	// Table properties.
+	if oldTable.clusterBy != newTable.clusterBy {
+		if newTable.clusterBy == "" {
+			diff.alterTableList = append(diff.alterTableList, fmt.Sprintf("ALTER TABLE %s DROP CLUSTERING KEY;", tableName))
+		} else {
+			diff.alterTableList = append(diff.alterTableList, fmt.Sprintf("ALTER TABLE %s %s;", tableName, newTable.clusterBy))
+		}
+	}
+	diff.alterTableList = append(diff.alterTableList, alterProperties("TABLE", tableName, oldTable.properties, newTable.properties)...)
+	return nil
+}
+
+func (diff *diffNode) modifyColumn(tableName string, oldColumn *columnInfo, newColumn *columnInfo) error {
+	columnName := quoteIdentifier(newColumn.name)
+	if oldColumn.otherSignature != newColumn.otherSignature {
+		return errors.Errorf("changing the collation, inline constraints, masking policy or tags of column %s.%s is not supported", tableName, columnName)
+	}
+	if oldColumn.dataType != newColumn.dataType {
+		diff.alterColumnList = append(diff.alterColumnList, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DATA TYPE %s;", tableName, columnName, newColumn.dataType))
+	}
+	if oldColumn.notNull != newColumn.notNull {
+		if newColumn.notNull {
+			diff.alterColumnList = append(diff.alterColumnList, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", tableName, columnName))
+		} else {
+			diff.alterColumnList = append(diff.alterColumnList, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;", tableName, columnName))
+		}
+	}
+	if oldColumn.defaultValue != newColumn.defaultValue {
+		upperDefault := strings.ToUpper(newColumn.defaultValue)
+		switch {
+		case newColumn.defaultValue == "":
+			diff.alterColumnList = append(diff.alterColumnList, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;", tableName, columnName))
+		case strings.HasPrefix(upperDefault, "DEFAULT ") && strings.HasSuffix(upperDefault, ".NEXTVAL"):
+			diff.alterColumnList = append(diff.alterColumnList, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET %s;", tableName, columnName, newColumn.defaultValue))
+		default:
+			// Snowflake only supports changing the default value to a sequence.
+			return errors.Errorf("changing the default value of column %s.%s to %q is not supported", tableName, columnName, newColumn.defaultValue)
+		}
+	}
+	if oldColumn.comment != newColumn.comment {
+		if newColumn.comment == "" {
+			diff.alterColumnList = append(diff.alterColumnList, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s UNSET COMMENT;", tableName, columnName))
+		} else {
+			diff.alterColumnList = append(diff.alterColumnList, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s COMMENT %s;", tableName, columnName, newColumn.comment))
+		}
+	}
+	return nil
+}
+
+func (diff *diffNode) appendDropConstraint(tableName string, constraint *constraintInfo) {
+	var stmt string
+	switch {
+	case constraint.name != "":
+		stmt = fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", tableName, quoteIdentifier(constraint.name))
+	case constraint.primaryKey:
+		stmt = fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY;", tableName)
+	case constraint.unique:
+		stmt = fmt.Sprintf("ALTER TABLE %s DROP UNIQUE %s;", tableName, constraint.columns)
+	default:
+		stmt = fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s;", tableName, constraint.columns)
+	}
+	if constraint.foreignKey {
+		diff.dropForeignKeyList = append(diff.dropForeignKeyList, stmt)
+	} else {
+		diff.dropConstraintExceptFkList = append(diff.dropConstraintExceptFkList, stmt)
+	}
+}
+
+// alterProperties returns the ALTER ... SET and ALTER ... UNSET statements for the changed properties.
+func alterProperties(objectType string, name string, oldProperties map[string]string, newProperties map[string]string) []string {
+	var set, unset []string
+	for _, key := range sortedKeys(newProperties) {
+		if oldProperties[key] != newProperties[key] {
+			set = append(set, fmt.Sprintf("%s = %s", key, newProperties[key]))
+		}
+	}
+	for _, key := range sortedKeys(oldProperties) {
+		if _, exists := newProperties[key]; !exists {
+			unset = append(unset, key)
+		}
+	}
+	var stmts []string
+	if len(set) > 0 {
+		stmts = append(stmts, fmt.Sprintf("ALTER %s %s SET %s;", objectType, name, strings.Join(set, " ")))
+	}
+	if len(unset) > 0 {
+		stmts = append(stmts, fmt.Sprintf("ALTER %s %s UNSET %s;", objectType, name, strings.Join(unset, ", ")))
+	}
+	return stmts
+}
+
+func (diff *diffNode) deparse() (string, error) {
+	var buf strings.Builder
+	for _, list := range [][]string{
+		diff.dropTaskList,
+		diff.dropStreamList,
+		diff.dropViewList,
+		diff.dropForeignKeyList,
+		diff.dropConstraintExceptFkList,
+		diff.dropColumnList,
+		diff.dropTableList,
+		diff.dropSequenceList,
+		diff.dropStageList,
+		diff.dropSchemaList,
+		diff.createSchemaList,
+		diff.createSequenceList,
+		diff.alterSequenceList,
+		diff.createStageList,
+		diff.alterStageList,
+		diff.createTableList,
+		diff.addColumnList,
+		diff.alterColumnList,
+		diff.alterTableList,
+		diff.addConstraintExceptFkList,
+		diff.addForeignKeyList,
+		diff.createOrReplaceViewList,
+		diff.createOrReplaceStreamList,
+		diff.createOrReplaceTaskList,
+	} {
+		for _, stmt := range list {
+			if _, err := buf.WriteString(stmt); err != nil {
+				return "", err
+			}
+			if _, err := buf.WriteString("\n"); err != nil {
+				return "", err
+			}
+		}
+	}
+	return buf.String(), nil
+}
+
+func buildSchemaMap(statement string) (schemaMap, error) {
+	m := make(schemaMap)
+	if strings.TrimSpace(statement) == "" {
+		return m, nil
+	}
+	result, err := ParseSnowSQL(statement)
+	if err != nil {
+		return nil, err
+	}
+	listener := &buildSchemaMapListener{
+		schemaMap:     m,
+		stream:        result.Tokens,
+		currentSchema: defaultSchemaName,
+	}
+	antlr.ParseTreeWalkerDefault.Walk(listener, result.Tree)
+	if listener.err != nil {
+		return nil, listener.err
+	}
+	return m, nil
+}
+
+type buildSchemaMapListener struct {
+	*parser.BaseSnowflakeParserListener
+
+	schemaMap schemaMap
+	stream    antlr.TokenStream
+	// currentSchema is the schema of the unqualified objects.
+	// Like Snowflake, CREATE SCHEMA changes the current schema.
+	currentSchema string
+	objectCount   int
+	err           error
+}
+
+// EnterCreate_schema is called when production create_schema is entered.
+func (l *buildSchemaMapListener) EnterCreate_schema(ctx *parser.Create_schemaContext) {
+	if l.err != nil {
+		return
+	}
+	ids := ctx.Schema_name().AllId_()
+	schemaName := NormalizeSnowSQLObjectNamePart(ids[len(ids)-1])
+	schema := l.schemaMap.getOrCreateSchema(schemaName)
+	schema.createSchema, _ = l.body(ctx, ctx.Or_replace(), ctx.If_not_exists(), ctx.Schema_name(), quoteIdentifier(schemaName))
+	l.currentSchema = schemaName
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *buildSchemaMapListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	if l.err != nil {
+		return
+	}
+	table := l.addObject(objectKindTable, ctx, ctx.Or_replace(), ctx.If_not_exists(), ctx.Object_name())
+	if table == nil {
+		return
+	}
+	if ctx.Table_type() != nil {
+		table.tableType = strings.ToUpper(l.signature(ctx.Table_type()))
+	}
+	if ctx.Cluster_by() != nil {
+		table.clusterBy = l.text(ctx.Cluster_by())
+	}
+	l.addProperty(table, ctx.DATA_RETENTION_TIME_IN_DAYS())
+	l.addProperty(table, ctx.MAX_DATA_EXTENSION_TIME_IN_DAYS())
+	if ctx.Change_tracking() != nil {
+		l.addProperty(table, ctx.Change_tracking().CHANGE_TRACKING())
+	}
+	if ctx.Comment_clause() != nil {
+		l.addProperty(table, ctx.Comment_clause().COMMENT())
+	}
+	l.addColumnDeclItems(table, ctx.Column_decl_item_list())
+}
+
+// EnterCreate_table_as_select is called when production create_table_as_select is entered.
+func (l *buildSchemaMapListener) EnterCreate_table_as_select(ctx *parser.Create_table_as_selectContext) {
+	if l.err != nil {
+		return
+	}
+	table := l.addObject(objectKindTable, ctx, ctx.Or_replace(), ctx.If_not_exists(), ctx.Object_name())
+	if table == nil {
+		return
+	}
+	table.createTableAsSelect = true
+}
+
+func (l *buildSchemaMapListener) addColumnDeclItems(table *objectInfo, list parser.IColumn_decl_item_listContext) {
+	if list == nil {
+		return
+	}
+	for _, item := range list.AllColumn_decl_item() {
+		if column := item.Full_col_decl(); column != nil {
+			l.addColumn(table, column)
+			continue
+		}
+		l.addOutOfLineConstraint(table, item.Out_of_line_constraint())
+	}
+}
+
+func (l *buildSchemaMapListener) addColumn(table *objectInfo, column parser.IFull_col_declContext) {
+	info := &columnInfo{
+		name:       NormalizeSnowSQLObjectNamePart(column.Col_decl().Column_name().Id_()),
+		definition: l.text(column),
+		dataType:   l.text(column.Col_decl().Data_type()),
+	}
+	var others []string
+	for _, nullNotNull := range column.AllNull_not_null() {
+		info.notNull = nullNotNull.NOT() != nil
+	}
+	for _, defaultValue := range column.AllDefault_value() {
+		info.defaultValue = l.text(defaultValue)
+	}
+	for _, constraint := range column.AllInline_constraint() {
+		if constraint.Null_not_null() != nil {
+			info.notNull = constraint.Null_not_null().NOT() != nil
+		}
+		others = append(others, l.signature(constraint))
+	}
+	for _, collate := range column.AllCollate() {
+		others = append(others, l.signature(collate))
+	}
+	if column.With_masking_policy() != nil {
+		others = append(others, l.signature(column.With_masking_policy()))
+	}
+	if column.With_tags() != nil {
+		others = append(others, l.signature(column.With_tags()))
+	}
+	if column.COMMENT() != nil {
+		info.comment = l.text(column.String_())
+	}
+	info.otherSignature = strings.Join(others, " ")
+	table.columnList = append(table.columnList, info)
+	table.columnMap[info.name] = info
+}
+
+func (l *buildSchemaMapListener) addOutOfLineConstraint(table *objectInfo, constraint parser.IOut_of_line_constraintContext) {
+	info := &constraintInfo{
+		id:         len(table.constraintMap),
+		primaryKey: constraint.PRIMARY() != nil,
+		unique:     constraint.UNIQUE() != nil,
+		foreignKey: constraint.REFERENCES() != nil,
+		definition: l.text(constraint),
+		signature:  l.signature(constraint),
+	}
+	if constraint.Id_() != nil {
+		info.name = NormalizeSnowSQLObjectNamePart(constraint.Id_())
+	}
+	if len(constraint.AllColumn_list_in_parentheses()) > 0 {
+		info.columns = l.text(constraint.Column_list_in_parentheses(0))
+	}
+	table.constraintMap[constraintKey(info)] = info
+}
+
+func constraintKey(constraint *constraintInfo) string {
+	if constraint.name != "" {
+		return constraint.name
+	}
+	// The unnamed constraints are identified by the type and columns, which are used to drop them.
+	switch {
+	case constraint.primaryKey:
+		return "PRIMARY KEY"
+	case constraint.unique:
+		return "UNIQUE " + constraint.columns
+	default:
+		return "FOREIGN KEY " + constraint.columns
+	}
+}
+
+// EnterCreate_view is called when production create_view is entered.
+func (l *buildSchemaMapListener) EnterCreate_view(ctx *parser.Create_viewContext) {
+	if l.err != nil {
+		return
+	}
+	l.addObject(objectKindView, ctx, ctx.Or_replace(), ctx.If_not_exists(), ctx.Object_name())
+}
+
+// EnterCreate_sequence is called when production create_sequence is entered.
+func (l *buildSchemaMapListener) EnterCreate_sequence(ctx *parser.Create_sequenceContext) {
+	if l.err != nil {
+		return
+	}
+	sequence := l.addObject(objectKindSequence, ctx, ctx.Or_replace(), ctx.If_not_exists(), ctx.Object_name())
+	if sequence == nil {
+		return
+	}
+	if ctx.Increment_by() != nil {
+		sequence.properties["INCREMENT"] = l.text(ctx.Increment_by().Num())
+	}
+	if ctx.Comment_clause() != nil {
+		l.addProperty(sequence, ctx.Comment_clause().COMMENT())
+	}
+}
+
+// EnterCreate_stage is called when production create_stage is entered.
+func (l *buildSchemaMapListener) EnterCreate_stage(ctx *parser.Create_stageContext) {
+	if l.err != nil {
+		return
+	}
+	stage := l.addObject(objectKindStage, ctx, ctx.Or_replace(), ctx.If_not_exists(), ctx.Object_name())
+	if stage == nil || ctx.External_stage_params() != nil {
+		return
+	}
+	stage.internalStage = true
+	var fixed []string
+	for _, item := range []antlr.ParserRuleContext{ctx.Internal_stage_params(), ctx.Directory_table_params(), ctx.With_tags()} {
+		if item != nil && !item.IsEmpty() {
+			fixed = append(fixed, l.signature(item))
+		}
+	}
+	stage.stageFixedSignature = strings.Join(fixed, " ")
+
+	// FILE_FORMAT, COPY_OPTIONS and COMMENT can be changed by ALTER STAGE ... SET.
+	var set []string
+	if ctx.FILE_FORMAT() != nil {
+		set = append(set, l.textFromToken(ctx.FILE_FORMAT().GetSymbol(), closingBracket(ctx.FILE_FORMAT().GetSymbol(), l.stream)))
+	}
+	if ctx.COPY_OPTIONS_() != nil {
+		set = append(set, l.textFromToken(ctx.COPY_OPTIONS_().GetSymbol(), closingBracket(ctx.COPY_OPTIONS_().GetSymbol(), l.stream)))
+	}
+	if ctx.Comment_clause() != nil {
+		set = append(set, l.text(ctx.Comment_clause()))
+	}
+	stage.stageSetClause = strings.Join(set, " ")
+}
+
+// EnterCreate_stream is called when production create_stream is entered.
+func (l *buildSchemaMapListener) EnterCreate_stream(ctx *parser.Create_streamContext) {
+	if l.err != nil {
+		return
+	}
+	l.addObject(objectKindStream, ctx, ctx.Or_replace(), ctx.If_not_exists(), ctx.Object_name(0))
+}
+
+// EnterCreate_task is called when production create_task is entered.
+func (l *buildSchemaMapListener) EnterCreate_task(ctx *parser.Create_taskContext) {
+	if l.err != nil {
+		return
+	}
+	l.addObject(objectKindTask, ctx, ctx.Or_replace(), ctx.If_not_exists(), ctx.Object_name())
+}
+
+func (l *buildSchemaMapListener) addObject(kind objectKind, ctx antlr.ParserRuleContext, orReplace parser.IOr_replaceContext, ifNotExists parser.IIf_not_existsContext, objectName parser.IObject_nameContext) *objectInfo {
+	schemaName := l.currentSchema
+	if objectName.GetS() != nil {
+		schemaName = NormalizeSnowSQLObjectNamePart(objectName.GetS())
+	}
+	name := NormalizeSnowSQLObjectNamePart(objectName.GetO())
+	schema := l.schemaMap.getOrCreateSchema(schemaName)
+	objects := schema.getObjectMap(kind)
+	if _, exists := objects[name]; exists {
+		l.err = errors.Errorf("duplicate %s %s", strings.ToLower(kind.String()), qualifiedName(schemaName, name))
+		return nil
+	}
+	body, signature := l.body(ctx, orReplace, ifNotExists, objectName, qualifiedName(schemaName, name))
+	object := &objectInfo{
+		id:            l.objectCount,
+		kind:          kind,
+		schemaName:    schemaName,
+		name:          name,
+		body:          body,
+		signature:     signature,
+		properties:    make(map[string]string),
+		columnMap:     make(columnMap),
+		constraintMap: make(constraintMap),
+	}
+	l.objectCount++
+	objects[name] = object
+	return object
+}
+
+// addProperty adds the property whose keyword is the given node and whose value follows the equal sign.
+func (l *buildSchemaMapListener) addProperty(object *objectInfo, keyword antlr.TerminalNode) {
+	if keyword == nil {
+		return
+	}
+	index := keyword.GetSymbol().GetTokenIndex() + 1
+	for ; index < l.stream.Size(); index++ {
+		token := l.stream.Get(index)
+		if token.GetChannel() != antlr.TokenDefaultChannel || token.GetTokenType() == parser.SnowflakeParserEQ {
+			continue
+		}
+		object.properties[strings.ToUpper(keyword.GetText())] = token.GetText()
+		return
+	}
+}
+
+// body returns the text following CREATE or CREATE OR REPLACE, without IF NOT EXISTS and with the qualified object name,
+// so that it can be used for both CREATE and CREATE OR REPLACE. It also returns the signature of the body,
+// which is the text of the tokens in the default channel separated by a single space.
+func (l *buildSchemaMapListener) body(ctx antlr.ParserRuleContext, orReplace parser.IOr_replaceContext, ifNotExists parser.IIf_not_existsContext, name antlr.ParserRuleContext, qualifiedName string) (string, string) {
+	start := ctx.GetStart().GetTokenIndex() + 1
+	if orReplace != nil {
+		start = orReplace.GetStop().GetTokenIndex() + 1
+	}
+	stop := ctx.GetStop().GetTokenIndex()
+	var buf strings.Builder
+	var tokens []string
+	for i := start; i <= stop; i++ {
+		if ifNotExists != nil && i >= ifNotExists.GetStart().GetTokenIndex() && i <= ifNotExists.GetStop().GetTokenIndex() {
+			continue
+		}
+		if i == name.GetStart().GetTokenIndex() {
+			_, _ = buf.WriteString(qualifiedName)
+			tokens = append(tokens, qualifiedName)
+			i = name.GetStop().GetTokenIndex()
+			continue
+		}
+		token := l.stream.Get(i)
+		if token.GetTokenType() == parser.SnowflakeParserSEMI {
+			continue
+		}
+		_, _ = buf.WriteString(token.GetText())
+		if token.GetChannel() == antlr.TokenDefaultChannel {
+			tokens = append(tokens, token.GetText())
+		}
+	}
+	return strings.TrimSpace(buf.String()), strings.Join(tokens, " ")
+}
+
+func (l *buildSchemaMapListener) text(ctx antlr.ParserRuleContext) string {
+	if ctx == nil || ctx.GetStart() == nil || ctx.GetStop() == nil || ctx.GetStop().GetTokenIndex() < ctx.GetStart().GetTokenIndex() {
+		return ""
+	}
+	return strings.TrimSpace(l.stream.GetTextFromInterval(antlr.NewInterval(ctx.GetStart().GetTokenIndex(), ctx.GetStop().GetTokenIndex())))
+}
+
+func (l *buildSchemaMapListener) textFromToken(start antlr.Token, stop int) string {
+	return strings.TrimSpace(l.stream.GetTextFromInterval(antlr.NewInterval(start.GetTokenIndex(), stop)))
+}
+
+// signature returns the text of the tokens in the default channel separated by a single space,
+// so that the whitespaces and comments don't affect the comparison.
+func (l *buildSchemaMapListener) signature(ctx antlr.ParserRuleContext) string {
+	if ctx == nil || ctx.GetStart() == nil || ctx.GetStop() == nil {
+		return ""
+	}
+	var tokens []string
+	for i := ctx.GetStart().GetTokenIndex(); i <= ctx.GetStop().GetTokenIndex(); i++ {
+		token := l.stream.Get(i)
+		if token.GetChannel() != antlr.TokenDefaultChannel {
+			continue
+		}
+		tokens = append(tokens, token.GetText())
+	}
+	return strings.Join(tokens, " ")
+}
+
+// closingBracket returns the index of the bracket closing the first opening bracket after the given token.
+func closingBracket(start antlr.Token, stream antlr.TokenStream) int {
+	depth := 0
+	for i := start.GetTokenIndex(); i < stream.Size(); i++ {
+		switch stream.Get(i).GetTokenType() {
+		case parser.SnowflakeParserLR_BRACKET:
+			depth++
+		case parser.SnowflakeParserRR_BRACKET:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return start.GetTokenIndex()
+}
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
+
+func qualifiedName(schemaName string, name string) string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(schemaName), quoteIdentifier(name))
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedSchemas(m schemaMap) []*schemaInfo {
+	var schemas []*schemaInfo
+	for _, schema := range m {
+		schemas = append(schemas, schema)
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].id < schemas[j].id
+	})
+	return schemas
+}
+
+func sortedObjects(m schemaMap) []*objectInfo {
+	var objects []*objectInfo
+	for _, schema := range m {
+		for _, objectMap := range schema.objectMaps {
+			for _, object := range objectMap {
+				objects = append(objects, object)
+			}
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].id < objects[j].id
+	})
+	return objects
+}
+
+func sortedConstraints(m constraintMap) []*constraintInfo {
+	var constraints []*constraintInfo
+	for _, constraint := range m {
+		constraints = append(constraints, constraint)
+	}
+	sort.Slice(constraints, func(i, j int) bool {
+		return constraints[i].id < constraints[j].id
+	})
+	return constraints
+}
@@ -0,0 +1,133 @@
+package snowflake
+
+import (
+	"sort"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/snowsql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterExtractChangedResourcesFunc(storepb.Engine_SNOWFLAKE, extractChangedResources)
+}
+
+func extractChangedResources(currentDatabase string, currentSchema string, statement string) ([]base.SchemaResource, error) {
+	tree, err := ParseSnowSQL(statement)
+	if err != nil {
+		return nil, err
+	}
+	if currentSchema == "" {
+		currentSchema = "PUBLIC"
+	}
+
+	l := &resourceChangedListener{
+		currentDatabase: currentDatabase,
+		currentSchema:   currentSchema,
+		resourceMap:     make(map[string]base.SchemaResource),
+	}
+
+	var result []base.SchemaResource
+	antlr.ParseTreeWalkerDefault.Walk(l, tree.Tree)
+	for _, resource := range l.resourceMap {
+		result = append(result, resource)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result, nil
+}
+
+type resourceChangedListener struct {
+	*parser.BaseSnowflakeParserListener
+
+	currentDatabase string
+	currentSchema   string
+	resourceMap     map[string]base.SchemaResource
+}
+
+func (l *resourceChangedListener) addObjectName(ctx parser.IObject_nameContext) {
+	if ctx == nil {
+		return
+	}
+	resource := base.SchemaResource{
+		Database: l.currentDatabase,
+		Schema:   l.currentSchema,
+		Table:    NormalizeSnowSQLObjectNamePart(ctx.GetO()),
+	}
+	if resource.Table == "" {
+		return
+	}
+	if d := NormalizeSnowSQLObjectNamePart(ctx.GetD()); d != "" {
+		resource.Database = d
+	}
+	if s := NormalizeSnowSQLObjectNamePart(ctx.GetS()); s != "" {
+		resource.Schema = s
+	}
+	l.resourceMap[resource.String()] = resource
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *resourceChangedListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.addObjectName(ctx.Object_name())
+}
+
+// EnterCreate_table_as_select is called when production create_table_as_select is entered.
+func (l *resourceChangedListener) EnterCreate_table_as_select(ctx *parser.Create_table_as_selectContext) {
+	l.addObjectName(ctx.Object_name())
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *resourceChangedListener) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	// Both tables are changed by the RENAME TO and SWAP WITH.
+	if ctx.RENAME() != nil || ctx.SWAP() != nil {
+		for _, name := range ctx.AllObject_name() {
+			l.addObjectName(name)
+		}
+		return
+	}
+	l.addObjectName(ctx.Object_name(0))
+}
+
+// EnterDrop_table is called when production drop_table is entered.
+func (l *resourceChangedListener) EnterDrop_table(ctx *parser.Drop_tableContext) {
+	l.addObjectName(ctx.Object_name())
+}
+
+// EnterUndrop_table is called when production undrop_table is entered.
+func (l *resourceChangedListener) EnterUndrop_table(ctx *parser.Undrop_tableContext) {
+	l.addObjectName(ctx.Object_name())
+}
+
+// EnterTruncate_table is called when production truncate_table is entered.
+func (l *resourceChangedListener) EnterTruncate_table(ctx *parser.Truncate_tableContext) {
+	l.addObjectName(ctx.Object_name())
+}
+
+// EnterInsert_statement is called when production insert_statement is entered.
+func (l *resourceChangedListener) EnterInsert_statement(ctx *parser.Insert_statementContext) {
+	l.addObjectName(ctx.Object_name())
+}
+
+// EnterInto_clause2 is called when production into_clause2 is entered.
+func (l *resourceChangedListener) EnterInto_clause2(ctx *parser.Into_clause2Context) {
+	l.addObjectName(ctx.Object_name())
+}
+
+// EnterMerge_statement is called when production merge_statement is entered.
+func (l *resourceChangedListener) EnterMerge_statement(ctx *parser.Merge_statementContext) {
+	l.addObjectName(ctx.Object_name())
+}
+
+// EnterUpdate_statement is called when production update_statement is entered.
+func (l *resourceChangedListener) EnterUpdate_statement(ctx *parser.Update_statementContext) {
+	l.addObjectName(ctx.Object_name())
+}
+
+// EnterDelete_statement is called when production delete_statement is entered.
+func (l *resourceChangedListener) EnterDelete_statement(ctx *parser.Delete_statementContext) {
+	l.addObjectName(ctx.Object_name())
+}
@@ -0,0 +1,43 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+func TestExtractChangedResources(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      []base.SchemaResource
+	}{
+		{
+			statement: `CREATE TABLE t1 (id INT); CREATE TABLE s1."t2" AS SELECT * FROM t3; ALTER TABLE t4 RENAME TO db2.s2.t5; DROP TABLE t6;`,
+			want: []base.SchemaResource{
+				{Database: "DB", Schema: "PUBLIC", Table: "T1"},
+				{Database: "DB", Schema: "PUBLIC", Table: "T4"},
+				{Database: "DB", Schema: "PUBLIC", Table: "T6"},
+				{Database: "DB", Schema: "S1", Table: "t2"},
+				{Database: "DB2", Schema: "S2", Table: "T5"},
+			},
+		},
+		{
+			statement: `INSERT INTO t1 SELECT * FROM t2; UPDATE s1.t3 SET a = 1; DELETE FROM t4 USING t5; MERGE INTO t6 USING t7 ON t6.id = t7.id WHEN MATCHED THEN DELETE; SELECT * FROM t8;`,
+			want: []base.SchemaResource{
+				{Database: "DB", Schema: "PUBLIC", Table: "T1"},
+				{Database: "DB", Schema: "PUBLIC", Table: "T4"},
+				{Database: "DB", Schema: "PUBLIC", Table: "T6"},
+				{Database: "DB", Schema: "S1", Table: "T3"},
+			},
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := extractChangedResources("DB", "", test.statement)
+		a.NoError(err, test.statement)
+		a.Equal(test.want, got, test.statement)
+	}
+}
@@ -0,0 +1,107 @@
+package tidb
+
+import (
+	"sort"
+
+	tidbast "github.com/pingcap/tidb/parser/ast"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterExtractChangedResourcesFunc(storepb.Engine_TIDB, extractChangedResources)
+}
+
+func extractChangedResources(currentDatabase string, _ string, statement string) ([]base.SchemaResource, error) {
+	nodes, err := ParseTiDB(statement, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	resourceMap := make(map[string]base.SchemaResource)
+	for _, node := range nodes {
+		for _, table := range extractChangedTables(node) {
+			resource := base.SchemaResource{
+				Database: table.Schema.O,
+				Table:    table.Name.O,
+			}
+			if resource.Database == "" {
+				resource.Database = currentDatabase
+			}
+			resourceMap[resource.String()] = resource
+		}
+	}
+
+	var result []base.SchemaResource
+	for _, resource := range resourceMap {
+		result = append(result, resource)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result, nil
+}
+
+// extractChangedTables extracts the tables whose schema or data are changed by the statement.
+func extractChangedTables(node tidbast.StmtNode) []*tidbast.TableName {
+	switch n := node.(type) {
+	case *tidbast.CreateTableStmt:
+		return []*tidbast.TableName{n.Table}
+	case *tidbast.AlterTableStmt:
+		return []*tidbast.TableName{n.Table}
+	case *tidbast.DropTableStmt:
+		if n.IsView {
+			return nil
+		}
+		return n.Tables
+	case *tidbast.TruncateTableStmt:
+		return []*tidbast.TableName{n.Table}
+	case *tidbast.RenameTableStmt:
+		var result []*tidbast.TableName
+		for _, table := range n.TableToTables {
+			result = append(result, table.OldTable, table.NewTable)
+		}
+		return result
+	case *tidbast.CreateIndexStmt:
+		return []*tidbast.TableName{n.Table}
+	case *tidbast.DropIndexStmt:
+		return []*tidbast.TableName{n.Table}
+	case *tidbast.InsertStmt:
+		return ExtractMySQLTableList(n.Table.TableRefs, false /* asName */)
+	case *tidbast.UpdateStmt:
+		return ExtractMySQLTableList(n.TableRefs.TableRefs, false /* asName */)
+	case *tidbast.DeleteStmt:
+		if !n.IsMultiTable {
+			return ExtractMySQLTableList(n.TableRefs.TableRefs, false /* asName */)
+		}
+		// The tables of the multiple-table DELETE may refer to the aliases in the table references.
+		aliasMap := make(map[string]*tidbast.TableName)
+		collectTableAliases(n.TableRefs.TableRefs, aliasMap)
+		var result []*tidbast.TableName
+		for _, table := range n.Tables.Tables {
+			if origin, ok := aliasMap[table.Name.L]; ok && table.Schema.L == "" {
+				result = append(result, origin)
+				continue
+			}
+			result = append(result, table)
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+func collectTableAliases(node tidbast.ResultSetNode, aliasMap map[string]*tidbast.TableName) {
+	switch n := node.(type) {
+	case *tidbast.Join:
+		collectTableAliases(n.Left, aliasMap)
+		if n.Right != nil {
+			collectTableAliases(n.Right, aliasMap)
+		}
+	case *tidbast.TableSource:
+		if table, ok := n.Source.(*tidbast.TableName); ok && n.AsName.L != "" {
+			aliasMap[n.AsName.L] = table
+		}
+	}
+}
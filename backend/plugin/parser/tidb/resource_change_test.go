@@ -0,0 +1,41 @@
+package tidb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+func TestExtractChangedResources(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      []base.SchemaResource
+	}{
+		{
+			statement: "CREATE TABLE t1 (id int); ALTER TABLE db2.t2 ADD COLUMN a int; RENAME TABLE t3 TO t4; DROP VIEW v1;",
+			want: []base.SchemaResource{
+				{Database: "db", Table: "t1"},
+				{Database: "db", Table: "t3"},
+				{Database: "db", Table: "t4"},
+				{Database: "db2", Table: "t2"},
+			},
+		},
+		{
+			statement: "INSERT INTO t1 SELECT * FROM t2; UPDATE t3 SET a = 1 WHERE id IN (SELECT id FROM t4); DELETE a FROM t5 AS a JOIN t6 ON a.id = t6.id; SELECT * FROM t7;",
+			want: []base.SchemaResource{
+				{Database: "db", Table: "t1"},
+				{Database: "db", Table: "t3"},
+				{Database: "db", Table: "t5"},
+			},
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := extractChangedResources("db", "", test.statement)
+		a.NoError(err, test.statement)
+		a.Equal(test.want, got, test.statement)
+	}
+}
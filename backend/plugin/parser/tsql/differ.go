@@ -0,0 +1,1025 @@
+package tsql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/pkg/errors"
+
+	parser "github.com/bytebase/tsql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const defaultSchemaName = "dbo"
+
+func init() {
+	base.RegisterSchemaDiffFunc(storepb.Engine_MSSQL, SchemaDiff)
+}
+
+// diffNode defines different modification types as the safe change order.
+// The safe change order means we can change them with no dependency conflicts as this order.
+type diffNode struct {
+	// Drop nodes
+	dropForeignKeyList         []string
+	dropModuleList             []string
+	dropIndexList              []string
+	dropConstraintExceptFkList []string
+	dropColumnList             []string
+	dropTableList              []string
+	dropSchemaList             []string
+
+	// Create nodes
+	createSchemaList          []string
+	createTableList           []string
+	addColumnList             []string
+	alterColumnList           []string
+	addConstraintExceptFkList []string
+	createIndexList           []string
+	addForeignKeyList         []string
+	createOrAlterModuleList   []string
+}
+
+type objectKind int
+
+const (
+	objectKindView objectKind = iota
+	objectKindProcedure
+	objectKindFunction
+)
+
+func (k objectKind) String() string {
+	switch k {
+	case objectKindView:
+		return "VIEW"
+	case objectKindProcedure:
+		return "PROCEDURE"
+	case objectKindFunction:
+		return "FUNCTION"
+	}
+	return ""
+}
+
+type schemaMap map[string]*schemaInfo
+type tableMap map[string]*tableInfo
+type columnMap map[string]*columnInfo
+type constraintMap map[string]*constraintInfo
+type indexMap map[string]*indexInfo
+type moduleMap map[string]*moduleInfo
+
+type schemaInfo struct {
+	id   int
+	name string
+	// createSchema is nil for the schemas that are only referenced by other objects, e.g. dbo.
+	createSchema *parser.Create_schemaContext
+	tableMap     tableMap
+	moduleMap    moduleMap
+}
+
+type tableInfo struct {
+	id            int
+	schemaName    string
+	name          string
+	createTable   *parser.Create_tableContext
+	columnMap     columnMap
+	columnList    []*columnInfo
+	constraintMap constraintMap
+	indexMap      indexMap
+}
+
+type columnInfo struct {
+	id   int
+	name string
+	// definition is the full text of the column definition.
+	definition string
+	// computed is true if the column is a computed column.
+	computed bool
+	// dataType and nullability are used to build the ALTER COLUMN statement.
+	dataType    string
+	collation   string
+	nullability string
+	// signature is the normalized text of the column properties except the default value and the constraints.
+	signature string
+	// defaultSignature is the normalized text of the unnamed default value.
+	defaultSignature string
+	defaultText      string
+}
+
+type constraintInfo struct {
+	id   int
+	name string
+	// unnamed constraints are keyed by signature and cannot be dropped by name.
+	unnamed    bool
+	foreignKey bool
+	// columnName is set for the constraints defined inline in a column definition.
+	columnName string
+	// standalone is true if the constraint is added by the ALTER TABLE statement instead of the CREATE TABLE statement.
+	standalone bool
+	// definition is the constraint definition that can follow ALTER TABLE ... ADD.
+	definition string
+	signature  string
+}
+
+type indexInfo struct {
+	id   int
+	name string
+	// inline is true if the index is defined in the CREATE TABLE statement.
+	inline     bool
+	definition string
+	signature  string
+}
+
+type moduleInfo struct {
+	id         int
+	schemaName string
+	name       string
+	kind       objectKind
+	// body is the text starting from the VIEW/PROCEDURE/FUNCTION keyword.
+	body      string
+	signature string
+}
+
+func newSchemaInfo(id int, name string) *schemaInfo {
+	return &schemaInfo{
+		id:        id,
+		name:      name,
+		tableMap:  make(tableMap),
+		moduleMap: make(moduleMap),
+	}
+}
+
+func newTableInfo(id int, schemaName string, name string, createTable *parser.Create_tableContext) *tableInfo {
+	return &tableInfo{
+		id:            id,
+		schemaName:    schemaName,
+		name:          name,
+		createTable:   createTable,
+		columnMap:     make(columnMap),
+		constraintMap: make(constraintMap),
+		indexMap:      make(indexMap),
+	}
+}
+
+func (m schemaMap) getOrCreateSchema(name string) *schemaInfo {
+	key := strings.ToLower(name)
+	if schema, ok := m[key]; ok {
+		return schema
+	}
+	schema := newSchemaInfo(len(m), name)
+	m[key] = schema
+	return schema
+}
+
+func (m schemaMap) getTable(schemaName string, tableName string) *tableInfo {
+	schema, ok := m[strings.ToLower(schemaName)]
+	if !ok {
+		return nil
+	}
+	return schema.tableMap[strings.ToLower(tableName)]
+}
+
+// SchemaDiff computes the migration DDL from the old schema to the new schema for SQL Server.
+func SchemaDiff(oldStmt, newStmt string, _ bool) (string, error) {
+	oldSchemaMap, err := buildSchemaMap(oldStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema info for old statement")
+	}
+	newSchemaMap, err := buildSchemaMap(newStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema info for new statement")
+	}
+
+	diff := &diffNode{}
+	for _, newSchema := range sortedSchemas(newSchemaMap) {
+		oldSchema, exists := oldSchemaMap[strings.ToLower(newSchema.name)]
+		if !exists {
+			oldSchema = newSchemaInfo(-1, newSchema.name)
+		}
+		if newSchema.createSchema != nil && oldSchema.createSchema == nil && !strings.EqualFold(newSchema.name, defaultSchemaName) {
+			diff.createSchemaList = append(diff.createSchemaList, getTextFromContext(newSchema.createSchema))
+		}
+		for _, newTable := range sortedTables(newSchema.tableMap) {
+			oldTable, exists := oldSchema.tableMap[strings.ToLower(newTable.name)]
+			if !exists {
+				diff.createTable(newTable)
+				continue
+			}
+			if err := diff.modifyTable(oldTable, newTable); err != nil {
+				return "", err
+			}
+			delete(oldSchema.tableMap, strings.ToLower(newTable.name))
+		}
+	}
+
+	// The views, procedures and functions may depend on each other, so we create them in the order of the new schema
+	// and drop them in the reverse order of the old schema.
+	for _, newModule := range sortedModules(newSchemaMap) {
+		oldSchema, exists := oldSchemaMap[strings.ToLower(newModule.schemaName)]
+		if !exists {
+			diff.appendCreateOrAlterModule(newModule, false /* alter */)
+			continue
+		}
+		oldModule, exists := oldSchema.moduleMap[strings.ToLower(newModule.name)]
+		if !exists {
+			diff.appendCreateOrAlterModule(newModule, false /* alter */)
+			continue
+		}
+		if oldModule.kind != newModule.kind {
+			// A view cannot be altered to a procedure, etc., so we drop the old one and create the new one.
+			diff.dropModuleList = append(diff.dropModuleList, dropModuleStatement(oldModule))
+			diff.appendCreateOrAlterModule(newModule, false /* alter */)
+		} else if oldModule.signature != newModule.signature {
+			diff.appendCreateOrAlterModule(newModule, true /* alter */)
+		}
+		delete(oldSchema.moduleMap, strings.ToLower(newModule.name))
+	}
+	oldModules := sortedModules(oldSchemaMap)
+	for i := len(oldModules) - 1; i >= 0; i-- {
+		diff.dropModuleList = append(diff.dropModuleList, dropModuleStatement(oldModules[i]))
+	}
+
+	// Drop the remaining tables and schemas which don't exist in the new schema.
+	for _, oldSchema := range sortedSchemas(oldSchemaMap) {
+		for _, table := range sortedTables(oldSchema.tableMap) {
+			diff.dropTable(table)
+		}
+		if _, exists := newSchemaMap[strings.ToLower(oldSchema.name)]; exists {
+			continue
+		}
+		if oldSchema.createSchema != nil && !strings.EqualFold(oldSchema.name, defaultSchemaName) {
+			diff.dropSchemaList = append(diff.dropSchemaList, fmt.Sprintf("DROP SCHEMA %s;", quoteIdentifier(oldSchema.name)))
+		}
+	}
+
+	return diff.deparse()
+}
+
+func (diff *diffNode) dropTable(table *tableInfo) {
+	// The foreign keys must be dropped before the tables, otherwise the referenced tables cannot be dropped.
+	for _, constraint := range sortedConstraints(table.constraintMap) {
+		if constraint.foreignKey && !constraint.unnamed {
+			diff.dropForeignKeyList = append(diff.dropForeignKeyList, dropConstraintStatement(table, constraint))
+		}
+	}
+	diff.dropTableList = append(diff.dropTableList, fmt.Sprintf("DROP TABLE %s;", qualifiedName(table.schemaName, table.name)))
+}
+
+func (diff *diffNode) createTable(table *tableInfo) {
+	diff.createTableList = append(diff.createTableList, getTextFromContext(table.createTable))
+	// The constraints and indexes defined in the CREATE TABLE statement are created with the table.
+	for _, constraint := range sortedConstraints(table.constraintMap) {
+		if !constraint.standalone {
+			continue
+		}
+		diff.appendAddConstraint(table, constraint)
+	}
+	for _, index := range sortedIndexes(table.indexMap) {
+		if index.inline {
+			continue
+		}
+		diff.createIndexList = append(diff.createIndexList, index.definition)
+	}
+}
+
+func (diff *diffNode) modifyTable(oldTable *tableInfo, newTable *tableInfo) error {
+	// Columns.
+	var droppedColumns []string
+	for _, newColumn := range newTable.columnList {
+		oldColumn, exists := oldTable.columnMap[strings.ToLower(newColumn.name)]
+		if !exists {
+			diff.addColumnList = append(diff.addColumnList, fmt.Sprintf("ALTER TABLE %s ADD %s;", qualifiedName(newTable.schemaName, newTable.name), newColumn.definition))
+			continue
+		}
+		if err := diff.modifyColumn(newTable, oldColumn, newColumn); err != nil {
+			return err
+		}
+	}
+	for _, oldColumn := range oldTable.columnList {
+		if _, exists := newTable.columnMap[strings.ToLower(oldColumn.name)]; exists {
+			continue
+		}
+		droppedColumns = append(droppedColumns, quoteIdentifier(oldColumn.name))
+	}
+	if len(droppedColumns) > 0 {
+		diff.dropColumnList = append(diff.dropColumnList, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", qualifiedName(oldTable.schemaName, oldTable.name), strings.Join(droppedColumns, ", ")))
+	}
+
+	// Constraints.
+	for _, newConstraint := range sortedConstraints(newTable.constraintMap) {
+		key := constraintKey(newConstraint)
+		oldConstraint, exists := oldTable.constraintMap[key]
+		if exists {
+			delete(oldTable.constraintMap, key)
+			if oldConstraint.signature == newConstraint.signature {
+				continue
+			}
+			diff.appendDropConstraint(oldTable, oldConstraint)
+		}
+		if newConstraint.columnName != "" {
+			// The inline constraints of the new columns are created with the columns.
+			if _, exists := oldTable.columnMap[strings.ToLower(newConstraint.columnName)]; !exists {
+				continue
+			}
+		}
+		diff.appendAddConstraint(newTable, newConstraint)
+	}
+	for _, oldConstraint := range sortedConstraints(oldTable.constraintMap) {
+		if oldConstraint.unnamed {
+			return errors.Errorf("cannot drop the unnamed constraint %q on table %s, please name the constraint explicitly", oldConstraint.definition, qualifiedName(oldTable.schemaName, oldTable.name))
+		}
+		diff.appendDropConstraint(oldTable, oldConstraint)
+	}
+
+	// Indexes.
+	for _, newIndex := range sortedIndexes(newTable.indexMap) {
+		oldIndex, exists := oldTable.indexMap[strings.ToLower(newIndex.name)]
+		if exists {
+			delete(oldTable.indexMap, strings.ToLower(newIndex.name))
+			if oldIndex.signature == newIndex.signature {
+				continue
+			}
+			diff.dropIndexList = append(diff.dropIndexList, dropIndexStatement(oldTable, oldIndex))
+		}
+		diff.createIndexList = append(diff.createIndexList, newIndex.definition)
+	}
+	for _, oldIndex := range sortedIndexes(oldTable.indexMap) {
+		diff.dropIndexList = append(diff.dropIndexList, dropIndexStatement(oldTable, oldIndex))
+	}
+	return nil
+}
+
+func (diff *diffNode) modifyColumn(table *tableInfo, oldColumn *columnInfo, newColumn *columnInfo) error {
+	tableName := qualifiedName(table.schemaName, table.name)
+	if oldColumn.computed || newColumn.computed {
+		// SQL Server doesn't support altering the computed columns, so we drop and re-add them.
+		if oldColumn.definition != newColumn.definition {
+			diff.dropColumnList = append(diff.dropColumnList, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", tableName, quoteIdentifier(oldColumn.name)))
+			diff.addColumnList = append(diff.addColumnList, fmt.Sprintf("ALTER TABLE %s ADD %s;", tableName, newColumn.definition))
+		}
+		return nil
+	}
+
+	if oldColumn.signature != newColumn.signature {
+		if oldColumn.dataType == newColumn.dataType && oldColumn.collation == newColumn.collation && oldColumn.nullability == newColumn.nullability {
+			return errors.Errorf("changing the column properties except the data type, collation and nullability is not supported, column: %s.%s", tableName, quoteIdentifier(newColumn.name))
+		}
+		var buf strings.Builder
+		_, _ = fmt.Fprintf(&buf, "ALTER TABLE %s ALTER COLUMN %s %s", tableName, quoteIdentifier(newColumn.name), newColumn.dataType)
+		if newColumn.collation != "" {
+			_, _ = fmt.Fprintf(&buf, " COLLATE %s", newColumn.collation)
+		}
+		if newColumn.nullability != "" {
+			_, _ = fmt.Fprintf(&buf, " %s", newColumn.nullability)
+		}
+		_, _ = buf.WriteString(";")
+		diff.alterColumnList = append(diff.alterColumnList, buf.String())
+	}
+
+	if oldColumn.defaultSignature != newColumn.defaultSignature {
+		if oldColumn.defaultSignature != "" {
+			return errors.Errorf("cannot drop the unnamed default constraint of column %s.%s, please name the constraint explicitly", tableName, quoteIdentifier(oldColumn.name))
+		}
+		diff.addConstraintExceptFkList = append(diff.addConstraintExceptFkList, fmt.Sprintf("ALTER TABLE %s ADD DEFAULT %s FOR %s;", tableName, newColumn.defaultText, quoteIdentifier(newColumn.name)))
+	}
+	return nil
+}
+
+func (diff *diffNode) appendAddConstraint(table *tableInfo, constraint *constraintInfo) {
+	stmt := fmt.Sprintf("ALTER TABLE %s ADD %s;", qualifiedName(table.schemaName, table.name), constraint.definition)
+	if constraint.foreignKey {
+		diff.addForeignKeyList = append(diff.addForeignKeyList, stmt)
+	} else {
+		diff.addConstraintExceptFkList = append(diff.addConstraintExceptFkList, stmt)
+	}
+}
+
+func (diff *diffNode) appendDropConstraint(table *tableInfo, constraint *constraintInfo) {
+	stmt := dropConstraintStatement(table, constraint)
+	if constraint.foreignKey {
+		diff.dropForeignKeyList = append(diff.dropForeignKeyList, stmt)
+	} else {
+		diff.dropConstraintExceptFkList = append(diff.dropConstraintExceptFkList, stmt)
+	}
+}
+
+func (diff *diffNode) appendCreateOrAlterModule(module *moduleInfo, alter bool) {
+	if alter {
+		diff.createOrAlterModuleList = append(diff.createOrAlterModuleList, fmt.Sprintf("CREATE OR ALTER %s", module.body))
+	} else {
+		diff.createOrAlterModuleList = append(diff.createOrAlterModuleList, fmt.Sprintf("CREATE %s", module.body))
+	}
+}
+
+func dropConstraintStatement(table *tableInfo, constraint *constraintInfo) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", qualifiedName(table.schemaName, table.name), quoteIdentifier(constraint.name))
+}
+
+func dropIndexStatement(table *tableInfo, index *indexInfo) string {
+	return fmt.Sprintf("DROP INDEX %s ON %s;", quoteIdentifier(index.name), qualifiedName(table.schemaName, table.name))
+}
+
+func dropModuleStatement(module *moduleInfo) string {
+	return fmt.Sprintf("DROP %s %s;", module.kind.String(), qualifiedName(module.schemaName, module.name))
+}
+
+// deparse prints the statements in the safe change order.
+// The statements which must be the only statement in a batch, such as CREATE SCHEMA and CREATE VIEW,
+// are separated by the GO command.
+func (diff *diffNode) deparse() (string, error) {
+	var buf strings.Builder
+	for _, list := range [][]string{
+		diff.dropForeignKeyList,
+		diff.dropModuleList,
+		diff.dropIndexList,
+		diff.dropConstraintExceptFkList,
+		diff.dropColumnList,
+		diff.dropTableList,
+		diff.dropSchemaList,
+	} {
+		for _, stmt := range list {
+			if err := writeStatement(&buf, stmt); err != nil {
+				return "", err
+			}
+		}
+	}
+	for _, stmt := range diff.createSchemaList {
+		if err := writeBatch(&buf, stmt); err != nil {
+			return "", err
+		}
+	}
+	for _, list := range [][]string{
+		diff.createTableList,
+		diff.addColumnList,
+		diff.alterColumnList,
+		diff.addConstraintExceptFkList,
+		diff.createIndexList,
+		diff.addForeignKeyList,
+	} {
+		for _, stmt := range list {
+			if err := writeStatement(&buf, stmt); err != nil {
+				return "", err
+			}
+		}
+	}
+	for _, stmt := range diff.createOrAlterModuleList {
+		if err := writeBatch(&buf, stmt); err != nil {
+			return "", err
+		}
+	}
+	return buf.String(), nil
+}
+
+func writeStatement(buf *strings.Builder, stmt string) error {
+	if _, err := buf.WriteString(stmt); err != nil {
+		return err
+	}
+	if _, err := buf.WriteString("\n"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeBatch(buf *strings.Builder, stmt string) error {
+	if buf.Len() > 0 && !strings.HasSuffix(buf.String(), "GO\n") {
+		if _, err := buf.WriteString("GO\n"); err != nil {
+			return err
+		}
+	}
+	if err := writeStatement(buf, stmt); err != nil {
+		return err
+	}
+	if _, err := buf.WriteString("GO\n"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func buildSchemaMap(statement string) (schemaMap, error) {
+	m := make(schemaMap)
+	if strings.TrimSpace(statement) == "" {
+		return m, nil
+	}
+	result, err := ParseTSQL(statement)
+	if err != nil {
+		return nil, err
+	}
+	listener := &buildSchemaMapListener{
+		schemaMap: m,
+		stream:    result.Tokens,
+	}
+	antlr.ParseTreeWalkerDefault.Walk(listener, result.Tree)
+	if listener.err != nil {
+		return nil, listener.err
+	}
+	return m, nil
+}
+
+type buildSchemaMapListener struct {
+	*parser.BaseTSqlParserListener
+
+	schemaMap schemaMap
+	stream    antlr.TokenStream
+	// currentSchema is the schema of the CREATE SCHEMA statement that is being walked.
+	currentSchema string
+	// tableCount and moduleCount are used to keep the order of the objects across schemas.
+	tableCount  int
+	moduleCount int
+	err         error
+}
+
+// EnterCreate_schema is called when production create_schema is entered.
+func (l *buildSchemaMapListener) EnterCreate_schema(ctx *parser.Create_schemaContext) {
+	if l.err != nil || ctx.GetSchema_name() == nil {
+		return
+	}
+	schemaName := originalIdentifier(ctx.GetSchema_name())
+	schema := l.schemaMap.getOrCreateSchema(schemaName)
+	schema.createSchema = ctx
+	l.currentSchema = schemaName
+}
+
+// ExitCreate_schema is called when production create_schema is exited.
+func (l *buildSchemaMapListener) ExitCreate_schema(_ *parser.Create_schemaContext) {
+	l.currentSchema = ""
+}
+
+func (l *buildSchemaMapListener) schemaName(id parser.IId_Context) string {
+	if id != nil {
+		return originalIdentifier(id)
+	}
+	if l.currentSchema != "" {
+		return l.currentSchema
+	}
+	return defaultSchemaName
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *buildSchemaMapListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	if l.err != nil {
+		return
+	}
+	schema := l.schemaMap.getOrCreateSchema(l.schemaName(ctx.Table_name().GetSchema()))
+	tableName := originalIdentifier(ctx.Table_name().GetTable())
+	key := strings.ToLower(tableName)
+	if _, exists := schema.tableMap[key]; exists {
+		l.err = errors.Errorf("duplicate table %s", qualifiedName(schema.name, tableName))
+		return
+	}
+	table := newTableInfo(l.tableCount, schema.name, tableName, ctx)
+	l.tableCount++
+	schema.tableMap[key] = table
+
+	for _, item := range ctx.Column_def_table_constraints().AllColumn_def_table_constraint() {
+		switch {
+		case item.Column_definition() != nil:
+			l.addColumn(table, item.Column_definition())
+		case item.Materialized_column_definition() != nil:
+			column := item.Materialized_column_definition()
+			name := originalIdentifier(column.Id_())
+			l.appendColumn(table, &columnInfo{
+				name:       name,
+				definition: l.text(column),
+				computed:   true,
+			})
+		case item.Table_constraint() != nil:
+			l.addTableConstraint(table, item.Table_constraint(), false /* standalone */)
+		}
+	}
+	for _, index := range ctx.AllTable_indices() {
+		name := originalIdentifier(index.Id_(0))
+		// The modifiers such as UNIQUE and CLUSTERED come after the index name in the inline index definition.
+		modifiers, rest := l.splitInlineIndex(index, index.Id_(0))
+		l.addIndex(table, name, true /* inline */, modifiers, rest)
+	}
+}
+
+func (l *buildSchemaMapListener) addColumn(table *tableInfo, column parser.IColumn_definitionContext) {
+	name := originalIdentifier(column.Id_())
+	info := &columnInfo{
+		name:       name,
+		definition: l.text(column),
+	}
+	if column.Data_type() == nil {
+		info.computed = true
+		l.appendColumn(table, info)
+		return
+	}
+
+	var signature []string
+	dataType := column.Data_type()
+	if dataType.IDENTITY() != nil {
+		// The IDENTITY property is parsed as a part of the data type, e.g. INT IDENTITY(1, 1).
+		info.dataType = l.text(dataType.GetExt_type())
+		signature = append(signature, l.signature(dataType))
+	} else {
+		info.dataType = l.text(dataType)
+		signature = append(signature, info.dataType)
+	}
+	for _, element := range column.AllColumn_definition_element() {
+		switch {
+		case element.COLLATE() != nil:
+			info.collation = l.text(element.GetCollation_name())
+			signature = append(signature, "COLLATE "+strings.ToLower(info.collation))
+		case element.DEFAULT() != nil:
+			if element.GetConstraint() != nil {
+				constraintName := originalIdentifier(element.GetConstraint())
+				definition := fmt.Sprintf("CONSTRAINT %s DEFAULT %s FOR %s", quoteIdentifier(constraintName), l.text(element.GetConstant_expr()), quoteIdentifier(name))
+				l.addConstraint(table, &constraintInfo{
+					name:       constraintName,
+					columnName: name,
+					definition: definition,
+					signature:  l.signature(element),
+				})
+			} else {
+				info.defaultText = l.text(element.GetConstant_expr())
+				info.defaultSignature = l.signature(element.GetConstant_expr())
+			}
+		case element.Column_constraint() != nil:
+			constraint := element.Column_constraint()
+			if constraint.Null_notnull() != nil {
+				info.nullability = strings.ToUpper(l.signature(constraint.Null_notnull()))
+				signature = append(signature, info.nullability)
+				continue
+			}
+			l.addColumnConstraint(table, name, constraint)
+		default:
+			signature = append(signature, l.signature(element))
+		}
+	}
+	if column.Column_index() != nil {
+		index := column.Column_index()
+		modifiers := ""
+		if index.Clustered() != nil {
+			modifiers = strings.ToUpper(l.text(index.Clustered()))
+		}
+		rest := fmt.Sprintf("(%s)", quoteIdentifier(name))
+		if index.Create_table_index_options() != nil {
+			rest = fmt.Sprintf("%s %s", rest, l.text(index.Create_table_index_options()))
+		}
+		if index.On_partition_or_filegroup() != nil {
+			rest = fmt.Sprintf("%s %s", rest, l.text(index.On_partition_or_filegroup()))
+		}
+		l.addIndex(table, originalIdentifier(index.GetIndex_name()), true /* inline */, modifiers, rest)
+	}
+	info.signature = strings.Join(signature, " ")
+	l.appendColumn(table, info)
+}
+
+func (*buildSchemaMapListener) appendColumn(table *tableInfo, column *columnInfo) {
+	column.id = len(table.columnList)
+	table.columnList = append(table.columnList, column)
+	table.columnMap[strings.ToLower(column.name)] = column
+}
+
+// addColumnConstraint converts the inline column constraint to the table constraint.
+func (l *buildSchemaMapListener) addColumnConstraint(table *tableInfo, columnName string, constraint parser.IColumn_constraintContext) {
+	var body string
+	foreignKey := false
+	switch {
+	case constraint.PRIMARY() != nil || constraint.UNIQUE() != nil:
+		keyword := "UNIQUE"
+		if constraint.PRIMARY() != nil {
+			keyword = "PRIMARY KEY"
+		}
+		if constraint.Clustered() != nil {
+			keyword = fmt.Sprintf("%s %s", keyword, strings.ToUpper(l.text(constraint.Clustered())))
+		}
+		body = fmt.Sprintf("%s (%s)", keyword, quoteIdentifier(columnName))
+		if options := l.text(constraint.Primary_key_options()); options != "" {
+			body = fmt.Sprintf("%s %s", body, options)
+		}
+	case constraint.Foreign_key_options() != nil:
+		foreignKey = true
+		body = fmt.Sprintf("FOREIGN KEY (%s) %s", quoteIdentifier(columnName), l.text(constraint.Foreign_key_options()))
+	case constraint.Check_constraint() != nil:
+		body = l.text(constraint.Check_constraint())
+	default:
+		return
+	}
+	info := &constraintInfo{
+		foreignKey: foreignKey,
+		columnName: columnName,
+		signature:  fmt.Sprintf("%s %s", strings.ToLower(columnName), l.signature(constraint)),
+	}
+	if constraint.GetConstraint() != nil {
+		info.name = originalIdentifier(constraint.GetConstraint())
+		info.definition = fmt.Sprintf("CONSTRAINT %s %s", quoteIdentifier(info.name), body)
+	} else {
+		info.unnamed = true
+		info.definition = body
+	}
+	l.addConstraint(table, info)
+}
+
+func (l *buildSchemaMapListener) addTableConstraint(table *tableInfo, constraint parser.ITable_constraintContext, standalone bool) {
+	info := &constraintInfo{
+		foreignKey: constraint.FOREIGN() != nil,
+		standalone: standalone,
+		definition: l.text(constraint),
+		signature:  l.signature(constraint),
+	}
+	if constraint.GetConstraint() != nil {
+		info.name = originalIdentifier(constraint.GetConstraint())
+	} else {
+		info.unnamed = true
+	}
+	l.addConstraint(table, info)
+}
+
+func (*buildSchemaMapListener) addConstraint(table *tableInfo, constraint *constraintInfo) {
+	constraint.id = len(table.constraintMap)
+	table.constraintMap[constraintKey(constraint)] = constraint
+}
+
+func constraintKey(constraint *constraintInfo) string {
+	if constraint.unnamed {
+		return constraint.signature
+	}
+	return strings.ToLower(constraint.name)
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+// We only collect the constraints added by ALTER TABLE statements, which are commonly used in the schema dumps.
+func (l *buildSchemaMapListener) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	if l.err != nil || ctx.ADD() == nil {
+		return
+	}
+	tableNameCtx := ctx.Table_name(0)
+	schemaName := l.schemaName(tableNameCtx.GetSchema())
+	tableName := originalIdentifier(tableNameCtx.GetTable())
+	table := l.schemaMap.getTable(schemaName, tableName)
+	if table == nil {
+		l.err = errors.Errorf("table %s not found", qualifiedName(schemaName, tableName))
+		return
+	}
+
+	if ctx.Column_def_table_constraints() != nil {
+		for _, item := range ctx.Column_def_table_constraints().AllColumn_def_table_constraint() {
+			switch {
+			case item.Table_constraint() != nil:
+				l.addTableConstraint(table, item.Table_constraint(), true /* standalone */)
+			case item.Column_definition() != nil:
+				l.addColumn(table, item.Column_definition())
+			}
+		}
+		return
+	}
+
+	// ALTER TABLE ... WITH CHECK ADD CONSTRAINT ...
+	start := ctx.ADD().GetSymbol().GetTokenIndex() + 1
+	stop := ctx.GetStop().GetTokenIndex()
+	if ctx.SEMI() != nil {
+		stop = ctx.SEMI().GetSymbol().GetTokenIndex() - 1
+	}
+	info := &constraintInfo{
+		foreignKey: ctx.FOREIGN() != nil,
+		standalone: true,
+		definition: strings.TrimSpace(l.stream.GetTextFromInterval(antlr.NewInterval(start, stop))),
+		signature:  l.signatureFromInterval(start, stop),
+	}
+	if ctx.GetConstraint() != nil {
+		info.name = originalIdentifier(ctx.GetConstraint())
+	} else {
+		info.unnamed = true
+	}
+	l.addConstraint(table, info)
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *buildSchemaMapListener) EnterCreate_index(ctx *parser.Create_indexContext) {
+	if l.err != nil {
+		return
+	}
+	schemaName := l.schemaName(ctx.Table_name().GetSchema())
+	tableName := originalIdentifier(ctx.Table_name().GetTable())
+	table := l.schemaMap.getTable(schemaName, tableName)
+	if table == nil {
+		l.err = errors.Errorf("table %s not found", qualifiedName(schemaName, tableName))
+		return
+	}
+	modifiers := ""
+	if start, stop := ctx.CREATE().GetSymbol().GetTokenIndex()+1, ctx.INDEX().GetSymbol().GetTokenIndex()-1; start <= stop {
+		modifiers = strings.ToUpper(l.signatureFromInterval(start, stop))
+	}
+	start := ctx.Table_name().GetStop().GetTokenIndex() + 1
+	stop := ctx.GetStop().GetTokenIndex()
+	if ctx.SEMI() != nil {
+		stop = ctx.SEMI().GetSymbol().GetTokenIndex() - 1
+	}
+	rest := strings.TrimSpace(l.stream.GetTextFromInterval(antlr.NewInterval(start, stop)))
+	l.addIndex(table, originalIdentifier(ctx.Id_(0)), false /* inline */, modifiers, rest)
+}
+
+// splitInlineIndex splits the inline index definition into the modifiers and the remaining part.
+func (l *buildSchemaMapListener) splitInlineIndex(ctx antlr.ParserRuleContext, name parser.IId_Context) (string, string) {
+	start := name.GetStop().GetTokenIndex() + 1
+	stop := ctx.GetStop().GetTokenIndex()
+	restStart := stop + 1
+	for i := start; i <= stop; i++ {
+		if l.stream.Get(i).GetTokenType() == parser.TSqlParserLR_BRACKET {
+			restStart = i
+			break
+		}
+	}
+	modifiers := ""
+	if start <= restStart-1 {
+		modifiers = strings.ToUpper(l.signatureFromInterval(start, restStart-1))
+	}
+	rest := ""
+	if restStart <= stop {
+		rest = strings.TrimSpace(l.stream.GetTextFromInterval(antlr.NewInterval(restStart, stop)))
+	}
+	return modifiers, rest
+}
+
+func (l *buildSchemaMapListener) addIndex(table *tableInfo, name string, inline bool, modifiers string, rest string) {
+	var buf strings.Builder
+	_, _ = buf.WriteString("CREATE ")
+	if modifiers != "" {
+		_, _ = buf.WriteString(modifiers)
+		_, _ = buf.WriteString(" ")
+	}
+	_, _ = fmt.Fprintf(&buf, "INDEX %s ON %s", quoteIdentifier(name), qualifiedName(table.schemaName, table.name))
+	if rest != "" {
+		_, _ = buf.WriteString(" ")
+		_, _ = buf.WriteString(rest)
+	}
+	_, _ = buf.WriteString(";")
+	definition := buf.String()
+	table.indexMap[strings.ToLower(name)] = &indexInfo{
+		id:         len(table.indexMap),
+		name:       name,
+		inline:     inline,
+		definition: definition,
+		signature:  normalizeSignature(modifiers + " " + rest),
+	}
+}
+
+// EnterCreate_view is called when production create_view is entered.
+func (l *buildSchemaMapListener) EnterCreate_view(ctx *parser.Create_viewContext) {
+	if l.err != nil {
+		return
+	}
+	name := ctx.Simple_name()
+	l.addModule(objectKindView, name.GetSchema(), name.GetName(), ctx, ctx.VIEW().GetSymbol())
+}
+
+// EnterCreate_or_alter_procedure is called when production create_or_alter_procedure is entered.
+func (l *buildSchemaMapListener) EnterCreate_or_alter_procedure(ctx *parser.Create_or_alter_procedureContext) {
+	if l.err != nil {
+		return
+	}
+	name := ctx.GetProcName()
+	l.addModule(objectKindProcedure, name.GetSchema(), name.GetProcedure(), ctx, ctx.GetProc())
+}
+
+// EnterCreate_or_alter_function is called when production create_or_alter_function is entered.
+func (l *buildSchemaMapListener) EnterCreate_or_alter_function(ctx *parser.Create_or_alter_functionContext) {
+	if l.err != nil {
+		return
+	}
+	name := ctx.GetFuncName()
+	l.addModule(objectKindFunction, name.GetSchema(), name.GetProcedure(), ctx, ctx.FUNCTION().GetSymbol())
+}
+
+func (l *buildSchemaMapListener) addModule(kind objectKind, schemaID parser.IId_Context, nameID parser.IId_Context, ctx antlr.ParserRuleContext, keyword antlr.Token) {
+	schema := l.schemaMap.getOrCreateSchema(l.schemaName(schemaID))
+	name := originalIdentifier(nameID)
+	key := strings.ToLower(name)
+	if _, exists := schema.moduleMap[key]; exists {
+		l.err = errors.Errorf("duplicate object %s", qualifiedName(schema.name, name))
+		return
+	}
+	start := keyword.GetTokenIndex()
+	stop := ctx.GetStop().GetTokenIndex()
+	schema.moduleMap[key] = &moduleInfo{
+		id:         l.moduleCount,
+		schemaName: schema.name,
+		name:       name,
+		kind:       kind,
+		body:       strings.TrimSpace(l.stream.GetTextFromInterval(antlr.NewInterval(start, stop))),
+		signature:  l.signatureFromInterval(start, stop),
+	}
+	l.moduleCount++
+}
+
+func (l *buildSchemaMapListener) text(ctx antlr.ParserRuleContext) string {
+	if ctx == nil || ctx.GetStart() == nil || ctx.GetStop() == nil || ctx.GetStop().GetTokenIndex() < ctx.GetStart().GetTokenIndex() {
+		return ""
+	}
+	return strings.TrimSpace(l.stream.GetTextFromInterval(antlr.NewInterval(ctx.GetStart().GetTokenIndex(), ctx.GetStop().GetTokenIndex())))
+}
+
+func (l *buildSchemaMapListener) signature(ctx antlr.ParserRuleContext) string {
+	if ctx == nil || ctx.GetStart() == nil || ctx.GetStop() == nil {
+		return ""
+	}
+	return l.signatureFromInterval(ctx.GetStart().GetTokenIndex(), ctx.GetStop().GetTokenIndex())
+}
+
+// signatureFromInterval returns the text of the tokens in the default channel separated by a single space,
+// so that the whitespaces and comments don't affect the comparison.
+func (l *buildSchemaMapListener) signatureFromInterval(start, stop int) string {
+	var tokens []string
+	for i := start; i <= stop; i++ {
+		token := l.stream.Get(i)
+		if token.GetChannel() != antlr.TokenDefaultChannel || token.GetTokenType() == parser.TSqlParserSEMI {
+			continue
+		}
+		tokens = append(tokens, token.GetText())
+	}
+	return strings.Join(tokens, " ")
+}
+
+func normalizeSignature(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func getTextFromContext(ctx antlr.ParserRuleContext) string {
+	text := strings.TrimSpace(ctx.GetStart().GetInputStream().GetTextFromInterval(antlr.NewInterval(ctx.GetStart().GetStart(), ctx.GetStop().GetStop())))
+	if !strings.HasSuffix(text, ";") {
+		text += ";"
+	}
+	return text
+}
+
+// originalIdentifier returns the identifier without the delimiters, keeping the letter case.
+func originalIdentifier(id parser.IId_Context) string {
+	if id == nil {
+		return ""
+	}
+	text := id.GetText()
+	if len(text) >= 2 && ((text[0] == '[' && text[len(text)-1] == ']') || (text[0] == '"' && text[len(text)-1] == '"')) {
+		text = text[1 : len(text)-1]
+		text = strings.ReplaceAll(text, "]]", "]")
+	}
+	return text
+}
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf("[%s]", strings.ReplaceAll(name, "]", "]]"))
+}
+
+func qualifiedName(schemaName string, name string) string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(schemaName), quoteIdentifier(name))
+}
+
+func sortedSchemas(m schemaMap) []*schemaInfo {
+	var schemas []*schemaInfo
+	for _, schema := range m {
+		schemas = append(schemas, schema)
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].id < schemas[j].id
+	})
+	return schemas
+}
+
+func sortedTables(m tableMap) []*tableInfo {
+	var tables []*tableInfo
+	for _, table := range m {
+		tables = append(tables, table)
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].id < tables[j].id
+	})
+	return tables
+}
+
+func sortedConstraints(m constraintMap) []*constraintInfo {
+	var constraints []*constraintInfo
+	for _, constraint := range m {
+		constraints = append(constraints, constraint)
+	}
+	sort.Slice(constraints, func(i, j int) bool {
+		return constraints[i].id < constraints[j].id
+	})
+	return constraints
+}
+
+func sortedIndexes(m indexMap) []*indexInfo {
+	var indexes []*indexInfo
+	for _, index := range m {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].id < indexes[j].id
+	})
+	return indexes
+}
+
+func sortedModules(m schemaMap) []*moduleInfo {
+	var modules []*moduleInfo
+	for _, schema := range m {
+		for _, module := range schema.moduleMap {
+			modules = append(modules, module)
+		}
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].id < modules[j].id
+	})
+	return modules
+}
@@ -0,0 +1,50 @@
+package tsql
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+var inListRegexp = regexp.MustCompile(`\b(in|values)(?:,? ?\(\?(?:, ?\?)*\))+`)
+
+// GetFingerprint gets the T-SQL query fingerprint.
+// The literals are replaced with question marks (?), the comments are removed, the whitespaces are collapsed
+// and the keywords and unquoted identifiers are lowercased, so the queries differing only in the literals share the fingerprint.
+func GetFingerprint(query string) (string, error) {
+	lexer := parser.NewTSqlLexer(antlr.NewInputStream(query))
+	lexerErrorListener := &base.ParseErrorListener{}
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(lexerErrorListener)
+
+	var buf strings.Builder
+	space := false
+	for _, token := range lexer.GetAllTokens() {
+		switch token.GetTokenType() {
+		case parser.TSqlLexerCOMMENT, parser.TSqlLexerLINE_COMMENT, parser.TSqlLexerSPACE:
+			space = buf.Len() > 0
+			continue
+		}
+		if space {
+			_, _ = buf.WriteString(" ")
+			space = false
+		}
+		switch token.GetTokenType() {
+		case parser.TSqlLexerSTRING, parser.TSqlLexerDECIMAL, parser.TSqlLexerFLOAT, parser.TSqlLexerREAL, parser.TSqlLexerBINARY, parser.TSqlLexerNULL_:
+			_, _ = buf.WriteString("?")
+		case parser.TSqlLexerSQUARE_BRACKET_ID, parser.TSqlLexerDOUBLE_QUOTE_ID:
+			_, _ = buf.WriteString(token.GetText())
+		default:
+			_, _ = buf.WriteString(strings.ToLower(token.GetText()))
+		}
+	}
+	if lexerErrorListener.Err != nil {
+		return "", lexerErrorListener.Err
+	}
+	fingerprint := strings.TrimRight(buf.String(), " ;")
+	return inListRegexp.ReplaceAllString(fingerprint, "$1(?+)"), nil
+}
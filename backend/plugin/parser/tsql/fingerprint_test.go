@@ -0,0 +1,38 @@
+package tsql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTSQLFingerprint(t *testing.T) {
+	tests := []struct {
+		stmt string
+		want string
+	}{
+		{
+			stmt: "-- comment\nSELECT TOP 10 * FROM [dbo].[MyTable] WHERE name = N'O''Brien' AND id > 5",
+			want: "select top ? * from [dbo].[MyTable] where name = ? and id > ?",
+		},
+		{
+			stmt: "select * from \"T\" where id in (1, 2,3) and flag = 0x1F /* comment */ and v is null;",
+			want: `select * from "T" where id in(?+) and flag = ? and v is ?`,
+		},
+		{
+			stmt: "INSERT INTO t (a, b) VALUES (1.5, 'x'), (2, 'y')",
+			want: "insert into t (a, b) values(?+)",
+		},
+		{
+			stmt: "EXEC sp_who @p1 = 'x'",
+			want: "exec sp_who @p1 = ?",
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := GetFingerprint(test.stmt)
+		a.NoError(err)
+		a.Equal(test.want, got, test.stmt)
+	}
+}
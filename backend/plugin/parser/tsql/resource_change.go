@@ -0,0 +1,131 @@
+package tsql
+
+import (
+	"sort"
+
+	"github.com/antlr4-go/antlr/v4"
+	parser "github.com/bytebase/tsql-parser"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func init() {
+	base.RegisterExtractChangedResourcesFunc(storepb.Engine_MSSQL, extractChangedResources)
+}
+
+func extractChangedResources(currentDatabase string, currentSchema string, statement string) ([]base.SchemaResource, error) {
+	tree, err := ParseTSQL(statement)
+	if err != nil {
+		return nil, err
+	}
+	if currentSchema == "" {
+		currentSchema = "dbo"
+	}
+
+	l := &resourceChangedListener{
+		currentDatabase: currentDatabase,
+		currentSchema:   currentSchema,
+		resourceMap:     make(map[string]base.SchemaResource),
+	}
+
+	var result []base.SchemaResource
+	antlr.ParseTreeWalkerDefault.Walk(l, tree.Tree)
+	for _, resource := range l.resourceMap {
+		result = append(result, resource)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result, nil
+}
+
+type resourceChangedListener struct {
+	*parser.BaseTSqlParserListener
+
+	currentDatabase string
+	currentSchema   string
+	resourceMap     map[string]base.SchemaResource
+}
+
+func (l *resourceChangedListener) addTable(database, schema, table parser.IId_Context) {
+	resource := base.SchemaResource{
+		Database: l.currentDatabase,
+		Schema:   l.currentSchema,
+		Table:    NormalizeTSQLIdentifier(table),
+	}
+	if resource.Table == "" {
+		return
+	}
+	if id := NormalizeTSQLIdentifier(database); id != "" {
+		resource.Database = id
+	}
+	if id := NormalizeTSQLIdentifier(schema); id != "" {
+		resource.Schema = id
+	}
+	l.resourceMap[resource.String()] = resource
+}
+
+func (l *resourceChangedListener) addTableName(ctx parser.ITable_nameContext) {
+	if ctx == nil {
+		return
+	}
+	l.addTable(ctx.GetDatabase(), ctx.GetSchema(), ctx.GetTable())
+}
+
+func (l *resourceChangedListener) addDDLObject(ctx parser.IDdl_objectContext) {
+	// The table variable such as @t is not a database resource.
+	if ctx == nil || ctx.Full_table_name() == nil {
+		return
+	}
+	name := ctx.Full_table_name()
+	// The linked server table belongs to another server.
+	if name.GetLinkedServer() != nil || name.GetServer() != nil {
+		return
+	}
+	l.addTable(name.GetDatabase(), name.GetSchema(), name.GetTable())
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *resourceChangedListener) EnterCreate_table(ctx *parser.Create_tableContext) {
+	l.addTableName(ctx.Table_name())
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+func (l *resourceChangedListener) EnterAlter_table(ctx *parser.Alter_tableContext) {
+	// The second table name is the referenced table of the foreign key, which is not changed.
+	l.addTableName(ctx.Table_name(0))
+}
+
+// EnterDrop_table is called when production drop_table is entered.
+func (l *resourceChangedListener) EnterDrop_table(ctx *parser.Drop_tableContext) {
+	for _, table := range ctx.AllTable_name() {
+		l.addTableName(table)
+	}
+}
+
+// EnterTruncate_table is called when production truncate_table is entered.
+func (l *resourceChangedListener) EnterTruncate_table(ctx *parser.Truncate_tableContext) {
+	l.addTableName(ctx.Table_name())
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *resourceChangedListener) EnterCreate_index(ctx *parser.Create_indexContext) {
+	l.addTableName(ctx.Table_name())
+}
+
+// EnterInsert_statement is called when production insert_statement is entered.
+func (l *resourceChangedListener) EnterInsert_statement(ctx *parser.Insert_statementContext) {
+	l.addDDLObject(ctx.Ddl_object())
+}
+
+// EnterUpdate_statement is called when production update_statement is entered.
+func (l *resourceChangedListener) EnterUpdate_statement(ctx *parser.Update_statementContext) {
+	l.addDDLObject(ctx.Ddl_object())
+}
+
+// EnterDelete_statement is called when production delete_statement is entered.
+func (l *resourceChangedListener) EnterDelete_statement(ctx *parser.Delete_statementContext) {
+	l.addDDLObject(ctx.Delete_statement_from().Ddl_object())
+}
@@ -0,0 +1,42 @@
+package tsql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/parser/base"
+)
+
+func TestExtractChangedResources(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      []base.SchemaResource
+	}{
+		{
+			statement: `CREATE TABLE t1 (id INT); ALTER TABLE [S1].[T2] ADD CONSTRAINT fk FOREIGN KEY (id) REFERENCES t3 (id); DROP TABLE db2..t4, t5; CREATE INDEX idx ON t6 (id);`,
+			want: []base.SchemaResource{
+				{Database: "db", Schema: "dbo", Table: "t1"},
+				{Database: "db", Schema: "dbo", Table: "t5"},
+				{Database: "db", Schema: "dbo", Table: "t6"},
+				{Database: "db", Schema: "s1", Table: "t2"},
+				{Database: "db2", Schema: "dbo", Table: "t4"},
+			},
+		},
+		{
+			statement: `INSERT INTO t1 SELECT * FROM t2; UPDATE s1.t3 SET a = 1; DELETE FROM t4 WHERE id IN (SELECT id FROM t5); DECLARE @t TABLE (id INT); INSERT INTO @t VALUES (1); SELECT * FROM t6;`,
+			want: []base.SchemaResource{
+				{Database: "db", Schema: "dbo", Table: "t1"},
+				{Database: "db", Schema: "dbo", Table: "t4"},
+				{Database: "db", Schema: "s1", Table: "t3"},
+			},
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := extractChangedResources("db", "", test.statement)
+		a.NoError(err, test.statement)
+		a.Equal(test.want, got, test.statement)
+	}
+}
@@ -2,31 +2,56 @@ package webhook
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/pkg/errors"
 )
 
+const (
+	// SignatureHeader is the header carrying the HMAC-SHA256 signature of the custom webhook request body.
+	SignatureHeader = "X-Bytebase-Signature-256"
+	// DeliveryHeader is the header carrying the delivery ID of the custom webhook request.
+	DeliveryHeader = "X-Bytebase-Delivery"
+)
+
 // CustomWebhookResponse is the API message for Custom webhook response.
 type CustomWebhookResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
+// CustomWebhookPayloadVersion is the version of the custom webhook request payload.
+// Fields are only added within a version, the version is bumped for breaking changes.
+const CustomWebhookPayloadVersion = "v1"
+
 // CustomWebhookRequest is the API message for Custom webhook request.
+// The activity type identifies the event, and the typed objects related to the event are set, the others are omitted.
 type CustomWebhookRequest struct {
-	Level        Level    `json:"level"`
-	ActivityType string   `json:"activity_type"`
-	Title        string   `json:"title"`
-	Description  string   `json:"description"`
-	Link         string   `json:"link"`
-	CreatorID    int      `json:"creator_id"`
-	CreatorName  string   `json:"creator_name"`
-	CreatedTS    int64    `json:"created_ts"`
-	Issue        *Issue   `json:"issue"`
-	Project      *Project `json:"project"`
+	Version      string      `json:"version"`
+	Level        Level       `json:"level"`
+	ActivityType string      `json:"activity_type"`
+	Title        string      `json:"title"`
+	Description  string      `json:"description"`
+	Link         string      `json:"link"`
+	CreatorID    int         `json:"creator_id"`
+	CreatorName  string      `json:"creator_name"`
+	CreatorEmail string      `json:"creator_email"`
+	CreatedTS    int64       `json:"created_ts"`
+	Issue        *Issue      `json:"issue"`
+	Project      *Project    `json:"project"`
+	Task         *TaskResult `json:"task,omitempty"`
+	Instance     *Instance   `json:"instance,omitempty"`
+	Database     *Database   `json:"database,omitempty"`
+	Anomaly      *Anomaly    `json:"anomaly,omitempty"`
+	Policy       *Policy     `json:"policy,omitempty"`
+	User         *User       `json:"user,omitempty"`
+	Export       *Export     `json:"export,omitempty"`
 }
 
 func init() {
@@ -37,8 +62,8 @@ func init() {
 type CustomReceiver struct{}
 
 func (*CustomReceiver) post(context Context) error {
-	// TODO(p0ny): handle context.Task
 	payload := CustomWebhookRequest{
+		Version:      CustomWebhookPayloadVersion,
 		Level:        context.Level,
 		ActivityType: context.ActivityType,
 		Title:        context.Title,
@@ -46,9 +71,17 @@ func (*CustomReceiver) post(context Context) error {
 		Link:         context.Link,
 		CreatorID:    context.CreatorID,
 		CreatorName:  context.CreatorName,
+		CreatorEmail: context.CreatorEmail,
 		CreatedTS:    context.CreatedTs,
 		Issue:        context.Issue,
 		Project:      context.Project,
+		Task:         context.TaskResult,
+		Instance:     context.Instance,
+		Database:     context.Database,
+		Anomaly:      context.Anomaly,
+		Policy:       context.Policy,
+		User:         context.User,
+		Export:       context.Export,
 	}
 
 	body, err := json.Marshal(&payload)
@@ -62,9 +95,13 @@ func (*CustomReceiver) post(context Context) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	client := &http.Client{
-		Timeout: timeout,
+	if context.DeliveryID != 0 {
+		req.Header.Set(DeliveryHeader, strconv.Itoa(context.DeliveryID))
 	}
+	if context.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(context.Secret, body))
+	}
+	client := context.newHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return errors.Wrapf(err, "failed to POST webhook to %s", context.URL)
@@ -91,3 +128,11 @@ func (*CustomReceiver) post(context Context) error {
 
 	return nil
 }
+
+// Sign returns the signature of the body with the secret in the form of "sha256=<hex encoded HMAC-SHA256>".
+// Receivers should compute the same signature from the raw request body and compare it with the SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,176 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		secret string
+		body   string
+		want   string
+	}{
+		{
+			secret: "key",
+			body:   "The quick brown fox jumps over the lazy dog",
+			want:   "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		},
+		{
+			secret: "",
+			body:   "",
+			want:   "sha256=b613679a0814d9ec772f95d778c35fc5ff1697c493715653c6c712144292c5ad",
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, Sign(test.secret, []byte(test.body)))
+	}
+}
+
+func TestCustomReceiverPost(t *testing.T) {
+	tests := []struct {
+		secret         string
+		deliveryID     int
+		responseStatus int
+		wantSignature  bool
+		wantErr        bool
+	}{
+		{
+			secret:         "secret",
+			deliveryID:     101,
+			responseStatus: http.StatusOK,
+			wantSignature:  true,
+		},
+		{
+			responseStatus: http.StatusOK,
+		},
+		{
+			secret:         "secret",
+			deliveryID:     102,
+			responseStatus: http.StatusInternalServerError,
+			wantSignature:  true,
+			wantErr:        true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		var gotSignature, gotDelivery, wantSignature string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			a.NoError(err)
+			gotSignature = r.Header.Get(SignatureHeader)
+			gotDelivery = r.Header.Get(DeliveryHeader)
+			wantSignature = Sign(test.secret, body)
+			w.WriteHeader(test.responseStatus)
+			_, _ = w.Write([]byte(`{"code":0}`))
+		}))
+
+		statusCode, err := Post("bb.plugin.webhook.custom", Context{
+			URL:        server.URL,
+			Title:      "title",
+			DeliveryID: test.deliveryID,
+			Secret:     test.secret,
+		})
+		server.Close()
+
+		a.Equal(test.responseStatus, statusCode)
+		if test.wantErr {
+			a.Error(err)
+		} else {
+			a.NoError(err)
+		}
+		if test.wantSignature {
+			a.Equal(wantSignature, gotSignature)
+		} else {
+			a.Empty(gotSignature)
+		}
+		if test.deliveryID != 0 {
+			a.NotEmpty(gotDelivery)
+		} else {
+			a.Empty(gotDelivery)
+		}
+	}
+}
+
+func TestCustomReceiverPayload(t *testing.T) {
+	tests := []struct {
+		context Context
+		want    map[string]any
+		absent  []string
+	}{
+		{
+			context: Context{
+				ActivityType: "bb.pipeline.taskrun.status.update",
+				TaskResult: &TaskResult{
+					Name:   "task name",
+					Status: "FAILED",
+					Detail: "SQL STATE",
+				},
+			},
+			want: map[string]any{
+				"version":       CustomWebhookPayloadVersion,
+				"activity_type": "bb.pipeline.taskrun.status.update",
+				"task": map[string]any{
+					"name":          "task name",
+					"status":        "FAILED",
+					"detail":        "SQL STATE",
+					"skippedReason": "",
+				},
+			},
+			absent: []string{"instance", "database", "anomaly", "policy", "user", "export"},
+		},
+		{
+			context: Context{
+				ActivityType: "bb.instance.create",
+				Instance: &Instance{
+					ID:          "prod-instance",
+					Name:        "Prod",
+					Engine:      "POSTGRES",
+					Environment: "prod",
+				},
+			},
+			want: map[string]any{
+				"version":       CustomWebhookPayloadVersion,
+				"activity_type": "bb.instance.create",
+				"instance": map[string]any{
+					"id":          "prod-instance",
+					"name":        "Prod",
+					"engine":      "POSTGRES",
+					"environment": "prod",
+				},
+			},
+			absent: []string{"task", "database", "anomaly", "policy", "user", "export"},
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		var got map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			a.NoError(err)
+			a.NoError(json.Unmarshal(body, &got))
+			_, _ = w.Write([]byte(`{"code":0}`))
+		}))
+
+		test.context.URL = server.URL
+		_, err := Post("bb.plugin.webhook.custom", test.context)
+		server.Close()
+		a.NoError(err)
+
+		for key, value := range test.want {
+			a.Equal(value, got[key], key)
+		}
+		for _, key := range test.absent {
+			a.NotContains(got, key)
+		}
+	}
+}
@@ -2,6 +2,8 @@
 package webhook
 
 import (
+	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
@@ -67,6 +69,61 @@ type Approval struct {
 	MentionUsersByPhone []string
 }
 
+// Instance object of instance.
+type Instance struct {
+	// ID is the resource ID of the instance.
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Engine      string `json:"engine"`
+	Environment string `json:"environment"`
+}
+
+// Database object of database.
+type Database struct {
+	Name string `json:"name"`
+	// Instance is the resource ID of the instance.
+	Instance string `json:"instance"`
+	// Project is the resource ID of the project.
+	Project string `json:"project"`
+}
+
+// Anomaly object of anomaly.
+type Anomaly struct {
+	Type string `json:"type"`
+	// Instance is the resource ID of the instance.
+	Instance string `json:"instance"`
+	// Database is empty for the instance anomalies.
+	Database string `json:"database"`
+	Detail   string `json:"detail"`
+}
+
+// Policy object of policy.
+type Policy struct {
+	// Name is the resource name of the policy, e.g. environments/prod/policies/backup_plan.
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Enforce bool   `json:"enforce"`
+}
+
+// User object of user.
+type User struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// Export object of SQL export.
+type Export struct {
+	// Instance is the resource ID of the instance.
+	Instance  string `json:"instance"`
+	Database  string `json:"database"`
+	Statement string `json:"statement"`
+	Format    string `json:"format"`
+	// Error is empty if the export succeeded.
+	Error string `json:"error"`
+}
+
 // Context is the context of webhook.
 type Context struct {
 	URL          string
@@ -83,6 +140,44 @@ type Context struct {
 	Project      *Project
 	TaskResult   *TaskResult
 	Approval     *Approval
+	Instance     *Instance
+	Database     *Database
+	Anomaly      *Anomaly
+	Policy       *Policy
+	User         *User
+	Export       *Export
+	// DeliveryID is the ID of the webhook delivery, sent in the delivery header by the custom receiver.
+	DeliveryID int `json:"-"`
+	// Secret is the signing secret of the webhook. The custom receiver signs the request body with it if not empty.
+	Secret string `json:"-"`
+
+	// recorder records the status code of the last response.
+	recorder *statusRecorder
+}
+
+// statusRecorder is the http.RoundTripper recording the status code of the last response.
+type statusRecorder struct {
+	transport  http.RoundTripper
+	statusCode int
+}
+
+func (r *statusRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if resp != nil {
+		r.statusCode = resp.StatusCode
+	}
+	return resp, err
+}
+
+// newHTTPClient returns the http client to post the webhook.
+func (c *Context) newHTTPClient() *http.Client {
+	client := &http.Client{
+		Timeout: timeout,
+	}
+	if c.recorder != nil {
+		client.Transport = c.recorder
+	}
+	return client
 }
 
 // Receiver is the webhook receiver.
@@ -138,6 +233,60 @@ func (c *Context) getMetaList() []meta {
 		}
 	}
 
+	if c.Instance != nil {
+		m = append(m, meta{
+			Name:  "Instance",
+			Value: c.Instance.Name,
+		})
+	}
+
+	if c.Database != nil {
+		m = append(m, meta{
+			Name:  "Database",
+			Value: fmt.Sprintf("%s/%s", c.Database.Instance, c.Database.Name),
+		})
+	}
+
+	if c.Anomaly != nil {
+		m = append(m, meta{
+			Name:  "Anomaly",
+			Value: c.Anomaly.Type,
+		})
+		if c.Anomaly.Detail != "" {
+			m = append(m, meta{
+				Name:  "Anomaly Detail",
+				Value: common.TruncateStringWithDescription(c.Anomaly.Detail),
+			})
+		}
+	}
+
+	if c.Policy != nil {
+		m = append(m, meta{
+			Name:  "Policy",
+			Value: c.Policy.Name,
+		})
+	}
+
+	if c.User != nil {
+		m = append(m, meta{
+			Name:  "User",
+			Value: c.User.Email,
+		})
+	}
+
+	if c.Export != nil {
+		m = append(m, meta{
+			Name:  "Statement",
+			Value: common.TruncateStringWithDescription(c.Export.Statement),
+		})
+		if c.Export.Error != "" {
+			m = append(m, meta{
+				Name:  "Export Error",
+				Value: common.TruncateStringWithDescription(c.Export.Error),
+			})
+		}
+	}
+
 	return m
 }
 
@@ -157,12 +306,15 @@ func register(host string, r Receiver) {
 }
 
 // Post posts the message to webhook.
-func Post(webhookType string, context Context) error {
+// It returns the HTTP status code of the response, or 0 if no response is received.
+func Post(webhookType string, context Context) (int, error) {
 	receiverMu.RLock()
 	r, ok := receivers[webhookType]
 	receiverMu.RUnlock()
 	if !ok {
-		return errors.Errorf("webhook: no applicable receiver for webhook type: %v", webhookType)
+		return 0, errors.Errorf("webhook: no applicable receiver for webhook type: %v", webhookType)
 	}
-	return r.post(context)
+	context.recorder = &statusRecorder{transport: http.DefaultTransport}
+	err := r.post(context)
+	return context.recorder.statusCode, err
 }
@@ -121,4 +121,30 @@ func TestContext_getMetaList(t *testing.T) {
 		}
 		a.Equal(want, context.getMetaList())
 	})
+	t.Run("anomaly", func(t *testing.T) {
+		a := require.New(t)
+		context := Context{
+			Database: &Database{
+				Name:     "db",
+				Instance: "prod-instance",
+				Project:  "default",
+			},
+			Anomaly: &Anomaly{
+				Type:     "bb.anomaly.database.schema.drift",
+				Instance: "prod-instance",
+				Database: "db",
+			},
+		}
+		want := []meta{
+			{
+				Name:  "Database",
+				Value: "prod-instance/db",
+			},
+			{
+				Name:  "Anomaly",
+				Value: "bb.anomaly.database.schema.drift",
+			},
+		}
+		a.Equal(want, context.getMetaList())
+	})
 }
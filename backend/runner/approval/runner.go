@@ -58,12 +58,19 @@ func NewRunner(store *store.Store, dbFactory *dbfactory.DBFactory, stateCfg *sta
 	}
 }
 
-const approvalRunnerInterval = 1 * time.Second
+const (
+	approvalRunnerInterval = 1 * time.Second
+	// retryFindApprovalTemplateInterval is the interval to pick up the issues waiting for the approval template from the database,
+	// e.g. the issues created on another replica.
+	retryFindApprovalTemplateInterval = 10 * time.Second
+)
 
 // Run runs the runner.
 func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
 	ticker := time.NewTicker(approvalRunnerInterval)
 	defer ticker.Stop()
+	retryTicker := time.NewTicker(retryFindApprovalTemplateInterval)
+	defer retryTicker.Stop()
 	defer wg.Done()
 	slog.Debug(fmt.Sprintf("Approval runner started and will run every %v", approvalRunnerInterval))
 	r.retryFindApprovalTemplate(ctx)
@@ -71,6 +78,8 @@ func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
 		select {
 		case <-ticker.C:
 			r.runOnce(ctx)
+		case <-retryTicker.C:
+			r.retryFindApprovalTemplate(ctx)
 		case <-ctx.Done():
 			return
 		}
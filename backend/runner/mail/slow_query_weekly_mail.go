@@ -416,6 +416,12 @@ func engineTypeString(engine storepb.Engine) string {
 		return "MySQL"
 	case storepb.Engine_POSTGRES:
 		return "Postgres"
+	case storepb.Engine_TIDB:
+		return "TiDB"
+	case storepb.Engine_MSSQL:
+		return "SQL Server"
+	case storepb.Engine_ORACLE:
+		return "Oracle"
 	}
 	return ""
 }
@@ -698,6 +704,12 @@ func engineOrder(engine storepb.Engine) int {
 		return 1
 	case storepb.Engine_POSTGRES:
 		return 2
+	case storepb.Engine_TIDB:
+		return 3
+	case storepb.Engine_MSSQL:
+		return 4
+	case storepb.Engine_ORACLE:
+		return 5
 	default:
 		return 100
 	}
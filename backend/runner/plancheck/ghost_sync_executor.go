@@ -2,12 +2,16 @@ package plancheck
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
+	"strings"
 
 	"github.com/github/gh-ost/go/logic"
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/pgosc"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
@@ -15,17 +19,20 @@ import (
 )
 
 // NewGhostSyncExecutor creates a gh-ost sync check executor.
-func NewGhostSyncExecutor(store *store.Store, secret string) Executor {
+func NewGhostSyncExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, secret string) Executor {
 	return &GhostSyncExecutor{
-		store:  store,
-		secret: secret,
+		store:     store,
+		dbFactory: dbFactory,
+		secret:    secret,
 	}
 }
 
 // GhostSyncExecutor is the gh-ost sync check executor.
+// For PostgreSQL, it checks whether the table supports the online schema change.
 type GhostSyncExecutor struct {
-	store  *store.Store
-	secret string
+	store     *store.Store
+	dbFactory *dbfactory.DBFactory
+	secret    string
 }
 
 // Run runs the gh-ost sync check executor.
@@ -101,6 +108,10 @@ func (e *GhostSyncExecutor) Run(ctx context.Context, config *storepb.PlanCheckRu
 	// To avoid leaking the rendered statement, the error message should use the original statement and not the rendered statement.
 	renderedStatement := utils.RenderStatement(statement, materials)
 
+	if instance.Engine == storepb.Engine_POSTGRES {
+		return e.checkPostgresOnlineMigration(ctx, instance, database, renderedStatement)
+	}
+
 	tableName, err := utils.GetTableNameFromStatement(renderedStatement)
 	if err != nil {
 		return nil, common.Wrapf(err, common.Internal, "failed to parse table name from statement, statement: %v", statement)
@@ -140,3 +151,37 @@ func (e *GhostSyncExecutor) Run(ctx context.Context, config *storepb.PlanCheckRu
 		},
 	}, nil
 }
+
+func (e *GhostSyncExecutor) checkPostgresOnlineMigration(ctx context.Context, instance *store.InstanceMessage, database *store.DatabaseMessage, statement string) ([]*storepb.PlanCheckRunResult_Result, error) {
+	driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return nil, err
+	}
+	defer driver.Close(ctx)
+
+	migration, err := pgosc.NewMigration(ctx, driver.GetDB(), strings.TrimSpace(statement))
+	if err == nil {
+		err = migration.Check(ctx)
+	}
+	if err != nil {
+		return []*storepb.PlanCheckRunResult_Result{
+			{
+				Status:  storepb.PlanCheckRunResult_Result_ERROR,
+				Title:   "Online schema migration check failed",
+				Content: err.Error(),
+				Code:    common.Internal.Int64(),
+				Report:  nil,
+			},
+		}, nil
+	}
+
+	return []*storepb.PlanCheckRunResult_Result{
+		{
+			Status:  storepb.PlanCheckRunResult_Result_SUCCESS,
+			Title:   "OK",
+			Content: fmt.Sprintf("table %s supports the online schema migration", migration.Table()),
+			Code:    common.Ok.Int64(),
+			Report:  nil,
+		},
+	}, nil
+}
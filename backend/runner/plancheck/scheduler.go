@@ -14,6 +14,7 @@ import (
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/metric"
 	"github.com/bytebase/bytebase/backend/store"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -123,11 +124,14 @@ func (s *Scheduler) runPlanCheckRun(ctx context.Context, planCheckRun *store.Pla
 			s.stateCfg.InstanceOutstandingConnections[instanceUID]--
 			s.stateCfg.Unlock()
 		}()
+		start := time.Now()
 		results, err := runExecutorOnce(ctx, executor, planCheckRun.Config)
 		if err != nil {
+			metric.ObservePlanCheck(string(planCheckRun.Type), string(store.PlanCheckRunStatusFailed), time.Since(start))
 			s.markPlanCheckRunFailed(ctx, planCheckRun, err.Error())
 			return
 		}
+		metric.ObservePlanCheck(string(planCheckRun.Type), string(store.PlanCheckRunStatusDone), time.Since(start))
 		s.markPlanCheckRunDone(ctx, planCheckRun, results)
 	}()
 }
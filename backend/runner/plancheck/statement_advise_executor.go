@@ -161,12 +161,14 @@ func (e *StatementAdviseExecutor) runForDatabaseTarget(ctx context.Context, conf
 	// To avoid leaking the rendered statement, the error message should use the original statement and not the rendered statement.
 	renderedStatement := utils.RenderStatement(statement, materials)
 	adviceList, err := advisor.SQLReviewCheck(renderedStatement, policy.RuleList, advisor.SQLReviewCheckContext{
-		Charset:   dbSchema.Metadata.CharacterSet,
-		Collation: dbSchema.Metadata.Collation,
-		DbType:    instance.Engine,
-		Catalog:   catalog,
-		Driver:    connection,
-		Context:   ctx,
+		Charset:         dbSchema.Metadata.CharacterSet,
+		Collation:       dbSchema.Metadata.Collation,
+		DbType:          instance.Engine,
+		Catalog:         catalog,
+		Driver:          connection,
+		Context:         ctx,
+		CurrentSchema:   getCurrentSchema(instance, database),
+		CurrentDatabase: database.DatabaseName,
 	})
 	if err != nil {
 		return nil, err
@@ -373,12 +375,14 @@ func (e *StatementAdviseExecutor) runForDatabaseGroupTarget(ctx context.Context,
 				// To avoid leaking the rendered statement, the error message should use the original statement and not the rendered statement.
 				renderedStatement := utils.RenderStatement(statement, materials)
 				adviceList, err := advisor.SQLReviewCheck(renderedStatement, policy.RuleList, advisor.SQLReviewCheckContext{
-					Charset:   dbSchema.Metadata.CharacterSet,
-					Collation: dbSchema.Metadata.Collation,
-					DbType:    instance.Engine,
-					Catalog:   catalog,
-					Driver:    connection,
-					Context:   ctx,
+					Charset:         dbSchema.Metadata.CharacterSet,
+					Collation:       dbSchema.Metadata.Collation,
+					DbType:          instance.Engine,
+					Catalog:         catalog,
+					Driver:          connection,
+					Context:         ctx,
+					CurrentSchema:   getCurrentSchema(instance, db),
+					CurrentDatabase: db.DatabaseName,
 				})
 				if err != nil {
 					return nil, err
@@ -448,3 +452,18 @@ func getSyntaxMode(t storepb.PlanCheckRunConfig_ChangeDatabaseType) advisor.Synt
 	}
 	return advisor.SyntaxModeNormal
 }
+
+// getCurrentSchema returns the schema of the unqualified object names in the statements.
+// The statements are executed by the admin data source, so the current schema is its user for Oracle and DM.
+func getCurrentSchema(instance *store.InstanceMessage, database *store.DatabaseMessage) string {
+	if instance.Engine != storepb.Engine_ORACLE && instance.Engine != storepb.Engine_DM {
+		return ""
+	}
+	if instance.Options != nil && instance.Options.SchemaTenantMode {
+		return database.DatabaseName
+	}
+	if dataSource := utils.DataSourceFromInstanceWithType(instance, api.Admin); dataSource != nil {
+		return dataSource.Username
+	}
+	return ""
+}
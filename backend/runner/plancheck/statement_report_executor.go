@@ -4,7 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"strings"
+	"sort"
 
 	"log/slog"
 
@@ -127,7 +127,7 @@ func (e *StatementReportExecutor) runForDatabaseTarget(ctx context.Context, conf
 		sqlDB := driver.GetDB()
 
 		return reportForPostgres(ctx, sqlDB, database.DatabaseName, renderedStatement, dbSchema.Metadata)
-	case storepb.Engine_MYSQL, storepb.Engine_OCEANBASE:
+	case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_OCEANBASE:
 		driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
 		if err != nil {
 			return nil, err
@@ -145,6 +145,8 @@ func (e *StatementReportExecutor) runForDatabaseTarget(ctx context.Context, conf
 			schema = database.DatabaseName
 		}
 		return reportForOracle(database.DatabaseName, schema, renderedStatement)
+	case storepb.Engine_MSSQL, storepb.Engine_SNOWFLAKE:
+		return reportForChangedResources(instance.Engine, database.DatabaseName, renderedStatement)
 	default:
 		return []*storepb.PlanCheckRunResult_Result{
 			{
@@ -284,7 +286,7 @@ func (e *StatementReportExecutor) runForDatabaseGroupTarget(ctx context.Context,
 					sqlDB := driver.GetDB()
 
 					return reportForPostgres(ctx, sqlDB, database.DatabaseName, renderedStatement, dbSchema.Metadata)
-				case storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE:
+				case storepb.Engine_MYSQL, storepb.Engine_MARIADB, storepb.Engine_TIDB, storepb.Engine_OCEANBASE:
 					driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
 					if err != nil {
 						return nil, err
@@ -302,6 +304,8 @@ func (e *StatementReportExecutor) runForDatabaseGroupTarget(ctx context.Context,
 						schema = database.DatabaseName
 					}
 					return reportForOracle(database.DatabaseName, schema, renderedStatement)
+				case storepb.Engine_MSSQL, storepb.Engine_SNOWFLAKE:
+					return reportForChangedResources(instance.Engine, database.DatabaseName, renderedStatement)
 				default:
 					return nil, nil
 				}
@@ -384,6 +388,43 @@ func reportForOracle(databaseName string, schemaName string, statement string) (
 	}, nil
 }
 
+// reportForChangedResources reports the changed resources only, for the engines that we cannot get the affected rows.
+// The resources are resolved in the default schema of the engine.
+func reportForChangedResources(engine storepb.Engine, databaseName string, statement string) ([]*storepb.PlanCheckRunResult_Result, error) {
+	changedResources, err := base.ExtractChangedResources(engine, databaseName, "" /* currentSchema */, statement)
+	if err != nil {
+		// nolint:nilerr
+		return []*storepb.PlanCheckRunResult_Result{
+			{
+				Status:  storepb.PlanCheckRunResult_Result_ERROR,
+				Title:   "Syntax error",
+				Content: err.Error(),
+				Code:    0,
+				Report: &storepb.PlanCheckRunResult_Result_SqlSummaryReport_{
+					SqlSummaryReport: &storepb.PlanCheckRunResult_Result_SqlSummaryReport{
+						Code: advisor.StatementSyntaxError.Int64(),
+					},
+				},
+			},
+		}, nil
+	}
+
+	return []*storepb.PlanCheckRunResult_Result{
+		{
+			Status: storepb.PlanCheckRunResult_Result_SUCCESS,
+			Code:   common.Ok.Int64(),
+			Title:  "OK",
+			Report: &storepb.PlanCheckRunResult_Result_SqlSummaryReport_{
+				SqlSummaryReport: &storepb.PlanCheckRunResult_Result_SqlSummaryReport{
+					StatementTypes:   nil,
+					AffectedRows:     0,
+					ChangedResources: convertToChangedResources(changedResources),
+				},
+			},
+		},
+	}, nil
+}
+
 func reportForMySQL(ctx context.Context, sqlDB *sql.DB, engine storepb.Engine, databaseName string, statement string, dbMetadata *storepb.DatabaseSchemaMetadata) ([]*storepb.PlanCheckRunResult_Result, error) {
 	charset := dbMetadata.CharacterSet
 	collation := dbMetadata.Collation
@@ -430,16 +471,17 @@ func reportForMySQL(ctx context.Context, sqlDB *sql.DB, engine storepb.Engine, d
 			slog.Debug("failed to parse statement, expect to get one node from parser", slog.String("statement", stmt.Text))
 			continue
 		}
-		sqlType, resources := getStatementTypeFromTidbAstNode(strings.ToLower(databaseName), root[0])
+		sqlType := getStatementTypeFromTidbAstNode(root[0])
 		sqlTypeSet[sqlType] = struct{}{}
-		if !isDML(sqlType) {
-			if engine != storepb.Engine_TIDB {
-				resources, err := base.ExtractChangedResources(storepb.Engine_MYSQL, databaseName, "" /* currentSchema */, stmt.Text)
-				if err != nil {
-					slog.Error("failed to get statement changed resources", log.BBError(err))
-				} else {
-					changedResources = append(changedResources, resources...)
-				}
+		// The TiDB extractor covers the DML statements, the MySQL one only covers the DDL statements.
+		if engine == storepb.Engine_TIDB || !isDML(sqlType) {
+			resourceEngine := storepb.Engine_MYSQL
+			if engine == storepb.Engine_TIDB {
+				resourceEngine = storepb.Engine_TIDB
+			}
+			resources, err := base.ExtractChangedResources(resourceEngine, databaseName, "" /* currentSchema */, stmt.Text)
+			if err != nil {
+				slog.Error("failed to get statement changed resources", log.BBError(err))
 			} else {
 				changedResources = append(changedResources, resources...)
 			}
@@ -485,8 +527,20 @@ func isDML(tp string) bool {
 
 func convertToChangedResources(resources []base.SchemaResource) *storepb.ChangedResources {
 	meta := &storepb.ChangedResources{}
-	// resources is ordered by (db, schema, table)
-	for _, resource := range resources {
+	// The resources are collected statement by statement, order them by (db, schema, table) and remove the duplicates.
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Database != resources[j].Database {
+			return resources[i].Database < resources[j].Database
+		}
+		if resources[i].Schema != resources[j].Schema {
+			return resources[i].Schema < resources[j].Schema
+		}
+		return resources[i].Table < resources[j].Table
+	})
+	for i, resource := range resources {
+		if i > 0 && resources[i-1] == resource {
+			continue
+		}
 		if len(meta.Databases) == 0 || meta.Databases[len(meta.Databases)-1].Name != resource.Database {
 			meta.Databases = append(meta.Databases, &storepb.ChangedResourceDatabase{Name: resource.Database})
 		}
@@ -696,111 +750,65 @@ func convertColumnName(node *pgquery.Node_List) (string, string, string, error)
 	}
 }
 
-func getStatementTypeFromTidbAstNode(database string, node tidbast.StmtNode) (string, []base.SchemaResource) {
-	var result []base.SchemaResource
+func getStatementTypeFromTidbAstNode(node tidbast.StmtNode) string {
 	switch n := node.(type) {
 	// DDL
 
 	// CREATE
 	case *tidbast.CreateDatabaseStmt:
-		return "CREATE_DATABASE", result
+		return "CREATE_DATABASE"
 	case *tidbast.CreateIndexStmt:
-		return "CREATE_INDEX", result
+		return "CREATE_INDEX"
 	case *tidbast.CreateTableStmt:
-		resource := base.SchemaResource{
-			Database: n.Table.Schema.L,
-			Table:    n.Table.Name.L,
-		}
-		if resource.Database == "" {
-			resource.Database = database
-		}
-		result = append(result, resource)
-		return "CREATE_TABLE", result
+		return "CREATE_TABLE"
 	case *tidbast.CreateViewStmt:
-		return "CREATE_VIEW", result
+		return "CREATE_VIEW"
 	case *tidbast.CreateSequenceStmt:
-		return "CREATE_SEQUENCE", result
+		return "CREATE_SEQUENCE"
 	case *tidbast.CreatePlacementPolicyStmt:
-		return "CREATE_PLACEMENT_POLICY", result
+		return "CREATE_PLACEMENT_POLICY"
 
 	// DROP
 	case *tidbast.DropIndexStmt:
-		return "DROP_INDEX", result
+		return "DROP_INDEX"
 	case *tidbast.DropTableStmt:
-		for _, table := range n.Tables {
-			resource := base.SchemaResource{
-				Database: table.Schema.L,
-				Table:    table.Name.L,
-			}
-			if resource.Database == "" {
-				resource.Database = database
-			}
-			result = append(result, resource)
-		}
-		return "DROP_TABLE", result
+		return "DROP_TABLE"
 	case *tidbast.DropSequenceStmt:
-		return "DROP_SEQUENCE", result
+		return "DROP_SEQUENCE"
 	case *tidbast.DropPlacementPolicyStmt:
-		return "DROP_PLACEMENT_POLICY", result
+		return "DROP_PLACEMENT_POLICY"
 	case *tidbast.DropDatabaseStmt:
-		return "DROP_DATABASE", result
+		return "DROP_DATABASE"
 
 	// ALTER
 	case *tidbast.AlterTableStmt:
-		resource := base.SchemaResource{
-			Database: n.Table.Schema.L,
-			Table:    n.Table.Name.L,
-		}
-		if resource.Database == "" {
-			resource.Database = database
-		}
-		result = append(result, resource)
-		return "ALTER_TABLE", result
+		return "ALTER_TABLE"
 	case *tidbast.AlterSequenceStmt:
-		return "ALTER_SEQUENCE", result
+		return "ALTER_SEQUENCE"
 	case *tidbast.AlterPlacementPolicyStmt:
-		return "ALTER_PLACEMENT_POLICY", result
+		return "ALTER_PLACEMENT_POLICY"
 
 	// TRUNCATE
 	case *tidbast.TruncateTableStmt:
-		return "TRUNCATE", result
+		return "TRUNCATE"
 
 	// RENAME
 	case *tidbast.RenameTableStmt:
-		for _, pair := range n.TableToTables {
-			resource := base.SchemaResource{
-				Database: pair.OldTable.Schema.L,
-				Table:    pair.OldTable.Name.L,
-			}
-			if resource.Database == "" {
-				resource.Database = database
-			}
-			result = append(result, resource)
-
-			newResource := base.SchemaResource{
-				Database: pair.NewTable.Schema.L,
-				Table:    pair.NewTable.Name.L,
-			}
-			if newResource.Database == "" {
-				newResource.Database = resource.Database
-			}
-			result = append(result, newResource)
-		}
-		return "RENAME_TABLE", result
+		return "RENAME_TABLE"
 
 	// DML
 
 	case *tidbast.InsertStmt:
 		if n.IsReplace {
-			return "REPLACE", result
+			return "REPLACE"
 		}
-		return "INSERT", result
+		return "INSERT"
 	case *tidbast.DeleteStmt:
-		return "DELETE", result
+		return "DELETE"
 	case *tidbast.UpdateStmt:
-		return "UPDATE", result
+		return "UPDATE"
 	}
-	return "UNKNOWN", result
+	return "UNKNOWN"
 }
 
 func getStatementTypeAndResourcesFromAstNode(database, schema string, node ast.Node) (string, []base.SchemaResource) {
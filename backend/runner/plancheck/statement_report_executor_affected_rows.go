@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -229,6 +230,9 @@ func getAffectedRowsForMysql(ctx context.Context, engine storepb.Engine, sqlDB *
 		if engine == storepb.Engine_OCEANBASE {
 			return getAffectedRowsCount(ctx, sqlDB, fmt.Sprintf("EXPLAIN FORMAT=JSON %s", node.Text()), getAffectedRowsCountForOceanBase)
 		}
+		if engine == storepb.Engine_TIDB {
+			return getAffectedRowsCount(ctx, sqlDB, fmt.Sprintf("EXPLAIN %s", node.Text()), getAffectedRowsCountForTiDB)
+		}
 		return getAffectedRowsCount(ctx, sqlDB, fmt.Sprintf("EXPLAIN %s", node.Text()), getAffectedRowsCountForMysql)
 
 	case *tidbast.AlterTableStmt:
@@ -324,6 +328,75 @@ func getAffectedRowsCountForOceanBase(res []any) (int64, error) {
 	return 0, errors.Errorf("failed to extract 'EST.ROWS' from query plan")
 }
 
+func getAffectedRowsCountForTiDB(res []any) (int64, error) {
+	// the res struct is []any{columnName, columnTable, rowDataList}
+	if len(res) != 3 {
+		return 0, errors.Errorf("expected 3 but got %d", len(res))
+	}
+	columnNames, ok := res[0].([]string)
+	if !ok {
+		return 0, errors.Errorf("expected []string but got %t", res[0])
+	}
+	rowList, ok := res[2].([]any)
+	if !ok {
+		return 0, errors.Errorf("expected []any but got %t", res[2])
+	}
+	if len(rowList) < 1 {
+		return 0, errors.Errorf("not found any data")
+	}
+
+	// TiDB EXPLAIN statement result has 5 columns, and the column 'estRows' is the estimated rows.
+	// The DML operator on the root has no estimation, the first estimated value of its children is the affected rows count.
+	//
+	// mysql> explain delete from td;
+	// +-------------------------+----------+-----------+---------------+--------------------------------+
+	// | id                      | estRows  | task      | access object | operator info                  |
+	// +-------------------------+----------+-----------+---------------+--------------------------------+
+	// | Delete_4                | N/A      | root      |               | N/A                            |
+	// | └─TableReader_8         | 10000.00 | root      |               | data:TableFullScan_7           |
+	// |   └─TableFullScan_7     | 10000.00 | cop[tikv] | table:td      | keep order:false, stats:pseudo |
+	// +-------------------------+----------+-----------+---------------+--------------------------------+
+	estRowsIndex := -1
+	for i, name := range columnNames {
+		if strings.EqualFold(name, "estRows") || strings.EqualFold(name, "count") {
+			estRowsIndex = i
+			break
+		}
+	}
+	if estRowsIndex < 0 {
+		return 0, errors.Errorf("failed to find column estRows in %v", columnNames)
+	}
+
+	for _, rowAny := range rowList {
+		row, ok := rowAny.([]any)
+		if !ok {
+			return 0, errors.Errorf("expected []any but got %t", row)
+		}
+		if len(row) != len(columnNames) {
+			return 0, errors.Errorf("expected %d but got %d", len(columnNames), len(row))
+		}
+		var estRows float64
+		switch col := row[estRowsIndex].(type) {
+		case float64:
+			estRows = col
+		case int64:
+			estRows = float64(col)
+		case string:
+			v, err := strconv.ParseFloat(col, 64)
+			if err != nil {
+				// The DML operator has "N/A" as the estimation.
+				continue
+			}
+			estRows = v
+		default:
+			continue
+		}
+		return int64(math.Round(estRows)), nil
+	}
+
+	return 0, errors.Errorf("failed to extract estRows from query plan")
+}
+
 func getAffectedRowsCountForMysql(res []any) (int64, error) {
 	// the res struct is []any{columnName, columnTable, rowDataList}
 	if len(res) != 3 {
@@ -0,0 +1,89 @@
+package plancheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAffectedRowsCountForTiDB(t *testing.T) {
+	tests := []struct {
+		description string
+		res         []any
+		want        int64
+		wantErr     bool
+	}{
+		{
+			description: "delete with full table scan",
+			res: []any{
+				[]string{"id", "estRows", "task", "access object", "operator info"},
+				[]string{"VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR"},
+				[]any{
+					[]any{"Delete_4", "N/A", "root", "", "N/A"},
+					[]any{"└─TableReader_8", "10000.00", "root", "", "data:TableFullScan_7"},
+					[]any{"  └─TableFullScan_7", "10000.00", "cop[tikv]", "table:td", "keep order:false, stats:pseudo"},
+				},
+			},
+			want: 10000,
+		},
+		{
+			description: "update with selection",
+			res: []any{
+				[]string{"id", "estRows", "task", "access object", "operator info"},
+				[]string{"VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR"},
+				[]any{
+					[]any{"Update_4", "N/A", "root", "", "N/A"},
+					[]any{"└─TableReader_8", "3.33", "root", "", "data:Selection_7"},
+					[]any{"  └─Selection_7", "3.33", "cop[tikv]", "", "eq(test.td.a, 1)"},
+					[]any{"    └─TableFullScan_6", "10000.00", "cop[tikv]", "table:td", "keep order:false, stats:pseudo"},
+				},
+			},
+			want: 3,
+		},
+		{
+			description: "legacy count column",
+			res: []any{
+				[]string{"id", "count", "task", "operator info"},
+				[]string{"VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR"},
+				[]any{
+					[]any{"Delete_3", "N/A", "root", "N/A"},
+					[]any{"└─TableReader_6", "42.00", "root", "data:TableScan_5"},
+				},
+			},
+			want: 42,
+		},
+		{
+			description: "mysql explain result",
+			res: []any{
+				[]string{"id", "select_type", "table", "partitions", "type", "possible_keys", "key", "key_len", "ref", "rows", "filtered", "Extra"},
+				[]string{"BIGINT", "VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR", "BIGINT", "DOUBLE", "VARCHAR"},
+				[]any{
+					[]any{int64(1), "DELETE", "td", nil, "ALL", nil, nil, nil, nil, int64(1), float64(100), nil},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			description: "no estimation",
+			res: []any{
+				[]string{"id", "estRows", "task", "access object", "operator info"},
+				[]string{"VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR"},
+				[]any{
+					[]any{"Delete_4", "N/A", "root", "", "N/A"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := getAffectedRowsCountForTiDB(test.res)
+		if test.wantErr {
+			a.Error(err, test.description)
+			continue
+		}
+		a.NoError(err, test.description)
+		a.Equal(test.want, got, test.description)
+	}
+}
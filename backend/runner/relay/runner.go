@@ -21,6 +21,7 @@ import (
 	"github.com/bytebase/bytebase/backend/utils"
 
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/metric"
 	relayplugin "github.com/bytebase/bytebase/backend/plugin/app/relay"
 	"github.com/bytebase/bytebase/backend/store"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -67,8 +68,6 @@ func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
 
 	wg.Add(1)
 	go r.listenIssueExternalApprovalRelayCancelChan(ctx, wg)
-	wg.Add(1)
-	go r.listenCheckExternalApprovalChan(ctx, wg)
 
 	for {
 		select {
@@ -105,7 +104,9 @@ func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
 	}
 }
 
-func (r *Runner) listenCheckExternalApprovalChan(ctx context.Context, wg *sync.WaitGroup) {
+// ListenCheckExternalApprovalChan serves the requests to check the external approvals.
+// Unlike Run which only runs on the leader, it runs on every replica to serve the requests from the API on the replica.
+func (r *Runner) ListenCheckExternalApprovalChan(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 	for {
 		select {
@@ -275,6 +276,9 @@ func (r *Runner) approveExternalApprovalNode(ctx context.Context, issueUID int)
 	if err != nil {
 		return errors.Wrapf(err, "failed to update issue")
 	}
+	if approved {
+		metric.ObserveApprovalWait(string(issue.Type), time.Since(issue.CreatedTime))
+	}
 
 	// It's ok to fail to create activity.
 	if err := func() error {
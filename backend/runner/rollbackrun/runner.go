@@ -19,6 +19,7 @@ import (
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	"github.com/bytebase/bytebase/backend/component/state"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
 	"github.com/bytebase/bytebase/backend/store"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -34,6 +35,9 @@ func NewRunner(profile *config.Profile, store *store.Store, dbFactory *dbfactory
 	}
 }
 
+// retryGenerateRollbackSQLInterval is the interval to pick up the unfinished rollback SQL generation from the database.
+const retryGenerateRollbackSQLInterval = 10 * time.Second
+
 // Runner is the rollback runner generating rollback SQL statements.
 type Runner struct {
 	profile   *config.Profile
@@ -46,10 +50,14 @@ type Runner struct {
 func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
+	retryTicker := time.NewTicker(retryGenerateRollbackSQLInterval)
+	defer retryTicker.Stop()
 	defer wg.Done()
 	r.retryGenerateRollbackSQL(ctx)
 	for {
 		select {
+		case <-retryTicker.C:
+			r.retryGenerateRollbackSQL(ctx)
 		case <-ticker.C:
 			r.stateCfg.RollbackGenerate.Range(func(key, value any) bool {
 				task := value.(*store.TaskMessage)
@@ -69,7 +77,8 @@ func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
 }
 
 // retryGenerateRollbackSQL retries generating rollback SQL for tasks.
-// It is currently called when Bytebase server starts and only rerun unfinished generation.
+// It is called when the runner starts and periodically, and only reruns unfinished generation,
+// e.g. the generation lost in a restart or enqueued on another replica.
 func (r *Runner) retryGenerateRollbackSQL(ctx context.Context) {
 	taskList, err := r.store.ListTasks(ctx, &api.TaskFind{
 		LatestTaskRunStatusList: &[]api.TaskRunStatus{api.TaskRunDone},
@@ -124,6 +133,28 @@ func (r *Runner) generateRollbackSQL(ctx context.Context, task *store.TaskMessag
 		r.generateMySQLRollbackSQL(ctx, task, payload, instance, project)
 	case storepb.Engine_ORACLE:
 		r.generateOracleRollbackSQL(ctx, task, payload, instance, project)
+	case storepb.Engine_POSTGRES:
+		r.generatePostgresRollbackSQL(ctx, task, payload)
+	}
+}
+
+// generatePostgresRollbackSQL handles the rollback SQL generation enabled after the task is done.
+// The rollback SQL for PostgreSQL is generated from the before-images captured in the migration transaction by the task executor,
+// so it cannot be generated afterwards.
+func (r *Runner) generatePostgresRollbackSQL(ctx context.Context, task *store.TaskMessage, payload *api.TaskDatabaseDataUpdatePayload) {
+	if payload.RollbackSQLStatus != api.RollbackSQLStatusPending {
+		return
+	}
+	rollbackSQLStatus := api.RollbackSQLStatusFailed
+	rollbackError := "Failed to generate rollback SQL statement. Rollback SQL for PostgreSQL must be enabled before the task runs."
+	patch := &api.TaskPatch{
+		ID:                task.ID,
+		UpdaterID:         api.SystemBotID,
+		RollbackSQLStatus: &rollbackSQLStatus,
+		RollbackError:     &rollbackError,
+	}
+	if _, err := r.store.UpdateTaskV2(ctx, patch); err != nil {
+		slog.Error("Failed to patch task with the PostgreSQL rollback error", slog.Int("taskID", task.ID))
 	}
 }
 
@@ -304,7 +335,7 @@ func (r *Runner) generateMySQLRollbackSQLImpl(ctx context.Context, payload *api.
 	if err != nil {
 		return "", errors.WithMessage(err, "failed to parse the schema")
 	}
-	mysqlDriver, ok := driver.(*mysql.Driver)
+	mysqlDriver, ok := db.UnwrapDriver(driver).(*mysql.Driver)
 	if !ok {
 		return "", errors.Errorf("failed to cast driver to mysql.Driver")
 	}
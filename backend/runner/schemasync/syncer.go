@@ -17,11 +17,14 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/activity"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/metric"
+	webhookPlugin "github.com/bytebase/bytebase/backend/plugin/webhook"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/store/model"
 	"github.com/bytebase/bytebase/backend/utils"
@@ -35,23 +38,25 @@ const (
 )
 
 // NewSyncer creates a schema syncer.
-func NewSyncer(store *store.Store, dbFactory *dbfactory.DBFactory, stateCfg *state.State, profile config.Profile, licenseService enterpriseAPI.LicenseService) *Syncer {
+func NewSyncer(store *store.Store, dbFactory *dbfactory.DBFactory, stateCfg *state.State, profile config.Profile, licenseService enterpriseAPI.LicenseService, activityManager *activity.Manager) *Syncer {
 	return &Syncer{
-		store:          store,
-		dbFactory:      dbFactory,
-		stateCfg:       stateCfg,
-		profile:        profile,
-		licenseService: licenseService,
+		store:           store,
+		dbFactory:       dbFactory,
+		stateCfg:        stateCfg,
+		profile:         profile,
+		licenseService:  licenseService,
+		activityManager: activityManager,
 	}
 }
 
 // Syncer is the schema syncer.
 type Syncer struct {
-	store          *store.Store
-	dbFactory      *dbfactory.DBFactory
-	stateCfg       *state.State
-	profile        config.Profile
-	licenseService enterpriseAPI.LicenseService
+	store           *store.Store
+	dbFactory       *dbfactory.DBFactory
+	stateCfg        *state.State
+	profile         config.Profile
+	licenseService  enterpriseAPI.LicenseService
+	activityManager *activity.Manager
 }
 
 // Run will run the schema syncer once.
@@ -64,6 +69,18 @@ func (s *Syncer) Run(ctx context.Context, wg *sync.WaitGroup) {
 		select {
 		case <-ticker.C:
 			s.trySyncAll(ctx)
+		case <-ctx.Done(): // if cancel() execute
+			return
+		}
+	}
+}
+
+// RunSyncRequests will serve the requests to sync instances.
+// Unlike Run which only runs on the leader, it runs on every replica to serve the requests from the API on the replica.
+func (s *Syncer) RunSyncRequests(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	for {
+		select {
 		case instance := <-s.stateCfg.InstanceDatabaseSyncChan:
 			// Sync all databases for instance.
 			s.syncAllDatabases(ctx, instance)
@@ -113,12 +130,17 @@ func (s *Syncer) trySyncAll(ctx context.Context) {
 	}
 
 	now := time.Now()
+	// Reset the lags so that the deleted instances and the instances without the periodic sync are not reported.
+	metric.ResetSchemaSyncLag()
 	for _, instance := range instances {
 		interval := getOrDefaultSyncInterval(instance)
 		if interval == defaultSyncInterval {
 			continue
 		}
 		lastSyncTime := getOrDefaultLastSyncTime(instance.Metadata.LastSyncTime)
+		if instance.Metadata.LastSyncTime != nil {
+			metric.SetSchemaSyncLag(instance.ResourceID, instance.Engine.String(), now.Sub(lastSyncTime))
+		}
 		// lastSyncTime + syncInterval > now
 		// Next round not started yet.
 		nextSyncTime := lastSyncTime.Add(interval)
@@ -131,6 +153,8 @@ func (s *Syncer) trySyncAll(ctx context.Context) {
 			slog.Debug("Failed to sync instance",
 				slog.String("instance", instance.ResourceID),
 				slog.String("error", err.Error()))
+		} else {
+			metric.SetSchemaSyncLag(instance.ResourceID, instance.Engine.String(), 0)
 		}
 	}
 
@@ -306,6 +330,7 @@ func (s *Syncer) SyncInstance(ctx context.Context, instance *store.InstanceMessa
 			}); err != nil {
 				return errors.Wrapf(err, "failed to create instance %q database %q in sync runner", instance.ResourceID, databaseMetadata.Name)
 			}
+			s.postDatabaseEvent(ctx, api.ActivityDatabaseCreate, "Database created", instance, databaseMetadata.Name, api.DefaultProjectID)
 		}
 	}
 
@@ -326,6 +351,9 @@ func (s *Syncer) SyncInstance(ctx context.Context, instance *store.InstanceMessa
 			}, api.SystemBotID); err != nil {
 				return errors.Errorf("failed to update database %q for instance %q", database.DatabaseName, instance.ResourceID)
 			}
+			if database.SyncState != api.NotFound {
+				s.postDatabaseEvent(ctx, api.ActivityDatabaseDelete, "Database deleted", instance, database.DatabaseName, database.ProjectID)
+			}
 		}
 	}
 
@@ -452,7 +480,7 @@ func (s *Syncer) SyncDatabaseSchema(ctx context.Context, database *store.Databas
 						slog.String("type", string(api.AnomalyDatabaseSchemaDrift)),
 						log.BBError(err))
 				} else {
-					if _, err = s.store.UpsertActiveAnomalyV2(ctx, api.SystemBotID, &store.AnomalyMessage{
+					if err = s.upsertActiveAnomaly(ctx, &store.AnomalyMessage{
 						InstanceID:  instance.ResourceID,
 						DatabaseUID: &database.UID,
 						Type:        api.AnomalyDatabaseSchemaDrift,
@@ -483,6 +511,55 @@ func (s *Syncer) SyncDatabaseSchema(ctx context.Context, database *store.Databas
 	return nil
 }
 
+// upsertActiveAnomaly upserts the active anomaly, and posts the anomaly event to the workspace webhooks
+// if the anomaly is newly opened.
+func (s *Syncer) upsertActiveAnomaly(ctx context.Context, anomaly *store.AnomalyMessage) error {
+	_, created, err := s.store.UpsertActiveAnomalyV2(ctx, api.SystemBotID, anomaly)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+
+	webhookAnomaly := &webhookPlugin.Anomaly{
+		Type:     string(anomaly.Type),
+		Instance: anomaly.InstanceID,
+		Detail:   anomaly.Payload,
+	}
+	title := fmt.Sprintf("Anomaly detected - %s", anomaly.InstanceID)
+	if anomaly.DatabaseUID != nil {
+		database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: anomaly.DatabaseUID})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get database %d", *anomaly.DatabaseUID)
+		}
+		if database != nil {
+			webhookAnomaly.Database = database.DatabaseName
+			title = fmt.Sprintf("Anomaly detected - %s/%s", anomaly.InstanceID, database.DatabaseName)
+		}
+	}
+	s.activityManager.PostWorkspaceEvent(ctx, api.SystemBotID, &webhookPlugin.Context{
+		ActivityType: string(api.ActivityAnomalyCreate),
+		Level:        webhookPlugin.WebhookWarn,
+		Title:        title,
+		Anomaly:      webhookAnomaly,
+	})
+	return nil
+}
+
+// postDatabaseEvent posts the database event to the workspace webhooks.
+func (s *Syncer) postDatabaseEvent(ctx context.Context, activityType api.ActivityType, title string, instance *store.InstanceMessage, databaseName, projectID string) {
+	s.activityManager.PostWorkspaceEvent(ctx, api.SystemBotID, &webhookPlugin.Context{
+		ActivityType: string(activityType),
+		Title:        fmt.Sprintf("%s - %s/%s", title, instance.ResourceID, databaseName),
+		Database: &webhookPlugin.Database{
+			Name:     databaseName,
+			Instance: instance.ResourceID,
+			Project:  projectID,
+		},
+	})
+}
+
 func (s *Syncer) upsertInstanceConnectionAnomaly(ctx context.Context, instance *store.InstanceMessage, connErr error) {
 	if connErr != nil {
 		anomalyPayload := api.AnomalyInstanceConnectionPayload{
@@ -496,7 +573,7 @@ func (s *Syncer) upsertInstanceConnectionAnomaly(ctx context.Context, instance *
 				log.BBError(err))
 			return
 		}
-		if _, err = s.store.UpsertActiveAnomalyV2(ctx, api.SystemBotID, &store.AnomalyMessage{
+		if err = s.upsertActiveAnomaly(ctx, &store.AnomalyMessage{
 			InstanceID: instance.ResourceID,
 			Type:       api.AnomalyInstanceConnection,
 			Payload:    string(payload),
@@ -534,7 +611,7 @@ func (s *Syncer) upsertDatabaseConnectionAnomaly(ctx context.Context, instance *
 				slog.String("type", string(api.AnomalyDatabaseConnection)),
 				log.BBError(err))
 		} else {
-			if _, err = s.store.UpsertActiveAnomalyV2(ctx, api.SystemBotID, &store.AnomalyMessage{
+			if err = s.upsertActiveAnomaly(ctx, &store.AnomalyMessage{
 				InstanceID:  instance.ResourceID,
 				DatabaseUID: &database.UID,
 				Type:        api.AnomalyDatabaseConnection,
@@ -617,7 +694,7 @@ func (s *Syncer) checkBackupAnomaly(ctx context.Context, environment *store.Envi
 					slog.String("type", string(api.AnomalyDatabaseBackupPolicyViolation)),
 					log.BBError(err))
 			} else {
-				if _, err = s.store.UpsertActiveAnomalyV2(ctx, api.SystemBotID, &store.AnomalyMessage{
+				if err = s.upsertActiveAnomaly(ctx, &store.AnomalyMessage{
 					InstanceID:  instance.ResourceID,
 					DatabaseUID: &database.UID,
 					Type:        api.AnomalyDatabaseBackupPolicyViolation,
@@ -699,7 +776,7 @@ func (s *Syncer) checkBackupAnomaly(ctx context.Context, environment *store.Envi
 					slog.String("type", string(api.AnomalyDatabaseBackupMissing)),
 					log.BBError(err))
 			} else {
-				if _, err = s.store.UpsertActiveAnomalyV2(ctx, api.SystemBotID, &store.AnomalyMessage{
+				if err = s.upsertActiveAnomaly(ctx, &store.AnomalyMessage{
 					InstanceID:  instance.ResourceID,
 					DatabaseUID: &database.UID,
 					Type:        api.AnomalyDatabaseBackupMissing,
@@ -57,9 +57,6 @@ func (s *Syncer) Run(ctx context.Context, wg *sync.WaitGroup) {
 		case <-ctx.Done():
 			slog.Debug("Slow query syncer received context cancellation")
 			return
-		case message := <-s.stateCfg.InstanceSlowQuerySyncChan:
-			slog.Debug("Slow query syncer received instance slow query sync request", slog.String("instance", message.InstanceID), slog.String("project", message.ProjectID))
-			s.syncSlowQuery(ctx, message)
 		case <-ticker.C:
 			slog.Debug("Slow query syncer received tick")
 			s.syncSlowQuery(ctx, nil)
@@ -67,6 +64,21 @@ func (s *Syncer) Run(ctx context.Context, wg *sync.WaitGroup) {
 	}
 }
 
+// RunSyncRequests will serve the requests to sync slow queries.
+// Unlike Run which only runs on the leader, it runs on every replica to serve the requests from the API on the replica.
+func (s *Syncer) RunSyncRequests(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message := <-s.stateCfg.InstanceSlowQuerySyncChan:
+			slog.Debug("Slow query syncer received instance slow query sync request", slog.String("instance", message.InstanceID), slog.String("project", message.ProjectID))
+			s.syncSlowQuery(ctx, message)
+		}
+	}
+}
+
 func (s *Syncer) syncSlowQuery(ctx context.Context, message *state.InstanceSlowQuerySyncMessage) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -118,10 +130,12 @@ func (s *Syncer) syncInstanceSlowQuery(ctx context.Context, instance *store.Inst
 	}
 
 	switch instance.Engine {
-	case storepb.Engine_MYSQL:
+	case storepb.Engine_MYSQL, storepb.Engine_TIDB:
 		return s.syncMySQLSlowQuery(ctx, instance)
 	case storepb.Engine_POSTGRES:
 		return s.syncPostgreSQLSlowQuery(ctx, instance, project)
+	case storepb.Engine_MSSQL, storepb.Engine_ORACLE:
+		return s.syncDatabaseSlowQuery(ctx, instance, project)
 	default:
 		return errors.Errorf("unsupported database engine: %s", instance.Engine)
 	}
@@ -337,3 +351,86 @@ func (s *Syncer) syncMySQLSlowQuery(ctx context.Context, instance *store.Instanc
 
 	return nil
 }
+
+// syncDatabaseSlowQuery syncs the slow query for the engines collecting the statistics per database,
+// such as the Query Store of SQL Server and the AWR snapshots of Oracle.
+func (s *Syncer) syncDatabaseSlowQuery(ctx context.Context, instance *store.InstanceMessage, project string) error {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	earliestDate := today.AddDate(0, 0, -retentionCycle)
+
+	if err := s.store.DeleteOutdatedSlowLog(ctx, instance.UID, earliestDate); err != nil {
+		return err
+	}
+
+	latestSlowLogDate, err := s.store.GetLatestSlowLogDate(ctx, instance.UID)
+	if err != nil {
+		return err
+	}
+
+	if latestSlowLogDate == nil || latestSlowLogDate.AddDate(0, 0, retentionCycle).Before(today) {
+		latestSlowLogDate = &earliestDate
+	}
+
+	findDatabases := &store.FindDatabaseMessage{
+		InstanceID: &instance.ResourceID,
+	}
+	if project != "" {
+		findDatabases.ProjectID = &project
+	}
+	databases, err := s.store.ListDatabases(ctx, findDatabases)
+	if err != nil {
+		return err
+	}
+
+	for _, database := range databases {
+		if database.SyncState != api.OK {
+			continue
+		}
+		if err := s.syncDatabaseSlowQueryFromDate(ctx, instance, database, latestSlowLogDate.Truncate(24*time.Hour), today); err != nil {
+			slog.Warn("Failed to sync database slow query",
+				slog.String("instance", instance.ResourceID),
+				slog.String("database", database.DatabaseName),
+				slog.Int("databaseID", database.UID),
+				log.BBError(err))
+		}
+	}
+
+	return nil
+}
+
+func (s *Syncer) syncDatabaseSlowQueryFromDate(ctx context.Context, instance *store.InstanceMessage, database *store.DatabaseMessage, startDate, endDate time.Time) error {
+	driver, err := s.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+	if err := driver.CheckSlowQueryLogEnabled(ctx); err != nil {
+		return err
+	}
+
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		logs, err := driver.SyncSlowQuery(ctx, date)
+		if err != nil {
+			return err
+		}
+
+		slowLog, ok := logs[database.DatabaseName]
+		if !ok || len(slowLog.Items) == 0 {
+			continue
+		}
+		if err := s.store.UpsertSlowLog(ctx, &store.UpsertSlowLogMessage{
+			EnvironmentID: &instance.EnvironmentID,
+			InstanceID:    &instance.ResourceID,
+			DatabaseName:  database.DatabaseName,
+			InstanceUID:   instance.UID,
+			LogDate:       date,
+			SlowLog:       slowLog,
+			UpdaterID:     api.SystemBotID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
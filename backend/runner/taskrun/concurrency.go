@@ -0,0 +1,160 @@
+package taskrun
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// taskRunSlot is the resources held by an executing task run, which are limited by the concurrency policies.
+type taskRunSlot struct {
+	instanceID    int
+	environmentID int
+	projectID     int
+	// exclusionGroups is the titles of the exclusion groups the task run belongs to.
+	exclusionGroups []string
+}
+
+// concurrencyPolicies is the concurrency policies applied to a task run.
+type concurrencyPolicies struct {
+	workspace   *storepb.ConcurrencyPolicy
+	environment *storepb.ConcurrencyPolicy
+	instance    *storepb.ConcurrencyPolicy
+	project     *storepb.ConcurrencyPolicy
+}
+
+// getExclusionGroups returns the titles of the exclusion groups in the workspace policy which the task on the instance belongs to.
+func getExclusionGroups(workspacePolicy *storepb.ConcurrencyPolicy, instanceID string, taskType api.TaskType) []string {
+	var groups []string
+	instanceName := fmt.Sprintf("%s%s", common.InstanceNamePrefix, instanceID)
+	for _, group := range workspacePolicy.GetExclusionGroups() {
+		switch group.Scope {
+		case storepb.ExclusionGroup_SCHEMA_CHANGE:
+			if !isSchemaChangeTaskType(taskType) {
+				continue
+			}
+		case storepb.ExclusionGroup_DATA_CHANGE:
+			if taskType != api.TaskDatabaseDataUpdate {
+				continue
+			}
+		}
+		for _, instance := range group.Instances {
+			if instance == instanceName {
+				groups = append(groups, group.Title)
+				break
+			}
+		}
+	}
+	return groups
+}
+
+func isSchemaChangeTaskType(taskType api.TaskType) bool {
+	switch taskType {
+	case api.TaskDatabaseSchemaUpdate,
+		api.TaskDatabaseSchemaUpdateSDL,
+		api.TaskDatabaseSchemaUpdateGhostSync,
+		api.TaskDatabaseSchemaUpdateGhostCutover:
+		return true
+	default:
+		return false
+	}
+}
+
+// canStartTaskRun checks whether the task run can start with the running task runs under the concurrency policies.
+// If not, it returns the reason.
+func canStartTaskRun(slot *taskRunSlot, running []*taskRunSlot, policies concurrencyPolicies, now time.Time) (bool, string, error) {
+	for _, policy := range []*storepb.ConcurrencyPolicy{policies.workspace, policies.environment, policies.instance, policies.project} {
+		for _, window := range policy.GetMaintenanceWindows() {
+			in, err := inMaintenanceWindow(window, now)
+			if err != nil {
+				return false, "", err
+			}
+			if in {
+				return false, "in the maintenance window", nil
+			}
+		}
+	}
+
+	var workspaceCount, environmentCount, instanceCount, projectCount int32
+	for _, r := range running {
+		workspaceCount++
+		if r.environmentID == slot.environmentID {
+			environmentCount++
+		}
+		if r.instanceID == slot.instanceID {
+			instanceCount++
+		}
+		if r.projectID == slot.projectID {
+			projectCount++
+		}
+		for _, group := range r.exclusionGroups {
+			for _, g := range slot.exclusionGroups {
+				if group == g {
+					return false, fmt.Sprintf("another task run is running in the exclusion group %q", g), nil
+				}
+			}
+		}
+	}
+	for _, limit := range []struct {
+		resource string
+		policy   *storepb.ConcurrencyPolicy
+		count    int32
+	}{
+		{"workspace", policies.workspace, workspaceCount},
+		{"environment", policies.environment, environmentCount},
+		{"instance", policies.instance, instanceCount},
+		{"project", policies.project, projectCount},
+	} {
+		if maxCount := limit.policy.GetMaxRunningTaskRuns(); maxCount > 0 && limit.count >= maxCount {
+			return false, fmt.Sprintf("the %s has reached the max running task runs %d", limit.resource, maxCount), nil
+		}
+	}
+	return true, "", nil
+}
+
+// inMaintenanceWindow checks whether the time is in the maintenance window.
+func inMaintenanceWindow(window *storepb.MaintenanceWindow, now time.Time) (bool, error) {
+	location := time.UTC
+	if window.TimeZone != "" {
+		l, err := time.LoadLocation(window.TimeZone)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid time zone %q", window.TimeZone)
+		}
+		location = l
+	}
+	startTime, err := time.Parse("15:04", window.StartTime)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid start time %q", window.StartTime)
+	}
+	duration := window.Duration.AsDuration()
+	now = now.In(location)
+	// Check the windows starting on the days the current window may span.
+	for i := 0; i <= int(duration/(24*time.Hour))+1; i++ {
+		day := now.AddDate(0, 0, -i)
+		start := time.Date(day.Year(), day.Month(), day.Day(), startTime.Hour(), startTime.Minute(), 0, 0, location)
+		if !isMaintenanceDay(window.Days, start.Weekday()) {
+			continue
+		}
+		if !now.Before(start) && now.Before(start.Add(duration)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isMaintenanceDay(days []int32, weekday time.Weekday) bool {
+	if len(days) == 0 {
+		return true
+	}
+	for _, day := range days {
+		if time.Weekday(day) == weekday {
+			return true
+		}
+	}
+	return false
+}
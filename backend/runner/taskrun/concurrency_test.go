@@ -0,0 +1,126 @@
+package taskrun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestInMaintenanceWindow(t *testing.T) {
+	// 2023-11-06 is Monday.
+	monday := time.Date(2023, 11, 6, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		window *storepb.MaintenanceWindow
+		now    time.Time
+		want   bool
+	}{
+		{
+			window: &storepb.MaintenanceWindow{StartTime: "02:00", Duration: durationpb.New(2 * time.Hour)},
+			now:    monday.Add(3 * time.Hour),
+			want:   true,
+		},
+		{
+			window: &storepb.MaintenanceWindow{StartTime: "02:00", Duration: durationpb.New(2 * time.Hour)},
+			now:    monday.Add(4 * time.Hour),
+			want:   false,
+		},
+		// The window starting on Sunday night spans to Monday.
+		{
+			window: &storepb.MaintenanceWindow{Days: []int32{0}, StartTime: "23:00", Duration: durationpb.New(3 * time.Hour)},
+			now:    monday.Add(time.Hour),
+			want:   true,
+		},
+		{
+			window: &storepb.MaintenanceWindow{Days: []int32{1}, StartTime: "23:00", Duration: durationpb.New(3 * time.Hour)},
+			now:    monday.Add(time.Hour),
+			want:   false,
+		},
+		// 18:00 in Los Angeles is 02:00 the next day in UTC.
+		{
+			window: &storepb.MaintenanceWindow{Days: []int32{0}, StartTime: "18:00", Duration: durationpb.New(time.Hour), TimeZone: "America/Los_Angeles"},
+			now:    monday.Add(2*time.Hour + 30*time.Minute),
+			want:   true,
+		},
+	}
+
+	a := require.New(t)
+	for i, test := range tests {
+		got, err := inMaintenanceWindow(test.window, test.now)
+		a.NoError(err)
+		a.Equal(test.want, got, i)
+	}
+}
+
+func TestCanStartTaskRun(t *testing.T) {
+	now := time.Date(2023, 11, 6, 12, 0, 0, 0, time.UTC)
+	slot := &taskRunSlot{instanceID: 1, environmentID: 101, projectID: 1001}
+	tests := []struct {
+		slot     *taskRunSlot
+		running  []*taskRunSlot
+		policies concurrencyPolicies
+		want     bool
+	}{
+		{
+			slot:    slot,
+			running: []*taskRunSlot{{instanceID: 1, environmentID: 101, projectID: 1001}},
+			want:    true,
+		},
+		{
+			slot:     slot,
+			running:  []*taskRunSlot{{instanceID: 1, environmentID: 102, projectID: 1002}},
+			policies: concurrencyPolicies{instance: &storepb.ConcurrencyPolicy{MaxRunningTaskRuns: 1}},
+			want:     false,
+		},
+		{
+			slot:     slot,
+			running:  []*taskRunSlot{{instanceID: 2, environmentID: 101, projectID: 1002}},
+			policies: concurrencyPolicies{instance: &storepb.ConcurrencyPolicy{MaxRunningTaskRuns: 1}, environment: &storepb.ConcurrencyPolicy{MaxRunningTaskRuns: 2}},
+			want:     true,
+		},
+		{
+			slot:     slot,
+			running:  []*taskRunSlot{{instanceID: 2, environmentID: 102, projectID: 1001}},
+			policies: concurrencyPolicies{project: &storepb.ConcurrencyPolicy{MaxRunningTaskRuns: 1}},
+			want:     false,
+		},
+		{
+			slot:    &taskRunSlot{instanceID: 1, environmentID: 101, projectID: 1001, exclusionGroups: []string{"cluster"}},
+			running: []*taskRunSlot{{instanceID: 2, environmentID: 102, projectID: 1002, exclusionGroups: []string{"cluster"}}},
+			want:    false,
+		},
+		{
+			slot: slot,
+			policies: concurrencyPolicies{environment: &storepb.ConcurrencyPolicy{
+				MaintenanceWindows: []*storepb.MaintenanceWindow{{StartTime: "11:00", Duration: durationpb.New(2 * time.Hour)}},
+			}},
+			want: false,
+		},
+	}
+
+	a := require.New(t)
+	for i, test := range tests {
+		got, _, err := canStartTaskRun(test.slot, test.running, test.policies, now)
+		a.NoError(err)
+		a.Equal(test.want, got, i)
+	}
+}
+
+func TestGetExclusionGroups(t *testing.T) {
+	a := require.New(t)
+	policy := &storepb.ConcurrencyPolicy{
+		ExclusionGroups: []*storepb.ExclusionGroup{
+			{Title: "cluster-ddl", Instances: []string{"instances/a", "instances/b"}, Scope: storepb.ExclusionGroup_SCHEMA_CHANGE},
+			{Title: "cluster", Instances: []string{"instances/b"}, Scope: storepb.ExclusionGroup_ALL},
+		},
+	}
+	a.Equal([]string{"cluster-ddl"}, getExclusionGroups(policy, "a", api.TaskDatabaseSchemaUpdate))
+	a.Empty(getExclusionGroups(policy, "a", api.TaskDatabaseDataUpdate))
+	a.Equal([]string{"cluster-ddl", "cluster"}, getExclusionGroups(policy, "b", api.TaskDatabaseSchemaUpdateGhostSync))
+	a.Equal([]string{"cluster"}, getExclusionGroups(policy, "b", api.TaskDatabaseDataUpdate))
+	a.Empty(getExclusionGroups(nil, "b", api.TaskDatabaseDataUpdate))
+}
@@ -0,0 +1,95 @@
+package taskrun
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/bytebase/bytebase/backend/component/batchdml"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/state"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// getBatchedDataUpdatePayload returns the payload of the data update task to execute in batches, or nil if the task
+// is not executed in batches.
+func getBatchedDataUpdatePayload(task *store.TaskMessage) (*api.TaskDatabaseDataUpdatePayload, error) {
+	if task.Type != api.TaskDatabaseDataUpdate {
+		return nil, nil
+	}
+	payload := &api.TaskDatabaseDataUpdatePayload{}
+	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+		return nil, errors.Wrap(err, "invalid database data update payload")
+	}
+	if payload.BatchConfig == nil {
+		return nil, nil
+	}
+	return payload, nil
+}
+
+// executeBatchedMigration executes the data update statement in batches. The checkpoint is saved to the progress table
+// on the target database with each batch, so that the next task run resumes from it if the task run is interrupted.
+func executeBatchedMigration(ctx context.Context, driverCtx context.Context, stores *store.Store, dbFactory *dbfactory.DBFactory, stateCfg *state.State, task *store.TaskMessage, taskRunUID int, instance *store.InstanceMessage, database *store.DatabaseMessage, driver db.Driver, mi *db.MigrationInfo, statement string, sheetID *int, payload *api.TaskDatabaseDataUpdatePayload) (string, string, error) {
+	config := batchdml.Config{
+		ID:            strconv.Itoa(task.ID),
+		BatchSize:     payload.BatchConfig.BatchSize,
+		Sleep:         time.Duration(payload.BatchConfig.SleepMs) * time.Millisecond,
+		MaxReplicaLag: time.Duration(payload.BatchConfig.MaxReplicaLagMs) * time.Millisecond,
+	}
+	if config.MaxReplicaLag > 0 {
+		switch instance.Engine {
+		case storepb.Engine_POSTGRES:
+			config.GetReplicaLag = func(ctx context.Context) (time.Duration, error) {
+				return batchdml.GetPostgresReplicaLag(ctx, driver.GetDB())
+			}
+		case storepb.Engine_MYSQL, storepb.Engine_MARIADB:
+			if utils.DataSourceFromInstanceWithType(instance, api.RO) == nil {
+				return "", "", errors.Errorf("checking the replica lag requires the read-only data source connecting to the replica")
+			}
+			replicaDriver, err := dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, database)
+			if err != nil {
+				return "", "", errors.Wrapf(err, "failed to connect to the replica")
+			}
+			defer replicaDriver.Close(ctx)
+			config.GetReplicaLag = func(ctx context.Context) (time.Duration, error) {
+				return batchdml.GetMySQLReplicaLag(ctx, replicaDriver.GetDB())
+			}
+		}
+	}
+
+	execFunc := func(execCtx context.Context, execStatement string) error {
+		execution, err := batchdml.NewExecution(execCtx, instance.Engine, driver.GetDB(), execStatement, config)
+		if err != nil {
+			return err
+		}
+		createdTs := time.Now().Unix()
+		return execution.Run(execCtx, func(_ batchdml.Checkpoint, progress batchdml.Progress) error {
+			detail := &v1pb.TaskRun_ExecutionDetail{
+				TotalBatches:     progress.TotalBatches,
+				CompletedBatches: progress.CompletedBatches,
+				AffectedRows:     progress.AffectedRows,
+			}
+			if progress.ReplicaLag > 0 {
+				detail.ReplicaLag = durationpb.New(progress.ReplicaLag)
+			}
+			utils.UpdateTaskRunExecutionStatus(ctx, stores, taskRunUID, v1pb.TaskRun_EXECUTING, detail)
+			stateCfg.TaskProgress.Store(task.ID, api.Progress{
+				TotalUnit:     progress.TotalBatches,
+				CompletedUnit: progress.CompletedBatches,
+				CreatedTs:     createdTs,
+				UpdatedTs:     time.Now().Unix(),
+			})
+			return nil
+		})
+	}
+	return utils.ExecuteMigrationWithFunc(ctx, driverCtx, stores, stateCfg, taskRunUID, driver, mi, statement, sheetID, execFunc)
+}
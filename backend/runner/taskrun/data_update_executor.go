@@ -3,7 +3,6 @@ package taskrun
 import (
 	"context"
 	"encoding/json"
-	"time"
 
 	"github.com/pkg/errors"
 
@@ -19,6 +18,7 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/store/model"
+	"github.com/bytebase/bytebase/backend/utils"
 )
 
 // NewDataUpdateExecutor creates a data update (DML) task executor.
@@ -45,11 +45,7 @@ type DataUpdateExecutor struct {
 
 // RunOnce will run the data update (DML) task executor once.
 func (exec *DataUpdateExecutor) RunOnce(ctx context.Context, driverCtx context.Context, task *store.TaskMessage, taskRunUID int) (terminated bool, result *api.TaskRunResultPayload, err error) {
-	exec.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
-		state.TaskRunExecutionStatus{
-			ExecutionStatus: v1pb.TaskRun_PRE_EXECUTING,
-			UpdateTime:      time.Now(),
-		})
+	utils.UpdateTaskRunExecutionStatus(ctx, exec.store, taskRunUID, v1pb.TaskRun_PRE_EXECUTING, nil)
 
 	payload := &api.TaskDatabaseDataUpdatePayload{}
 	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
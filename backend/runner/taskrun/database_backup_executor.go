@@ -7,7 +7,6 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/sys/unix"
@@ -21,6 +20,7 @@ import (
 	bbs3 "github.com/bytebase/bytebase/backend/plugin/storage/s3"
 	"github.com/bytebase/bytebase/backend/runner/backuprun"
 	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
@@ -52,11 +52,7 @@ type DatabaseBackupExecutor struct {
 // RunOnce will run database backup once.
 // TODO: support cancellation.
 func (exec *DatabaseBackupExecutor) RunOnce(ctx context.Context, _ context.Context, task *store.TaskMessage, taskRunUID int) (terminated bool, result *api.TaskRunResultPayload, err error) {
-	exec.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
-		state.TaskRunExecutionStatus{
-			ExecutionStatus: v1pb.TaskRun_PRE_EXECUTING,
-			UpdateTime:      time.Now(),
-		})
+	utils.UpdateTaskRunExecutionStatus(ctx, exec.store, taskRunUID, v1pb.TaskRun_PRE_EXECUTING, nil)
 
 	payload := &api.TaskDatabaseBackupPayload{}
 	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
@@ -92,20 +88,12 @@ func (exec *DatabaseBackupExecutor) RunOnce(ctx context.Context, _ context.Conte
 		}
 	}
 
-	exec.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
-		state.TaskRunExecutionStatus{
-			ExecutionStatus: v1pb.TaskRun_EXECUTING,
-			UpdateTime:      time.Now(),
-		})
+	utils.UpdateTaskRunExecutionStatus(ctx, exec.store, taskRunUID, v1pb.TaskRun_EXECUTING, nil)
 
 	slog.Debug("Start database backup.", slog.String("instance", instance.Title), slog.String("database", database.DatabaseName), slog.String("backup", backup.Name))
 	backupPayload, backupErr := exec.backupDatabase(ctx, exec.dbFactory, exec.s3Client, exec.profile, instance, database, backup)
 
-	exec.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
-		state.TaskRunExecutionStatus{
-			ExecutionStatus: v1pb.TaskRun_POST_EXECUTING,
-			UpdateTime:      time.Now(),
-		})
+	utils.UpdateTaskRunExecutionStatus(ctx, exec.store, taskRunUID, v1pb.TaskRun_POST_EXECUTING, nil)
 
 	backupStatus := string(api.BackupStatusDone)
 	comment := ""
@@ -12,11 +12,13 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/activity"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	"github.com/bytebase/bytebase/backend/component/state"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	webhookPlugin "github.com/bytebase/bytebase/backend/plugin/webhook"
 	"github.com/bytebase/bytebase/backend/runner/schemasync"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/store/model"
@@ -26,23 +28,25 @@ import (
 )
 
 // NewDatabaseCreateExecutor creates a database create task executor.
-func NewDatabaseCreateExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, schemaSyncer *schemasync.Syncer, stateCfg *state.State, profile config.Profile) Executor {
+func NewDatabaseCreateExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, schemaSyncer *schemasync.Syncer, stateCfg *state.State, profile config.Profile, activityManager *activity.Manager) Executor {
 	return &DatabaseCreateExecutor{
-		store:        store,
-		dbFactory:    dbFactory,
-		schemaSyncer: schemaSyncer,
-		stateCfg:     stateCfg,
-		profile:      profile,
+		store:           store,
+		dbFactory:       dbFactory,
+		schemaSyncer:    schemaSyncer,
+		stateCfg:        stateCfg,
+		profile:         profile,
+		activityManager: activityManager,
 	}
 }
 
 // DatabaseCreateExecutor is the database create task executor.
 type DatabaseCreateExecutor struct {
-	store        *store.Store
-	dbFactory    *dbfactory.DBFactory
-	schemaSyncer *schemasync.Syncer
-	stateCfg     *state.State
-	profile      config.Profile
+	store           *store.Store
+	dbFactory       *dbfactory.DBFactory
+	schemaSyncer    *schemasync.Syncer
+	stateCfg        *state.State
+	profile         config.Profile
+	activityManager *activity.Manager
 }
 
 var cannotCreateDatabase = map[storepb.Engine]bool{
@@ -53,11 +57,7 @@ var cannotCreateDatabase = map[storepb.Engine]bool{
 
 // RunOnce will run the database create task executor once.
 func (exec *DatabaseCreateExecutor) RunOnce(ctx context.Context, driverCtx context.Context, task *store.TaskMessage, taskRunUID int) (terminated bool, result *api.TaskRunResultPayload, err error) {
-	exec.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
-		state.TaskRunExecutionStatus{
-			ExecutionStatus: v1pb.TaskRun_PRE_EXECUTING,
-			UpdateTime:      time.Now(),
-		})
+	utils.UpdateTaskRunExecutionStatus(ctx, exec.store, taskRunUID, v1pb.TaskRun_PRE_EXECUTING, nil)
 
 	payload := &api.TaskDatabaseCreatePayload{}
 	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
@@ -153,21 +153,13 @@ func (exec *DatabaseCreateExecutor) RunOnce(ctx context.Context, driverCtx conte
 	}
 	defer defaultDBDriver.Close(ctx)
 
-	exec.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
-		state.TaskRunExecutionStatus{
-			ExecutionStatus: v1pb.TaskRun_EXECUTING,
-			UpdateTime:      time.Now(),
-		})
+	utils.UpdateTaskRunExecutionStatus(ctx, exec.store, taskRunUID, v1pb.TaskRun_EXECUTING, nil)
 
 	if _, err := defaultDBDriver.Execute(driverCtx, statement, true /* createDatabase */, db.ExecuteOptions{}); err != nil {
 		return true, nil, err
 	}
 
-	exec.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
-		state.TaskRunExecutionStatus{
-			ExecutionStatus: v1pb.TaskRun_POST_EXECUTING,
-			UpdateTime:      time.Now(),
-		})
+	utils.UpdateTaskRunExecutionStatus(ctx, exec.store, taskRunUID, v1pb.TaskRun_POST_EXECUTING, nil)
 
 	environmentID := instance.EnvironmentID
 	if payload.EnvironmentID != "" {
@@ -192,6 +184,15 @@ func (exec *DatabaseCreateExecutor) RunOnce(ctx context.Context, driverCtx conte
 	}, api.SystemBotID); err != nil {
 		return true, nil, err
 	}
+	exec.activityManager.PostWorkspaceEvent(ctx, task.CreatorID, &webhookPlugin.Context{
+		ActivityType: string(api.ActivityDatabaseCreate),
+		Title:        fmt.Sprintf("Database created - %s/%s", instance.ResourceID, payload.DatabaseName),
+		Database: &webhookPlugin.Database{
+			Name:     payload.DatabaseName,
+			Instance: instance.ResourceID,
+			Project:  project.ResourceID,
+		},
+	})
 
 	// After the task related database entry created successfully,
 	// we need to update task's database_id and statement with the newly created database immediately.
@@ -23,6 +23,7 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
+	"github.com/bytebase/bytebase/backend/plugin/db/pg"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/transform"
 	vcsPlugin "github.com/bytebase/bytebase/backend/plugin/vcs"
 	"github.com/bytebase/bytebase/backend/store"
@@ -45,6 +46,12 @@ type Executor interface {
 	RunOnce(ctx context.Context, driverCtx context.Context, task *store.TaskMessage, taskRunUID int) (terminated bool, result *api.TaskRunResultPayload, err error)
 }
 
+// Cleaner is implemented by the executors whose tasks leave the changes in the database for the following tasks.
+type Cleaner interface {
+	// Cleanup drops the changes left in the database for the task, which is skipped or whose issue is canceled.
+	Cleanup(ctx context.Context, task *store.TaskMessage) error
+}
+
 // RunExecutorOnce wraps a TaskExecutor.RunOnce call with panic recovery.
 func RunExecutorOnce(ctx context.Context, driverCtx context.Context, exec Executor, task *store.TaskMessage, taskRunUID int) (terminated bool, result *api.TaskRunResultPayload, err error) {
 	defer func() {
@@ -179,6 +186,32 @@ func getMigrationInfo(ctx context.Context, stores *store.Store, profile config.P
 	return mi, nil
 }
 
+// setMigrationTimeoutOptions sets the timeouts and retries from the migration timeout policy of the database's project and environment.
+func setMigrationTimeoutOptions(ctx context.Context, stores *store.Store, database *store.DatabaseMessage, opts *db.ExecuteOptions) error {
+	environment, err := stores.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{ResourceID: &database.EffectiveEnvironmentID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get environment %q", database.EffectiveEnvironmentID)
+	}
+	if environment == nil {
+		return errors.Errorf("environment %q not found", database.EffectiveEnvironmentID)
+	}
+	project, err := stores.GetProjectV2(ctx, &store.FindProjectMessage{ResourceID: &database.ProjectID})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get project %q", database.ProjectID)
+	}
+	if project == nil {
+		return errors.Errorf("project %q not found", database.ProjectID)
+	}
+	policy, err := stores.GetMigrationTimeoutPolicy(ctx, environment.UID, project.UID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get migration timeout policy")
+	}
+	opts.LockTimeout = policy.GetLockTimeout().AsDuration()
+	opts.StatementTimeout = policy.GetStatementTimeout().AsDuration()
+	opts.LockTimeoutRetries = int(policy.MaxRetries)
+	return nil
+}
+
 func executeMigration(ctx context.Context, driverCtx context.Context, stores *store.Store, dbFactory *dbfactory.DBFactory, stateCfg *state.State, task *store.TaskMessage, taskRunUID int, statement string, sheetID *int, mi *db.MigrationInfo) (string, string, error) {
 	instance, err := stores.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
 	if err != nil {
@@ -221,11 +254,61 @@ func executeMigration(ctx context.Context, driverCtx context.Context, stores *st
 		opts.EndTransactionFunc = getSetOracleTransactionIDFunc(ctx, task, stores)
 	}
 
-	migrationID, schema, err := utils.ExecuteMigrationDefault(ctx, driverCtx, stores, stateCfg, taskRunUID, driver, mi, statement, sheetID, opts)
+	// For PostgreSQL, we capture the before-images in the migration transaction to generate the rollback SQL.
+	var pgRollbackCapture *pg.RollbackCapture
+	var pgRollbackStatement string
+	var pgRollbackErr error
+	if task.Type == api.TaskDatabaseDataUpdate && instance.Engine == storepb.Engine_POSTGRES {
+		payload := &api.TaskDatabaseDataUpdatePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			return "", "", errors.Wrap(err, "invalid database data update payload")
+		}
+		if payload.RollbackEnabled {
+			if len(statement) > common.MaxSheetSizeForRollback {
+				pgRollbackErr = errors.Errorf("rollback SQL isn't supported for large sheet")
+			} else {
+				pgRollbackCapture, pgRollbackErr = pg.NewRollbackCapture(statement)
+			}
+		}
+	}
+	if pgRollbackCapture != nil {
+		opts.BeginTransactionFunc = func(tx *sql.Tx) error {
+			// Failing to capture the before-images doesn't block the migration.
+			pgRollbackErr = pgRollbackCapture.Begin(ctx, tx)
+			return nil
+		}
+		opts.EndTransactionFunc = func(tx *sql.Tx) error {
+			if pgRollbackErr == nil {
+				pgRollbackStatement, pgRollbackErr = pgRollbackCapture.Generate(ctx, tx)
+			}
+			return pgRollbackCapture.Cleanup(ctx, tx)
+		}
+	}
+
+	if database != nil {
+		if err := setMigrationTimeoutOptions(ctx, stores, database, &opts); err != nil {
+			return "", "", err
+		}
+	}
+
+	batchPayload, err := getBatchedDataUpdatePayload(task)
+	if err != nil {
+		return "", "", err
+	}
+	var schema string
+	if batchPayload != nil {
+		migrationID, schema, err = executeBatchedMigration(ctx, driverCtx, stores, dbFactory, stateCfg, task, taskRunUID, instance, database, driver, mi, statement, sheetID, batchPayload)
+	} else {
+		migrationID, schema, err = utils.ExecuteMigrationDefault(ctx, driverCtx, stores, stateCfg, taskRunUID, driver, mi, statement, sheetID, opts)
+	}
 	if err != nil {
 		return "", "", err
 	}
 
+	if pgRollbackCapture != nil || pgRollbackErr != nil {
+		updatePostgresRollbackSQL(ctx, stores, task, database, pgRollbackStatement, pgRollbackErr)
+	}
+
 	// If the migration is a data migration, enable the rollback SQL generation and the type of the driver is Oracle, we need to get the rollback SQL before the transaction is committed.
 	if task.Type == api.TaskDatabaseDataUpdate && instance.Engine == storepb.Engine_ORACLE {
 		updatedTask, err := stores.GetTaskV2ByID(ctx, task.ID)
@@ -266,6 +349,64 @@ func executeMigration(ctx context.Context, driverCtx context.Context, stores *st
 	return migrationID, schema, nil
 }
 
+// updatePostgresRollbackSQL stores the rollback SQL generated in the migration transaction to the task.
+// The migration has been committed, so we only log the errors here.
+// No rollback sheet is created if the generation fails, the error is recorded on the task instead.
+func updatePostgresRollbackSQL(ctx context.Context, stores *store.Store, task *store.TaskMessage, database *store.DatabaseMessage, rollbackStatement string, rollbackErr error) {
+	if rollbackErr != nil {
+		slog.Error("Failed to generate rollback SQL statement", slog.Int("taskID", task.ID), log.BBError(rollbackErr))
+		rollbackSQLStatus := api.RollbackSQLStatusFailed
+		rollbackError := rollbackErr.Error()
+		patch := &api.TaskPatch{
+			ID:                task.ID,
+			UpdaterID:         api.SystemBotID,
+			RollbackSQLStatus: &rollbackSQLStatus,
+			RollbackError:     &rollbackError,
+		}
+		if _, err := stores.UpdateTaskV2(ctx, patch); err != nil {
+			slog.Error("Failed to patch task with the PostgreSQL rollback SQL error", slog.Int("taskID", task.ID), log.BBError(err))
+		}
+		return
+	}
+
+	project, err := stores.GetProjectV2(ctx, &store.FindProjectMessage{ResourceID: &database.ProjectID})
+	if err != nil {
+		slog.Error("Failed to find project", slog.String("project", database.ProjectID), log.BBError(err))
+		return
+	}
+	if project == nil {
+		slog.Error("Project not found", slog.String("project", database.ProjectID))
+		return
+	}
+	sheet, err := stores.CreateSheet(ctx, &store.SheetMessage{
+		CreatorID:  api.SystemBotID,
+		ProjectUID: project.UID,
+		Name:       fmt.Sprintf("Sheet for rolling back task %d", task.ID),
+		Statement:  rollbackStatement,
+		Visibility: store.ProjectSheet,
+		Source:     store.SheetFromBytebaseArtifact,
+		Type:       store.SheetForSQL,
+	})
+	if err != nil {
+		slog.Error("Failed to create rollback sheet", slog.Int("taskID", task.ID), log.BBError(err))
+		return
+	}
+	rollbackSQLStatus := api.RollbackSQLStatusDone
+	rollbackError := ""
+	patch := &api.TaskPatch{
+		ID:                task.ID,
+		UpdaterID:         api.SystemBotID,
+		RollbackSQLStatus: &rollbackSQLStatus,
+		RollbackSheetID:   &sheet.UID,
+		RollbackError:     &rollbackError,
+	}
+	if _, err := stores.UpdateTaskV2(ctx, patch); err != nil {
+		slog.Error("Failed to patch task with the PostgreSQL rollback SQL", slog.Int("taskID", task.ID), log.BBError(err))
+		return
+	}
+	slog.Debug("Rollback SQL generation success", slog.Int("taskID", task.ID))
+}
+
 func getSetOracleTransactionIDFunc(ctx context.Context, task *store.TaskMessage, store *store.Store) func(tx *sql.Tx) error {
 	return func(tx *sql.Tx) error {
 		payload := &api.TaskDatabaseDataUpdatePayload{}
@@ -56,11 +56,7 @@ type PITRCutoverExecutor struct {
 // RunOnce will run the PITR cutover task executor once.
 // TODO: support cancellation.
 func (exec *PITRCutoverExecutor) RunOnce(ctx context.Context, _ context.Context, task *store.TaskMessage, taskRunUID int) (terminated bool, result *api.TaskRunResultPayload, err error) {
-	exec.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
-		state.TaskRunExecutionStatus{
-			ExecutionStatus: v1pb.TaskRun_EXECUTING,
-			UpdateTime:      time.Now(),
-		})
+	utils.UpdateTaskRunExecutionStatus(ctx, exec.store, taskRunUID, v1pb.TaskRun_EXECUTING, nil)
 
 	slog.Info("Run PITR cutover task", slog.String("task", task.Name))
 	issue, err := exec.store.GetIssueV2(ctx, &store.FindIssueMessage{PipelineID: &task.PipelineID})
@@ -57,11 +57,7 @@ type PITRRestoreExecutor struct {
 // RunOnce will run the PITR restore task executor once.
 // TODO: support cancellation.
 func (exec *PITRRestoreExecutor) RunOnce(ctx context.Context, _ context.Context, task *store.TaskMessage, taskRunUID int) (terminated bool, result *api.TaskRunResultPayload, err error) {
-	exec.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
-		state.TaskRunExecutionStatus{
-			ExecutionStatus: v1pb.TaskRun_EXECUTING,
-			UpdateTime:      time.Now(),
-		})
+	utils.UpdateTaskRunExecutionStatus(ctx, exec.store, taskRunUID, v1pb.TaskRun_EXECUTING, nil)
 
 	slog.Info("Run PITR restore task", slog.String("task", task.Name))
 
@@ -240,8 +236,8 @@ func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *d
 	}
 	slog.Debug("Found backup list", "backups", store.SLogBackupArray(backupList))
 
-	mysqlSourceDriver, sourceOk := sourceDriver.(*mysql.Driver)
-	mysqlTargetDriver, targetOk := targetDriver.(*mysql.Driver)
+	mysqlSourceDriver, sourceOk := db.UnwrapDriver(sourceDriver).(*mysql.Driver)
+	mysqlTargetDriver, targetOk := db.UnwrapDriver(targetDriver).(*mysql.Driver)
 	if (!sourceOk) || (!targetOk) {
 		slog.Error("Failed to cast driver to mysql.Driver")
 		return nil, errors.Errorf("[internal] cast driver to mysql.Driver failed")
@@ -394,7 +390,7 @@ func (*PITRRestoreExecutor) doRestoreInPlacePostgres(ctx context.Context, stores
 	}
 	defer driver.Close(ctx)
 
-	pgDriver, ok := driver.(*pg.Driver)
+	pgDriver, ok := db.UnwrapDriver(driver).(*pg.Driver)
 	if !ok {
 		slog.Error("Failed to cast driver to pg.Driver")
 		return nil, errors.Errorf("[internal] cast driver to pg.Driver failed")
@@ -16,6 +16,7 @@ import (
 	"github.com/bytebase/bytebase/backend/component/activity"
 	"github.com/bytebase/bytebase/backend/component/state"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/metric"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -31,6 +32,10 @@ type SchedulerV2 struct {
 	stateCfg        *state.State
 	activityManager *activity.Manager
 	executorMap     map[api.TaskType]Executor
+	// runningSlots is the slots held by the executing task runs, guarded by the stateCfg lock.
+	runningSlots map[int]*taskRunSlot
+	// executing tracks the goroutines executing the task runs, which Run waits for before returning.
+	executing sync.WaitGroup
 }
 
 // NewSchedulerV2 will create a new scheduler.
@@ -40,6 +45,7 @@ func NewSchedulerV2(store *store.Store, stateCfg *state.State, activityManager *
 		stateCfg:        stateCfg,
 		activityManager: activityManager,
 		executorMap:     map[api.TaskType]Executor{},
+		runningSlots:    map[int]*taskRunSlot{},
 	}
 }
 
@@ -55,9 +61,9 @@ func (s *SchedulerV2) Register(taskType api.TaskType, executorGetter Executor) {
 }
 
 // Run will start the scheduler.
+// The executing task runs are canceled with the context, and Run returns after they exit, so that the replica keeps
+// the leader lease until then.
 func (s *SchedulerV2) Run(ctx context.Context, wg *sync.WaitGroup) {
-	go s.ListenTaskSkippedOrDone(ctx)
-
 	ticker := time.NewTicker(taskSchedulerInterval)
 	defer ticker.Stop()
 	defer wg.Done()
@@ -69,6 +75,8 @@ func (s *SchedulerV2) Run(ctx context.Context, wg *sync.WaitGroup) {
 		case <-s.stateCfg.TaskRunTickleChan:
 			s.runOnce(ctx)
 		case <-ctx.Done():
+			s.executing.Wait()
+			abortGhostMigrations(s.stateCfg)
 			return
 		}
 	}
@@ -96,6 +104,10 @@ func (s *SchedulerV2) runOnce(ctx context.Context) {
 	if err := s.scheduleRunningTaskRuns(ctx); err != nil {
 		slog.Error("failed to schedule running task runs", log.BBError(err))
 	}
+
+	if err := s.cancelCanceledTaskRuns(ctx); err != nil {
+		slog.Error("failed to cancel canceled task runs", log.BBError(err))
+	}
 }
 
 func (s *SchedulerV2) scheduleAutoRolloutTasks(ctx context.Context) error {
@@ -306,6 +318,8 @@ func (s *SchedulerV2) scheduleRunningTaskRuns(ctx context.Context) error {
 		}
 	}
 
+	// stageEnvironments is the environment ID of each stage.
+	stageEnvironments := map[int]int{}
 	for _, taskRun := range taskRuns {
 		// Skip the task run if it is already executing.
 		if _, ok := s.stateCfg.RunningTaskRuns.Load(taskRun.ID); ok {
@@ -335,36 +349,149 @@ func (s *SchedulerV2) scheduleRunningTaskRuns(ctx context.Context) error {
 			)
 			continue
 		}
+		slot, policies, err := s.getTaskRunSlot(ctx, taskRun, task, instance, stageEnvironments)
+		if err != nil {
+			slog.Error("failed to get task run concurrency policies", slog.Int("task run id", taskRun.ID), log.BBError(err))
+			continue
+		}
 		s.stateCfg.Lock()
 		if s.stateCfg.InstanceOutstandingConnections[task.InstanceID] >= state.InstanceMaximumConnectionNumber {
 			s.stateCfg.Unlock()
 			continue
 		}
+		var running []*taskRunSlot
+		for _, runningSlot := range s.runningSlots {
+			running = append(running, runningSlot)
+		}
+		canStart, reason, err := canStartTaskRun(slot, running, policies, time.Now())
+		if err != nil || !canStart {
+			s.stateCfg.Unlock()
+			if err != nil {
+				slog.Error("failed to check task run concurrency policies", slog.Int("task run id", taskRun.ID), log.BBError(err))
+			} else {
+				slog.Debug("task run is held by the concurrency policies", slog.Int("task run id", taskRun.ID), slog.String("reason", reason))
+			}
+			continue
+		}
 		s.stateCfg.InstanceOutstandingConnections[task.InstanceID]++
+		s.runningSlots[taskRun.ID] = slot
 		s.stateCfg.Unlock()
 
 		s.stateCfg.RunningTaskRuns.Store(taskRun.ID, true)
-		go s.runTaskRunOnce(ctx, taskRun, task, executor)
+		s.executing.Add(1)
+		go func(taskRun *store.TaskRunMessage, task *store.TaskMessage, instance *store.InstanceMessage, executor Executor) {
+			defer s.executing.Done()
+			s.runTaskRunOnce(ctx, taskRun, task, instance, executor)
+		}(taskRun, task, instance, executor)
+	}
+
+	return nil
+}
+
+// getTaskRunSlot gets the slot held by the task run and the concurrency policies applied to it.
+func (s *SchedulerV2) getTaskRunSlot(ctx context.Context, taskRun *store.TaskRunMessage, task *store.TaskMessage, instance *store.InstanceMessage, stageEnvironments map[int]int) (*taskRunSlot, concurrencyPolicies, error) {
+	policies := concurrencyPolicies{}
+	if _, ok := stageEnvironments[task.StageID]; !ok {
+		stages, err := s.store.ListStageV2(ctx, task.PipelineID)
+		if err != nil {
+			return nil, policies, errors.Wrapf(err, "failed to list stages of pipeline %d", task.PipelineID)
+		}
+		for _, stage := range stages {
+			stageEnvironments[stage.ID] = stage.EnvironmentID
+		}
 	}
+	environmentID, ok := stageEnvironments[task.StageID]
+	if !ok {
+		return nil, policies, errors.Errorf("stage %d not found", task.StageID)
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{ResourceID: &taskRun.ProjectID})
+	if err != nil {
+		return nil, policies, errors.Wrapf(err, "failed to get project %q", taskRun.ProjectID)
+	}
+	if project == nil {
+		return nil, policies, errors.Errorf("project %q not found", taskRun.ProjectID)
+	}
+
+	for _, resource := range []struct {
+		resourceType api.PolicyResourceType
+		resourceID   int
+		policy       **storepb.ConcurrencyPolicy
+	}{
+		{api.PolicyResourceTypeWorkspace, 0, &policies.workspace},
+		{api.PolicyResourceTypeEnvironment, environmentID, &policies.environment},
+		{api.PolicyResourceTypeInstance, instance.UID, &policies.instance},
+		{api.PolicyResourceTypeProject, project.UID, &policies.project},
+	} {
+		policy, err := s.store.GetConcurrencyPolicy(ctx, resource.resourceType, resource.resourceID)
+		if err != nil {
+			return nil, policies, errors.Wrapf(err, "failed to get %s concurrency policy", resource.resourceType)
+		}
+		*resource.policy = policy
+	}
+
+	return &taskRunSlot{
+		instanceID:      instance.UID,
+		environmentID:   environmentID,
+		projectID:       project.UID,
+		exclusionGroups: getExclusionGroups(policies.workspace, instance.ResourceID, task.Type),
+	}, policies, nil
+}
 
+// cancelCanceledTaskRuns cancels the executing task runs which are canceled in the database,
+// e.g. by the API on another replica.
+func (s *SchedulerV2) cancelCanceledTaskRuns(ctx context.Context) error {
+	var taskRunIDs []int
+	s.stateCfg.RunningTaskRunsCancelFunc.Range(func(key, _ any) bool {
+		taskRunIDs = append(taskRunIDs, key.(int))
+		return true
+	})
+	if len(taskRunIDs) == 0 {
+		return nil
+	}
+	taskRuns, err := s.store.ListTaskRunsV2(ctx, &store.FindTaskRunMessage{
+		UIDs:   &taskRunIDs,
+		Status: &[]api.TaskRunStatus{api.TaskRunCanceled},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list canceled task runs")
+	}
+	for _, taskRun := range taskRuns {
+		if cancelFunc, ok := s.stateCfg.RunningTaskRunsCancelFunc.Load(taskRun.ID); ok {
+			cancelFunc.(context.CancelFunc)()
+		}
+	}
 	return nil
 }
 
-func (s *SchedulerV2) runTaskRunOnce(ctx context.Context, taskRun *store.TaskRunMessage, task *store.TaskMessage, executor Executor) {
+func (s *SchedulerV2) runTaskRunOnce(ctx context.Context, taskRun *store.TaskRunMessage, task *store.TaskMessage, instance *store.InstanceMessage, executor Executor) {
 	defer func() {
-		s.stateCfg.TaskRunExecutionStatuses.Delete(taskRun.ID)
+		// The task run may be stopped by the leader stepping down, delete the execution status anyway.
+		if err := s.store.DeleteTaskRunExecution(context.WithoutCancel(ctx), taskRun.ID); err != nil {
+			slog.Warn("Failed to delete task run execution status", slog.Int("taskRun", taskRun.ID), log.BBError(err))
+		}
 
 		s.stateCfg.RunningTaskRuns.Delete(taskRun.ID)
 		s.stateCfg.RunningTaskRunsCancelFunc.Delete(taskRun.ID)
 		s.stateCfg.Lock()
 		s.stateCfg.InstanceOutstandingConnections[task.InstanceID]--
+		delete(s.runningSlots, taskRun.ID)
 		s.stateCfg.Unlock()
 	}()
 
 	driverCtx, cancel := context.WithCancel(ctx)
 	s.stateCfg.RunningTaskRunsCancelFunc.Store(taskRun.ID, cancel)
 
+	start := time.Now()
 	done, result, err := RunExecutorOnce(ctx, driverCtx, executor, task, taskRun.ID)
+	if done {
+		status := api.TaskRunDone
+		if err != nil && errors.Is(err, context.Canceled) {
+			status = api.TaskRunCanceled
+		} else if err != nil {
+			status = api.TaskRunFailed
+		}
+		metric.ObserveTaskRun(instance.Engine.String(), string(task.Type), string(status), time.Since(start))
+	}
 
 	if !done && err != nil {
 		slog.Debug("Encountered transient error running task, will retry",
@@ -497,7 +624,42 @@ func (s *SchedulerV2) runTaskRunOnce(ctx context.Context, taskRun *store.TaskRun
 	}
 }
 
-func (s *SchedulerV2) ListenTaskSkippedOrDone(ctx context.Context) {
+// ListenTaskCleanup drops the changes left in the database for the tasks which are skipped or whose issues are
+// canceled. It runs on every replica because tasks can be skipped and issues can be canceled by the API on any replica.
+func (s *SchedulerV2) ListenTaskCleanup(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	slog.Info("TaskCleanupListener started")
+	for {
+		select {
+		case taskUID := <-s.stateCfg.TaskCleanupChan:
+			if err := s.cleanupTask(ctx, taskUID); err != nil {
+				slog.Error("failed to clean up task", slog.Int("id", taskUID), log.BBError(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *SchedulerV2) cleanupTask(ctx context.Context, taskUID int) error {
+	task, err := s.store.GetTaskV2ByID(ctx, taskUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get task")
+	}
+	if task == nil {
+		return nil
+	}
+	cleaner, ok := s.executorMap[task.Type].(Cleaner)
+	if !ok {
+		return nil
+	}
+	return cleaner.Cleanup(ctx, task)
+}
+
+// ListenTaskSkippedOrDone handles the skipped or done tasks.
+// It runs on every replica because tasks can be skipped by the API on any replica.
+func (s *SchedulerV2) ListenTaskSkippedOrDone(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
 	defer func() {
 		if r := recover(); r != nil {
 			err, ok := r.(error)
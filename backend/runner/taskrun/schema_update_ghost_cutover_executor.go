@@ -17,6 +17,7 @@ import (
 	"github.com/bytebase/bytebase/backend/component/activity"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/pgosc"
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
@@ -25,6 +26,7 @@ import (
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/store/model"
 	"github.com/bytebase/bytebase/backend/utils"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
@@ -55,11 +57,7 @@ type SchemaUpdateGhostCutoverExecutor struct {
 // RunOnce will run SchemaUpdateGhostCutover task once.
 // TODO: support cancellation.
 func (e *SchemaUpdateGhostCutoverExecutor) RunOnce(ctx context.Context, _ context.Context, task *store.TaskMessage, taskRunUID int) (bool, *api.TaskRunResultPayload, error) {
-	e.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
-		state.TaskRunExecutionStatus{
-			ExecutionStatus: v1pb.TaskRun_PRE_EXECUTING,
-			UpdateTime:      time.Now(),
-		})
+	utils.UpdateTaskRunExecutionStatus(ctx, e.store, taskRunUID, v1pb.TaskRun_PRE_EXECUTING, nil)
 
 	if len(task.BlockedBy) != 1 {
 		return true, nil, errors.Errorf("failed to find task dag for ToTask %v", task.ID)
@@ -91,6 +89,19 @@ func (e *SchemaUpdateGhostCutoverExecutor) RunOnce(ctx context.Context, _ contex
 	materials := utils.GetSecretMapFromDatabaseMessage(database)
 	// To avoid leaking the rendered statement, the error message should use the original statement and not the rendered statement.
 	renderedStatement := utils.RenderStatement(statement, materials)
+	version := model.Version{Version: payload.SchemaVersion}
+
+	if instance.Engine == storepb.Engine_POSTGRES {
+		terminated, result, err := e.cutoverPostgres(ctx, instance, database, task, taskRunUID, statement, renderedStatement, payload.SheetID, version)
+		if err := e.schemaSyncer.SyncDatabaseSchema(ctx, database, true /* force */); err != nil {
+			slog.Error("failed to sync database schema",
+				slog.String("instanceName", instance.ResourceID),
+				slog.String("databaseName", database.DatabaseName),
+				log.BBError(err),
+			)
+		}
+		return terminated, result, err
+	}
 
 	tableName, err := utils.GetTableNameFromStatement(renderedStatement)
 	if err != nil {
@@ -101,12 +112,13 @@ func (e *SchemaUpdateGhostCutoverExecutor) RunOnce(ctx context.Context, _ contex
 
 	value, ok := e.stateCfg.GhostTaskState.Load(syncTaskID)
 	if !ok {
-		return true, nil, errors.Errorf("failed to get gh-ost state from sync task")
+		// The gh-ost state is local to the replica, and the synced migration is aborted when the replica steps down as
+		// the leader, so the state is lost if the server restarts or another replica becomes the leader after the sync.
+		return true, nil, errors.Errorf("failed to get gh-ost state from sync task %d, please rerun the sync task", syncTaskID)
 	}
 	sharedGhost := value.(sharedGhostState)
 
 	// not using the rendered statement here because we want to avoid leaking the rendered statement
-	version := model.Version{Version: payload.SchemaVersion}
 	terminated, result, err := cutover(ctx, e.store, e.dbFactory, e.activityManager, e.stateCfg, e.license, e.profile, task, taskRunUID, statement, payload.SheetID, version, postponeFilename, sharedGhost.migrationContext, sharedGhost.errCh)
 	if err := e.schemaSyncer.SyncDatabaseSchema(ctx, database, true /* force */); err != nil {
 		slog.Error("failed to sync database schema",
@@ -163,6 +175,95 @@ func cutover(ctx context.Context, stores *store.Store, dbFactory *dbfactory.DBFa
 	return postMigration(ctx, stores, activityManager, license, task, mi, migrationID, schema, &sheetID)
 }
 
+// cutoverPostgres swaps the original table and the shadow table synced by the sync task.
+// Unlike gh-ost, the state of the PostgreSQL online schema change is in the database, so it doesn't need the sync
+// task to run in the same process.
+func (e *SchemaUpdateGhostCutoverExecutor) cutoverPostgres(ctx context.Context, instance *store.InstanceMessage, database *store.DatabaseMessage, task *store.TaskMessage, taskRunUID int, statement, renderedStatement string, sheetID int, schemaVersion model.Version) (terminated bool, result *api.TaskRunResultPayload, err error) {
+	statement = strings.TrimSpace(statement)
+	mi, err := getMigrationInfo(ctx, e.store, e.profile, task, db.Migrate, statement, schemaVersion)
+	if err != nil {
+		return true, nil, err
+	}
+
+	driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return true, nil, err
+	}
+	defer driver.Close(ctx)
+	migration, err := pgosc.NewMigration(ctx, driver.GetDB(), strings.TrimSpace(renderedStatement))
+	if err != nil {
+		return true, nil, err
+	}
+	defer func() {
+		// The cutover cleans up if it fails, but the migration may also fail before the cutover.
+		if err != nil {
+			cleanupCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			if cleanupErr := migration.Cleanup(cleanupCtx); cleanupErr != nil {
+				slog.Error("failed to clean up online schema change", slog.String("table", migration.Table()), log.BBError(cleanupErr))
+			}
+		}
+	}()
+	execFunc := func(execCtx context.Context, _ string) error {
+		if err := migration.Cutover(execCtx); err != nil {
+			return errors.Wrapf(err, "failed to cutover table %s", migration.Table())
+		}
+		return nil
+	}
+	migrationID, schema, err := utils.ExecuteMigrationWithFunc(ctx, ctx, e.store, e.stateCfg, taskRunUID, driver, mi, statement, &sheetID, execFunc)
+	if err != nil {
+		return true, nil, err
+	}
+
+	return postMigration(ctx, e.store, e.activityManager, e.license, task, mi, migrationID, schema, &sheetID)
+}
+
+// Cleanup drops the shadow table and the sync trigger of the PostgreSQL online schema change if the cutover task
+// is skipped or the issue is canceled, because the sync trigger slows down the writes of the original table.
+func (e *SchemaUpdateGhostCutoverExecutor) Cleanup(ctx context.Context, task *store.TaskMessage) error {
+	if task.Status == api.TaskDone || len(task.BlockedBy) != 1 {
+		return nil
+	}
+	instance, err := e.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return err
+	}
+	if instance == nil || instance.Engine != storepb.Engine_POSTGRES {
+		return nil
+	}
+	database, err := e.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return err
+	}
+	if database == nil {
+		return errors.Errorf("database not found")
+	}
+	syncTask, err := e.store.GetTaskV2ByID(ctx, task.BlockedBy[0])
+	if err != nil {
+		return errors.Wrap(err, "failed to get schema update gh-ost sync task for cutover task")
+	}
+	payload := &api.TaskDatabaseSchemaUpdateGhostSyncPayload{}
+	if err := json.Unmarshal([]byte(syncTask.Payload), payload); err != nil {
+		return errors.Wrap(err, "invalid database schema update gh-ost sync payload")
+	}
+	statement, err := e.store.GetSheetStatementByID(ctx, payload.SheetID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get sheet statement by id: %d", payload.SheetID)
+	}
+	renderedStatement := utils.RenderStatement(statement, utils.GetSecretMapFromDatabaseMessage(database))
+
+	driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+	migration, err := pgosc.NewMigration(ctx, driver.GetDB(), strings.TrimSpace(renderedStatement))
+	if err != nil {
+		return err
+	}
+	return migration.Cleanup(ctx)
+}
+
 func waitForCutover(ctx context.Context, migrationContext *base.MigrationContext) bool {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
@@ -14,37 +14,39 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/pgosc"
 	"github.com/bytebase/bytebase/backend/component/state"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
 // NewSchemaUpdateGhostSyncExecutor creates a schema update (gh-ost) sync task executor.
-func NewSchemaUpdateGhostSyncExecutor(store *store.Store, stateCfg *state.State, secret string) Executor {
+func NewSchemaUpdateGhostSyncExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, stateCfg *state.State, secret string) Executor {
 	return &SchemaUpdateGhostSyncExecutor{
-		store:    store,
-		stateCfg: stateCfg,
-		secret:   secret,
+		store:     store,
+		dbFactory: dbFactory,
+		stateCfg:  stateCfg,
+		secret:    secret,
 	}
 }
 
 // SchemaUpdateGhostSyncExecutor is the schema update (gh-ost) sync task executor.
+// For PostgreSQL, it runs the trigger-based online schema change instead of gh-ost.
 type SchemaUpdateGhostSyncExecutor struct {
-	store    *store.Store
-	stateCfg *state.State
-	secret   string
+	store     *store.Store
+	dbFactory *dbfactory.DBFactory
+	stateCfg  *state.State
+	secret    string
 }
 
 // RunOnce will run SchemaUpdateGhostSync task once.
 // TODO: support cancellation.
 func (exec *SchemaUpdateGhostSyncExecutor) RunOnce(ctx context.Context, _ context.Context, task *store.TaskMessage, taskRunUID int) (terminated bool, result *api.TaskRunResultPayload, err error) {
-	exec.stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
-		state.TaskRunExecutionStatus{
-			ExecutionStatus: v1pb.TaskRun_EXECUTING,
-			UpdateTime:      time.Now(),
-		})
+	utils.UpdateTaskRunExecutionStatus(ctx, exec.store, taskRunUID, v1pb.TaskRun_EXECUTING, nil)
 
 	payload := &api.TaskDatabaseSchemaUpdateGhostSyncPayload{}
 	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
@@ -55,14 +57,84 @@ func (exec *SchemaUpdateGhostSyncExecutor) RunOnce(ctx context.Context, _ contex
 		return true, nil, err
 	}
 
+	instance, err := exec.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return true, nil, err
+	}
+	if instance == nil {
+		return true, nil, errors.Errorf("instance %d not found", task.InstanceID)
+	}
+	if instance.Engine == storepb.Engine_POSTGRES {
+		return exec.runPostgresOnlineMigration(ctx, instance, task, taskRunUID, statement)
+	}
 	return exec.runGhostMigration(ctx, exec.store, task, statement)
 }
 
+// runPostgresOnlineMigration creates the shadow table, starts syncing the changes with the trigger and backfills
+// the shadow table. The trigger keeps the shadow table in sync until the cutover task swaps the tables. The shadow
+// table and the trigger are dropped if the sync fails or is canceled, the cutover fails, the cutover task is skipped
+// or the issue is canceled.
+func (exec *SchemaUpdateGhostSyncExecutor) runPostgresOnlineMigration(ctx context.Context, instance *store.InstanceMessage, task *store.TaskMessage, taskRunUID int, statement string) (terminated bool, result *api.TaskRunResultPayload, err error) {
+	database, err := exec.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return true, nil, err
+	}
+	if database == nil {
+		return true, nil, errors.Errorf("database not found")
+	}
+	materials := utils.GetSecretMapFromDatabaseMessage(database)
+	renderedStatement := utils.RenderStatement(strings.TrimSpace(statement), materials)
+
+	driver, err := exec.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return true, nil, err
+	}
+	defer driver.Close(ctx)
+	migration, err := pgosc.NewMigration(ctx, driver.GetDB(), renderedStatement)
+	if err != nil {
+		return true, nil, err
+	}
+
+	createdTs := time.Now().Unix()
+	if err := migration.Sync(ctx, func(progress pgosc.Progress) {
+		utils.UpdateTaskRunExecutionStatus(ctx, exec.store, taskRunUID, v1pb.TaskRun_EXECUTING, &v1pb.TaskRun_ExecutionDetail{
+			TotalRows:  progress.TotalRows,
+			CopiedRows: progress.CopiedRows,
+		})
+		exec.stateCfg.TaskProgress.Store(task.ID, api.Progress{
+			TotalUnit:     progress.TotalRows,
+			CompletedUnit: progress.CopiedRows,
+			CreatedTs:     createdTs,
+			UpdatedTs:     time.Now().Unix(),
+		})
+	}); err != nil {
+		if ctx.Err() != nil {
+			return true, nil, errors.New("task canceled")
+		}
+		return true, nil, errors.Wrapf(err, "failed to sync table %s", migration.Table())
+	}
+	return true, &api.TaskRunResultPayload{Detail: "sync done"}, nil
+}
+
 type sharedGhostState struct {
 	migrationContext *base.MigrationContext
 	errCh            <-chan error
 }
 
+// abortGhostMigrations aborts the synced gh-ost migrations waiting for the cutover when the replica steps down as the
+// leader. The gh-ost state is local to the replica, so the cutover can't proceed on the new leader, and the gh-ost
+// migration would otherwise keep copying the binlogs on this replica.
+func abortGhostMigrations(stateCfg *state.State) {
+	stateCfg.GhostTaskState.Range(func(key, value any) bool {
+		select {
+		case value.(sharedGhostState).migrationContext.PanicAbort <- errors.New("the replica is no longer the leader"):
+		default:
+		}
+		stateCfg.GhostTaskState.Delete(key)
+		return true
+	})
+}
+
 func (exec *SchemaUpdateGhostSyncExecutor) runGhostMigration(ctx context.Context, stores *store.Store, task *store.TaskMessage, statement string) (terminated bool, result *api.TaskRunResultPayload, err error) {
 	syncDone := make(chan struct{})
 	// set buffer size to 1 to unblock the sender because there is no listner if the task is canceled.
@@ -35,6 +35,14 @@ func ComputeDatabaseSchemaDiff(ctx context.Context, instance *store.InstanceMess
 
 	var engine storepb.Engine
 	switch instance.Engine {
+	case storepb.Engine_SNOWFLAKE:
+		// Snowflake dumps are produced by GET_DDL and are already declarative,
+		// so we diff them directly without the SDL transformation.
+		diff, err := base.SchemaDiff(storepb.Engine_SNOWFLAKE, schema.String(), newSchema, true /* ignoreCaseSensitivity */)
+		if err != nil {
+			return "", errors.Wrapf(err, "compute schema diff")
+		}
+		return diff, nil
 	case storepb.Engine_POSTGRES, storepb.Engine_RISINGWAVE:
 		engine = storepb.Engine_POSTGRES
 	case storepb.Engine_MYSQL, storepb.Engine_TIDB, storepb.Engine_MARIADB, storepb.Engine_OCEANBASE:
@@ -0,0 +1,195 @@
+// Package webhookdelivery is the runner delivering the project webhook events stored in the outbox.
+package webhookdelivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/state"
+	webhookplugin "github.com/bytebase/bytebase/backend/plugin/webhook"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	webhookDeliveryInterval = 10 * time.Second
+	// deliveryBatchSize is the maximum number of deliveries posted in one round.
+	deliveryBatchSize = 100
+	// maxDeliveryAttempt is the maximum number of attempts before a delivery is dead.
+	// With the backoff below, a delivery is retried for about 21 minutes.
+	maxDeliveryAttempt = 8
+	retryBaseInterval  = 10 * time.Second
+	retryMaxInterval   = time.Hour
+	// deliveryRetention is how long the finished deliveries are kept for the delivery history.
+	deliveryRetention = 30 * 24 * time.Hour
+	pruneInterval     = time.Hour
+	// maxErrorLength is the maximum length of the delivery error kept in the history.
+	maxErrorLength = 1024
+)
+
+// NewRunner creates a new webhook delivery runner.
+func NewRunner(store *store.Store, stateCfg *state.State) *Runner {
+	return &Runner{
+		store:    store,
+		stateCfg: stateCfg,
+	}
+}
+
+// Runner is the webhook delivery runner.
+type Runner struct {
+	store    *store.Store
+	stateCfg *state.State
+
+	lastPruneTime time.Time
+}
+
+// Run runs the runner.
+func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(webhookDeliveryInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	slog.Debug(fmt.Sprintf("Webhook delivery runner started and will run every %v", webhookDeliveryInterval))
+	for {
+		select {
+		case <-ticker.C:
+			r.runOnce(ctx)
+		case <-r.stateCfg.WebhookDeliveryTickleChan:
+			r.runOnce(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Runner) runOnce(ctx context.Context) {
+	if time.Since(r.lastPruneTime) >= pruneInterval {
+		if err := r.store.DeleteWebhookDeliveriesBefore(ctx, time.Now().Add(-deliveryRetention).Unix()); err != nil {
+			slog.Error("failed to prune webhook deliveries", log.BBError(err))
+		} else {
+			r.lastPruneTime = time.Now()
+		}
+	}
+
+	deliveries, err := r.store.ListDueWebhookDeliveries(ctx, time.Now().Unix(), deliveryBatchSize)
+	if err != nil {
+		slog.Error("failed to list due webhook deliveries", log.BBError(err))
+		return
+	}
+
+	// The webhooks are posted concurrently, and the deliveries of a webhook are posted one after another in the order
+	// of the events. A failed delivery is retried later without holding back the following ones.
+	var wg sync.WaitGroup
+	for _, webhookDeliveries := range groupDeliveriesByWebhook(deliveries) {
+		wg.Add(1)
+		go func(webhookDeliveries []*store.WebhookDeliveryMessage) {
+			defer wg.Done()
+			for _, delivery := range webhookDeliveries {
+				if err := r.deliver(ctx, delivery); err != nil {
+					slog.Error("failed to deliver webhook", slog.Int("delivery", delivery.UID), log.BBError(err))
+				}
+			}
+		}(webhookDeliveries)
+	}
+	wg.Wait()
+
+	// There may be more due deliveries, tickle for the next round.
+	if len(deliveries) == deliveryBatchSize {
+		r.stateCfg.TickleWebhookDelivery()
+	}
+}
+
+// deliver makes one attempt to post the delivery, and records the result.
+func (r *Runner) deliver(ctx context.Context, delivery *store.WebhookDeliveryMessage) error {
+	attempt := delivery.Attempt + 1
+	update := &store.UpdateWebhookDeliveryMessage{
+		UID:     delivery.UID,
+		Attempt: &attempt,
+	}
+
+	responseCode, postErr := r.post(ctx, delivery)
+	update.ResponseCode = &responseCode
+	if postErr == nil {
+		status, errMsg := store.WebhookDeliveryDelivered, ""
+		update.Status, update.Error = &status, &errMsg
+	} else {
+		errMsg, _ := common.TruncateString(postErr.Error(), maxErrorLength)
+		update.Error = &errMsg
+		if attempt >= maxDeliveryAttempt {
+			status := store.WebhookDeliveryDead
+			update.Status = &status
+			slog.Warn("Webhook delivery is dead after max attempts",
+				slog.Int("delivery", delivery.UID),
+				slog.Int("webhook", delivery.WebhookUID),
+				log.BBError(postErr))
+		} else {
+			nextAttemptTs := time.Now().Add(getRetryInterval(attempt)).Unix()
+			update.NextAttemptTs = &nextAttemptTs
+		}
+	}
+
+	if _, err := r.store.UpdateWebhookDelivery(ctx, update); err != nil {
+		return errors.Wrapf(err, "failed to update webhook delivery %d", delivery.UID)
+	}
+	return nil
+}
+
+// post posts the delivery to the webhook and returns the response code.
+func (r *Runner) post(ctx context.Context, delivery *store.WebhookDeliveryMessage) (int, error) {
+	hook, err := r.store.GetProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{ID: &delivery.WebhookUID})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get webhook %d", delivery.WebhookUID)
+	}
+	if hook == nil {
+		return 0, errors.Errorf("webhook %d not found", delivery.WebhookUID)
+	}
+
+	var webhookCtx webhookplugin.Context
+	if err := json.Unmarshal([]byte(delivery.Payload), &webhookCtx); err != nil {
+		return 0, errors.Wrapf(err, "failed to unmarshal webhook delivery payload")
+	}
+	webhookCtx.URL = hook.URL
+	webhookCtx.Secret = hook.SigningSecret
+	webhookCtx.DeliveryID = delivery.UID
+	return webhookplugin.Post(hook.Type, webhookCtx)
+}
+
+// groupDeliveriesByWebhook groups the deliveries by the webhook, and sorts the deliveries of a webhook by the event order.
+func groupDeliveriesByWebhook(deliveries []*store.WebhookDeliveryMessage) [][]*store.WebhookDeliveryMessage {
+	var groups [][]*store.WebhookDeliveryMessage
+	index := map[int]int{}
+	for _, delivery := range deliveries {
+		i, ok := index[delivery.WebhookUID]
+		if !ok {
+			i = len(groups)
+			index[delivery.WebhookUID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], delivery)
+	}
+	for _, group := range groups {
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].UID < group[j].UID
+		})
+	}
+	return groups
+}
+
+// getRetryInterval returns the exponential backoff interval after the attempt-th attempt failed.
+func getRetryInterval(attempt int) time.Duration {
+	interval := retryBaseInterval
+	for i := 1; i < attempt; i++ {
+		interval *= 2
+		if interval >= retryMaxInterval {
+			return retryMaxInterval
+		}
+	}
+	return interval
+}
@@ -0,0 +1,48 @@
+package webhookdelivery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func TestGetRetryInterval(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 10 * time.Second},
+		{attempt: 2, want: 20 * time.Second},
+		{attempt: 7, want: 640 * time.Second},
+		{attempt: 10, want: time.Hour},
+		{attempt: 100, want: time.Hour},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, getRetryInterval(test.attempt), test.attempt)
+	}
+}
+
+func TestGroupDeliveriesByWebhook(t *testing.T) {
+	a := require.New(t)
+	// The due deliveries are ordered by the next attempt, and the retry of delivery 1 is due after delivery 3.
+	deliveries := []*store.WebhookDeliveryMessage{
+		{UID: 2, WebhookUID: 20},
+		{UID: 3, WebhookUID: 10},
+		{UID: 4, WebhookUID: 20},
+		{UID: 1, WebhookUID: 10},
+	}
+	var got [][]int
+	for _, group := range groupDeliveriesByWebhook(deliveries) {
+		var uids []int
+		for _, delivery := range group {
+			uids = append(uids, delivery.UID)
+		}
+		got = append(got, uids)
+	}
+	a.Equal([][]int{{2, 4}, {1, 3}}, got)
+}
@@ -46,7 +46,7 @@ func configureGrpcRouters(
 	errorRecordRing *api.ErrorRecordRing,
 	tokenDuration time.Duration) (*v1.RolloutService, *v1.IssueService, error) {
 	// Register services.
-	authService, err := v1.NewAuthService(stores, secret, tokenDuration, licenseService, metricReporter, profile, stateCfg, postCreateUser)
+	authService, err := v1.NewAuthService(stores, secret, tokenDuration, licenseService, metricReporter, profile, stateCfg, activityManager, postCreateUser)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -65,15 +65,16 @@ func configureGrpcRouters(
 		secret,
 		stateCfg,
 		dbFactory,
-		schemaSyncer))
+		schemaSyncer,
+		activityManager))
 	v1pb.RegisterProjectServiceServer(grpcServer, v1.NewProjectService(stores, activityManager, licenseService))
 	v1pb.RegisterDatabaseServiceServer(grpcServer, v1.NewDatabaseService(stores, backupRunner, schemaSyncer, licenseService, profile))
 	v1pb.RegisterInstanceRoleServiceServer(grpcServer, v1.NewInstanceRoleService(stores, dbFactory))
-	v1pb.RegisterOrgPolicyServiceServer(grpcServer, v1.NewOrgPolicyService(stores, licenseService))
-	v1pb.RegisterIdentityProviderServiceServer(grpcServer, v1.NewIdentityProviderService(stores, licenseService))
+	v1pb.RegisterOrgPolicyServiceServer(grpcServer, v1.NewOrgPolicyService(stores, licenseService, activityManager))
+	v1pb.RegisterIdentityProviderServiceServer(grpcServer, v1.NewIdentityProviderService(stores, licenseService, secret))
 	v1pb.RegisterSettingServiceServer(grpcServer, v1.NewSettingService(stores, profile, licenseService, stateCfg))
 	v1pb.RegisterAnomalyServiceServer(grpcServer, v1.NewAnomalyService(stores))
-	v1pb.RegisterSQLServiceServer(grpcServer, v1.NewSQLService(stores, schemaSyncer, dbFactory, activityManager, licenseService))
+	v1pb.RegisterSQLServiceServer(grpcServer, v1.NewSQLService(stores, schemaSyncer, dbFactory, activityManager, licenseService, profile))
 	v1pb.RegisterExternalVersionControlServiceServer(grpcServer, v1.NewExternalVersionControlService(stores))
 	v1pb.RegisterRiskServiceServer(grpcServer, v1.NewRiskService(stores, licenseService))
 	issueService := v1.NewIssueService(stores, activityManager, relayRunner, stateCfg, licenseService, metricReporter)
@@ -88,6 +89,7 @@ func configureGrpcRouters(
 	v1pb.RegisterBookmarkServiceServer(grpcServer, v1.NewBookmarkService(stores))
 	v1pb.RegisterInboxServiceServer(grpcServer, v1.NewInboxService(stores))
 	v1pb.RegisterChangelistServiceServer(grpcServer, v1.NewChangelistService(stores))
+	v1pb.RegisterWebhookServiceServer(grpcServer, v1.NewWebhookService(stores))
 
 	// REST gateway proxy.
 	grpcEndpoint := fmt.Sprintf(":%d", profile.GrpcPort)
@@ -162,5 +164,8 @@ func configureGrpcRouters(
 	if err := v1pb.RegisterChangelistServiceHandler(ctx, mux, grpcConn); err != nil {
 		return nil, nil, err
 	}
+	if err := v1pb.RegisterWebhookServiceHandler(ctx, mux, grpcConn); err != nil {
+		return nil, nil, err
+	}
 	return rolloutService, issueService, nil
 }
@@ -10,6 +10,8 @@ import (
 
 	"github.com/bytebase/bytebase/backend/api/auth"
 	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/component/audit"
+	"github.com/bytebase/bytebase/backend/component/config"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/metric"
 	metricCollector "github.com/bytebase/bytebase/backend/metric/collector"
@@ -224,3 +226,53 @@ func (s *Server) initMetricReporter() {
 	metricReporter.Register(metric.MemberCountMetricName, metricCollector.NewMemberCountCollector(s.store))
 	s.metricReporter = metricReporter
 }
+
+// newAuditLogger creates the audit logger of the replica writing to the configured sinks, or returns nil if no sink is
+// configured.
+func newAuditLogger(profile *config.Profile, replica string) (*audit.Logger, error) {
+	if profile.AuditLogFile == "" && profile.AuditLogSyslog == "" && profile.AuditLogURL == "" {
+		return nil, nil
+	}
+	if profile.AuditLogKeyFile == "" {
+		return nil, errors.New("--audit-log-key-file is required for the audit log")
+	}
+	key, err := audit.ReadKeyFile(profile.AuditLogKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	var sinks []audit.Sink
+	closeSinks := func() {
+		for _, sink := range sinks {
+			_ = sink.Close()
+		}
+	}
+	if profile.AuditLogFile != "" {
+		sink, err := audit.NewFileSink(profile.AuditLogFile)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if profile.AuditLogSyslog != "" {
+		sink, err := audit.NewSyslogSink(profile.AuditLogSyslog)
+		if err != nil {
+			closeSinks()
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if profile.AuditLogURL != "" {
+		sink, err := audit.NewHTTPSink(profile.AuditLogURL)
+		if err != nil {
+			closeSinks()
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	logger, err := audit.NewLogger(key, replica, sinks...)
+	if err != nil {
+		closeSinks()
+		return nil, err
+	}
+	return logger, nil
+}
@@ -28,8 +28,10 @@ func configureEchoRouters(e *echo.Echo, grpcServer *grpc.Server, mux *grpcRuntim
 	e.Use(recoverMiddleware)
 	grpcSkipper := func(c echo.Context) bool {
 		// Skip grpc and webhook calls.
+		// The streaming export may take longer than the timeout.
 		return strings.HasPrefix(c.Request().URL.Path, "/bytebase.v1.") ||
 			strings.HasPrefix(c.Request().URL.Path, "/v1:adminExecute") ||
+			strings.HasSuffix(c.Request().URL.Path, ":exportStream") ||
 			strings.HasPrefix(c.Request().URL.Path, webhookAPIPrefix)
 	}
 	e.Use(middleware.TimeoutWithConfig(middleware.TimeoutConfig{
@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"os"
 	"sync"
 	"time"
 
 	grpcRuntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
@@ -21,13 +23,19 @@ import (
 
 	"github.com/bytebase/bytebase/backend/api/auth"
 	"github.com/bytebase/bytebase/backend/api/gitops"
+	"github.com/bytebase/bytebase/backend/api/scim"
+	"github.com/bytebase/bytebase/backend/api/sso"
 	v1 "github.com/bytebase/bytebase/backend/api/v1"
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/common/stacktrace"
 	"github.com/bytebase/bytebase/backend/component/activity"
+	"github.com/bytebase/bytebase/backend/component/audit"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/leader"
+	externalsecret "github.com/bytebase/bytebase/backend/component/secret"
 	"github.com/bytebase/bytebase/backend/component/state"
+	"github.com/bytebase/bytebase/backend/component/tracing"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	enterpriseService "github.com/bytebase/bytebase/backend/enterprise/service"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
@@ -47,13 +55,18 @@ import (
 	"github.com/bytebase/bytebase/backend/runner/schemasync"
 	"github.com/bytebase/bytebase/backend/runner/slowquerysync"
 	"github.com/bytebase/bytebase/backend/runner/taskrun"
+	"github.com/bytebase/bytebase/backend/runner/webhookdelivery"
 	"github.com/bytebase/bytebase/backend/store"
 	_ "github.com/bytebase/bytebase/docs/openapi" // initial the swagger doc
 )
 
 const (
 	// webhookAPIPrefix is the API prefix for Bytebase webhook.
-	webhookAPIPrefix       = "/hook"
+	webhookAPIPrefix = "/hook"
+	// samlAPIPrefix is the API prefix for the SAML service provider endpoints.
+	samlAPIPrefix = "/saml"
+	// scimAPIPrefix is the API prefix for the SCIM provisioning endpoints.
+	scimAPIPrefix          = "/scim/v2"
 	maxStacksize           = 1024 * 10240
 	gracefulShutdownPeriod = 10 * time.Second
 )
@@ -61,17 +74,24 @@ const (
 // Server is the Bytebase server.
 type Server struct {
 	// Asynchronous runners.
-	taskSchedulerV2    *taskrun.SchedulerV2
-	planCheckScheduler *plancheck.Scheduler
-	metricReporter     *metricreport.Reporter
-	schemaSyncer       *schemasync.Syncer
-	slowQuerySyncer    *slowquerysync.Syncer
-	mailSender         *mail.SlowQueryWeeklyMailSender
-	backupRunner       *backuprun.Runner
-	rollbackRunner     *rollbackrun.Runner
-	approvalRunner     *approval.Runner
-	relayRunner        *relay.Runner
-	runnerWG           sync.WaitGroup
+	taskSchedulerV2       *taskrun.SchedulerV2
+	planCheckScheduler    *plancheck.Scheduler
+	metricReporter        *metricreport.Reporter
+	schemaSyncer          *schemasync.Syncer
+	slowQuerySyncer       *slowquerysync.Syncer
+	mailSender            *mail.SlowQueryWeeklyMailSender
+	backupRunner          *backuprun.Runner
+	rollbackRunner        *rollbackrun.Runner
+	approvalRunner        *approval.Runner
+	relayRunner           *relay.Runner
+	webhookDeliveryRunner *webhookdelivery.Runner
+	runnerWG              sync.WaitGroup
+	// leaderElector elects the replica running the runners among the replicas sharing the metadata database.
+	leaderElector *leader.Elector
+	// tracingProvider exports the traces if the OTLP endpoint is configured.
+	tracingProvider *tracing.Provider
+	// auditLogger records the API mutations if any audit log sink is configured.
+	auditLogger *audit.Logger
 
 	activityManager *activity.Manager
 
@@ -137,6 +157,13 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 	}()
 
 	var err error
+	if profile.OTLPEndpoint != "" {
+		s.tracingProvider, err = tracing.NewProvider(ctx, profile.OTLPEndpoint, profile.OTLPInsecure, profile.Version)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Install mysqlutil
 	s.mysqlBinDir, err = mysqlutil.Install(profile.ResourceDir)
 	if err != nil {
@@ -225,8 +252,8 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 		return nil, errors.Wrap(err, "failed to init config")
 	}
 	s.secret = secret
-	s.activityManager = activity.NewManager(storeInstance)
-	s.dbFactory = dbfactory.New(s.mysqlBinDir, s.mongoBinDir, s.pgBinDir, profile.DataDir, s.secret)
+	s.activityManager = activity.NewManager(storeInstance, s.stateCfg)
+	s.dbFactory = dbfactory.New(s.mysqlBinDir, s.mongoBinDir, s.pgBinDir, profile.DataDir, s.secret, externalsecret.NewManagerFromEnv())
 
 	// Configure echo server.
 	s.e = echo.New()
@@ -249,28 +276,31 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 	}
 
 	s.metricReporter = metricreport.NewReporter(s.store, s.licenseService, &s.profile, false)
-	s.schemaSyncer = schemasync.NewSyncer(storeInstance, s.dbFactory, s.stateCfg, profile, s.licenseService)
+	s.schemaSyncer = schemasync.NewSyncer(storeInstance, s.dbFactory, s.stateCfg, profile, s.licenseService, s.activityManager)
 	if !profile.Readonly {
 		s.slowQuerySyncer = slowquerysync.NewSyncer(storeInstance, s.dbFactory, s.stateCfg, profile)
 		s.backupRunner = backuprun.NewRunner(storeInstance, s.dbFactory, s.s3Client, s.stateCfg, &profile)
 		s.rollbackRunner = rollbackrun.NewRunner(&profile, storeInstance, s.dbFactory, s.stateCfg)
 		s.mailSender = mail.NewSender(s.store, s.stateCfg)
 		s.relayRunner = relay.NewRunner(storeInstance, s.activityManager, s.stateCfg)
+		s.webhookDeliveryRunner = webhookdelivery.NewRunner(storeInstance, s.stateCfg)
 		s.approvalRunner = approval.NewRunner(storeInstance, s.dbFactory, s.stateCfg, s.activityManager, s.relayRunner, s.licenseService)
 
 		s.taskSchedulerV2 = taskrun.NewSchedulerV2(storeInstance, s.stateCfg, s.activityManager)
 		s.taskSchedulerV2.Register(api.TaskGeneral, taskrun.NewDefaultExecutor())
-		s.taskSchedulerV2.Register(api.TaskDatabaseCreate, taskrun.NewDatabaseCreateExecutor(storeInstance, s.dbFactory, s.schemaSyncer, s.stateCfg, profile))
+		s.taskSchedulerV2.Register(api.TaskDatabaseCreate, taskrun.NewDatabaseCreateExecutor(storeInstance, s.dbFactory, s.schemaSyncer, s.stateCfg, profile, s.activityManager))
 		s.taskSchedulerV2.Register(api.TaskDatabaseSchemaBaseline, taskrun.NewSchemaBaselineExecutor(storeInstance, s.dbFactory, s.activityManager, s.licenseService, s.stateCfg, s.schemaSyncer, profile))
 		s.taskSchedulerV2.Register(api.TaskDatabaseSchemaUpdate, taskrun.NewSchemaUpdateExecutor(storeInstance, s.dbFactory, s.activityManager, s.licenseService, s.stateCfg, s.schemaSyncer, profile))
 		s.taskSchedulerV2.Register(api.TaskDatabaseSchemaUpdateSDL, taskrun.NewSchemaUpdateSDLExecutor(storeInstance, s.dbFactory, s.activityManager, s.licenseService, s.stateCfg, s.schemaSyncer, profile))
 		s.taskSchedulerV2.Register(api.TaskDatabaseDataUpdate, taskrun.NewDataUpdateExecutor(storeInstance, s.dbFactory, s.activityManager, s.licenseService, s.stateCfg, profile))
 		s.taskSchedulerV2.Register(api.TaskDatabaseBackup, taskrun.NewDatabaseBackupExecutor(storeInstance, s.dbFactory, s.s3Client, s.stateCfg, profile))
-		s.taskSchedulerV2.Register(api.TaskDatabaseSchemaUpdateGhostSync, taskrun.NewSchemaUpdateGhostSyncExecutor(storeInstance, s.stateCfg, s.secret))
+		s.taskSchedulerV2.Register(api.TaskDatabaseSchemaUpdateGhostSync, taskrun.NewSchemaUpdateGhostSyncExecutor(storeInstance, s.dbFactory, s.stateCfg, s.secret))
 		s.taskSchedulerV2.Register(api.TaskDatabaseSchemaUpdateGhostCutover, taskrun.NewSchemaUpdateGhostCutoverExecutor(storeInstance, s.dbFactory, s.activityManager, s.licenseService, s.stateCfg, s.schemaSyncer, profile))
 		s.taskSchedulerV2.Register(api.TaskDatabaseRestorePITRRestore, taskrun.NewPITRRestoreExecutor(storeInstance, s.dbFactory, s.s3Client, s.schemaSyncer, s.stateCfg, profile))
 		s.taskSchedulerV2.Register(api.TaskDatabaseRestorePITRCutover, taskrun.NewPITRCutoverExecutor(storeInstance, s.dbFactory, s.schemaSyncer, s.stateCfg, s.backupRunner, s.activityManager, profile))
 
+		s.leaderElector = leader.NewElector(storeInstance, leader.RunnerLeaseName)
+
 		s.planCheckScheduler = plancheck.NewScheduler(storeInstance, s.licenseService, s.stateCfg)
 		databaseConnectExecutor := plancheck.NewDatabaseConnectExecutor(storeInstance, s.dbFactory)
 		s.planCheckScheduler.Register(store.PlanCheckDatabaseConnect, databaseConnectExecutor)
@@ -278,7 +308,7 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 		s.planCheckScheduler.Register(store.PlanCheckDatabaseStatementType, statementTypeExecutor)
 		statementAdviseExecutor := plancheck.NewStatementAdviseExecutor(storeInstance, s.dbFactory, s.licenseService)
 		s.planCheckScheduler.Register(store.PlanCheckDatabaseStatementAdvise, statementAdviseExecutor)
-		ghostSyncExecutor := plancheck.NewGhostSyncExecutor(storeInstance, s.secret)
+		ghostSyncExecutor := plancheck.NewGhostSyncExecutor(storeInstance, s.dbFactory, s.secret)
 		s.planCheckScheduler.Register(store.PlanCheckDatabaseGhostSync, ghostSyncExecutor)
 		pitrMySQLExecutor := plancheck.NewPITRMySQLExecutor(storeInstance, s.dbFactory)
 		s.planCheckScheduler.Register(store.PlanCheckDatabasePITRMySQL, pitrMySQLExecutor)
@@ -293,6 +323,16 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 	authProvider := auth.New(s.store, s.secret, tokenDuration, s.licenseService, s.stateCfg, profile.Mode)
 	aclProvider := v1.NewACLInterceptor(s.store, s.secret, s.licenseService, profile.Mode)
 	debugProvider := v1.NewDebugInterceptor(&s.errorRecordRing, &profile, s.metricReporter)
+	// The audit log chain of the replica is identified by its holder in the leader election.
+	auditReplica, _ := os.Hostname()
+	if s.leaderElector != nil {
+		auditReplica = s.leaderElector.Holder()
+	}
+	s.auditLogger, err = newAuditLogger(&profile, auditReplica)
+	if err != nil {
+		return nil, err
+	}
+	auditProvider := v1.NewAuditInterceptor(s.store, aclProvider, s.auditLogger)
 	onPanic := func(p any) error {
 		stack := stacktrace.TakeStacktrace(20 /* n */, 5 /* skip */)
 		// keep a multiline stack
@@ -308,15 +348,21 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 		grpc.InitialWindowSize(100000000),
 		grpc.InitialConnWindowSize(100000000),
 		grpc.ChainUnaryInterceptor(
+			// The tracing interceptor comes first so that the spans cover the rest of the interceptors.
+			otelgrpc.UnaryServerInterceptor(),
 			debugProvider.DebugInterceptor,
 			authProvider.AuthenticationInterceptor,
 			aclProvider.ACLInterceptor,
+			// The audit interceptor comes after the ACL so that it acts with the role of the caller.
+			auditProvider.AuditInterceptor,
 			recoveryUnaryInterceptor,
 		),
 		grpc.ChainStreamInterceptor(
+			otelgrpc.StreamServerInterceptor(),
 			debugProvider.DebugStreamInterceptor,
 			authProvider.AuthenticationStreamInterceptor,
 			aclProvider.ACLStreamInterceptor,
+			auditProvider.AuditStreamInterceptor,
 			recoveryStreamInterceptor,
 		),
 	)
@@ -345,6 +391,14 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 	gitOpsService := gitops.NewService(s.store, s.dbFactory, s.activityManager, s.stateCfg, s.licenseService, rolloutService, issueService)
 	gitOpsService.RegisterWebhookRoutes(webhookGroup)
 
+	samlGroup := s.e.Group(samlAPIPrefix)
+	ssoService := sso.NewService(s.store, s.secret)
+	ssoService.RegisterSAMLRoutes(samlGroup)
+
+	scimGroup := s.e.Group(scimAPIPrefix)
+	scimService := scim.NewService(s.store, s.licenseService)
+	scimService.RegisterRoutes(scimGroup)
+
 	reflection.Register(s.grpcServer)
 
 	serverStarted = true
@@ -357,31 +411,22 @@ func (s *Server) Run(ctx context.Context, port int) error {
 	s.cancel = cancel
 	if !s.profile.Readonly {
 		// runnerWG waits for all goroutines to complete.
-		if err := s.taskSchedulerV2.ClearRunningTaskRuns(ctx); err != nil {
-			return errors.Wrap(err, "failed to clear existing RUNNING tasks before starting the task scheduler")
-		}
+		// The requests from the API are served on every replica.
 		s.runnerWG.Add(1)
-		go s.taskSchedulerV2.Run(ctx, &s.runnerWG)
+		go s.schemaSyncer.RunSyncRequests(ctx, &s.runnerWG)
 		s.runnerWG.Add(1)
-		go s.schemaSyncer.Run(ctx, &s.runnerWG)
+		go s.slowQuerySyncer.RunSyncRequests(ctx, &s.runnerWG)
 		s.runnerWG.Add(1)
-		go s.slowQuerySyncer.Run(ctx, &s.runnerWG)
+		go s.taskSchedulerV2.ListenTaskSkippedOrDone(ctx, &s.runnerWG)
 		s.runnerWG.Add(1)
-		go s.mailSender.Run(ctx, &s.runnerWG)
+		go s.taskSchedulerV2.ListenTaskCleanup(ctx, &s.runnerWG)
 		s.runnerWG.Add(1)
-		go s.backupRunner.Run(ctx, &s.runnerWG)
-		s.runnerWG.Add(1)
-		go s.rollbackRunner.Run(ctx, &s.runnerWG)
-		s.runnerWG.Add(1)
-		go s.approvalRunner.Run(ctx, &s.runnerWG)
-		s.runnerWG.Add(1)
-		go s.relayRunner.Run(ctx, &s.runnerWG)
-
+		go s.relayRunner.ListenCheckExternalApprovalChan(ctx, &s.runnerWG)
+		// The runners only run on the leader.
 		s.runnerWG.Add(1)
-		go s.metricReporter.Run(ctx, &s.runnerWG)
-
+		go s.leaderElector.Run(ctx, &s.runnerWG, s.runRunners)
 		s.runnerWG.Add(1)
-		go s.planCheckScheduler.Run(ctx, &s.runnerWG)
+		go s.clearFollowerRunnerQueues(ctx, &s.runnerWG)
 	}
 
 	listen, err := net.Listen("tcp", fmt.Sprintf(":%d", port+1))
@@ -396,6 +441,60 @@ func (s *Server) Run(ctx context.Context, port int) error {
 	return s.e.Start(fmt.Sprintf(":%d", port))
 }
 
+// runRunners runs the runners until the context is canceled when the replica is no longer the leader.
+func (s *Server) runRunners(ctx context.Context) {
+	// The task runs left RUNNING by the previous leader are no longer executing.
+	if err := s.taskSchedulerV2.ClearRunningTaskRuns(ctx); err != nil {
+		slog.Error("failed to clear existing RUNNING tasks before starting the task scheduler", log.BBError(err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go s.taskSchedulerV2.Run(ctx, &wg)
+	wg.Add(1)
+	go s.schemaSyncer.Run(ctx, &wg)
+	wg.Add(1)
+	go s.slowQuerySyncer.Run(ctx, &wg)
+	wg.Add(1)
+	go s.mailSender.Run(ctx, &wg)
+	wg.Add(1)
+	go s.backupRunner.Run(ctx, &wg)
+	wg.Add(1)
+	go s.rollbackRunner.Run(ctx, &wg)
+	wg.Add(1)
+	go s.approvalRunner.Run(ctx, &wg)
+	wg.Add(1)
+	go s.relayRunner.Run(ctx, &wg)
+	wg.Add(1)
+	go s.webhookDeliveryRunner.Run(ctx, &wg)
+
+	wg.Add(1)
+	go s.metricReporter.Run(ctx, &wg)
+
+	wg.Add(1)
+	go s.planCheckScheduler.Run(ctx, &wg)
+	wg.Wait()
+}
+
+// clearFollowerRunnerQueues clears the runner queues periodically while the replica is not the leader.
+// The leader picks up the work in the queues from the database instead.
+func (s *Server) clearFollowerRunnerQueues(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !s.leaderElector.IsLeader() {
+				s.stateCfg.ClearRunnerQueues()
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // Shutdown will shut down the server.
 func (s *Server) Shutdown(ctx context.Context) error {
 	slog.Info("Stopping Bytebase...")
@@ -436,6 +535,13 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	// Close the audit log after the API stops.
+	if s.auditLogger != nil {
+		if err := s.auditLogger.Close(); err != nil {
+			slog.Warn("Failed to close the audit log", log.BBError(err))
+		}
+	}
+
 	// Wait for all runners to exit.
 	s.runnerWG.Wait()
 
@@ -451,5 +557,12 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		stopper()
 	}
 
+	// Flush the pending spans.
+	if s.tracingProvider != nil {
+		if err := s.tracingProvider.Shutdown(ctx); err != nil {
+			slog.Warn("Failed to flush the traces", log.BBError(err))
+		}
+	}
+
 	return nil
 }
@@ -52,5 +52,6 @@ func embedFrontend(e *echo.Echo) {
 // defaultAPIRequestSkipper is echo skipper for api requests.
 func defaultAPIRequestSkipper(c echo.Context) bool {
 	path := c.Path()
-	return common.HasPrefixes(path, "/api", "/v1", "/hook")
+	// The SAML callback page is served by the frontend, while the others are the SAML service provider endpoints.
+	return common.HasPrefixes(path, "/api", "/v1", "/hook", "/saml/metadata", "/saml/sso", "/saml/acs", "/scim")
 }
@@ -16,6 +16,7 @@ import (
 	_ "github.com/bytebase/bytebase/backend/plugin/db/spanner"
 
 	// Parsers.
+	_ "github.com/bytebase/bytebase/backend/plugin/parser/clickhouse"
 	_ "github.com/bytebase/bytebase/backend/plugin/parser/plsql"
 	_ "github.com/bytebase/bytebase/backend/plugin/parser/snowflake"
 	_ "github.com/bytebase/bytebase/backend/plugin/parser/standard"
@@ -54,10 +54,11 @@ type updateAnomalyMessage struct {
 }
 
 // UpsertActiveAnomalyV2 upserts an instance of anomaly.
-func (s *Store) UpsertActiveAnomalyV2(ctx context.Context, principalUID int, upsert *AnomalyMessage) (*AnomalyMessage, error) {
+// It also returns whether the anomaly is newly created, i.e. there was no active anomaly before.
+func (s *Store) UpsertActiveAnomalyV2(ctx context.Context, principalUID int, upsert *AnomalyMessage) (*AnomalyMessage, bool, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	defer tx.Rollback()
 
@@ -70,7 +71,7 @@ func (s *Store) UpsertActiveAnomalyV2(ctx context.Context, principalUID int, ups
 	}
 	list, err := s.listAnomalyImplV2(ctx, tx, find)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	var anomaly *AnomalyMessage
@@ -82,7 +83,7 @@ func (s *Store) UpsertActiveAnomalyV2(ctx context.Context, principalUID int, ups
 			Payload:     upsert.Payload,
 		})
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 	} else if len(list) == 1 {
 		// Even if field value does not change, we still patch to update the updated_ts.
@@ -91,17 +92,17 @@ func (s *Store) UpsertActiveAnomalyV2(ctx context.Context, principalUID int, ups
 			Payload: upsert.Payload,
 		})
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 	} else {
-		return nil, &common.Error{Code: common.Conflict, Err: errors.Errorf("found %d active anomalies with filter %+v, expect 1", len(list), find)}
+		return nil, false, &common.Error{Code: common.Conflict, Err: errors.Errorf("found %d active anomalies with filter %+v, expect 1", len(list), find)}
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	return anomaly, nil
+	return anomaly, len(list) == 0, nil
 }
 
 // ListAnomalyV2 lists anomalies, only return the normal ones.
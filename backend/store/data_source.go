@@ -35,6 +35,7 @@ type DataSourceMessage struct {
 	SSHUser                 string
 	SSHObfuscatedPassword   string
 	SSHObfuscatedPrivateKey string
+	AuthenticationType      storepb.DataSourceOptions_AuthenticationType
 	// (deprecated) Output only.
 	UID int
 }
@@ -61,6 +62,7 @@ func (m *DataSourceMessage) Copy() *DataSourceMessage {
 		SSHUser:                 m.SSHUser,
 		SSHObfuscatedPassword:   m.SSHObfuscatedPassword,
 		SSHObfuscatedPrivateKey: m.SSHObfuscatedPrivateKey,
+		AuthenticationType:      m.AuthenticationType,
 		UID:                     m.UID,
 	}
 }
@@ -91,6 +93,7 @@ type UpdateDataSourceMessage struct {
 	SSHUser                 *string
 	SSHObfuscatedPassword   *string
 	SSHObfuscatedPrivateKey *string
+	AuthenticationType      *storepb.DataSourceOptions_AuthenticationType
 }
 
 func (*Store) listDataSourceV2(ctx context.Context, tx *Tx, instanceID string) ([]*DataSourceMessage, error) {
@@ -151,6 +154,7 @@ func (*Store) listDataSourceV2(ctx context.Context, tx *Tx, instanceID string) (
 		dataSourceMessage.SSHUser = dataSourceOptions.SshUser
 		dataSourceMessage.SSHObfuscatedPassword = dataSourceOptions.SshObfuscatedPassword
 		dataSourceMessage.SSHObfuscatedPrivateKey = dataSourceOptions.SshObfuscatedPrivateKey
+		dataSourceMessage.AuthenticationType = dataSourceOptions.AuthenticationType
 
 		dataSourceMessages = append(dataSourceMessages, &dataSourceMessage)
 	}
@@ -273,6 +277,9 @@ func (s *Store) UpdateDataSourceV2(ctx context.Context, patch *UpdateDataSourceM
 	if v := patch.SSHObfuscatedPrivateKey; v != nil {
 		optionSet, args = append(optionSet, fmt.Sprintf("jsonb_build_object('sshObfuscatedPrivateKey', to_jsonb($%d::TEXT))", len(args)+1)), append(args, *v)
 	}
+	if v := patch.AuthenticationType; v != nil {
+		optionSet, args = append(optionSet, fmt.Sprintf("jsonb_build_object('authenticationType', to_jsonb($%d::TEXT))", len(args)+1)), append(args, v.String())
+	}
 	if len(optionSet) != 0 {
 		set = append(set, fmt.Sprintf(`options = options || %s`, strings.Join(optionSet, "||")))
 	}
@@ -321,6 +328,7 @@ func (*Store) addDataSourceToInstanceImplV2(ctx context.Context, tx *Tx, instanc
 		SshUser:                 dataSource.SSHUser,
 		SshObfuscatedPassword:   dataSource.SSHObfuscatedPassword,
 		SshObfuscatedPrivateKey: dataSource.SSHObfuscatedPrivateKey,
+		AuthenticationType:      dataSource.AuthenticationType,
 	}
 	protoBytes, err := protojson.Marshal(&dataSourceOptions)
 	if err != nil {
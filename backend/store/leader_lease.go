@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// AcquireLeaderLease acquires or renews the leader lease with the name for the holder.
+// It returns true if the holder holds the lease until the TTL expires.
+// The lease expiration is computed with the clock of the metadata database, so it doesn't depend on the clocks of the replicas.
+func (s *Store) AcquireLeaderLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	query := `
+		INSERT INTO leader_lease (name, holder, expire_ts)
+		VALUES ($1, $2, extract(epoch from now())::BIGINT + $3)
+		ON CONFLICT (name) DO UPDATE SET
+			holder = EXCLUDED.holder,
+			expire_ts = EXCLUDED.expire_ts
+		WHERE leader_lease.holder = EXCLUDED.holder OR leader_lease.expire_ts < extract(epoch from now())
+		RETURNING holder`
+	var got string
+	if err := s.db.db.QueryRowContext(ctx, query, name, holder, int64(ttl.Seconds())).Scan(&got); err != nil {
+		if err == sql.ErrNoRows {
+			// The lease is held by another holder.
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to acquire leader lease %q", name)
+	}
+	return got == holder, nil
+}
+
+// ReleaseLeaderLease releases the leader lease with the name if it's held by the holder.
+func (s *Store) ReleaseLeaderLease(ctx context.Context, name, holder string) error {
+	query := `DELETE FROM leader_lease WHERE name = $1 AND holder = $2`
+	if _, err := s.db.db.ExecContext(ctx, query, name, holder); err != nil {
+		return errors.Wrapf(err, "failed to release leader lease %q", name)
+	}
+	return nil
+}
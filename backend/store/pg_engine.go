@@ -4,9 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"runtime"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	dbdriver "github.com/bytebase/bytebase/backend/plugin/db"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -16,10 +21,12 @@ import (
 	"github.com/bytebase/bytebase/backend/common"
 )
 
+var tracer = otel.Tracer("github.com/bytebase/bytebase/backend/store")
+
 // DB represents the database connection.
 type DB struct {
 	metadataDriver dbdriver.Driver
-	db             *sql.DB
+	db             *sqlDB
 
 	// db.ConnCfg is the connection configuration to a Postgres database.
 	// The user has superuser privilege to the database.
@@ -99,7 +106,7 @@ func (db *DB) Open(ctx context.Context) error {
 	}
 	// Don't close metadataDriver.
 	db.metadataDriver = metadataDriver
-	db.db = metadataDriver.GetDB()
+	db.db = &sqlDB{DB: metadataDriver.GetDB()}
 	// Set the max open connections so that we won't exceed the connection limit of metaDB.
 	// The limit is the max connections minus connections reserved for superuser.
 	var maxConns, reservedConns int
@@ -126,23 +133,65 @@ func (db *DB) Close(ctx context.Context) error {
 // BeginTx starts a transaction and returns a wrapper Tx type. This type
 // provides a reference to the database and a fixed timestamp at the start of
 // the transaction. The timestamp allows us to mock time during tests as well.
+//
+// The transaction is traced in a span named after the store method beginning it, which ends on commit or rollback.
 func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	ctx, span := tracer.Start(ctx, getCallerSpanName(), trace.WithSpanKind(trace.SpanKindClient))
 	ptx, err := db.db.BeginTx(ctx, opts)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+		span.End()
 		return nil, err
 	}
 
 	// Return wrapper Tx that includes the transaction start time.
 	return &Tx{
-		Tx:  ptx,
-		db:  db,
-		now: db.Now().UTC().Truncate(time.Second),
+		Tx:   ptx,
+		db:   db,
+		now:  db.Now().UTC().Truncate(time.Second),
+		span: span,
 	}, nil
 }
 
 // Tx wraps the SQL Tx object to provide a timestamp at the start of the transaction.
 type Tx struct {
 	*sql.Tx
-	db  *DB
-	now time.Time
+	db   *DB
+	now  time.Time
+	span trace.Span
+}
+
+// Commit overrides sql.Tx Commit to end the span of the transaction.
+func (tx *Tx) Commit() error {
+	err := tx.Tx.Commit()
+	if err != nil {
+		tx.span.RecordError(err)
+		tx.span.SetStatus(otelcodes.Error, err.Error())
+	}
+	tx.span.End()
+	return err
+}
+
+// Rollback overrides sql.Tx Rollback to end the span of the transaction.
+// The span has ended if the transaction has been committed, and ending it again is a no-op.
+func (tx *Tx) Rollback() error {
+	err := tx.Tx.Rollback()
+	tx.span.End()
+	return err
+}
+
+// getCallerSpanName returns the span name of the store method calling BeginTx, e.g. "store.GetInstanceV2".
+func getCallerSpanName() string {
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return "store.BeginTx"
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "store.BeginTx"
+	}
+	name := fn.Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	return strings.Replace(name, "(*Store).", "", 1)
 }
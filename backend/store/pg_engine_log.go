@@ -4,8 +4,6 @@
 package store
 
 import (
-	"context"
-	"database/sql"
 	"log/slog"
 	"regexp"
 )
@@ -17,26 +15,6 @@ func cleanQuery(query string) string {
 	return pattern.ReplaceAllString(query, " ")
 }
 
-// PrepareContext overrides sql.Tx PrepareContext.
-func (tx *Tx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
-	slog.Debug("PrepareContext", slog.String("query", cleanQuery(query)))
-	return tx.Tx.PrepareContext(ctx, query)
-}
-
-// ExecContext overrides sql.Tx ExecContext.
-func (tx *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
-	slog.Debug("ExecContext", slog.String("query", cleanQuery(query)))
-	return tx.Tx.ExecContext(ctx, query, args...)
-}
-
-// QueryContext overrides sql.Tx QueryContext.
-func (tx *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
-	slog.Debug("QueryContext", slog.String("query", cleanQuery(query)))
-	return tx.Tx.QueryContext(ctx, query, args...)
-}
-
-// QueryRowContext overrides sql.Tx QueryRowContext.
-func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
-	slog.Debug("QueryRowContext", slog.String("query", cleanQuery(query)))
-	return tx.Tx.QueryRowContext(ctx, query, args...)
+func logQuery(operation, query string) {
+	slog.Debug(operation+"Context", slog.String("query", cleanQuery(query)))
 }
@@ -0,0 +1,6 @@
+//go:build !store.db
+
+package store
+
+// logQuery is a no-op unless the server is built with the store.db tag.
+func logQuery(string, string) {}
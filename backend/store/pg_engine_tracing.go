@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The statements against the metadata database are traced in spans, both in and out of the transactions, so that a
+// slow store method can be broken down into its statements. The statement spans in a transaction are the children of
+// the span of the transaction started by BeginTx, whichever context the statements are executed with.
+
+// sqlDB wraps the sql.DB of the metadata database to trace the statements out of the transactions.
+type sqlDB struct {
+	*sql.DB
+}
+
+// ExecContext overrides sql.DB ExecContext.
+func (db *sqlDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startStatementSpan(ctx, "Exec", query)
+	result, err := db.DB.ExecContext(ctx, query, args...)
+	endStatementSpan(span, err)
+	return result, err
+}
+
+// QueryContext overrides sql.DB QueryContext.
+func (db *sqlDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startStatementSpan(ctx, "Query", query)
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	endStatementSpan(span, err)
+	return rows, err
+}
+
+// QueryRowContext overrides sql.DB QueryRowContext.
+func (db *sqlDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startStatementSpan(ctx, "QueryRow", query)
+	row := db.DB.QueryRowContext(ctx, query, args...)
+	endStatementSpan(span, row.Err())
+	return row
+}
+
+// PrepareContext overrides sql.Tx PrepareContext.
+func (tx *Tx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, span := startStatementSpan(trace.ContextWithSpan(ctx, tx.span), "Prepare", query)
+	stmt, err := tx.Tx.PrepareContext(ctx, query)
+	endStatementSpan(span, err)
+	return stmt, err
+}
+
+// ExecContext overrides sql.Tx ExecContext.
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startStatementSpan(trace.ContextWithSpan(ctx, tx.span), "Exec", query)
+	result, err := tx.Tx.ExecContext(ctx, query, args...)
+	endStatementSpan(span, err)
+	return result, err
+}
+
+// QueryContext overrides sql.Tx QueryContext.
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startStatementSpan(trace.ContextWithSpan(ctx, tx.span), "Query", query)
+	rows, err := tx.Tx.QueryContext(ctx, query, args...)
+	endStatementSpan(span, err)
+	return rows, err
+}
+
+// QueryRowContext overrides sql.Tx QueryRowContext.
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startStatementSpan(trace.ContextWithSpan(ctx, tx.span), "QueryRow", query)
+	row := tx.Tx.QueryRowContext(ctx, query, args...)
+	endStatementSpan(span, row.Err())
+	return row
+}
+
+// startStatementSpan starts the span of the statement, e.g. "db.Query".
+// The statement is recorded without the arguments, which may contain the secrets.
+func startStatementSpan(ctx context.Context, operation, query string) (context.Context, trace.Span) {
+	logQuery(operation, query)
+	return tracer.Start(ctx, "db."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.statement", query),
+	))
+}
+
+func endStatementSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// fakeConn fails the statements containing "fail".
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return fakeTx{}, nil }
+
+func (fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if strings.Contains(query, "fail") {
+		return nil, errors.New("syntax error")
+	}
+	return driver.RowsAffected(1), nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{}, nil }
+func (fakeConnector) Driver() driver.Driver                        { return nil }
+
+func TestTraceStatements(t *testing.T) {
+	a := require.New(t)
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+
+	ctx := context.Background()
+	db := &DB{db: &sqlDB{DB: sql.OpenDB(fakeConnector{})}, Now: time.Now}
+	defer db.db.Close()
+	_, err := db.db.ExecContext(ctx, "UPDATE setting SET value = $1", "v")
+	a.NoError(err)
+
+	tx, err := db.BeginTx(ctx, nil)
+	a.NoError(err)
+	_, err = tx.ExecContext(ctx, "fail")
+	a.Error(err)
+	a.NoError(tx.Rollback())
+
+	spans := recorder.Ended()
+	a.Len(spans, 3)
+	a.Equal("db.Exec", spans[0].Name())
+	a.Equal(otelcodes.Unset, spans[0].Status().Code)
+	attributes := map[string]string{}
+	for _, attribute := range spans[0].Attributes() {
+		attributes[string(attribute.Key)] = attribute.Value.AsString()
+	}
+	a.Equal(map[string]string{"db.system": "postgresql", "db.statement": "UPDATE setting SET value = $1"}, attributes)
+
+	// The statement in the transaction is the child of the span of the transaction.
+	a.Equal("db.Exec", spans[1].Name())
+	a.Equal(otelcodes.Error, spans[1].Status().Code)
+	a.Equal("store.TestTraceStatements", spans[2].Name())
+	a.Equal(spans[2].SpanContext().SpanID(), spans[1].Parent().SpanID())
+}
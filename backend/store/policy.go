@@ -105,6 +105,58 @@ func (s *Store) GetSlowQueryPolicy(ctx context.Context, resourceType api.PolicyR
 	return api.UnmarshalSlowQueryPolicy(policy.Payload)
 }
 
+// GetMigrationTimeoutPolicy will get the migration timeout policy for a database in the environment and project.
+// The project policy takes precedence over the environment policy.
+func (s *Store) GetMigrationTimeoutPolicy(ctx context.Context, environmentID, projectID int) (*storepb.MigrationTimeoutPolicy, error) {
+	pType := api.PolicyTypeMigrationTimeout
+	for _, resource := range []struct {
+		resourceType api.PolicyResourceType
+		resourceID   int
+	}{
+		{api.PolicyResourceTypeProject, projectID},
+		{api.PolicyResourceTypeEnvironment, environmentID},
+	} {
+		policy, err := s.GetPolicyV2(ctx, &FindPolicyMessage{
+			ResourceType: &resource.resourceType,
+			ResourceUID:  &resource.resourceID,
+			Type:         &pType,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get policy")
+		}
+		if policy == nil {
+			continue
+		}
+		p := &storepb.MigrationTimeoutPolicy{}
+		if err := protojson.Unmarshal([]byte(policy.Payload), p); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal migration timeout policy")
+		}
+		return p, nil
+	}
+	return &storepb.MigrationTimeoutPolicy{}, nil
+}
+
+// GetConcurrencyPolicy will get the task run concurrency policy of the resource.
+func (s *Store) GetConcurrencyPolicy(ctx context.Context, resourceType api.PolicyResourceType, resourceID int) (*storepb.ConcurrencyPolicy, error) {
+	pType := api.PolicyTypeConcurrency
+	policy, err := s.GetPolicyV2(ctx, &FindPolicyMessage{
+		ResourceType: &resourceType,
+		ResourceUID:  &resourceID,
+		Type:         &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return &storepb.ConcurrencyPolicy{}, nil
+	}
+	p := &storepb.ConcurrencyPolicy{}
+	if err := protojson.Unmarshal([]byte(policy.Payload), p); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal concurrency policy")
+	}
+	return p, nil
+}
+
 // GetMaskingRulePolicy will get the masking rule policy.
 func (s *Store) GetMaskingRulePolicy(ctx context.Context) (*storepb.MaskingRulePolicy, error) {
 	pType := api.PolicyTypeMaskingRule
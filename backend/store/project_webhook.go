@@ -23,10 +23,13 @@ type ProjectWebhookMessage struct {
 	URL string
 	// ActivityList is the list of activities that the webhook is interested in.
 	ActivityList []string
+	// SigningSecret is the secret to sign the webhook request body.
+	SigningSecret string
 	// Output only fields.
 	//
 	// ID is the unique identifier of the project webhook.
-	ID        int
+	ID int
+	// ProjectID is 0 for the workspace webhooks.
 	ProjectID int
 }
 
@@ -38,19 +41,37 @@ type UpdateProjectWebhookMessage struct {
 	URL *string
 	// ActivityList is the list of activities that the webhook is interested in.
 	ActivityList []string
+	// SigningSecret is the secret to sign the webhook request body.
+	SigningSecret *string
 }
 
 // FindProjectWebhookMessage is the message for finding project webhooks,
 // if all fields are nil, it will list all project webhooks.
 type FindProjectWebhookMessage struct {
-	ID           *int
-	ProjectID    *int
+	ID        *int
+	ProjectID *int
+	// Workspace finds the workspace webhooks, which are not bound to any project.
+	Workspace    bool
 	URL          *string
 	ActivityType *api.ActivityType
 }
 
 // CreateProjectWebhookV2 creates an instance of ProjectWebhook.
 func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, projectUID int, projectResourceID string, create *ProjectWebhookMessage) (*ProjectWebhookMessage, error) {
+	projectWebhook, err := s.createProjectWebhookImpl(ctx, principalUID, &projectUID, create)
+	if err != nil {
+		return nil, err
+	}
+	s.removeProjectCache(projectResourceID)
+	return projectWebhook, nil
+}
+
+// CreateWorkspaceWebhook creates a workspace webhook, which is a project webhook without project.
+func (s *Store) CreateWorkspaceWebhook(ctx context.Context, principalUID int, create *ProjectWebhookMessage) (*ProjectWebhookMessage, error) {
+	return s.createProjectWebhookImpl(ctx, principalUID, nil, create)
+}
+
+func (s *Store) createProjectWebhookImpl(ctx context.Context, principalUID int, projectUID *int, create *ProjectWebhookMessage) (*ProjectWebhookMessage, error) {
 	query := `
 		INSERT INTO project_webhook (
 			creator_id,
@@ -59,12 +80,14 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 			type,
 			name,
 			url,
-			activity_list
+			activity_list,
+			signing_secret
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, project_id, type, name, url, activity_list
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, project_id, type, name, url, activity_list, signing_secret
 	`
 	var projectWebhook ProjectWebhookMessage
+	var projectID sql.NullInt32
 	var txtArray pgtype.TextArray
 
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -80,19 +103,22 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 		create.Title,
 		create.URL,
 		create.ActivityList,
+		create.SigningSecret,
 	).Scan(
 		&projectWebhook.ID,
-		&projectWebhook.ProjectID,
+		&projectID,
 		&projectWebhook.Type,
 		&projectWebhook.Title,
 		&projectWebhook.URL,
 		&txtArray,
+		&projectWebhook.SigningSecret,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
 		}
 		return nil, err
 	}
+	projectWebhook.ProjectID = int(projectID.Int32)
 	if err := txtArray.AssignTo(&projectWebhook.ActivityList); err != nil {
 		return nil, err
 	}
@@ -100,7 +126,6 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 		return nil, errors.Wrapf(err, "failed to commit transaction")
 	}
 
-	s.removeProjectCache(projectResourceID)
 	return &projectWebhook, nil
 }
 
@@ -149,6 +174,7 @@ func (s *Store) GetProjectWebhookV2(ctx context.Context, find *FindProjectWebhoo
 }
 
 // UpdateProjectWebhookV2 updates an instance of ProjectWebhook.
+// The projectResourceID is empty for the workspace webhooks.
 func (s *Store) UpdateProjectWebhookV2(ctx context.Context, principalUID int, projectResourceID string, projectWebhookID int, update *UpdateProjectWebhookMessage) (*ProjectWebhookMessage, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -165,32 +191,38 @@ func (s *Store) UpdateProjectWebhookV2(ctx context.Context, principalUID int, pr
 	if v := update.ActivityList; v != nil {
 		set, args = append(set, fmt.Sprintf("activity_list = $%d", len(args)+1)), append(args, v)
 	}
+	if v := update.SigningSecret; v != nil {
+		set, args = append(set, fmt.Sprintf("signing_secret = $%d", len(args)+1)), append(args, *v)
+	}
 
 	args = append(args, projectWebhookID)
 
 	var projectWebhook ProjectWebhookMessage
+	var projectID sql.NullInt32
 	var txtArray pgtype.TextArray
 	// Execute update query with RETURNING.
 	if err := tx.QueryRowContext(ctx, fmt.Sprintf(`
 	UPDATE project_webhook
 	SET `+strings.Join(set, ", ")+`
 	WHERE id = $%d
-	RETURNING id, project_id, type, name, url, activity_list
+	RETURNING id, project_id, type, name, url, activity_list, signing_secret
 `, len(args)),
 		args...,
 	).Scan(
 		&projectWebhook.ID,
-		&projectWebhook.ProjectID,
+		&projectID,
 		&projectWebhook.Type,
 		&projectWebhook.Title,
 		&projectWebhook.URL,
 		&txtArray,
+		&projectWebhook.SigningSecret,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("project hook ID not found: %d", projectWebhookID)}
 		}
 		return nil, err
 	}
+	projectWebhook.ProjectID = int(projectID.Int32)
 	if err := txtArray.AssignTo(&projectWebhook.ActivityList); err != nil {
 		return nil, err
 	}
@@ -204,6 +236,7 @@ func (s *Store) UpdateProjectWebhookV2(ctx context.Context, principalUID int, pr
 }
 
 // DeleteProjectWebhookV2 deletes an existing projectWebhook by projectUID and url.
+// The projectResourceID is empty for the workspace webhooks.
 func (s *Store) DeleteProjectWebhookV2(ctx context.Context, projectResourceID string, projectWebhookUID int) error {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -231,6 +264,9 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 	if v := find.ProjectID; v != nil {
 		where, args = append(where, fmt.Sprintf("project_id = $%d", len(args)+1)), append(args, *v)
 	}
+	if find.Workspace {
+		where = append(where, "project_id IS NULL")
+	}
 	if v := find.URL; v != nil {
 		where, args = append(where, fmt.Sprintf("url = $%d", len(args)+1)), append(args, *v)
 	}
@@ -241,7 +277,8 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 			type,
 			name,
 			url,
-			activity_list
+			activity_list,
+			signing_secret
 		FROM project_webhook
 		WHERE `+strings.Join(where, " AND "),
 		args...,
@@ -262,6 +299,7 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 			&projectWebhook.Title,
 			&projectWebhook.URL,
 			&txtArray,
+			&projectWebhook.SigningSecret,
 		); err != nil {
 			return nil, err
 		}
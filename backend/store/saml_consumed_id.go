@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// ConsumeSAMLID records that the single-use SAML ID, i.e. the AuthnRequest ID or the login code ID, is consumed.
+// It returns false if the ID was consumed already, so that a replayed SAML response or code is rejected by any replica.
+// The IDs that have expired for an hour are purged since they are rejected by their expiration anyway. The hour
+// tolerates the clock skew between the replicas checking the expiration and the metadata database.
+func (s *Store) ConsumeSAMLID(ctx context.Context, id string, expireTs int64) (bool, error) {
+	if _, err := s.db.db.ExecContext(ctx, `DELETE FROM saml_consumed_id WHERE expire_ts < extract(epoch from now())::BIGINT - 3600`); err != nil {
+		return false, errors.Wrap(err, "failed to purge expired SAML IDs")
+	}
+	result, err := s.db.db.ExecContext(ctx, `
+		INSERT INTO saml_consumed_id (id, expire_ts)
+		VALUES ($1, $2)
+		ON CONFLICT (id) DO NOTHING`, id, expireTs)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to consume SAML ID %q", id)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to consume SAML ID %q", id)
+	}
+	return rows == 1, nil
+}
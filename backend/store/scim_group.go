@@ -0,0 +1,283 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+// SCIMGroupMessage is the message for a group provisioned through SCIM.
+type SCIMGroupMessage struct {
+	DisplayName string
+	ExternalID  string
+	// MemberIDs is the principal IDs of the group members.
+	MemberIDs []int
+
+	// Output only fields.
+	//
+	// UID is the unique identifier of the group.
+	UID       int
+	CreatedTs int64
+	UpdatedTs int64
+}
+
+// FindSCIMGroupMessage is the message for finding SCIM groups.
+type FindSCIMGroupMessage struct {
+	UID         *int
+	DisplayName *string
+	// MemberID finds the groups having the principal as a member.
+	MemberID *int
+
+	Limit  *int
+	Offset *int
+}
+
+// UpdateSCIMGroupMessage is the message for updating a SCIM group.
+type UpdateSCIMGroupMessage struct {
+	UID int
+
+	DisplayName *string
+	ExternalID  *string
+	// MemberIDs replaces all members of the group if not nil.
+	MemberIDs *[]int
+}
+
+// SCIMProjectPolicyMessage is the IAM policy of the project that a SCIM group is mapped to,
+// which is set in the same transaction as the change of the group.
+type SCIMProjectPolicyMessage struct {
+	ProjectUID        int
+	ProjectResourceID string
+	Policy            *IAMPolicyMessage
+}
+
+// CreateSCIMGroup creates a SCIM group and sets the project IAM policies for the members of the group.
+func (s *Store) CreateSCIMGroup(ctx context.Context, create *SCIMGroupMessage, policies []*SCIMProjectPolicyMessage) (*SCIMGroupMessage, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var uid int
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO scim_group (
+			display_name,
+			external_id
+		) VALUES ($1, $2)
+		RETURNING id
+	`, create.DisplayName, create.ExternalID).Scan(&uid); err != nil {
+		return nil, errors.Wrapf(err, "failed to insert SCIM group")
+	}
+	if err := setSCIMGroupMembersImpl(ctx, tx, uid, create.MemberIDs); err != nil {
+		return nil, err
+	}
+	if err := s.setSCIMProjectPoliciesImpl(ctx, tx, policies); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	s.deleteSCIMProjectPolicyCache(policies)
+	return s.GetSCIMGroup(ctx, &FindSCIMGroupMessage{UID: &uid})
+}
+
+// ListSCIMGroups returns a list of SCIM groups based on find.
+func (s *Store) ListSCIMGroups(ctx context.Context, find *FindSCIMGroupMessage) ([]*SCIMGroupMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.UID; v != nil {
+		where, args = append(where, fmt.Sprintf("scim_group.id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.DisplayName; v != nil {
+		where, args = append(where, fmt.Sprintf("scim_group.display_name = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.MemberID; v != nil {
+		where, args = append(where, fmt.Sprintf("EXISTS (SELECT 1 FROM scim_group_member WHERE scim_group_member.group_id = scim_group.id AND scim_group_member.principal_id = $%d)", len(args)+1)), append(args, *v)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			scim_group.id,
+			scim_group.created_ts,
+			scim_group.updated_ts,
+			scim_group.display_name,
+			scim_group.external_id,
+			COALESCE(jsonb_agg(scim_group_member.principal_id ORDER BY scim_group_member.principal_id) FILTER (WHERE scim_group_member.principal_id IS NOT NULL), '[]')
+		FROM scim_group
+		LEFT JOIN scim_group_member ON scim_group.id = scim_group_member.group_id
+		WHERE %s
+		GROUP BY scim_group.id
+		ORDER BY scim_group.id
+	`, strings.Join(where, " AND "))
+	if v := find.Limit; v != nil {
+		query += fmt.Sprintf(" LIMIT %d", *v)
+	}
+	if v := find.Offset; v != nil {
+		query += fmt.Sprintf(" OFFSET %d", *v)
+	}
+
+	rows, err := s.db.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []*SCIMGroupMessage
+	for rows.Next() {
+		var group SCIMGroupMessage
+		var members string
+		if err := rows.Scan(
+			&group.UID,
+			&group.CreatedTs,
+			&group.UpdatedTs,
+			&group.DisplayName,
+			&group.ExternalID,
+			&members,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(members), &group.MemberIDs); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal members of SCIM group %d", group.UID)
+		}
+		groups = append(groups, &group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// CountSCIMGroups returns the number of SCIM groups.
+func (s *Store) CountSCIMGroups(ctx context.Context) (int, error) {
+	var count int
+	if err := s.db.db.QueryRowContext(ctx, `SELECT COUNT(1) FROM scim_group`).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetSCIMGroup gets a SCIM group.
+func (s *Store) GetSCIMGroup(ctx context.Context, find *FindSCIMGroupMessage) (*SCIMGroupMessage, error) {
+	groups, err := s.ListSCIMGroups(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	if len(groups) > 1 {
+		return nil, errors.Errorf("expected find one SCIM group with %+v, but found %d", find, len(groups))
+	}
+	return groups[0], nil
+}
+
+// UpdateSCIMGroup updates a SCIM group and sets the project IAM policies for the members of the group.
+func (s *Store) UpdateSCIMGroup(ctx context.Context, update *UpdateSCIMGroupMessage, policies []*SCIMProjectPolicyMessage) (*SCIMGroupMessage, error) {
+	set, args := []string{"updated_ts = extract(epoch from now())"}, []any{}
+	if v := update.DisplayName; v != nil {
+		set, args = append(set, fmt.Sprintf("display_name = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.ExternalID; v != nil {
+		set, args = append(set, fmt.Sprintf("external_id = $%d", len(args)+1)), append(args, *v)
+	}
+	args = append(args, update.UID)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var uid int
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE scim_group
+		SET `+strings.Join(set, ", ")+`
+		WHERE id = $%d
+		RETURNING id
+	`, len(args)), args...).Scan(&uid); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("SCIM group ID not found: %d", update.UID)}
+		}
+		return nil, err
+	}
+	if update.MemberIDs != nil {
+		if err := setSCIMGroupMembersImpl(ctx, tx, uid, *update.MemberIDs); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.setSCIMProjectPoliciesImpl(ctx, tx, policies); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	s.deleteSCIMProjectPolicyCache(policies)
+	return s.GetSCIMGroup(ctx, &FindSCIMGroupMessage{UID: &uid})
+}
+
+// DeleteSCIMGroup deletes a SCIM group and its memberships, and sets the project IAM policies without the members of the group.
+func (s *Store) DeleteSCIMGroup(ctx context.Context, uid int, policies []*SCIMProjectPolicyMessage) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM scim_group WHERE id = $1`, uid); err != nil {
+		return errors.Wrapf(err, "failed to delete SCIM group %d", uid)
+	}
+	if err := s.setSCIMProjectPoliciesImpl(ctx, tx, policies); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.deleteSCIMProjectPolicyCache(policies)
+	return nil
+}
+
+func (s *Store) setSCIMProjectPoliciesImpl(ctx context.Context, tx *Tx, policies []*SCIMProjectPolicyMessage) error {
+	for _, policy := range policies {
+		if err := s.setProjectIAMPolicyImpl(ctx, tx, policy.Policy, api.SystemBotID, policy.ProjectUID); err != nil {
+			return errors.Wrapf(err, "failed to set IAM policy of project %q", policy.ProjectResourceID)
+		}
+	}
+	return nil
+}
+
+func (s *Store) deleteSCIMProjectPolicyCache(policies []*SCIMProjectPolicyMessage) {
+	for _, policy := range policies {
+		s.projectPolicyCache.Delete(policy.ProjectResourceID)
+		s.projectIDPolicyCache.Delete(policy.ProjectUID)
+	}
+}
+
+func setSCIMGroupMembersImpl(ctx context.Context, tx *Tx, groupUID int, memberIDs []int) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM scim_group_member WHERE group_id = $1`, groupUID); err != nil {
+		return errors.Wrapf(err, "failed to delete members of SCIM group %d", groupUID)
+	}
+	if len(memberIDs) == 0 {
+		return nil
+	}
+	var placeholders []string
+	args := []any{groupUID}
+	for _, memberID := range memberIDs {
+		placeholders = append(placeholders, fmt.Sprintf("($1, $%d)", len(args)+1))
+		args = append(args, memberID)
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO scim_group_member (group_id, principal_id)
+		VALUES %s
+		ON CONFLICT DO NOTHING
+	`, strings.Join(placeholders, ", ")), args...); err != nil {
+		return errors.Wrapf(err, "failed to insert members of SCIM group %d", groupUID)
+	}
+	return nil
+}
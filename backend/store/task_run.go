@@ -36,6 +36,8 @@ type TaskRunMessage struct {
 	UpdatedTs int64
 	ProjectID string
 	StartedTs int64
+	// Execution is the execution status of the running task run, which is nil if the task run is not executing.
+	Execution *TaskRunExecutionMessage
 }
 
 // FindTaskRunMessage is the message for finding task runs.
@@ -114,8 +116,12 @@ func (s *Store) ListTaskRunsV2(ctx context.Context, find *FindTaskRunMessage) ([
 			task_run.result,
 			task.pipeline_id,
 			task.stage_id,
-			project.resource_id
+			project.resource_id,
+			task_run_execution.execution_status,
+			task_run_execution.updated_ts,
+			task_run_execution.execution_detail
 		FROM task_run
+		LEFT JOIN task_run_execution ON task_run_execution.task_run_id = task_run.id
 		LEFT JOIN task ON task.id = task_run.task_id
 		LEFT JOIN pipeline ON pipeline.id = task.pipeline_id
 		LEFT JOIN project ON project.id = pipeline.project_id
@@ -131,6 +137,9 @@ func (s *Store) ListTaskRunsV2(ctx context.Context, find *FindTaskRunMessage) ([
 	var taskRuns []*TaskRunMessage
 	for rows.Next() {
 		var taskRun TaskRunMessage
+		var executionStatus *string
+		var executionUpdatedTs *int64
+		var executionDetail []byte
 		if err := rows.Scan(
 			&taskRun.ID,
 			&taskRun.CreatorID,
@@ -146,9 +155,17 @@ func (s *Store) ListTaskRunsV2(ctx context.Context, find *FindTaskRunMessage) ([
 			&taskRun.PipelineUID,
 			&taskRun.StageUID,
 			&taskRun.ProjectID,
+			&executionStatus,
+			&executionUpdatedTs,
+			&executionDetail,
 		); err != nil {
 			return nil, err
 		}
+		execution, err := convertTaskRunExecution(executionStatus, executionUpdatedTs, executionDetail)
+		if err != nil {
+			return nil, err
+		}
+		taskRun.Execution = execution
 
 		var resultProto storepb.TaskRunResult
 		decoder := protojson.UnmarshalOptions{DiscardUnknown: true}
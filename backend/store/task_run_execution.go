@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// TaskRunExecutionMessage is the execution status of a running task run.
+type TaskRunExecutionMessage struct {
+	ExecutionStatus v1pb.TaskRun_ExecutionStatus
+	// ExecutionDetail is the optional detail of the execution, e.g. the progress of the online schema migration.
+	ExecutionDetail *v1pb.TaskRun_ExecutionDetail
+
+	// Output only.
+	UpdatedTs int64
+}
+
+// UpsertTaskRunExecution records the execution status of the running task run.
+// It's recorded by the leader executing the task run, and read by any replica along with the task run.
+func (s *Store) UpsertTaskRunExecution(ctx context.Context, taskRunUID int, execution *TaskRunExecutionMessage) error {
+	detail := []byte("{}")
+	if execution.ExecutionDetail != nil {
+		b, err := protojson.Marshal(execution.ExecutionDetail)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal task run execution detail")
+		}
+		detail = b
+	}
+	query := `
+		INSERT INTO task_run_execution (task_run_id, execution_status, execution_detail)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (task_run_id) DO UPDATE SET
+			updated_ts = extract(epoch from now()),
+			execution_status = EXCLUDED.execution_status,
+			execution_detail = EXCLUDED.execution_detail`
+	if _, err := s.db.db.ExecContext(ctx, query, taskRunUID, execution.ExecutionStatus.String(), detail); err != nil {
+		return errors.Wrapf(err, "failed to upsert task run execution")
+	}
+	return nil
+}
+
+// DeleteTaskRunExecution deletes the execution status of the task run when it's no longer running.
+func (s *Store) DeleteTaskRunExecution(ctx context.Context, taskRunUID int) error {
+	if _, err := s.db.db.ExecContext(ctx, `DELETE FROM task_run_execution WHERE task_run_id = $1`, taskRunUID); err != nil {
+		return errors.Wrapf(err, "failed to delete task run execution")
+	}
+	return nil
+}
+
+// convertTaskRunExecution converts the nullable columns of the left joined task_run_execution.
+func convertTaskRunExecution(executionStatus *string, updatedTs *int64, executionDetail []byte) (*TaskRunExecutionMessage, error) {
+	if executionStatus == nil || updatedTs == nil {
+		return nil, nil
+	}
+	execution := &TaskRunExecutionMessage{
+		ExecutionStatus: v1pb.TaskRun_ExecutionStatus(v1pb.TaskRun_ExecutionStatus_value[*executionStatus]),
+		UpdatedTs:       *updatedTs,
+	}
+	if len(executionDetail) > 0 && string(executionDetail) != "{}" {
+		execution.ExecutionDetail = &v1pb.TaskRun_ExecutionDetail{}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(executionDetail, execution.ExecutionDetail); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal task run execution detail")
+		}
+	}
+	return execution, nil
+}
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// TaskRunLog is the log of a task run.
+type TaskRunLog struct {
+	TaskRunUID int
+	CreatedTs  int64
+	Payload    *storepb.TaskRunLog
+}
+
+// CreateTaskRunLog creates a task run log.
+func (s *Store) CreateTaskRunLog(ctx context.Context, taskRunUID int, log *storepb.TaskRunLog) error {
+	payload, err := protojson.Marshal(log)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal task run log")
+	}
+	query := `INSERT INTO task_run_log (task_run_id, payload) VALUES ($1, $2)`
+	if _, err := s.db.db.ExecContext(ctx, query, taskRunUID, payload); err != nil {
+		return errors.Wrapf(err, "failed to create task run log")
+	}
+	return nil
+}
+
+// ListTaskRunLogs lists the logs of a task run in the creation order.
+func (s *Store) ListTaskRunLogs(ctx context.Context, taskRunUID int) ([]*TaskRunLog, error) {
+	query := `
+		SELECT
+			created_ts,
+			payload
+		FROM task_run_log
+		WHERE task_run_id = $1
+		ORDER BY id ASC`
+	rows, err := s.db.db.QueryContext(ctx, query, taskRunUID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list task run logs")
+	}
+	defer rows.Close()
+
+	var logs []*TaskRunLog
+	for rows.Next() {
+		l := TaskRunLog{
+			TaskRunUID: taskRunUID,
+			Payload:    &storepb.TaskRunLog{},
+		}
+		var payload []byte
+		if err := rows.Scan(&l.CreatedTs, &payload); err != nil {
+			return nil, err
+		}
+		if err := protojson.Unmarshal(payload, l.Payload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal task run log")
+		}
+		logs = append(logs, &l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
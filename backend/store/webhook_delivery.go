@@ -0,0 +1,260 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+)
+
+// WebhookDeliveryStatus is the status of a webhook delivery.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending is the webhook delivery status for PENDING.
+	// The delivery is waiting for the next attempt.
+	WebhookDeliveryPending WebhookDeliveryStatus = "PENDING"
+	// WebhookDeliveryDelivered is the webhook delivery status for DELIVERED.
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "DELIVERED"
+	// WebhookDeliveryDead is the webhook delivery status for DEAD.
+	// The delivery has used up all attempts and will not be retried unless redelivered.
+	WebhookDeliveryDead WebhookDeliveryStatus = "DEAD"
+)
+
+// WebhookDeliveryMessage is the message for a webhook delivery.
+type WebhookDeliveryMessage struct {
+	WebhookUID int
+	Status     WebhookDeliveryStatus
+	// Payload is the JSON encoded webhook context.
+	Payload string
+	// NextAttemptTs is the earliest time in unix seconds for the next attempt.
+	NextAttemptTs int64
+
+	// Output only fields.
+	//
+	// UID is the unique identifier of the webhook delivery.
+	UID          int
+	CreatedTs    int64
+	UpdatedTs    int64
+	Attempt      int
+	ResponseCode int
+	Error        string
+}
+
+// FindWebhookDeliveryMessage is the message for finding webhook deliveries.
+type FindWebhookDeliveryMessage struct {
+	UID        *int
+	WebhookUID *int
+	Status     *WebhookDeliveryStatus
+
+	Limit  *int
+	Offset *int
+}
+
+// UpdateWebhookDeliveryMessage is the message for updating a webhook delivery.
+type UpdateWebhookDeliveryMessage struct {
+	UID int
+
+	Status        *WebhookDeliveryStatus
+	Attempt       *int
+	NextAttemptTs *int64
+	ResponseCode  *int
+	Error         *string
+}
+
+// CreateWebhookDeliveries creates new webhook deliveries.
+func (s *Store) CreateWebhookDeliveries(ctx context.Context, creates ...*WebhookDeliveryMessage) error {
+	if len(creates) == 0 {
+		return nil
+	}
+
+	var query strings.Builder
+	var values []any
+	if _, err := query.WriteString(`INSERT INTO webhook_delivery (
+		webhook_id,
+		status,
+		payload,
+		next_attempt_ts
+	) VALUES
+	`); err != nil {
+		return err
+	}
+	for i, create := range creates {
+		values = append(values,
+			create.WebhookUID,
+			create.Status,
+			create.Payload,
+			create.NextAttemptTs,
+		)
+		if i != 0 {
+			if _, err := query.WriteString(","); err != nil {
+				return err
+			}
+		}
+		count := 4
+		if _, err := query.WriteString(getPlaceholders(i*count+1, count)); err != nil {
+			return err
+		}
+	}
+	if _, err := s.db.db.ExecContext(ctx, query.String(), values...); err != nil {
+		return errors.Wrapf(err, "failed to execute insert")
+	}
+	return nil
+}
+
+// ListWebhookDeliveries returns a list of webhook deliveries based on find, the most recent first.
+func (s *Store) ListWebhookDeliveries(ctx context.Context, find *FindWebhookDeliveryMessage) ([]*WebhookDeliveryMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.UID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.WebhookUID; v != nil {
+		where, args = append(where, fmt.Sprintf("webhook_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Status; v != nil {
+		where, args = append(where, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	return s.listWebhookDeliveries(ctx, where, args, "id DESC", find.Limit, find.Offset)
+}
+
+// ListDueWebhookDeliveries returns the pending webhook deliveries due no later than nowTs for the delivery runner,
+// the earliest due first so that the retries are not starved by the new deliveries.
+func (s *Store) ListDueWebhookDeliveries(ctx context.Context, nowTs int64, limit int) ([]*WebhookDeliveryMessage, error) {
+	where := []string{"status = $1", "next_attempt_ts <= $2"}
+	args := []any{WebhookDeliveryPending, nowTs}
+	return s.listWebhookDeliveries(ctx, where, args, "next_attempt_ts, id", &limit, nil)
+}
+
+func (s *Store) listWebhookDeliveries(ctx context.Context, where []string, args []any, orderBy string, limit, offset *int) ([]*WebhookDeliveryMessage, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			created_ts,
+			updated_ts,
+			webhook_id,
+			status,
+			payload,
+			attempt,
+			next_attempt_ts,
+			response_code,
+			error
+		FROM webhook_delivery
+		WHERE %s
+		ORDER BY %s
+	`, strings.Join(where, " AND "), orderBy)
+	if v := limit; v != nil {
+		query += fmt.Sprintf(" LIMIT %d", *v)
+	}
+	if v := offset; v != nil {
+		query += fmt.Sprintf(" OFFSET %d", *v)
+	}
+
+	rows, err := s.db.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*WebhookDeliveryMessage
+	for rows.Next() {
+		var delivery WebhookDeliveryMessage
+		if err := rows.Scan(
+			&delivery.UID,
+			&delivery.CreatedTs,
+			&delivery.UpdatedTs,
+			&delivery.WebhookUID,
+			&delivery.Status,
+			&delivery.Payload,
+			&delivery.Attempt,
+			&delivery.NextAttemptTs,
+			&delivery.ResponseCode,
+			&delivery.Error,
+		); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// GetWebhookDelivery gets a webhook delivery.
+func (s *Store) GetWebhookDelivery(ctx context.Context, find *FindWebhookDeliveryMessage) (*WebhookDeliveryMessage, error) {
+	deliveries, err := s.ListWebhookDeliveries(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+	if len(deliveries) > 1 {
+		return nil, errors.Errorf("expected find one webhook delivery with %+v, but found %d", find, len(deliveries))
+	}
+	return deliveries[0], nil
+}
+
+// UpdateWebhookDelivery updates a webhook delivery.
+func (s *Store) UpdateWebhookDelivery(ctx context.Context, update *UpdateWebhookDeliveryMessage) (*WebhookDeliveryMessage, error) {
+	set, args := []string{"updated_ts = extract(epoch from now())"}, []any{}
+	if v := update.Status; v != nil {
+		set, args = append(set, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.Attempt; v != nil {
+		set, args = append(set, fmt.Sprintf("attempt = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.NextAttemptTs; v != nil {
+		set, args = append(set, fmt.Sprintf("next_attempt_ts = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.ResponseCode; v != nil {
+		set, args = append(set, fmt.Sprintf("response_code = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.Error; v != nil {
+		set, args = append(set, fmt.Sprintf("error = $%d", len(args)+1)), append(args, *v)
+	}
+	args = append(args, update.UID)
+
+	var delivery WebhookDeliveryMessage
+	query := fmt.Sprintf(`
+		UPDATE webhook_delivery
+		SET `+strings.Join(set, ", ")+`
+		WHERE id = $%d
+		RETURNING id, created_ts, updated_ts, webhook_id, status, payload, attempt, next_attempt_ts, response_code, error
+	`, len(args))
+	if err := s.db.db.QueryRowContext(ctx, query, args...).Scan(
+		&delivery.UID,
+		&delivery.CreatedTs,
+		&delivery.UpdatedTs,
+		&delivery.WebhookUID,
+		&delivery.Status,
+		&delivery.Payload,
+		&delivery.Attempt,
+		&delivery.NextAttemptTs,
+		&delivery.ResponseCode,
+		&delivery.Error,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("webhook delivery ID not found: %d", update.UID)}
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// DeleteWebhookDeliveriesBefore deletes the finished webhook deliveries created before createdBeforeTs.
+// Pending deliveries are kept so that they are never lost.
+func (s *Store) DeleteWebhookDeliveriesBefore(ctx context.Context, createdBeforeTs int64) error {
+	if _, err := s.db.db.ExecContext(ctx, `
+		DELETE FROM webhook_delivery
+		WHERE created_ts < $1 AND status != $2
+	`, createdBeforeTs, WebhookDeliveryPending); err != nil {
+		return errors.Wrapf(err, "failed to delete webhook deliveries")
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
@@ -392,17 +393,83 @@ func MergeTaskCreateLists(taskCreateLists [][]*store.TaskMessage, taskIndexDAGLi
 	return resTaskCreateList, resTaskIndexDAGList, nil
 }
 
+// UpdateTaskRunExecutionStatus records the execution status of the running task run in the metadata database, so that
+// any replica serving the API reads it. The failure is only logged since the status is informational.
+func UpdateTaskRunExecutionStatus(ctx context.Context, s *store.Store, taskRunUID int, status v1pb.TaskRun_ExecutionStatus, detail *v1pb.TaskRun_ExecutionDetail) {
+	if err := s.UpsertTaskRunExecution(ctx, taskRunUID, &store.TaskRunExecutionMessage{
+		ExecutionStatus: status,
+		ExecutionDetail: detail,
+	}); err != nil {
+		slog.Warn("Failed to update task run execution status", slog.Int("taskRun", taskRunUID), log.BBError(err))
+	}
+}
+
 // ExecuteMigrationDefault executes migration.
 func ExecuteMigrationDefault(ctx context.Context, driverCtx context.Context, store *store.Store, stateCfg *state.State, taskRunUID int, driver db.Driver, mi *db.MigrationInfo, statement string, sheetID *int, opts db.ExecuteOptions) (migrationHistoryID string, updatedSchema string, resErr error) {
-	execFunc := func(ctx context.Context, execStatement string) error {
-		if _, err := driver.Execute(ctx, execStatement, false /* createDatabase */, opts); err != nil {
-			return err
+	execFunc := func(execCtx context.Context, execStatement string) error {
+		for attempt := 1; ; attempt++ {
+			startTime := time.Now()
+			_, err := driver.Execute(execCtx, execStatement, false /* createDatabase */, opts)
+			var lockTimeoutErr *db.LockTimeoutError
+			isLockTimeout := errors.As(err, &lockTimeoutErr)
+			var retryDelay time.Duration
+			if isLockTimeout && attempt <= opts.LockTimeoutRetries {
+				retryDelay = GetLockTimeoutRetryDelay(attempt)
+			}
+			if taskRunUID != 0 {
+				recordExecuteAttempt(ctx, store, taskRunUID, attempt, time.Since(startTime), err, isLockTimeout, retryDelay)
+			}
+			if retryDelay == 0 {
+				return err
+			}
+			slog.Info("Retry the migration on the lock timeout",
+				slog.Int("task_run", taskRunUID),
+				slog.Int("attempt", attempt),
+				slog.Duration("delay", retryDelay),
+			)
+			select {
+			case <-execCtx.Done():
+				return execCtx.Err()
+			case <-time.After(retryDelay):
+			}
 		}
-		return nil
 	}
 	return ExecuteMigrationWithFunc(ctx, driverCtx, store, stateCfg, taskRunUID, driver, mi, statement, sheetID, execFunc)
 }
 
+// GetLockTimeoutRetryDelay returns the exponential backoff delay before retrying the attempt that failed on the lock timeout.
+func GetLockTimeoutRetryDelay(attempt int) time.Duration {
+	const maxDelay = 30 * time.Second
+	if attempt > 5 {
+		return maxDelay
+	}
+	delay := time.Second << (attempt - 1)
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+func recordExecuteAttempt(ctx context.Context, s *store.Store, taskRunUID int, attempt int, duration time.Duration, err error, isLockTimeout bool, retryDelay time.Duration) {
+	executeAttempt := &storepb.TaskRunLog_ExecuteAttempt{
+		Attempt:     int32(attempt),
+		Duration:    durationpb.New(duration),
+		LockTimeout: isLockTimeout,
+	}
+	if err != nil {
+		executeAttempt.Error = err.Error()
+	}
+	if retryDelay > 0 {
+		executeAttempt.RetryDelay = durationpb.New(retryDelay)
+	}
+	if err := s.CreateTaskRunLog(ctx, taskRunUID, &storepb.TaskRunLog{
+		Type:           storepb.TaskRunLog_EXECUTE_ATTEMPT,
+		ExecuteAttempt: executeAttempt,
+	}); err != nil {
+		slog.Error("failed to create task run log", slog.Int("task_run", taskRunUID), log.BBError(err))
+	}
+}
+
 // ExecuteMigrationWithFunc executes the migration with custom migration function.
 func ExecuteMigrationWithFunc(ctx context.Context, driverCtx context.Context, s *store.Store, stateCfg *state.State, taskRunUID int, driver db.Driver, m *db.MigrationInfo, statement string, sheetID *int, execFunc func(ctx context.Context, execStatement string) error) (migrationHistoryID string, updatedSchema string, resErr error) {
 	var prevSchemaBuf bytes.Buffer
@@ -463,11 +530,7 @@ func ExecuteMigrationWithFunc(ctx context.Context, driverCtx context.Context, s
 		}
 
 		if stateCfg != nil {
-			stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
-				state.TaskRunExecutionStatus{
-					ExecutionStatus: v1pb.TaskRun_EXECUTING,
-					UpdateTime:      time.Now(),
-				})
+			UpdateTaskRunExecutionStatus(ctx, s, taskRunUID, v1pb.TaskRun_EXECUTING, nil)
 		}
 
 		if err := execFunc(driverCtx, renderedStatement); err != nil {
@@ -476,11 +539,7 @@ func ExecuteMigrationWithFunc(ctx context.Context, driverCtx context.Context, s
 	}
 
 	if stateCfg != nil {
-		stateCfg.TaskRunExecutionStatuses.Store(taskRunUID,
-			state.TaskRunExecutionStatus{
-				ExecutionStatus: v1pb.TaskRun_POST_EXECUTING,
-				UpdateTime:      time.Now(),
-			})
+		UpdateTaskRunExecutionStatus(ctx, s, taskRunUID, v1pb.TaskRun_POST_EXECUTING, nil)
 	}
 
 	// Phase 4 - Dump the schema after migration
@@ -20,6 +20,64 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type DataSourceOptions_AuthenticationType int32
+
+const (
+	DataSourceOptions_AUTHENTICATION_UNSPECIFIED DataSourceOptions_AuthenticationType = 0
+	DataSourceOptions_PASSWORD                   DataSourceOptions_AuthenticationType = 1
+	// AWS RDS and Aurora IAM database authentication for MySQL and PostgreSQL.
+	DataSourceOptions_AWS_RDS_IAM DataSourceOptions_AuthenticationType = 2
+	// GCP Cloud SQL IAM database authentication for MySQL and PostgreSQL.
+	DataSourceOptions_GOOGLE_CLOUD_SQL_IAM DataSourceOptions_AuthenticationType = 3
+	// Azure Active Directory authentication for SQL Server and PostgreSQL.
+	DataSourceOptions_AZURE_IAM DataSourceOptions_AuthenticationType = 4
+)
+
+// Enum value maps for DataSourceOptions_AuthenticationType.
+var (
+	DataSourceOptions_AuthenticationType_name = map[int32]string{
+		0: "AUTHENTICATION_UNSPECIFIED",
+		1: "PASSWORD",
+		2: "AWS_RDS_IAM",
+		3: "GOOGLE_CLOUD_SQL_IAM",
+		4: "AZURE_IAM",
+	}
+	DataSourceOptions_AuthenticationType_value = map[string]int32{
+		"AUTHENTICATION_UNSPECIFIED": 0,
+		"PASSWORD":                   1,
+		"AWS_RDS_IAM":                2,
+		"GOOGLE_CLOUD_SQL_IAM":       3,
+		"AZURE_IAM":                  4,
+	}
+)
+
+func (x DataSourceOptions_AuthenticationType) Enum() *DataSourceOptions_AuthenticationType {
+	p := new(DataSourceOptions_AuthenticationType)
+	*p = x
+	return p
+}
+
+func (x DataSourceOptions_AuthenticationType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataSourceOptions_AuthenticationType) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_data_source_proto_enumTypes[0].Descriptor()
+}
+
+func (DataSourceOptions_AuthenticationType) Type() protoreflect.EnumType {
+	return &file_store_data_source_proto_enumTypes[0]
+}
+
+func (x DataSourceOptions_AuthenticationType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataSourceOptions_AuthenticationType.Descriptor instead.
+func (DataSourceOptions_AuthenticationType) EnumDescriptor() ([]byte, []int) {
+	return file_store_data_source_proto_rawDescGZIP(), []int{0, 0}
+}
+
 type DataSourceOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -43,6 +101,8 @@ type DataSourceOptions struct {
 	SshObfuscatedPassword string `protobuf:"bytes,8,opt,name=ssh_obfuscated_password,json=sshObfuscatedPassword,proto3" json:"ssh_obfuscated_password,omitempty"`
 	// The private key to login the server. If it's empty string, we will use the system default private key from os.Getenv("SSH_AUTH_SOCK").
 	SshObfuscatedPrivateKey string `protobuf:"bytes,9,opt,name=ssh_obfuscated_private_key,json=sshObfuscatedPrivateKey,proto3" json:"ssh_obfuscated_private_key,omitempty"`
+	// The cloud IAM authentication types mint a short-lived token for each connection instead of using a password.
+	AuthenticationType DataSourceOptions_AuthenticationType `protobuf:"varint,10,opt,name=authentication_type,json=authenticationType,proto3,enum=bytebase.store.DataSourceOptions_AuthenticationType" json:"authentication_type,omitempty"`
 }
 
 func (x *DataSourceOptions) Reset() {
@@ -140,12 +200,19 @@ func (x *DataSourceOptions) GetSshObfuscatedPrivateKey() string {
 	return ""
 }
 
+func (x *DataSourceOptions) GetAuthenticationType() DataSourceOptions_AuthenticationType {
+	if x != nil {
+		return x.AuthenticationType
+	}
+	return DataSourceOptions_AUTHENTICATION_UNSPECIFIED
+}
+
 var File_store_data_source_proto protoreflect.FileDescriptor
 
 var file_store_data_source_proto_rawDesc = []byte{
 	0x0a, 0x17, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x6f, 0x75,
 	0x72, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x62,
-	0x61, 0x73, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x22, 0xd9, 0x02, 0x0a, 0x11, 0x44, 0x61,
+	0x61, 0x73, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x22, 0xbe, 0x04, 0x0a, 0x11, 0x44, 0x61,
 	0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
 	0x10, 0x0a, 0x03, 0x73, 0x72, 0x76, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x73, 0x72,
 	0x76, 0x12, 0x37, 0x0a, 0x17, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74,
@@ -167,9 +234,23 @@ var file_store_data_source_proto_rawDesc = []byte{
 	0x6f, 0x62, 0x66, 0x75, 0x73, 0x63, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x76, 0x61,
 	0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x17, 0x73, 0x73,
 	0x68, 0x4f, 0x62, 0x66, 0x75, 0x73, 0x63, 0x61, 0x74, 0x65, 0x64, 0x50, 0x72, 0x69, 0x76, 0x61,
-	0x74, 0x65, 0x4b, 0x65, 0x79, 0x42, 0x14, 0x5a, 0x12, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x64, 0x2d, 0x67, 0x6f, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x65, 0x0a, 0x13, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x34, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x12, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e,
+	0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x22, 0x7c, 0x0a, 0x12,
+	0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x41, 0x53, 0x53, 0x57, 0x4f, 0x52, 0x44, 0x10, 0x01,
+	0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x57, 0x53, 0x5f, 0x52, 0x44, 0x53, 0x5f, 0x49, 0x41, 0x4d, 0x10,
+	0x02, 0x12, 0x18, 0x0a, 0x14, 0x47, 0x4f, 0x4f, 0x47, 0x4c, 0x45, 0x5f, 0x43, 0x4c, 0x4f, 0x55,
+	0x44, 0x5f, 0x53, 0x51, 0x4c, 0x5f, 0x49, 0x41, 0x4d, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x41,
+	0x5a, 0x55, 0x52, 0x45, 0x5f, 0x49, 0x41, 0x4d, 0x10, 0x04, 0x42, 0x14, 0x5a, 0x12, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2d, 0x67, 0x6f, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -184,16 +265,19 @@ func file_store_data_source_proto_rawDescGZIP() []byte {
 	return file_store_data_source_proto_rawDescData
 }
 
+var file_store_data_source_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_store_data_source_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_store_data_source_proto_goTypes = []interface{}{
-	(*DataSourceOptions)(nil), // 0: bytebase.store.DataSourceOptions
+	(DataSourceOptions_AuthenticationType)(0), // 0: bytebase.store.DataSourceOptions.AuthenticationType
+	(*DataSourceOptions)(nil),                 // 1: bytebase.store.DataSourceOptions
 }
 var file_store_data_source_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: bytebase.store.DataSourceOptions.authentication_type:type_name -> bytebase.store.DataSourceOptions.AuthenticationType
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_store_data_source_proto_init() }
@@ -220,13 +304,14 @@ func file_store_data_source_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_store_data_source_proto_rawDesc,
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_store_data_source_proto_goTypes,
 		DependencyIndexes: file_store_data_source_proto_depIdxs,
+		EnumInfos:         file_store_data_source_proto_enumTypes,
 		MessageInfos:      file_store_data_source_proto_msgTypes,
 	}.Build()
 	File_store_data_source_proto = out.File
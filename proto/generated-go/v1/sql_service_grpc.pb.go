@@ -21,7 +21,9 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	SQLService_Pretty_FullMethodName        = "/bytebase.v1.SQLService/Pretty"
 	SQLService_Query_FullMethodName         = "/bytebase.v1.SQLService/Query"
+	SQLService_FetchMore_FullMethodName     = "/bytebase.v1.SQLService/FetchMore"
 	SQLService_Export_FullMethodName        = "/bytebase.v1.SQLService/Export"
+	SQLService_ExportStream_FullMethodName  = "/bytebase.v1.SQLService/ExportStream"
 	SQLService_AdminExecute_FullMethodName  = "/bytebase.v1.SQLService/AdminExecute"
 	SQLService_DifferPreview_FullMethodName = "/bytebase.v1.SQLService/DifferPreview"
 	SQLService_Check_FullMethodName         = "/bytebase.v1.SQLService/Check"
@@ -33,7 +35,14 @@ const (
 type SQLServiceClient interface {
 	Pretty(ctx context.Context, in *PrettyRequest, opts ...grpc.CallOption) (*PrettyResponse, error)
 	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
+	// FetchMore fetches the next page of the rows of a paginated query.
+	// The cursor is held in the memory of the server replica serving the query, so FetchMore must be served by the same replica.
+	// Thus the paginated query is rejected if the server runs in HA mode with multiple replicas.
+	FetchMore(ctx context.Context, in *FetchMoreRequest, opts ...grpc.CallOption) (*FetchMoreResponse, error)
 	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error)
+	// ExportStream exports the SQL query result in chunks.
+	// The rows are read from the database page by page, so that the result doesn't need to fit in memory.
+	ExportStream(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (SQLService_ExportStreamClient, error)
 	AdminExecute(ctx context.Context, opts ...grpc.CallOption) (SQLService_AdminExecuteClient, error)
 	DifferPreview(ctx context.Context, in *DifferPreviewRequest, opts ...grpc.CallOption) (*DifferPreviewResponse, error)
 	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
@@ -65,6 +74,15 @@ func (c *sQLServiceClient) Query(ctx context.Context, in *QueryRequest, opts ...
 	return out, nil
 }
 
+func (c *sQLServiceClient) FetchMore(ctx context.Context, in *FetchMoreRequest, opts ...grpc.CallOption) (*FetchMoreResponse, error) {
+	out := new(FetchMoreResponse)
+	err := c.cc.Invoke(ctx, SQLService_FetchMore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *sQLServiceClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error) {
 	out := new(ExportResponse)
 	err := c.cc.Invoke(ctx, SQLService_Export_FullMethodName, in, out, opts...)
@@ -74,8 +92,40 @@ func (c *sQLServiceClient) Export(ctx context.Context, in *ExportRequest, opts .
 	return out, nil
 }
 
+func (c *sQLServiceClient) ExportStream(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (SQLService_ExportStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SQLService_ServiceDesc.Streams[0], SQLService_ExportStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sQLServiceExportStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SQLService_ExportStreamClient interface {
+	Recv() (*ExportResponse, error)
+	grpc.ClientStream
+}
+
+type sQLServiceExportStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *sQLServiceExportStreamClient) Recv() (*ExportResponse, error) {
+	m := new(ExportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *sQLServiceClient) AdminExecute(ctx context.Context, opts ...grpc.CallOption) (SQLService_AdminExecuteClient, error) {
-	stream, err := c.cc.NewStream(ctx, &SQLService_ServiceDesc.Streams[0], SQLService_AdminExecute_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &SQLService_ServiceDesc.Streams[1], SQLService_AdminExecute_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +179,14 @@ func (c *sQLServiceClient) Check(ctx context.Context, in *CheckRequest, opts ...
 type SQLServiceServer interface {
 	Pretty(context.Context, *PrettyRequest) (*PrettyResponse, error)
 	Query(context.Context, *QueryRequest) (*QueryResponse, error)
+	// FetchMore fetches the next page of the rows of a paginated query.
+	// The cursor is held in the memory of the server replica serving the query, so FetchMore must be served by the same replica.
+	// Thus the paginated query is rejected if the server runs in HA mode with multiple replicas.
+	FetchMore(context.Context, *FetchMoreRequest) (*FetchMoreResponse, error)
 	Export(context.Context, *ExportRequest) (*ExportResponse, error)
+	// ExportStream exports the SQL query result in chunks.
+	// The rows are read from the database page by page, so that the result doesn't need to fit in memory.
+	ExportStream(*ExportRequest, SQLService_ExportStreamServer) error
 	AdminExecute(SQLService_AdminExecuteServer) error
 	DifferPreview(context.Context, *DifferPreviewRequest) (*DifferPreviewResponse, error)
 	Check(context.Context, *CheckRequest) (*CheckResponse, error)
@@ -146,9 +203,15 @@ func (UnimplementedSQLServiceServer) Pretty(context.Context, *PrettyRequest) (*P
 func (UnimplementedSQLServiceServer) Query(context.Context, *QueryRequest) (*QueryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Query not implemented")
 }
+func (UnimplementedSQLServiceServer) FetchMore(context.Context, *FetchMoreRequest) (*FetchMoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchMore not implemented")
+}
 func (UnimplementedSQLServiceServer) Export(context.Context, *ExportRequest) (*ExportResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Export not implemented")
 }
+func (UnimplementedSQLServiceServer) ExportStream(*ExportRequest, SQLService_ExportStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportStream not implemented")
+}
 func (UnimplementedSQLServiceServer) AdminExecute(SQLService_AdminExecuteServer) error {
 	return status.Errorf(codes.Unimplemented, "method AdminExecute not implemented")
 }
@@ -207,6 +270,24 @@ func _SQLService_Query_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SQLService_FetchMore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchMoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQLServiceServer).FetchMore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SQLService_FetchMore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQLServiceServer).FetchMore(ctx, req.(*FetchMoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SQLService_Export_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExportRequest)
 	if err := dec(in); err != nil {
@@ -225,6 +306,27 @@ func _SQLService_Export_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SQLService_ExportStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SQLServiceServer).ExportStream(m, &sQLServiceExportStreamServer{stream})
+}
+
+type SQLService_ExportStreamServer interface {
+	Send(*ExportResponse) error
+	grpc.ServerStream
+}
+
+type sQLServiceExportStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *sQLServiceExportStreamServer) Send(m *ExportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _SQLService_AdminExecute_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(SQLServiceServer).AdminExecute(&sQLServiceAdminExecuteServer{stream})
 }
@@ -302,6 +404,10 @@ var SQLService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Query",
 			Handler:    _SQLService_Query_Handler,
 		},
+		{
+			MethodName: "FetchMore",
+			Handler:    _SQLService_FetchMore_Handler,
+		},
 		{
 			MethodName: "Export",
 			Handler:    _SQLService_Export_Handler,
@@ -316,6 +422,11 @@ var SQLService_ServiceDesc = grpc.ServiceDesc{
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportStream",
+			Handler:       _SQLService_ExportStream_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "AdminExecute",
 			Handler:       _SQLService_AdminExecute_Handler,